@@ -72,6 +72,14 @@ export function useArticleActions(
         iconWeight: article.is_read_later ? 'fill' : 'regular',
         iconColor: article.is_read_later ? 'text-blue-500' : '',
       },
+      {
+        label: article.is_pinned
+          ? t('article.action.unpinArticle')
+          : t('article.action.pinArticle'),
+        action: 'togglePin',
+        icon: 'ph-push-pin',
+        iconWeight: article.is_pinned ? 'fill' : 'regular',
+      },
       { separator: true },
     ];
 
@@ -254,6 +262,15 @@ export function useArticleActions(
         article.is_read_later = !newState;
         window.showToast(t('common.errors.savingSettings'), 'error');
       }
+    } else if (action === 'togglePin') {
+      try {
+        await fetch(`/api/articles/toggle-pin?id=${article.id}`, { method: 'POST' });
+        // Pinning moves the article, so reload the list in its new order
+        window.dispatchEvent(new CustomEvent('refresh-articles'));
+      } catch (e) {
+        console.error('Error toggling pin:', e);
+        window.showToast(t('common.errors.savingSettings'), 'error');
+      }
     } else if (action === 'toggleHide') {
       try {
         await fetch(`/api/articles/toggle-hide?id=${article.id}`, { method: 'POST' });
@@ -115,6 +115,9 @@ export function useArticleDetail() {
   const showContent = ref(false);
   const articleContent = ref('');
   const isLoadingContent = ref(false);
+  // Language and text direction of the content, for the lang/dir attributes of the reading pane
+  const contentLanguage = ref('');
+  const contentDirection = ref('auto');
   const currentArticleId = ref<number | null>(null);
   const defaultViewMode = ref<ViewMode>('original');
   const pendingRenderAction = ref<RenderAction>(null);
@@ -240,6 +243,10 @@ export function useArticleDetail() {
     if (article.value) openInBrowser(article.value.url);
   }
 
+  function openDiscussion() {
+    if (article.value?.discussion_url) openInBrowser(article.value.discussion_url);
+  }
+
   async function toggleContentView() {
     if (!showContent.value) {
       // Switching to content view - fetch content if needed
@@ -287,6 +294,17 @@ export function useArticleDetail() {
         }
 
         articleContent.value = content;
+        contentLanguage.value = data.content_language || '';
+        contentDirection.value = data.content_direction || 'auto';
+
+        // Let the reader know they may be about to re-read coverage of the same story
+        if (data.similar_read) {
+          window.showToast(
+            t('article.content.similarAlreadyRead', { title: data.similar_read.title }),
+            'info',
+            6000
+          );
+        }
 
         // Only show loading animation for non-cached content
         if (!data.cached) {
@@ -805,6 +823,8 @@ export function useArticleDetail() {
     showContent,
     articleContent,
     isLoadingContent,
+    contentLanguage,
+    contentDirection,
     imageViewerSrc,
     imageViewerAlt,
     imageViewerImages,
@@ -821,6 +841,7 @@ export function useArticleDetail() {
     toggleFavorite,
     toggleReadLater,
     openOriginal,
+    openDiscussion,
     toggleContentView,
     closeImageViewer,
     copyImage,
@@ -1,6 +1,7 @@
-import { ref, type Ref } from 'vue';
+import { computed, ref, type Ref } from 'vue';
 import { useI18n } from 'vue-i18n';
-import type { Article } from '@/types/models';
+import { useAppStore } from '@/stores/app';
+import type { Article, Feed } from '@/types/models';
 
 interface TranslationSettings {
   enabled: boolean;
@@ -8,8 +9,25 @@ interface TranslationSettings {
   translationOnlyMode: boolean;
 }
 
+// Whether articles of a feed are translated: feeds set to 'always' are translated even when
+// translation is off globally, feeds set to 'never' never are, others follow the global setting
+export function isTranslationEnabledForFeed(
+  feed: Feed | undefined,
+  globalEnabled: boolean
+): boolean {
+  switch (feed?.translation_mode) {
+    case 'always':
+      return true;
+    case 'never':
+      return false;
+    default:
+      return globalEnabled;
+  }
+}
+
 export function useArticleTranslation() {
   const { t } = useI18n();
+  const store = useAppStore();
   const translationSettings = ref<TranslationSettings>({
     enabled: false,
     targetLang: 'en',
@@ -18,6 +36,21 @@ export function useArticleTranslation() {
   const translatingArticles: Ref<Set<number>> = ref(new Set());
   let observer: IntersectionObserver | null = null;
 
+  // True when any article may need translating: globally, or for a feed set to 'always'
+  const translationActive = computed(
+    () =>
+      translationSettings.value.enabled ||
+      store.feeds.some((feed) => feed.translation_mode === 'always')
+  );
+
+  // Whether an article's feed is translated, see isTranslationEnabledForFeed
+  function isTranslationEnabledFor(article: Article): boolean {
+    return isTranslationEnabledForFeed(
+      store.feedMap.get(article.feed_id),
+      translationSettings.value.enabled
+    );
+  }
+
   // Load translation settings
   async function loadTranslationSettings(): Promise<void> {
     try {
@@ -41,8 +74,8 @@ export function useArticleTranslation() {
 
     observer = new IntersectionObserver(
       (entries) => {
-        // Check if translation is still enabled before processing
-        if (!translationSettings.value.enabled) {
+        // Check if translation is still active before processing
+        if (!translationActive.value) {
           return;
         }
 
@@ -55,7 +88,9 @@ export function useArticleTranslation() {
             // - No translation exists, OR
             // - Translation equals original title (indicates failed/skipped translation)
             const needsTranslation =
-              article && (!article.translated_title || article.translated_title === article.title);
+              article &&
+              isTranslationEnabledFor(article) &&
+              (!article.translated_title || article.translated_title === article.title);
 
             // Only translate if article exists, needs translation, and is not already being translated
             if (needsTranslation && !translatingArticles.value.has(articleId)) {
@@ -72,7 +107,7 @@ export function useArticleTranslation() {
     );
 
     // Automatically observe all current article elements
-    if (listRef && translationSettings.value.enabled) {
+    if (listRef && translationActive.value) {
       // Use setTimeout to ensure DOM is updated
       setTimeout(() => {
         const cards = listRef.querySelectorAll('[data-article-id]');
@@ -83,8 +118,8 @@ export function useArticleTranslation() {
 
   // Translate an article
   async function translateArticle(article: Article): Promise<void> {
-    // Don't translate if translation is disabled
-    if (!translationSettings.value.enabled) return;
+    // Don't translate if translation is disabled for the article's feed
+    if (!isTranslationEnabledFor(article)) return;
     if (translatingArticles.value.has(article.id)) return;
 
     translatingArticles.value.add(article.id);
@@ -125,7 +160,7 @@ export function useArticleTranslation() {
 
   // Observe an article element
   function observeArticle(el: Element | null): void {
-    if (el && observer && translationSettings.value.enabled) {
+    if (el && observer && translationActive.value) {
       observer.observe(el);
     }
   }
@@ -138,13 +173,13 @@ export function useArticleTranslation() {
       translationOnlyMode: translationSettings.value.translationOnlyMode,
     };
 
-    // Disconnect observer if translation is disabled
-    if (!enabled && observer) {
+    // Disconnect observer if no feed is translated any more
+    if (!translationActive.value && observer) {
       observer.disconnect();
       observer = null;
     }
-    // Re-observe if translation is enabled
-    else if (enabled && observer) {
+    // Re-observe if translation is active
+    else if (translationActive.value && observer) {
       setTimeout(() => {
         const cards = document.querySelectorAll('[data-article-id]');
         cards.forEach((card) => observer?.observe(card));
@@ -162,6 +197,8 @@ export function useArticleTranslation() {
 
   return {
     translationSettings,
+    translationActive,
+    isTranslationEnabledFor,
     translatingArticles,
     loadTranslationSettings,
     setupIntersectionObserver,
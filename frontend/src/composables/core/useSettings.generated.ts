@@ -13,21 +13,36 @@ import { settingsDefaults } from '@/config/defaults';
  */
 export function generateInitialSettings(): SettingsData {
   return {
+    adaptive_concurrency: settingsDefaults.adaptive_concurrency,
     ai_api_key: settingsDefaults.ai_api_key,
     ai_chat_enabled: settingsDefaults.ai_chat_enabled,
+    ai_chat_max_tokens: settingsDefaults.ai_chat_max_tokens,
     ai_custom_headers: settingsDefaults.ai_custom_headers,
     ai_endpoint: settingsDefaults.ai_endpoint,
+    ai_endpoints: settingsDefaults.ai_endpoints,
+    ai_feed_summary_prompt: settingsDefaults.ai_feed_summary_prompt,
     ai_model: settingsDefaults.ai_model,
+    ai_preamble_patterns: settingsDefaults.ai_preamble_patterns,
+    ai_summary_max_tokens: settingsDefaults.ai_summary_max_tokens,
     ai_summary_prompt: settingsDefaults.ai_summary_prompt,
+    ai_translation_chunk_chars: settingsDefaults.ai_translation_chunk_chars,
+    ai_translation_max_tokens: settingsDefaults.ai_translation_max_tokens,
     ai_translation_prompt: settingsDefaults.ai_translation_prompt,
     ai_usage_limit: settingsDefaults.ai_usage_limit,
     ai_usage_tokens: settingsDefaults.ai_usage_tokens,
+    ai_worker_concurrency: settingsDefaults.ai_worker_concurrency,
+    ai_worker_timeout: settingsDefaults.ai_worker_timeout,
     auto_cleanup_enabled: settingsDefaults.auto_cleanup_enabled,
+    auto_read_dwell_ms: settingsDefaults.auto_read_dwell_ms,
     auto_show_all_content: settingsDefaults.auto_show_all_content,
+    azure_translator_key: settingsDefaults.azure_translator_key,
+    azure_translator_region: settingsDefaults.azure_translator_region,
     baidu_app_id: settingsDefaults.baidu_app_id,
     baidu_secret_key: settingsDefaults.baidu_secret_key,
     close_to_tray: settingsDefaults.close_to_tray,
+    collapse_duplicate_titles: settingsDefaults.collapse_duplicate_titles,
     compact_mode: settingsDefaults.compact_mode,
+    compress_article_content: settingsDefaults.compress_article_content,
     content_font_family: settingsDefaults.content_font_family,
     content_font_size: settingsDefaults.content_font_size,
     content_line_height: settingsDefaults.content_line_height,
@@ -44,35 +59,58 @@ export function generateInitialSettings(): SettingsData {
     deepl_api_key: settingsDefaults.deepl_api_key,
     deepl_endpoint: settingsDefaults.deepl_endpoint,
     default_view_mode: settingsDefaults.default_view_mode,
+    discovery_feed_timeout: settingsDefaults.discovery_feed_timeout,
+    discovery_result_ttl: settingsDefaults.discovery_result_ttl,
+    discovery_target_count: settingsDefaults.discovery_target_count,
+    discovery_validation_retries: settingsDefaults.discovery_validation_retries,
+    discovery_validation_timeout: settingsDefaults.discovery_validation_timeout,
     feed_drawer_expanded: settingsDefaults.feed_drawer_expanded,
     feed_drawer_pinned: settingsDefaults.feed_drawer_pinned,
     freshrss_api_password: settingsDefaults.freshrss_api_password,
     freshrss_auto_sync_interval: settingsDefaults.freshrss_auto_sync_interval,
     freshrss_enabled: settingsDefaults.freshrss_enabled,
+    freshrss_insecure_tls: settingsDefaults.freshrss_insecure_tls,
     freshrss_last_sync_time: settingsDefaults.freshrss_last_sync_time,
+    freshrss_push_delay_seconds: settingsDefaults.freshrss_push_delay_seconds,
+    freshrss_save_batch_size: settingsDefaults.freshrss_save_batch_size,
+    freshrss_server_type: settingsDefaults.freshrss_server_type,
     freshrss_server_url: settingsDefaults.freshrss_server_url,
     freshrss_sync_on_startup: settingsDefaults.freshrss_sync_on_startup,
+    freshrss_timeout_seconds: settingsDefaults.freshrss_timeout_seconds,
     freshrss_username: settingsDefaults.freshrss_username,
     full_text_fetch_enabled: settingsDefaults.full_text_fetch_enabled,
     google_translate_endpoint: settingsDefaults.google_translate_endpoint,
+    google_translate_fallback_provider: settingsDefaults.google_translate_fallback_provider,
     hover_mark_as_read: settingsDefaults.hover_mark_as_read,
+    http_idle_conn_timeout: settingsDefaults.http_idle_conn_timeout,
+    http_max_idle_conns: settingsDefaults.http_max_idle_conns,
+    http_max_idle_conns_per_host: settingsDefaults.http_max_idle_conns_per_host,
     image_gallery_enabled: settingsDefaults.image_gallery_enabled,
+    keep_favorite_content: settingsDefaults.keep_favorite_content,
     language: settingsDefaults.language,
     last_global_refresh: settingsDefaults.last_global_refresh,
     last_network_test: settingsDefaults.last_network_test,
+    libre_api_key: settingsDefaults.libre_api_key,
+    libre_endpoint: settingsDefaults.libre_endpoint,
     max_article_age_days: settingsDefaults.max_article_age_days,
+    max_article_images: settingsDefaults.max_article_images,
     max_cache_size_mb: settingsDefaults.max_cache_size_mb,
     max_concurrent_refreshes: settingsDefaults.max_concurrent_refreshes,
+    max_fetches_per_hour: settingsDefaults.max_fetches_per_hour,
     media_cache_enabled: settingsDefaults.media_cache_enabled,
     media_cache_max_age_days: settingsDefaults.media_cache_max_age_days,
     media_cache_max_size_mb: settingsDefaults.media_cache_max_size_mb,
     media_proxy_fallback: settingsDefaults.media_proxy_fallback,
+    metadata_only_fetch: settingsDefaults.metadata_only_fetch,
+    min_manual_refresh_interval: settingsDefaults.min_manual_refresh_interval,
     network_bandwidth_mbps: settingsDefaults.network_bandwidth_mbps,
     network_latency_ms: settingsDefaults.network_latency_ms,
     network_speed: settingsDefaults.network_speed,
     obsidian_enabled: settingsDefaults.obsidian_enabled,
     obsidian_vault: settingsDefaults.obsidian_vault,
     obsidian_vault_path: settingsDefaults.obsidian_vault_path,
+    open_external_links_new_tab: settingsDefaults.open_external_links_new_tab,
+    open_original_marks_read: settingsDefaults.open_original_marks_read,
     proxy_enabled: settingsDefaults.proxy_enabled,
     proxy_host: settingsDefaults.proxy_host,
     proxy_password: settingsDefaults.proxy_password,
@@ -88,14 +126,18 @@ export function generateInitialSettings(): SettingsData {
     shortcuts: settingsDefaults.shortcuts,
     shortcuts_enabled: settingsDefaults.shortcuts_enabled,
     show_article_preview_images: settingsDefaults.show_article_preview_images,
+    show_feed_badge: settingsDefaults.show_feed_badge,
     show_hidden_articles: settingsDefaults.show_hidden_articles,
     startup_on_boot: settingsDefaults.startup_on_boot,
     summary_enabled: settingsDefaults.summary_enabled,
     summary_length: settingsDefaults.summary_length,
+    summary_min_length: settingsDefaults.summary_min_length,
     summary_provider: settingsDefaults.summary_provider,
     summary_trigger_mode: settingsDefaults.summary_trigger_mode,
+    sync_conflict_strategy: settingsDefaults.sync_conflict_strategy,
     target_language: settingsDefaults.target_language,
     theme: settingsDefaults.theme,
+    translation_concurrency: settingsDefaults.translation_concurrency,
     translation_enabled: settingsDefaults.translation_enabled,
     translation_only_mode: settingsDefaults.translation_only_mode,
     translation_provider: settingsDefaults.translation_provider,
@@ -114,21 +156,41 @@ export function generateInitialSettings(): SettingsData {
  */
 export function parseSettingsData(data: Record<string, string>): SettingsData {
   return {
+    adaptive_concurrency: data.adaptive_concurrency === 'true',
     ai_api_key: data.ai_api_key || settingsDefaults.ai_api_key,
     ai_chat_enabled: data.ai_chat_enabled === 'true',
+    ai_chat_max_tokens: parseInt(data.ai_chat_max_tokens) || settingsDefaults.ai_chat_max_tokens,
     ai_custom_headers: data.ai_custom_headers || settingsDefaults.ai_custom_headers,
     ai_endpoint: data.ai_endpoint || settingsDefaults.ai_endpoint,
+    ai_endpoints: data.ai_endpoints || settingsDefaults.ai_endpoints,
+    ai_feed_summary_prompt: data.ai_feed_summary_prompt || settingsDefaults.ai_feed_summary_prompt,
     ai_model: data.ai_model || settingsDefaults.ai_model,
+    ai_preamble_patterns: data.ai_preamble_patterns || settingsDefaults.ai_preamble_patterns,
+    ai_summary_max_tokens:
+      parseInt(data.ai_summary_max_tokens) || settingsDefaults.ai_summary_max_tokens,
     ai_summary_prompt: data.ai_summary_prompt || settingsDefaults.ai_summary_prompt,
+    ai_translation_chunk_chars:
+      parseInt(data.ai_translation_chunk_chars) || settingsDefaults.ai_translation_chunk_chars,
+    ai_translation_max_tokens:
+      parseInt(data.ai_translation_max_tokens) || settingsDefaults.ai_translation_max_tokens,
     ai_translation_prompt: data.ai_translation_prompt || settingsDefaults.ai_translation_prompt,
     ai_usage_limit: data.ai_usage_limit || settingsDefaults.ai_usage_limit,
     ai_usage_tokens: data.ai_usage_tokens || settingsDefaults.ai_usage_tokens,
+    ai_worker_concurrency:
+      parseInt(data.ai_worker_concurrency) || settingsDefaults.ai_worker_concurrency,
+    ai_worker_timeout: parseInt(data.ai_worker_timeout) || settingsDefaults.ai_worker_timeout,
     auto_cleanup_enabled: data.auto_cleanup_enabled === 'true',
+    auto_read_dwell_ms: parseInt(data.auto_read_dwell_ms) || settingsDefaults.auto_read_dwell_ms,
     auto_show_all_content: data.auto_show_all_content === 'true',
+    azure_translator_key: data.azure_translator_key || settingsDefaults.azure_translator_key,
+    azure_translator_region:
+      data.azure_translator_region || settingsDefaults.azure_translator_region,
     baidu_app_id: data.baidu_app_id || settingsDefaults.baidu_app_id,
     baidu_secret_key: data.baidu_secret_key || settingsDefaults.baidu_secret_key,
     close_to_tray: data.close_to_tray === 'true',
+    collapse_duplicate_titles: data.collapse_duplicate_titles === 'true',
     compact_mode: data.compact_mode === 'true',
+    compress_article_content: data.compress_article_content === 'true',
     content_font_family: data.content_font_family || settingsDefaults.content_font_family,
     content_font_size: parseInt(data.content_font_size) || settingsDefaults.content_font_size,
     content_line_height: data.content_line_height || settingsDefaults.content_line_height,
@@ -153,42 +215,79 @@ export function parseSettingsData(data: Record<string, string>): SettingsData {
     deepl_api_key: data.deepl_api_key || settingsDefaults.deepl_api_key,
     deepl_endpoint: data.deepl_endpoint || settingsDefaults.deepl_endpoint,
     default_view_mode: data.default_view_mode || settingsDefaults.default_view_mode,
+    discovery_feed_timeout:
+      parseInt(data.discovery_feed_timeout) || settingsDefaults.discovery_feed_timeout,
+    discovery_result_ttl:
+      parseInt(data.discovery_result_ttl) || settingsDefaults.discovery_result_ttl,
+    discovery_target_count:
+      parseInt(data.discovery_target_count) || settingsDefaults.discovery_target_count,
+    discovery_validation_retries:
+      parseInt(data.discovery_validation_retries) || settingsDefaults.discovery_validation_retries,
+    discovery_validation_timeout:
+      parseInt(data.discovery_validation_timeout) || settingsDefaults.discovery_validation_timeout,
     feed_drawer_expanded: data.feed_drawer_expanded === 'true',
     feed_drawer_pinned: data.feed_drawer_pinned === 'true',
     freshrss_api_password: data.freshrss_api_password || settingsDefaults.freshrss_api_password,
     freshrss_auto_sync_interval:
       parseInt(data.freshrss_auto_sync_interval) || settingsDefaults.freshrss_auto_sync_interval,
     freshrss_enabled: data.freshrss_enabled === 'true',
+    freshrss_insecure_tls: data.freshrss_insecure_tls === 'true',
     freshrss_last_sync_time:
       data.freshrss_last_sync_time || settingsDefaults.freshrss_last_sync_time,
+    freshrss_push_delay_seconds:
+      parseInt(data.freshrss_push_delay_seconds) || settingsDefaults.freshrss_push_delay_seconds,
+    freshrss_save_batch_size:
+      parseInt(data.freshrss_save_batch_size) || settingsDefaults.freshrss_save_batch_size,
+    freshrss_server_type: data.freshrss_server_type || settingsDefaults.freshrss_server_type,
     freshrss_server_url: data.freshrss_server_url || settingsDefaults.freshrss_server_url,
     freshrss_sync_on_startup: data.freshrss_sync_on_startup === 'true',
+    freshrss_timeout_seconds:
+      parseInt(data.freshrss_timeout_seconds) || settingsDefaults.freshrss_timeout_seconds,
     freshrss_username: data.freshrss_username || settingsDefaults.freshrss_username,
     full_text_fetch_enabled: data.full_text_fetch_enabled === 'true',
     google_translate_endpoint:
       data.google_translate_endpoint || settingsDefaults.google_translate_endpoint,
+    google_translate_fallback_provider:
+      data.google_translate_fallback_provider ||
+      settingsDefaults.google_translate_fallback_provider,
     hover_mark_as_read: data.hover_mark_as_read === 'true',
+    http_idle_conn_timeout:
+      parseInt(data.http_idle_conn_timeout) || settingsDefaults.http_idle_conn_timeout,
+    http_max_idle_conns: parseInt(data.http_max_idle_conns) || settingsDefaults.http_max_idle_conns,
+    http_max_idle_conns_per_host:
+      parseInt(data.http_max_idle_conns_per_host) || settingsDefaults.http_max_idle_conns_per_host,
     image_gallery_enabled: data.image_gallery_enabled === 'true',
+    keep_favorite_content: data.keep_favorite_content === 'true',
     language: data.language || settingsDefaults.language,
     last_global_refresh: data.last_global_refresh || settingsDefaults.last_global_refresh,
     last_network_test: data.last_network_test || settingsDefaults.last_network_test,
+    libre_api_key: data.libre_api_key || settingsDefaults.libre_api_key,
+    libre_endpoint: data.libre_endpoint || settingsDefaults.libre_endpoint,
     max_article_age_days:
       parseInt(data.max_article_age_days) || settingsDefaults.max_article_age_days,
+    max_article_images: parseInt(data.max_article_images) || settingsDefaults.max_article_images,
     max_cache_size_mb: parseInt(data.max_cache_size_mb) || settingsDefaults.max_cache_size_mb,
     max_concurrent_refreshes:
       data.max_concurrent_refreshes || settingsDefaults.max_concurrent_refreshes,
+    max_fetches_per_hour:
+      parseInt(data.max_fetches_per_hour) || settingsDefaults.max_fetches_per_hour,
     media_cache_enabled: data.media_cache_enabled === 'true',
     media_cache_max_age_days:
       parseInt(data.media_cache_max_age_days) || settingsDefaults.media_cache_max_age_days,
     media_cache_max_size_mb:
       parseInt(data.media_cache_max_size_mb) || settingsDefaults.media_cache_max_size_mb,
     media_proxy_fallback: data.media_proxy_fallback === 'true',
+    metadata_only_fetch: data.metadata_only_fetch === 'true',
+    min_manual_refresh_interval:
+      parseInt(data.min_manual_refresh_interval) || settingsDefaults.min_manual_refresh_interval,
     network_bandwidth_mbps: data.network_bandwidth_mbps || settingsDefaults.network_bandwidth_mbps,
     network_latency_ms: data.network_latency_ms || settingsDefaults.network_latency_ms,
     network_speed: data.network_speed || settingsDefaults.network_speed,
     obsidian_enabled: data.obsidian_enabled === 'true',
     obsidian_vault: data.obsidian_vault || settingsDefaults.obsidian_vault,
     obsidian_vault_path: data.obsidian_vault_path || settingsDefaults.obsidian_vault_path,
+    open_external_links_new_tab: data.open_external_links_new_tab === 'true',
+    open_original_marks_read: data.open_original_marks_read === 'true',
     proxy_enabled: data.proxy_enabled === 'true',
     proxy_host: data.proxy_host || settingsDefaults.proxy_host,
     proxy_password: data.proxy_password || settingsDefaults.proxy_password,
@@ -205,14 +304,19 @@ export function parseSettingsData(data: Record<string, string>): SettingsData {
     shortcuts: data.shortcuts || settingsDefaults.shortcuts,
     shortcuts_enabled: data.shortcuts_enabled === 'true',
     show_article_preview_images: data.show_article_preview_images === 'true',
+    show_feed_badge: data.show_feed_badge === 'true',
     show_hidden_articles: data.show_hidden_articles === 'true',
     startup_on_boot: data.startup_on_boot === 'true',
     summary_enabled: data.summary_enabled === 'true',
     summary_length: data.summary_length || settingsDefaults.summary_length,
+    summary_min_length: parseInt(data.summary_min_length) || settingsDefaults.summary_min_length,
     summary_provider: data.summary_provider || settingsDefaults.summary_provider,
     summary_trigger_mode: data.summary_trigger_mode || settingsDefaults.summary_trigger_mode,
+    sync_conflict_strategy: data.sync_conflict_strategy || settingsDefaults.sync_conflict_strategy,
     target_language: data.target_language || settingsDefaults.target_language,
     theme: data.theme || settingsDefaults.theme,
+    translation_concurrency:
+      parseInt(data.translation_concurrency) || settingsDefaults.translation_concurrency,
     translation_enabled: data.translation_enabled === 'true',
     translation_only_mode: data.translation_only_mode === 'true',
     translation_provider: data.translation_provider || settingsDefaults.translation_provider,
@@ -231,28 +335,67 @@ export function parseSettingsData(data: Record<string, string>): SettingsData {
  */
 export function buildAutoSavePayload(settingsRef: Ref<SettingsData>): Record<string, string> {
   return {
+    adaptive_concurrency: (
+      settingsRef.value.adaptive_concurrency ?? settingsDefaults.adaptive_concurrency
+    ).toString(),
     ai_api_key: settingsRef.value.ai_api_key ?? settingsDefaults.ai_api_key,
     ai_chat_enabled: (
       settingsRef.value.ai_chat_enabled ?? settingsDefaults.ai_chat_enabled
     ).toString(),
+    ai_chat_max_tokens: (
+      settingsRef.value.ai_chat_max_tokens ?? settingsDefaults.ai_chat_max_tokens
+    ).toString(),
     ai_custom_headers: settingsRef.value.ai_custom_headers ?? settingsDefaults.ai_custom_headers,
     ai_endpoint: settingsRef.value.ai_endpoint ?? settingsDefaults.ai_endpoint,
+    ai_endpoints: settingsRef.value.ai_endpoints ?? settingsDefaults.ai_endpoints,
+    ai_feed_summary_prompt:
+      settingsRef.value.ai_feed_summary_prompt ?? settingsDefaults.ai_feed_summary_prompt,
     ai_model: settingsRef.value.ai_model ?? settingsDefaults.ai_model,
+    ai_preamble_patterns:
+      settingsRef.value.ai_preamble_patterns ?? settingsDefaults.ai_preamble_patterns,
+    ai_summary_max_tokens: (
+      settingsRef.value.ai_summary_max_tokens ?? settingsDefaults.ai_summary_max_tokens
+    ).toString(),
     ai_summary_prompt: settingsRef.value.ai_summary_prompt ?? settingsDefaults.ai_summary_prompt,
+    ai_translation_chunk_chars: (
+      settingsRef.value.ai_translation_chunk_chars ?? settingsDefaults.ai_translation_chunk_chars
+    ).toString(),
+    ai_translation_max_tokens: (
+      settingsRef.value.ai_translation_max_tokens ?? settingsDefaults.ai_translation_max_tokens
+    ).toString(),
     ai_translation_prompt:
       settingsRef.value.ai_translation_prompt ?? settingsDefaults.ai_translation_prompt,
     ai_usage_limit: settingsRef.value.ai_usage_limit ?? settingsDefaults.ai_usage_limit,
     ai_usage_tokens: settingsRef.value.ai_usage_tokens ?? settingsDefaults.ai_usage_tokens,
+    ai_worker_concurrency: (
+      settingsRef.value.ai_worker_concurrency ?? settingsDefaults.ai_worker_concurrency
+    ).toString(),
+    ai_worker_timeout: (
+      settingsRef.value.ai_worker_timeout ?? settingsDefaults.ai_worker_timeout
+    ).toString(),
     auto_cleanup_enabled: (
       settingsRef.value.auto_cleanup_enabled ?? settingsDefaults.auto_cleanup_enabled
     ).toString(),
+    auto_read_dwell_ms: (
+      settingsRef.value.auto_read_dwell_ms ?? settingsDefaults.auto_read_dwell_ms
+    ).toString(),
     auto_show_all_content: (
       settingsRef.value.auto_show_all_content ?? settingsDefaults.auto_show_all_content
     ).toString(),
+    azure_translator_key:
+      settingsRef.value.azure_translator_key ?? settingsDefaults.azure_translator_key,
+    azure_translator_region:
+      settingsRef.value.azure_translator_region ?? settingsDefaults.azure_translator_region,
     baidu_app_id: settingsRef.value.baidu_app_id ?? settingsDefaults.baidu_app_id,
     baidu_secret_key: settingsRef.value.baidu_secret_key ?? settingsDefaults.baidu_secret_key,
     close_to_tray: (settingsRef.value.close_to_tray ?? settingsDefaults.close_to_tray).toString(),
+    collapse_duplicate_titles: (
+      settingsRef.value.collapse_duplicate_titles ?? settingsDefaults.collapse_duplicate_titles
+    ).toString(),
     compact_mode: (settingsRef.value.compact_mode ?? settingsDefaults.compact_mode).toString(),
+    compress_article_content: (
+      settingsRef.value.compress_article_content ?? settingsDefaults.compress_article_content
+    ).toString(),
     content_font_family:
       settingsRef.value.content_font_family ?? settingsDefaults.content_font_family,
     content_font_size: (
@@ -287,6 +430,23 @@ export function buildAutoSavePayload(settingsRef: Ref<SettingsData>): Record<str
     deepl_api_key: settingsRef.value.deepl_api_key ?? settingsDefaults.deepl_api_key,
     deepl_endpoint: settingsRef.value.deepl_endpoint ?? settingsDefaults.deepl_endpoint,
     default_view_mode: settingsRef.value.default_view_mode ?? settingsDefaults.default_view_mode,
+    discovery_feed_timeout: (
+      settingsRef.value.discovery_feed_timeout ?? settingsDefaults.discovery_feed_timeout
+    ).toString(),
+    discovery_result_ttl: (
+      settingsRef.value.discovery_result_ttl ?? settingsDefaults.discovery_result_ttl
+    ).toString(),
+    discovery_target_count: (
+      settingsRef.value.discovery_target_count ?? settingsDefaults.discovery_target_count
+    ).toString(),
+    discovery_validation_retries: (
+      settingsRef.value.discovery_validation_retries ??
+      settingsDefaults.discovery_validation_retries
+    ).toString(),
+    discovery_validation_timeout: (
+      settingsRef.value.discovery_validation_timeout ??
+      settingsDefaults.discovery_validation_timeout
+    ).toString(),
     freshrss_api_password:
       settingsRef.value.freshrss_api_password ?? settingsDefaults.freshrss_api_password,
     freshrss_auto_sync_interval: (
@@ -295,35 +455,73 @@ export function buildAutoSavePayload(settingsRef: Ref<SettingsData>): Record<str
     freshrss_enabled: (
       settingsRef.value.freshrss_enabled ?? settingsDefaults.freshrss_enabled
     ).toString(),
+    freshrss_insecure_tls: (
+      settingsRef.value.freshrss_insecure_tls ?? settingsDefaults.freshrss_insecure_tls
+    ).toString(),
     freshrss_last_sync_time:
       settingsRef.value.freshrss_last_sync_time ?? settingsDefaults.freshrss_last_sync_time,
+    freshrss_push_delay_seconds: (
+      settingsRef.value.freshrss_push_delay_seconds ?? settingsDefaults.freshrss_push_delay_seconds
+    ).toString(),
+    freshrss_save_batch_size: (
+      settingsRef.value.freshrss_save_batch_size ?? settingsDefaults.freshrss_save_batch_size
+    ).toString(),
+    freshrss_server_type:
+      settingsRef.value.freshrss_server_type ?? settingsDefaults.freshrss_server_type,
     freshrss_server_url:
       settingsRef.value.freshrss_server_url ?? settingsDefaults.freshrss_server_url,
     freshrss_sync_on_startup: (
       settingsRef.value.freshrss_sync_on_startup ?? settingsDefaults.freshrss_sync_on_startup
     ).toString(),
+    freshrss_timeout_seconds: (
+      settingsRef.value.freshrss_timeout_seconds ?? settingsDefaults.freshrss_timeout_seconds
+    ).toString(),
     freshrss_username: settingsRef.value.freshrss_username ?? settingsDefaults.freshrss_username,
     full_text_fetch_enabled: (
       settingsRef.value.full_text_fetch_enabled ?? settingsDefaults.full_text_fetch_enabled
     ).toString(),
     google_translate_endpoint:
       settingsRef.value.google_translate_endpoint ?? settingsDefaults.google_translate_endpoint,
+    google_translate_fallback_provider:
+      settingsRef.value.google_translate_fallback_provider ??
+      settingsDefaults.google_translate_fallback_provider,
     hover_mark_as_read: (
       settingsRef.value.hover_mark_as_read ?? settingsDefaults.hover_mark_as_read
     ).toString(),
+    http_idle_conn_timeout: (
+      settingsRef.value.http_idle_conn_timeout ?? settingsDefaults.http_idle_conn_timeout
+    ).toString(),
+    http_max_idle_conns: (
+      settingsRef.value.http_max_idle_conns ?? settingsDefaults.http_max_idle_conns
+    ).toString(),
+    http_max_idle_conns_per_host: (
+      settingsRef.value.http_max_idle_conns_per_host ??
+      settingsDefaults.http_max_idle_conns_per_host
+    ).toString(),
     image_gallery_enabled: (
       settingsRef.value.image_gallery_enabled ?? settingsDefaults.image_gallery_enabled
     ).toString(),
+    keep_favorite_content: (
+      settingsRef.value.keep_favorite_content ?? settingsDefaults.keep_favorite_content
+    ).toString(),
     language: settingsRef.value.language ?? settingsDefaults.language,
     last_network_test: settingsRef.value.last_network_test ?? settingsDefaults.last_network_test,
+    libre_api_key: settingsRef.value.libre_api_key ?? settingsDefaults.libre_api_key,
+    libre_endpoint: settingsRef.value.libre_endpoint ?? settingsDefaults.libre_endpoint,
     max_article_age_days: (
       settingsRef.value.max_article_age_days ?? settingsDefaults.max_article_age_days
     ).toString(),
+    max_article_images: (
+      settingsRef.value.max_article_images ?? settingsDefaults.max_article_images
+    ).toString(),
     max_cache_size_mb: (
       settingsRef.value.max_cache_size_mb ?? settingsDefaults.max_cache_size_mb
     ).toString(),
     max_concurrent_refreshes:
       settingsRef.value.max_concurrent_refreshes ?? settingsDefaults.max_concurrent_refreshes,
+    max_fetches_per_hour: (
+      settingsRef.value.max_fetches_per_hour ?? settingsDefaults.max_fetches_per_hour
+    ).toString(),
     media_cache_enabled: (
       settingsRef.value.media_cache_enabled ?? settingsDefaults.media_cache_enabled
     ).toString(),
@@ -336,6 +534,12 @@ export function buildAutoSavePayload(settingsRef: Ref<SettingsData>): Record<str
     media_proxy_fallback: (
       settingsRef.value.media_proxy_fallback ?? settingsDefaults.media_proxy_fallback
     ).toString(),
+    metadata_only_fetch: (
+      settingsRef.value.metadata_only_fetch ?? settingsDefaults.metadata_only_fetch
+    ).toString(),
+    min_manual_refresh_interval: (
+      settingsRef.value.min_manual_refresh_interval ?? settingsDefaults.min_manual_refresh_interval
+    ).toString(),
     network_bandwidth_mbps:
       settingsRef.value.network_bandwidth_mbps ?? settingsDefaults.network_bandwidth_mbps,
     network_latency_ms: settingsRef.value.network_latency_ms ?? settingsDefaults.network_latency_ms,
@@ -346,6 +550,12 @@ export function buildAutoSavePayload(settingsRef: Ref<SettingsData>): Record<str
     obsidian_vault: settingsRef.value.obsidian_vault ?? settingsDefaults.obsidian_vault,
     obsidian_vault_path:
       settingsRef.value.obsidian_vault_path ?? settingsDefaults.obsidian_vault_path,
+    open_external_links_new_tab: (
+      settingsRef.value.open_external_links_new_tab ?? settingsDefaults.open_external_links_new_tab
+    ).toString(),
+    open_original_marks_read: (
+      settingsRef.value.open_original_marks_read ?? settingsDefaults.open_original_marks_read
+    ).toString(),
     proxy_enabled: (settingsRef.value.proxy_enabled ?? settingsDefaults.proxy_enabled).toString(),
     proxy_host: settingsRef.value.proxy_host ?? settingsDefaults.proxy_host,
     proxy_password: settingsRef.value.proxy_password ?? settingsDefaults.proxy_password,
@@ -369,6 +579,9 @@ export function buildAutoSavePayload(settingsRef: Ref<SettingsData>): Record<str
     show_article_preview_images: (
       settingsRef.value.show_article_preview_images ?? settingsDefaults.show_article_preview_images
     ).toString(),
+    show_feed_badge: (
+      settingsRef.value.show_feed_badge ?? settingsDefaults.show_feed_badge
+    ).toString(),
     show_hidden_articles: (
       settingsRef.value.show_hidden_articles ?? settingsDefaults.show_hidden_articles
     ).toString(),
@@ -379,11 +592,19 @@ export function buildAutoSavePayload(settingsRef: Ref<SettingsData>): Record<str
       settingsRef.value.summary_enabled ?? settingsDefaults.summary_enabled
     ).toString(),
     summary_length: settingsRef.value.summary_length ?? settingsDefaults.summary_length,
+    summary_min_length: (
+      settingsRef.value.summary_min_length ?? settingsDefaults.summary_min_length
+    ).toString(),
     summary_provider: settingsRef.value.summary_provider ?? settingsDefaults.summary_provider,
     summary_trigger_mode:
       settingsRef.value.summary_trigger_mode ?? settingsDefaults.summary_trigger_mode,
+    sync_conflict_strategy:
+      settingsRef.value.sync_conflict_strategy ?? settingsDefaults.sync_conflict_strategy,
     target_language: settingsRef.value.target_language ?? settingsDefaults.target_language,
     theme: settingsRef.value.theme ?? settingsDefaults.theme,
+    translation_concurrency: (
+      settingsRef.value.translation_concurrency ?? settingsDefaults.translation_concurrency
+    ).toString(),
     translation_enabled: (
       settingsRef.value.translation_enabled ?? settingsDefaults.translation_enabled
     ).toString(),
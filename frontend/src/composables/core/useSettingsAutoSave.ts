@@ -9,7 +9,7 @@ import { settingsDefaults } from '@/config/defaults';
 import { buildAutoSavePayload } from './useSettings.generated';
 
 export function useSettingsAutoSave(settings: Ref<SettingsData> | (() => SettingsData)) {
-  const { locale } = useI18n();
+  const { t, locale } = useI18n();
   const store = useAppStore();
 
   let saveTimeout: ReturnType<typeof setTimeout> | null = null;
@@ -88,16 +88,21 @@ export function useSettingsAutoSave(settings: Ref<SettingsData> | (() => Setting
       // Note: Validation is used for UI feedback only (showing red borders on invalid fields).
       // We do NOT block saving settings to the backend based on validation.
       // This allows users to save their preferences immediately, even if some fields are invalid.
-      // The backend saves all provided values to the database without validation.
-      // Features that require valid settings (e.g., translation with API keys) will check
-      // for valid values at runtime and fail gracefully if settings are incomplete/invalid.
+      // The backend only rejects values that would break requests, such as malformed
+      // AI custom headers. Other features that require valid settings (e.g., translation
+      // with API keys) check for valid values at runtime and fail gracefully.
 
       // Save to backend using generated payload (alphabetically sorted)
-      await fetch('/api/settings', {
+      const res = await fetch('/api/settings', {
         method: 'POST',
         headers: { 'Content-Type': 'application/json' },
         body: JSON.stringify(buildAutoSavePayload(settingsRef)),
       });
+      if (!res.ok) {
+        const error = (await res.text()).trim();
+        window.showToast(t('setting.general.saveFailed', { error }), 'error');
+        return;
+      }
 
       // Clear and re-translate if translation settings changed
       if (translationChanged) {
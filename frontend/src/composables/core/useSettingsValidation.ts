@@ -25,6 +25,10 @@ export function useSettingsValidation(settings: Ref<SettingsData>) {
 
     if (settings.value.translation_provider === 'deepl') {
       return !!settings.value.deepl_api_key?.trim();
+    } else if (settings.value.translation_provider === 'libre') {
+      return !!settings.value.libre_endpoint?.trim();
+    } else if (settings.value.translation_provider === 'azure') {
+      return !!settings.value.azure_translator_key?.trim();
     } else if (settings.value.translation_provider === 'baidu') {
       return !!(settings.value.baidu_app_id?.trim() && settings.value.baidu_secret_key?.trim());
     } else if (settings.value.translation_provider === 'ai') {
@@ -6,6 +6,7 @@ import { useAppStore } from '@/stores/app';
 export type FeedType = 'url' | 'script' | 'xpath' | 'email';
 export type ProxyMode = 'global' | 'custom' | 'none';
 export type RefreshMode = 'global' | 'fixed' | 'intelligent' | 'custom' | 'never';
+export type TranslationMode = 'inherit' | 'always' | 'never';
 
 export function useFeedForm(feed?: Feed) {
   const { t } = useI18n();
@@ -51,6 +52,9 @@ export function useFeedForm(feed?: Feed) {
   // Auto expand content mode
   const autoExpandContent = ref<'global' | 'enabled' | 'disabled'>('global');
 
+  // Translation override
+  const translationMode = ref<TranslationMode>('inherit');
+
   // Proxy settings
   const proxyMode = ref<ProxyMode>('global');
   const proxyType = ref('http');
@@ -236,6 +240,9 @@ export function useFeedForm(feed?: Feed) {
     autoExpandContent.value =
       (feed.auto_expand_content as 'global' | 'enabled' | 'disabled') || 'global';
 
+    // Initialize translation override
+    translationMode.value = (feed.translation_mode as TranslationMode) || 'inherit';
+
     // Determine feed type based on feed properties
     if (feed.script_path) {
       feedType.value = 'script';
@@ -325,6 +332,7 @@ export function useFeedForm(feed?: Feed) {
     emailFolder.value = 'INBOX';
     articleViewMode.value = 'global';
     autoExpandContent.value = 'global';
+    translationMode.value = 'inherit';
     proxyMode.value = 'global';
     proxyType.value = 'http';
     proxyHost.value = '';
@@ -391,6 +399,7 @@ export function useFeedForm(feed?: Feed) {
     emailFolder,
     articleViewMode,
     autoExpandContent,
+    translationMode,
     proxyMode,
     proxyType,
     proxyHost,
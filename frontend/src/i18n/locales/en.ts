@@ -28,6 +28,7 @@ const en: TranslationMessages = {
       openInBrowser: 'Open in Browser',
       openInBrowserShortcut: 'Open in Browser',
       openOriginal: 'Open Original',
+      pinArticle: 'Pin to Top',
       refresh: 'Refresh',
       refreshFeed: 'Refresh Feed',
       refreshFeedsShortcut: 'Refresh Feeds',
@@ -37,8 +38,10 @@ const en: TranslationMessages = {
       removeFromReadLater: 'Remove from Read Later',
       toggleFavoriteStatus: 'Toggle Favorite',
       unhideArticle: 'Unhide Article',
+      unpinArticle: 'Unpin',
       viewArticle: 'View Article',
       viewContent: 'View Content',
+      viewDiscussion: 'View Discussion',
       viewImage: 'View Image',
       viewModeOriginal: 'View as Webpage',
       viewModeRendered: 'View as Rendered Content',
@@ -77,13 +80,16 @@ const en: TranslationMessages = {
       noContentAvailable: 'No content available',
       renderContent: 'Render Content',
       selectArticle: 'Select an article to start reading',
+      similarAlreadyRead: 'You recently read a similar story: "{title}"',
     },
     imageGallery: {
       actionFavorite: 'Add to Favorites',
       actionUnfavorite: 'Remove from Favorites',
       addToFavorite: 'Add to Favorites',
     },
-    list: {},
+    list: {
+      collapsedDuplicates: '{count} more with the same title',
+    },
     navigation: {
       goToAllArticles: 'Go to All Articles',
       goToFavorites: 'Go to Favorites',
@@ -340,6 +346,9 @@ const en: TranslationMessages = {
       feedUpdatedSuccess: 'Feed updated successfully',
       manageFeeds: 'Manage Feeds',
       noFeeds: 'No feeds yet',
+      possibleDuplicateMessage:
+        'This looks like a feed you already follow: "{title}" ({url}). Add it anyway?',
+      possibleDuplicateTitle: 'Possible Duplicate Feed',
       proxy: 'Feed Proxy',
       proxyDesc: 'Configure proxy settings for this feed',
       proxyHost: 'Proxy Host',
@@ -358,6 +367,14 @@ const en: TranslationMessages = {
       rssUrlDescription: 'Subscribe to RSS/Atom feeds',
       sourceUrl: 'Source URL',
       sourceUrlPlaceholder: 'https://example.com/blog',
+      statistics: 'Statistics',
+      statsArticlesPerWeek: 'Articles per week',
+      statsAvgLength: 'Average length',
+      statsAvgLengthValue: '{count} characters',
+      statsLastArticle: 'Last article',
+      statsLoadFailed: 'Failed to load feed statistics',
+      statsRefreshInterval: 'Intelligent refresh interval',
+      statsUnreadRatio: 'Unread',
       syncFeed: 'Sync Feed',
       syncFeedStarted: 'Feed sync started',
       titlePlaceholder: 'Custom feed title',
@@ -472,10 +489,17 @@ const en: TranslationMessages = {
       aiEndpoint: 'API Endpoint',
       aiEndpointDesc: 'Full API endpoint URL including path',
       aiEndpointPlaceholder: 'https://api.openai.com/v1/chat/completions',
+      aiFallbackEndpoints: 'Fallback Endpoints',
+      aiFallbackEndpointsDesc:
+        'Tried in order when the endpoint above fails (rate limit, outage). A JSON list of endpoint, api_key and model; an empty model uses the one above',
       aiFeatures: 'AI Features',
       aiModel: 'Model Name',
       aiModelDesc: 'AI model to use for translation and summarization',
       aiModelPlaceholder: 'gpt-4o-mini',
+      aiPreamblePatterns: 'Strip Output Boilerplate',
+      aiPreamblePatternsDesc:
+        'Extra patterns (one regular expression per line) removed from the start of AI output, in addition to phrases like "Here is the translation:"',
+      aiPreamblePatternsPlaceholder: '^Translated:\\s*',
       aiSettings: 'AI Settings',
       aiTestFailed: 'AI configuration test failed',
       aiTestSuccess: 'AI configuration test completed successfully',
@@ -510,17 +534,33 @@ const en: TranslationMessages = {
     content: {
       addHeader: 'Add Header',
       addLangMapping: 'Add Mapping',
+      aiFeedSummaryPrompt: 'Feed Overview Prompt',
+      aiFeedSummaryPromptDesc:
+        "Custom system prompt for the AI overview of a feed's recent articles",
+      aiFeedSummaryPromptPlaceholder:
+        "Write a brief bulleted list of the feed's recent themes. Output ONLY the list.",
       aiSummary: 'AI Summary',
       aiSummaryPrompt: 'Summary Prompt',
       aiSummaryPromptDesc: 'Custom system prompt for AI summarization',
       aiSummaryPromptPlaceholder:
         'You are a summarizer. Generate a concise summary of the given text. Output ONLY the summary, nothing else.',
       aiTranslation: 'AI Translation',
+      aiTranslationChunkChars: 'Chunk Size',
+      aiTranslationChunkCharsDesc:
+        'Long content is translated in chunks of about this many characters (0 sends it all at once)',
       aiTranslationPrompt: 'Translation Prompt',
       aiTranslationPromptDesc: 'Custom system prompt for AI translation',
       aiTranslationPromptPlaceholder:
         'You are a translator. Translate the given text accurately. Output ONLY the translated text, nothing else.',
       apiLangCode: 'API Code',
+      azureTranslator: 'Azure Translator',
+      azureTranslatorKey: 'Azure Translator Key',
+      azureTranslatorKeyDesc: 'Subscription key of your Azure Translator resource',
+      azureTranslatorKeyPlaceholder: 'Enter your Azure Translator key',
+      azureTranslatorRegion: 'Azure Region',
+      azureTranslatorRegionDesc:
+        'Region of the resource, such as eastus. Leave empty for global resources',
+      azureTranslatorRegionPlaceholder: 'eastus',
       baiduAppId: 'Baidu App ID',
       baiduAppIdDesc: 'Enter the Baidu Translate App ID',
       baiduAppIdPlaceholder: 'Enter your App ID',
@@ -561,6 +601,17 @@ const en: TranslationMessages = {
       googleTranslateEndpointAlternate: 'Alternate (clients5.google.com)',
       googleTranslateEndpointDefault: 'Default (translate.googleapis.com)',
       googleTranslateEndpointDesc: 'Select the Google Translate API endpoint to use',
+      googleTranslateFallback: 'Fallback When Blocked',
+      googleTranslateFallbackDesc:
+        'Translation provider to use while Google Translate is rate limiting or showing CAPTCHAs. Its settings are configured by selecting it as the provider.',
+      googleTranslateFallbackNone: 'None',
+      libreApiKey: 'LibreTranslate API Key',
+      libreApiKeyDesc: 'Only needed if your LibreTranslate server requires an API key',
+      libreApiKeyPlaceholder: 'Enter your LibreTranslate API key',
+      libreEndpoint: 'LibreTranslate Endpoint',
+      libreEndpointDesc: 'URL of your LibreTranslate server',
+      libreEndpointPlaceholder: 'http://localhost:5000',
+      libreTranslate: 'LibreTranslate',
       localAlgorithm: 'Local Algorithm',
       noSummaryAvailable: 'Summary not available',
       regenerateSummary: 'Regenerate',
@@ -585,6 +636,8 @@ const en: TranslationMessages = {
       targetLanguageDesc: 'Language to translate article titles to',
       translatingContent: 'Translating content...',
       translation: 'Translation',
+      translationConcurrency: 'Parallel Requests',
+      translationConcurrencyDesc: 'Paragraphs of long content translated at the same time',
       translationCredentialsRequired: 'Translation service requires API key or credentials',
       translationOnlyMode: 'Translation Only Mode',
       translationOnlyModeDesc: 'Show only translated text, hide original content',
@@ -675,6 +728,9 @@ const en: TranslationMessages = {
       currentCachedArticles: 'Current cached articles',
       dataManagement: 'Data Management',
       days: 'days',
+      keepFavoriteContent: 'Keep Saved Article Content',
+      keepFavoriteContentDesc:
+        'Never clear the cached content of favorited and read-later articles, so they stay readable offline',
       maxArticleAge: 'Max Article Age',
       maxArticleAgeDesc: 'Delete articles older than this many days (except favorites)',
       maxCacheSize: 'Max Cache Size',
@@ -707,11 +763,25 @@ const en: TranslationMessages = {
       imageMode: 'Image Mode',
       imageModeDesc: 'Display this feed in image gallery view instead of article list',
       intelligentInterval: 'Intelligent Interval',
+      maxFetchesPerHour: 'Hourly Fetch Budget',
+      maxFetchesPerHourDesc:
+        'Maximum feed fetches per hour across all refreshes; feeds over the budget wait for the next hour (0 = no limit)',
+      metadataOnlyFetch: 'Fetch Only Titles',
+      metadataOnlyFetchDesc:
+        'Store only titles and metadata at refresh and load content when an article is opened, to save bandwidth. Feeds with eager content keep fetching it',
+      minManualRefreshInterval: 'Minimum Manual Refresh Interval',
+      minManualRefreshIntervalDesc:
+        'Ignore manual refreshes requested sooner than this after the last one (0 = no limit)',
       neverRefresh: 'Never Refresh',
       refreshMode: 'Refresh Mode',
       refreshModeDesc: 'Choose how often to refresh all subscriptions',
       retryTimeout: 'Timeout',
       retryTimeoutDesc: 'Time to wait before marking refresh as failed',
+      translationMode: 'Translation',
+      translationModeAlways: 'Always Translate',
+      translationModeDesc:
+        'Override the global translation setting for this feed. Always translates it even when translation is turned off',
+      translationModeNever: 'Never Translate',
       useCustomInterval: 'Custom Interval',
       useGlobalRefresh: 'Use Global Setting',
       useGlobalSettings: 'Use Global Settings',
@@ -726,6 +796,7 @@ const en: TranslationMessages = {
       language: 'Language',
       languageDesc: 'Select interface language',
       light: 'Light',
+      saveFailed: 'Settings not saved: {error}',
       startupOnBoot: 'Start on System Boot',
       startupOnBootDesc: 'Automatically start MrRSS when the computer starts',
       theme: 'Theme',
@@ -777,6 +848,12 @@ const en: TranslationMessages = {
       apiPassword: 'API Password',
       apiPasswordDesc: 'FreshRSS API password (different from login password)',
       apiPasswordPlaceholder: 'Enter your API password',
+      conflictLocalWins: 'Local wins',
+      conflictNewestWins: 'Newest wins',
+      conflictRemoteWins: 'Server wins',
+      conflictStrategy: 'Conflict Resolution',
+      conflictStrategyDesc:
+        'Which read and star state to keep when an article changed here and on the server between syncs. The server does not say when its change was made, so "Newest wins" dates it to the last sync: a local change since then wins, like "Local wins", and only older unsent local changes lose',
       daysAgo: '{count} days ago',
       disableConfirm:
         'Disabling FreshRSS will delete local FreshRSS feeds and articles. This action cannot be undone. Are you sure you want to continue?',
@@ -784,13 +861,25 @@ const en: TranslationMessages = {
       enabledDesc: 'Sync feeds and articles with a FreshRSS server',
       hoursAgo: '{count} hours ago',
       minsAgo: '{count} minutes ago',
+      pushDelay: 'Push Delay (seconds)',
+      pushDelayDesc:
+        'Read and star changes made within this time are sent together. Set to 0 to send each change at once',
+      saveBatchSize: 'Save Batch Size',
+      saveBatchSizeDesc:
+        'Articles saved per database transaction during sync. Lower values keep the app responsive during large syncs',
       syncFailed: 'Sync failed',
       feedLocked: 'FreshRSS feed cannot be edited, moved, or modified',
+      insecureTls: 'Allow Self-Signed Certificates',
+      insecureTlsDesc:
+        'Skip TLS certificate verification. Only use this for servers on a trusted network',
       justNow: 'Just now',
       lastSync: 'Last Sync',
       never: 'Never',
+      serverType: 'Server Type',
+      serverTypeDesc: 'Google Reader API service to sync with',
       serverUrl: 'Server URL',
-      serverUrlDesc: 'FreshRSS server endpoint (without /api path)',
+      serverUrlDesc:
+        'FreshRSS server endpoint, or the Inoreader / The Old Reader site URL (without /api path)',
       serverUrlPlaceholder: 'https://freshrss.example.com',
       sync: 'Sync Now',
       syncedFeed: 'Synced from FreshRSS',
@@ -798,6 +887,8 @@ const en: TranslationMessages = {
       syncNow: 'Sync Subscription Status',
       syncNowDesc: 'Synchronize feed and article statuses bidirectionally',
       syncStarted: 'Sync started',
+      timeout: 'Request Timeout (seconds)',
+      timeoutDesc: 'Raise this for slow servers syncing large streams',
       username: 'Username',
       usernameDesc: 'The FreshRSS username',
       usernamePlaceholder: 'Enter your username',
@@ -821,6 +912,9 @@ const en: TranslationMessages = {
       autoShowAllContent: 'Auto Show All Content',
       autoShowAllContentDesc:
         'Automatically display the full content of all articles when viewed as rendered content (may increase loading time)',
+      collapseDuplicateTitles: 'Collapse Duplicate Titles',
+      collapseDuplicateTitlesDesc:
+        'Group articles of the same feed with near-identical titles (such as recurring roundups) into one list entry with a count',
       defaultViewMode: 'Article View Mode',
       defaultViewModeDesc: 'Choose how articles should be displayed',
       hideAdvancedSettings: 'Hide Advanced Settings',
@@ -834,9 +928,18 @@ const en: TranslationMessages = {
         'Automatically mark articles as read when hovering over them (does not apply to Read Later articles)',
       imageGalleryEnabled: 'Enable Image Gallery',
       imageGalleryEnabledDesc: 'Enable image waterfall mode for image-focused feeds',
+      maxArticleImages: 'Gallery Images per Article',
+      maxArticleImagesDesc:
+        'Number of images from the article body kept for galleries (0 = none)',
+      openOriginalMarksRead: 'Mark as Read When Opening Original',
+      openOriginalMarksReadDesc:
+        'Opening an article through its cleaned original link also marks it as read',
       showAdvancedSettings: 'Show Advanced Settings',
       showArticlePreviewImages: 'Show Preview Images',
       showArticlePreviewImagesDesc: 'Display preview images in the article list',
+      showFeedBadge: 'Show Feed Badges',
+      showFeedBadgeDesc:
+        "Show each feed's short name and icon in front of its articles in the list",
       showHiddenArticles: 'Show Hidden Articles',
       showHiddenArticlesDesc: 'Show articles hidden in the All Articles list',
       showOnlyUnread: 'Show only unread articles',
@@ -28,6 +28,7 @@ const zh: TranslationMessages = {
       openInBrowser: '在浏览器中打开',
       openInBrowserShortcut: '在浏览器中打开',
       openOriginal: '打开原文',
+      pinArticle: '置顶',
       refresh: '刷新',
       refreshFeed: '刷新订阅',
       refreshFeedsShortcut: '刷新订阅',
@@ -37,8 +38,10 @@ const zh: TranslationMessages = {
       removeFromReadLater: '从稀后阅读中移除',
       toggleFavoriteStatus: '切换收藏',
       unhideArticle: '取消隐藏',
+      unpinArticle: '取消置顶',
       viewArticle: '查看文章',
       viewContent: '查看内容',
+      viewDiscussion: '查看讨论',
       viewImage: '查看图片',
       viewModeOriginal: '以网页查看',
       viewModeRendered: '以渲染查看',
@@ -76,13 +79,16 @@ const zh: TranslationMessages = {
       noContentAvailable: '暂无内容',
       renderContent: '渲染内容',
       selectArticle: '选择一篇文章开始阅读',
+      similarAlreadyRead: '你最近读过一篇相似的报道：“{title}”',
     },
     imageGallery: {
       actionFavorite: '添加到收藏',
       actionUnfavorite: '取消收藏',
       addToFavorite: '添加到收藏',
     },
-    list: {},
+    list: {
+      collapsedDuplicates: '另有 {count} 篇同标题文章',
+    },
     navigation: {
       goToAllArticles: '转到所有文章',
       goToFavorites: '转到收藏',
@@ -336,6 +342,8 @@ const zh: TranslationMessages = {
       feedUpdatedSuccess: '订阅更新成功',
       manageFeeds: '管理订阅',
       noFeeds: '暂无订阅',
+      possibleDuplicateMessage: '该订阅可能已存在：“{title}”（{url}）。仍要添加吗？',
+      possibleDuplicateTitle: '可能重复的订阅',
       proxy: '订阅代理',
       proxyDesc: '为此订阅配置代理设置',
       proxyHost: '代理主机',
@@ -354,6 +362,14 @@ const zh: TranslationMessages = {
       rssUrlDescription: '订阅 RSS/Atom 源',
       sourceUrl: '来源链接',
       sourceUrlPlaceholder: 'https://example.com/blog',
+      statistics: '统计',
+      statsArticlesPerWeek: '每周文章数',
+      statsAvgLength: '平均长度',
+      statsAvgLengthValue: '{count} 个字符',
+      statsLastArticle: '最新文章',
+      statsLoadFailed: '加载订阅源统计失败',
+      statsRefreshInterval: '智能刷新间隔',
+      statsUnreadRatio: '未读',
       syncFeed: '同步订阅',
       syncFeedStarted: '订阅同步已开始',
       titlePlaceholder: '自定义订阅标题',
@@ -468,10 +484,17 @@ const zh: TranslationMessages = {
       aiEndpoint: 'API 端点',
       aiEndpointDesc: '完整的 API 端点 URL，包括路径',
       aiEndpointPlaceholder: 'https://api.openai.com/v1/chat/completions',
+      aiFallbackEndpoints: '备用接口',
+      aiFallbackEndpointsDesc:
+        '上方接口失败（限流、故障）时依次尝试。JSON 列表，包含 endpoint、api_key 和 model；model 为空时使用上方的模型',
       aiFeatures: 'AI 功能',
       aiModel: '模型名称',
       aiModelDesc: '用于翻译和摘要的 AI 模型',
       aiModelPlaceholder: 'gpt-4o-mini',
+      aiPreamblePatterns: '清理输出中的多余内容',
+      aiPreamblePatternsDesc:
+        '额外的清理规则（每行一个正则表达式），会从 AI 输出开头移除，内置规则已涵盖 “Here is the translation:” 等常见语句',
+      aiPreamblePatternsPlaceholder: '^译文：\\s*',
       aiSettings: 'AI 设置',
       aiTestFailed: 'AI 配置测试失败',
       aiTestSuccess: 'AI 配置测试成功',
@@ -505,17 +528,30 @@ const zh: TranslationMessages = {
     content: {
       addHeader: '添加请求头',
       addLangMapping: '添加映射',
+      aiFeedSummaryPrompt: '订阅源概览提示词',
+      aiFeedSummaryPromptDesc: 'AI 概览订阅源近期文章时使用的自定义系统提示词',
+      aiFeedSummaryPromptPlaceholder:
+        '用简短的项目符号列表概括订阅源近期的主要主题。只输出列表。',
       aiSummary: 'AI 摘要',
       aiSummaryPrompt: '摘要提示词',
       aiSummaryPromptDesc: 'AI 摘要的自定义系统提示词',
       aiSummaryPromptPlaceholder:
         '你是一个摘要生成器。生成给定文本的简洁摘要。只输出摘要，不要输出其他内容。',
       aiTranslation: 'AI 翻译',
+      aiTranslationChunkChars: '分块大小',
+      aiTranslationChunkCharsDesc: '长内容按约此字符数分块翻译（0 表示一次性发送）',
       aiTranslationPrompt: '翻译提示词',
       aiTranslationPromptDesc: 'AI 翻译的自定义系统提示词',
       aiTranslationPromptPlaceholder:
         '你是一个翻译器。准确翻译给定的文本。只输出翻译的文本，不要输出其他内容。',
       apiLangCode: 'API 代码',
+      azureTranslator: 'Azure 翻译',
+      azureTranslatorKey: 'Azure 翻译密钥',
+      azureTranslatorKeyDesc: '您的 Azure 翻译资源的订阅密钥',
+      azureTranslatorKeyPlaceholder: '输入您的 Azure 翻译密钥',
+      azureTranslatorRegion: 'Azure 区域',
+      azureTranslatorRegionDesc: '资源所在区域，例如 eastus。全局资源请留空',
+      azureTranslatorRegionPlaceholder: 'eastus',
       baiduAppId: '百度 App ID',
       baiduAppIdDesc: '输入百度翻译的 App ID',
       baiduAppIdPlaceholder: '输入您的 App ID',
@@ -554,6 +590,17 @@ const zh: TranslationMessages = {
       googleTranslateEndpointAlternate: '备用 (clients5.google.com)',
       googleTranslateEndpointDefault: '默认 (translate.googleapis.com)',
       googleTranslateEndpointDesc: '选择要使用的谷歌翻译 API 端点',
+      googleTranslateFallback: '被限制时的备用服务',
+      googleTranslateFallbackDesc:
+        '谷歌翻译限流或要求验证码时改用的翻译服务。其配置需先将其选为翻译服务后填写。',
+      googleTranslateFallbackNone: '无',
+      libreApiKey: 'LibreTranslate API 密钥',
+      libreApiKeyDesc: '仅当 LibreTranslate 服务器要求 API 密钥时需要填写',
+      libreApiKeyPlaceholder: '输入您的 LibreTranslate API 密钥',
+      libreEndpoint: 'LibreTranslate 端点',
+      libreEndpointDesc: '您的 LibreTranslate 服务器 URL',
+      libreEndpointPlaceholder: 'http://localhost:5000',
+      libreTranslate: 'LibreTranslate',
       localAlgorithm: '本地算法',
       noSummaryAvailable: '摘要不可用',
       regenerateSummary: '重新生成',
@@ -578,6 +625,8 @@ const zh: TranslationMessages = {
       targetLanguageDesc: '将文章标题翻译成此语言',
       translatingContent: '正在翻译内容...',
       translation: '翻译',
+      translationConcurrency: '并行请求数',
+      translationConcurrencyDesc: '长内容同时翻译的段落数',
       translationCredentialsRequired: '翻译服务需要 API 密钥或凭据',
       translationOnlyMode: '仅翻译模式',
       translationOnlyModeDesc: '仅显示翻译后的文本，隐藏原文',
@@ -667,6 +716,8 @@ const zh: TranslationMessages = {
       currentCachedArticles: '当前缓存文章数',
       dataManagement: '数据管理',
       days: '天',
+      keepFavoriteContent: '保留已保存文章的内容',
+      keepFavoriteContentDesc: '从不清除收藏和稍后阅读文章的缓存内容，以便离线阅读',
       maxArticleAge: '文章最大保留天数',
       maxArticleAgeDesc: '删除超过此天数的文章（收藏除外）',
       maxCacheSize: '最大缓存大小',
@@ -695,11 +746,21 @@ const zh: TranslationMessages = {
       imageMode: '图片模式',
       imageModeDesc: '以图片库视图而非文章列表展示此订阅源',
       intelligentInterval: '智能间隔',
+      maxFetchesPerHour: '每小时抓取上限',
+      maxFetchesPerHourDesc: '所有刷新每小时最多抓取的订阅源次数，超出的订阅源将顺延到下一小时（0 表示不限制）',
+      metadataOnlyFetch: '仅抓取标题',
+      metadataOnlyFetchDesc: '刷新时只保存标题等元数据，打开文章时再加载内容，以节省流量。启用预取全文的订阅源不受影响',
+      minManualRefreshInterval: '手动刷新最小间隔',
+      minManualRefreshIntervalDesc: '距上次刷新不足此时间的手动刷新将被忽略（0 表示不限制）',
       neverRefresh: '不刷新',
       refreshMode: '刷新模式',
       refreshModeDesc: '选择以何种频率刷新所有订阅源',
       retryTimeout: '超时时间',
       retryTimeoutDesc: '在宣告刷新失败前等待响应的时间',
+      translationMode: '翻译',
+      translationModeAlways: '始终翻译',
+      translationModeDesc: '覆盖此订阅源的全局翻译设置。选择始终翻译时，即使关闭了翻译功能也会翻译',
+      translationModeNever: '从不翻译',
       useCustomInterval: '自定义间隔',
       useGlobalRefresh: '使用全局设置',
       useGlobalSettings: '使用全局设置',
@@ -714,6 +775,7 @@ const zh: TranslationMessages = {
       language: '语言',
       languageDesc: '选择界面语言',
       light: '亮色',
+      saveFailed: '设置未保存：{error}',
       startupOnBoot: '开机自启动',
       startupOnBootDesc: '在电脑启动时自动启动 MrRSS',
       theme: '主题',
@@ -764,6 +826,12 @@ const zh: TranslationMessages = {
       apiPassword: 'API 密码',
       apiPasswordDesc: 'FreshRSS API 密码（不同于登录密码）',
       apiPasswordPlaceholder: '输入 API 密码',
+      conflictLocalWins: '本地优先',
+      conflictNewestWins: '最新更改优先',
+      conflictRemoteWins: '服务器优先',
+      conflictStrategy: '冲突处理',
+      conflictStrategyDesc:
+        '文章在两次同步之间于本地和服务器上都被更改时，保留哪一方的已读和收藏状态。服务器不提供更改时间，“最新更改优先”将其视为上次同步时的更改：此后的本地更改与“本地优先”一样会保留，只有更早且未发送的本地更改会被覆盖',
       daysAgo: '{count} 天前',
       disableConfirm:
         '禁用 FreshRSS 将删除本地的 FreshRSS 订阅源和文章。此操作不可撤销。确定要继续吗？',
@@ -771,13 +839,21 @@ const zh: TranslationMessages = {
       enabledDesc: '与 FreshRSS 服务器同步订阅源和文章',
       hoursAgo: '{count} 小时前',
       minsAgo: '{count} 分钟前',
+      pushDelay: '推送延迟（秒）',
+      pushDelayDesc: '在此时间内的已读和收藏更改将合并发送。设为 0 则每次更改立即发送',
+      saveBatchSize: '保存批次大小',
+      saveBatchSizeDesc: '同步时每个数据库事务保存的文章数。较小的值可在大量同步时保持应用响应',
       syncFailed: '同步失败',
       feedLocked: 'FreshRSS 订阅源无法编辑、移动或修改',
+      insecureTls: '允许自签名证书',
+      insecureTlsDesc: '跳过 TLS 证书验证。仅对可信网络中的服务器使用',
       justNow: '刚刚',
       lastSync: '上次同步',
       never: '从未',
+      serverType: '服务器类型',
+      serverTypeDesc: '要同步的 Google Reader API 服务',
       serverUrl: '服务器地址',
-      serverUrlDesc: 'FreshRSS 服务器端点（不含 /api 路径）',
+      serverUrlDesc: 'FreshRSS 服务器端点，或 Inoreader / The Old Reader 网站地址（不含 /api 路径）',
       serverUrlPlaceholder: 'https://freshrss.example.com',
       sync: '立即同步',
       syncedFeed: '从 FreshRSS 同步',
@@ -785,6 +861,8 @@ const zh: TranslationMessages = {
       syncNow: '同步订阅状态',
       syncNowDesc: '双向同步订阅源和文章状态',
       syncStarted: '同步已开始',
+      timeout: '请求超时（秒）',
+      timeoutDesc: '服务器较慢或同步大量文章时可调大此值',
       username: '用户名',
       usernameDesc: 'FreshRSS 用户名',
       usernamePlaceholder: '输入用户名',
@@ -808,6 +886,9 @@ const zh: TranslationMessages = {
       autoShowAllContent: '自动展示所有内容',
       autoShowAllContentDesc:
         '作为渲染内容查看时，自动显示所有文章的完整内容（可能会增加加载时间）',
+      collapseDuplicateTitles: '折叠重复标题',
+      collapseDuplicateTitlesDesc:
+        '将同一订阅源中标题几乎相同的文章（如定期汇总）合并为列表中的一项并显示数量',
       defaultViewMode: '文章查看模式',
       defaultViewModeDesc: '选择文章应如何显示',
       hideAdvancedSettings: '隐藏高级设置',
@@ -820,9 +901,15 @@ const zh: TranslationMessages = {
       hoverMarkAsReadDesc: '鼠标悬停在文章上时自动标记为已读（不适用于稍后阅读的文章）',
       imageGalleryEnabled: '启用图片库',
       imageGalleryEnabledDesc: '为图片类订阅源启用图片瀑布流模式',
+      maxArticleImages: '每篇文章的图集图片数',
+      maxArticleImagesDesc: '为图集保留的正文图片数量（0 表示不提取）',
+      openOriginalMarksRead: '打开原文时标记为已读',
+      openOriginalMarksReadDesc: '通过去除跟踪参数的原文链接打开文章时，同时将其标记为已读',
       showAdvancedSettings: '显示高级设置',
       showArticlePreviewImages: '显示预览图片',
       showArticlePreviewImagesDesc: '在文章列表中显示预览图片',
+      showFeedBadge: '显示订阅源标识',
+      showFeedBadgeDesc: '在文章列表中的文章前显示订阅源的简称和图标',
       showHiddenArticles: '显示隐藏文章',
       showHiddenArticlesDesc: '在全部文章列表中显示隐藏的文章',
       showOnlyUnread: '仅显示未读文章',
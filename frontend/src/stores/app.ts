@@ -30,6 +30,7 @@ export interface AppState {
   theme: Ref<Theme>;
   refreshProgress: Ref<RefreshProgress>;
   showOnlyUnread: Ref<boolean>;
+  newSinceVisit: Ref<Article[]>;
 }
 
 export interface AppActions {
@@ -51,6 +52,7 @@ export interface AppActions {
   checkForAppUpdates: () => Promise<void>;
   startAutoRefresh: (minutes: number) => void;
   toggleShowOnlyUnread: () => void;
+  recordFeedVisit: (feedId: number) => Promise<void>;
 }
 
 export const useAppStore = defineStore('app', () => {
@@ -75,6 +77,8 @@ export const useAppStore = defineStore('app', () => {
   const currentCategory = ref<string | null>(null);
   const currentArticleId = ref<number | null>(null);
   const tempSelection = ref<TempSelection>({ feedId: null, category: null });
+  // Articles that arrived in the current feed since it was last opened
+  const newSinceVisit = ref<Article[]>([]);
   const isLoading = ref<boolean>(false);
   const page = ref<number>(1);
   const hasMore = ref<boolean>(true);
@@ -121,6 +125,25 @@ export const useAppStore = defineStore('app', () => {
       tempSelection.value = { feedId, category: null };
       fetchArticles();
     }
+    recordFeedVisit(feedId);
+  }
+
+  // Record that the feed's view was opened and keep the articles new since the previous visit
+  async function recordFeedVisit(feedId: number): Promise<void> {
+    newSinceVisit.value = [];
+    try {
+      const res = await fetch(`/api/feeds/new-since-visit?feed_id=${feedId}`, { method: 'POST' });
+      if (!res.ok) return;
+      const data = await res.json();
+      // Ignore the answer if the user has already moved on to another feed
+      if (currentFeedId.value !== feedId) return;
+      newSinceVisit.value = data.articles || [];
+      if (data.count > 0 && window.showToast) {
+        window.showToast(`${data.count} new since your last visit`, 'info', 3000);
+      }
+    } catch (e) {
+      console.error('Error recording feed visit:', e);
+    }
   }
 
   function setCategory(category: string): void {
@@ -173,6 +196,7 @@ export const useAppStore = defineStore('app', () => {
     if (currentFeedId.value) url += `&feed_id=${currentFeedId.value}`;
     if (currentCategory.value !== null)
       url += `&category=${encodeURIComponent(currentCategory.value)}`;
+    if (settingsRef.value.collapse_duplicate_titles) url += '&collapse_duplicate_titles=true';
 
     try {
       const res = await fetch(url);
@@ -367,12 +391,30 @@ export const useAppStore = defineStore('app', () => {
     try {
       // First, trigger standard refresh
       const refreshRes = await fetch('/api/refresh', { method: 'POST' });
+      if (refreshRes.status === 409) {
+        // A refresh is already running: follow its progress instead of starting another
+        await fetchProgressOnce();
+        pollProgress();
+        return;
+      }
+      if (refreshRes.status === 429) {
+        const data = await refreshRes.json();
+        refreshProgress.value.isRunning = false;
+        if (window.showToast) {
+          window.showToast(`Refreshed recently, try again in ${data.retry_after_seconds}s`, 'info', 3000);
+        }
+        return;
+      }
       if (!refreshRes.ok) {
         throw new Error(`Refresh API returned ${refreshRes.status}: ${refreshRes.statusText}`);
       }
       // Verify the response is valid JSON by consuming it
       try {
-        await refreshRes.json();
+        const data = await refreshRes.json();
+        // The refresh started but will not fit in the hourly fetch budget
+        if (data.warning && window.showToast) {
+          window.showToast(data.warning, 'warning', 5000);
+        }
       } catch (e) {
         console.error('Invalid JSON response from /api/refresh:', e);
         throw new Error(`Invalid JSON response from refresh API: ${e}`);
@@ -713,6 +755,7 @@ export const useAppStore = defineStore('app', () => {
     refreshProgress,
     showOnlyUnread,
     articleViewModePreferences,
+    newSinceVisit,
 
     // Actions
     setFilter,
@@ -737,5 +780,6 @@ export const useAppStore = defineStore('app', () => {
     startAutoRefresh,
     toggleShowOnlyUnread,
     fetchTaskDetails,
+    recordFeedVisit,
   };
 });
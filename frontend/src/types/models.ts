@@ -10,6 +10,8 @@ export interface Article {
   translated_title?: string;
   url: string;
   image_url?: string; // Article thumbnail image
+  images?: string[]; // Gallery images extracted from the article body
+  discussion_url?: string; // Comment thread on an aggregator (Hacker News, Reddit, ...)
   audio_url?: string; // Podcast audio file URL
   video_url?: string; // YouTube video embed URL
   published_at: string;
@@ -17,9 +19,19 @@ export interface Article {
   is_favorite: boolean;
   is_hidden: boolean;
   is_read_later: boolean;
+  is_pinned?: boolean; // Listed above all other articles regardless of date
+  read_later_at?: string; // When the article was queued for reading later
   author?: string; // Article author
   summary?: string; // Cached AI-generated summary
   freshrss_item_id?: string; // FreshRSS/Google Reader item ID
+  duplicate_count?: number; // Near-identical titles collapsed into this article
+  duplicate_ids?: number[]; // IDs of the collapsed group, this article first
+  feed_badge?: FeedBadge; // Feed short name and icon, when show_feed_badge is enabled
+}
+
+export interface FeedBadge {
+  short_name: string;
+  icon_url?: string;
 }
 
 export interface Feed {
@@ -53,6 +65,16 @@ export interface Feed {
   xpath_item_uid?: string;
   article_view_mode?: string; // Article view mode override ('global', 'webpage', 'rendered', 'external')
   auto_expand_content?: string; // Auto expand content mode ('global', 'enabled', 'disabled')
+  translation_mode?: string; // Translation override ('inherit', 'always', 'never')
+  source_language_override?: string; // Source language for translation, empty for auto-detect
+  max_article_age_days?: number; // Hide articles older than this many days in the feed view (0 = no limit)
+  read_later_by_default?: boolean; // Queue new articles from this feed for reading later
+  eager_content?: boolean; // Fetch and store full content of new articles at refresh time
+  guid_unstable?: boolean; // Feed keeps changing article identifiers; deduped by URL and title
+  force_url_dedup?: boolean; // Always dedupe this feed's articles by URL and title
+  content_strategy?: Array<'feed' | 'selector' | 'readability' | 'script'>; // Content extraction order
+  content_selector?: string; // CSS selector used by the selector strategy
+  content_script_path?: string; // Script used by the script strategy, given the article URL
   // Email/Newsletter support
   email_address?: string;
   email_imap_server?: string;
@@ -63,12 +85,24 @@ export interface Feed {
   // FreshRSS integration
   is_freshrss_source?: boolean; // Whether this feed is from FreshRSS sync
   freshrss_stream_id?: string; // FreshRSS stream ID (e.g., "feed/http://...")
+  list_prefix?: string; // Short name shown in the article list, empty for the title's initials
   // Statistics
   latest_article_time?: string; // Latest article publish time
   articles_per_month?: number; // Average articles per month (calculated from last 90 days)
   last_update_status?: string; // Last update status ("success" or "failed")
 }
 
+export interface FeedStats {
+  feed_id: number;
+  article_count: number;
+  unread_count: number;
+  unread_ratio: number;
+  articles_per_week: number;
+  avg_length: number; // Characters of text in the cached contents
+  last_article_at?: string;
+  intelligent_refresh_minutes: number;
+}
+
 export interface UnreadCounts {
   total: number;
   feedCounts: Record<number, number>;
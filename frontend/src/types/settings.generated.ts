@@ -5,21 +5,36 @@
 // To add new settings, edit internal/config/settings_schema.json and run: go run tools/settings-generator/main.go
 
 export interface SettingsData {
+  adaptive_concurrency: boolean;
   ai_api_key: string;
   ai_chat_enabled: boolean;
+  ai_chat_max_tokens: number;
   ai_custom_headers: string;
   ai_endpoint: string;
+  ai_endpoints: string;
+  ai_feed_summary_prompt: string;
   ai_model: string;
+  ai_preamble_patterns: string;
+  ai_summary_max_tokens: number;
   ai_summary_prompt: string;
+  ai_translation_chunk_chars: number;
+  ai_translation_max_tokens: number;
   ai_translation_prompt: string;
   ai_usage_limit: string;
   ai_usage_tokens: string;
+  ai_worker_concurrency: number;
+  ai_worker_timeout: number;
   auto_cleanup_enabled: boolean;
+  auto_read_dwell_ms: number;
   auto_show_all_content: boolean;
+  azure_translator_key: string;
+  azure_translator_region: string;
   baidu_app_id: string;
   baidu_secret_key: string;
   close_to_tray: boolean;
+  collapse_duplicate_titles: boolean;
   compact_mode: boolean;
+  compress_article_content: boolean;
   content_font_family: string;
   content_font_size: number;
   content_line_height: string;
@@ -36,35 +51,58 @@ export interface SettingsData {
   deepl_api_key: string;
   deepl_endpoint: string;
   default_view_mode: string;
+  discovery_feed_timeout: number;
+  discovery_result_ttl: number;
+  discovery_target_count: number;
+  discovery_validation_retries: number;
+  discovery_validation_timeout: number;
   feed_drawer_expanded: boolean;
   feed_drawer_pinned: boolean;
   freshrss_api_password: string;
   freshrss_auto_sync_interval: number;
   freshrss_enabled: boolean;
+  freshrss_insecure_tls: boolean;
   freshrss_last_sync_time: string;
+  freshrss_push_delay_seconds: number;
+  freshrss_save_batch_size: number;
+  freshrss_server_type: string;
   freshrss_server_url: string;
   freshrss_sync_on_startup: boolean;
+  freshrss_timeout_seconds: number;
   freshrss_username: string;
   full_text_fetch_enabled: boolean;
   google_translate_endpoint: string;
+  google_translate_fallback_provider: string;
   hover_mark_as_read: boolean;
+  http_idle_conn_timeout: number;
+  http_max_idle_conns: number;
+  http_max_idle_conns_per_host: number;
   image_gallery_enabled: boolean;
+  keep_favorite_content: boolean;
   language: string;
   last_global_refresh: string;
   last_network_test: string;
+  libre_api_key: string;
+  libre_endpoint: string;
   max_article_age_days: number;
+  max_article_images: number;
   max_cache_size_mb: number;
   max_concurrent_refreshes: string;
+  max_fetches_per_hour: number;
   media_cache_enabled: boolean;
   media_cache_max_age_days: number;
   media_cache_max_size_mb: number;
   media_proxy_fallback: boolean;
+  metadata_only_fetch: boolean;
+  min_manual_refresh_interval: number;
   network_bandwidth_mbps: string;
   network_latency_ms: string;
   network_speed: string;
   obsidian_enabled: boolean;
   obsidian_vault: string;
   obsidian_vault_path: string;
+  open_external_links_new_tab: boolean;
+  open_original_marks_read: boolean;
   proxy_enabled: boolean;
   proxy_host: string;
   proxy_password: string;
@@ -80,14 +118,18 @@ export interface SettingsData {
   shortcuts: string;
   shortcuts_enabled: boolean;
   show_article_preview_images: boolean;
+  show_feed_badge: boolean;
   show_hidden_articles: boolean;
   startup_on_boot: boolean;
   summary_enabled: boolean;
   summary_length: string;
+  summary_min_length: number;
   summary_provider: string;
   summary_trigger_mode: string;
+  sync_conflict_strategy: string;
   target_language: string;
   theme: string;
+  translation_concurrency: number;
   translation_enabled: boolean;
   translation_only_mode: boolean;
   translation_provider: string;
@@ -161,6 +161,10 @@ func (h *AnthropicHandler) ParseResponse(body []byte) (ResponseResult, error) {
 		Content:    contentBuilder.String(),
 		Thinking:   thinkingContent,
 		FormatUsed: FormatTypeAnthropic,
+		Usage: Usage{
+			PromptTokens:     response.Usage.InputTokens,
+			CompletionTokens: response.Usage.OutputTokens,
+		},
 	}
 
 	return result, nil
@@ -3,6 +3,7 @@ package ai
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,6 +11,8 @@ import (
 	"net/url"
 	"strings"
 	"time"
+
+	"golang.org/x/net/http/httpguts"
 )
 
 // ClientConfig holds the configuration for the AI client
@@ -20,12 +23,20 @@ type ClientConfig struct {
 	SystemPrompt  string
 	CustomHeaders string
 	Timeout       time.Duration
+	MaxTokens     int // Maximum output tokens; zero uses DefaultMaxTokens
+	// Fallbacks are tried in order when the endpoint above fails, see PreferredEndpointTTL
+	Fallbacks []Endpoint
 }
 
+// DefaultMaxTokens is the output token limit used when a feature doesn't configure one
+const DefaultMaxTokens = 2048
+
 // Client represents a universal AI client that supports multiple API formats
 type Client struct {
 	config ClientConfig
 	client *http.Client
+	sleep  func(context.Context, time.Duration) error // Waits between rate-limited attempts; replaced in tests
+	ctx    context.Context                            // Context of the requests, see WithContext
 }
 
 // NewClient creates a new universal AI client
@@ -37,6 +48,7 @@ func NewClient(config ClientConfig) *Client {
 	return &Client{
 		config: config,
 		client: &http.Client{Timeout: config.Timeout},
+		sleep:  sleepContext,
 	}
 }
 
@@ -45,9 +57,26 @@ func NewClientWithHTTPClient(config ClientConfig, httpClient *http.Client) *Clie
 	return &Client{
 		config: config,
 		client: httpClient,
+		sleep:  sleepContext,
 	}
 }
 
+// WithContext returns a copy of the client whose requests use ctx. Canceling ctx aborts
+// them, and log lines are tagged with the request ID it carries.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	clone := *c
+	clone.ctx = ctx
+	return &clone
+}
+
+// requestContext returns the context of the client's requests
+func (c *Client) requestContext() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
 // Request makes an AI request with automatic format detection and fallback
 func (c *Client) Request(systemPrompt, userPrompt string) (string, error) {
 	result, err := c.RequestWithThinking(systemPrompt, userPrompt)
@@ -64,8 +93,8 @@ func (c *Client) RequestWithThinking(systemPrompt, userPrompt string) (ResponseR
 		SystemPrompt: systemPrompt,
 		UserPrompt:   userPrompt,
 		Temperature:  0.3,
-		MaxTokens:    2048,
 	}
+	c.applyMaxTokens(&config)
 
 	return c.RequestWithConfig(config)
 }
@@ -76,70 +105,96 @@ func (c *Client) RequestWithMessages(messages []map[string]string) (ResponseResu
 		Model:       c.config.Model,
 		Messages:    messages,
 		Temperature: 0.3,
-		MaxTokens:   2048,
 	}
+	c.applyMaxTokens(&config)
 
 	return c.RequestWithConfig(config)
 }
 
-// RequestWithConfig makes an AI request with full configuration
-func (c *Client) RequestWithConfig(config RequestConfig) (ResponseResult, error) {
-	provider := DetectAPIProvider(c.config.Endpoint)
+// applyMaxTokens sets the output limit on config. Reasoning models reject max_tokens on
+// OpenAI-style APIs, so for them the limit is also sent as max_completion_tokens.
+func (c *Client) applyMaxTokens(config *RequestConfig) {
+	maxTokens := c.config.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = DefaultMaxTokens
+	}
+	config.MaxTokens = maxTokens
+	if IsReasoningModel(config.Model) {
+		config.MaxCompletionTokens = maxTokens
+	}
+}
 
-	// Try provider-specific format first based on endpoint detection
-	switch provider {
-	case "gemini":
-		result, err := c.tryFormat(NewGeminiHandler(), config)
-		if err == nil {
-			return result, nil
+// IsReasoningModel reports whether model is an OpenAI reasoning model (o-series, gpt-5)
+// that only accepts max_completion_tokens
+func IsReasoningModel(model string) bool {
+	m := strings.ToLower(model)
+	if i := strings.LastIndex(m, "/"); i >= 0 {
+		m = m[i+1:]
+	}
+	for _, prefix := range []string{"o1", "o3", "o4", "gpt-5"} {
+		if m == prefix || strings.HasPrefix(m, prefix+"-") {
+			return true
 		}
-		// Fall through to other formats
+	}
+	return false
+}
 
-	case "anthropic":
-		result, err := c.tryFormat(&AnthropicHandler{}, config)
-		if err == nil {
-			return result, nil
-		}
-		// Fall through to other formats
+// RequestWithConfig makes an AI request with full configuration. When the endpoint fails
+// and fallback endpoints are configured, they are tried in order.
+func (c *Client) RequestWithConfig(config RequestConfig) (ResponseResult, error) {
+	if len(c.config.Fallbacks) > 0 {
+		return c.requestWithFallbacks(config)
+	}
+	return c.requestAllFormats(config)
+}
 
-	case "deepseek":
-		result, err := c.tryFormat(&DeepSeekHandler{}, config)
+// requestAllFormats makes an AI request to the client's endpoint, trying the detected
+// API format first and the other formats after it
+func (c *Client) requestAllFormats(config RequestConfig) (ResponseResult, error) {
+	for _, handler := range c.formatOrder(DetectAPIProvider(c.config.Endpoint)) {
+		result, err := c.tryFormat(handler, config)
 		if err == nil {
 			return result, nil
 		}
-		// Fall through to other formats
-
-	case "ollama":
-		result, err := c.tryFormat(NewOllamaHandler(), config)
-		if err == nil {
-			return result, nil
+		// Rate limiting means the endpoint understood the format; other formats won't help
+		if IsRateLimitError(err) {
+			return ResponseResult{}, err
+		}
+		// A canceled request fails every format the same way
+		if c.requestContext().Err() != nil {
+			return ResponseResult{}, err
 		}
-		// Fall through to other formats
 	}
 
-	// Try OpenAI format (most common, good fallback)
-	result, err := c.tryFormat(NewOpenAIHandler(), config)
-	if err == nil {
-		return result, nil
+	// All formats failed
+	return ResponseResult{}, fmt.Errorf("all API formats failed")
+}
+
+// formatOrder returns the handlers requestAllFormats tries, in order: the provider-specific
+// format based on endpoint detection, then OpenAI (most common, good fallback), then the
+// remaining formats
+func (c *Client) formatOrder(provider string) []FormatHandler {
+	var handlers []FormatHandler
+	switch provider {
+	case "gemini":
+		handlers = append(handlers, NewGeminiHandler())
+	case "anthropic":
+		handlers = append(handlers, &AnthropicHandler{})
+	case "deepseek":
+		handlers = append(handlers, &DeepSeekHandler{})
+	case "ollama":
+		handlers = append(handlers, NewOllamaHandler())
 	}
 
-	// Try other formats as fallback
+	handlers = append(handlers, c.openAICompatibleHandler())
+
 	if provider != "gemini" {
-		result, err = c.tryFormat(NewGeminiHandler(), config)
-		if err == nil {
-			return result, nil
-		}
+		handlers = append(handlers, NewGeminiHandler())
 	}
-
 	if provider != "ollama" {
-		result, err = c.tryFormat(NewOllamaHandler(), config)
-		if err == nil {
-			return result, nil
-		}
+		handlers = append(handlers, NewOllamaHandler())
 	}
-
-	// All formats failed
-	return ResponseResult{}, fmt.Errorf("all API formats failed")
+	return handlers
 }
 
 // tryFormat attempts to make a request using a specific format handler
@@ -158,14 +213,13 @@ func (c *Client) tryFormat(handler FormatHandler, config RequestConfig) (Respons
 	// Format endpoint
 	formattedEndpoint := handler.FormatEndpoint(c.config.Endpoint, c.config.Model)
 
-	// Special handling for Ollama: use /api/chat if messages are provided
-	if _, ok := handler.(*OllamaHandler); ok && len(config.Messages) > 0 {
-		// Replace /api/generate with /api/chat for message-based requests
-		formattedEndpoint = strings.Replace(formattedEndpoint, "/api/generate", "/api/chat", 1)
+	// Special handling for Ollama: the endpoint depends on whether messages are provided
+	if oh, ok := handler.(*OllamaHandler); ok {
+		formattedEndpoint = oh.FormatEndpointForRequest(c.config.Endpoint, len(config.Messages) > 0)
 	}
 
-	// Send request with formatted endpoint and handler
-	resp, err := c.sendRequestToEndpointWithHandler(jsonBody, formattedEndpoint, handler)
+	// Send request with formatted endpoint and handler, waiting out rate limits
+	resp, err := c.sendWithRateLimitRetry(jsonBody, formattedEndpoint, handler)
 	if err != nil {
 		return ResponseResult{}, fmt.Errorf("request failed: %w", err)
 	}
@@ -212,8 +266,12 @@ func (c *Client) sendRequestToEndpointWithHandler(jsonBody []byte, apiURL string
 		return nil, fmt.Errorf("API endpoint must use HTTP or HTTPS")
 	}
 
-	// Check if this is a Gemini endpoint that needs API key in URL
+	// Check if this is a Gemini endpoint that needs API key in URL. A request in Gemini
+	// format goes to a Gemini-compatible API even when the URL doesn't look like one, but
+	// that may be a fallback attempt against another provider, so the key then goes in the
+	// x-goog-api-key header rather than the URL.
 	isGeminiEndpoint := IsGeminiEndpoint(apiURL)
+	_, isGeminiFormat := handler.(*GeminiHandler)
 
 	// For Gemini API, add API key as URL query parameter instead of Authorization header
 	if isGeminiEndpoint && c.config.APIKey != "" {
@@ -224,7 +282,7 @@ func (c *Client) sendRequestToEndpointWithHandler(jsonBody []byte, apiURL string
 		apiURL = parsedURL.String()
 	}
 
-	req, err := http.NewRequest("POST", apiURL, bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequestWithContext(c.requestContext(), "POST", apiURL, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -244,8 +302,12 @@ func (c *Client) sendRequestToEndpointWithHandler(jsonBody []byte, apiURL string
 		} else {
 			// Use default headers
 			req.Header.Set("Content-Type", "application/json")
-			// For non-Gemini endpoints, use Authorization header
-			if !isGeminiEndpoint {
+			if isGeminiFormat && !isGeminiEndpoint {
+				if c.config.APIKey != "" {
+					req.Header.Set("x-goog-api-key", c.config.APIKey)
+				}
+			} else if !isGeminiEndpoint {
+				// For non-Gemini endpoints, use Authorization header
 				// Only add Authorization header if API key is provided
 				if c.config.APIKey != "" {
 					req.Header.Set("Authorization", "Bearer "+c.config.APIKey)
@@ -266,7 +328,7 @@ func (c *Client) sendRequestToEndpointWithHandler(jsonBody []byte, apiURL string
 
 	// Parse and add custom headers if provided
 	if c.config.CustomHeaders != "" {
-		customHeaders, err := parseCustomHeaders(c.config.CustomHeaders)
+		customHeaders, err := ValidateCustomHeaders(c.config.CustomHeaders)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse custom headers: %w", err)
 		}
@@ -279,20 +341,73 @@ func (c *Client) sendRequestToEndpointWithHandler(jsonBody []byte, apiURL string
 	return c.client.Do(req)
 }
 
-// parseCustomHeaders parses the JSON string of custom headers into a map
-func parseCustomHeaders(headersJSON string) (map[string]string, error) {
-	// Return empty map if headers string is empty
-	if headersJSON == "" {
-		return make(map[string]string), nil
+// forbiddenCustomHeaders are set by the HTTP client itself and can't be overridden
+var forbiddenCustomHeaders = map[string]bool{
+	"Host":              true,
+	"Content-Length":    true,
+	"Transfer-Encoding": true,
+	"Connection":        true,
+	"Keep-Alive":        true,
+	"Proxy-Connection":  true,
+	"Te":                true,
+	"Trailer":           true,
+	"Upgrade":           true,
+}
+
+// ValidateCustomHeaders parses the ai_custom_headers setting, a JSON object of header names
+// to string values, and returns the headers. An empty setting has no headers. Nested values,
+// invalid names or values and headers the HTTP client manages are rejected.
+func ValidateCustomHeaders(headersJSON string) (map[string]string, error) {
+	headers := make(map[string]string)
+	if strings.TrimSpace(headersJSON) == "" {
+		return headers, nil
 	}
 
-	var headers map[string]string
-	if err := json.Unmarshal([]byte(headersJSON), &headers); err != nil {
+	var raw map[string]interface{}
+	if err := json.Unmarshal([]byte(headersJSON), &raw); err != nil {
 		return nil, fmt.Errorf("failed to parse custom headers JSON: %w", err)
 	}
+	if raw == nil {
+		return nil, fmt.Errorf("custom headers must be a JSON object")
+	}
+
+	for name, value := range raw {
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("custom header %q must have a string value, got %s", name, jsonTypeName(value))
+		}
+		if !httpguts.ValidHeaderFieldName(name) {
+			return nil, fmt.Errorf("invalid custom header name %q", name)
+		}
+		if forbiddenCustomHeaders[http.CanonicalHeaderKey(name)] {
+			return nil, fmt.Errorf("custom header %q is managed by the HTTP client and can't be set", name)
+		}
+		if !httpguts.ValidHeaderFieldValue(str) {
+			return nil, fmt.Errorf("custom header %q has an invalid value", name)
+		}
+		headers[name] = str
+	}
 	return headers, nil
 }
 
+// jsonTypeName names the JSON type of a decoded value, for error messages
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "a boolean"
+	case float64:
+		return "a number"
+	case []interface{}:
+		return "an array"
+	case map[string]interface{}:
+		return "an object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
 // ExtractThinking extracts thinking content from <thinking> tags (case-insensitive)
 func ExtractThinking(content string) string {
 	tagVariations := []struct {
@@ -0,0 +1,214 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestIsReasoningModel(t *testing.T) {
+	for model, want := range map[string]bool{
+		"o1":                 true,
+		"o3-mini":            true,
+		"openai/o4-mini":     true,
+		"gpt-5":              true,
+		"gpt-5-mini":         true,
+		"gpt-4o-mini":        false,
+		"claude-3-haiku":     false,
+		"deepseek-reasoner":  false,
+		"gpt-4.1-2025-04-14": false,
+	} {
+		if got := IsReasoningModel(model); got != want {
+			t.Errorf("IsReasoningModel(%q) = %v, want %v", model, got, want)
+		}
+	}
+}
+
+func TestClientMaxTokens(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body = nil
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"ok"}}]}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(ClientConfig{Endpoint: srv.URL, Model: "gpt-4o-mini", MaxTokens: 4096})
+	if _, err := client.RequestWithThinking("", "hi"); err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if body["max_tokens"] != float64(4096) || body["max_completion_tokens"] != nil {
+		t.Fatalf("unexpected token fields: %v", body)
+	}
+
+	client = NewClient(ClientConfig{Endpoint: srv.URL, Model: "o3-mini"})
+	if _, err := client.RequestWithMessages([]map[string]string{{"role": "user", "content": "hi"}}); err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if body["max_completion_tokens"] != float64(DefaultMaxTokens) || body["max_tokens"] != nil {
+		t.Fatalf("expected max_completion_tokens for reasoning model: %v", body)
+	}
+}
+
+func TestClientWithContext(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"ok"}}]}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(ClientConfig{Endpoint: srv.URL, Model: "gpt-4o-mini"})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.WithContext(ctx).Request("", "hi"); err == nil || requests != 0 {
+		t.Fatalf("expected a canceled context to stop the request, got err %v after %d requests", err, requests)
+	}
+
+	// The original client keeps its own context
+	if _, err := client.Request("", "hi"); err != nil || requests == 0 {
+		t.Fatalf("expected the original client to still work, got err %v after %d requests", err, requests)
+	}
+}
+
+func TestClientAnthropicHeaders(t *testing.T) {
+	var headers http.Header
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"content":[{"type":"text","text":"ok"}]}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(ClientConfig{Endpoint: srv.URL, APIKey: "sk-ant-test", Model: "claude-3-haiku"})
+	result, err := client.tryFormat(&AnthropicHandler{}, RequestConfig{Model: "claude-3-haiku", UserPrompt: "hi"})
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if result.Content != "ok" || path != "/v1/messages" {
+		t.Errorf("unexpected result %q from %s", result.Content, path)
+	}
+	if headers.Get("x-api-key") != "sk-ant-test" || headers.Get("anthropic-version") != "2023-06-01" {
+		t.Errorf("expected Anthropic auth headers, got %v", headers)
+	}
+	if auth := headers.Get("Authorization"); auth != "" {
+		t.Errorf("expected no Bearer header, got %q", auth)
+	}
+}
+
+func TestClientGeminiAPIKey(t *testing.T) {
+	var headers http.Header
+	var query url.Values
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		query = r.URL.Query()
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"candidates":[{"content":{"parts":[{"text":"ok"}]}}]}`))
+	}))
+	defer srv.Close()
+
+	// Neither the proxy URL nor the model name look like Gemini, so only the handler tells
+	client := NewClient(ClientConfig{Endpoint: srv.URL + "/v1beta/models", APIKey: "gemini-key", Model: "gemma-3-27b-it"})
+	result, err := client.tryFormat(NewGeminiHandler(), RequestConfig{Model: "gemma-3-27b-it", UserPrompt: "hi"})
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if result.Content != "ok" || path != "/v1beta/models/gemma-3-27b-it:generateContent" {
+		t.Errorf("unexpected result %q from %s", result.Content, path)
+	}
+	// The URL may belong to another provider, so the key stays out of it
+	if query.Has("key") {
+		t.Errorf("expected no key in the query of a non-Gemini URL, got %v", query)
+	}
+	if got := headers.Get("x-goog-api-key"); got != "gemini-key" {
+		t.Errorf("expected the key in x-goog-api-key, got %q", got)
+	}
+	if auth := headers.Get("Authorization"); auth != "" {
+		t.Errorf("expected no Bearer header, got %q", auth)
+	}
+
+	// A Gemini URL keeps the key as a query parameter
+	geminiURL := srv.URL + "/gemini/v1beta/models"
+	client = NewClient(ClientConfig{Endpoint: geminiURL, APIKey: "gemini-key", Model: "gemini-2.0-flash"})
+	if _, err := client.tryFormat(NewGeminiHandler(), RequestConfig{Model: "gemini-2.0-flash", UserPrompt: "hi"}); err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	if query.Get("key") != "gemini-key" || headers.Get("x-goog-api-key") != "" {
+		t.Errorf("expected only the query key for a Gemini URL, got query %v and header %q", query, headers.Get("x-goog-api-key"))
+	}
+}
+
+func TestClientOllamaEndpoint(t *testing.T) {
+	var path string
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		body = nil
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/chat" {
+			w.Write([]byte(`{"message":{"role":"assistant","content":"ok"},"done":true}`))
+			return
+		}
+		w.Write([]byte(`{"response":"ok","done":true}`))
+	}))
+	defer srv.Close()
+
+	// Whichever path is configured, the request type picks the endpoint
+	for _, endpoint := range []string{srv.URL, srv.URL + "/api/generate", srv.URL + "/api/chat/"} {
+		client := NewClient(ClientConfig{Endpoint: endpoint, Model: "llama3"})
+
+		messages := []map[string]string{{"role": "system", "content": "be brief"}, {"role": "user", "content": "hi"}}
+		if _, err := client.tryFormat(NewOllamaHandler(), RequestConfig{Model: "llama3", Messages: messages}); err != nil {
+			t.Fatalf("%s: chat request: %v", endpoint, err)
+		}
+		if _, ok := body["messages"]; path != "/api/chat" || !ok {
+			t.Errorf("%s: expected messages posted to /api/chat, got %s with %v", endpoint, path, body)
+		}
+
+		if _, err := client.tryFormat(NewOllamaHandler(), RequestConfig{Model: "llama3", UserPrompt: "hi"}); err != nil {
+			t.Fatalf("%s: generate request: %v", endpoint, err)
+		}
+		if _, ok := body["prompt"]; path != "/api/generate" || !ok {
+			t.Errorf("%s: expected a prompt posted to /api/generate, got %s with %v", endpoint, path, body)
+		}
+	}
+}
+
+func TestValidateCustomHeaders(t *testing.T) {
+	headers, err := ValidateCustomHeaders(`{"X-Api-Version": "2", "HTTP-Referer": "https://example.com"}`)
+	if err != nil {
+		t.Fatalf("ValidateCustomHeaders: %v", err)
+	}
+	if len(headers) != 2 || headers["X-Api-Version"] != "2" {
+		t.Errorf("unexpected headers %v", headers)
+	}
+	if headers, err := ValidateCustomHeaders("  "); err != nil || len(headers) != 0 {
+		t.Errorf("expected no headers for an empty setting, got %v, %v", headers, err)
+	}
+
+	for _, invalid := range []string{
+		`not json`,
+		`null`,
+		`["X-A", "b"]`,
+		`{"X-Nested": {"a": "b"}}`,
+		`{"X-Number": 1}`,
+		`{"Bad Name": "x"}`,
+		`{"X-Newline": "a\nb"}`,
+		`{"host": "evil.example.com"}`,
+		`{"Content-Length": "10"}`,
+	} {
+		if _, err := ValidateCustomHeaders(invalid); err == nil {
+			t.Errorf("expected %s to be rejected", invalid)
+		}
+	}
+}
@@ -144,6 +144,11 @@ func (h *DeepSeekHandler) ParseResponse(body []byte) (ResponseResult, error) {
 	result := ResponseResult{
 		Content:    content,
 		FormatUsed: FormatTypeDeepSeek,
+		Usage: Usage{
+			PromptTokens:     response.Usage.PromptTokens,
+			CompletionTokens: response.Usage.CompletionTokens,
+			TotalTokens:      response.Usage.TotalTokens,
+		},
 	}
 
 	// DeepSeek doesn't have separate thinking content in standard mode
@@ -0,0 +1,171 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"MrRSS/internal/utils"
+)
+
+// Endpoint is an alternative AI service tried, in order, when the configured one fails
+type Endpoint struct {
+	Endpoint string `json:"endpoint"`
+	APIKey   string `json:"api_key"`
+	Model    string `json:"model"` // Empty uses the configured model
+}
+
+// PreferredEndpointTTL is how long a fallback endpoint that answered after the configured
+// one failed keeps being tried first, before the configured endpoint gets another chance
+const PreferredEndpointTTL = 10 * time.Minute
+
+// ParseEndpoints parses the ai_endpoints setting, a JSON array of endpoints. Entries
+// without an endpoint URL are dropped.
+func ParseEndpoints(value string) ([]Endpoint, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var endpoints []Endpoint
+	if err := json.Unmarshal([]byte(value), &endpoints); err != nil {
+		return nil, fmt.Errorf("invalid AI endpoints list: %w", err)
+	}
+	valid := endpoints[:0]
+	for _, ep := range endpoints {
+		ep.Endpoint = strings.TrimSuffix(strings.TrimSpace(ep.Endpoint), "/")
+		if ep.Endpoint == "" {
+			continue
+		}
+		valid = append(valid, ep)
+	}
+	return valid, nil
+}
+
+// EncryptedSettings reads encrypted settings, such as the database
+type EncryptedSettings interface {
+	GetEncryptedSetting(key string) (string, error)
+}
+
+// LoadEndpoints returns the fallback endpoints from the ai_endpoints setting. An invalid
+// list is logged and ignored, so AI features keep working with the configured endpoint.
+func LoadEndpoints(settings EncryptedSettings) []Endpoint {
+	value, _ := settings.GetEncryptedSetting("ai_endpoints")
+	endpoints, err := ParseEndpoints(value)
+	if err != nil {
+		log.Printf("Ignoring AI fallback endpoints: %v", err)
+		return nil
+	}
+	return endpoints
+}
+
+// preferredEndpoint remembers which endpoint of a chain last answered
+type preferredEndpoint struct {
+	index int
+	until time.Time
+}
+
+// preferredEndpoints is shared by all clients, since a client is usually built per request
+var preferredEndpoints = struct {
+	sync.Mutex
+	byChain map[string]preferredEndpoint
+}{byChain: make(map[string]preferredEndpoint)}
+
+// endpointChain returns the configured endpoint followed by its fallbacks
+func (c *Client) endpointChain() []Endpoint {
+	chain := []Endpoint{{Endpoint: c.config.Endpoint, APIKey: c.config.APIKey, Model: c.config.Model}}
+	for _, ep := range c.config.Fallbacks {
+		if ep.Model == "" {
+			ep.Model = c.config.Model
+		}
+		chain = append(chain, ep)
+	}
+	return chain
+}
+
+// chainKey identifies a chain by its endpoints and models; keys never leave the process
+func chainKey(chain []Endpoint) string {
+	var b strings.Builder
+	for _, ep := range chain {
+		b.WriteString(ep.Endpoint)
+		b.WriteByte('\x00')
+		b.WriteString(ep.Model)
+		b.WriteByte('\x00')
+	}
+	return b.String()
+}
+
+// attemptOrder returns the order in which the chain's endpoints are tried: the last one
+// that worked first while it is remembered, then the rest in configured order
+func attemptOrder(key string, size int, now time.Time) []int {
+	preferredEndpoints.Lock()
+	pref, ok := preferredEndpoints.byChain[key]
+	if ok && !now.Before(pref.until) {
+		delete(preferredEndpoints.byChain, key)
+		ok = false
+	}
+	preferredEndpoints.Unlock()
+
+	order := make([]int, 0, size)
+	if ok && pref.index < size {
+		order = append(order, pref.index)
+	}
+	for i := 0; i < size; i++ {
+		if !ok || i != pref.index {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// rememberEndpoint records the endpoint that answered. The configured endpoint needs no
+// record, since it is tried first anyway.
+func rememberEndpoint(key string, index int, now time.Time) {
+	preferredEndpoints.Lock()
+	defer preferredEndpoints.Unlock()
+	if index == 0 {
+		delete(preferredEndpoints.byChain, key)
+		return
+	}
+	preferredEndpoints.byChain[key] = preferredEndpoint{index: index, until: now.Add(PreferredEndpointTTL)}
+}
+
+// requestWithFallbacks sends the request to the configured endpoint and, when it fails,
+// to each fallback endpoint in turn
+func (c *Client) requestWithFallbacks(config RequestConfig) (ResponseResult, error) {
+	chain := c.endpointChain()
+	key := chainKey(chain)
+
+	var lastErr error
+	for _, i := range attemptOrder(key, len(chain), time.Now()) {
+		ep := chain[i]
+		alt := *c
+		alt.config.Endpoint = ep.Endpoint
+		alt.config.APIKey = ep.APIKey
+		alt.config.Model = ep.Model
+
+		attempt := config
+		if attempt.Model == c.config.Model && ep.Model != c.config.Model {
+			// The output limit parameter depends on the model, see applyMaxTokens
+			attempt.Model = ep.Model
+			attempt.MaxCompletionTokens = 0
+			if IsReasoningModel(ep.Model) {
+				attempt.MaxCompletionTokens = attempt.MaxTokens
+			}
+		}
+
+		result, err := alt.requestAllFormats(attempt)
+		if err == nil {
+			rememberEndpoint(key, i, time.Now())
+			return result, nil
+		}
+		if c.requestContext().Err() != nil {
+			return ResponseResult{}, err
+		}
+		lastErr = fmt.Errorf("%s: %w", ep.Endpoint, err)
+		utils.ContextLog(c.requestContext(), "AI endpoint %s failed: %v", ep.Endpoint, err)
+	}
+	return ResponseResult{}, fmt.Errorf("all AI endpoints failed, last error: %w", lastErr)
+}
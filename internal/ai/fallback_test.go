@@ -0,0 +1,129 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseEndpoints(t *testing.T) {
+	endpoints, err := ParseEndpoints(`[
+		{"endpoint": "https://backup.example.com/v1/chat/completions/", "api_key": "k", "model": "m"},
+		{"endpoint": "  ", "api_key": "dropped"}
+	]`)
+	if err != nil {
+		t.Fatalf("ParseEndpoints: %v", err)
+	}
+	if len(endpoints) != 1 || endpoints[0].Endpoint != "https://backup.example.com/v1/chat/completions" || endpoints[0].APIKey != "k" {
+		t.Fatalf("unexpected endpoints %+v", endpoints)
+	}
+
+	if endpoints, err := ParseEndpoints(""); err != nil || endpoints != nil {
+		t.Errorf("expected no endpoints for an empty setting, got %v, %v", endpoints, err)
+	}
+	if _, err := ParseEndpoints("not json"); err == nil {
+		t.Error("expected an error for an invalid list")
+	}
+}
+
+func TestClientFallsBackToNextEndpoint(t *testing.T) {
+	var primaryCalls int32
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&primaryCalls, 1)
+		http.Error(w, `{"error":"rate limited"}`, http.StatusTooManyRequests)
+	}))
+	defer primary.Close()
+
+	var backupModel string
+	backup := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		backupModel, _ = body["model"].(string)
+		if r.Header.Get("Authorization") != "Bearer backup-key" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"from backup"}}]}`))
+	}))
+	defer backup.Close()
+
+	client := NewClient(ClientConfig{
+		Endpoint:  primary.URL,
+		APIKey:    "primary-key",
+		Model:     "primary-model",
+		Fallbacks: []Endpoint{{Endpoint: backup.URL, APIKey: "backup-key", Model: "cheap-model"}},
+	})
+	client.sleep = func(context.Context, time.Duration) error { return nil } // The primary's rate limit is retried before falling back
+	key := chainKey(client.endpointChain())
+	defer rememberEndpoint(key, 0, time.Now())
+
+	got, err := client.Request("", "hi")
+	if err != nil {
+		t.Fatalf("expected the backup to answer, got %v", err)
+	}
+	if got != "from backup" || backupModel != "cheap-model" {
+		t.Errorf("expected the backup's model and answer, got %q from %q", got, backupModel)
+	}
+	callsAfterFirst := atomic.LoadInt32(&primaryCalls)
+	if callsAfterFirst == 0 {
+		t.Fatal("expected the primary endpoint to be tried first")
+	}
+
+	// The working backup is tried first while it is remembered
+	if _, err := client.Request("", "again"); err != nil {
+		t.Fatalf("second request: %v", err)
+	}
+	if calls := atomic.LoadInt32(&primaryCalls); calls != callsAfterFirst {
+		t.Errorf("expected the primary to be skipped, got %d more calls", calls-callsAfterFirst)
+	}
+
+	// Once the memory expires the primary gets another chance
+	preferredEndpoints.Lock()
+	pref := preferredEndpoints.byChain[key]
+	pref.until = time.Now().Add(-time.Second)
+	preferredEndpoints.byChain[key] = pref
+	preferredEndpoints.Unlock()
+	if _, err := client.Request("", "later"); err != nil {
+		t.Fatalf("third request: %v", err)
+	}
+	if calls := atomic.LoadInt32(&primaryCalls); calls == callsAfterFirst {
+		t.Error("expected the primary to be retried after the TTL")
+	}
+}
+
+func TestClientFallbacksAllFail(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	client := NewClient(ClientConfig{
+		Endpoint:  failing.URL + "/primary",
+		Model:     "m",
+		Fallbacks: []Endpoint{{Endpoint: failing.URL + "/backup"}},
+	})
+	if _, err := client.Request("", "hi"); err == nil {
+		t.Fatal("expected an error when every endpoint fails")
+	}
+}
+
+func TestAttemptOrder(t *testing.T) {
+	now := time.Now()
+	key := "attempt-order-test"
+	if got := attemptOrder(key, 3, now); len(got) != 3 || got[0] != 0 || got[1] != 1 || got[2] != 2 {
+		t.Errorf("expected configured order, got %v", got)
+	}
+
+	rememberEndpoint(key, 2, now)
+	if got := attemptOrder(key, 3, now); len(got) != 3 || got[0] != 2 || got[1] != 0 || got[2] != 1 {
+		t.Errorf("expected the remembered endpoint first, got %v", got)
+	}
+	if got := attemptOrder(key, 3, now.Add(PreferredEndpointTTL)); got[0] != 0 {
+		t.Errorf("expected configured order after the TTL, got %v", got)
+	}
+}
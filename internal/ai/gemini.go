@@ -145,6 +145,11 @@ func (h *GeminiHandler) ParseResponse(body []byte) (ResponseResult, error) {
 		PromptFeedback struct {
 			BlockReason string `json:"blockReason,omitempty"`
 		} `json:"promptFeedback"`
+		UsageMetadata struct {
+			PromptTokenCount     int `json:"promptTokenCount"`
+			CandidatesTokenCount int `json:"candidatesTokenCount"`
+			TotalTokenCount      int `json:"totalTokenCount"`
+		} `json:"usageMetadata"`
 	}
 
 	if err := json.Unmarshal(body, &response); err != nil {
@@ -182,6 +187,11 @@ func (h *GeminiHandler) ParseResponse(body []byte) (ResponseResult, error) {
 	return ResponseResult{
 		Content:    content,
 		FormatUsed: FormatTypeGemini,
+		Usage: Usage{
+			PromptTokens:     response.UsageMetadata.PromptTokenCount,
+			CompletionTokens: response.UsageMetadata.CandidatesTokenCount,
+			TotalTokens:      response.UsageMetadata.TotalTokenCount,
+		},
 	}, nil
 }
 
@@ -205,7 +215,7 @@ func (h *GeminiHandler) FormatEndpoint(endpoint, model string) string {
 }
 
 // DetectAPIProvider detects the AI provider from the endpoint URL
-// Returns "gemini", "openai", "anthropic", "deepseek", "ollama", or "unknown"
+// Returns "gemini", "anthropic", "deepseek", "ollama", or "openai" for any other host
 func DetectAPIProvider(endpoint string) string {
 	endpoint = strings.ToLower(endpoint)
 
@@ -235,13 +245,10 @@ func DetectAPIProvider(endpoint string) string {
 		return "ollama"
 	}
 
-	// OpenAI-compatible endpoints (default)
-	if strings.Contains(endpoint, "openai.com") ||
-		strings.Contains(endpoint, "api.openai.com") {
-		return "openai"
-	}
-
-	return "unknown"
+	// Everything else is treated as OpenAI-compatible, the format most providers accept
+	// (OpenAI, Azure, Mistral, Groq, Together, ...). Requests go through GenericHandler,
+	// which picks the auth header from CompatibleProvider and the custom headers.
+	return "openai"
 }
 
 // IsGeminiEndpoint checks if the given endpoint is a Gemini API endpoint
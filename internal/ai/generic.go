@@ -0,0 +1,93 @@
+// Package ai provides a generic handler for OpenAI-compatible APIs
+package ai
+
+import (
+	"net/http"
+	"strings"
+)
+
+// compatibleProviders maps host fragments of OpenAI-compatible APIs to provider hints
+var compatibleProviders = []struct {
+	host     string
+	provider string
+}{
+	{"openai.azure.com", "azure"},
+	{"mistral.ai", "mistral"},
+	{"groq.com", "groq"},
+	{"together.xyz", "together"},
+	{"together.ai", "together"},
+	{"cohere.ai", "cohere"},
+	{"cohere.com", "cohere"},
+	{"openrouter.ai", "openrouter"},
+}
+
+// providerAuthHeaders are the API key headers of providers that don't use Bearer auth
+var providerAuthHeaders = map[string]string{
+	"azure": "api-key",
+}
+
+// authHeaderNames are headers that carry credentials. When custom headers set one of
+// them, the API key is not added again in the default header.
+var authHeaderNames = []string{"Authorization", "Api-Key", "X-Api-Key"}
+
+// CompatibleProvider returns the hint for an OpenAI-compatible provider recognized by
+// its endpoint URL, or "" for other hosts
+func CompatibleProvider(endpoint string) string {
+	endpoint = strings.ToLower(endpoint)
+	for _, p := range compatibleProviders {
+		if strings.Contains(endpoint, p.host) {
+			return p.provider
+		}
+	}
+	return ""
+}
+
+// GenericHandler implements FormatHandler for OpenAI-compatible APIs that differ from
+// OpenAI in how they authenticate. Requests and responses are OpenAI-shaped; the API key
+// goes in the provider's auth header, unless the custom headers already authenticate.
+type GenericHandler struct {
+	OpenAIHandler
+	Provider string            // Provider hint, see CompatibleProvider
+	Headers  map[string]string // Custom headers configured for the endpoint
+}
+
+// NewGenericHandler creates a handler for an OpenAI-compatible provider
+func NewGenericHandler(provider string, headers map[string]string) *GenericHandler {
+	return &GenericHandler{Provider: provider, Headers: headers}
+}
+
+// GetRequiredHeaders returns the content type and auth headers for the provider. The
+// custom headers themselves are applied to every request after these.
+func (h *GenericHandler) GetRequiredHeaders(apiKey string) map[string]string {
+	headers := map[string]string{"Content-Type": "application/json"}
+	if apiKey == "" || h.hasAuthHeader() {
+		return headers
+	}
+
+	if name, ok := providerAuthHeaders[h.Provider]; ok {
+		headers[name] = apiKey
+	} else {
+		headers["Authorization"] = "Bearer " + apiKey
+	}
+	return headers
+}
+
+// hasAuthHeader reports whether the custom headers carry credentials
+func (h *GenericHandler) hasAuthHeader() bool {
+	for name := range h.Headers {
+		canonical := http.CanonicalHeaderKey(name)
+		for _, auth := range authHeaderNames {
+			if canonical == auth {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// openAICompatibleHandler returns the handler for the client's endpoint in OpenAI format.
+// Invalid custom headers are ignored here; sending the request reports them.
+func (c *Client) openAICompatibleHandler() *GenericHandler {
+	headers, _ := ValidateCustomHeaders(c.config.CustomHeaders)
+	return NewGenericHandler(CompatibleProvider(c.config.Endpoint), headers)
+}
@@ -0,0 +1,65 @@
+package ai
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestGenericHandlerAuth(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"ok"}}]}`))
+	}))
+	defer srv.Close()
+	target, _ := url.Parse(srv.URL)
+
+	cases := []struct {
+		name, endpoint, headers string
+		wantHeader, wantValue   string
+		noBearer                bool
+	}{
+		{"unknown host uses Bearer", "https://api.example.com/v1/chat/completions", "", "Authorization", "Bearer secret", false},
+		{"mistral uses Bearer", "https://api.mistral.ai/v1/chat/completions", "", "Authorization", "Bearer secret", false},
+		{"azure uses api-key", "https://res.openai.azure.com/openai/deployments/gpt/chat/completions", "", "Api-Key", "secret", true},
+		{"custom auth header wins", "https://api.example.com/v1/chat/completions", `{"X-API-Key": "custom"}`, "X-Api-Key", "custom", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if provider := DetectAPIProvider(tc.endpoint); provider != "openai" {
+				t.Fatalf("expected %s to be detected as openai, got %s", tc.endpoint, provider)
+			}
+			client := NewClientWithHTTPClient(
+				ClientConfig{Endpoint: tc.endpoint, APIKey: "secret", Model: "m", CustomHeaders: tc.headers},
+				&http.Client{Transport: redirectTransport{target: target}},
+			)
+			if _, err := client.Request("", "hi"); err != nil {
+				t.Fatalf("Request: %v", err)
+			}
+			if v := got.Get(tc.wantHeader); v != tc.wantValue {
+				t.Errorf("expected %s %q, got %q", tc.wantHeader, tc.wantValue, v)
+			}
+			if tc.noBearer && got.Get("Authorization") != "" {
+				t.Errorf("expected no Authorization header, got %q", got.Get("Authorization"))
+			}
+		})
+	}
+}
+
+func TestCompatibleProvider(t *testing.T) {
+	cases := map[string]string{
+		"https://api.groq.com/openai/v1/chat/completions":      "groq",
+		"https://api.together.xyz/v1/chat/completions":         "together",
+		"https://res.openai.azure.com/openai/deployments/x":    "azure",
+		"https://api.openai.com/v1/chat/completions":           "",
+		"https://llm.internal.example.com/v1/chat/completions": "",
+	}
+	for endpoint, want := range cases {
+		if got := CompatibleProvider(endpoint); got != want {
+			t.Errorf("CompatibleProvider(%q) = %q, want %q", endpoint, got, want)
+		}
+	}
+}
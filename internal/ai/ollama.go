@@ -91,8 +91,10 @@ func (h *OllamaHandler) ParseResponse(body []byte) (ResponseResult, error) {
 			Role    string `json:"role"`
 			Content string `json:"content"`
 		} `json:"message"`
-		Done  bool   `json:"done"`
-		Error string `json:"error,omitempty"`
+		Done            bool   `json:"done"`
+		Error           string `json:"error,omitempty"`
+		PromptEvalCount int    `json:"prompt_eval_count"`
+		EvalCount       int    `json:"eval_count"`
 	}
 
 	if err := json.Unmarshal(body, &chatResponse); err == nil && chatResponse.Message.Content != "" {
@@ -114,14 +116,17 @@ func (h *OllamaHandler) ParseResponse(body []byte) (ResponseResult, error) {
 		return ResponseResult{
 			Content:    content,
 			FormatUsed: FormatTypeOllama,
+			Usage:      Usage{PromptTokens: chatResponse.PromptEvalCount, CompletionTokens: chatResponse.EvalCount},
 		}, nil
 	}
 
 	// Fallback to generate response format (old format)
 	var generateResponse struct {
-		Response string `json:"response"`
-		Done     bool   `json:"done"`
-		Error    string `json:"error,omitempty"`
+		Response        string `json:"response"`
+		Done            bool   `json:"done"`
+		Error           string `json:"error,omitempty"`
+		PromptEvalCount int    `json:"prompt_eval_count"`
+		EvalCount       int    `json:"eval_count"`
 	}
 
 	if err := json.Unmarshal(body, &generateResponse); err != nil {
@@ -146,6 +151,7 @@ func (h *OllamaHandler) ParseResponse(body []byte) (ResponseResult, error) {
 	return ResponseResult{
 		Content:    content,
 		FormatUsed: FormatTypeOllama,
+		Usage:      Usage{PromptTokens: generateResponse.PromptEvalCount, CompletionTokens: generateResponse.EvalCount},
 	}, nil
 }
 
@@ -177,6 +183,20 @@ func (h *OllamaHandler) FormatEndpoint(endpoint, model string) string {
 	return endpoint
 }
 
+// FormatEndpointForRequest returns /api/chat for message-based requests and /api/generate
+// for prompt-based ones, whichever of the two the configured endpoint names, since
+// BuildRequest builds a different body for each
+func (h *OllamaHandler) FormatEndpointForRequest(endpoint string, useChat bool) string {
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	endpoint = strings.TrimSuffix(endpoint, "/api/generate")
+	endpoint = strings.TrimSuffix(endpoint, "/api/chat")
+
+	if useChat {
+		return endpoint + "/api/chat"
+	}
+	return endpoint + "/api/generate"
+}
+
 // IsOllamaError checks if an error message indicates an Ollama API format
 func IsOllamaError(errorMessage string) bool {
 	ollamaErrorPatterns := []string{
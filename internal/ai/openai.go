@@ -96,6 +96,11 @@ func (h *OpenAIHandler) ParseResponse(body []byte) (ResponseResult, error) {
 				Content string `json:"content"`
 			} `json:"message"`
 		} `json:"choices"`
+		Usage struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+			TotalTokens      int `json:"total_tokens"`
+		} `json:"usage"`
 		Error *struct {
 			Message string `json:"message"`
 			Type    string `json:"type"`
@@ -124,6 +129,7 @@ func (h *OpenAIHandler) ParseResponse(body []byte) (ResponseResult, error) {
 	return ResponseResult{
 		Content:    content,
 		FormatUsed: FormatTypeOpenAI,
+		Usage:      Usage(response.Usage),
 	}, nil
 }
 
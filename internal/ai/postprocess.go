@@ -0,0 +1,118 @@
+package ai
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Leading boilerplate that chatty models prepend despite "output only" instructions.
+// Patterns are matched case-insensitively at the start of the output. They only match
+// phrases about the answer itself, so real content like "Here are the results:" is kept.
+var defaultPreamblePatterns = []string{
+	`(?:(?:sure|certainly|of course|okay|ok)[,!.]?\s+)?here(?:'s| is| are)\s+(?:the|your|my|a)\s+(?:[^:\n]{0,40}\s)?(?:translation|translated\s+[^:\n]{0,20}|labels|tags)\s*:\s*`,
+	`(?:sure|certainly|of course)[,!.]\s*\n+`,
+}
+
+// Bare labels in front of a short answer. An article may well start with "Tags:", so
+// these only apply to short outputs.
+var shortPreamblePatterns = []string{
+	`(?:translation|translated text|translated title|labels|tags)\s*:\s*`,
+}
+
+// Notes appended after a short answer. Only a single short paragraph at the very end
+// matches, so everything before it is kept.
+var defaultTrailerPatterns = []string{
+	`\n\s*\n\s*\(?(?:note|notes|explanation)\s*:[^\n]{0,300}$`,
+	`\n+\s*(?:i hope this helps|let me know if)[^\n]{0,200}$`,
+}
+
+var (
+	patternCacheMu sync.Mutex
+	patternCache   = make(map[string]*regexp.Regexp)
+)
+
+// StripPreamble removes common model boilerplate such as "Here is the translation:"
+// or a trailing "Note: ..." from a short AI output, leaving the answer itself.
+func StripPreamble(output string) string {
+	return StripPreambleWithPatterns(output, nil)
+}
+
+// StripPreambleWithPatterns is StripPreamble with additional user-supplied leading
+// patterns. Each pattern is a regular expression matched case-insensitively at the
+// start of the output; invalid patterns are ignored. It is meant for short outputs
+// such as titles and snippets; use StripBodyPreamble for article bodies.
+func StripPreambleWithPatterns(output string, extra []string) string {
+	leading := append(append(append([]string{}, defaultPreamblePatterns...), shortPreamblePatterns...), extra...)
+	return stripBoilerplate(output, leading, defaultTrailerPatterns)
+}
+
+// StripBodyPreamble removes boilerplate from a long output such as a translated article.
+// Only a leading phrase about the answer and the extra patterns are removed; the text
+// itself, including any "Note:" paragraph, is kept as is.
+func StripBodyPreamble(output string, extra []string) string {
+	leading := append(append([]string{}, defaultPreamblePatterns...), extra...)
+	return stripBoilerplate(output, leading, nil)
+}
+
+// stripBoilerplate removes the leading patterns from the start of output and the trailer
+// patterns from its end
+func stripBoilerplate(output string, leading, trailers []string) string {
+	result := strings.TrimSpace(output)
+	if result == "" {
+		return result
+	}
+
+	// A model may stack several phrases ("Sure! Translation: ..."), so strip until stable
+	for i := 0; i < 3; i++ {
+		before := result
+		for _, p := range leading {
+			re := compilePattern(`(?i)^(?:` + p + `)`)
+			if re == nil {
+				continue
+			}
+			if loc := re.FindStringIndex(result); loc != nil && loc[1] < len(result) {
+				result = strings.TrimSpace(result[loc[1]:])
+			}
+		}
+		if result == before {
+			break
+		}
+	}
+
+	for _, p := range trailers {
+		re := compilePattern(`(?i)` + p)
+		if loc := re.FindStringIndex(result); loc != nil && loc[0] > 0 {
+			result = strings.TrimSpace(result[:loc[0]])
+		}
+	}
+
+	return result
+}
+
+// ParsePreamblePatterns splits a newline-separated setting value into patterns,
+// skipping blank lines
+func ParsePreamblePatterns(setting string) []string {
+	var patterns []string
+	for _, line := range strings.Split(setting, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			patterns = append(patterns, line)
+		}
+	}
+	return patterns
+}
+
+// compilePattern compiles and caches a pattern, returning nil if it is invalid
+func compilePattern(pattern string) *regexp.Regexp {
+	patternCacheMu.Lock()
+	defer patternCacheMu.Unlock()
+	if re, ok := patternCache[pattern]; ok {
+		return re
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		re = nil
+	}
+	patternCache[pattern] = re
+	return re
+}
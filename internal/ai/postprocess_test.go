@@ -0,0 +1,60 @@
+package ai
+
+import "testing"
+
+func TestStripPreamble(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain output", "Bonjour le monde", "Bonjour le monde"},
+		{"here is prefix", "Here is the translation: Bonjour le monde", "Bonjour le monde"},
+		{"sure here is", "Sure, here's the translated text:\nBonjour le monde", "Bonjour le monde"},
+		{"sure on own line", "Sure!\nBonjour le monde", "Bonjour le monde"},
+		{"translation label", "Translation: Bonjour", "Bonjour"},
+		{"trailing note", "Bonjour le monde\n\nNote: I kept the original tone.", "Bonjour le monde"},
+		{"trailing pleasantry", "Bonjour\nI hope this helps!", "Bonjour"},
+		{"keeps sentence starting with sure", "Sure enough, it rained.", "Sure enough, it rained."},
+		{"label only is kept", "Translation:", "Translation:"},
+		{"content list is kept", "Here are the results:\n- one\n- two", "Here are the results:\n- one\n- two"},
+		{"note before the end is kept", "Bonjour\n\nNote: important.\n\nSuite.", "Bonjour\n\nNote: important.\n\nSuite."},
+		{"note on the same paragraph is kept", "Bonjour\nNote: important.", "Bonjour\nNote: important."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripPreamble(tt.input); got != tt.want {
+				t.Errorf("StripPreamble(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripPreambleWithPatterns(t *testing.T) {
+	patterns := ParsePreamblePatterns("\n译文：\\s*\n[invalid\n")
+	if len(patterns) != 2 {
+		t.Fatalf("expected 2 patterns, got %v", patterns)
+	}
+	if got := StripPreambleWithPatterns("译文：你好", patterns); got != "你好" {
+		t.Errorf("got %q, want %q", got, "你好")
+	}
+}
+
+func TestStripBodyPreamble(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"preamble", "Here is the translation:\nTexte.\n\nNote: gardez ceci.", "Texte.\n\nNote: gardez ceci."},
+		{"trailing note is kept", "Texte.\n\nNote: gardez ceci.", "Texte.\n\nNote: gardez ceci."},
+		{"label is kept", "Tags: go, rust\n\nTexte.", "Tags: go, rust\n\nTexte."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripBodyPreamble(tt.input, nil); got != tt.want {
+				t.Errorf("StripBodyPreamble(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package ai
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ProbeResult reports whether an endpoint answered a test request in one API format
+type ProbeResult struct {
+	Format    FormatType `json:"format"`
+	Success   bool       `json:"success"`
+	LatencyMs int64      `json:"latency_ms"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// probeFormats lists the formats tried by ProbeFormats, in order
+var probeFormats = []struct {
+	format  FormatType
+	handler func(c *Client) FormatHandler
+}{
+	{FormatTypeOpenAI, func(c *Client) FormatHandler { return c.openAICompatibleHandler() }},
+	{FormatTypeAnthropic, func(c *Client) FormatHandler { return &AnthropicHandler{} }},
+	{FormatTypeGemini, func(c *Client) FormatHandler { return NewGeminiHandler() }},
+	{FormatTypeDeepSeek, func(c *Client) FormatHandler { return &DeepSeekHandler{} }},
+	{FormatTypeOllama, func(c *Client) FormatHandler { return NewOllamaHandler() }},
+}
+
+// ProbeFormats sends a minimal request to the endpoint in every supported API format
+// and reports which ones succeed. Unlike Request it does not stop at the first success,
+// so the caller can see every format the endpoint accepts. Error messages have the
+// API key redacted.
+func (c *Client) ProbeFormats() []ProbeResult {
+	config := RequestConfig{
+		Model:      c.config.Model,
+		UserPrompt: "test",
+		MaxTokens:  16,
+	}
+
+	results := make([]ProbeResult, 0, len(probeFormats))
+	for _, p := range probeFormats {
+		start := time.Now()
+		_, err := c.tryFormat(p.handler(c), config)
+		result := ProbeResult{
+			Format:    p.format,
+			Success:   err == nil,
+			LatencyMs: time.Since(start).Milliseconds(),
+		}
+		if err != nil {
+			result.Error = RedactAPIKey(err.Error(), c.config.APIKey)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// keyParamPattern matches API keys passed as URL query parameters (Gemini style)
+var keyParamPattern = regexp.MustCompile(`([?&](?:key|api_key|apikey)=)[^&\s"]+`)
+
+// RedactAPIKey removes an API key from text meant for logs or users, including
+// keys embedded in URLs as query parameters
+func RedactAPIKey(text, apiKey string) string {
+	if apiKey != "" {
+		text = strings.ReplaceAll(text, apiKey, "[REDACTED]")
+		if escaped := url.QueryEscape(apiKey); escaped != apiKey {
+			text = strings.ReplaceAll(text, escaped, "[REDACTED]")
+		}
+	}
+	return keyParamPattern.ReplaceAllString(text, "${1}[REDACTED]")
+}
@@ -0,0 +1,54 @@
+package ai
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestProbeFormats(t *testing.T) {
+	const apiKey = "sk-secret-123"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Only Gemini puts the model in the path; answer it with an error echoing the request
+		if strings.Contains(r.URL.Path, "generateContent") {
+			http.Error(w, "bad request for "+r.URL.String()+" with "+r.Header.Get("x-goog-api-key"), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"ok"}}]}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(ClientConfig{APIKey: apiKey, Endpoint: srv.URL, Model: "test-model"})
+	results := client.ProbeFormats()
+	if len(results) != len(probeFormats) {
+		t.Fatalf("expected %d probe results, got %d", len(probeFormats), len(results))
+	}
+
+	byFormat := map[FormatType]ProbeResult{}
+	for _, r := range results {
+		byFormat[r.Format] = r
+		if strings.Contains(r.Error, apiKey) {
+			t.Errorf("%s probe error leaks the API key: %s", r.Format, r.Error)
+		}
+	}
+	if !byFormat[FormatTypeOpenAI].Success {
+		t.Errorf("expected the OpenAI format to succeed: %+v", byFormat[FormatTypeOpenAI])
+	}
+	if byFormat[FormatTypeGemini].Success || byFormat[FormatTypeGemini].Error == "" {
+		t.Errorf("expected the Gemini format to fail with an error: %+v", byFormat[FormatTypeGemini])
+	}
+}
+
+func TestRedactAPIKey(t *testing.T) {
+	for in, want := range map[string]string{
+		"Bearer abc123 rejected":                 "Bearer [REDACTED] rejected",
+		"POST https://x.test/v1?key=other&alt=1": "POST https://x.test/v1?key=[REDACTED]&alt=1",
+		"no secrets here":                        "no secrets here",
+	} {
+		if got := RedactAPIKey(in, "abc123"); got != want {
+			t.Errorf("RedactAPIKey(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
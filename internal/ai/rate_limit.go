@@ -0,0 +1,105 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"MrRSS/internal/utils"
+)
+
+// RateLimitRetries is how many times a request answered with 429 Too Many Requests is
+// sent again in the same format before giving up
+const RateLimitRetries = 2
+
+// rateLimitBaseDelay is the first wait when the endpoint doesn't send Retry-After; it
+// doubles on each retry
+const rateLimitBaseDelay = time.Second
+
+// maxRateLimitWait caps how long a Retry-After is honored. An endpoint asking for a longer
+// wait fails the request instead of blocking the feature that made it.
+const maxRateLimitWait = 30 * time.Second
+
+// RateLimitError is returned when an endpoint is still rate limiting after the retries
+type RateLimitError struct {
+	RetryAfter time.Duration // The wait the endpoint last asked for, or the backoff used
+	Message    string        // Response body, if any
+}
+
+func (e *RateLimitError) Error() string {
+	msg := fmt.Sprintf("rate limited by the API (HTTP 429), retry after %s", e.RetryAfter)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// IsRateLimitError reports whether err is, or wraps, a RateLimitError
+func IsRateLimitError(err error) bool {
+	var rateLimit *RateLimitError
+	return errors.As(err, &rateLimit)
+}
+
+// sendWithRateLimitRetry sends the request like sendRequestToEndpointWithHandler. While the
+// endpoint answers 429, it waits as long as Retry-After asks, or with exponential backoff,
+// and sends the request again up to RateLimitRetries times. The wait ends early when the
+// client's context is canceled.
+func (c *Client) sendWithRateLimitRetry(jsonBody []byte, apiURL string, handler FormatHandler) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.sendRequestToEndpointWithHandler(jsonBody, apiURL, handler)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+			return resp, err
+		}
+
+		wait := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if wait == 0 {
+			wait = rateLimitBaseDelay << attempt
+		}
+		if attempt == RateLimitRetries || wait > maxRateLimitWait {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+			return nil, &RateLimitError{RetryAfter: wait, Message: strings.TrimSpace(string(body))}
+		}
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		utils.ContextLog(c.requestContext(), "AI endpoint rate limited, retrying in %v", wait)
+		if err := c.sleep(c.requestContext(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// sleepContext waits for d, returning early with the context's error when it is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
@@ -0,0 +1,132 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientRetriesRateLimit(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			w.Header().Set("Retry-After", "3")
+			http.Error(w, `{"error":{"message":"slow down"}}`, http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"ok"}}]}`))
+	}))
+	defer srv.Close()
+
+	client := newGatewayClient(srv)
+	var waits []time.Duration
+	client.sleep = func(_ context.Context, d time.Duration) error { waits = append(waits, d); return nil }
+
+	got, err := client.Request("", "hi")
+	if err != nil {
+		t.Fatalf("expected success after the rate limit, got %v", err)
+	}
+	if got != "ok" || atomic.LoadInt32(&calls) != 3 {
+		t.Errorf("expected an answer on the third call, got %q after %d calls", got, calls)
+	}
+	if len(waits) != 2 || waits[0] != 3*time.Second || waits[1] != 3*time.Second {
+		t.Errorf("expected to wait the requested 3s twice, got %v", waits)
+	}
+}
+
+func TestClientRateLimitStaysOnFormat(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	client := newGatewayClient(srv)
+	var waits []time.Duration
+	client.sleep = func(_ context.Context, d time.Duration) error { waits = append(waits, d); return nil }
+
+	_, err := client.Request("", "hi")
+	if !IsRateLimitError(err) {
+		t.Fatalf("expected a rate limit error, got %v", err)
+	}
+	// One format, tried once and retried twice with backoff
+	if n := atomic.LoadInt32(&calls); n != 1+RateLimitRetries {
+		t.Errorf("expected %d calls without trying other formats, got %d", 1+RateLimitRetries, n)
+	}
+	if len(waits) != 2 || waits[0] != rateLimitBaseDelay || waits[1] != 2*rateLimitBaseDelay {
+		t.Errorf("expected exponential backoff, got %v", waits)
+	}
+}
+
+func TestClientRateLimitLongRetryAfter(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "3600")
+		http.Error(w, "come back later", http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	client := newGatewayClient(srv)
+	client.sleep = func(_ context.Context, d time.Duration) error { t.Errorf("unexpected wait of %v", d); return nil }
+
+	if _, err := client.Request("", "hi"); !IsRateLimitError(err) {
+		t.Fatalf("expected a rate limit error, got %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("expected no retry for a wait over the cap, got %d calls", n)
+	}
+}
+
+func TestClientRateLimitWaitCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "20")
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	client := newGatewayClient(srv).WithContext(ctx)
+
+	start := time.Now()
+	_, err := client.Request("", "hi")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the context's error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the wait to end with the context, took %v", elapsed)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cases := map[string]time.Duration{
+		"":                              0,
+		"5":                             5 * time.Second,
+		"-1":                            0,
+		"soon":                          0,
+		"Mon, 01 Jan 2024 12:00:10 GMT": 10 * time.Second,
+	}
+	for value, want := range cases {
+		if got := parseRetryAfter(value, now); got != want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+// newGatewayClient returns a client for an OpenAI-compatible gateway served by srv
+func newGatewayClient(srv *httptest.Server) *Client {
+	target, _ := url.Parse(srv.URL)
+	return NewClientWithHTTPClient(
+		ClientConfig{Endpoint: "http://gateway.example/v1/chat/completions", Model: "m"},
+		&http.Client{Transport: redirectTransport{target: target}},
+	)
+}
@@ -0,0 +1,196 @@
+package ai
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxStreamLineSize caps a single line of a streamed response
+const maxStreamLineSize = 1024 * 1024
+
+// RequestStreamWithMessages makes a streaming AI request using messages format, see RequestStream
+func (c *Client) RequestStreamWithMessages(messages []map[string]string, onDelta func(delta string) error) (ResponseResult, error) {
+	config := RequestConfig{
+		Model:       c.config.Model,
+		Messages:    messages,
+		Temperature: 0.3,
+	}
+	c.applyMaxTokens(&config)
+
+	return c.RequestStream(config, onDelta)
+}
+
+// RequestStream makes an AI request with stream:true and calls onDelta with each piece of
+// the answer as it arrives, reading OpenAI-style "data:" events and Ollama's newline-delimited
+// JSON. The returned result holds the assembled content. Formats without streaming support,
+// and streams that fail before producing any output, fall back to RequestWithConfig with the
+// whole answer delivered as a single delta, unless the endpoint is rate limiting. An error
+// returned by onDelta stops the request.
+func (c *Client) RequestStream(config RequestConfig, onDelta func(delta string) error) (ResponseResult, error) {
+	var handler FormatHandler
+	switch DetectAPIProvider(c.config.Endpoint) {
+	case "gemini", "anthropic":
+		// Their streaming formats differ, use a regular request
+	case "ollama":
+		handler = NewOllamaHandler()
+	default:
+		handler = c.openAICompatibleHandler()
+	}
+
+	if handler != nil {
+		var streamed bool
+		result, err := c.tryStream(handler, config, func(delta string) error {
+			streamed = true
+			return onDelta(delta)
+		})
+		// A rate-limited endpoint would only limit the regular request too
+		if err == nil || streamed || IsRateLimitError(err) {
+			return result, err
+		}
+	}
+
+	result, err := c.RequestWithConfig(config)
+	if err != nil {
+		return ResponseResult{}, err
+	}
+	if err := onDelta(result.Content); err != nil {
+		return ResponseResult{}, err
+	}
+	return result, nil
+}
+
+// tryStream sends a streaming request in the handler's format and reads the answer
+func (c *Client) tryStream(handler FormatHandler, config RequestConfig, onDelta func(delta string) error) (ResponseResult, error) {
+	requestBody, err := handler.BuildRequest(config)
+	if err != nil {
+		return ResponseResult{}, fmt.Errorf("failed to build request: %w", err)
+	}
+	requestBody["stream"] = true
+
+	jsonBody, err := json.Marshal(requestBody)
+	if err != nil {
+		return ResponseResult{}, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	endpoint := handler.FormatEndpoint(c.config.Endpoint, c.config.Model)
+	ollama, isOllama := handler.(*OllamaHandler)
+	if isOllama {
+		endpoint = ollama.FormatEndpointForRequest(c.config.Endpoint, len(config.Messages) > 0)
+	}
+
+	resp, err := c.sendWithRateLimitRetry(jsonBody, endpoint, handler)
+	if err != nil {
+		return ResponseResult{}, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		if err := handler.ValidateResponse(resp.StatusCode, body); err != nil {
+			return ResponseResult{}, err
+		}
+		return ResponseResult{}, fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	var content strings.Builder
+	emit := func(delta string) error {
+		if delta == "" {
+			return nil
+		}
+		content.WriteString(delta)
+		return onDelta(delta)
+	}
+	if isOllama {
+		err = readOllamaStream(resp.Body, emit)
+	} else {
+		err = readOpenAIStream(resp.Body, emit)
+	}
+	if err != nil {
+		return ResponseResult{Content: content.String()}, err
+	}
+
+	format := FormatTypeOpenAI
+	if isOllama {
+		format = FormatTypeOllama
+	}
+	return ResponseResult{Content: content.String(), FormatUsed: format}, nil
+}
+
+// readOpenAIStream reads server-sent events of an OpenAI-compatible chat completion until
+// the [DONE] event or the end of the body
+func readOpenAIStream(body io.Reader, emit func(string) error) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStreamLineSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			return nil
+		}
+
+		var chunk struct {
+			Choices []struct {
+				Delta struct {
+					Content string `json:"content"`
+				} `json:"delta"`
+			} `json:"choices"`
+			Error *struct {
+				Message string `json:"message"`
+			} `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return fmt.Errorf("failed to decode stream chunk: %w", err)
+		}
+		if chunk.Error != nil {
+			return fmt.Errorf("API error: %s", chunk.Error.Message)
+		}
+		for _, choice := range chunk.Choices {
+			if err := emit(choice.Delta.Content); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// readOllamaStream reads Ollama's newline-delimited JSON from /api/chat or /api/generate
+// until the object marked done
+func readOllamaStream(body io.Reader, emit func(string) error) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStreamLineSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var chunk struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+			Response string `json:"response"`
+			Done     bool   `json:"done"`
+			Error    string `json:"error,omitempty"`
+		}
+		if err := json.Unmarshal([]byte(line), &chunk); err != nil {
+			return fmt.Errorf("failed to decode Ollama stream chunk: %w", err)
+		}
+		if chunk.Error != "" {
+			return fmt.Errorf("Ollama API error: %s", chunk.Error)
+		}
+		if err := emit(chunk.Message.Content + chunk.Response); err != nil {
+			return err
+		}
+		if chunk.Done {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
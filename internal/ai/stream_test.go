@@ -0,0 +1,110 @@
+package ai
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestRequestStreamOpenAI(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, chunk := range []string{
+			`{"choices":[{"delta":{"role":"assistant"}}]}`,
+			`{"choices":[{"delta":{"content":"<think>hmm</think>Hel"}}]}`,
+			`{"choices":[{"delta":{"content":"lo"}}]}`,
+			`[DONE]`,
+		} {
+			w.Write([]byte("data: " + chunk + "\n\n"))
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer srv.Close()
+
+	// An OpenAI-compatible gateway, not recognized as any other provider
+	target, _ := url.Parse(srv.URL)
+	client := NewClientWithHTTPClient(
+		ClientConfig{Endpoint: "http://gateway.example/v1/chat/completions", Model: "gpt-4o-mini"},
+		&http.Client{Transport: redirectTransport{target: target}},
+	)
+
+	var deltas []string
+	result, err := client.RequestStreamWithMessages([]map[string]string{{"role": "user", "content": "hi"}}, func(delta string) error {
+		deltas = append(deltas, delta)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RequestStream: %v", err)
+	}
+	if body["stream"] != true {
+		t.Errorf("expected stream:true in the request, got %v", body)
+	}
+	if strings.Join(deltas, "|") != "<think>hmm</think>Hel|lo" {
+		t.Errorf("unexpected deltas %q", deltas)
+	}
+	if result.Content != "<think>hmm</think>Hello" || RemoveThinkingTags(result.Content) != "Hello" {
+		t.Errorf("unexpected assembled content %q", result.Content)
+	}
+}
+
+func TestRequestStreamOllama(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Write([]byte(`{"message":{"role":"assistant","content":"Hi "},"done":false}` + "\n"))
+		w.Write([]byte(`{"message":{"role":"assistant","content":"there"},"done":false}` + "\n"))
+		w.Write([]byte(`{"message":{"role":"assistant","content":""},"done":true}` + "\n"))
+	}))
+	defer srv.Close()
+
+	// A 127.0.0.1 endpoint is detected as Ollama
+	client := NewClient(ClientConfig{Endpoint: srv.URL, Model: "llama3"})
+	var got strings.Builder
+	result, err := client.RequestStreamWithMessages([]map[string]string{{"role": "user", "content": "hi"}}, func(delta string) error {
+		got.WriteString(delta)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RequestStream: %v", err)
+	}
+	if path != "/api/chat" || got.String() != "Hi there" || result.Content != "Hi there" {
+		t.Errorf("unexpected stream from %s: %q, result %q", path, got.String(), result.Content)
+	}
+}
+
+func TestRequestStreamStopsOnCallbackError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"message":{"content":"one"},"done":false}` + "\n"))
+		w.Write([]byte(`{"message":{"content":"two"},"done":false}` + "\n"))
+	}))
+	defer srv.Close()
+
+	stop := errors.New("client gone")
+	calls := 0
+	client := NewClient(ClientConfig{Endpoint: srv.URL, Model: "llama3"})
+	_, err := client.RequestStreamWithMessages([]map[string]string{{"role": "user", "content": "hi"}}, func(string) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("expected the callback error after one delta, got %v after %d calls", err, calls)
+	}
+}
+
+// redirectTransport sends every request to the test server, whatever host it names
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (rt redirectTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r.URL.Scheme = rt.target.Scheme
+	r.URL.Host = rt.target.Host
+	return http.DefaultTransport.RoundTrip(r)
+}
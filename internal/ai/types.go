@@ -36,6 +36,31 @@ type ResponseResult struct {
 	Content    string     // The main response content
 	Thinking   string     // Optional thinking/reasoning content (for models that support it)
 	FormatUsed FormatType // Which format was successful
+	Usage      Usage      // Token counts reported by the API, zero when it reports none
+}
+
+// Usage is the token count an API reports for a request
+type Usage struct {
+	PromptTokens     int
+	CompletionTokens int
+	TotalTokens      int
+}
+
+// Total returns the total token count, adding up the parts when the API only reports those
+func (u Usage) Total() int {
+	if u.TotalTokens > 0 {
+		return u.TotalTokens
+	}
+	return u.PromptTokens + u.CompletionTokens
+}
+
+// Add returns the combined usage of two requests
+func (u Usage) Add(other Usage) Usage {
+	return Usage{
+		PromptTokens:     u.PromptTokens + other.PromptTokens,
+		CompletionTokens: u.CompletionTokens + other.CompletionTokens,
+		TotalTokens:      u.Total() + other.Total(),
+	}
 }
 
 // FormatHandler defines the interface for handling different API formats
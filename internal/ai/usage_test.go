@@ -0,0 +1,61 @@
+package ai
+
+import "testing"
+
+func TestParseResponseUsage(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler FormatHandler
+		body    string
+		want    Usage
+	}{
+		{
+			name:    "openai",
+			handler: NewOpenAIHandler(),
+			body:    `{"choices":[{"message":{"content":"hi"}}],"usage":{"prompt_tokens":12,"completion_tokens":3,"total_tokens":15}}`,
+			want:    Usage{PromptTokens: 12, CompletionTokens: 3, TotalTokens: 15},
+		},
+		{
+			name:    "anthropic",
+			handler: &AnthropicHandler{},
+			body:    `{"content":[{"type":"text","text":"hi"}],"usage":{"input_tokens":20,"output_tokens":5}}`,
+			want:    Usage{PromptTokens: 20, CompletionTokens: 5},
+		},
+		{
+			name:    "gemini",
+			handler: NewGeminiHandler(),
+			body:    `{"candidates":[{"content":{"parts":[{"text":"hi"}]}}],"usageMetadata":{"promptTokenCount":8,"candidatesTokenCount":2,"totalTokenCount":10}}`,
+			want:    Usage{PromptTokens: 8, CompletionTokens: 2, TotalTokens: 10},
+		},
+		{
+			name:    "ollama",
+			handler: NewOllamaHandler(),
+			body:    `{"message":{"role":"assistant","content":"hi"},"done":true,"prompt_eval_count":9,"eval_count":4}`,
+			want:    Usage{PromptTokens: 9, CompletionTokens: 4},
+		},
+		{
+			name:    "no usage",
+			handler: NewOpenAIHandler(),
+			body:    `{"choices":[{"message":{"content":"hi"}}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.handler.ParseResponse([]byte(tt.body))
+			if err != nil {
+				t.Fatalf("ParseResponse: %v", err)
+			}
+			if result.Usage != tt.want {
+				t.Errorf("Usage = %+v, want %+v", result.Usage, tt.want)
+			}
+		})
+	}
+
+	if total := (Usage{PromptTokens: 9, CompletionTokens: 4}).Total(); total != 13 {
+		t.Errorf("Total() = %d, want 13", total)
+	}
+	sum := Usage{PromptTokens: 9, CompletionTokens: 4}.Add(Usage{PromptTokens: 1, CompletionTokens: 1, TotalTokens: 2})
+	if sum.Total() != 15 {
+		t.Errorf("Add() total = %d, want 15", sum.Total())
+	}
+}
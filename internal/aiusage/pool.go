@@ -0,0 +1,165 @@
+package aiusage
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultWorkerConcurrency is the default number of AI calls allowed in flight at once
+	DefaultWorkerConcurrency = 2
+	// DefaultWorkerTimeout is the default time limit for a single AI task
+	DefaultWorkerTimeout = 120 * time.Second
+)
+
+// WorkerPool limits how many AI tasks run at once across the whole application.
+// Bulk operations (translate, label, summarize) share one pool so they don't
+// compete for the AI endpoint and trip provider rate limits.
+type WorkerPool struct {
+	tracker *Tracker
+
+	mu      sync.Mutex
+	limit   int
+	active  int
+	timeout time.Duration
+	waiters []chan struct{}
+}
+
+// NewWorkerPool creates a worker pool that applies the tracker's rate limit to every task.
+// Concurrency and timeout are refreshed from the ai_worker_concurrency and
+// ai_worker_timeout settings before each task.
+func NewWorkerPool(tracker *Tracker) *WorkerPool {
+	return &WorkerPool{
+		tracker: tracker,
+		limit:   DefaultWorkerConcurrency,
+		timeout: DefaultWorkerTimeout,
+	}
+}
+
+// SetConcurrency sets the maximum number of tasks running at once.
+// Non-positive values are ignored.
+func (p *WorkerPool) SetConcurrency(n int) {
+	if n <= 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.limit = n
+	// Wake waiters that fit under the new limit
+	for p.active < p.limit && len(p.waiters) > 0 {
+		p.active++
+		close(p.popWaiter())
+	}
+}
+
+// SetTimeout sets the per-task timeout. Zero disables the timeout.
+func (p *WorkerPool) SetTimeout(d time.Duration) {
+	if d < 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.timeout = d
+}
+
+// Concurrency returns the current concurrency limit.
+func (p *WorkerPool) Concurrency() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.limit
+}
+
+// Do runs task once a slot is free and the rate limit allows it.
+// The task's context is cancelled when ctx is done or the per-task timeout expires.
+func (p *WorkerPool) Do(ctx context.Context, task func(ctx context.Context) error) error {
+	p.loadSettings()
+
+	if err := p.acquire(ctx); err != nil {
+		return err
+	}
+	defer p.release()
+
+	if p.tracker != nil {
+		p.tracker.WaitForRateLimit()
+	}
+
+	p.mu.Lock()
+	timeout := p.timeout
+	p.mu.Unlock()
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	return task(ctx)
+}
+
+// loadSettings refreshes concurrency and timeout from the tracker's settings provider
+func (p *WorkerPool) loadSettings() {
+	if p.tracker == nil || p.tracker.settings == nil {
+		return
+	}
+	if s, err := p.tracker.settings.GetSetting("ai_worker_concurrency"); err == nil {
+		if n, err := strconv.Atoi(s); err == nil && n > 0 {
+			p.SetConcurrency(n)
+		}
+	}
+	if s, err := p.tracker.settings.GetSetting("ai_worker_timeout"); err == nil {
+		if n, err := strconv.Atoi(s); err == nil && n > 0 {
+			p.SetTimeout(time.Duration(n) * time.Second)
+		}
+	}
+}
+
+// acquire blocks until a slot is available or ctx is done
+func (p *WorkerPool) acquire(ctx context.Context) error {
+	p.mu.Lock()
+	if p.active < p.limit {
+		p.active++
+		p.mu.Unlock()
+		return nil
+	}
+	ch := make(chan struct{})
+	p.waiters = append(p.waiters, ch)
+	p.mu.Unlock()
+
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		p.mu.Lock()
+		for i, w := range p.waiters {
+			if w == ch {
+				p.waiters = append(p.waiters[:i], p.waiters[i+1:]...)
+				p.mu.Unlock()
+				return ctx.Err()
+			}
+		}
+		p.mu.Unlock()
+		// The slot was handed to us while cancelling; give it back
+		p.release()
+		return ctx.Err()
+	}
+}
+
+// release frees a slot, handing it directly to the next waiter if one is queued
+func (p *WorkerPool) release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.active <= p.limit && len(p.waiters) > 0 {
+		close(p.popWaiter())
+		return
+	}
+	p.active--
+}
+
+// popWaiter removes and returns the oldest waiter; p.mu must be held
+func (p *WorkerPool) popWaiter() chan struct{} {
+	ch := p.waiters[0]
+	p.waiters = p.waiters[1:]
+	return ch
+}
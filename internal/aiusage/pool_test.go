@@ -0,0 +1,88 @@
+package aiusage
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type mapSettings map[string]string
+
+func (m mapSettings) GetSetting(key string) (string, error) { return m[key], nil }
+func (m mapSettings) SetSetting(key, value string) error    { m[key] = value; return nil }
+
+func TestWorkerPool_LimitsConcurrency(t *testing.T) {
+	tracker := NewTracker(mapSettings{"ai_worker_concurrency": "2"})
+	tracker.SetMinInterval(0)
+	pool := NewWorkerPool(tracker)
+
+	var inFlight, maxInFlight int32
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = pool.Do(context.Background(), func(ctx context.Context) error {
+				n := atomic.AddInt32(&inFlight, 1)
+				for {
+					m := atomic.LoadInt32(&maxInFlight)
+					if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&inFlight, -1)
+				return nil
+			})
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight != 2 {
+		t.Fatalf("expected at most 2 tasks in flight, got %d", maxInFlight)
+	}
+}
+
+func TestWorkerPool_Timeout(t *testing.T) {
+	pool := NewWorkerPool(nil)
+	pool.SetTimeout(20 * time.Millisecond)
+
+	err := pool.Do(context.Background(), func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestWorkerPool_CancelWhileWaiting(t *testing.T) {
+	pool := NewWorkerPool(nil)
+	pool.SetConcurrency(1)
+
+	started := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		_ = pool.Do(context.Background(), func(ctx context.Context) error {
+			close(started)
+			<-done
+			return nil
+		})
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := pool.Do(ctx, func(ctx context.Context) error { return nil }); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected waiting task to be cancelled, got %v", err)
+	}
+	close(done)
+
+	// The slot must be usable again once the first task finishes
+	if err := pool.Do(context.Background(), func(ctx context.Context) error { return nil }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"MrRSS/internal/ai"
 )
 
 // SettingsProvider is an interface for retrieving and storing settings.
@@ -249,26 +251,26 @@ func isCJK(r rune) bool {
 	return false
 }
 
-// TrackTranslation tracks token usage for a translation operation.
-func (t *Tracker) TrackTranslation(sourceText, translatedText string) {
-	// Estimate tokens for both input and output
-	inputTokens := EstimateTokens(sourceText)
-	outputTokens := EstimateTokens(translatedText)
-
-	totalTokens := inputTokens + outputTokens
-	if err := t.AddUsage(totalTokens); err != nil {
+// TrackUsage adds the token usage an AI API reported for an operation, or the estimate
+// when the API reported none.
+func (t *Tracker) TrackUsage(usage ai.Usage, estimated int64) {
+	tokens := int64(usage.Total())
+	if tokens <= 0 {
+		tokens = estimated
+	}
+	if err := t.AddUsage(tokens); err != nil {
 		log.Printf("Warning: failed to track AI usage: %v", err)
 	}
 }
 
-// TrackSummary tracks token usage for a summarization operation.
-func (t *Tracker) TrackSummary(content, summary string) {
-	// Estimate tokens for both input and output
-	inputTokens := EstimateTokens(content)
-	outputTokens := EstimateTokens(summary)
+// TrackTranslation tracks token usage for a translation operation, estimating it from the
+// texts when the API didn't report it.
+func (t *Tracker) TrackTranslation(sourceText, translatedText string, usage ai.Usage) {
+	t.TrackUsage(usage, EstimateTokens(sourceText)+EstimateTokens(translatedText))
+}
 
-	totalTokens := inputTokens + outputTokens
-	if err := t.AddUsage(totalTokens); err != nil {
-		log.Printf("Warning: failed to track AI usage: %v", err)
-	}
+// TrackSummary tracks token usage for a summarization operation, estimating it from the
+// texts when the API didn't report it.
+func (t *Tracker) TrackSummary(content, summary string, usage ai.Usage) {
+	t.TrackUsage(usage, EstimateTokens(content)+EstimateTokens(summary))
 }
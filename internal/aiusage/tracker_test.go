@@ -0,0 +1,30 @@
+package aiusage
+
+import (
+	"testing"
+
+	"MrRSS/internal/ai"
+)
+
+func TestTracker_PrefersReportedTokens(t *testing.T) {
+	settings := mapSettings{}
+	tracker := NewTracker(settings)
+
+	// Without reported usage the estimate is counted
+	tracker.TrackTranslation("Hello world", "你好，世界", ai.Usage{})
+	estimated, _ := tracker.GetCurrentUsage()
+	if estimated <= 0 {
+		t.Fatalf("expected an estimate to be counted, got %d", estimated)
+	}
+
+	// Reported usage replaces the estimate
+	tracker.TrackTranslation("Hello world", "你好，世界", ai.Usage{PromptTokens: 120, CompletionTokens: 30})
+	if usage, _ := tracker.GetCurrentUsage(); usage != estimated+150 {
+		t.Errorf("expected %d after reported usage, got %d", estimated+150, usage)
+	}
+
+	tracker.TrackUsage(ai.Usage{}, 7)
+	if usage, _ := tracker.GetCurrentUsage(); usage != estimated+157 {
+		t.Errorf("expected %d after an estimate, got %d", estimated+157, usage)
+	}
+}
@@ -18,98 +18,140 @@ var defaultsJSON []byte
 
 // Defaults holds all default settings values
 type Defaults struct {
-	AIAPIKey                      string `json:"ai_api_key"`
-	AIChatEnabled                 bool   `json:"ai_chat_enabled"`
-	AICustomHeaders               string `json:"ai_custom_headers"`
-	AIEndpoint                    string `json:"ai_endpoint"`
-	AIModel                       string `json:"ai_model"`
-	AISummaryPrompt               string `json:"ai_summary_prompt"`
-	AITranslationPrompt           string `json:"ai_translation_prompt"`
-	AIUsageLimit                  string `json:"ai_usage_limit"`
-	AIUsageTokens                 string `json:"ai_usage_tokens"`
-	AutoCleanupEnabled            bool   `json:"auto_cleanup_enabled"`
-	AutoShowAllContent            bool   `json:"auto_show_all_content"`
-	BaiduAppId                    string `json:"baidu_app_id"`
-	BaiduSecretKey                string `json:"baidu_secret_key"`
-	CloseToTray                   bool   `json:"close_to_tray"`
-	CompactMode                   bool   `json:"compact_mode"`
-	ContentFontFamily             string `json:"content_font_family"`
-	ContentFontSize               int    `json:"content_font_size"`
-	ContentLineHeight             string `json:"content_line_height"`
-	CustomCssFile                 string `json:"custom_css_file"`
-	CustomTranslationBodyTemplate string `json:"custom_translation_body_template"`
-	CustomTranslationEnabled      bool   `json:"custom_translation_enabled"`
-	CustomTranslationEndpoint     string `json:"custom_translation_endpoint"`
-	CustomTranslationHeaders      string `json:"custom_translation_headers"`
-	CustomTranslationLangMapping  string `json:"custom_translation_lang_mapping"`
-	CustomTranslationMethod       string `json:"custom_translation_method"`
-	CustomTranslationName         string `json:"custom_translation_name"`
-	CustomTranslationResponsePath string `json:"custom_translation_response_path"`
-	CustomTranslationTimeout      int    `json:"custom_translation_timeout"`
-	DeeplAPIKey                   string `json:"deepl_api_key"`
-	DeeplEndpoint                 string `json:"deepl_endpoint"`
-	DefaultViewMode               string `json:"default_view_mode"`
-	FeedDrawerExpanded            bool   `json:"feed_drawer_expanded"`
-	FeedDrawerPinned              bool   `json:"feed_drawer_pinned"`
-	FreshRSSAPIPassword           string `json:"freshrss_api_password"`
-	FreshRSSAutoSyncInterval      int    `json:"freshrss_auto_sync_interval"`
-	FreshRSSEnabled               bool   `json:"freshrss_enabled"`
-	FreshRSSLastSyncTime          string `json:"freshrss_last_sync_time"`
-	FreshRSSServerUrl             string `json:"freshrss_server_url"`
-	FreshRSSSyncOnStartup         bool   `json:"freshrss_sync_on_startup"`
-	FreshRSSUsername              string `json:"freshrss_username"`
-	FullTextFetchEnabled          bool   `json:"full_text_fetch_enabled"`
-	GoogleTranslateEndpoint       string `json:"google_translate_endpoint"`
-	HoverMarkAsRead               bool   `json:"hover_mark_as_read"`
-	ImageGalleryEnabled           bool   `json:"image_gallery_enabled"`
-	Language                      string `json:"language"`
-	LastGlobalRefresh             string `json:"last_global_refresh"`
-	LastNetworkTest               string `json:"last_network_test"`
-	MaxArticleAgeDays             int    `json:"max_article_age_days"`
-	MaxCacheSizeMb                int    `json:"max_cache_size_mb"`
-	MaxConcurrentRefreshes        string `json:"max_concurrent_refreshes"`
-	MediaCacheEnabled             bool   `json:"media_cache_enabled"`
-	MediaCacheMaxAgeDays          int    `json:"media_cache_max_age_days"`
-	MediaCacheMaxSizeMb           int    `json:"media_cache_max_size_mb"`
-	MediaProxyFallback            bool   `json:"media_proxy_fallback"`
-	NetworkBandwidthMbps          string `json:"network_bandwidth_mbps"`
-	NetworkLatencyMs              string `json:"network_latency_ms"`
-	NetworkSpeed                  string `json:"network_speed"`
-	ObsidianEnabled               bool   `json:"obsidian_enabled"`
-	ObsidianVault                 string `json:"obsidian_vault"`
-	ObsidianVaultPath             string `json:"obsidian_vault_path"`
-	ProxyEnabled                  bool   `json:"proxy_enabled"`
-	ProxyHost                     string `json:"proxy_host"`
-	ProxyPassword                 string `json:"proxy_password"`
-	ProxyPort                     string `json:"proxy_port"`
-	ProxyType                     string `json:"proxy_type"`
-	ProxyUsername                 string `json:"proxy_username"`
-	RefreshMode                   string `json:"refresh_mode"`
-	RetryTimeoutSeconds           int    `json:"retry_timeout_seconds"`
-	RsshubAPIKey                  string `json:"rsshub_api_key"`
-	RsshubEnabled                 bool   `json:"rsshub_enabled"`
-	RsshubEndpoint                string `json:"rsshub_endpoint"`
-	Rules                         string `json:"rules"`
-	Shortcuts                     string `json:"shortcuts"`
-	ShortcutsEnabled              bool   `json:"shortcuts_enabled"`
-	ShowArticlePreviewImages      bool   `json:"show_article_preview_images"`
-	ShowHiddenArticles            bool   `json:"show_hidden_articles"`
-	StartupOnBoot                 bool   `json:"startup_on_boot"`
-	SummaryEnabled                bool   `json:"summary_enabled"`
-	SummaryLength                 string `json:"summary_length"`
-	SummaryProvider               string `json:"summary_provider"`
-	SummaryTriggerMode            string `json:"summary_trigger_mode"`
-	TargetLanguage                string `json:"target_language"`
-	Theme                         string `json:"theme"`
-	TranslationEnabled            bool   `json:"translation_enabled"`
-	TranslationOnlyMode           bool   `json:"translation_only_mode"`
-	TranslationProvider           string `json:"translation_provider"`
-	UpdateInterval                int    `json:"update_interval"`
-	WindowHeight                  string `json:"window_height"`
-	WindowMaximized               string `json:"window_maximized"`
-	WindowWidth                   string `json:"window_width"`
-	WindowX                       string `json:"window_x"`
-	WindowY                       string `json:"window_y"`
+	AdaptiveConcurrency             bool   `json:"adaptive_concurrency"`
+	AIAPIKey                        string `json:"ai_api_key"`
+	AIChatEnabled                   bool   `json:"ai_chat_enabled"`
+	AIChatMaxTokens                 int    `json:"ai_chat_max_tokens"`
+	AICustomHeaders                 string `json:"ai_custom_headers"`
+	AIEndpoint                      string `json:"ai_endpoint"`
+	AIEndpoints                     string `json:"ai_endpoints"`
+	AIFeedSummaryPrompt             string `json:"ai_feed_summary_prompt"`
+	AIModel                         string `json:"ai_model"`
+	AIPreamblePatterns              string `json:"ai_preamble_patterns"`
+	AISummaryMaxTokens              int    `json:"ai_summary_max_tokens"`
+	AISummaryPrompt                 string `json:"ai_summary_prompt"`
+	AITranslationChunkChars         int    `json:"ai_translation_chunk_chars"`
+	AITranslationMaxTokens          int    `json:"ai_translation_max_tokens"`
+	AITranslationPrompt             string `json:"ai_translation_prompt"`
+	AIUsageLimit                    string `json:"ai_usage_limit"`
+	AIUsageTokens                   string `json:"ai_usage_tokens"`
+	AIWorkerConcurrency             int    `json:"ai_worker_concurrency"`
+	AIWorkerTimeout                 int    `json:"ai_worker_timeout"`
+	AutoCleanupEnabled              bool   `json:"auto_cleanup_enabled"`
+	AutoReadDwellMs                 int    `json:"auto_read_dwell_ms"`
+	AutoShowAllContent              bool   `json:"auto_show_all_content"`
+	AzureTranslatorKey              string `json:"azure_translator_key"`
+	AzureTranslatorRegion           string `json:"azure_translator_region"`
+	BaiduAppId                      string `json:"baidu_app_id"`
+	BaiduSecretKey                  string `json:"baidu_secret_key"`
+	CloseToTray                     bool   `json:"close_to_tray"`
+	CollapseDuplicateTitles         bool   `json:"collapse_duplicate_titles"`
+	CompactMode                     bool   `json:"compact_mode"`
+	CompressArticleContent          bool   `json:"compress_article_content"`
+	ContentFontFamily               string `json:"content_font_family"`
+	ContentFontSize                 int    `json:"content_font_size"`
+	ContentLineHeight               string `json:"content_line_height"`
+	CustomCssFile                   string `json:"custom_css_file"`
+	CustomTranslationBodyTemplate   string `json:"custom_translation_body_template"`
+	CustomTranslationEnabled        bool   `json:"custom_translation_enabled"`
+	CustomTranslationEndpoint       string `json:"custom_translation_endpoint"`
+	CustomTranslationHeaders        string `json:"custom_translation_headers"`
+	CustomTranslationLangMapping    string `json:"custom_translation_lang_mapping"`
+	CustomTranslationMethod         string `json:"custom_translation_method"`
+	CustomTranslationName           string `json:"custom_translation_name"`
+	CustomTranslationResponsePath   string `json:"custom_translation_response_path"`
+	CustomTranslationTimeout        int    `json:"custom_translation_timeout"`
+	DeeplAPIKey                     string `json:"deepl_api_key"`
+	DeeplEndpoint                   string `json:"deepl_endpoint"`
+	DefaultViewMode                 string `json:"default_view_mode"`
+	DiscoveryFeedTimeout            int    `json:"discovery_feed_timeout"`
+	DiscoveryResultTtl              int    `json:"discovery_result_ttl"`
+	DiscoveryTargetCount            int    `json:"discovery_target_count"`
+	DiscoveryValidationRetries      int    `json:"discovery_validation_retries"`
+	DiscoveryValidationTimeout      int    `json:"discovery_validation_timeout"`
+	FeedDrawerExpanded              bool   `json:"feed_drawer_expanded"`
+	FeedDrawerPinned                bool   `json:"feed_drawer_pinned"`
+	FreshRSSAPIPassword             string `json:"freshrss_api_password"`
+	FreshRSSAutoSyncInterval        int    `json:"freshrss_auto_sync_interval"`
+	FreshRSSEnabled                 bool   `json:"freshrss_enabled"`
+	FreshRSSInsecureTls             bool   `json:"freshrss_insecure_tls"`
+	FreshRSSLastSyncTime            string `json:"freshrss_last_sync_time"`
+	FreshRSSPushDelaySeconds        int    `json:"freshrss_push_delay_seconds"`
+	FreshRSSSaveBatchSize           int    `json:"freshrss_save_batch_size"`
+	FreshRSSServerType              string `json:"freshrss_server_type"`
+	FreshRSSServerUrl               string `json:"freshrss_server_url"`
+	FreshRSSSyncOnStartup           bool   `json:"freshrss_sync_on_startup"`
+	FreshRSSTimeoutSeconds          int    `json:"freshrss_timeout_seconds"`
+	FreshRSSUsername                string `json:"freshrss_username"`
+	FullTextFetchEnabled            bool   `json:"full_text_fetch_enabled"`
+	GoogleTranslateEndpoint         string `json:"google_translate_endpoint"`
+	GoogleTranslateFallbackProvider string `json:"google_translate_fallback_provider"`
+	HoverMarkAsRead                 bool   `json:"hover_mark_as_read"`
+	HttpIdleConnTimeout             int    `json:"http_idle_conn_timeout"`
+	HttpMaxIdleConns                int    `json:"http_max_idle_conns"`
+	HttpMaxIdleConnsPerHost         int    `json:"http_max_idle_conns_per_host"`
+	ImageGalleryEnabled             bool   `json:"image_gallery_enabled"`
+	KeepFavoriteContent             bool   `json:"keep_favorite_content"`
+	Language                        string `json:"language"`
+	LastGlobalRefresh               string `json:"last_global_refresh"`
+	LastNetworkTest                 string `json:"last_network_test"`
+	LibreAPIKey                     string `json:"libre_api_key"`
+	LibreEndpoint                   string `json:"libre_endpoint"`
+	MaxArticleAgeDays               int    `json:"max_article_age_days"`
+	MaxArticleImages                int    `json:"max_article_images"`
+	MaxCacheSizeMb                  int    `json:"max_cache_size_mb"`
+	MaxConcurrentRefreshes          string `json:"max_concurrent_refreshes"`
+	MaxFetchesPerHour               int    `json:"max_fetches_per_hour"`
+	MediaCacheEnabled               bool   `json:"media_cache_enabled"`
+	MediaCacheMaxAgeDays            int    `json:"media_cache_max_age_days"`
+	MediaCacheMaxSizeMb             int    `json:"media_cache_max_size_mb"`
+	MediaProxyFallback              bool   `json:"media_proxy_fallback"`
+	MetadataOnlyFetch               bool   `json:"metadata_only_fetch"`
+	MinManualRefreshInterval        int    `json:"min_manual_refresh_interval"`
+	NetworkBandwidthMbps            string `json:"network_bandwidth_mbps"`
+	NetworkLatencyMs                string `json:"network_latency_ms"`
+	NetworkSpeed                    string `json:"network_speed"`
+	ObsidianEnabled                 bool   `json:"obsidian_enabled"`
+	ObsidianVault                   string `json:"obsidian_vault"`
+	ObsidianVaultPath               string `json:"obsidian_vault_path"`
+	OpenExternalLinksNewTab         bool   `json:"open_external_links_new_tab"`
+	OpenOriginalMarksRead           bool   `json:"open_original_marks_read"`
+	ProxyEnabled                    bool   `json:"proxy_enabled"`
+	ProxyHost                       string `json:"proxy_host"`
+	ProxyPassword                   string `json:"proxy_password"`
+	ProxyPort                       string `json:"proxy_port"`
+	ProxyType                       string `json:"proxy_type"`
+	ProxyUsername                   string `json:"proxy_username"`
+	RefreshMode                     string `json:"refresh_mode"`
+	RetryTimeoutSeconds             int    `json:"retry_timeout_seconds"`
+	RsshubAPIKey                    string `json:"rsshub_api_key"`
+	RsshubEnabled                   bool   `json:"rsshub_enabled"`
+	RsshubEndpoint                  string `json:"rsshub_endpoint"`
+	Rules                           string `json:"rules"`
+	Shortcuts                       string `json:"shortcuts"`
+	ShortcutsEnabled                bool   `json:"shortcuts_enabled"`
+	ShowArticlePreviewImages        bool   `json:"show_article_preview_images"`
+	ShowFeedBadge                   bool   `json:"show_feed_badge"`
+	ShowHiddenArticles              bool   `json:"show_hidden_articles"`
+	StartupOnBoot                   bool   `json:"startup_on_boot"`
+	SummaryEnabled                  bool   `json:"summary_enabled"`
+	SummaryLength                   string `json:"summary_length"`
+	SummaryMinLength                int    `json:"summary_min_length"`
+	SummaryProvider                 string `json:"summary_provider"`
+	SummaryTriggerMode              string `json:"summary_trigger_mode"`
+	SyncConflictStrategy            string `json:"sync_conflict_strategy"`
+	TargetLanguage                  string `json:"target_language"`
+	Theme                           string `json:"theme"`
+	TranslationConcurrency          int    `json:"translation_concurrency"`
+	TranslationEnabled              bool   `json:"translation_enabled"`
+	TranslationOnlyMode             bool   `json:"translation_only_mode"`
+	TranslationProvider             string `json:"translation_provider"`
+	UpdateInterval                  int    `json:"update_interval"`
+	WindowHeight                    string `json:"window_height"`
+	WindowMaximized                 string `json:"window_maximized"`
+	WindowWidth                     string `json:"window_width"`
+	WindowX                         string `json:"window_x"`
+	WindowY                         string `json:"window_y"`
 }
 
 var defaults Defaults
@@ -128,36 +170,66 @@ func Get() Defaults {
 // GetString returns a setting default as a string
 func GetString(key string) string {
 	switch key {
+	case "adaptive_concurrency":
+		return strconv.FormatBool(defaults.AdaptiveConcurrency)
 	case "ai_api_key":
 		return defaults.AIAPIKey
 	case "ai_chat_enabled":
 		return strconv.FormatBool(defaults.AIChatEnabled)
+	case "ai_chat_max_tokens":
+		return strconv.Itoa(defaults.AIChatMaxTokens)
 	case "ai_custom_headers":
 		return defaults.AICustomHeaders
 	case "ai_endpoint":
 		return defaults.AIEndpoint
+	case "ai_endpoints":
+		return defaults.AIEndpoints
+	case "ai_feed_summary_prompt":
+		return defaults.AIFeedSummaryPrompt
 	case "ai_model":
 		return defaults.AIModel
+	case "ai_preamble_patterns":
+		return defaults.AIPreamblePatterns
+	case "ai_summary_max_tokens":
+		return strconv.Itoa(defaults.AISummaryMaxTokens)
 	case "ai_summary_prompt":
 		return defaults.AISummaryPrompt
+	case "ai_translation_chunk_chars":
+		return strconv.Itoa(defaults.AITranslationChunkChars)
+	case "ai_translation_max_tokens":
+		return strconv.Itoa(defaults.AITranslationMaxTokens)
 	case "ai_translation_prompt":
 		return defaults.AITranslationPrompt
 	case "ai_usage_limit":
 		return defaults.AIUsageLimit
 	case "ai_usage_tokens":
 		return defaults.AIUsageTokens
+	case "ai_worker_concurrency":
+		return strconv.Itoa(defaults.AIWorkerConcurrency)
+	case "ai_worker_timeout":
+		return strconv.Itoa(defaults.AIWorkerTimeout)
 	case "auto_cleanup_enabled":
 		return strconv.FormatBool(defaults.AutoCleanupEnabled)
+	case "auto_read_dwell_ms":
+		return strconv.Itoa(defaults.AutoReadDwellMs)
 	case "auto_show_all_content":
 		return strconv.FormatBool(defaults.AutoShowAllContent)
+	case "azure_translator_key":
+		return defaults.AzureTranslatorKey
+	case "azure_translator_region":
+		return defaults.AzureTranslatorRegion
 	case "baidu_app_id":
 		return defaults.BaiduAppId
 	case "baidu_secret_key":
 		return defaults.BaiduSecretKey
 	case "close_to_tray":
 		return strconv.FormatBool(defaults.CloseToTray)
+	case "collapse_duplicate_titles":
+		return strconv.FormatBool(defaults.CollapseDuplicateTitles)
 	case "compact_mode":
 		return strconv.FormatBool(defaults.CompactMode)
+	case "compress_article_content":
+		return strconv.FormatBool(defaults.CompressArticleContent)
 	case "content_font_family":
 		return defaults.ContentFontFamily
 	case "content_font_size":
@@ -190,6 +262,16 @@ func GetString(key string) string {
 		return defaults.DeeplEndpoint
 	case "default_view_mode":
 		return defaults.DefaultViewMode
+	case "discovery_feed_timeout":
+		return strconv.Itoa(defaults.DiscoveryFeedTimeout)
+	case "discovery_result_ttl":
+		return strconv.Itoa(defaults.DiscoveryResultTtl)
+	case "discovery_target_count":
+		return strconv.Itoa(defaults.DiscoveryTargetCount)
+	case "discovery_validation_retries":
+		return strconv.Itoa(defaults.DiscoveryValidationRetries)
+	case "discovery_validation_timeout":
+		return strconv.Itoa(defaults.DiscoveryValidationTimeout)
 	case "feed_drawer_expanded":
 		return strconv.FormatBool(defaults.FeedDrawerExpanded)
 	case "feed_drawer_pinned":
@@ -200,34 +282,62 @@ func GetString(key string) string {
 		return strconv.Itoa(defaults.FreshRSSAutoSyncInterval)
 	case "freshrss_enabled":
 		return strconv.FormatBool(defaults.FreshRSSEnabled)
+	case "freshrss_insecure_tls":
+		return strconv.FormatBool(defaults.FreshRSSInsecureTls)
 	case "freshrss_last_sync_time":
 		return defaults.FreshRSSLastSyncTime
+	case "freshrss_push_delay_seconds":
+		return strconv.Itoa(defaults.FreshRSSPushDelaySeconds)
+	case "freshrss_save_batch_size":
+		return strconv.Itoa(defaults.FreshRSSSaveBatchSize)
+	case "freshrss_server_type":
+		return defaults.FreshRSSServerType
 	case "freshrss_server_url":
 		return defaults.FreshRSSServerUrl
 	case "freshrss_sync_on_startup":
 		return strconv.FormatBool(defaults.FreshRSSSyncOnStartup)
+	case "freshrss_timeout_seconds":
+		return strconv.Itoa(defaults.FreshRSSTimeoutSeconds)
 	case "freshrss_username":
 		return defaults.FreshRSSUsername
 	case "full_text_fetch_enabled":
 		return strconv.FormatBool(defaults.FullTextFetchEnabled)
 	case "google_translate_endpoint":
 		return defaults.GoogleTranslateEndpoint
+	case "google_translate_fallback_provider":
+		return defaults.GoogleTranslateFallbackProvider
 	case "hover_mark_as_read":
 		return strconv.FormatBool(defaults.HoverMarkAsRead)
+	case "http_idle_conn_timeout":
+		return strconv.Itoa(defaults.HttpIdleConnTimeout)
+	case "http_max_idle_conns":
+		return strconv.Itoa(defaults.HttpMaxIdleConns)
+	case "http_max_idle_conns_per_host":
+		return strconv.Itoa(defaults.HttpMaxIdleConnsPerHost)
 	case "image_gallery_enabled":
 		return strconv.FormatBool(defaults.ImageGalleryEnabled)
+	case "keep_favorite_content":
+		return strconv.FormatBool(defaults.KeepFavoriteContent)
 	case "language":
 		return defaults.Language
 	case "last_global_refresh":
 		return defaults.LastGlobalRefresh
 	case "last_network_test":
 		return defaults.LastNetworkTest
+	case "libre_api_key":
+		return defaults.LibreAPIKey
+	case "libre_endpoint":
+		return defaults.LibreEndpoint
 	case "max_article_age_days":
 		return strconv.Itoa(defaults.MaxArticleAgeDays)
+	case "max_article_images":
+		return strconv.Itoa(defaults.MaxArticleImages)
 	case "max_cache_size_mb":
 		return strconv.Itoa(defaults.MaxCacheSizeMb)
 	case "max_concurrent_refreshes":
 		return defaults.MaxConcurrentRefreshes
+	case "max_fetches_per_hour":
+		return strconv.Itoa(defaults.MaxFetchesPerHour)
 	case "media_cache_enabled":
 		return strconv.FormatBool(defaults.MediaCacheEnabled)
 	case "media_cache_max_age_days":
@@ -236,6 +346,10 @@ func GetString(key string) string {
 		return strconv.Itoa(defaults.MediaCacheMaxSizeMb)
 	case "media_proxy_fallback":
 		return strconv.FormatBool(defaults.MediaProxyFallback)
+	case "metadata_only_fetch":
+		return strconv.FormatBool(defaults.MetadataOnlyFetch)
+	case "min_manual_refresh_interval":
+		return strconv.Itoa(defaults.MinManualRefreshInterval)
 	case "network_bandwidth_mbps":
 		return defaults.NetworkBandwidthMbps
 	case "network_latency_ms":
@@ -248,6 +362,10 @@ func GetString(key string) string {
 		return defaults.ObsidianVault
 	case "obsidian_vault_path":
 		return defaults.ObsidianVaultPath
+	case "open_external_links_new_tab":
+		return strconv.FormatBool(defaults.OpenExternalLinksNewTab)
+	case "open_original_marks_read":
+		return strconv.FormatBool(defaults.OpenOriginalMarksRead)
 	case "proxy_enabled":
 		return strconv.FormatBool(defaults.ProxyEnabled)
 	case "proxy_host":
@@ -278,6 +396,8 @@ func GetString(key string) string {
 		return strconv.FormatBool(defaults.ShortcutsEnabled)
 	case "show_article_preview_images":
 		return strconv.FormatBool(defaults.ShowArticlePreviewImages)
+	case "show_feed_badge":
+		return strconv.FormatBool(defaults.ShowFeedBadge)
 	case "show_hidden_articles":
 		return strconv.FormatBool(defaults.ShowHiddenArticles)
 	case "startup_on_boot":
@@ -286,14 +406,20 @@ func GetString(key string) string {
 		return strconv.FormatBool(defaults.SummaryEnabled)
 	case "summary_length":
 		return defaults.SummaryLength
+	case "summary_min_length":
+		return strconv.Itoa(defaults.SummaryMinLength)
 	case "summary_provider":
 		return defaults.SummaryProvider
 	case "summary_trigger_mode":
 		return defaults.SummaryTriggerMode
+	case "sync_conflict_strategy":
+		return defaults.SyncConflictStrategy
 	case "target_language":
 		return defaults.TargetLanguage
 	case "theme":
 		return defaults.Theme
+	case "translation_concurrency":
+		return strconv.Itoa(defaults.TranslationConcurrency)
 	case "translation_enabled":
 		return strconv.FormatBool(defaults.TranslationEnabled)
 	case "translation_only_mode":
@@ -1,6 +1,12 @@
 package database
 
-import "database/sql"
+import (
+	"bytes"
+	"compress/gzip"
+	"database/sql"
+	"fmt"
+	"io"
+)
 
 // ArticleContent represents a cached article content entry
 type ArticleContent struct {
@@ -10,14 +16,17 @@ type ArticleContent struct {
 	FetchedAt string
 }
 
-// GetArticleContent retrieves cached content for an article
+// GetArticleContent retrieves cached content for an article.
+// Compressed entries are decompressed transparently.
 func (db *DB) GetArticleContent(articleID int64) (string, bool, error) {
 	db.WaitForReady()
 	var content string
+	var compressedContent []byte
+	var compressed sql.NullBool
 	err := db.QueryRow(
-		`SELECT content FROM article_contents WHERE article_id = ?`,
+		`SELECT content, content_compressed, compressed FROM article_contents WHERE article_id = ?`,
 		articleID,
-	).Scan(&content)
+	).Scan(&content, &compressedContent, &compressed)
 
 	if err == sql.ErrNoRows {
 		return "", false, nil
@@ -25,20 +34,124 @@ func (db *DB) GetArticleContent(articleID int64) (string, bool, error) {
 	if err != nil {
 		return "", false, err
 	}
+	if compressed.Bool {
+		content, err = decompressContent(compressedContent)
+		if err != nil {
+			return "", false, err
+		}
+	}
 	return content, true, nil
 }
 
-// SetArticleContent stores or updates content for an article
+// SetArticleContent stores or updates content for an article.
+// When compress_article_content is enabled the content is stored gzip-compressed,
+// like article summaries.
 func (db *DB) SetArticleContent(articleID int64, content string) error {
 	db.WaitForReady()
+	if db.compressionEnabled() {
+		compressedContent, err := compressContent(content)
+		if err != nil {
+			return err
+		}
+		_, err = db.Exec(
+			`INSERT OR REPLACE INTO article_contents (article_id, content, content_compressed, compressed, fetched_at)
+			 VALUES (?, '', ?, 1, CURRENT_TIMESTAMP)`,
+			articleID, compressedContent,
+		)
+		return err
+	}
+
 	_, err := db.Exec(
-		`INSERT OR REPLACE INTO article_contents (article_id, content, fetched_at)
-		 VALUES (?, ?, CURRENT_TIMESTAMP)`,
+		`INSERT OR REPLACE INTO article_contents (article_id, content, content_compressed, compressed, fetched_at)
+		 VALUES (?, ?, NULL, 0, CURRENT_TIMESTAMP)`,
 		articleID, content,
 	)
 	return err
 }
 
+// articleSummaryColumn selects an article's summary: the compressed BLOB when the row's
+// summary_is_compressed flag is set, the plain text otherwise. Scan it into a storedText.
+const articleSummaryColumn = `CASE WHEN a.summary_is_compressed = 1 THEN a.summary_compressed ELSE a.summary END`
+
+// storedText scans a column selected like articleSummaryColumn, decompressing BLOB values
+type storedText struct {
+	String string
+}
+
+// Scan implements sql.Scanner
+func (t *storedText) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		t.String = ""
+	case string:
+		t.String = v
+	case []byte:
+		if !isGzip(v) {
+			t.String = string(v)
+			return nil
+		}
+		text, err := decompressContent(v)
+		if err != nil {
+			return err
+		}
+		t.String = text
+	default:
+		return fmt.Errorf("unsupported stored text type %T", value)
+	}
+	return nil
+}
+
+// encodeSummary returns the summary text, compressed summary and compressed flag to store
+// for an article. Non-empty summaries are compressed when compress is set.
+func encodeSummary(summary string, compress bool) (string, []byte, bool, error) {
+	if !compress || summary == "" {
+		return summary, nil, false, nil
+	}
+	compressed, err := compressContent(summary)
+	if err != nil {
+		return "", nil, false, err
+	}
+	return "", compressed, true, nil
+}
+
+// compressionEnabled reports whether compress_article_content is on
+func (db *DB) compressionEnabled() bool {
+	enabled, _ := db.GetSetting("compress_article_content")
+	return enabled == "true"
+}
+
+// compressContent gzips article content for storage
+func compressContent(content string) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write([]byte(content)); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// isGzip reports whether data starts with the gzip magic number
+func isGzip(data []byte) bool {
+	return len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b
+}
+
+// decompressContent reverses compressContent
+func decompressContent(data []byte) (string, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	defer zr.Close()
+	out, err := io.ReadAll(zr)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
 // DeleteArticleContent removes cached content for an article
 func (db *DB) DeleteArticleContent(articleID int64) error {
 	db.WaitForReady()
@@ -49,11 +162,25 @@ func (db *DB) DeleteArticleContent(articleID int64) error {
 	return err
 }
 
-// CleanupOldArticleContents removes article content cache entries older than maxAgeDays
+// savedArticleContentFilter excludes cached content of favorited and read-later articles,
+// which is kept for offline reading like the articles themselves
+const savedArticleContentFilter = `article_id NOT IN (SELECT id FROM articles WHERE is_favorite = 1 OR is_read_later = 1)`
+
+// contentCleanupFilter returns the predicate every content-clearing cleanup applies. Saved
+// articles' content is kept unless keep_favorite_content has been turned off.
+func (db *DB) contentCleanupFilter() string {
+	if keep, err := db.GetSetting("keep_favorite_content"); err == nil && keep == "false" {
+		return "1 = 1"
+	}
+	return savedArticleContentFilter
+}
+
+// CleanupOldArticleContents removes article content cache entries older than maxAgeDays.
+// Content of favorited and read-later articles is kept unless keep_favorite_content is off.
 func (db *DB) CleanupOldArticleContents(maxAgeDays int) (int64, error) {
 	db.WaitForReady()
 	result, err := db.Exec(
-		`DELETE FROM article_contents WHERE fetched_at < datetime('now', '-' || ? || ' days')`,
+		`DELETE FROM article_contents WHERE fetched_at < datetime('now', '-' || ? || ' days') AND `+db.contentCleanupFilter(),
 		maxAgeDays,
 	)
 	if err != nil {
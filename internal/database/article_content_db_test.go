@@ -1,7 +1,11 @@
 package database
 
 import (
+	"context"
 	"testing"
+	"time"
+
+	"MrRSS/internal/models"
 )
 
 func TestArticleContentCache(t *testing.T) {
@@ -129,4 +133,210 @@ func TestArticleContentCache(t *testing.T) {
 			t.Errorf("Expected 0 rows affected, got %d", affected)
 		}
 	})
+
+	t.Run("Compressed ArticleContent", func(t *testing.T) {
+		if err := db.SetSetting("compress_article_content", "true"); err != nil {
+			t.Fatalf("Failed to enable compression: %v", err)
+		}
+		defer db.SetSetting("compress_article_content", "false")
+
+		articleID := int64(42)
+		testContent := "<p>Compressed content</p><p>Compressed content</p>"
+		if err := db.SetArticleContent(articleID, testContent); err != nil {
+			t.Fatalf("Failed to set article content: %v", err)
+		}
+
+		var raw string
+		var compressed bool
+		if err := db.QueryRow(`SELECT content, compressed FROM article_contents WHERE article_id = ?`, articleID).Scan(&raw, &compressed); err != nil {
+			t.Fatalf("Failed to read raw row: %v", err)
+		}
+		if !compressed || raw != "" {
+			t.Errorf("Expected compressed storage, got compressed=%v content=%q", compressed, raw)
+		}
+
+		content, found, err := db.GetArticleContent(articleID)
+		if err != nil || !found {
+			t.Fatalf("Failed to get compressed content: found=%v err=%v", found, err)
+		}
+		if content != testContent {
+			t.Errorf("Expected %q, got %q", testContent, content)
+		}
+	})
+}
+
+func TestCompressedArticleSummary(t *testing.T) {
+	db, err := NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.DB.Close()
+	if err := db.Init(); err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+	if err := db.SetSetting("compress_article_content", "true"); err != nil {
+		t.Fatalf("Failed to enable compression: %v", err)
+	}
+
+	feedID, err := db.AddFeed(&models.Feed{Title: "Feed", URL: "https://example.com/feed"})
+	if err != nil {
+		t.Fatalf("Failed to add feed: %v", err)
+	}
+	feedSummary := "<p>Summary from the feed</p>"
+	if err := db.SaveArticles(context.Background(), []*models.Article{
+		{FeedID: feedID, Title: "A", URL: "https://example.com/a", PublishedAt: time.Now(), Summary: feedSummary},
+	}); err != nil {
+		t.Fatalf("Failed to save article: %v", err)
+	}
+
+	articles, err := db.GetArticles("", feedID, "", false, 10, 0)
+	if err != nil || len(articles) != 1 {
+		t.Fatalf("Failed to get articles: %v (%d)", err, len(articles))
+	}
+	if articles[0].Summary != feedSummary {
+		t.Errorf("Expected %q from GetArticles, got %q", feedSummary, articles[0].Summary)
+	}
+	id := articles[0].ID
+
+	var raw string
+	var compressed bool
+	if err := db.QueryRow(`SELECT summary, summary_is_compressed FROM articles WHERE id = ?`, id).Scan(&raw, &compressed); err != nil {
+		t.Fatalf("Failed to read raw row: %v", err)
+	}
+	if !compressed || raw != "" {
+		t.Errorf("Expected compressed summary storage, got compressed=%v summary=%q", compressed, raw)
+	}
+
+	generated := "A generated summary of the article."
+	if err := db.UpdateArticleSummary(id, generated); err != nil {
+		t.Fatalf("Failed to update summary: %v", err)
+	}
+	if summary, err := db.GetArticleSummary(id); err != nil || summary != generated {
+		t.Errorf("Expected %q from GetArticleSummary, got %q (%v)", generated, summary, err)
+	}
+	if article, err := db.GetArticleByID(id); err != nil || article.Summary != generated {
+		t.Errorf("Expected %q from GetArticleByID, got %+v (%v)", generated, article, err)
+	}
+
+	content := "<p>Compressed content</p>"
+	if err := db.SetArticleContent(id, content); err != nil {
+		t.Fatalf("Failed to set article content: %v", err)
+	}
+	if got, found, err := db.GetArticleContent(id); err != nil || !found || got != content {
+		t.Errorf("Expected %q from GetArticleContent, got %q (found=%v, %v)", content, got, found, err)
+	}
+
+	// Rows written before compression was turned off still read back
+	if err := db.SetSetting("compress_article_content", "false"); err != nil {
+		t.Fatalf("Failed to disable compression: %v", err)
+	}
+	if summary, err := db.GetArticleSummary(id); err != nil || summary != generated {
+		t.Errorf("Expected %q after disabling compression, got %q (%v)", generated, summary, err)
+	}
+
+	// Clearing summaries also clears the compressed copy
+	if err := db.ClearAllSummaries(); err != nil {
+		t.Fatalf("Failed to clear summaries: %v", err)
+	}
+	if summary, err := db.GetArticleSummary(id); err != nil || summary != "" {
+		t.Errorf("Expected no summary after clearing, got %q (%v)", summary, err)
+	}
+	var stored []byte
+	if err := db.QueryRow(`SELECT summary_compressed, summary_is_compressed FROM articles WHERE id = ?`, id).Scan(&stored, &compressed); err != nil {
+		t.Fatalf("Failed to read raw row: %v", err)
+	}
+	if len(stored) != 0 || compressed {
+		t.Errorf("Expected the compressed summary to be cleared, got %d bytes (compressed=%v)", len(stored), compressed)
+	}
+}
+
+func TestCleanupArticleContentsKeepsSavedArticles(t *testing.T) {
+	db, err := NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.DB.Close()
+	if err := db.Init(); err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+
+	if _, err := db.Exec(`INSERT INTO feeds (id, title, url) VALUES (1, 'Feed', 'https://example.com/feed')`); err != nil {
+		t.Fatalf("Failed to insert feed: %v", err)
+	}
+	for _, a := range []struct {
+		id                  int64
+		favorite, readLater bool
+	}{{1, false, false}, {2, true, false}, {3, false, true}} {
+		if _, err := db.Exec(`INSERT INTO articles (id, feed_id, title, url, published_at, is_favorite, is_read_later, unique_id) VALUES (?, 1, ?, ?, datetime('now'), ?, ?, ?)`,
+			a.id, "Article", "https://example.com/a", a.favorite, a.readLater, a.id); err != nil {
+			t.Fatalf("Failed to insert article: %v", err)
+		}
+		if err := db.SetArticleContent(a.id, "<p>content</p>"); err != nil {
+			t.Fatalf("Failed to set article content: %v", err)
+		}
+	}
+	if _, err := db.Exec(`UPDATE article_contents SET fetched_at = datetime('now', '-10 days')`); err != nil {
+		t.Fatalf("Failed to backdate content: %v", err)
+	}
+
+	affected, err := db.CleanupArticleContentsByAge(7)
+	if err != nil {
+		t.Fatalf("CleanupArticleContentsByAge failed: %v", err)
+	}
+	if affected != 1 {
+		t.Errorf("Expected 1 row affected, got %d", affected)
+	}
+	for id, want := range map[int64]bool{1: false, 2: true, 3: true} {
+		if _, found, _ := db.GetArticleContent(id); found != want {
+			t.Errorf("Article %d content found = %v, want %v", id, found, want)
+		}
+	}
+}
+
+func TestCleanupAllArticleContentsKeepsFavorites(t *testing.T) {
+	db, err := NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	defer db.DB.Close()
+	if err := db.Init(); err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+
+	if _, err := db.Exec(`INSERT INTO feeds (id, title, url) VALUES (1, 'Feed', 'https://example.com/feed')`); err != nil {
+		t.Fatalf("Failed to insert feed: %v", err)
+	}
+	for _, a := range []struct {
+		id       int64
+		favorite bool
+	}{{1, false}, {2, true}} {
+		if _, err := db.Exec(`INSERT INTO articles (id, feed_id, title, url, published_at, is_favorite, unique_id) VALUES (?, 1, ?, ?, datetime('now'), ?, ?)`,
+			a.id, "Article", "https://example.com/a", a.favorite, a.id); err != nil {
+			t.Fatalf("Failed to insert article: %v", err)
+		}
+		if err := db.SetArticleContent(a.id, "<p>content</p>"); err != nil {
+			t.Fatalf("Failed to set article content: %v", err)
+		}
+	}
+
+	if _, err := db.CleanupAllArticleContents(); err != nil {
+		t.Fatalf("CleanupAllArticleContents failed: %v", err)
+	}
+	if _, found, _ := db.GetArticleContent(1); found {
+		t.Error("Expected content of the plain article to be cleared")
+	}
+	if content, found, _ := db.GetArticleContent(2); !found || content != "<p>content</p>" {
+		t.Errorf("Expected favorite content to survive, got %q (found %v)", content, found)
+	}
+
+	// With the guarantee turned off, a full cleanup clears favorites too
+	if err := db.SetSetting("keep_favorite_content", "false"); err != nil {
+		t.Fatalf("Failed to set setting: %v", err)
+	}
+	if _, err := db.CleanupAllArticleContents(); err != nil {
+		t.Fatalf("CleanupAllArticleContents failed: %v", err)
+	}
+	if _, found, _ := db.GetArticleContent(2); found {
+		t.Error("Expected favorite content to be cleared with keep_favorite_content off")
+	}
 }
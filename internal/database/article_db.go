@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
@@ -18,14 +19,25 @@ func (db *DB) SaveArticle(article *models.Article) error {
 
 	// Generate unique_id for deduplication
 	uniqueID := utils.GenerateArticleUniqueID(article.Title, article.FeedID, article.PublishedAt, article.HasValidPublishedTime)
-	query := `INSERT OR IGNORE INTO articles (feed_id, title, url, image_url, audio_url, video_url, published_at, translated_title, is_read, is_favorite, is_hidden, is_read_later, summary, unique_id, author) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
-	_, err := db.Exec(query, article.FeedID, article.Title, article.URL, article.ImageURL, article.AudioURL, article.VideoURL, article.PublishedAt, article.TranslatedTitle, article.IsRead, article.IsFavorite, article.IsHidden, article.IsReadLater, article.Summary, uniqueID, article.Author)
+	summary, compressedSummary, summaryCompressed, err := encodeSummary(article.Summary, db.compressionEnabled())
+	if err != nil {
+		return err
+	}
+	query := `INSERT OR IGNORE INTO articles (feed_id, title, url, image_url, audio_url, video_url, published_at, translated_title, is_read, is_favorite, is_hidden, is_read_later, read_later_at, summary, summary_compressed, summary_is_compressed, unique_id, author, images, discussion_url, added_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err = db.Exec(query, article.FeedID, article.Title, article.URL, article.ImageURL, article.AudioURL, article.VideoURL, article.PublishedAt, article.TranslatedTitle, article.IsRead, article.IsFavorite, article.IsHidden, article.IsReadLater, readLaterTimestamp(article), summary, compressedSummary, summaryCompressed, uniqueID, article.Author, encodeArticleImages(article.Images), article.DiscussionURL, time.Now())
 	return err
 }
 
 // SaveArticles saves multiple articles in a transaction.
 // Includes progressive cleanup check to prevent database from exceeding size limit during refresh.
 func (db *DB) SaveArticles(ctx context.Context, articles []*models.Article) error {
+	_, err := db.SaveArticlesCount(ctx, articles)
+	return err
+}
+
+// SaveArticlesCount is SaveArticles that also reports how many of the articles
+// were new. Articles that already exist are ignored and not counted.
+func (db *DB) SaveArticlesCount(ctx context.Context, articles []*models.Article) (int, error) {
 	db.WaitForReady()
 
 	// Progressive cleanup: check if we need to clean up before saving
@@ -45,43 +57,86 @@ func (db *DB) SaveArticles(ctx context.Context, articles []*models.Article) erro
 		}
 	}
 
+	// Read the setting before the transaction so the lookup doesn't contend with it
+	compress := db.compressionEnabled()
+
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer tx.Rollback()
 
-	stmt, err := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO articles (feed_id, title, url, image_url, audio_url, video_url, published_at, translated_title, is_read, is_favorite, is_hidden, is_read_later, summary, unique_id, author) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	stmt, err := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO articles (feed_id, title, url, image_url, audio_url, video_url, published_at, translated_title, is_read, is_favorite, is_hidden, is_read_later, read_later_at, summary, summary_compressed, summary_is_compressed, unique_id, author, images, discussion_url, added_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer stmt.Close()
 
+	addedAt := time.Now()
+	inserted := 0
 	for _, article := range articles {
 		// Check context before each insert
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return 0, ctx.Err()
 		default:
 		}
 
 		// Generate unique_id for deduplication
 		uniqueID := utils.GenerateArticleUniqueID(article.Title, article.FeedID, article.PublishedAt, article.HasValidPublishedTime)
-		_, err := stmt.ExecContext(ctx, article.FeedID, article.Title, article.URL, article.ImageURL, article.AudioURL, article.VideoURL, article.PublishedAt, article.TranslatedTitle, article.IsRead, article.IsFavorite, article.IsHidden, article.IsReadLater, article.Summary, uniqueID, article.Author)
+		summary, compressedSummary, summaryCompressed, err := encodeSummary(article.Summary, compress)
+		if err != nil {
+			log.Println("Error compressing article summary in batch:", err)
+			continue
+		}
+		result, err := stmt.ExecContext(ctx, article.FeedID, article.Title, article.URL, article.ImageURL, article.AudioURL, article.VideoURL, article.PublishedAt, article.TranslatedTitle, article.IsRead, article.IsFavorite, article.IsHidden, article.IsReadLater, readLaterTimestamp(article), summary, compressedSummary, summaryCompressed, uniqueID, article.Author, encodeArticleImages(article.Images), article.DiscussionURL, addedAt)
 		if err != nil {
 			log.Println("Error saving article in batch:", err)
 			// Continue even if one fails
+			continue
+		}
+		if n, err := result.RowsAffected(); err == nil {
+			inserted += int(n)
 		}
 	}
 
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return inserted, nil
 }
 
 // GetArticles retrieves articles with filtering, pagination, and sorting.
 func (db *DB) GetArticles(filter string, feedID int64, category string, showHidden bool, limit, offset int) ([]models.Article, error) {
+	return db.getArticles(filter, feedID, category, showHidden, limit, offset, false, "")
+}
+
+// GetArticlesCompact is GetArticles without heavy fields: the summary column is not
+// read at all, which keeps list payloads small. Use the content and summary endpoints
+// to load those for a single article.
+func (db *DB) GetArticlesCompact(filter string, feedID int64, category string, showHidden bool, limit, offset int) ([]models.Article, error) {
+	return db.getArticles(filter, feedID, category, showHidden, limit, offset, true, "")
+}
+
+// GetArticlesForList is GetArticles for the article list: pinned articles come first,
+// then, with SortFavoritesFirst, favorites, each group newest first
+func (db *DB) GetArticlesForList(filter string, feedID int64, category string, showHidden bool, sortOrder string, limit, offset int, compact bool) ([]models.Article, error) {
+	order := "a.is_pinned DESC, a.published_at DESC"
+	if sortOrder == SortFavoritesFirst {
+		order = "a.is_pinned DESC, a.is_favorite DESC, a.published_at DESC"
+	}
+	return db.getArticles(filter, feedID, category, showHidden, limit, offset, compact, order)
+}
+
+// getArticles lists articles; an empty order sorts newest first
+func (db *DB) getArticles(filter string, feedID int64, category string, showHidden bool, limit, offset int, compact bool, order string) ([]models.Article, error) {
 	db.WaitForReady()
+	summaryColumn := articleSummaryColumn
+	if compact {
+		summaryColumn = "NULL"
+	}
 	baseQuery := `
-		SELECT a.id, a.feed_id, a.title, a.url, a.image_url, a.audio_url, a.video_url, a.published_at, a.is_read, a.is_favorite, a.is_hidden, a.is_read_later, a.translated_title, a.summary, a.freshrss_item_id, f.title, a.author
+		SELECT a.id, a.feed_id, a.title, a.url, a.image_url, a.audio_url, a.video_url, a.published_at, a.is_read, a.is_favorite, a.is_hidden, a.is_read_later, COALESCE(a.is_pinned, 0), a.translated_title, ` + summaryColumn + `, a.freshrss_item_id, f.title, a.author, a.images, a.discussion_url
 		FROM articles a
 		JOIN feeds f ON a.feed_id = f.id
 	`
@@ -114,6 +169,13 @@ func (db *DB) GetArticles(filter string, feedID int64, category string, showHidd
 	if feedID > 0 {
 		whereClauses = append(whereClauses, "a.feed_id = ?")
 		args = append(args, feedID)
+
+		// Per-feed display cutoff: older articles stay stored but are not listed
+		var maxAgeDays int
+		if err := db.QueryRow("SELECT COALESCE(max_article_age_days, 0) FROM feeds WHERE id = ?", feedID).Scan(&maxAgeDays); err == nil && maxAgeDays > 0 {
+			whereClauses = append(whereClauses, "a.published_at > ?")
+			args = append(args, time.Now().AddDate(0, 0, -maxAgeDays))
+		}
 	}
 
 	if category == "\x00" {
@@ -134,7 +196,10 @@ func (db *DB) GetArticles(filter string, feedID int64, category string, showHidd
 			query += " AND " + whereClauses[i]
 		}
 	}
-	query += " ORDER BY a.published_at DESC LIMIT ? OFFSET ?"
+	if order == "" {
+		order = "a.published_at DESC"
+	}
+	query += " ORDER BY " + order + " LIMIT ? OFFSET ?"
 	args = append(args, limit, offset)
 
 	rows, err := db.Query(query, args...)
@@ -146,9 +211,10 @@ func (db *DB) GetArticles(filter string, feedID int64, category string, showHidd
 	var articles []models.Article
 	for rows.Next() {
 		var a models.Article
-		var imageURL, audioURL, videoURL, translatedTitle, summary, freshrssItemID, author sql.NullString
+		var imageURL, audioURL, videoURL, translatedTitle, freshrssItemID, author, images, discussionURL sql.NullString
+		var summary storedText
 		var publishedAt sql.NullTime
-		if err := rows.Scan(&a.ID, &a.FeedID, &a.Title, &a.URL, &imageURL, &audioURL, &videoURL, &publishedAt, &a.IsRead, &a.IsFavorite, &a.IsHidden, &a.IsReadLater, &translatedTitle, &summary, &freshrssItemID, &a.FeedTitle, &author); err != nil {
+		if err := rows.Scan(&a.ID, &a.FeedID, &a.Title, &a.URL, &imageURL, &audioURL, &videoURL, &publishedAt, &a.IsRead, &a.IsFavorite, &a.IsHidden, &a.IsReadLater, &a.IsPinned, &translatedTitle, &summary, &freshrssItemID, &a.FeedTitle, &author, &images, &discussionURL); err != nil {
 			log.Println("Error scanning article:", err)
 			continue
 		}
@@ -164,6 +230,8 @@ func (db *DB) GetArticles(filter string, feedID int64, category string, showHidd
 		a.Summary = summary.String
 		a.FreshRSSItemID = freshrssItemID.String
 		a.Author = author.String
+		a.Images = decodeArticleImages(images)
+		a.DiscussionURL = discussionURL.String
 		articles = append(articles, a)
 	}
 	return articles, nil
@@ -174,7 +242,7 @@ func (db *DB) GetArticles(filter string, feedID int64, category string, showHidd
 func (db *DB) GetArticleByID(id int64) (*models.Article, error) {
 	db.WaitForReady()
 	query := `
-		SELECT a.id, a.feed_id, a.title, a.url, a.image_url, a.audio_url, a.video_url, a.published_at, a.is_read, a.is_favorite, a.is_hidden, a.is_read_later, a.translated_title, a.summary, a.freshrss_item_id, f.title, a.author
+		SELECT a.id, a.feed_id, a.title, a.url, a.image_url, a.audio_url, a.video_url, a.published_at, a.is_read, a.is_favorite, a.is_hidden, a.is_read_later, COALESCE(a.is_pinned, 0), a.translated_title, ` + articleSummaryColumn + `, a.freshrss_item_id, f.title, a.author, a.images, a.discussion_url
 		FROM articles a
 		JOIN feeds f ON a.feed_id = f.id
 		WHERE a.id = ?
@@ -182,9 +250,10 @@ func (db *DB) GetArticleByID(id int64) (*models.Article, error) {
 	row := db.QueryRow(query, id)
 
 	var a models.Article
-	var imageURL, audioURL, videoURL, translatedTitle, summary, freshrssItemID, author sql.NullString
+	var imageURL, audioURL, videoURL, translatedTitle, freshrssItemID, author, images, discussionURL sql.NullString
+	var summary storedText
 	var publishedAt sql.NullTime
-	if err := row.Scan(&a.ID, &a.FeedID, &a.Title, &a.URL, &imageURL, &audioURL, &videoURL, &publishedAt, &a.IsRead, &a.IsFavorite, &a.IsHidden, &a.IsReadLater, &translatedTitle, &summary, &freshrssItemID, &a.FeedTitle, &author); err != nil {
+	if err := row.Scan(&a.ID, &a.FeedID, &a.Title, &a.URL, &imageURL, &audioURL, &videoURL, &publishedAt, &a.IsRead, &a.IsFavorite, &a.IsHidden, &a.IsReadLater, &a.IsPinned, &translatedTitle, &summary, &freshrssItemID, &a.FeedTitle, &author, &images, &discussionURL); err != nil {
 		return nil, err
 	}
 	a.ImageURL = imageURL.String
@@ -199,6 +268,8 @@ func (db *DB) GetArticleByID(id int64) (*models.Article, error) {
 	a.Summary = summary.String
 	a.FreshRSSItemID = freshrssItemID.String
 	a.Author = author.String
+	a.Images = decodeArticleImages(images)
+	a.DiscussionURL = discussionURL.String
 	return &a, nil
 }
 
@@ -218,7 +289,7 @@ func (db *DB) GetArticlesByIDs(ids []int64) ([]models.Article, error) {
 	}
 
 	query := `
-		SELECT a.id, a.feed_id, a.title, a.url, a.image_url, a.audio_url, a.video_url, a.published_at, a.is_read, a.is_favorite, a.is_hidden, a.is_read_later, a.translated_title, a.summary, a.freshrss_item_id, f.title, a.author
+		SELECT a.id, a.feed_id, a.title, a.url, a.image_url, a.audio_url, a.video_url, a.published_at, a.is_read, a.is_favorite, a.is_hidden, a.is_read_later, a.translated_title, ` + articleSummaryColumn + `, a.freshrss_item_id, f.title, a.author
 		FROM articles a
 		JOIN feeds f ON a.feed_id = f.id
 		WHERE a.id IN (` + strings.Join(placeholders, ",") + `)
@@ -233,7 +304,8 @@ func (db *DB) GetArticlesByIDs(ids []int64) ([]models.Article, error) {
 	articles := []models.Article{}
 	for rows.Next() {
 		var a models.Article
-		var imageURL, audioURL, videoURL, translatedTitle, summary, freshrssItemID, author sql.NullString
+		var imageURL, audioURL, videoURL, translatedTitle, freshrssItemID, author sql.NullString
+		var summary storedText
 		var publishedAt sql.NullTime
 
 		err := rows.Scan(&a.ID, &a.FeedID, &a.Title, &a.URL, &imageURL, &audioURL, &videoURL, &publishedAt, &a.IsRead, &a.IsFavorite, &a.IsHidden, &a.IsReadLater, &translatedTitle, &summary, &freshrssItemID, &a.FeedTitle, &author)
@@ -266,19 +338,109 @@ func (db *DB) GetArticlesByIDs(ids []int64) ([]models.Article, error) {
 
 // MarkArticleRead marks an article as read or unread.
 // When marking as read, also removes from read later list.
+// An explicit mark replaces any earlier auto-read.
 func (db *DB) MarkArticleRead(id int64, read bool) error {
 	db.WaitForReady()
 	isRead := 0
 	if read {
 		isRead = 1
 		// When marking as read, also remove from read later
-		_, err := db.Exec("UPDATE articles SET is_read = 1, is_read_later = 0 WHERE id = ?", id)
+		_, err := db.Exec("UPDATE articles SET is_read = 1, is_read_later = 0, read_later_at = NULL, read_at = ?, auto_read = 0 WHERE id = ?", time.Now().UTC(), id)
 		return err
 	}
-	_, err := db.Exec("UPDATE articles SET is_read = ? WHERE id = ?", isRead, id)
+	_, err := db.Exec("UPDATE articles SET is_read = ?, read_at = NULL, auto_read = 0 WHERE id = ?", isRead, id)
 	return err
 }
 
+// MarkArticleAutoRead marks an unread article as read because it stayed in view for the
+// configured dwell time, flagging it as auto-read so these marks can be told apart from
+// manual ones and undone. Articles that are already read are left untouched.
+func (db *DB) MarkArticleAutoRead(id int64) error {
+	db.WaitForReady()
+	_, err := db.Exec("UPDATE articles SET is_read = 1, is_read_later = 0, read_later_at = NULL, read_at = ?, auto_read = 1 WHERE id = ? AND is_read = 0", time.Now().UTC(), id)
+	return err
+}
+
+// GetRecentlyRead retrieves articles marked as read since the given time,
+// most recently read first.
+func (db *DB) GetRecentlyRead(since time.Time, limit, offset int) ([]models.Article, error) {
+	db.WaitForReady()
+	query := `
+		SELECT a.id, a.feed_id, a.title, a.url, a.image_url, a.audio_url, a.video_url, a.published_at, a.is_read, a.is_favorite, a.is_hidden, a.is_read_later, a.translated_title, ` + articleSummaryColumn + `, a.freshrss_item_id, f.title, a.author
+		FROM articles a
+		JOIN feeds f ON a.feed_id = f.id
+		WHERE a.is_read = 1 AND a.read_at IS NOT NULL AND a.read_at >= ?
+		ORDER BY a.read_at DESC LIMIT ? OFFSET ?
+	`
+	rows, err := db.Query(query, since.UTC(), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var articles []models.Article
+	for rows.Next() {
+		var a models.Article
+		var imageURL, audioURL, videoURL, translatedTitle, freshrssItemID, author sql.NullString
+		var summary storedText
+		var publishedAt sql.NullTime
+		if err := rows.Scan(&a.ID, &a.FeedID, &a.Title, &a.URL, &imageURL, &audioURL, &videoURL, &publishedAt, &a.IsRead, &a.IsFavorite, &a.IsHidden, &a.IsReadLater, &translatedTitle, &summary, &freshrssItemID, &a.FeedTitle, &author); err != nil {
+			log.Println("Error scanning article:", err)
+			continue
+		}
+		a.ImageURL = imageURL.String
+		a.AudioURL = audioURL.String
+		a.VideoURL = videoURL.String
+		if publishedAt.Valid {
+			a.PublishedAt = publishedAt.Time
+		}
+		a.TranslatedTitle = translatedTitle.String
+		a.Summary = summary.String
+		a.FreshRSSItemID = freshrssItemID.String
+		a.Author = author.String
+		articles = append(articles, a)
+	}
+	return articles, rows.Err()
+}
+
+const (
+	// SimilarReadWindow is how far back FindSimilarRead looks for already-read articles
+	SimilarReadWindow = 7 * 24 * time.Hour
+	// SimilarReadThreshold is the minimum title similarity for two articles to count as the same story
+	SimilarReadThreshold = 0.6
+	// similarReadCandidates caps how many recently read articles are compared
+	similarReadCandidates = 500
+)
+
+// FindSimilarRead returns a recently read article that most likely covers the same story
+// as article, judged by normalized title overlap, along with its similarity score.
+// Returns nil if no read article within SimilarReadWindow reaches SimilarReadThreshold.
+func (db *DB) FindSimilarRead(article models.Article) (*models.Article, float64, error) {
+	target := utils.TitleTokens(article.Title)
+	if len(target) == 0 {
+		return nil, 0, nil
+	}
+
+	candidates, err := db.GetRecentlyRead(time.Now().Add(-SimilarReadWindow), similarReadCandidates, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var best *models.Article
+	bestScore := 0.0
+	for i := range candidates {
+		if candidates[i].ID == article.ID {
+			continue
+		}
+		score := utils.TokenSimilarity(target, utils.TitleTokens(candidates[i].Title))
+		if score >= SimilarReadThreshold && score > bestScore {
+			best = &candidates[i]
+			bestScore = score
+		}
+	}
+	return best, bestScore, nil
+}
+
 // ToggleFavorite toggles the favorite status of an article.
 func (db *DB) ToggleFavorite(id int64) error {
 	db.WaitForReady()
@@ -313,10 +475,52 @@ func (db *DB) ClearAllTranslations() error {
 	return err
 }
 
-// ClearAllSummaries clears all summaries from articles.
+// ClearTranslationsFor clears the translated titles of a feed's articles (feedID > 0) and/or
+// those translated into lang, along with the matching translation cache entries so they are
+// translated afresh. A feed's cache entries are those of its titles and the full-text
+// translations recorded for it. Titles do not record their language, so with lang set only titles found
+// in the cache under that language are cleared. Without either scope everything is cleared.
+// It returns the number of articles whose title translation was cleared.
+func (db *DB) ClearTranslationsFor(feedID int64, lang string) (int64, error) {
+	db.WaitForReady()
+
+	var articleConds, cacheConds []string
+	var articleArgs, cacheArgs []interface{}
+	if feedID > 0 {
+		articleConds = append(articleConds, "feed_id = ?")
+		articleArgs = append(articleArgs, feedID)
+		cacheConds = append(cacheConds, "(feed_id = ? OR source_text IN (SELECT title FROM articles WHERE feed_id = ?))")
+		cacheArgs = append(cacheArgs, feedID, feedID)
+	}
+	if lang != "" {
+		articleConds = append(articleConds, "translated_title IN (SELECT translated_text FROM translation_cache WHERE target_lang = ?)")
+		articleArgs = append(articleArgs, lang)
+		cacheConds = append(cacheConds, "target_lang = ?")
+		cacheArgs = append(cacheArgs, lang)
+	}
+
+	articleQuery := "UPDATE articles SET translated_title = '' WHERE translated_title != ''"
+	cacheQuery := "DELETE FROM translation_cache"
+	if len(articleConds) > 0 {
+		articleQuery += " AND " + strings.Join(articleConds, " AND ")
+		cacheQuery += " WHERE " + strings.Join(cacheConds, " AND ")
+	}
+
+	// Clear titles first: matching them by language needs the cache entries
+	result, err := db.Exec(articleQuery, articleArgs...)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := db.Exec(cacheQuery, cacheArgs...); err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// ClearAllSummaries clears all summaries from articles, including AI summaries.
 func (db *DB) ClearAllSummaries() error {
 	db.WaitForReady()
-	_, err := db.Exec("UPDATE articles SET summary = ''")
+	_, err := db.Exec("UPDATE articles SET summary = '', summary_compressed = NULL, summary_is_compressed = 0, ai_summary = ''")
 	return err
 }
 
@@ -333,6 +537,18 @@ func (db *DB) ToggleArticleHidden(id int64) error {
 	return err
 }
 
+// ToggleArticlePinned toggles whether an article is pinned to the top of article lists.
+func (db *DB) ToggleArticlePinned(id int64) error {
+	db.WaitForReady()
+	var isPinned bool
+	err := db.QueryRow("SELECT COALESCE(is_pinned, 0) FROM articles WHERE id = ?", id).Scan(&isPinned)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec("UPDATE articles SET is_pinned = ? WHERE id = ?", !isPinned, id)
+	return err
+}
+
 // SetArticleHidden sets the hidden status of an article.
 func (db *DB) SetArticleHidden(id int64, hidden bool) error {
 	db.WaitForReady()
@@ -353,9 +569,9 @@ func (db *DB) ToggleReadLater(id int64) error {
 	newState := !isReadLater
 	// If adding to read later, also mark as unread
 	if newState {
-		_, err = db.Exec("UPDATE articles SET is_read_later = 1, is_read = 0 WHERE id = ?", id)
+		_, err = db.Exec("UPDATE articles SET is_read_later = 1, read_later_at = ?, is_read = 0, read_at = NULL, auto_read = 0 WHERE id = ?", time.Now().UTC(), id)
 	} else {
-		_, err = db.Exec("UPDATE articles SET is_read_later = 0 WHERE id = ?", id)
+		_, err = db.Exec("UPDATE articles SET is_read_later = 0, read_later_at = NULL WHERE id = ?", id)
 	}
 	return err
 }
@@ -366,20 +582,108 @@ func (db *DB) SetArticleReadLater(id int64, readLater bool) error {
 	db.WaitForReady()
 	// If adding to read later, also mark as unread
 	if readLater {
-		_, err := db.Exec("UPDATE articles SET is_read_later = 1, is_read = 0 WHERE id = ?", id)
+		_, err := db.Exec("UPDATE articles SET is_read_later = 1, read_later_at = ?, is_read = 0, read_at = NULL, auto_read = 0 WHERE id = ?", time.Now().UTC(), id)
 		return err
 	}
-	_, err := db.Exec("UPDATE articles SET is_read_later = 0 WHERE id = ?", id)
+	_, err := db.Exec("UPDATE articles SET is_read_later = 0, read_later_at = NULL WHERE id = ?", id)
 	return err
 }
 
+// readLaterTimestamp returns the read_later_at value for a newly saved article,
+// which is queued on arrival when its feed adds articles to read later by default
+func readLaterTimestamp(article *models.Article) interface{} {
+	if !article.IsReadLater {
+		return nil
+	}
+	return time.Now().UTC()
+}
+
+// encodeArticleImages serializes gallery image URLs for the images column; no images is NULL
+func encodeArticleImages(images []string) interface{} {
+	if len(images) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(images)
+	if err != nil {
+		return nil
+	}
+	return string(data)
+}
+
+// decodeArticleImages reverses encodeArticleImages, ignoring malformed values
+func decodeArticleImages(value sql.NullString) []string {
+	if !value.Valid || value.String == "" {
+		return nil
+	}
+	var images []string
+	if err := json.Unmarshal([]byte(value.String), &images); err != nil {
+		return nil
+	}
+	return images
+}
+
+// GetReadLaterArticles returns the read-later queue in the order articles were added,
+// oldest first.
+func (db *DB) GetReadLaterArticles(limit, offset int) ([]models.Article, error) {
+	db.WaitForReady()
+	query := `
+		SELECT a.id, a.feed_id, a.title, a.url, a.image_url, a.audio_url, a.video_url, a.published_at, a.is_read, a.is_favorite, a.is_hidden, a.is_read_later, a.read_later_at, a.translated_title, ` + articleSummaryColumn + `, a.freshrss_item_id, f.title, a.author
+		FROM articles a
+		JOIN feeds f ON a.feed_id = f.id
+		WHERE a.is_read_later = 1 AND a.is_hidden = 0
+		ORDER BY COALESCE(a.read_later_at, a.published_at) ASC, a.id ASC LIMIT ? OFFSET ?
+	`
+	rows, err := db.Query(query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var articles []models.Article
+	for rows.Next() {
+		var a models.Article
+		var imageURL, audioURL, videoURL, translatedTitle, freshrssItemID, author sql.NullString
+		var summary storedText
+		var publishedAt, readLaterAt sql.NullTime
+		if err := rows.Scan(&a.ID, &a.FeedID, &a.Title, &a.URL, &imageURL, &audioURL, &videoURL, &publishedAt, &a.IsRead, &a.IsFavorite, &a.IsHidden, &a.IsReadLater, &readLaterAt, &translatedTitle, &summary, &freshrssItemID, &a.FeedTitle, &author); err != nil {
+			log.Println("Error scanning article:", err)
+			continue
+		}
+		a.ImageURL = imageURL.String
+		a.AudioURL = audioURL.String
+		a.VideoURL = videoURL.String
+		if publishedAt.Valid {
+			a.PublishedAt = publishedAt.Time
+		}
+		if readLaterAt.Valid {
+			a.ReadLaterAt = &readLaterAt.Time
+		}
+		a.TranslatedTitle = translatedTitle.String
+		a.Summary = summary.String
+		a.FreshRSSItemID = freshrssItemID.String
+		a.Author = author.String
+		articles = append(articles, a)
+	}
+	return articles, rows.Err()
+}
+
 // UpdateArticleContent updates the content field for an article.
+// It holds the snippet of articles fetched in metadata-only mode.
 func (db *DB) UpdateArticleContent(id int64, content string) error {
 	db.WaitForReady()
 	_, err := db.Exec("UPDATE articles SET content = ? WHERE id = ?", content, id)
 	return err
 }
 
+// GetArticleSnippet returns the snippet stored for an article fetched in metadata-only mode,
+// empty for other articles.
+func (db *DB) GetArticleSnippet(id int64) (string, error) {
+	db.WaitForReady()
+	var snippet sql.NullString
+	err := db.QueryRow("SELECT content FROM articles WHERE id = ?", id).Scan(&snippet)
+	return snippet.String, err
+}
+
 // GetTotalUnreadCount returns the total number of unread articles.
 func (db *DB) GetTotalUnreadCount() (int, error) {
 	db.WaitForReady()
@@ -429,6 +733,53 @@ func (db *DB) GetUnreadCountsForAllFeeds() (map[int64]int, error) {
 	return counts, rows.Err()
 }
 
+// ArticleCounts holds the unread and total number of visible articles of a feed or category
+type ArticleCounts struct {
+	Unread int `json:"unread"`
+	Total  int `json:"total"`
+}
+
+// GetArticleCountsForAllFeeds returns unread and total article counts per feed and rolled up
+// per category, computed in a single grouped query. Feeds without a category are counted
+// under "uncategorized".
+func (db *DB) GetArticleCountsForAllFeeds() (map[int64]ArticleCounts, map[string]ArticleCounts, error) {
+	db.WaitForReady()
+	rows, err := db.Query(`
+		SELECT a.feed_id, COALESCE(f.category, ''),
+			SUM(CASE WHEN a.is_read = 0 THEN 1 ELSE 0 END), COUNT(*)
+		FROM articles a
+		JOIN feeds f ON f.id = a.feed_id
+		WHERE a.is_hidden = 0
+		GROUP BY a.feed_id
+	`)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	feedCounts := make(map[int64]ArticleCounts)
+	categoryCounts := make(map[string]ArticleCounts)
+	for rows.Next() {
+		var feedID int64
+		var category string
+		var counts ArticleCounts
+		if err := rows.Scan(&feedID, &category, &counts.Unread, &counts.Total); err != nil {
+			log.Println("Error scanning article counts:", err)
+			continue
+		}
+		feedCounts[feedID] = counts
+
+		if category == "" {
+			category = "uncategorized"
+		}
+		rollup := categoryCounts[category]
+		rollup.Unread += counts.Unread
+		rollup.Total += counts.Total
+		categoryCounts[category] = rollup
+	}
+	return feedCounts, categoryCounts, rows.Err()
+}
+
 // GetFavoriteCountsForAllFeeds returns a map of feed_id to favorite article count.
 func (db *DB) GetFavoriteCountsForAllFeeds() (map[int64]int, error) {
 	db.WaitForReady()
@@ -594,39 +945,41 @@ func (db *DB) GetReadLaterUnreadCountsForAllFeeds() (map[int64]int, error) {
 // MarkAllAsReadForFeed marks all articles in a feed as read.
 func (db *DB) MarkAllAsReadForFeed(feedID int64) error {
 	db.WaitForReady()
-	_, err := db.Exec("UPDATE articles SET is_read = 1 WHERE feed_id = ? AND is_hidden = 0", feedID)
+	_, err := db.Exec("UPDATE articles SET is_read = 1, read_at = ? WHERE feed_id = ? AND is_hidden = 0 AND is_read = 0", time.Now().UTC(), feedID)
 	return err
 }
 
 // MarkAllAsRead marks all articles as read.
 func (db *DB) MarkAllAsRead() error {
 	db.WaitForReady()
-	_, err := db.Exec("UPDATE articles SET is_read = 1 WHERE is_hidden = 0")
+	_, err := db.Exec("UPDATE articles SET is_read = 1, read_at = ? WHERE is_hidden = 0 AND is_read = 0", time.Now().UTC())
 	return err
 }
 
-// MarkAllAsReadForCategory marks all articles in a category as read.
+// MarkAllAsReadForCategory marks all articles in a category and its subcategories as read.
+// An empty category marks the uncategorized feeds.
 func (db *DB) MarkAllAsReadForCategory(category string) error {
 	db.WaitForReady()
-	// Get all feed IDs in this category
-	// Handle empty category (uncategorized) by matching NULL or empty string
-	var query string
+	feedFilter, args := categoryFeedFilter(category)
+	query := `UPDATE articles SET is_read = 1, read_at = ?
+		WHERE feed_id IN (SELECT id FROM feeds WHERE ` + feedFilter + `) AND is_hidden = 0 AND is_read = 0`
+	_, err := db.Exec(query, append([]interface{}{time.Now().UTC()}, args...)...)
+	return err
+}
+
+// categoryFeedFilter returns the feeds condition selecting a category and its subcategories,
+// or the uncategorized feeds when category is empty
+func categoryFeedFilter(category string) (string, []interface{}) {
 	if category == "" {
-		query = `UPDATE articles SET is_read = 1
-			WHERE feed_id IN (SELECT id FROM feeds WHERE category IS NULL OR category = '') AND is_hidden = 0`
-		_, err := db.Exec(query)
-		return err
+		return "(category IS NULL OR category = '')", nil
 	}
-	query = `UPDATE articles SET is_read = 1
-		WHERE feed_id IN (SELECT id FROM feeds WHERE category = ?) AND is_hidden = 0`
-	_, err := db.Exec(query, category)
-	return err
+	return "(category = ? OR category LIKE ?)", []interface{}{category, category + "/%"}
 }
 
 // ClearReadLater removes all articles from the read later list.
 func (db *DB) ClearReadLater() error {
 	db.WaitForReady()
-	_, err := db.Exec("UPDATE articles SET is_read_later = 0 WHERE is_read_later = 1")
+	_, err := db.Exec("UPDATE articles SET is_read_later = 0, read_later_at = NULL WHERE is_read_later = 1")
 	return err
 }
 
@@ -649,8 +1002,8 @@ func (db *DB) MarkArticlesRelativeToPublishedTime(referencePublishedAt time.Time
 		return 0, fmt.Errorf("invalid direction: %s", direction)
 	}
 
-	baseQuery := "UPDATE articles SET is_read = 1 WHERE is_read = 0 AND is_hidden = 0 AND published_at IS NOT NULL AND published_at " + operator + " ?"
-	args := []interface{}{referencePublishedAt}
+	baseQuery := "UPDATE articles SET is_read = 1, read_at = ? WHERE is_read = 0 AND is_hidden = 0 AND published_at IS NOT NULL AND published_at " + operator + " ?"
+	args := []interface{}{time.Now().UTC(), referencePublishedAt}
 
 	if feedID > 0 {
 		baseQuery += " AND feed_id = ?"
@@ -687,7 +1040,7 @@ func (db *DB) MarkArticlesRelativeToPublishedTime(referencePublishedAt time.Time
 func (db *DB) GetImageGalleryArticles(feedID int64, category string, showHidden bool, limit, offset int) ([]models.Article, error) {
 	db.WaitForReady()
 	baseQuery := `
-		SELECT a.id, a.feed_id, a.title, a.url, a.image_url, a.audio_url, a.video_url, a.published_at, a.is_read, a.is_favorite, a.is_hidden, a.is_read_later, a.translated_title, a.summary, f.title, a.author
+		SELECT a.id, a.feed_id, a.title, a.url, a.image_url, a.audio_url, a.video_url, a.published_at, a.is_read, a.is_favorite, a.is_hidden, a.is_read_later, a.translated_title, ` + articleSummaryColumn + `, f.title, a.author
 		FROM articles a
 		JOIN feeds f ON a.feed_id = f.id
 		WHERE COALESCE(f.is_image_mode, 0) = 1
@@ -728,7 +1081,8 @@ func (db *DB) GetImageGalleryArticles(feedID int64, category string, showHidden
 	articles := make([]models.Article, 0)
 	for rows.Next() {
 		var a models.Article
-		var imageURL, audioURL, videoURL, translatedTitle, summary, author sql.NullString
+		var imageURL, audioURL, videoURL, translatedTitle, author sql.NullString
+		var summary storedText
 		var publishedAt sql.NullTime
 		if err := rows.Scan(&a.ID, &a.FeedID, &a.Title, &a.URL, &imageURL, &audioURL, &videoURL, &publishedAt, &a.IsRead, &a.IsFavorite, &a.IsHidden, &a.IsReadLater, &translatedTitle, &summary, &a.FeedTitle, &author); err != nil {
 			log.Println("Error scanning article:", err)
@@ -751,9 +1105,41 @@ func (db *DB) GetImageGalleryArticles(feedID int64, category string, showHidden
 }
 
 // UpdateArticleSummary updates the cached summary for an article.
+// When compress_article_content is enabled the summary is stored gzip-compressed.
 func (db *DB) UpdateArticleSummary(id int64, summary string) error {
 	db.WaitForReady()
-	_, err := db.Exec("UPDATE articles SET summary = ? WHERE id = ?", summary, id)
+	text, compressed, isCompressed, err := encodeSummary(summary, db.compressionEnabled())
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(
+		"UPDATE articles SET summary = ?, summary_compressed = ?, summary_is_compressed = ? WHERE id = ?",
+		text, compressed, isCompressed, id,
+	)
+	return err
+}
+
+// GetArticleSummary returns the cached summary for an article, or "" if there is none.
+// Compressed summaries are decompressed transparently.
+func (db *DB) GetArticleSummary(id int64) (string, error) {
+	db.WaitForReady()
+	var summary storedText
+	err := db.QueryRow("SELECT "+articleSummaryColumn+" FROM articles a WHERE a.id = ?", id).Scan(&summary)
+	return summary.String, err
+}
+
+// GetArticleAISummary returns the cached AI summary for an article, or "" if there is none.
+func (db *DB) GetArticleAISummary(id int64) (string, error) {
+	db.WaitForReady()
+	var summary sql.NullString
+	err := db.QueryRow("SELECT ai_summary FROM articles WHERE id = ?", id).Scan(&summary)
+	return summary.String, err
+}
+
+// UpdateArticleAISummary updates the cached AI summary for an article.
+func (db *DB) UpdateArticleAISummary(id int64, summary string) error {
+	db.WaitForReady()
+	_, err := db.Exec("UPDATE articles SET ai_summary = ? WHERE id = ?", summary, id)
 	return err
 }
 
@@ -770,3 +1156,21 @@ func (db *DB) GetArticleIDByUniqueID(title string, feedID int64, publishedAt tim
 	}
 	return id, nil
 }
+
+// GetFeedArticlePublishedByURLTitle returns the publish time of a stored article of the
+// feed with the given URL and title, reporting false if there is none
+func (db *DB) GetFeedArticlePublishedByURLTitle(feedID int64, url, title string) (time.Time, bool, error) {
+	db.WaitForReady()
+	var publishedAt sql.NullTime
+	err := db.QueryRow(
+		`SELECT published_at FROM articles WHERE feed_id = ? AND url = ? AND title = ? ORDER BY id DESC LIMIT 1`,
+		feedID, url, title,
+	).Scan(&publishedAt)
+	if err == sql.ErrNoRows {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return publishedAt.Time, true, nil
+}
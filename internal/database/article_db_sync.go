@@ -3,6 +3,7 @@ package database
 import (
 	"database/sql"
 	"log"
+	"time"
 )
 
 // This file adds FreshRSS sync tracking to article operations
@@ -17,6 +18,25 @@ type SyncRequest struct {
 
 // MarkArticleReadWithSync marks an article as read/unread and returns sync request if FreshRSS is enabled
 func (db *DB) MarkArticleReadWithSync(id int64, read bool) (*SyncRequest, error) {
+	return db.markReadWithSync(id, read, func() error { return db.MarkArticleRead(id, read) })
+}
+
+// MarkArticleAutoReadWithSync auto-reads an article (see MarkArticleAutoRead) and returns
+// sync request if FreshRSS is enabled. FreshRSS has no notion of auto-read, so it just sees a read.
+func (db *DB) MarkArticleAutoReadWithSync(id int64) (*SyncRequest, error) {
+	var isRead bool
+	if err := db.QueryRow("SELECT is_read FROM articles WHERE id = ?", id).Scan(&isRead); err != nil {
+		return nil, err
+	}
+	if isRead {
+		// Nothing changes, so there is nothing to sync or count
+		return nil, nil
+	}
+	return db.markReadWithSync(id, true, func() error { return db.MarkArticleAutoRead(id) })
+}
+
+// markReadWithSync applies mark and builds the FreshRSS sync request for the new read state
+func (db *DB) markReadWithSync(id int64, read bool, mark func() error) (*SyncRequest, error) {
 	// Get article URL and feed_id first
 	var url string
 	var feedID int64
@@ -26,7 +46,7 @@ func (db *DB) MarkArticleReadWithSync(id int64, read bool) (*SyncRequest, error)
 	}
 
 	// Mark as read
-	err = db.MarkArticleRead(id, read)
+	err = mark()
 	if err != nil {
 		return nil, err
 	}
@@ -51,11 +71,15 @@ func (db *DB) MarkArticleReadWithSync(id int64, read bool) (*SyncRequest, error)
 			action = SyncActionMarkUnread
 		}
 		log.Printf("[FreshRSS Sync] Article %d needs sync: %s", id, action)
-		return &SyncRequest{
+		req := SyncRequest{
 			ArticleID:  id,
 			ArticleURL: url,
 			Action:     action,
-		}, nil
+		}
+		if err := db.recordLocalChanges([]SyncRequest{req}); err != nil {
+			return nil, err
+		}
+		return &req, nil
 	}
 
 	return nil, nil
@@ -92,11 +116,15 @@ func (db *DB) SetArticleFavoriteWithSync(id int64, favorite bool) (*SyncRequest,
 			action = SyncActionUnstar
 		}
 		log.Printf("[FreshRSS Sync] Article %d needs sync: %s", id, action)
-		return &SyncRequest{
+		req := SyncRequest{
 			ArticleID:  id,
 			ArticleURL: url,
 			Action:     action,
-		}, nil
+		}
+		if err := db.recordLocalChanges([]SyncRequest{req}); err != nil {
+			return nil, err
+		}
+		return &req, nil
 	}
 
 	return nil, nil
@@ -140,22 +168,43 @@ func (db *DB) ToggleFavoriteWithSync(id int64) (*SyncRequest, error) {
 			action = SyncActionUnstar
 		}
 		log.Printf("[FreshRSS Sync] Article %d needs sync: %s", id, action)
-		return &SyncRequest{
+		req := SyncRequest{
 			ArticleID:  id,
 			ArticleURL: url,
 			Action:     action,
-		}, nil
+		}
+		if err := db.recordLocalChanges([]SyncRequest{req}); err != nil {
+			return nil, err
+		}
+		return &req, nil
 	}
 
 	return nil, nil
 }
 
+// recordLocalChanges stores when the synced states of the articles were changed locally,
+// see Article.ReadChangedAt
+func (db *DB) recordLocalChanges(requests []SyncRequest) error {
+	now := time.Now().Unix()
+	for _, req := range requests {
+		column := "read_changed_at"
+		if req.Action == SyncActionStar || req.Action == SyncActionUnstar {
+			column = "favorite_changed_at"
+		}
+		if _, err := db.Exec("UPDATE articles SET "+column+" = ? WHERE id = ?", now, req.ArticleID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetArticleByURL retrieves an article by its URL for sync purposes
 func (db *DB) GetArticleByURL(url string) (*Article, error) {
 	db.WaitForReady()
 
 	query := `
-		SELECT id, feed_id, title, url, is_read, is_favorite, published_at, freshrss_item_id
+		SELECT id, feed_id, title, url, is_read, is_favorite, published_at, freshrss_item_id,
+			read_changed_at, favorite_changed_at
 		FROM articles
 		WHERE url = ?
 		LIMIT 1
@@ -164,6 +213,7 @@ func (db *DB) GetArticleByURL(url string) (*Article, error) {
 	var article Article
 	var publishedAt interface{}
 	var freshRSSItemID sql.NullString
+	var readChangedAt, favoriteChangedAt sql.NullInt64
 	err := db.QueryRow(query, url).Scan(
 		&article.ID,
 		&article.FeedID,
@@ -173,6 +223,8 @@ func (db *DB) GetArticleByURL(url string) (*Article, error) {
 		&article.IsFavorite,
 		&publishedAt,
 		&freshRSSItemID,
+		&readChangedAt,
+		&favoriteChangedAt,
 	)
 
 	if err != nil {
@@ -180,6 +232,12 @@ func (db *DB) GetArticleByURL(url string) (*Article, error) {
 	}
 
 	article.FreshRSSItemID = freshRSSItemID.String
+	if readChangedAt.Valid {
+		article.ReadChangedAt = time.Unix(readChangedAt.Int64, 0)
+	}
+	if favoriteChangedAt.Valid {
+		article.FavoriteChangedAt = time.Unix(favoriteChangedAt.Int64, 0)
+	}
 	return &article, nil
 }
 
@@ -193,6 +251,10 @@ type Article struct {
 	IsFavorite     bool
 	PublishedAt    interface{}
 	FreshRSSItemID string
+	// ReadChangedAt and FavoriteChangedAt are when the user last changed the read and
+	// star states of a FreshRSS article; zero if never
+	ReadChangedAt     time.Time
+	FavoriteChangedAt time.Time
 }
 
 // MarkArticlesReadWithSync marks multiple articles as read and returns sync requests if FreshRSS is enabled
@@ -222,8 +284,13 @@ func (db *DB) MarkArticlesReadWithSync(ids []int64, read bool) ([]SyncRequest, e
 		isRead = 1
 	}
 
+	var readAt interface{}
+	if read {
+		readAt = time.Now().UTC()
+	}
+
 	for _, id := range ids {
-		_, err := db.Exec("UPDATE articles SET is_read = ? WHERE id = ?", isRead, id)
+		_, err := db.Exec("UPDATE articles SET is_read = ?, read_at = ?, auto_read = 0 WHERE id = ?", isRead, readAt, id)
 		if err != nil {
 			return nil, err
 		}
@@ -254,6 +321,51 @@ func (db *DB) MarkArticlesReadWithSync(ids []int64, read bool) ([]SyncRequest, e
 		}
 	}
 
+	if err := db.recordLocalChanges(syncRequests); err != nil {
+		return nil, err
+	}
+	return syncRequests, nil
+}
+
+// MarkAllAsReadForCategoryWithSync marks all articles in a category and its subcategories
+// as read, returning sync requests for the affected FreshRSS articles if FreshRSS is enabled
+func (db *DB) MarkAllAsReadForCategoryWithSync(category string) ([]SyncRequest, error) {
+	db.WaitForReady()
+
+	var syncRequests []SyncRequest
+	enabled, _ := db.GetSetting("freshrss_enabled")
+	if enabled == "true" {
+		// Collect the unread FreshRSS articles before they are marked
+		feedFilter, args := categoryFeedFilter(category)
+		rows, err := db.Query(`SELECT id, url FROM articles
+			WHERE feed_id IN (SELECT id FROM feeds WHERE `+feedFilter+` AND COALESCE(is_freshrss_source, 0) = 1)
+			AND is_hidden = 0 AND is_read = 0`, args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			req := SyncRequest{Action: SyncActionMarkRead}
+			if err := rows.Scan(&req.ArticleID, &req.ArticleURL); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			syncRequests = append(syncRequests, req)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := db.MarkAllAsReadForCategory(category); err != nil {
+		return nil, err
+	}
+	if err := db.recordLocalChanges(syncRequests); err != nil {
+		return nil, err
+	}
+	if len(syncRequests) > 0 {
+		log.Printf("[FreshRSS Sync] %d articles in category %q need sync: %s", len(syncRequests), category, SyncActionMarkRead)
+	}
 	return syncRequests, nil
 }
 
@@ -3,6 +3,7 @@ package database_test
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
@@ -323,3 +324,363 @@ func TestArticleDifferentTitlesNotDeduplicated(t *testing.T) {
 		t.Fatalf("expected 2 articles with different titles, got %d", len(articles))
 	}
 }
+
+func TestGetRecentlyRead(t *testing.T) {
+	db := setupDBWithFeed(t)
+
+	var feedID int64
+	_ = db.QueryRow(`SELECT id FROM feeds WHERE url = ?`, "https://example.com/feed").Scan(&feedID)
+
+	var ids []int64
+	for i := 0; i < 3; i++ {
+		res, err := db.Exec(`INSERT INTO articles (feed_id, title, url, published_at) VALUES (?, ?, ?, ?)`, feedID, fmt.Sprintf("A%d", i), fmt.Sprintf("u%d", i), time.Now())
+		if err != nil {
+			t.Fatalf("insert article: %v", err)
+		}
+		id, _ := res.LastInsertId()
+		ids = append(ids, id)
+	}
+
+	// Read the first two, then read one of them long ago
+	for _, id := range ids[:2] {
+		if err := db.MarkArticleRead(id, true); err != nil {
+			t.Fatalf("MarkArticleRead error: %v", err)
+		}
+	}
+	if _, err := db.Exec(`UPDATE articles SET read_at = ? WHERE id = ?`, time.Now().Add(-48*time.Hour).UTC(), ids[0]); err != nil {
+		t.Fatalf("backdate read_at: %v", err)
+	}
+
+	articles, err := db.GetRecentlyRead(time.Now().Add(-24*time.Hour), 10, 0)
+	if err != nil {
+		t.Fatalf("GetRecentlyRead error: %v", err)
+	}
+	if len(articles) != 1 || articles[0].ID != ids[1] {
+		t.Fatalf("expected only article %d, got %+v", ids[1], articles)
+	}
+
+	// Marking unread removes it from the stream
+	if err := db.MarkArticleRead(ids[1], false); err != nil {
+		t.Fatalf("MarkArticleRead error: %v", err)
+	}
+	articles, err = db.GetRecentlyRead(time.Now().Add(-24*time.Hour), 10, 0)
+	if err != nil {
+		t.Fatalf("GetRecentlyRead error: %v", err)
+	}
+	if len(articles) != 0 {
+		t.Fatalf("expected no recently read articles, got %d", len(articles))
+	}
+}
+
+func TestGetArticlesCompact(t *testing.T) {
+	db := setupDBWithFeed(t)
+
+	var feedID int64
+	if err := db.QueryRow(`SELECT id FROM feeds WHERE url = ?`, "https://example.com/feed").Scan(&feedID); err != nil {
+		t.Fatalf("scan feed id: %v", err)
+	}
+	a := &models.Article{FeedID: feedID, Title: "Long", URL: "https://example.com/long", PublishedAt: time.Now()}
+	if err := db.SaveArticle(a); err != nil {
+		t.Fatalf("SaveArticle: %v", err)
+	}
+	full, err := db.GetArticles("", feedID, "", false, 10, 0)
+	if err != nil || len(full) != 1 {
+		t.Fatalf("GetArticles: %v (%d)", err, len(full))
+	}
+	if err := db.UpdateArticleSummary(full[0].ID, "a long cached summary"); err != nil {
+		t.Fatalf("UpdateArticleSummary: %v", err)
+	}
+
+	full, _ = db.GetArticles("", feedID, "", false, 10, 0)
+	if full[0].Summary == "" {
+		t.Fatal("expected summary in full listing")
+	}
+	compact, err := db.GetArticlesCompact("", feedID, "", false, 10, 0)
+	if err != nil || len(compact) != 1 {
+		t.Fatalf("GetArticlesCompact: %v (%d)", err, len(compact))
+	}
+	if compact[0].Summary != "" || compact[0].Title != "Long" {
+		t.Fatalf("unexpected compact article: %+v", compact[0])
+	}
+}
+
+func TestGetArticlesForListOrdering(t *testing.T) {
+	db := setupDBWithFeed(t)
+
+	var feedID int64
+	if err := db.QueryRow(`SELECT id FROM feeds WHERE url = ?`, "https://example.com/feed").Scan(&feedID); err != nil {
+		t.Fatalf("scan feed id: %v", err)
+	}
+	now := time.Now()
+	for i, title := range []string{"Oldest", "Favorite", "Newest"} {
+		a := &models.Article{FeedID: feedID, Title: title, URL: "https://example.com/" + title, PublishedAt: now.Add(time.Duration(i) * time.Hour)}
+		if err := db.SaveArticle(a); err != nil {
+			t.Fatalf("SaveArticle: %v", err)
+		}
+	}
+	ids := map[string]int64{}
+	articles, _ := db.GetArticles("", feedID, "", false, 10, 0)
+	for _, a := range articles {
+		ids[a.Title] = a.ID
+	}
+	if err := db.ToggleArticlePinned(ids["Oldest"]); err != nil {
+		t.Fatalf("ToggleArticlePinned: %v", err)
+	}
+	if err := db.ToggleFavorite(ids["Favorite"]); err != nil {
+		t.Fatalf("ToggleFavorite: %v", err)
+	}
+
+	titles := func(sortOrder string) []string {
+		t.Helper()
+		list, err := db.GetArticlesForList("", feedID, "", false, sortOrder, 10, 0, false)
+		if err != nil {
+			t.Fatalf("GetArticlesForList: %v", err)
+		}
+		var out []string
+		for _, a := range list {
+			out = append(out, a.Title)
+		}
+		return out
+	}
+	if got, want := titles(dbpkg.SortNewest), []string{"Oldest", "Newest", "Favorite"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("newest: got %v, want %v", got, want)
+	}
+	if got, want := titles(dbpkg.SortFavoritesFirst), []string{"Oldest", "Favorite", "Newest"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("favorites_first: got %v, want %v", got, want)
+	}
+
+	pinned, _ := db.GetArticleByID(ids["Oldest"])
+	if !pinned.IsPinned {
+		t.Error("expected IsPinned on the pinned article")
+	}
+}
+
+func TestFindSimilarRead(t *testing.T) {
+	db := setupDBWithFeed(t)
+
+	var feedID int64
+	if err := db.QueryRow(`SELECT id FROM feeds WHERE url = ?`, "https://example.com/feed").Scan(&feedID); err != nil {
+		t.Fatalf("scan feed id: %v", err)
+	}
+	for _, a := range []*models.Article{
+		{FeedID: feedID, Title: "Apple announces new iPhone 17 at September event", URL: "https://example.com/read", PublishedAt: time.Now()},
+		{FeedID: feedID, Title: "Apple Announces New iPhone 17 at Its September Event", URL: "https://example.com/unread", PublishedAt: time.Now()},
+	} {
+		if err := db.SaveArticle(a); err != nil {
+			t.Fatalf("SaveArticle: %v", err)
+		}
+	}
+	articles, _ := db.GetArticles("", feedID, "", false, 10, 0)
+	byURL := map[string]models.Article{}
+	for _, a := range articles {
+		byURL[a.URL] = a
+	}
+
+	unread := byURL["https://example.com/unread"]
+	if similar, _, err := db.FindSimilarRead(unread); err != nil || similar != nil {
+		t.Fatalf("expected no match before reading, got %+v, %v", similar, err)
+	}
+
+	if err := db.MarkArticleRead(byURL["https://example.com/read"].ID, true); err != nil {
+		t.Fatalf("MarkArticleRead: %v", err)
+	}
+	similar, score, err := db.FindSimilarRead(unread)
+	if err != nil {
+		t.Fatalf("FindSimilarRead: %v", err)
+	}
+	if similar == nil || similar.URL != "https://example.com/read" || score < dbpkg.SimilarReadThreshold {
+		t.Fatalf("expected read article to match, got %+v (%.2f)", similar, score)
+	}
+}
+
+func TestGetArticles_FeedMaxArticleAge(t *testing.T) {
+	db := setupDBWithFeed(t)
+
+	var feedID int64
+	if err := db.QueryRow(`SELECT id FROM feeds WHERE url = ?`, "https://example.com/feed").Scan(&feedID); err != nil {
+		t.Fatalf("scan feed id: %v", err)
+	}
+
+	now := time.Now()
+	articles := []*models.Article{
+		{FeedID: feedID, Title: "Recent", URL: "https://example.com/recent", PublishedAt: now.Add(-24 * time.Hour)},
+		{FeedID: feedID, Title: "Old", URL: "https://example.com/old", PublishedAt: now.AddDate(0, 0, -30)},
+	}
+	if err := db.SaveArticles(context.Background(), articles); err != nil {
+		t.Fatalf("SaveArticles: %v", err)
+	}
+
+	if err := db.UpdateFeedMaxArticleAge(feedID, 7); err != nil {
+		t.Fatalf("UpdateFeedMaxArticleAge: %v", err)
+	}
+	// GetFeedByID can't scan the NULL description left by setupDBWithFeed
+	_, _ = db.Exec(`UPDATE feeds SET description = '' WHERE id = ?`, feedID)
+	feed, err := db.GetFeedByID(feedID)
+	if err != nil || feed.MaxArticleAgeDays != 7 {
+		t.Fatalf("expected feed to report a 7 day limit, got %+v, %v", feed, err)
+	}
+
+	got, err := db.GetArticles("all", feedID, "", false, 10, 0)
+	if err != nil {
+		t.Fatalf("GetArticles: %v", err)
+	}
+	if len(got) != 1 || got[0].Title != "Recent" {
+		t.Fatalf("expected only the recent article in the feed view, got %v", got)
+	}
+
+	// The cutoff only applies to the feed view; the article is still stored
+	all, err := db.GetArticles("all", 0, "", false, 10, 0)
+	if err != nil {
+		t.Fatalf("GetArticles: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("expected both articles outside the feed view, got %d", len(all))
+	}
+
+	if err := db.UpdateFeedMaxArticleAge(feedID, 0); err != nil {
+		t.Fatalf("UpdateFeedMaxArticleAge: %v", err)
+	}
+	if got, _ := db.GetArticles("all", feedID, "", false, 10, 0); len(got) != 2 {
+		t.Fatalf("expected no cutoff after clearing the limit, got %d articles", len(got))
+	}
+}
+
+func TestReadLaterQueue(t *testing.T) {
+	db := setupDBWithFeed(t)
+	var feedID int64
+	_ = db.QueryRow(`SELECT id FROM feeds WHERE url = ?`, "https://example.com/feed").Scan(&feedID)
+
+	// Old articles that would otherwise be cleaned up
+	old := time.Now().AddDate(0, 0, -100)
+	articles := []*models.Article{
+		{FeedID: feedID, Title: "first", URL: "https://example.com/1", PublishedAt: old},
+		{FeedID: feedID, Title: "second", URL: "https://example.com/2", PublishedAt: old.Add(time.Hour)},
+	}
+	if err := db.SaveArticles(context.Background(), articles); err != nil {
+		t.Fatalf("SaveArticles error: %v", err)
+	}
+	ids := map[string]int64{}
+	list, _ := db.GetArticles("all", feedID, "", false, 10, 0)
+	for _, a := range list {
+		ids[a.Title] = a.ID
+	}
+
+	// Queue order follows when articles were added, not when they were published
+	if err := db.ToggleReadLater(ids["second"]); err != nil {
+		t.Fatalf("ToggleReadLater error: %v", err)
+	}
+	if _, err := db.Exec(`UPDATE articles SET read_later_at = ? WHERE id = ?`, time.Now().Add(-time.Minute).UTC(), ids["second"]); err != nil {
+		t.Fatalf("backdate read_later_at: %v", err)
+	}
+	if err := db.SetArticleReadLater(ids["first"], true); err != nil {
+		t.Fatalf("SetArticleReadLater error: %v", err)
+	}
+
+	queue, err := db.GetReadLaterArticles(10, 0)
+	if err != nil {
+		t.Fatalf("GetReadLaterArticles error: %v", err)
+	}
+	if len(queue) != 2 || queue[0].Title != "second" || queue[1].Title != "first" {
+		t.Fatalf("unexpected queue order: %+v", queue)
+	}
+	if queue[0].ReadLaterAt == nil || !queue[0].IsReadLater {
+		t.Fatalf("expected read_later_at and is_read_later to be set, got %+v", queue[0])
+	}
+
+	// Queued articles survive age-based cleanup
+	if _, err := db.CleanupOldArticles(); err != nil {
+		t.Fatalf("CleanupOldArticles error: %v", err)
+	}
+	if queue, _ = db.GetReadLaterArticles(10, 0); len(queue) != 2 {
+		t.Fatalf("expected cleanup to keep read-later articles, got %d", len(queue))
+	}
+
+	// Reading an article takes it out of the queue
+	if err := db.MarkArticleRead(ids["second"], true); err != nil {
+		t.Fatalf("MarkArticleRead error: %v", err)
+	}
+	if queue, _ = db.GetReadLaterArticles(10, 0); len(queue) != 1 || queue[0].Title != "first" {
+		t.Fatalf("expected only the unread article to remain queued, got %+v", queue)
+	}
+}
+
+func TestSaveArticlesQueuesReadLaterByDefault(t *testing.T) {
+	db := setupDBWithFeed(t)
+	var feedID int64
+	_ = db.QueryRow(`SELECT id FROM feeds WHERE url = ?`, "https://example.com/feed").Scan(&feedID)
+	if _, err := db.Exec(`UPDATE feeds SET description = '' WHERE id = ?`, feedID); err != nil {
+		t.Fatalf("set description: %v", err)
+	}
+
+	if err := db.UpdateFeedReadLaterDefault(feedID, true); err != nil {
+		t.Fatalf("UpdateFeedReadLaterDefault error: %v", err)
+	}
+	feed, err := db.GetFeedByID(feedID)
+	if err != nil || !feed.ReadLaterByDefault {
+		t.Fatalf("expected read_later_by_default to be stored, got %+v err=%v", feed, err)
+	}
+
+	// The fetcher marks articles of such feeds as read later before saving
+	article := &models.Article{FeedID: feedID, Title: "queued", URL: "https://example.com/q", PublishedAt: time.Now(), IsReadLater: feed.ReadLaterByDefault}
+	if err := db.SaveArticles(context.Background(), []*models.Article{article}); err != nil {
+		t.Fatalf("SaveArticles error: %v", err)
+	}
+	queue, err := db.GetReadLaterArticles(10, 0)
+	if err != nil {
+		t.Fatalf("GetReadLaterArticles error: %v", err)
+	}
+	if len(queue) != 1 || queue[0].ReadLaterAt == nil {
+		t.Fatalf("expected the new article to be queued with read_later_at, got %+v", queue)
+	}
+}
+
+func TestSaveArticlesStoresImages(t *testing.T) {
+	db := setupDBWithFeed(t)
+	var feedID int64
+	_ = db.QueryRow(`SELECT id FROM feeds WHERE url = ?`, "https://example.com/feed").Scan(&feedID)
+
+	images := []string{"https://example.com/1.jpg", "https://example.com/2.jpg"}
+	articles := []*models.Article{
+		{FeedID: feedID, Title: "gallery", URL: "https://example.com/g", PublishedAt: time.Now(), Images: images},
+		{FeedID: feedID, Title: "plain", URL: "https://example.com/p", PublishedAt: time.Now().Add(-time.Hour)},
+	}
+	if err := db.SaveArticles(context.Background(), articles); err != nil {
+		t.Fatalf("SaveArticles error: %v", err)
+	}
+
+	list, err := db.GetArticles("", feedID, "", false, 10, 0)
+	if err != nil || len(list) != 2 {
+		t.Fatalf("expected 2 articles, got %d (err %v)", len(list), err)
+	}
+	if !reflect.DeepEqual(list[0].Images, images) {
+		t.Errorf("expected images %v in the list payload, got %v", images, list[0].Images)
+	}
+	if list[1].Images != nil {
+		t.Errorf("expected no images for the plain article, got %v", list[1].Images)
+	}
+
+	article, err := db.GetArticleByID(list[0].ID)
+	if err != nil || !reflect.DeepEqual(article.Images, images) {
+		t.Errorf("expected images %v from GetArticleByID, got %v (err %v)", images, article, err)
+	}
+}
+
+func TestSaveArticlesStoresDiscussionURL(t *testing.T) {
+	db := setupDBWithFeed(t)
+	var feedID int64
+	_ = db.QueryRow(`SELECT id FROM feeds WHERE url = ?`, "https://example.com/feed").Scan(&feedID)
+
+	thread := "https://news.ycombinator.com/item?id=1"
+	article := &models.Article{FeedID: feedID, Title: "story", URL: "https://example.com/s", PublishedAt: time.Now(), DiscussionURL: thread}
+	if err := db.SaveArticles(context.Background(), []*models.Article{article}); err != nil {
+		t.Fatalf("SaveArticles error: %v", err)
+	}
+
+	list, err := db.GetArticles("", feedID, "", false, 10, 0)
+	if err != nil || len(list) != 1 || list[0].DiscussionURL != thread {
+		t.Fatalf("expected the discussion URL in the list payload, got %+v (err %v)", list, err)
+	}
+	if got, err := db.GetArticleByID(list[0].ID); err != nil || got.DiscussionURL != thread {
+		t.Errorf("expected the discussion URL from GetArticleByID, got %+v (err %v)", got, err)
+	}
+}
@@ -0,0 +1,102 @@
+package database
+
+import "database/sql"
+
+// LanguageSample holds the text used to detect an article's language
+type LanguageSample struct {
+	ID      int64
+	Title   string
+	Summary string
+}
+
+// languageFilter selects articles without a detected language unless all articles are wanted
+func languageFilter(all bool) string {
+	if all {
+		return "1=1"
+	}
+	return "(detected_language IS NULL OR detected_language = '')"
+}
+
+// CountArticlesNeedingLanguage counts articles that a language re-detection would visit.
+// With all set every article is counted, otherwise only those without a language.
+func (db *DB) CountArticlesNeedingLanguage(all bool) (int, error) {
+	db.WaitForReady()
+	var count int
+	err := db.QueryRow(`SELECT COUNT(*) FROM articles WHERE ` + languageFilter(all)).Scan(&count)
+	return count, err
+}
+
+// GetArticlesNeedingLanguage returns up to limit articles with an ID above afterID,
+// in ID order, for batched language re-detection
+func (db *DB) GetArticlesNeedingLanguage(afterID int64, limit int, all bool) ([]LanguageSample, error) {
+	db.WaitForReady()
+	rows, err := db.Query(
+		`SELECT a.id, COALESCE(a.title, ''), `+articleSummaryColumn+` FROM articles a
+		WHERE a.id > ? AND `+languageFilter(all)+`
+		ORDER BY id LIMIT ?`,
+		afterID, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var samples []LanguageSample
+	for rows.Next() {
+		var s LanguageSample
+		var summary storedText
+		if err := rows.Scan(&s.ID, &s.Title, &summary); err != nil {
+			return nil, err
+		}
+		s.Summary = summary.String
+		samples = append(samples, s)
+	}
+	return samples, rows.Err()
+}
+
+// UpdateArticleDetectedLanguages stores detected languages keyed by article ID in one
+// transaction and returns how many articles actually changed
+func (db *DB) UpdateArticleDetectedLanguages(languages map[int64]string) (int, error) {
+	db.WaitForReady()
+	if len(languages) == 0 {
+		return 0, nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`UPDATE articles SET detected_language = ? WHERE id = ? AND COALESCE(detected_language, '') != ?`)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	updated := 0
+	for id, lang := range languages {
+		result, err := stmt.Exec(lang, id, lang)
+		if err != nil {
+			return 0, err
+		}
+		if n, _ := result.RowsAffected(); n > 0 {
+			updated++
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return updated, nil
+}
+
+// GetArticleDetectedLanguage returns the stored language of an article, or "" if unknown
+func (db *DB) GetArticleDetectedLanguage(id int64) (string, error) {
+	db.WaitForReady()
+	var lang sql.NullString
+	err := db.QueryRow(`SELECT detected_language FROM articles WHERE id = ?`, id).Scan(&lang)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return lang.String, err
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"database/sql"
+	"strings"
+)
+
+// CategorySettings holds per-category overrides keyed by category path (e.g. "News/Tech")
+type CategorySettings struct {
+	Category              string `json:"category"`
+	TranslationTargetLang string `json:"translation_target_lang,omitempty"`
+}
+
+// InitCategorySettingsTable creates the category_settings table if it doesn't exist
+func InitCategorySettingsTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS category_settings (
+		category TEXT PRIMARY KEY,
+		translation_target_lang TEXT
+	);
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// GetCategorySettings returns every stored category override ordered by category path
+func (db *DB) GetCategorySettings() ([]CategorySettings, error) {
+	db.WaitForReady()
+
+	rows, err := db.Query(`SELECT category, COALESCE(translation_target_lang, '')
+		FROM category_settings ORDER BY category ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	settings := []CategorySettings{}
+	for rows.Next() {
+		var s CategorySettings
+		if err := rows.Scan(&s.Category, &s.TranslationTargetLang); err != nil {
+			return nil, err
+		}
+		settings = append(settings, s)
+	}
+	return settings, rows.Err()
+}
+
+// SetCategoryTranslationTargetLang sets the translation target language for a category.
+// An empty language removes the override so the category inherits again.
+func (db *DB) SetCategoryTranslationTargetLang(category, lang string) error {
+	db.WaitForReady()
+
+	if lang == "" {
+		_, err := db.Exec("DELETE FROM category_settings WHERE category = ?", category)
+		return err
+	}
+	_, err := db.Exec(`INSERT INTO category_settings (category, translation_target_lang) VALUES (?, ?)
+		ON CONFLICT(category) DO UPDATE SET translation_target_lang = excluded.translation_target_lang`,
+		category, lang)
+	return err
+}
+
+// ResolveCategoryTranslationTargetLang returns the translation target language override
+// for a feed category, or "" when none applies. An override on "News" also covers
+// "News/Tech", matching category filtering; the most specific override wins.
+func (db *DB) ResolveCategoryTranslationTargetLang(category string) (string, error) {
+	if category == "" {
+		return "", nil
+	}
+	db.WaitForReady()
+
+	var lang string
+	err := db.QueryRow(`SELECT translation_target_lang FROM category_settings
+		WHERE translation_target_lang IS NOT NULL AND translation_target_lang != ''
+		AND (? = category OR ? LIKE category || '/%')
+		ORDER BY LENGTH(category) DESC LIMIT 1`, category, category).Scan(&lang)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return strings.TrimSpace(lang), err
+}
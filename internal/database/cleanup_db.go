@@ -57,8 +57,20 @@ func (db *DB) CleanupOldArticles() (int64, error) {
 	return totalDeleted, nil
 }
 
-// CleanupAllArticleContents removes all cached article contents
+// CleanupAllArticleContents removes all cached article contents except those of favorited
+// and read-later articles, unless keep_favorite_content is off
 func (db *DB) CleanupAllArticleContents() (int64, error) {
+	db.WaitForReady()
+	result, err := db.Exec(`DELETE FROM article_contents WHERE ` + db.contentCleanupFilter())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// DeleteAllArticleContents removes every cached article content, for use alongside
+// DeleteAllArticles when all articles are being removed anyway
+func (db *DB) DeleteAllArticleContents() (int64, error) {
 	db.WaitForReady()
 	result, err := db.Exec(`DELETE FROM article_contents`)
 	if err != nil {
@@ -242,11 +254,12 @@ func (db *DB) CleanupBySize() (int64, error) {
 }
 
 // CleanupArticleContentsByAge removes article content cache entries older than maxAgeDays
-// This only deletes content, not article metadata
+// This only deletes content, not article metadata; content of favorited and read-later articles is kept
+// unless keep_favorite_content is off
 func (db *DB) CleanupArticleContentsByAge(maxAgeDays int) (int64, error) {
 	db.WaitForReady()
 	result, err := db.Exec(
-		`DELETE FROM article_contents WHERE fetched_at < datetime('now', '-' || ? || ' days')`,
+		`DELETE FROM article_contents WHERE fetched_at < datetime('now', '-' || ? || ' days') AND `+db.contentCleanupFilter(),
 		maxAgeDays,
 	)
 	if err != nil {
@@ -256,7 +269,8 @@ func (db *DB) CleanupArticleContentsByAge(maxAgeDays int) (int64, error) {
 }
 
 // CleanupArticleContentsBySize removes oldest article contents to reduce database size
-// This only deletes content, not article metadata
+// This only deletes content, not article metadata; content of favorited and read-later articles is kept
+// unless keep_favorite_content is off
 func (db *DB) CleanupArticleContentsBySize() (int64, error) {
 	db.WaitForReady()
 
@@ -289,6 +303,7 @@ func (db *DB) CleanupArticleContentsBySize() (int64, error) {
 			DELETE FROM article_contents
 			WHERE article_id IN (
 				SELECT article_id FROM article_contents
+				WHERE ` + db.contentCleanupFilter() + `
 				ORDER BY fetched_at ASC
 				LIMIT 100
 			)
@@ -71,6 +71,21 @@ func (db *DB) Init() error {
 			return
 		}
 
+		// Initialize persisted discovery results table
+		if err = InitDiscoveredBlogsTable(db.DB); err != nil {
+			return
+		}
+
+		// Initialize per-category overrides table
+		if err = InitCategorySettingsTable(db.DB); err != nil {
+			return
+		}
+
+		// Initialize article highlights table
+		if err = InitHighlightsTable(db.DB); err != nil {
+			return
+		}
+
 		// Create settings table if not exists
 		_, _ = db.Exec(`CREATE TABLE IF NOT EXISTS settings (
 			key TEXT PRIMARY KEY,
@@ -326,6 +341,43 @@ func (db *DB) Init() error {
 				log.Printf("Error creating feeds_new table: %v", err)
 			}
 		}
+
+		// Migration: Add translation_mode column to feeds table for per-feed translation override
+		// Runs after the feeds table rebuild above so the column isn't dropped.
+		// Error is ignored - if column exists, the operation fails harmlessly.
+		_, _ = db.Exec(`ALTER TABLE feeds ADD COLUMN translation_mode TEXT DEFAULT 'inherit'`)
+
+		// Migration: Add source_language_override column for feeds whose language is misdetected
+		_, _ = db.Exec(`ALTER TABLE feeds ADD COLUMN source_language_override TEXT DEFAULT ''`)
+
+		// Migration: Add max_article_age_days column to hide old articles from a feed's list view
+		_, _ = db.Exec(`ALTER TABLE feeds ADD COLUMN max_article_age_days INTEGER DEFAULT 0`)
+
+		// Migration: Add read_later_by_default column to queue a feed's new articles for later
+		_, _ = db.Exec(`ALTER TABLE feeds ADD COLUMN read_later_by_default BOOLEAN DEFAULT 0`)
+
+		// Migration: Add eager_content column to store full content of new articles at refresh time
+		_, _ = db.Exec(`ALTER TABLE feeds ADD COLUMN eager_content BOOLEAN DEFAULT 0`)
+
+		// Migration: Track feeds whose article identifiers change between fetches and let
+		// users dedupe a feed by URL and title instead
+		_, _ = db.Exec(`ALTER TABLE feeds ADD COLUMN guid_unstable BOOLEAN DEFAULT 0`)
+		_, _ = db.Exec(`ALTER TABLE feeds ADD COLUMN force_url_dedup BOOLEAN DEFAULT 0`)
+
+		// Migration: Per-feed content extraction pipeline (comma-separated strategy list)
+		_, _ = db.Exec(`ALTER TABLE feeds ADD COLUMN content_strategy TEXT DEFAULT ''`)
+		_, _ = db.Exec(`ALTER TABLE feeds ADD COLUMN content_selector TEXT DEFAULT ''`)
+		_, _ = db.Exec(`ALTER TABLE feeds ADD COLUMN content_script_path TEXT DEFAULT ''`)
+
+		// Migration: Track when a feed's view was last opened, for its "new since last visit" view
+		_, _ = db.Exec(`ALTER TABLE feeds ADD COLUMN last_viewed_at DATETIME`)
+
+		// Migration: Remember where a feed's URL ends up after redirects, to find feeds that
+		// are the same source under different addresses
+		_, _ = db.Exec(`ALTER TABLE feeds ADD COLUMN resolved_url TEXT DEFAULT ''`)
+
+		// Migration: Add list_prefix column for the short feed name shown in the article list
+		_, _ = db.Exec(`ALTER TABLE feeds ADD COLUMN list_prefix TEXT DEFAULT ''`)
 	})
 	return err
 }
@@ -495,6 +547,12 @@ func runMigrations(db *sql.DB) error {
 	)`)
 	_, _ = db.Exec(`CREATE INDEX IF NOT EXISTS idx_article_contents_article_id ON article_contents(article_id)`)
 
+	// Migration: Optional gzip compression of cached article content
+	_, _ = db.Exec(`ALTER TABLE article_contents ADD COLUMN content_compressed BLOB`)
+	_, _ = db.Exec(`ALTER TABLE article_contents ADD COLUMN compressed BOOLEAN DEFAULT 0`)
+	_, _ = db.Exec(`ALTER TABLE articles ADD COLUMN summary_compressed BLOB`)
+	_, _ = db.Exec(`ALTER TABLE articles ADD COLUMN summary_is_compressed BOOLEAN DEFAULT 0`)
+
 	// Migration: Add chat_sessions and chat_messages tables for AI chat feature
 	_, _ = db.Exec(`CREATE TABLE IF NOT EXISTS chat_sessions (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -534,6 +592,53 @@ func runMigrations(db *sql.DB) error {
 	// Migration: Add author field to articles table
 	_, _ = db.Exec(`ALTER TABLE articles ADD COLUMN author TEXT DEFAULT ''`)
 
+	// Migration: Track when an article was marked read for the recently read stream
+	_, _ = db.Exec(`ALTER TABLE articles ADD COLUMN read_at DATETIME`)
+	_, _ = db.Exec(`CREATE INDEX IF NOT EXISTS idx_articles_read_at ON articles(read_at DESC)`)
+
+	// Migration: Flag articles marked read by view dwell time rather than by the user
+	_, _ = db.Exec(`ALTER TABLE articles ADD COLUMN auto_read BOOLEAN DEFAULT 0`)
+
+	// Migration: Track when an article joined the read-later queue; existing entries
+	// fall back to their publish time
+	if _, err := db.Exec(`ALTER TABLE articles ADD COLUMN read_later_at DATETIME`); err == nil {
+		_, _ = db.Exec(`UPDATE articles SET read_later_at = published_at WHERE is_read_later = 1`)
+	}
+
+	// Migration: Indexes for the global unread stream. The first serves its keyset
+	// pagination in either direction, the second its cross-feed duplicate check.
+	_, _ = db.Exec(`CREATE INDEX IF NOT EXISTS idx_articles_unread_stream ON articles(is_read, published_at, id)`)
+	_, _ = db.Exec(`CREATE INDEX IF NOT EXISTS idx_articles_url_id ON articles(url, id)`)
+
+	// Migration: Store gallery image URLs extracted from the article body as a JSON array
+	_, _ = db.Exec(`ALTER TABLE articles ADD COLUMN images TEXT`)
+
+	// Migration: Cache the detected language of each article so translation can skip
+	// articles already in the target language without re-running detection
+	_, _ = db.Exec(`ALTER TABLE articles ADD COLUMN detected_language TEXT DEFAULT ''`)
+
+	// Migration: Pinned articles are listed above all others regardless of date
+	_, _ = db.Exec(`ALTER TABLE articles ADD COLUMN is_pinned BOOLEAN DEFAULT 0`)
+
+	// Migration: Track when each article was stored, for a feed's "new since last visit" view
+	_, _ = db.Exec(`ALTER TABLE articles ADD COLUMN added_at DATETIME`)
+
+	// Migration: Link to an aggregator's comment thread found in the article content
+	_, _ = db.Exec(`ALTER TABLE articles ADD COLUMN discussion_url TEXT`)
+
+	// Migration: When the read and star states were last changed locally, for resolving
+	// sync conflicts (Unix seconds, like the sync queue)
+	_, _ = db.Exec(`ALTER TABLE articles ADD COLUMN read_changed_at INTEGER`)
+	_, _ = db.Exec(`ALTER TABLE articles ADD COLUMN favorite_changed_at INTEGER`)
+
+	// Migration: Summaries generated by the AI-only endpoint, kept apart from the summary
+	// column, which may hold a locally computed summary
+	_, _ = db.Exec(`ALTER TABLE articles ADD COLUMN ai_summary TEXT DEFAULT ''`)
+
+	// Migration: The feed a cached full-text translation was made for, so clearing a feed's
+	// translations also drops them (0 when not tied to a feed)
+	_, _ = db.Exec(`ALTER TABLE translation_cache ADD COLUMN feed_id INTEGER DEFAULT 0`)
+
 	return nil
 }
 
@@ -577,6 +682,18 @@ func (db *DB) SetCachedTranslation(sourceTextHash, sourceText, targetLang, trans
 	return err
 }
 
+// SetCachedFeedTranslation stores a translation in cache, recording the feed it was made
+// for so ClearTranslationsFor removes it with that feed's translations
+func (db *DB) SetCachedFeedTranslation(feedID int64, sourceTextHash, sourceText, targetLang, translatedText, provider string) error {
+	_, err := db.Exec(
+		`INSERT OR REPLACE INTO translation_cache
+		 (source_text_hash, source_text, target_lang, translated_text, provider, feed_id, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)`,
+		sourceTextHash, sourceText, targetLang, translatedText, provider, feedID,
+	)
+	return err
+}
+
 // CleanupTranslationCache removes cached translations older than maxAgeDays
 func (db *DB) CleanupTranslationCache(maxAgeDays int) (int64, error) {
 	result, err := db.Exec(
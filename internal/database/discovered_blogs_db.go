@@ -0,0 +1,102 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+// DiscoveredBlogRecord is a blog suggested by batch discovery, persisted so results
+// survive restarts. RecentArticles holds the JSON-encoded recent article list.
+type DiscoveredBlogRecord struct {
+	SourceFeed     string
+	Name           string
+	Homepage       string
+	RSSFeed        string
+	IconURL        string
+	RecentArticles string
+	DiscoveredAt   time.Time
+}
+
+// InitDiscoveredBlogsTable creates the discovered_blogs table if it doesn't exist
+func InitDiscoveredBlogsTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS discovered_blogs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		source_feed TEXT,
+		name TEXT,
+		homepage TEXT,
+		rss_feed TEXT NOT NULL UNIQUE,
+		icon_url TEXT,
+		recent_articles TEXT,
+		discovered_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);
+	`
+	_, err := db.Exec(query)
+	return err
+}
+
+// ReplaceDiscoveredBlogs replaces the stored discovery results with the given blogs
+func (db *DB) ReplaceDiscoveredBlogs(blogs []DiscoveredBlogRecord) error {
+	db.WaitForReady()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM discovered_blogs"); err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(`INSERT OR IGNORE INTO discovered_blogs
+		(source_feed, name, homepage, rss_feed, icon_url, recent_articles, discovered_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	now := time.Now().UTC()
+	for _, b := range blogs {
+		if _, err := stmt.Exec(b.SourceFeed, b.Name, b.Homepage, b.RSSFeed, b.IconURL, b.RecentArticles, now); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetDiscoveredBlogs returns the stored discovery results in discovery order
+func (db *DB) GetDiscoveredBlogs() ([]DiscoveredBlogRecord, error) {
+	db.WaitForReady()
+
+	rows, err := db.Query(`SELECT COALESCE(source_feed, ''), COALESCE(name, ''), COALESCE(homepage, ''),
+		rss_feed, COALESCE(icon_url, ''), COALESCE(recent_articles, ''), discovered_at
+		FROM discovered_blogs ORDER BY id ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var blogs []DiscoveredBlogRecord
+	for rows.Next() {
+		var b DiscoveredBlogRecord
+		var discoveredAt sql.NullTime
+		if err := rows.Scan(&b.SourceFeed, &b.Name, &b.Homepage, &b.RSSFeed, &b.IconURL, &b.RecentArticles, &discoveredAt); err != nil {
+			return nil, err
+		}
+		if discoveredAt.Valid {
+			b.DiscoveredAt = discoveredAt.Time
+		}
+		blogs = append(blogs, b)
+	}
+	return blogs, rows.Err()
+}
+
+// ClearDiscoveredBlogs deletes all stored discovery results
+func (db *DB) ClearDiscoveredBlogs() error {
+	db.WaitForReady()
+	_, err := db.Exec("DELETE FROM discovered_blogs")
+	return err
+}
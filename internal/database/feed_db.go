@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"MrRSS/internal/models"
+	"MrRSS/internal/utils"
 )
 
 // parseDateTimeForSQL converts Go time format to SQLite-compatible format
@@ -168,11 +169,15 @@ func (db *DB) GetFeeds() ([]models.Feed, error) {
 			COALESCE(f.xpath_item_categories, ''), COALESCE(f.xpath_item_uid, ''),
 			COALESCE(f.article_view_mode, 'global'),
 			COALESCE(f.auto_expand_content, 'global'),
+			COALESCE(f.translation_mode, 'inherit'), COALESCE(f.source_language_override, ''),
+			COALESCE(f.max_article_age_days, 0), COALESCE(f.read_later_by_default, 0), COALESCE(f.eager_content, 0),
+			COALESCE(f.guid_unstable, 0), COALESCE(f.force_url_dedup, 0),
+			COALESCE(f.content_strategy, ''), COALESCE(f.content_selector, ''), COALESCE(f.content_script_path, ''),
 			COALESCE(f.email_address, ''), COALESCE(f.email_imap_server, ''),
 			COALESCE(f.email_imap_port, 993), COALESCE(f.email_username, ''),
 			COALESCE(f.email_password, ''), COALESCE(f.email_folder, 'INBOX'),
 			COALESCE(f.email_last_uid, 0), COALESCE(f.is_freshrss_source, 0),
-			COALESCE(f.freshrss_stream_id, ''),
+			COALESCE(f.freshrss_stream_id, ''), COALESCE(f.list_prefix, ''),
 			(SELECT MAX(a.published_at) FROM articles a WHERE a.feed_id = f.id) as latest_article_time,
 			CAST(COALESCE((
 				SELECT
@@ -203,7 +208,8 @@ func (db *DB) GetFeeds() ([]models.Feed, error) {
 	var feeds []models.Feed
 	for rows.Next() {
 		var f models.Feed
-		var link, category, imageURL, lastError, scriptPath, proxyURL, feedType, xpathItem, xpathItemTitle, xpathItemContent, xpathItemUri, xpathItemAuthor, xpathItemTimestamp, xpathItemTimeFormat, xpathItemThumbnail, xpathItemCategories, xpathItemUid, articleViewMode, autoExpandContent, emailAddress, emailIMAPServer, emailUsername, emailPassword, emailFolder, freshRSSStreamID, latestArticleTimeStr sql.NullString
+		var link, category, imageURL, lastError, scriptPath, proxyURL, feedType, xpathItem, xpathItemTitle, xpathItemContent, xpathItemUri, xpathItemAuthor, xpathItemTimestamp, xpathItemTimeFormat, xpathItemThumbnail, xpathItemCategories, xpathItemUid, articleViewMode, autoExpandContent, translationMode, sourceLanguageOverride, emailAddress, emailIMAPServer, emailUsername, emailPassword, emailFolder, freshRSSStreamID, latestArticleTimeStr sql.NullString
+		var contentStrategy string
 		var lastUpdated sql.NullTime
 		if err := rows.Scan(
 			&f.ID, &f.Title, &f.URL, &link, &f.Description, &category, &imageURL,
@@ -212,9 +218,9 @@ func (db *DB) GetFeeds() ([]models.Feed, error) {
 			&f.IsImageMode, &feedType, &xpathItem, &xpathItemTitle, &xpathItemContent,
 			&xpathItemUri, &xpathItemAuthor, &xpathItemTimestamp, &xpathItemTimeFormat,
 			&xpathItemThumbnail, &xpathItemCategories, &xpathItemUid, &articleViewMode,
-			&autoExpandContent, &emailAddress, &emailIMAPServer, &f.EmailIMAPPort,
+			&autoExpandContent, &translationMode, &sourceLanguageOverride, &f.MaxArticleAgeDays, &f.ReadLaterByDefault, &f.EagerContent, &f.GUIDUnstable, &f.ForceURLDedup, &contentStrategy, &f.ContentSelector, &f.ContentScriptPath, &emailAddress, &emailIMAPServer, &f.EmailIMAPPort,
 			&emailUsername, &emailPassword, &emailFolder, &f.EmailLastUID,
-			&f.IsFreshRSSSource, &freshRSSStreamID, &latestArticleTimeStr, &f.ArticlesPerMonth,
+			&f.IsFreshRSSSource, &freshRSSStreamID, &f.ListPrefix, &latestArticleTimeStr, &f.ArticlesPerMonth,
 		); err != nil {
 			return nil, err
 		}
@@ -252,6 +258,12 @@ func (db *DB) GetFeeds() ([]models.Feed, error) {
 		if f.AutoExpandContent == "" {
 			f.AutoExpandContent = "global"
 		}
+		f.TranslationMode = translationMode.String
+		if f.TranslationMode == "" {
+			f.TranslationMode = models.TranslationModeInherit
+		}
+		f.SourceLanguageOverride = sourceLanguageOverride.String
+		f.ContentStrategy = splitContentStrategy(contentStrategy)
 		f.EmailAddress = emailAddress.String
 		f.EmailIMAPServer = emailIMAPServer.String
 		f.EmailUsername = emailUsername.String
@@ -306,12 +318,13 @@ func (db *DB) GetFeeds() ([]models.Feed, error) {
 // GetFeedByID retrieves a specific feed by its ID.
 func (db *DB) GetFeedByID(id int64) (*models.Feed, error) {
 	db.WaitForReady()
-	row := db.QueryRow("SELECT id, title, url, link, description, category, image_url, COALESCE(position, 0), last_updated, last_error, COALESCE(discovery_completed, 0), COALESCE(script_path, ''), COALESCE(hide_from_timeline, 0), COALESCE(proxy_url, ''), COALESCE(proxy_enabled, 0), COALESCE(refresh_interval, 0), COALESCE(is_image_mode, 0), COALESCE(type, ''), COALESCE(xpath_item, ''), COALESCE(xpath_item_title, ''), COALESCE(xpath_item_content, ''), COALESCE(xpath_item_uri, ''), COALESCE(xpath_item_author, ''), COALESCE(xpath_item_timestamp, ''), COALESCE(xpath_item_time_format, ''), COALESCE(xpath_item_thumbnail, ''), COALESCE(xpath_item_categories, ''), COALESCE(xpath_item_uid, ''), COALESCE(article_view_mode, 'global'), COALESCE(auto_expand_content, 'global'), COALESCE(email_address, ''), COALESCE(email_imap_server, ''), COALESCE(email_imap_port, 993), COALESCE(email_username, ''), COALESCE(email_password, ''), COALESCE(email_folder, 'INBOX'), COALESCE(email_last_uid, 0), COALESCE(is_freshrss_source, 0), COALESCE(freshrss_stream_id, '') FROM feeds WHERE id = ?", id)
+	row := db.QueryRow("SELECT id, title, url, link, description, category, image_url, COALESCE(position, 0), last_updated, last_error, COALESCE(discovery_completed, 0), COALESCE(script_path, ''), COALESCE(hide_from_timeline, 0), COALESCE(proxy_url, ''), COALESCE(proxy_enabled, 0), COALESCE(refresh_interval, 0), COALESCE(is_image_mode, 0), COALESCE(type, ''), COALESCE(xpath_item, ''), COALESCE(xpath_item_title, ''), COALESCE(xpath_item_content, ''), COALESCE(xpath_item_uri, ''), COALESCE(xpath_item_author, ''), COALESCE(xpath_item_timestamp, ''), COALESCE(xpath_item_time_format, ''), COALESCE(xpath_item_thumbnail, ''), COALESCE(xpath_item_categories, ''), COALESCE(xpath_item_uid, ''), COALESCE(article_view_mode, 'global'), COALESCE(auto_expand_content, 'global'), COALESCE(translation_mode, 'inherit'), COALESCE(source_language_override, ''), COALESCE(max_article_age_days, 0), COALESCE(read_later_by_default, 0), COALESCE(eager_content, 0), COALESCE(guid_unstable, 0), COALESCE(force_url_dedup, 0), COALESCE(content_strategy, ''), COALESCE(content_selector, ''), COALESCE(content_script_path, ''), COALESCE(email_address, ''), COALESCE(email_imap_server, ''), COALESCE(email_imap_port, 993), COALESCE(email_username, ''), COALESCE(email_password, ''), COALESCE(email_folder, 'INBOX'), COALESCE(email_last_uid, 0), COALESCE(is_freshrss_source, 0), COALESCE(freshrss_stream_id, ''), COALESCE(list_prefix, '') FROM feeds WHERE id = ?", id)
 
 	var f models.Feed
-	var link, category, imageURL, lastError, scriptPath, proxyURL, feedType, xpathItem, xpathItemTitle, xpathItemContent, xpathItemUri, xpathItemAuthor, xpathItemTimestamp, xpathItemTimeFormat, xpathItemThumbnail, xpathItemCategories, xpathItemUid, articleViewMode, autoExpandContent, emailAddress, emailIMAPServer, emailUsername, emailPassword, emailFolder, freshRSSStreamID sql.NullString
+	var link, category, imageURL, lastError, scriptPath, proxyURL, feedType, xpathItem, xpathItemTitle, xpathItemContent, xpathItemUri, xpathItemAuthor, xpathItemTimestamp, xpathItemTimeFormat, xpathItemThumbnail, xpathItemCategories, xpathItemUid, articleViewMode, autoExpandContent, translationMode, sourceLanguageOverride, emailAddress, emailIMAPServer, emailUsername, emailPassword, emailFolder, freshRSSStreamID sql.NullString
+	var contentStrategy string
 	var lastUpdated sql.NullTime
-	if err := row.Scan(&f.ID, &f.Title, &f.URL, &link, &f.Description, &category, &imageURL, &f.Position, &lastUpdated, &lastError, &f.DiscoveryCompleted, &scriptPath, &f.HideFromTimeline, &proxyURL, &f.ProxyEnabled, &f.RefreshInterval, &f.IsImageMode, &feedType, &xpathItem, &xpathItemTitle, &xpathItemContent, &xpathItemUri, &xpathItemAuthor, &xpathItemTimestamp, &xpathItemTimeFormat, &xpathItemThumbnail, &xpathItemCategories, &xpathItemUid, &articleViewMode, &autoExpandContent, &emailAddress, &emailIMAPServer, &f.EmailIMAPPort, &emailUsername, &emailPassword, &emailFolder, &f.EmailLastUID, &f.IsFreshRSSSource, &freshRSSStreamID); err != nil {
+	if err := row.Scan(&f.ID, &f.Title, &f.URL, &link, &f.Description, &category, &imageURL, &f.Position, &lastUpdated, &lastError, &f.DiscoveryCompleted, &scriptPath, &f.HideFromTimeline, &proxyURL, &f.ProxyEnabled, &f.RefreshInterval, &f.IsImageMode, &feedType, &xpathItem, &xpathItemTitle, &xpathItemContent, &xpathItemUri, &xpathItemAuthor, &xpathItemTimestamp, &xpathItemTimeFormat, &xpathItemThumbnail, &xpathItemCategories, &xpathItemUid, &articleViewMode, &autoExpandContent, &translationMode, &sourceLanguageOverride, &f.MaxArticleAgeDays, &f.ReadLaterByDefault, &f.EagerContent, &f.GUIDUnstable, &f.ForceURLDedup, &contentStrategy, &f.ContentSelector, &f.ContentScriptPath, &emailAddress, &emailIMAPServer, &f.EmailIMAPPort, &emailUsername, &emailPassword, &emailFolder, &f.EmailLastUID, &f.IsFreshRSSSource, &freshRSSStreamID, &f.ListPrefix); err != nil {
 		return nil, err
 	}
 	f.Link = link.String
@@ -344,6 +357,12 @@ func (db *DB) GetFeedByID(id int64) (*models.Feed, error) {
 	if f.AutoExpandContent == "" {
 		f.AutoExpandContent = "global"
 	}
+	f.TranslationMode = translationMode.String
+	if f.TranslationMode == "" {
+		f.TranslationMode = models.TranslationModeInherit
+	}
+	f.SourceLanguageOverride = sourceLanguageOverride.String
+	f.ContentStrategy = splitContentStrategy(contentStrategy)
 	f.EmailAddress = emailAddress.String
 	f.EmailIMAPServer = emailIMAPServer.String
 	f.EmailUsername = emailUsername.String
@@ -380,6 +399,45 @@ func (db *DB) GetAllFeedURLs() (map[string]bool, error) {
 	return urls, rows.Err()
 }
 
+// FindFeedByNormalizedURL returns a local feed whose URL matches feedURL after
+// normalization (scheme, www, default port and trailing slash are ignored), or nil
+// if there is none. FreshRSS feeds are excluded since they may legitimately mirror
+// a local subscription.
+func (db *DB) FindFeedByNormalizedURL(feedURL string) (*models.Feed, error) {
+	db.WaitForReady()
+	target := utils.CanonicalFeedURL(feedURL)
+	if target == "" {
+		return nil, nil
+	}
+
+	rows, err := db.Query("SELECT id, url FROM feeds WHERE COALESCE(is_freshrss_source, 0) = 0")
+	if err != nil {
+		return nil, err
+	}
+
+	var matchID int64
+	for rows.Next() {
+		var id int64
+		var url string
+		if err := rows.Scan(&id, &url); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if utils.CanonicalFeedURL(url) == target {
+			matchID = id
+			break
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if matchID == 0 {
+		return nil, nil
+	}
+	return db.GetFeedByID(matchID)
+}
+
 // UpdateFeed updates feed title, URL, category, script_path, hide_from_timeline, proxy settings, refresh_interval, is_image_mode, XPath fields, article_view_mode, auto_expand_content, and email settings.
 func (db *DB) UpdateFeed(id int64, title, url, category, scriptPath string, hideFromTimeline bool, proxyURL string, proxyEnabled bool, refreshInterval int, isImageMode bool, feedType string, xpathItem, xpathItemTitle, xpathItemContent, xpathItemUri, xpathItemAuthor, xpathItemTimestamp, xpathItemTimeFormat, xpathItemThumbnail, xpathItemCategories, xpathItemUid, articleViewMode, autoExpandContent, emailAddress, emailIMAPServer, emailUsername, emailPassword, emailFolder string, emailIMAPPort int) error {
 	db.WaitForReady()
@@ -415,6 +473,13 @@ func (db *DB) UpdateFeedLink(id int64, link string) error {
 	return err
 }
 
+// UpdateFeedTitle updates a feed's title.
+func (db *DB) UpdateFeedTitle(id int64, title string) error {
+	db.WaitForReady()
+	_, err := db.Exec("UPDATE feeds SET title = ? WHERE id = ?", title, id)
+	return err
+}
+
 // UpdateFeedError updates a feed's error message.
 func (db *DB) UpdateFeedError(id int64, errorMsg string) error {
 	db.WaitForReady()
@@ -422,6 +487,98 @@ func (db *DB) UpdateFeedError(id int64, errorMsg string) error {
 	return err
 }
 
+// UpdateFeedTranslationMode sets the per-feed translation override.
+// Unknown modes fall back to inherit.
+func (db *DB) UpdateFeedTranslationMode(id int64, mode string) error {
+	db.WaitForReady()
+	switch mode {
+	case models.TranslationModeAlways, models.TranslationModeNever:
+	default:
+		mode = models.TranslationModeInherit
+	}
+	_, err := db.Exec("UPDATE feeds SET translation_mode = ? WHERE id = ?", mode, id)
+	return err
+}
+
+// UpdateFeedSourceLanguage sets the language translations of this feed are made from,
+// bypassing detection. "auto" or an empty string restores auto-detection.
+func (db *DB) UpdateFeedSourceLanguage(id int64, lang string) error {
+	db.WaitForReady()
+	lang = strings.TrimSpace(lang)
+	if strings.EqualFold(lang, "auto") {
+		lang = ""
+	}
+	_, err := db.Exec("UPDATE feeds SET source_language_override = ? WHERE id = ?", lang, id)
+	return err
+}
+
+// UpdateFeedMaxArticleAge sets how many days back the feed's article list reaches.
+// Zero or a negative value removes the limit.
+func (db *DB) UpdateFeedMaxArticleAge(id int64, days int) error {
+	db.WaitForReady()
+	if days < 0 {
+		days = 0
+	}
+	_, err := db.Exec("UPDATE feeds SET max_article_age_days = ? WHERE id = ?", days, id)
+	return err
+}
+
+// UpdateFeedReadLaterDefault sets whether new articles from the feed are queued for reading later.
+func (db *DB) UpdateFeedReadLaterDefault(id int64, enabled bool) error {
+	db.WaitForReady()
+	_, err := db.Exec("UPDATE feeds SET read_later_by_default = ? WHERE id = ?", enabled, id)
+	return err
+}
+
+// UpdateFeedListPrefix sets the short name shown in front of the feed's articles in the list view.
+// An empty prefix falls back to the feed title's initials.
+func (db *DB) UpdateFeedListPrefix(id int64, prefix string) error {
+	db.WaitForReady()
+	_, err := db.Exec("UPDATE feeds SET list_prefix = ? WHERE id = ?", strings.TrimSpace(prefix), id)
+	return err
+}
+
+// UpdateFeedEagerContent sets whether full content of the feed's new articles is fetched at refresh time.
+func (db *DB) UpdateFeedEagerContent(id int64, enabled bool) error {
+	db.WaitForReady()
+	_, err := db.Exec("UPDATE feeds SET eager_content = ? WHERE id = ?", enabled, id)
+	return err
+}
+
+// UpdateFeedGUIDUnstable records whether the feed's article identifiers change between fetches.
+func (db *DB) UpdateFeedGUIDUnstable(id int64, unstable bool) error {
+	db.WaitForReady()
+	_, err := db.Exec("UPDATE feeds SET guid_unstable = ? WHERE id = ?", unstable, id)
+	return err
+}
+
+// UpdateFeedForceURLDedup sets whether the feed's articles are always deduplicated by URL and title.
+func (db *DB) UpdateFeedForceURLDedup(id int64, enabled bool) error {
+	db.WaitForReady()
+	_, err := db.Exec("UPDATE feeds SET force_url_dedup = ? WHERE id = ?", enabled, id)
+	return err
+}
+
+// UpdateFeedContentStrategy sets the feed's ordered content extraction strategies along with
+// the selector and script they may use.
+func (db *DB) UpdateFeedContentStrategy(id int64, strategy []string, selector, scriptPath string) error {
+	db.WaitForReady()
+	_, err := db.Exec("UPDATE feeds SET content_strategy = ?, content_selector = ?, content_script_path = ? WHERE id = ?",
+		strings.Join(strategy, ","), selector, scriptPath, id)
+	return err
+}
+
+// splitContentStrategy decodes a stored comma-separated strategy list
+func splitContentStrategy(stored string) []string {
+	var strategy []string
+	for _, s := range strings.Split(stored, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			strategy = append(strategy, s)
+		}
+	}
+	return strategy
+}
+
 // ClearAllFeedErrors clears error messages for all feeds.
 func (db *DB) ClearAllFeedErrors() error {
 	db.WaitForReady()
@@ -450,6 +607,32 @@ func (db *DB) MarkFeedDiscovered(id int64) error {
 	return err
 }
 
+// GetUndiscoveredFeeds returns the feeds that batch discovery has not processed yet,
+// ordered like GetFeeds. Only id, title, url and category are populated.
+func (db *DB) GetUndiscoveredFeeds() ([]models.Feed, error) {
+	db.WaitForReady()
+	rows, err := db.Query(`
+		SELECT id, title, url, COALESCE(category, '')
+		FROM feeds
+		WHERE COALESCE(discovery_completed, 0) = 0
+		ORDER BY category ASC, position ASC, id ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var feeds []models.Feed
+	for rows.Next() {
+		var f models.Feed
+		if err := rows.Scan(&f.ID, &f.Title, &f.URL, &f.Category); err != nil {
+			return nil, err
+		}
+		feeds = append(feeds, f)
+	}
+	return feeds, rows.Err()
+}
+
 // UpdateFeedPosition updates a feed's category and position.
 func (db *DB) UpdateFeedPosition(id int64, category string, position int) error {
 	db.WaitForReady()
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"testing"
+
+	"MrRSS/internal/models"
+)
+
+func TestFindFeedByNormalizedURL(t *testing.T) {
+	db, err := NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("NewDB error: %v", err)
+	}
+	defer db.Close()
+	if err := db.Init(); err != nil {
+		t.Fatalf("Init error: %v", err)
+	}
+
+	id, err := db.AddFeed(&models.Feed{Title: "Example", URL: "http://www.example.com/feed/"})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+
+	found, err := db.FindFeedByNormalizedURL("https://example.com/feed")
+	if err != nil {
+		t.Fatalf("FindFeedByNormalizedURL: %v", err)
+	}
+	if found == nil || found.ID != id {
+		t.Fatalf("expected feed %d, got %+v", id, found)
+	}
+
+	found, err = db.FindFeedByNormalizedURL("https://example.com/other")
+	if err != nil {
+		t.Fatalf("FindFeedByNormalizedURL: %v", err)
+	}
+	if found != nil {
+		t.Fatalf("expected no match, got feed %d", found.ID)
+	}
+}
+
+func TestResolveCategoryTranslationTargetLang(t *testing.T) {
+	db, err := NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("NewDB error: %v", err)
+	}
+	defer db.Close()
+	if err := db.Init(); err != nil {
+		t.Fatalf("Init error: %v", err)
+	}
+
+	if err := db.SetCategoryTranslationTargetLang("Blogs", "en"); err != nil {
+		t.Fatalf("SetCategoryTranslationTargetLang error: %v", err)
+	}
+	if err := db.SetCategoryTranslationTargetLang("Blogs/Deutsch", "fr"); err != nil {
+		t.Fatalf("SetCategoryTranslationTargetLang error: %v", err)
+	}
+
+	cases := map[string]string{
+		"Blogs":              "en",
+		"Blogs/Tech":         "en",
+		"Blogs/Deutsch":      "fr",
+		"Blogs/Deutsch/Auto": "fr",
+		"BlogsExtra":         "",
+		"News":               "",
+		"":                   "",
+	}
+	for category, want := range cases {
+		got, err := db.ResolveCategoryTranslationTargetLang(category)
+		if err != nil {
+			t.Fatalf("Resolve(%q) error: %v", category, err)
+		}
+		if got != want {
+			t.Errorf("Resolve(%q) = %q, want %q", category, got, want)
+		}
+	}
+
+	// Clearing the override falls back to the parent category
+	if err := db.SetCategoryTranslationTargetLang("Blogs/Deutsch", ""); err != nil {
+		t.Fatalf("SetCategoryTranslationTargetLang error: %v", err)
+	}
+	if got, _ := db.ResolveCategoryTranslationTargetLang("Blogs/Deutsch"); got != "en" {
+		t.Errorf("expected parent override after clearing, got %q", got)
+	}
+	settings, err := db.GetCategorySettings()
+	if err != nil || len(settings) != 1 {
+		t.Fatalf("expected 1 stored override, got %v (err %v)", settings, err)
+	}
+}
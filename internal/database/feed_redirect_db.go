@@ -0,0 +1,154 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"MrRSS/internal/utils"
+)
+
+// UpdateFeedResolvedURL records the URL a feed's address resolved to after redirects.
+func (db *DB) UpdateFeedResolvedURL(id int64, resolvedURL string) error {
+	db.WaitForReady()
+	_, err := db.Exec("UPDATE feeds SET resolved_url = ? WHERE id = ?", resolvedURL, id)
+	return err
+}
+
+// GetFeedResolvedURL returns the URL a feed's address last resolved to, or an empty
+// string if it has not been fetched since this was tracked.
+func (db *DB) GetFeedResolvedURL(id int64) (string, error) {
+	db.WaitForReady()
+	var resolvedURL string
+	err := db.QueryRow("SELECT COALESCE(resolved_url, '') FROM feeds WHERE id = ?", id).Scan(&resolvedURL)
+	return resolvedURL, err
+}
+
+// GetRedirectDuplicateFeeds groups the IDs of feeds whose addresses resolve to the same
+// URL, keyed by that URL. Feeds without a duplicate are left out.
+func (db *DB) GetRedirectDuplicateFeeds() (map[string][]int64, error) {
+	db.WaitForReady()
+	rows, err := db.Query(`
+		SELECT resolved_url, id FROM feeds
+		WHERE resolved_url IN (
+			SELECT resolved_url FROM feeds
+			WHERE resolved_url != ''
+			GROUP BY resolved_url HAVING COUNT(*) > 1
+		)
+		ORDER BY resolved_url, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := make(map[string][]int64)
+	for rows.Next() {
+		var resolvedURL string
+		var id int64
+		if err := rows.Scan(&resolvedURL, &id); err != nil {
+			return nil, err
+		}
+		groups[resolvedURL] = append(groups[resolvedURL], id)
+	}
+	return groups, rows.Err()
+}
+
+// MergeFeeds moves the articles of the source feeds into the target feed and deletes the
+// source feeds. Articles the target feed already has are dropped with their source feed,
+// after their favorite, read-later and pinned flags are carried over to the target's copy.
+// It returns the number of articles moved.
+func (db *DB) MergeFeeds(targetID int64, sourceIDs []int64) (int64, error) {
+	db.WaitForReady()
+
+	var exists int
+	if err := db.QueryRow("SELECT 1 FROM feeds WHERE id = ?", targetID).Scan(&exists); err != nil {
+		return 0, fmt.Errorf("target feed %d: %w", targetID, err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var moved int64
+	for _, sourceID := range sourceIDs {
+		if sourceID == targetID {
+			continue
+		}
+		n, err := moveFeedArticles(tx, sourceID, targetID)
+		if err != nil {
+			return 0, err
+		}
+		moved += n
+		if _, err := tx.Exec("DELETE FROM articles WHERE feed_id = ?", sourceID); err != nil {
+			return 0, err
+		}
+		if _, err := tx.Exec("DELETE FROM feeds WHERE id = ?", sourceID); err != nil {
+			return 0, err
+		}
+	}
+	return moved, tx.Commit()
+}
+
+// moveFeedArticles reassigns a feed's articles to another feed, regenerating their unique
+// IDs so later refreshes of the target feed recognize them. Articles whose unique ID the
+// target feed already has stay behind, with their favorite, read-later and pinned flags
+// merged into the target's copy.
+func moveFeedArticles(tx *sql.Tx, sourceID, targetID int64) (int64, error) {
+	type movedArticle struct {
+		id          int64
+		title       string
+		publishedAt sql.NullTime
+		favorite    bool
+		readLater   bool
+		pinned      bool
+	}
+
+	rows, err := tx.Query(`
+		SELECT id, title, published_at, COALESCE(is_favorite, 0), COALESCE(is_read_later, 0), COALESCE(is_pinned, 0)
+		FROM articles WHERE feed_id = ?`, sourceID)
+	if err != nil {
+		return 0, err
+	}
+	var articles []movedArticle
+	for rows.Next() {
+		var a movedArticle
+		if err := rows.Scan(&a.id, &a.title, &a.publishedAt, &a.favorite, &a.readLater, &a.pinned); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		articles = append(articles, a)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	var moved int64
+	for _, a := range articles {
+		hasPublishedTime := a.publishedAt.Valid && !a.publishedAt.Time.IsZero()
+		publishedAt := time.Time{}
+		if hasPublishedTime {
+			publishedAt = a.publishedAt.Time
+		}
+		uniqueID := utils.GenerateArticleUniqueID(a.title, targetID, publishedAt, hasPublishedTime)
+		res, err := tx.Exec("UPDATE OR IGNORE articles SET feed_id = ?, unique_id = ? WHERE id = ?", targetID, uniqueID, a.id)
+		if err != nil {
+			return 0, err
+		}
+		n, _ := res.RowsAffected()
+		moved += n
+		if n == 0 && (a.favorite || a.readLater || a.pinned) {
+			if _, err := tx.Exec(`
+				UPDATE articles SET
+					is_favorite = (COALESCE(is_favorite, 0) OR ?),
+					is_read_later = (COALESCE(is_read_later, 0) OR ?),
+					is_pinned = (COALESCE(is_pinned, 0) OR ?)
+				WHERE unique_id = ?`, a.favorite, a.readLater, a.pinned, uniqueID); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return moved, nil
+}
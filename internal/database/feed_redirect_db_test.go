@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"MrRSS/internal/models"
+)
+
+func TestMergeFeedsKeepsFlagsOfDroppedDuplicates(t *testing.T) {
+	db, err := NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("NewDB error: %v", err)
+	}
+	defer db.Close()
+	if err := db.Init(); err != nil {
+		t.Fatalf("Init error: %v", err)
+	}
+
+	targetID, err := db.AddFeed(&models.Feed{Title: "Target", URL: "https://example.com/feed"})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+	sourceID, err := db.AddFeed(&models.Feed{Title: "Source", URL: "http://example.com/feed"})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+
+	published := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	if err := db.SaveArticles(context.Background(), []*models.Article{
+		{FeedID: targetID, Title: "Shared", URL: "https://example.com/shared", PublishedAt: published, HasValidPublishedTime: true},
+		{FeedID: sourceID, Title: "Shared", URL: "http://example.com/shared", PublishedAt: published, HasValidPublishedTime: true},
+		{FeedID: sourceID, Title: "Only in source", URL: "http://example.com/only", PublishedAt: published, HasValidPublishedTime: true},
+	}); err != nil {
+		t.Fatalf("SaveArticles: %v", err)
+	}
+	if _, err := db.Exec(`UPDATE articles SET is_favorite = 1, is_read_later = 1, is_pinned = 1 WHERE feed_id = ? AND title = 'Shared'`, sourceID); err != nil {
+		t.Fatalf("failed to flag the duplicate: %v", err)
+	}
+
+	moved, err := db.MergeFeeds(targetID, []int64{sourceID})
+	if err != nil {
+		t.Fatalf("MergeFeeds: %v", err)
+	}
+	if moved != 1 {
+		t.Errorf("expected 1 article moved, got %d", moved)
+	}
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM articles WHERE feed_id = ?`, targetID).Scan(&count); err != nil {
+		t.Fatalf("count query: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 articles in the target feed, got %d", count)
+	}
+
+	var favorite, readLater, pinned bool
+	if err := db.QueryRow(`SELECT is_favorite, is_read_later, is_pinned FROM articles WHERE feed_id = ? AND title = 'Shared'`, targetID).
+		Scan(&favorite, &readLater, &pinned); err != nil {
+		t.Fatalf("flag query: %v", err)
+	}
+	if !favorite || !readLater || !pinned {
+		t.Errorf("expected the duplicate's flags on the surviving article, got favorite=%v read_later=%v pinned=%v", favorite, readLater, pinned)
+	}
+}
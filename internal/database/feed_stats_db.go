@@ -0,0 +1,138 @@
+package database
+
+import (
+	"database/sql"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// feedStatsWindow is how far back a feed's posting rate is measured
+const feedStatsWindow = 12 * 7 * 24 * time.Hour
+
+// feedStatsLengthSample caps how many cached contents are read for the average length
+const feedStatsLengthSample = 50
+
+// feedStatsTagPattern matches HTML tags, which don't count towards an article's length
+var feedStatsTagPattern = regexp.MustCompile(`<[^>]+>`)
+
+// FeedStats summarizes a feed's activity, for deciding whether it is worth keeping
+type FeedStats struct {
+	FeedID          int64      `json:"feed_id"`
+	ArticleCount    int        `json:"article_count"`
+	UnreadCount     int        `json:"unread_count"`
+	UnreadRatio     float64    `json:"unread_ratio"`
+	ArticlesPerWeek float64    `json:"articles_per_week"`
+	AvgLength       int        `json:"avg_length"` // Characters of text in the cached contents
+	LastArticleAt   *time.Time `json:"last_article_at,omitempty"`
+}
+
+// GetFeedStats aggregates a feed's stored articles. The posting rate covers the last
+// twelve weeks, or the time since the oldest article when it is more recent.
+func (db *DB) GetFeedStats(feedID int64) (*FeedStats, error) {
+	db.WaitForReady()
+
+	rows, err := db.Query(`SELECT published_at, is_read FROM articles WHERE feed_id = ?`, feedID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	since := now.Add(-feedStatsWindow)
+	stats := &FeedStats{FeedID: feedID}
+	var oldest time.Time
+	recent := 0
+	for rows.Next() {
+		var publishedAt sql.NullTime
+		var isRead bool
+		if err := rows.Scan(&publishedAt, &isRead); err != nil {
+			return nil, err
+		}
+		stats.ArticleCount++
+		if !isRead {
+			stats.UnreadCount++
+		}
+		if !publishedAt.Valid {
+			continue
+		}
+		published := publishedAt.Time
+		if stats.LastArticleAt == nil || published.After(*stats.LastArticleAt) {
+			stats.LastArticleAt = &published
+		}
+		if oldest.IsZero() || published.Before(oldest) {
+			oldest = published
+		}
+		if published.After(since) {
+			recent++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if stats.ArticleCount > 0 {
+		stats.UnreadRatio = float64(stats.UnreadCount) / float64(stats.ArticleCount)
+	}
+	window := feedStatsWindow
+	if !oldest.IsZero() && oldest.After(since) {
+		window = now.Sub(oldest)
+	}
+	if weeks := window.Hours() / (24 * 7); weeks >= 1 {
+		stats.ArticlesPerWeek = float64(recent) / weeks
+	} else {
+		stats.ArticlesPerWeek = float64(recent)
+	}
+
+	avgLength, err := db.feedAverageLength(feedID)
+	if err != nil {
+		return nil, err
+	}
+	stats.AvgLength = avgLength
+	return stats, nil
+}
+
+// feedAverageLength returns the average text length of the feed's most recently cached contents
+func (db *DB) feedAverageLength(feedID int64) (int, error) {
+	rows, err := db.Query(`
+		SELECT c.article_id FROM article_contents c
+		JOIN articles a ON a.id = c.article_id
+		WHERE a.feed_id = ?
+		ORDER BY c.fetched_at DESC
+		LIMIT ?
+	`, feedID, feedStatsLengthSample)
+	if err != nil {
+		return 0, err
+	}
+	var articleIDs []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		articleIDs = append(articleIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	// Contents may be compressed, so they are read through GetArticleContent
+	total, counted := 0, 0
+	for _, id := range articleIDs {
+		content, found, err := db.GetArticleContent(id)
+		if err != nil || !found {
+			continue
+		}
+		text := html.UnescapeString(feedStatsTagPattern.ReplaceAllString(content, " "))
+		total += utf8.RuneCountInString(strings.Join(strings.Fields(text), " "))
+		counted++
+	}
+	if counted == 0 {
+		return 0, nil
+	}
+	return total / counted, nil
+}
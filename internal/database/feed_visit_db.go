@@ -0,0 +1,73 @@
+package database
+
+import (
+	"database/sql"
+	"log"
+	"time"
+
+	"MrRSS/internal/models"
+)
+
+// SetFeedLastViewed records the current time as when the feed's view was last opened
+func (db *DB) SetFeedLastViewed(feedID int64) error {
+	db.WaitForReady()
+	_, err := db.Exec(`UPDATE feeds SET last_viewed_at = ? WHERE id = ?`, time.Now(), feedID)
+	return err
+}
+
+// GetFeedLastViewed returns when the feed's view was last opened, or the zero time if it
+// never has been
+func (db *DB) GetFeedLastViewed(feedID int64) (time.Time, error) {
+	db.WaitForReady()
+	var lastViewed sql.NullTime
+	if err := db.QueryRow(`SELECT last_viewed_at FROM feeds WHERE id = ?`, feedID).Scan(&lastViewed); err != nil {
+		return time.Time{}, err
+	}
+	return lastViewed.Time, nil
+}
+
+// GetArticlesNewSince returns a feed's visible articles published or stored after since,
+// newest first, whatever their read state. Articles stored before their added time was
+// tracked only count by publish time.
+func (db *DB) GetArticlesNewSince(feedID int64, since time.Time, limit int) ([]models.Article, error) {
+	db.WaitForReady()
+
+	rows, err := db.Query(`
+		SELECT a.id, a.feed_id, a.title, a.url, a.image_url, a.audio_url, a.video_url, a.published_at, a.is_read, a.is_favorite, a.is_hidden, a.is_read_later, COALESCE(a.is_pinned, 0), a.translated_title, `+articleSummaryColumn+`, a.freshrss_item_id, f.title, a.author, a.images, a.discussion_url
+		FROM articles a
+		JOIN feeds f ON a.feed_id = f.id
+		WHERE a.feed_id = ? AND a.is_hidden = 0
+		AND (a.published_at > ? OR a.added_at > ?)
+		ORDER BY a.published_at DESC, a.id DESC
+		LIMIT ?`, feedID, since, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	articles := []models.Article{}
+	for rows.Next() {
+		var a models.Article
+		var imageURL, audioURL, videoURL, translatedTitle, freshrssItemID, author, images, discussionURL sql.NullString
+		var summary storedText
+		var publishedAt sql.NullTime
+		if err := rows.Scan(&a.ID, &a.FeedID, &a.Title, &a.URL, &imageURL, &audioURL, &videoURL, &publishedAt, &a.IsRead, &a.IsFavorite, &a.IsHidden, &a.IsReadLater, &a.IsPinned, &translatedTitle, &summary, &freshrssItemID, &a.FeedTitle, &author, &images, &discussionURL); err != nil {
+			log.Println("Error scanning article:", err)
+			continue
+		}
+		a.ImageURL = imageURL.String
+		a.AudioURL = audioURL.String
+		a.VideoURL = videoURL.String
+		if publishedAt.Valid {
+			a.PublishedAt = publishedAt.Time
+		}
+		a.TranslatedTitle = translatedTitle.String
+		a.Summary = summary.String
+		a.FreshRSSItemID = freshrssItemID.String
+		a.Author = author.String
+		a.Images = decodeArticleImages(images)
+		a.DiscussionURL = discussionURL.String
+		articles = append(articles, a)
+	}
+	return articles, rows.Err()
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 )
 
@@ -240,14 +241,60 @@ func (db *DB) MarkSyncFailed(itemID int64, errMsg string) error {
 	return nil
 }
 
-// ClearPendingSyncForArticle removes all pending sync changes for a specific article
+// GetPendingSyncChangesForArticle retrieves the pending sync changes of an article, oldest
+// first. When actions are given, only changes with those actions are returned.
+func (db *DB) GetPendingSyncChangesForArticle(articleID int64, actions ...SyncAction) ([]SyncQueueItem, error) {
+	db.WaitForReady()
+
+	query := `
+	SELECT id, article_id, article_url, sync_action, created_at
+	FROM freshrss_sync_queue
+	WHERE synced_at IS NULL AND article_id = ?
+	ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := db.Query(query, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("get pending sync changes for article: %w", err)
+	}
+	defer rows.Close()
+
+	var items []SyncQueueItem
+	for rows.Next() {
+		var item SyncQueueItem
+		var action string
+		var createdAt int64
+		if err := rows.Scan(&item.ID, &item.ArticleID, &item.ArticleURL, &action, &createdAt); err != nil {
+			return nil, fmt.Errorf("scan sync queue item: %w", err)
+		}
+		item.Action = SyncAction(action)
+		item.CreatedAt = time.Unix(createdAt, 0)
+		if len(actions) == 0 || containsSyncAction(actions, item.Action) {
+			items = append(items, item)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate sync queue items: %w", err)
+	}
+	return items, nil
+}
+
+// ClearPendingSyncForArticle removes the pending sync changes for a specific article, all
+// of them or only those with the given actions.
 // This is useful when resolving conflicts by accepting server state
-func (db *DB) ClearPendingSyncForArticle(articleID int64) error {
+func (db *DB) ClearPendingSyncForArticle(articleID int64, actions ...SyncAction) error {
 	db.WaitForReady()
 
 	query := `DELETE FROM freshrss_sync_queue WHERE article_id = ? AND synced_at IS NULL`
+	args := []interface{}{articleID}
+	if len(actions) > 0 {
+		query += ` AND sync_action IN (?` + strings.Repeat(", ?", len(actions)-1) + `)`
+		for _, action := range actions {
+			args = append(args, string(action))
+		}
+	}
 
-	_, err := db.Exec(query, articleID)
+	_, err := db.Exec(query, args...)
 	if err != nil {
 		return fmt.Errorf("clear pending sync for article: %w", err)
 	}
@@ -255,6 +302,16 @@ func (db *DB) ClearPendingSyncForArticle(articleID int64) error {
 	return nil
 }
 
+// containsSyncAction reports whether actions includes action
+func containsSyncAction(actions []SyncAction, action SyncAction) bool {
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
 // GetPendingSyncCount returns the count of pending sync changes
 func (db *DB) GetPendingSyncCount() (int, error) {
 	db.WaitForReady()
@@ -0,0 +1,120 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Highlight is a passage quoted from an article, with an optional note
+type Highlight struct {
+	ID           int64     `json:"id"`
+	ArticleID    int64     `json:"article_id"`
+	QuotedText   string    `json:"quoted_text"`
+	Note         string    `json:"note"`
+	CreatedAt    time.Time `json:"created_at"`
+	ArticleTitle string    `json:"article_title,omitempty"`
+	ArticleURL   string    `json:"article_url,omitempty"`
+	FeedTitle    string    `json:"feed_title,omitempty"`
+}
+
+// InitHighlightsTable creates the highlights table if it doesn't exist
+func InitHighlightsTable(db *sql.DB) error {
+	query := `
+	CREATE TABLE IF NOT EXISTS highlights (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		article_id INTEGER NOT NULL,
+		quoted_text TEXT NOT NULL,
+		note TEXT DEFAULT '',
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		article_title TEXT DEFAULT '',
+		article_url TEXT DEFAULT '',
+		feed_title TEXT DEFAULT '',
+		FOREIGN KEY(article_id) REFERENCES articles(id) ON DELETE CASCADE
+	);
+	CREATE INDEX IF NOT EXISTS idx_highlights_article_id ON highlights(article_id, created_at);
+	`
+	if _, err := db.Exec(query); err != nil {
+		return err
+	}
+
+	// Migration: Keep the article's title and link on the highlight, since foreign keys
+	// aren't enforced and cleanup can remove the article while its highlights stay.
+	// Errors are ignored - if the columns exist, the operation fails harmlessly.
+	_, _ = db.Exec(`ALTER TABLE highlights ADD COLUMN article_title TEXT DEFAULT ''`)
+	_, _ = db.Exec(`ALTER TABLE highlights ADD COLUMN article_url TEXT DEFAULT ''`)
+	_, _ = db.Exec(`ALTER TABLE highlights ADD COLUMN feed_title TEXT DEFAULT ''`)
+	return nil
+}
+
+// AddHighlight stores a quoted passage verbatim, along with the article's current title,
+// link and feed, and returns the new highlight's ID
+func (db *DB) AddHighlight(articleID int64, quotedText, note string) (int64, error) {
+	db.WaitForReady()
+
+	result, err := db.Exec(`
+		INSERT INTO highlights (article_id, quoted_text, note, created_at, article_title, article_url, feed_title)
+		SELECT a.id, ?, ?, ?, COALESCE(a.title, ''), COALESCE(a.url, ''), COALESCE(f.title, '')
+		FROM articles a
+		LEFT JOIN feeds f ON f.id = a.feed_id
+		WHERE a.id = ?`,
+		quotedText, note, time.Now(), articleID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to add highlight: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return 0, fmt.Errorf("failed to add highlight: article %d not found", articleID)
+	}
+	return result.LastInsertId()
+}
+
+// GetHighlights returns the highlights of an article in creation order, or those of every
+// article grouped by article when articleID is 0. Article details fall back to those stored
+// with the highlight when the article itself has since been cleaned up.
+func (db *DB) GetHighlights(articleID int64) ([]Highlight, error) {
+	db.WaitForReady()
+
+	query := `
+		SELECT h.id, h.article_id, h.quoted_text, COALESCE(h.note, ''), h.created_at,
+		       COALESCE(a.title, h.article_title, ''), COALESCE(a.url, h.article_url, ''),
+		       COALESCE(f.title, h.feed_title, '')
+		FROM highlights h
+		LEFT JOIN articles a ON a.id = h.article_id
+		LEFT JOIN feeds f ON f.id = a.feed_id`
+	var args []interface{}
+	if articleID > 0 {
+		query += ` WHERE h.article_id = ?`
+		args = append(args, articleID)
+	}
+	query += ` ORDER BY h.article_id, h.created_at, h.id`
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get highlights: %w", err)
+	}
+	defer rows.Close()
+
+	highlights := make([]Highlight, 0)
+	for rows.Next() {
+		var hl Highlight
+		if err := rows.Scan(&hl.ID, &hl.ArticleID, &hl.QuotedText, &hl.Note, &hl.CreatedAt,
+			&hl.ArticleTitle, &hl.ArticleURL, &hl.FeedTitle); err != nil {
+			return nil, fmt.Errorf("failed to scan highlight: %w", err)
+		}
+		highlights = append(highlights, hl)
+	}
+	return highlights, rows.Err()
+}
+
+// DeleteHighlight removes a highlight, reporting whether it existed
+func (db *DB) DeleteHighlight(id int64) (bool, error) {
+	db.WaitForReady()
+
+	result, err := db.Exec(`DELETE FROM highlights WHERE id = ?`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete highlight: %w", err)
+	}
+	n, _ := result.RowsAffected()
+	return n > 0, nil
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+// StorageUsage summarizes what the database stores, for projecting its growth
+type StorageUsage struct {
+	SizeMB          float64
+	FeedCount       int
+	ArticleCount    int
+	AvgArticleBytes float64 // Text stored in an article row
+	ContentCount    int
+	AvgContentBytes float64 // A cached full article content
+	RecentArticles  int     // Articles stored within WindowDays
+	WindowDays      float64
+}
+
+// GetStorageUsage measures the stored articles and how many arrived within the window.
+// On a database younger than the window, the window is shortened to its age so a fresh
+// install doesn't look like it ingests less than it does.
+func (db *DB) GetStorageUsage(window time.Duration) (StorageUsage, error) {
+	db.WaitForReady()
+
+	var usage StorageUsage
+	var err error
+	if usage.SizeMB, err = db.GetDatabaseSizeMB(); err != nil {
+		return usage, err
+	}
+	if err := db.QueryRow(`SELECT COUNT(*) FROM feeds`).Scan(&usage.FeedCount); err != nil {
+		return usage, err
+	}
+
+	if err := db.QueryRow(`
+		SELECT COUNT(*), COALESCE(AVG(
+			LENGTH(CAST(COALESCE(title, '') AS BLOB)) +
+			LENGTH(CAST(COALESCE(translated_title, '') AS BLOB)) +
+			LENGTH(CAST(COALESCE(url, '') AS BLOB)) +
+			LENGTH(CAST(COALESCE(summary, '') AS BLOB)) +
+			COALESCE(LENGTH(summary_compressed), 0) +
+			LENGTH(CAST(COALESCE(ai_summary, '') AS BLOB)) +
+			LENGTH(CAST(COALESCE(content, '') AS BLOB)) +
+			LENGTH(CAST(COALESCE(images, '') AS BLOB))
+		), 0)
+		FROM articles
+	`).Scan(&usage.ArticleCount, &usage.AvgArticleBytes); err != nil {
+		return usage, err
+	}
+
+	if err := db.QueryRow(`
+		SELECT COUNT(*), COALESCE(AVG(
+			LENGTH(CAST(content AS BLOB)) + COALESCE(LENGTH(content_compressed), 0)
+		), 0)
+		FROM article_contents
+	`).Scan(&usage.ContentCount, &usage.AvgContentBytes); err != nil {
+		return usage, err
+	}
+
+	// added_at is unset for articles stored before it existed, fall back to the publish date
+	since := time.Now().Add(-window)
+	if err := db.QueryRow(`
+		SELECT COUNT(*) FROM articles WHERE COALESCE(added_at, published_at) >= ?
+	`, since).Scan(&usage.RecentArticles); err != nil {
+		return usage, err
+	}
+	var addedAt, publishedAt sql.NullTime
+	err = db.QueryRow(`
+		SELECT added_at, published_at FROM articles
+		ORDER BY COALESCE(added_at, published_at) ASC LIMIT 1
+	`).Scan(&addedAt, &publishedAt)
+	if err != nil && err != sql.ErrNoRows {
+		return usage, err
+	}
+	oldest := addedAt
+	if !oldest.Valid {
+		oldest = publishedAt
+	}
+
+	usage.WindowDays = window.Hours() / 24
+	if oldest.Valid && oldest.Time.After(since) {
+		usage.WindowDays = time.Since(oldest.Time).Hours() / 24
+	}
+	if usage.WindowDays < 1 {
+		usage.WindowDays = 1
+	}
+	return usage, nil
+}
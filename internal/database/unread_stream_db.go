@@ -0,0 +1,140 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"MrRSS/internal/models"
+)
+
+// Sort orders for the unread stream and, with SortFavoritesFirst, the article list
+const (
+	SortNewest         = "newest"
+	SortOldest         = "oldest"
+	SortFavoritesFirst = "favorites_first"
+)
+
+// ErrInvalidCursor is returned when a cursor token cannot be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is a keyset pagination position: the sort key of the last article of the
+// previous page. The zero Cursor starts at the beginning of the stream.
+type Cursor struct {
+	PublishedAt time.Time
+	ID          int64
+}
+
+// IsZero reports whether the cursor starts at the beginning of the stream
+func (c Cursor) IsZero() bool {
+	return c.ID == 0
+}
+
+// String encodes the cursor as an opaque token for clients; the zero cursor encodes as ""
+func (c Cursor) String() string {
+	if c.IsZero() {
+		return ""
+	}
+	return strconv.FormatInt(c.PublishedAt.UnixNano(), 10) + "_" + strconv.FormatInt(c.ID, 10)
+}
+
+// ParseCursor decodes a token produced by Cursor.String. An empty token is the zero cursor.
+func ParseCursor(token string) (Cursor, error) {
+	if token == "" {
+		return Cursor{}, nil
+	}
+	nanos, id, ok := strings.Cut(token, "_")
+	if !ok {
+		return Cursor{}, ErrInvalidCursor
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	articleID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || articleID <= 0 {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return Cursor{PublishedAt: time.Unix(0, n), ID: articleID}, nil
+}
+
+// CursorAfter returns the cursor continuing after the given article
+func CursorAfter(a models.Article) Cursor {
+	return Cursor{PublishedAt: a.PublishedAt, ID: a.ID}
+}
+
+// GetAllUnread returns a page of the global unread stream: unread, non-hidden articles
+// from feeds that are not hidden from the timeline, ordered by publish time across all
+// feeds (sortOrder SortNewest or SortOldest, ties broken by ID). When the same URL is
+// unread in several feeds only its oldest copy is listed.
+//
+// Pagination is keyset based: pass the zero Cursor for the first page and CursorAfter
+// of the last returned article for the next one, so deep pages cost the same as the first.
+func (db *DB) GetAllUnread(sortOrder string, cursor Cursor, limit int) ([]models.Article, error) {
+	db.WaitForReady()
+
+	direction, comparison := "DESC", "<"
+	if sortOrder == SortOldest {
+		direction, comparison = "ASC", ">"
+	}
+
+	query := `
+		SELECT a.id, a.feed_id, a.title, a.url, a.image_url, a.audio_url, a.video_url, a.published_at, a.is_read, a.is_favorite, a.is_hidden, a.is_read_later, a.translated_title, ` + articleSummaryColumn + `, a.freshrss_item_id, f.title, a.author, a.images, a.discussion_url
+		FROM articles a
+		JOIN feeds f ON a.feed_id = f.id
+		WHERE a.is_read = 0 AND a.is_hidden = 0
+		AND COALESCE(f.hide_from_timeline, 0) = 0
+		AND NOT EXISTS (
+			SELECT 1 FROM articles d
+			JOIN feeds df ON d.feed_id = df.id
+			WHERE d.url = a.url AND d.url != '' AND d.id < a.id
+			AND d.is_read = 0 AND d.is_hidden = 0
+			AND COALESCE(df.hide_from_timeline, 0) = 0
+		)`
+	var args []interface{}
+	if !cursor.IsZero() {
+		// Compare against the stored publish time of the cursor article so ties match exactly;
+		// the encoded time is only used if that article has since been deleted
+		query += `
+		AND (a.published_at, a.id) ` + comparison + ` (COALESCE((SELECT published_at FROM articles WHERE id = ?), ?), ?)`
+		args = append(args, cursor.ID, cursor.PublishedAt, cursor.ID)
+	}
+	query += `
+		ORDER BY a.published_at ` + direction + `, a.id ` + direction + ` LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var articles []models.Article
+	for rows.Next() {
+		var a models.Article
+		var imageURL, audioURL, videoURL, translatedTitle, freshrssItemID, author, images, discussionURL sql.NullString
+		var summary storedText
+		var publishedAt sql.NullTime
+		if err := rows.Scan(&a.ID, &a.FeedID, &a.Title, &a.URL, &imageURL, &audioURL, &videoURL, &publishedAt, &a.IsRead, &a.IsFavorite, &a.IsHidden, &a.IsReadLater, &translatedTitle, &summary, &freshrssItemID, &a.FeedTitle, &author, &images, &discussionURL); err != nil {
+			log.Println("Error scanning article:", err)
+			continue
+		}
+		a.ImageURL = imageURL.String
+		a.AudioURL = audioURL.String
+		a.VideoURL = videoURL.String
+		if publishedAt.Valid {
+			a.PublishedAt = publishedAt.Time
+		}
+		a.TranslatedTitle = translatedTitle.String
+		a.Summary = summary.String
+		a.FreshRSSItemID = freshrssItemID.String
+		a.Author = author.String
+		a.Images = decodeArticleImages(images)
+		a.DiscussionURL = discussionURL.String
+		articles = append(articles, a)
+	}
+	return articles, rows.Err()
+}
@@ -1,7 +1,9 @@
 package database
 
 import (
+	"context"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -105,6 +107,18 @@ func TestUnreadCounts(t *testing.T) {
 		t.Errorf("Expected 2 unread articles in feed counts map, got %d", feedCounts[feedID])
 	}
 
+	// Test GetArticleCountsForAllFeeds
+	articleCounts, categoryCounts, err := db.GetArticleCountsForAllFeeds()
+	if err != nil {
+		t.Fatalf("Failed to get article counts for all feeds: %v", err)
+	}
+	if articleCounts[feedID] != (ArticleCounts{Unread: 2, Total: 3}) {
+		t.Errorf("Expected 2 unread of 3 articles for feed, got %+v", articleCounts[feedID])
+	}
+	if categoryCounts["Test"] != (ArticleCounts{Unread: 2, Total: 3}) {
+		t.Errorf("Expected 2 unread of 3 articles for category, got %+v", categoryCounts["Test"])
+	}
+
 	// Test MarkAllAsReadForFeed
 	if err := db.MarkAllAsReadForFeed(feedID); err != nil {
 		t.Fatalf("Failed to mark all as read for feed: %v", err)
@@ -222,6 +236,66 @@ func TestMarkAllAsReadExcludesHidden(t *testing.T) {
 	}
 }
 
+func TestMarkAllAsReadForCategoryWithSync(t *testing.T) {
+	db, err := NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.Close()
+	if err := db.Init(); err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+	if err := db.SetSetting("freshrss_enabled", "true"); err != nil {
+		t.Fatalf("SetSetting: %v", err)
+	}
+
+	feedIDs := make(map[string]int64)
+	for _, feed := range []*models.Feed{
+		{Title: "Tech", URL: "https://example.com/tech", Category: "Tech"},
+		{Title: "Go", URL: "https://example.com/go", Category: "Tech/Go", IsFreshRSSSource: true, FreshRSSStreamID: "feed/1"},
+		{Title: "Technology", URL: "https://example.com/technology", Category: "Technology"},
+		{Title: "Loose", URL: "https://example.com/loose"},
+	} {
+		id, err := db.AddFeed(feed)
+		if err != nil {
+			t.Fatalf("AddFeed: %v", err)
+		}
+		feedIDs[feed.Title] = id
+		article := models.Article{FeedID: id, Title: feed.Title + " article", URL: feed.URL + "/1", PublishedAt: time.Now()}
+		if err := db.SaveArticle(&article); err != nil {
+			t.Fatalf("SaveArticle: %v", err)
+		}
+	}
+
+	syncRequests, err := db.MarkAllAsReadForCategoryWithSync("Tech")
+	if err != nil {
+		t.Fatalf("MarkAllAsReadForCategoryWithSync: %v", err)
+	}
+	if len(syncRequests) != 1 || syncRequests[0].ArticleURL != "https://example.com/go/1" || syncRequests[0].Action != SyncActionMarkRead {
+		t.Errorf("Expected one mark_read sync request for the FreshRSS article, got %+v", syncRequests)
+	}
+
+	counts, err := db.GetUnreadCountsForAllFeeds()
+	if err != nil {
+		t.Fatalf("GetUnreadCountsForAllFeeds: %v", err)
+	}
+	// The subcategory is included, a category that merely shares the prefix is not
+	for title, want := range map[string]int{"Tech": 0, "Go": 0, "Technology": 1, "Loose": 1} {
+		if got := counts[feedIDs[title]]; got != want {
+			t.Errorf("Expected %d unread in %s, got %d", want, title, got)
+		}
+	}
+
+	// An empty category marks the uncategorized feeds
+	if _, err := db.MarkAllAsReadForCategoryWithSync(""); err != nil {
+		t.Fatalf("MarkAllAsReadForCategoryWithSync: %v", err)
+	}
+	counts, _ = db.GetUnreadCountsForAllFeeds()
+	if counts[feedIDs["Loose"]] != 0 || counts[feedIDs["Technology"]] != 1 {
+		t.Errorf("Expected only the uncategorized feed to be marked, got %v", counts)
+	}
+}
+
 func TestUnreadCountsWithHiddenArticles(t *testing.T) {
 	// Create temporary database
 	dbFile := "test_hidden.db"
@@ -265,3 +339,65 @@ func TestUnreadCountsWithHiddenArticles(t *testing.T) {
 		t.Errorf("Expected 1 unread article for feed (hidden should be excluded), got %d", feedCount)
 	}
 }
+
+func TestGetAllUnread(t *testing.T) {
+	db, err := NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer db.Close()
+	if err := db.Init(); err != nil {
+		t.Fatalf("Failed to initialize database: %v", err)
+	}
+
+	feedA, _ := db.AddFeed(&models.Feed{Title: "A", URL: "https://a.example.com/feed"})
+	feedB, _ := db.AddFeed(&models.Feed{Title: "B", URL: "https://b.example.com/feed"})
+
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	articles := []*models.Article{
+		{FeedID: feedA, Title: "a1", URL: "https://x.example.com/1", PublishedAt: base, HasValidPublishedTime: true},
+		{FeedID: feedB, Title: "b1", URL: "https://x.example.com/2", PublishedAt: base, HasValidPublishedTime: true}, // Same time as a1
+		{FeedID: feedA, Title: "a2", URL: "https://x.example.com/3", PublishedAt: base.Add(time.Hour), HasValidPublishedTime: true},
+		{FeedID: feedB, Title: "b2", URL: "https://x.example.com/4", PublishedAt: base.Add(2 * time.Hour), HasValidPublishedTime: true},
+		{FeedID: feedB, Title: "dup", URL: "https://x.example.com/3", PublishedAt: base.Add(3 * time.Hour), HasValidPublishedTime: true}, // Same URL as a2
+		{FeedID: feedA, Title: "read", URL: "https://x.example.com/5", PublishedAt: base.Add(4 * time.Hour), HasValidPublishedTime: true, IsRead: true},
+	}
+	if err := db.SaveArticles(context.Background(), articles); err != nil {
+		t.Fatalf("SaveArticles failed: %v", err)
+	}
+
+	collect := func(sortOrder string, pageSize int) []string {
+		var titles []string
+		cursor := Cursor{}
+		for i := 0; i < 10; i++ {
+			page, err := db.GetAllUnread(sortOrder, cursor, pageSize)
+			if err != nil {
+				t.Fatalf("GetAllUnread failed: %v", err)
+			}
+			for _, a := range page {
+				titles = append(titles, a.Title)
+			}
+			if len(page) < pageSize {
+				return titles
+			}
+			// Round-trip through the token like a client would
+			cursor, err = ParseCursor(CursorAfter(page[len(page)-1]).String())
+			if err != nil {
+				t.Fatalf("ParseCursor failed: %v", err)
+			}
+		}
+		t.Fatal("pagination did not terminate")
+		return nil
+	}
+
+	if got, want := strings.Join(collect(SortNewest, 1), ","), "b2,a2,b1,a1"; got != want {
+		t.Errorf("newest stream = %s, want %s", got, want)
+	}
+	if got, want := strings.Join(collect(SortOldest, 2), ","), "a1,b1,a2,b2"; got != want {
+		t.Errorf("oldest stream = %s, want %s", got, want)
+	}
+
+	if _, err := ParseCursor("garbage"); err != ErrInvalidCursor {
+		t.Errorf("expected ErrInvalidCursor, got %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -286,3 +287,107 @@ func TestGetFaviconAndResolveURLAndExtractLinks(t *testing.T) {
 		t.Fatalf("resolveURL failed: %s", resolved)
 	}
 }
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"example.com/feed", "https://example.com/feed", false},
+		{"  https://Example.com/blog/  ", "https://example.com/blog/", false},
+		{"http://example.com/post?utm_source=x&id=3&fbclid=abc#top", "http://example.com/post?id=3", false},
+		{"<https://example.com/rss.xml>.", "https://example.com/rss.xml", false},
+		{"http://localhost:8080/feed", "http://localhost:8080/feed", false},
+		{"https://example.com/feed?b=2&a=1&gclid=x&c=3", "https://example.com/feed?b=2&a=1&c=3", false},
+		{"https://en.wikipedia.org/wiki/Go_(game)", "https://en.wikipedia.org/wiki/Go_(game)", false},
+		{"https://example.com/feed).", "https://example.com/feed", false},
+		{"", "", true},
+		{"not a url", "", true},
+		{"ftp://example.com/feed", "", true},
+		{"foo", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeURL(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NormalizeURL(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("NormalizeURL(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestIsValidFeed_RetriesNetworkError(t *testing.T) {
+	var requests, dropAll int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Drop the first connection without a response
+		if atomic.AddInt32(&requests, 1) == 1 || atomic.LoadInt32(&dropAll) == 1 {
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.Header().Set("Content-Type", "application/rss+xml")
+	}))
+	defer srv.Close()
+
+	s := newServiceWithClient(srv.Client())
+	if !s.isValidFeed(context.Background(), srv.URL) {
+		t.Fatal("expected the feed to be valid after a retry")
+	}
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+
+	atomic.StoreInt32(&dropAll, 1)
+	ctx := WithValidationRun(context.Background(), ValidationOptions{Retries: 0})
+	if s.isValidFeed(ctx, srv.URL) {
+		t.Error("expected the check to fail without retries")
+	}
+}
+
+func TestIsValidFeed_Timeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(300 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/rss+xml")
+	}))
+	defer srv.Close()
+
+	s := newServiceWithClient(srv.Client())
+	ctx := WithValidationRun(context.Background(), ValidationOptions{Timeout: 50 * time.Millisecond})
+	if s.isValidFeed(ctx, srv.URL) {
+		t.Error("expected a check slower than the timeout to fail")
+	}
+	ctx = WithValidationRun(context.Background(), ValidationOptions{Timeout: 2 * time.Second})
+	if !s.isValidFeed(ctx, srv.URL) {
+		t.Error("expected a check within the timeout to succeed")
+	}
+}
+
+func TestIsValidFeed_CachedWithinRun(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/rss+xml")
+	}))
+	defer srv.Close()
+
+	s := newServiceWithClient(srv.Client())
+	ctx := WithValidationRun(context.Background(), DefaultValidationOptions())
+	for i := 0; i < 3; i++ {
+		if !s.isValidFeed(ctx, srv.URL) {
+			t.Fatal("expected the feed to be valid")
+		}
+	}
+	if got := atomic.LoadInt32(&requests); got != 1 {
+		t.Errorf("expected 1 request within a run, got %d", got)
+	}
+
+	// A new run checks again
+	s.isValidFeed(WithValidationRun(context.Background(), DefaultValidationOptions()), srv.URL)
+	if got := atomic.LoadInt32(&requests); got != 2 {
+		t.Errorf("expected a new run to check again, got %d requests", got)
+	}
+}
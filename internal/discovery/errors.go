@@ -6,4 +6,5 @@ var (
 	errTooManyRedirects       = errors.New("too many redirects")
 	errFriendLinkPageNotFound = errors.New("friend link page not found")
 	errRSSFeedNotFound        = errors.New("RSS feed not found")
+	errInvalidURL             = errors.New("invalid URL")
 )
@@ -15,8 +15,12 @@ func (s *Service) DiscoverFromFeed(ctx context.Context, feedURL string) ([]Disco
 	return s.DiscoverFromFeedWithProgress(ctx, feedURL, nil)
 }
 
-// DiscoverFromFeedWithProgress discovers blogs from a feed's homepage with progress updates
+// DiscoverFromFeedWithProgress discovers blogs from a feed's homepage with progress updates.
+// Candidate feeds are checked with the options of the validation run in ctx, or the
+// defaults when ctx doesn't carry one.
 func (s *Service) DiscoverFromFeedWithProgress(ctx context.Context, feedURL string, progressCb ProgressCallback) ([]DiscoveredBlog, error) {
+	ctx = ensureValidationRun(ctx)
+
 	// Report progress: fetching homepage
 	if progressCb != nil {
 		progressCb(Progress{
@@ -0,0 +1,115 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultValidationRetries is the number of extra attempts made after a network error
+// while checking a candidate feed URL
+const DefaultValidationRetries = 1
+
+// validationRetryDelay is the pause before each retry, multiplied by the attempt number
+const validationRetryDelay = 500 * time.Millisecond
+
+// ValidationOptions tunes how candidate feed URLs are checked during a discovery run
+type ValidationOptions struct {
+	// Timeout bounds each check request; zero uses HTTPClientTimeout
+	Timeout time.Duration
+	// Retries is the number of extra attempts after a network error
+	Retries int
+}
+
+// DefaultValidationOptions returns the options used when a run doesn't set its own
+func DefaultValidationOptions() ValidationOptions {
+	return ValidationOptions{Timeout: HTTPClientTimeout, Retries: DefaultValidationRetries}
+}
+
+// validationRun holds the options and results of feed checks shared by one discovery run
+type validationRun struct {
+	opts ValidationOptions
+
+	mu      sync.Mutex
+	results map[string]bool
+}
+
+type validationRunKey struct{}
+
+// WithValidationRun returns a context under which discovery calls share feed validation
+// results, so a candidate URL reached from several sources is only checked once. Use one
+// per discovery run; the results are not meant to outlive it.
+func WithValidationRun(ctx context.Context, opts ValidationOptions) context.Context {
+	return context.WithValue(ctx, validationRunKey{}, &validationRun{
+		opts:    opts,
+		results: make(map[string]bool),
+	})
+}
+
+// ensureValidationRun starts a validation run with the default options unless ctx already
+// carries one
+func ensureValidationRun(ctx context.Context) context.Context {
+	if validationRunFrom(ctx) != nil {
+		return ctx
+	}
+	return WithValidationRun(ctx, DefaultValidationOptions())
+}
+
+func validationRunFrom(ctx context.Context) *validationRun {
+	run, _ := ctx.Value(validationRunKey{}).(*validationRun)
+	return run
+}
+
+func (r *validationRun) lookup(feedURL string) (valid, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	valid, ok = r.results[feedURL]
+	return valid, ok
+}
+
+func (r *validationRun) store(feedURL string, valid bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results[feedURL] = valid
+}
+
+// doValidationRequest sends a feed check request, retrying network errors. Lookups of
+// hosts that don't exist are not retried, since they fail the same way again.
+func (s *Service) doValidationRequest(ctx context.Context, method, feedURL string, opts ValidationOptions) (*http.Response, error) {
+	client := s.client
+	if opts.Timeout > 0 && opts.Timeout != client.Timeout {
+		withTimeout := *s.client
+		withTimeout.Timeout = opts.Timeout
+		client = &withTimeout
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= opts.Retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, lastErr
+			case <-time.After(validationRetryDelay * time.Duration(attempt)):
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, feedURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+
+		var dnsErr *net.DNSError
+		if ctx.Err() != nil || (errors.As(err, &dnsErr) && dnsErr.IsNotFound) {
+			break
+		}
+	}
+	return nil, lastErr
+}
@@ -217,14 +217,29 @@ func (s *Service) findRSSFeed(ctx context.Context, blogURL string) (string, erro
 	return "", errRSSFeedNotFound
 }
 
-// isValidFeed checks if a URL is a valid RSS/Atom feed
+// isValidFeed checks if a URL is a valid RSS/Atom feed. Within a validation run the
+// result is reused for later checks of the same URL.
 func (s *Service) isValidFeed(ctx context.Context, feedURL string) bool {
-	req, err := http.NewRequestWithContext(ctx, "HEAD", feedURL, nil)
-	if err != nil {
-		return false
+	run := validationRunFrom(ctx)
+	opts := DefaultValidationOptions()
+	if run != nil {
+		if valid, ok := run.lookup(feedURL); ok {
+			return valid
+		}
+		opts = run.opts
+	}
+
+	valid := s.checkFeed(ctx, feedURL, opts)
+	// A check cut short by the run ending says nothing about the URL
+	if run != nil && ctx.Err() == nil {
+		run.store(feedURL, valid)
 	}
+	return valid
+}
 
-	resp, err := s.client.Do(req)
+// checkFeed requests a URL to see whether it serves an RSS/Atom feed
+func (s *Service) checkFeed(ctx context.Context, feedURL string, opts ValidationOptions) bool {
+	resp, err := s.doValidationRequest(ctx, "HEAD", feedURL, opts)
 	if err != nil {
 		return false
 	}
@@ -232,12 +247,7 @@ func (s *Service) isValidFeed(ctx context.Context, feedURL string) bool {
 
 	if resp.StatusCode != http.StatusOK {
 		// Try GET if HEAD doesn't work
-		req, err = http.NewRequestWithContext(ctx, "GET", feedURL, nil)
-		if err != nil {
-			return false
-		}
-
-		resp2, err := s.client.Do(req)
+		resp2, err := s.doValidationRequest(ctx, "GET", feedURL, opts)
 		if err != nil {
 			return false
 		}
@@ -0,0 +1,69 @@
+package discovery
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"MrRSS/internal/utils"
+)
+
+// NormalizeURL cleans up a URL pasted by the user: it trims whitespace and
+// surrounding junk, adds https:// when no scheme is given, lowercases the host,
+// drops the fragment and removes tracking parameters.
+func NormalizeURL(raw string) (string, error) {
+	s := trimSurroundingJunk(strings.TrimSpace(raw))
+	if s == "" || strings.ContainsAny(s, " \t\r\n") {
+		return "", errInvalidURL
+	}
+
+	if !strings.Contains(s, "://") {
+		s = "https://" + strings.TrimPrefix(s, "//")
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", errInvalidURL
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", errInvalidURL
+	}
+	host := u.Hostname()
+	if host == "" || (!strings.Contains(host, ".") && host != "localhost" && !strings.Contains(host, ":")) {
+		return "", errInvalidURL
+	}
+	u.Host = strings.ToLower(u.Host)
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	// Remaining parameters keep their order, which some feeds depend on
+	return utils.CleanURL(u.String()), nil
+}
+
+// trimSurroundingJunk removes quotes, angle brackets and trailing punctuation picked up
+// when a URL is copied from text. A closing bracket is only removed when the URL has no
+// matching opener, so URLs like https://en.wikipedia.org/wiki/Go_(game) keep theirs.
+func trimSurroundingJunk(s string) string {
+	for {
+		s = strings.Trim(s, "\"'<>")
+		if s == "" {
+			return s
+		}
+		last := s[len(s)-1]
+		switch {
+		case strings.IndexByte(".,;:!", last) >= 0:
+		case last == ')' && strings.Count(s, "(") < strings.Count(s, ")"):
+		case last == ']' && strings.Count(s, "[") < strings.Count(s, "]"):
+		case last == '}' && strings.Count(s, "{") < strings.Count(s, "}"):
+		default:
+			return s
+		}
+		s = s[:len(s)-1]
+	}
+}
+
+// IsFeedURL checks whether a URL points directly at an RSS/Atom feed
+func (s *Service) IsFeedURL(ctx context.Context, feedURL string) bool {
+	return s.isValidFeed(ctx, feedURL)
+}
@@ -0,0 +1,116 @@
+// Package events provides a small in-process pub/sub hub used to push live
+// updates (refresh progress, new articles, unread counts) to connected clients.
+package events
+
+import (
+	"errors"
+	"sync"
+)
+
+// Event types pushed to clients
+const (
+	TypeRefreshProgress     = "refresh_progress"
+	TypeNewArticles         = "new_articles"
+	TypeUnreadCountsChanged = "unread_counts_changed"
+)
+
+const (
+	// MaxSubscribers caps the number of concurrent live-update connections
+	MaxSubscribers = 16
+	// subscriberBuffer is the number of events queued per subscriber before the oldest is dropped
+	subscriberBuffer = 32
+)
+
+// ErrTooManySubscribers is returned when the subscriber cap has been reached
+var ErrTooManySubscribers = errors.New("too many event subscribers")
+
+// Event is a single message pushed to subscribers
+type Event struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data,omitempty"`
+}
+
+// Hub fans events out to subscribers without ever blocking the publisher.
+// A subscriber that falls behind loses its oldest queued events.
+// A nil *Hub is valid and discards everything published to it.
+type Hub struct {
+	mu             sync.Mutex
+	subscribers    map[chan Event]struct{}
+	maxSubscribers int
+}
+
+// NewHub creates a hub allowing at most maxSubscribers subscribers
+func NewHub(maxSubscribers int) *Hub {
+	if maxSubscribers < 1 {
+		maxSubscribers = MaxSubscribers
+	}
+	return &Hub{
+		subscribers:    make(map[chan Event]struct{}),
+		maxSubscribers: maxSubscribers,
+	}
+}
+
+// Subscribe registers a new subscriber and returns its event channel and an
+// unsubscribe function. The channel is closed on unsubscribe.
+func (h *Hub) Subscribe() (<-chan Event, func(), error) {
+	if h == nil {
+		return nil, nil, ErrTooManySubscribers
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.subscribers) >= h.maxSubscribers {
+		return nil, nil, ErrTooManySubscribers
+	}
+
+	ch := make(chan Event, subscriberBuffer)
+	h.subscribers[ch] = struct{}{}
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subscribers, ch)
+			close(ch)
+			h.mu.Unlock()
+		})
+	}
+	return ch, unsubscribe, nil
+}
+
+// Publish delivers an event to all subscribers without blocking
+func (h *Hub) Publish(eventType string, data interface{}) {
+	if h == nil {
+		return
+	}
+	event := Event{Type: eventType, Data: data}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// Subscriber is behind: drop its oldest event to make room
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- event:
+			default:
+			}
+		}
+	}
+}
+
+// SubscriberCount returns the number of active subscribers
+func (h *Hub) SubscriberCount() int {
+	if h == nil {
+		return 0
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subscribers)
+}
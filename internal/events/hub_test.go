@@ -0,0 +1,88 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHub_PublishDeliversInOrder(t *testing.T) {
+	h := NewHub(2)
+	ch, unsubscribe, err := h.Subscribe()
+	if err != nil {
+		t.Fatalf("Subscribe error: %v", err)
+	}
+	defer unsubscribe()
+
+	h.Publish(TypeNewArticles, 1)
+	h.Publish(TypeUnreadCountsChanged, 2)
+
+	for _, want := range []string{TypeNewArticles, TypeUnreadCountsChanged} {
+		select {
+		case e := <-ch:
+			if e.Type != want {
+				t.Fatalf("expected %s, got %s", want, e.Type)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for %s", want)
+		}
+	}
+}
+
+func TestHub_SlowSubscriberDropsOldest(t *testing.T) {
+	h := NewHub(1)
+	ch, unsubscribe, err := h.Subscribe()
+	if err != nil {
+		t.Fatalf("Subscribe error: %v", err)
+	}
+	defer unsubscribe()
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 1000; i++ {
+			h.Publish(TypeRefreshProgress, i)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("publisher blocked on a slow subscriber")
+	}
+
+	var last int
+	for len(ch) > 0 {
+		last = (<-ch).Data.(int)
+	}
+	if last != 999 {
+		t.Fatalf("expected the newest event to be kept, got %d", last)
+	}
+}
+
+func TestHub_SubscriberCap(t *testing.T) {
+	h := NewHub(1)
+	_, unsubscribe, err := h.Subscribe()
+	if err != nil {
+		t.Fatalf("Subscribe error: %v", err)
+	}
+	if _, _, err := h.Subscribe(); err != ErrTooManySubscribers {
+		t.Fatalf("expected ErrTooManySubscribers, got %v", err)
+	}
+
+	unsubscribe()
+	unsubscribe() // Must be safe to call twice
+	if h.SubscriberCount() != 0 {
+		t.Fatalf("expected no subscribers, got %d", h.SubscriberCount())
+	}
+	if _, _, err := h.Subscribe(); err != nil {
+		t.Fatalf("expected a free slot after unsubscribe, got %v", err)
+	}
+}
+
+func TestHub_NilIsNoop(t *testing.T) {
+	var h *Hub
+	h.Publish(TypeNewArticles, nil)
+	if h.SubscriberCount() != 0 {
+		t.Fatal("nil hub should have no subscribers")
+	}
+}
@@ -0,0 +1,113 @@
+package feed
+
+import (
+	"log"
+	"sync"
+)
+
+const (
+	// adaptiveWindowSize is the number of recent fetch outcomes the error rate is computed over
+	adaptiveWindowSize = 8
+	// adaptiveMinSamples is the number of outcomes needed before the limit may be cut
+	adaptiveMinSamples = 4
+	// adaptiveErrorThreshold is the share of network errors that halves the limit
+	adaptiveErrorThreshold = 0.5
+)
+
+// adaptiveLimiter adjusts the number of concurrent feed fetches to the observed
+// network error rate (AIMD). The limit is halved when network errors spike and
+// grows by one after a full limit's worth of successes, never exceeding the
+// configured ceiling.
+type adaptiveLimiter struct {
+	mu        sync.Mutex
+	enabled   bool
+	ceiling   int
+	limit     int
+	window    []bool // Recent outcomes, true for a network error
+	successes int    // Successes since the limit last changed
+}
+
+func newAdaptiveLimiter(ceiling int) *adaptiveLimiter {
+	l := &adaptiveLimiter{enabled: true}
+	l.Reset(ceiling)
+	return l
+}
+
+// Reset restores the limit to the ceiling and forgets past outcomes.
+// Called at the start of every global refresh.
+func (l *adaptiveLimiter) Reset(ceiling int) {
+	if ceiling < 1 {
+		ceiling = 1
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.ceiling = ceiling
+	l.limit = ceiling
+	l.window = l.window[:0]
+	l.successes = 0
+}
+
+// SetEnabled turns adaptation on or off; when off the limit stays at the ceiling
+func (l *adaptiveLimiter) SetEnabled(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.enabled = enabled
+	if !enabled {
+		l.limit = l.ceiling
+	}
+}
+
+// Limit returns the current number of fetches allowed in flight
+func (l *adaptiveLimiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limit
+}
+
+// Record feeds the outcome of a fetch into the limiter
+func (l *adaptiveLimiter) Record(networkErr bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.enabled {
+		return
+	}
+
+	l.window = append(l.window, networkErr)
+	if len(l.window) > adaptiveWindowSize {
+		l.window = l.window[len(l.window)-adaptiveWindowSize:]
+	}
+
+	if !networkErr {
+		l.successes++
+		if l.successes >= l.limit && l.limit < l.ceiling {
+			l.limit++
+			l.successes = 0
+			log.Printf("Adaptive concurrency: network recovering, raising limit to %d", l.limit)
+		}
+		return
+	}
+
+	l.successes = 0
+	if len(l.window) < adaptiveMinSamples {
+		return
+	}
+	failures := 0
+	for _, failed := range l.window {
+		if failed {
+			failures++
+		}
+	}
+	if float64(failures)/float64(len(l.window)) >= adaptiveErrorThreshold && l.limit > 1 {
+		l.limit /= 2
+		// Judge the new limit on fresh outcomes only
+		l.window = l.window[:0]
+		log.Printf("Adaptive concurrency: %d of the last fetches hit network errors, lowering limit to %d", failures, l.limit)
+	}
+}
+
+// isNetworkError reports whether a fetch error points at the network rather than
+// the feed itself. Only these errors slow down the refresh.
+func isNetworkError(err error) bool {
+	category, _ := ClassifyFetchError(err)
+	return category == FetchErrorTimeout || category == FetchErrorConnection
+}
@@ -0,0 +1,75 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestAdaptiveLimiter_DecreasesOnNetworkErrors(t *testing.T) {
+	l := newAdaptiveLimiter(8)
+
+	for i := 0; i < adaptiveMinSamples; i++ {
+		l.Record(true)
+	}
+	if got := l.Limit(); got != 4 {
+		t.Fatalf("expected limit to halve to 4, got %d", got)
+	}
+
+	for i := 0; i < 20; i++ {
+		l.Record(true)
+	}
+	if got := l.Limit(); got != 1 {
+		t.Fatalf("expected limit to bottom out at 1, got %d", got)
+	}
+}
+
+func TestAdaptiveLimiter_RampsUpToCeiling(t *testing.T) {
+	l := newAdaptiveLimiter(4)
+	for i := 0; i < 2*adaptiveMinSamples; i++ {
+		l.Record(true)
+	}
+	if got := l.Limit(); got != 1 {
+		t.Fatalf("expected limit 1 after sustained errors, got %d", got)
+	}
+
+	for i := 0; i < 50; i++ {
+		l.Record(false)
+	}
+	if got := l.Limit(); got != 4 {
+		t.Fatalf("expected limit to recover to the ceiling 4, got %d", got)
+	}
+}
+
+func TestAdaptiveLimiter_IgnoresSparseErrors(t *testing.T) {
+	l := newAdaptiveLimiter(6)
+	for i := 0; i < 30; i++ {
+		l.Record(i%4 == 0)
+	}
+	if got := l.Limit(); got != 6 {
+		t.Fatalf("expected occasional errors to keep the limit at 6, got %d", got)
+	}
+}
+
+func TestAdaptiveLimiter_Disabled(t *testing.T) {
+	l := newAdaptiveLimiter(6)
+	l.SetEnabled(false)
+	for i := 0; i < 10; i++ {
+		l.Record(true)
+	}
+	if got := l.Limit(); got != 6 {
+		t.Fatalf("expected disabled limiter to stay at 6, got %d", got)
+	}
+}
+
+func TestIsNetworkError(t *testing.T) {
+	if !isNetworkError(context.DeadlineExceeded) {
+		t.Error("expected a timeout to count as a network error")
+	}
+	if isNetworkError(nil) {
+		t.Error("expected success not to count as a network error")
+	}
+	if isNetworkError(errors.New("XML syntax error on line 3")) {
+		t.Error("expected a parse error not to count as a network error")
+	}
+}
@@ -3,12 +3,16 @@ package feed
 import (
 	"MrRSS/internal/models"
 	"MrRSS/internal/utils"
+	"html"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/mmcdole/gofeed"
+	nethtml "golang.org/x/net/html"
 )
 
 // ExtractContent extracts content from an RSS item with the correct priority order.
@@ -35,12 +39,22 @@ func ExtractContent(item *gofeed.Item) string {
 type ArticleWithContent struct {
 	Article *models.Article
 	Content string
+	// Snippet is the short plain-text preview kept instead of Content in metadata-only mode
+	Snippet string
 }
 
+// htmlTagRegex matches HTML tags, for reducing content to plain text
+var htmlTagRegex = regexp.MustCompile(`<[^>]+>`)
+
+// maxSnippetRunes caps the preview kept for articles fetched in metadata-only mode
+const maxSnippetRunes = 200
+
 // processArticles processes RSS feed items and converts them to Article models
 // Returns a slice of ArticleWithContent which includes both the article and its content
 func (f *Fetcher) processArticles(feed models.Feed, items []*gofeed.Item) []*ArticleWithContent {
 	var articlesWithContent []*ArticleWithContent
+	maxImages := f.maxArticleImages()
+	metadataOnly := f.metadataOnly(feed)
 
 	for _, item := range items {
 		var published time.Time
@@ -66,6 +80,15 @@ func (f *Fetcher) processArticles(feed models.Feed, items []*gofeed.Item) []*Art
 		// Clean HTML to fix malformed tags that can cause rendering issues
 		content = utils.CleanHTML(content)
 
+		// Gallery images from the article body
+		var images []string
+		for _, img := range ExtractImages(content, maxImages) {
+			images = append(images, resolveRelativeURL(img, feed.URL))
+		}
+
+		// Comment thread linked from aggregator items (Hacker News, Reddit, Lobsters, ...)
+		discussionURL := ExtractDiscussionURL(content, item.Link, feed.URL)
+
 		// Determine title: prefer media:title if available, then item.Title, then generate from content
 		title := item.Title
 		if mediaTitle != "" {
@@ -100,34 +123,73 @@ func (f *Fetcher) processArticles(feed models.Feed, items []*gofeed.Item) []*Art
 			HasValidPublishedTime: hasValidPublishedTime,
 			TranslatedTitle:       translatedTitle,
 			Author:                author,
+			IsReadLater:           feed.ReadLaterByDefault,
+			Images:                images,
+			DiscussionURL:         discussionURL,
 		}
 
-		articlesWithContent = append(articlesWithContent, &ArticleWithContent{
-			Article: article,
-			Content: content,
-		})
+		awc := &ArticleWithContent{Article: article, Content: content}
+		if metadataOnly {
+			// The body is fetched on demand when the article is opened
+			awc.Snippet = contentSnippet(content)
+			awc.Content = ""
+		}
+		articlesWithContent = append(articlesWithContent, awc)
 	}
 
 	return articlesWithContent
 }
 
-// extractImageURL extracts the image URL from a feed item and resolves relative URLs
-func extractImageURL(item *gofeed.Item, feedURL string) string {
-	// Try item.Image first
-	if item.Image != nil {
-		return resolveRelativeURL(item.Image.URL, feedURL)
+// defaultMaxArticleImages is used when max_article_images is unset or invalid
+const defaultMaxArticleImages = 10
+
+// maxArticleImages returns how many body images to store per article; 0 disables extraction
+func (f *Fetcher) maxArticleImages() int {
+	if f.db == nil {
+		return defaultMaxArticleImages
 	}
+	value, err := f.db.GetSetting("max_article_images")
+	if err != nil {
+		return defaultMaxArticleImages
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return defaultMaxArticleImages
+	}
+	return n
+}
 
-	// Try Media RSS thumbnail (YouTube feeds use this)
-	if thumbnailURL := extractMediaThumbnail(item); thumbnailURL != "" {
-		return resolveRelativeURL(thumbnailURL, feedURL)
+// metadataOnly reports whether articles of the feed are stored without their content.
+// Feeds with eager content enabled always keep it.
+func (f *Fetcher) metadataOnly(feed models.Feed) bool {
+	if f.db == nil || feed.EagerContent {
+		return false
 	}
+	enabled, _ := f.db.GetSetting("metadata_only_fetch")
+	return enabled == "true"
+}
 
-	// Try enclosures for images (check various image MIME types)
-	for _, enc := range item.Enclosures {
-		if strings.HasPrefix(enc.Type, "image/") {
-			return resolveRelativeURL(enc.URL, feedURL)
-		}
+// contentSnippet returns the start of the content's text, cut at a word boundary
+func contentSnippet(content string) string {
+	text := html.UnescapeString(htmlTagRegex.ReplaceAllString(content, " "))
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	if len(runes) <= maxSnippetRunes {
+		return text
+	}
+	cut := string(runes[:maxSnippetRunes])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return cut + "…"
+}
+
+// extractImageURL extracts the image URL from a feed item and resolves relative URLs
+func extractImageURL(item *gofeed.Item, feedURL string) string {
+	// Prefer images the feed announces (item.Image, Media RSS, image enclosures),
+	// picking the highest resolution available
+	if imageURL := bestFeedImage(item); imageURL != "" {
+		return resolveRelativeURL(imageURL, feedURL)
 	}
 
 	// Fallback: Try to find image in description/content
@@ -225,6 +287,124 @@ func ExtractAllImageURLsFromHTML(htmlContent string) []string {
 	return urls
 }
 
+// minGalleryImageSize is the smallest declared width or height of an image kept by
+// ExtractImages; smaller images are icons, emoji or tracking pixels
+const minGalleryImageSize = 50
+
+// trackingImagePatterns mark image URLs that are counters or analytics beacons rather than content
+var trackingImagePatterns = []string{
+	"feedburner.com", "/~r/", "/~ff/", "stats.wordpress.com", "pixel.wp.com", "doubleclick.net",
+	"google-analytics.com", "/pixel.", "/pixel?", "/beacon", "/track", "1x1.", "spacer.gif", "blank.gif",
+}
+
+// ExtractImages returns up to max distinct image URLs from HTML content, in document
+// order, for article galleries. Lazy-loaded images are read from data-src. Tracking
+// pixels, data URIs and images declared smaller than minGalleryImageSize are skipped.
+// Relative URLs are returned as-is.
+func ExtractImages(htmlContent string, max int) []string {
+	if htmlContent == "" || max <= 0 {
+		return nil
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil
+	}
+
+	var images []string
+	seen := make(map[string]bool)
+	doc.Find("img").EachWithBreak(func(_ int, img *goquery.Selection) bool {
+		src := strings.TrimSpace(img.AttrOr("src", ""))
+		if src == "" || strings.HasPrefix(src, "data:") {
+			src = strings.TrimSpace(img.AttrOr("data-src", ""))
+		}
+		if src == "" || strings.HasPrefix(src, "data:") || seen[src] || isTrackingImage(src) {
+			return true
+		}
+		if isTinyImage(img.AttrOr("width", ""), img.AttrOr("height", "")) {
+			return true
+		}
+		seen[src] = true
+		images = append(images, src)
+		return len(images) < max
+	})
+	return images
+}
+
+// discussionLinkText matches the text of links to a comment thread, such as "Comments",
+// "[comments]", "42 comments", "Discussion" or "评论"
+var discussionLinkText = regexp.MustCompile(`(?i)^[\[(]?\s*(\d+\s+)?(comments?|discussion|discuss|评论|讨论)\s*[\])]?$`)
+
+// discussionLabel matches text that labels the link after it, like "Comments URL:" in hnrss.org items
+var discussionLabel = regexp.MustCompile(`(?i)(comments?( url)?|discussion|评论|讨论)\s*[:：]\s*$`)
+
+// ExtractDiscussionURL returns the link to the comment thread of an aggregator item, found
+// by the text of a link in its content or the label right before it. A link back to the
+// article itself is ignored, as aggregators point self posts at their own thread. Relative
+// URLs are resolved against the feed URL.
+func ExtractDiscussionURL(htmlContent, articleURL, feedURL string) string {
+	if htmlContent == "" {
+		return ""
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return ""
+	}
+
+	var discussionURL string
+	doc.Find("a[href]").EachWithBreak(func(_ int, a *goquery.Selection) bool {
+		href := strings.TrimSpace(a.AttrOr("href", ""))
+		if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+			return true
+		}
+		if !discussionLinkText.MatchString(strings.TrimSpace(a.Text())) && !discussionLabel.MatchString(precedingText(a)) {
+			return true
+		}
+		href = resolveRelativeURL(href, feedURL)
+		if href == articleURL {
+			return true
+		}
+		discussionURL = href
+		return false
+	})
+	return discussionURL
+}
+
+// precedingText returns the text of the node right before a selection, when it is text
+func precedingText(s *goquery.Selection) string {
+	if len(s.Nodes) == 0 {
+		return ""
+	}
+	prev := s.Nodes[0].PrevSibling
+	if prev == nil || prev.Type != nethtml.TextNode {
+		return ""
+	}
+	return prev.Data
+}
+
+// isTrackingImage reports whether an image URL looks like a tracking pixel
+func isTrackingImage(src string) bool {
+	lower := strings.ToLower(src)
+	for _, pattern := range trackingImagePatterns {
+		if strings.Contains(lower, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTinyImage reports whether declared dimensions mark an image as too small for a gallery.
+// Missing or non-pixel dimensions are not held against the image.
+func isTinyImage(width, height string) bool {
+	for _, dim := range []string{width, height} {
+		if n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(dim), "px")); err == nil && n < minGalleryImageSize {
+			return true
+		}
+	}
+	return false
+}
+
 // extractAudioURL extracts the audio URL from a feed item (for podcasts)
 func extractAudioURL(item *gofeed.Item) string {
 	// Try enclosures for audio files
@@ -306,7 +486,6 @@ func generateTitleFromContent(content string) string {
 	}
 
 	// Remove HTML tags
-	htmlTagRegex := regexp.MustCompile(`<[^>]+>`)
 	plainText := htmlTagRegex.ReplaceAllString(content, "")
 
 	// Trim whitespace
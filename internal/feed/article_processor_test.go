@@ -3,6 +3,8 @@ package feed
 import (
 	"MrRSS/internal/database"
 	"MrRSS/internal/models"
+	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -339,6 +341,68 @@ func TestExtractImageURLWithMediaRSS(t *testing.T) {
 			},
 			expected: "https://cdn.example.com/image.jpg",
 		},
+		{
+			name: "Largest media:content wins over a small thumbnail and item.Image",
+			item: &gofeed.Item{
+				Image: &gofeed.Image{URL: "https://example.com/from-body.jpg"},
+				Extensions: ext.Extensions{
+					"media": {
+						"thumbnail": []ext.Extension{
+							{Name: "thumbnail", Attrs: map[string]string{"url": "https://example.com/thumb.jpg", "width": "150", "height": "100"}},
+						},
+						"content": []ext.Extension{
+							{Name: "content", Attrs: map[string]string{"url": "https://example.com/medium.jpg", "medium": "image", "width": "640"}},
+							{Name: "content", Attrs: map[string]string{"url": "https://example.com/large.jpg", "type": "image/jpeg", "width": "1280", "height": "720"}},
+							{Name: "content", Attrs: map[string]string{"url": "https://example.com/clip.mp4", "type": "video/mp4", "width": "1920", "height": "1080"}},
+						},
+					},
+				},
+			},
+			expected: "https://example.com/large.jpg",
+		},
+		{
+			name: "Small declared thumbnail does not replace an image of unknown size",
+			item: &gofeed.Item{
+				Image: &gofeed.Image{URL: "https://example.com/from-body.jpg"},
+				Extensions: ext.Extensions{
+					"media": {
+						"thumbnail": []ext.Extension{
+							{Name: "thumbnail", Attrs: map[string]string{"url": "https://example.com/thumb.jpg", "width": "150", "height": "100"}},
+						},
+					},
+				},
+			},
+			expected: "https://example.com/from-body.jpg",
+		},
+		{
+			name: "Video poster thumbnail nested in media:content",
+			item: &gofeed.Item{
+				Extensions: ext.Extensions{
+					"media": {
+						"content": []ext.Extension{
+							{
+								Name:  "content",
+								Attrs: map[string]string{"url": "https://example.com/clip.mp4", "medium": "video"},
+								Children: map[string][]ext.Extension{
+									"thumbnail": {{Name: "thumbnail", Attrs: map[string]string{"url": "https://example.com/poster.jpg"}}},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: "https://example.com/poster.jpg",
+		},
+		{
+			name: "Atom enclosure link without a type",
+			item: &gofeed.Item{
+				Enclosures: []*gofeed.Enclosure{
+					{URL: "https://example.com/episode.mp3", Type: "audio/mpeg"},
+					{URL: "https://example.com/cover.webp?v=2"},
+				},
+			},
+			expected: "https://example.com/cover.webp?v=2",
+		},
 		{
 			name:     "No image available",
 			item:     &gofeed.Item{},
@@ -456,3 +520,102 @@ func TestProcessArticlesWithYouTubeFeed(t *testing.T) {
 		t.Errorf("Expected video URL '%s', got '%s'", expectedVideoURL, article.VideoURL)
 	}
 }
+
+func TestExtractImages(t *testing.T) {
+	html := `<p>Intro</p>
+<img src="https://example.com/a.jpg">
+<img src="https://feeds.feedburner.com/~r/blog/~4/abc" width="1" height="1">
+<img src="https://example.com/icon.png" width="16" height="16">
+<img src="data:image/gif;base64,R0lGOD" data-src="https://example.com/lazy.jpg">
+<img src="https://example.com/a.jpg">
+<img src="/relative/b.jpg" width="800px">
+<img src="https://stats.wordpress.com/b.gif?host=example.com">
+<img src="https://example.com/c.jpg">`
+
+	got := ExtractImages(html, 10)
+	want := []string{"https://example.com/a.jpg", "https://example.com/lazy.jpg", "/relative/b.jpg", "https://example.com/c.jpg"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("ExtractImages() = %v, want %v", got, want)
+	}
+
+	if got := ExtractImages(html, 2); len(got) != 2 {
+		t.Errorf("expected the max to cap the result at 2, got %v", got)
+	}
+	if got := ExtractImages(html, 0); got != nil {
+		t.Errorf("expected no images with max 0, got %v", got)
+	}
+}
+
+func TestExtractDiscussionURL(t *testing.T) {
+	cases := []struct {
+		name, content, articleURL, want string
+	}{
+		{"hacker news", `<a href="https://news.ycombinator.com/item?id=1">Comments</a>`,
+			"https://example.com/story", "https://news.ycombinator.com/item?id=1"},
+		{"hnrss label", `<p>Article URL: <a href="https://example.com/story">https://example.com/story</a></p>` +
+			`<p>Comments URL: <a href="https://news.ycombinator.com/item?id=2">https://news.ycombinator.com/item?id=2</a></p>`,
+			"https://example.com/story", "https://news.ycombinator.com/item?id=2"},
+		{"reddit", `submitted by <a href="https://www.reddit.com/user/u">/u/u</a> <a href="https://example.com/img.png">[link]</a> ` +
+			`<a href="https://www.reddit.com/r/golang/comments/abc/post/">[comments]</a>`,
+			"https://example.com/img.png", "https://www.reddit.com/r/golang/comments/abc/post/"},
+		{"counted", `<a href="/s/xyz">12 comments</a>`, "https://example.com/story", "https://lobste.rs/s/xyz"},
+		{"self post", `<a href="https://news.ycombinator.com/item?id=3">Comments</a>`,
+			"https://news.ycombinator.com/item?id=3", ""},
+		{"no discussion", `<p>See <a href="https://example.com/more">more comments on this topic</a></p>`,
+			"https://example.com/story", ""},
+	}
+	for _, tc := range cases {
+		if got := ExtractDiscussionURL(tc.content, tc.articleURL, "https://lobste.rs/rss"); got != tc.want {
+			t.Errorf("%s: ExtractDiscussionURL() = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestFetchFeed_MetadataOnly(t *testing.T) {
+	db := setupDBForFeedTests(t)
+	if err := db.SetSetting("metadata_only_fetch", "true"); err != nil {
+		t.Fatalf("SetSetting failed: %v", err)
+	}
+	body := "<p>" + strings.Repeat("Long article body &amp; more words. ", 20) + "</p>"
+	published := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	fetcher := NewFetcher(db)
+	fetcher.fp = &MockParser{Feed: &gofeed.Feed{
+		Title: "Metered Feed",
+		Items: []*gofeed.Item{{
+			Title:           "Headline",
+			Link:            "http://test.com/headline",
+			Content:         body,
+			Author:          &gofeed.Person{Name: "Reporter"},
+			PublishedParsed: &published,
+		}},
+	}}
+
+	feedID, err := fetcher.AddSubscription("http://test.com/rss", "", "")
+	if err != nil {
+		t.Fatalf("AddSubscription failed: %v", err)
+	}
+	feed, _ := db.GetFeedByID(feedID)
+	fetcher.FetchFeed(context.Background(), *feed)
+
+	articles, err := db.GetArticles("", feedID, "", false, 10, 0)
+	if err != nil || len(articles) != 1 {
+		t.Fatalf("expected 1 article, got %d (err %v)", len(articles), err)
+	}
+	a := articles[0]
+	if a.Title != "Headline" || a.URL != "http://test.com/headline" || a.Author != "Reporter" || !a.PublishedAt.Equal(published) {
+		t.Errorf("expected the metadata to be stored, got %+v", a)
+	}
+	if content, found, _ := db.GetArticleContent(a.ID); found || content != "" {
+		t.Errorf("expected no content to be stored, got %q", content)
+	}
+	snippet, err := db.GetArticleSnippet(a.ID)
+	if err != nil || !strings.HasPrefix(snippet, "Long article body & more words.") || len([]rune(snippet)) > maxSnippetRunes+1 {
+		t.Errorf("expected a short plain-text snippet, got %q (err %v)", snippet, err)
+	}
+
+	// Eager content takes precedence over the global mode
+	feed.EagerContent = true
+	if fetcher.metadataOnly(*feed) {
+		t.Error("expected feeds with eager content to keep their content")
+	}
+}
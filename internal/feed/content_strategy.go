@@ -0,0 +1,133 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"MrRSS/internal/models"
+	"MrRSS/internal/utils"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// maxContentPageSize caps the article page downloaded for the selector strategy
+const maxContentPageSize = 10 << 20
+
+// NormalizeContentStrategy validates an ordered strategy list and drops blanks and repeats
+func NormalizeContentStrategy(strategy []string) ([]string, error) {
+	var normalized []string
+	seen := make(map[string]bool)
+	for _, s := range strategy {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if s == "" || seen[s] {
+			continue
+		}
+		switch s {
+		case models.ContentStrategyFeed, models.ContentStrategySelector, models.ContentStrategyReadability, models.ContentStrategyScript:
+		default:
+			return nil, fmt.Errorf("unknown content strategy %q", s)
+		}
+		seen[s] = true
+		normalized = append(normalized, s)
+	}
+	return normalized, nil
+}
+
+// ExtractContentWithStrategies tries the feed's content strategies in order and returns the
+// first non-empty content with the name of the strategy that produced it. A feed without
+// strategies uses its feed content only. feedContent is only called when the feed strategy
+// is reached, since it may have to fetch and parse the feed.
+func (f *Fetcher) ExtractContentWithStrategies(ctx context.Context, feed models.Feed, articleURL string, feedContent func() (string, error)) (string, string) {
+	strategy := feed.ContentStrategy
+	if len(strategy) == 0 {
+		strategy = []string{models.ContentStrategyFeed}
+	}
+
+	for _, name := range strategy {
+		if ctx.Err() != nil {
+			break
+		}
+		content, err := f.runContentStrategy(ctx, feed, name, articleURL, feedContent)
+		if err != nil {
+			utils.DebugLog("Content strategy %s failed for %s: %v", name, articleURL, err)
+			continue
+		}
+		if strings.TrimSpace(content) != "" {
+			if len(feed.ContentStrategy) > 0 {
+				log.Printf("Content for %s extracted with the %s strategy of feed %d", articleURL, name, feed.ID)
+			}
+			return content, name
+		}
+	}
+	return "", ""
+}
+
+// runContentStrategy runs a single content strategy
+func (f *Fetcher) runContentStrategy(ctx context.Context, feed models.Feed, name, articleURL string, feedContent func() (string, error)) (string, error) {
+	if name == models.ContentStrategyFeed {
+		return feedContent()
+	}
+	if articleURL == "" {
+		return "", fmt.Errorf("article has no URL")
+	}
+
+	switch name {
+	case models.ContentStrategySelector:
+		if feed.ContentSelector == "" {
+			return "", fmt.Errorf("no content selector configured")
+		}
+		client, err := f.getHTTPClient(feed)
+		if err != nil {
+			return "", err
+		}
+		return fetchSelectedContent(ctx, client, articleURL, feed.ContentSelector)
+	case models.ContentStrategyReadability:
+		return f.fetchReadableContent(ctx, feed, articleURL)
+	case models.ContentStrategyScript:
+		if feed.ContentScriptPath == "" {
+			return "", fmt.Errorf("no content script configured")
+		}
+		if f.scriptExecutor == nil {
+			return "", fmt.Errorf("script executor not initialized")
+		}
+		content, err := f.scriptExecutor.ExecuteContentScript(ctx, feed.ContentScriptPath, articleURL)
+		if err != nil {
+			return "", err
+		}
+		return utils.CleanHTML(content), nil
+	}
+	return "", fmt.Errorf("unknown content strategy %q", name)
+}
+
+// fetchSelectedContent downloads a page and returns the HTML of the elements matching selector
+func fetchSelectedContent(ctx context.Context, client *http.Client, pageURL, selector string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(io.LimitReader(resp.Body, maxContentPageSize))
+	if err != nil {
+		return "", err
+	}
+
+	var parts []string
+	doc.Find(selector).Each(func(_ int, s *goquery.Selection) {
+		if html, err := goquery.OuterHtml(s); err == nil {
+			parts = append(parts, html)
+		}
+	})
+	return utils.CleanHTML(strings.Join(parts, "\n")), nil
+}
@@ -0,0 +1,132 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"MrRSS/internal/database"
+	"MrRSS/internal/models"
+)
+
+func TestNormalizeContentStrategy(t *testing.T) {
+	got, err := NormalizeContentStrategy([]string{" Selector", "", "feed", "selector", "READABILITY"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(got, ",") != "selector,feed,readability" {
+		t.Errorf("unexpected normalized strategy: %v", got)
+	}
+	if _, err := NormalizeContentStrategy([]string{"feed", "magic"}); err == nil {
+		t.Error("expected an error for an unknown strategy")
+	}
+}
+
+func TestExtractContentWithStrategies(t *testing.T) {
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("NewDB error: %v", err)
+	}
+	defer db.Close()
+	if err := db.Init(); err != nil {
+		t.Fatalf("Init error: %v", err)
+	}
+	f := NewFetcher(db)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<html><body><nav>Menu</nav><div class="post"><p>Full story</p></div></body></html>`)
+	}))
+	defer server.Close()
+
+	feedCalls := 0
+	feedContent := func() (string, error) {
+		feedCalls++
+		return "<p>Feed summary</p>", nil
+	}
+
+	// The selector matches, so the feed is never consulted
+	feed := models.Feed{ID: 1, ContentStrategy: []string{"selector", "feed"}, ContentSelector: ".post"}
+	content, strategy := f.ExtractContentWithStrategies(context.Background(), feed, server.URL, feedContent)
+	if strategy != models.ContentStrategySelector || !strings.Contains(content, "Full story") || strings.Contains(content, "Menu") {
+		t.Errorf("expected selected content, got %q from %q", content, strategy)
+	}
+	if feedCalls != 0 {
+		t.Errorf("feed content should not be fetched when an earlier strategy succeeds")
+	}
+
+	// A selector that matches nothing falls through to the next strategy
+	feed.ContentSelector = ".missing"
+	content, strategy = f.ExtractContentWithStrategies(context.Background(), feed, server.URL, feedContent)
+	if strategy != models.ContentStrategyFeed || content != "<p>Feed summary</p>" {
+		t.Errorf("expected feed content fallback, got %q from %q", content, strategy)
+	}
+
+	// Without strategies only the feed content is used
+	content, strategy = f.ExtractContentWithStrategies(context.Background(), models.Feed{ID: 2}, server.URL, feedContent)
+	if strategy != models.ContentStrategyFeed || content != "<p>Feed summary</p>" {
+		t.Errorf("expected feed content by default, got %q from %q", content, strategy)
+	}
+}
+
+func TestExtractContentWithStrategies_ReadabilityUsesFeedProxy(t *testing.T) {
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("NewDB error: %v", err)
+	}
+	defer db.Close()
+	if err := db.Init(); err != nil {
+		t.Fatalf("Init error: %v", err)
+	}
+	f := NewFetcher(db)
+
+	// The article host doesn't resolve, so the page can only come through the feed's proxy
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		story := strings.Repeat("The full story is only reachable through the proxy. ", 20)
+		fmt.Fprintf(w, `<html><head><title>Story</title></head><body><nav>Menu</nav><article><h1>Story</h1><p>%s</p><p>%s</p></article></body></html>`, story, story)
+	}))
+	defer proxy.Close()
+
+	feed := models.Feed{ID: 1, ContentStrategy: []string{"readability"}, ProxyEnabled: true, ProxyURL: proxy.URL}
+	content, strategy := f.ExtractContentWithStrategies(context.Background(), feed, "http://article.invalid/story", func() (string, error) {
+		return "", nil
+	})
+	if strategy != models.ContentStrategyReadability || !strings.Contains(content, "only reachable through the proxy") {
+		t.Errorf("expected readable content through the proxy, got %q from %q", content, strategy)
+	}
+	if proxied != "http://article.invalid/story" {
+		t.Errorf("expected the page to be requested through the proxy, got %q", proxied)
+	}
+}
+
+func TestExtractContentWithStrategies_Script(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell scripts are not supported on Windows")
+	}
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not available")
+	}
+
+	scriptsDir := t.TempDir()
+	script := "#!/bin/bash\necho \"<article>Scripted $1</article>\"\n"
+	if err := os.WriteFile(filepath.Join(scriptsDir, "content.sh"), []byte(script), 0755); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+
+	f := &Fetcher{scriptExecutor: NewScriptExecutor(scriptsDir)}
+	feed := models.Feed{ID: 1, ContentStrategy: []string{"script"}, ContentScriptPath: "content.sh"}
+	content, strategy := f.ExtractContentWithStrategies(context.Background(), feed, "https://example.com/a", func() (string, error) {
+		return "", nil
+	})
+	if strategy != models.ContentStrategyScript || !strings.Contains(content, "Scripted https://example.com/a") {
+		t.Errorf("expected script content, got %q from %q", content, strategy)
+	}
+}
@@ -0,0 +1,138 @@
+package feed
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"MrRSS/internal/models"
+	"MrRSS/internal/utils"
+
+	"codeberg.org/readeck/go-readability/v2"
+)
+
+const (
+	// eagerContentConcurrency caps full-content page fetches across all feeds being refreshed
+	eagerContentConcurrency = 3
+	// eagerContentTimeout bounds a single full-content page fetch
+	eagerContentTimeout = 30 * time.Second
+)
+
+// newArticlesOnly returns the articles that are not stored yet.
+// Must be called before the articles are saved.
+func (f *Fetcher) newArticlesOnly(articlesWithContent []*ArticleWithContent) []*ArticleWithContent {
+	var fresh []*ArticleWithContent
+	for _, awc := range articlesWithContent {
+		a := awc.Article
+		if _, err := f.db.GetArticleIDByUniqueID(a.Title, a.FeedID, a.PublishedAt, a.HasValidPublishedTime); err != nil {
+			fresh = append(fresh, awc)
+		}
+	}
+	return fresh
+}
+
+// cacheEagerContents fetches and stores the full content of newly saved articles of a
+// feed with eager content enabled. Feeds with content strategies go through them in
+// order. Otherwise script and XPath feeds already carry the content their script or
+// selector extracted, so only standard feeds go through readability.
+// Articles whose page cannot be fetched keep the content from the feed.
+func (f *Fetcher) cacheEagerContents(ctx context.Context, feed models.Feed, newArticles []*ArticleWithContent) {
+	usesSelector := len(feed.ContentStrategy) == 0 &&
+		(feed.ScriptPath != "" || feed.Type == "HTML+XPath" || feed.Type == "XML+XPath")
+
+	var wg sync.WaitGroup
+	for _, awc := range newArticles {
+		articleID, err := f.db.GetArticleIDByUniqueID(awc.Article.Title, awc.Article.FeedID, awc.Article.PublishedAt, awc.Article.HasValidPublishedTime)
+		if err != nil {
+			utils.DebugLog("Could not find article ID for %s: %v", awc.Article.Title, err)
+			continue
+		}
+
+		if usesSelector || awc.Article.URL == "" {
+			f.storeEagerContent(articleID, awc.Content)
+			continue
+		}
+
+		wg.Add(1)
+		f.eagerContentSem <- struct{}{}
+		go func(articleID int64, awc *ArticleWithContent) {
+			defer func() {
+				<-f.eagerContentSem
+				wg.Done()
+			}()
+
+			if len(feed.ContentStrategy) > 0 {
+				content, _ := f.ExtractContentWithStrategies(ctx, feed, awc.Article.URL, func() (string, error) {
+					return awc.Content, nil
+				})
+				if content == "" {
+					content = awc.Content
+				}
+				f.storeEagerContent(articleID, content)
+				return
+			}
+
+			content, err := f.fetchReadableContent(ctx, feed, awc.Article.URL)
+			if err != nil {
+				utils.DebugLog("Eager content fetch failed for %s: %v", awc.Article.URL, err)
+				content = awc.Content
+			}
+			f.storeEagerContent(articleID, content)
+		}(articleID, awc)
+	}
+	wg.Wait()
+}
+
+// storeEagerContent caches content for an article, skipping empty content
+func (f *Fetcher) storeEagerContent(articleID int64, content string) {
+	if content == "" {
+		return
+	}
+	if err := f.db.SetArticleContent(articleID, content); err != nil {
+		log.Printf("Error caching content for article %d: %v", articleID, err)
+	}
+}
+
+// fetchReadableContent downloads a page through the feed's HTTP client, so its proxy
+// settings apply, and extracts its main content with readability
+func (f *Fetcher) fetchReadableContent(ctx context.Context, feed models.Feed, pageURL string) (string, error) {
+	parsedURL, err := url.Parse(pageURL)
+	if err != nil {
+		return "", err
+	}
+	client, err := f.getHTTPClient(feed)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, eagerContentTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	article, err := readability.FromReader(io.LimitReader(resp.Body, maxContentPageSize), parsedURL)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := article.RenderHTML(&buf); err != nil {
+		return "", err
+	}
+	return utils.CleanHTML(buf.String()), nil
+}
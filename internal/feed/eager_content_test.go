@@ -0,0 +1,69 @@
+package feed
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+)
+
+const eagerArticlePage = `<html><head><title>Full story</title></head><body>
+<nav>Site navigation</nav>
+<article><h1>Full story</h1>
+<p>This is the complete text of the article that only exists on the page itself. It has enough words for readability to pick it up as the main content of the document.</p>
+<p>A second paragraph keeps going with more of the full story so the extractor is confident this is the article body and not boilerplate.</p>
+</article></body></html>`
+
+func TestFetchFeed_EagerContent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(eagerArticlePage))
+	}))
+	defer srv.Close()
+
+	db := setupDBForFeedTests(t)
+	fetcher := NewFetcher(db)
+	fetcher.fp = &MockParser{Feed: &gofeed.Feed{
+		Title: "Eager Feed",
+		Items: []*gofeed.Item{{Title: "Full story", Link: srv.URL + "/story", Description: "Teaser only"}},
+	}}
+
+	feedID, err := fetcher.AddSubscription("http://test.com/rss", "", "")
+	if err != nil {
+		t.Fatalf("AddSubscription failed: %v", err)
+	}
+	if err := db.UpdateFeedEagerContent(feedID, true); err != nil {
+		t.Fatalf("UpdateFeedEagerContent failed: %v", err)
+	}
+	feed, err := db.GetFeedByID(feedID)
+	if err != nil {
+		t.Fatalf("GetFeedByID failed: %v", err)
+	}
+	if !feed.EagerContent {
+		t.Fatal("expected EagerContent to be stored")
+	}
+
+	fetcher.FetchFeed(context.Background(), *feed)
+
+	articles, err := db.GetArticles("", feedID, "", false, 10, 0)
+	if err != nil || len(articles) != 1 {
+		t.Fatalf("expected 1 article, got %d (err %v)", len(articles), err)
+	}
+	content, found, err := db.GetArticleContent(articles[0].ID)
+	if err != nil || !found {
+		t.Fatalf("expected content to be cached at refresh time (found %v, err %v)", found, err)
+	}
+	if !strings.Contains(content, "complete text of the article") {
+		t.Errorf("expected the full page content, got %q", content)
+	}
+
+	// A later refresh must not replace the full content with the feed's teaser
+	fetcher.FetchFeed(context.Background(), *feed)
+	content, _, _ = db.GetArticleContent(articles[0].ID)
+	if !strings.Contains(content, "complete text of the article") {
+		t.Errorf("full content was overwritten on refresh: %q", content)
+	}
+}
@@ -0,0 +1,132 @@
+package feed
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// Fetch error categories returned by ClassifyFetchError
+const (
+	FetchErrorNone       = ""
+	FetchErrorDNS        = "dns"
+	FetchErrorTLS        = "tls"
+	FetchErrorTimeout    = "timeout"
+	FetchErrorNotFound   = "not_found"
+	FetchErrorForbidden  = "forbidden"
+	FetchErrorRateLimit  = "rate_limited"
+	FetchErrorHTTP       = "http"
+	FetchErrorConnection = "connection"
+	FetchErrorNotFeed    = "not_feed"
+	FetchErrorParse      = "parse"
+	FetchErrorUnknown    = "unknown"
+)
+
+// fetchErrorSuggestions maps each error category to a human-readable suggestion
+var fetchErrorSuggestions = map[string]string{
+	FetchErrorDNS:        "The host name could not be resolved. Check the feed URL for typos, or the site may no longer exist.",
+	FetchErrorTLS:        "The TLS certificate could not be verified. Check the system clock, or the site may use a self-signed or expired certificate.",
+	FetchErrorTimeout:    "The server took too long to respond. Try again later, or configure a proxy if the site is slow or blocked on your network.",
+	FetchErrorNotFound:   "The feed was not found (404). It may have moved; try rediscovering the feed from the website.",
+	FetchErrorForbidden:  "The server refused the request. The site may block automated readers; try enabling a proxy for this feed.",
+	FetchErrorRateLimit:  "The server is rate limiting requests (429). Lower the refresh frequency for this feed or globally.",
+	FetchErrorHTTP:       "The server returned an error. Try again later; if it persists, check the feed URL.",
+	FetchErrorConnection: "The connection failed. Check your network or proxy settings.",
+	FetchErrorNotFeed:    "The URL does not point to an RSS or Atom feed. Try rediscovering the feed from the website.",
+	FetchErrorParse:      "The feed content could not be parsed. The feed may be malformed; try again later or use XPath mode.",
+	FetchErrorUnknown:    "An unexpected error occurred. Try refreshing the feed again.",
+}
+
+// ClassifyFetchError classifies a feed fetch error and returns its category
+// together with a human-readable suggestion. A nil error returns empty strings.
+func ClassifyFetchError(err error) (category, suggestion string) {
+	if err == nil {
+		return FetchErrorNone, ""
+	}
+
+	var dnsErr *net.DNSError
+	var certErr *x509.UnknownAuthorityError
+	var hostErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	var httpErr gofeed.HTTPError
+	var netErr net.Error
+
+	switch {
+	case errors.As(err, &dnsErr):
+		category = FetchErrorDNS
+	case errors.As(err, &certErr), errors.As(err, &hostErr), errors.As(err, &invalidErr):
+		category = FetchErrorTLS
+	case errors.As(err, &httpErr):
+		category = classifyHTTPStatus(httpErr.StatusCode)
+	case errors.Is(err, gofeed.ErrFeedTypeNotDetected):
+		category = FetchErrorNotFeed
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		category = FetchErrorTimeout
+	default:
+		category, _ = ClassifyFetchErrorMessage(err.Error())
+	}
+
+	return category, fetchErrorSuggestions[category]
+}
+
+// httpStatusPattern matches the status code in the HTTP error messages fetches produce,
+// such as gofeed's "http error: 404 Not Found" or "unexpected status code: 429"
+var httpStatusPattern = regexp.MustCompile(`(?:http error:|\bhttp|status code:?|status)\s+(\d{3})\b`)
+
+// quotedOrURLPattern matches the quoted URLs and bare URLs Go puts in network error messages
+var quotedOrURLPattern = regexp.MustCompile(`"[^"]*"|\S+://\S+`)
+
+// ClassifyFetchErrorMessage classifies a stored error message (such as feeds.last_error).
+// It is used when the original error value is no longer available.
+func ClassifyFetchErrorMessage(msg string) (category, suggestion string) {
+	if strings.TrimSpace(msg) == "" {
+		return FetchErrorNone, ""
+	}
+
+	// URLs and host names can contain anything, so only the surrounding text is matched
+	lower := strings.ToLower(quotedOrURLPattern.ReplaceAllString(msg, " "))
+	switch {
+	case strings.Contains(lower, "no such host"), strings.Contains(lower, "server misbehaving"):
+		category = FetchErrorDNS
+	case strings.Contains(lower, "x509"), strings.Contains(lower, "tls:"), strings.Contains(lower, "certificate"):
+		category = FetchErrorTLS
+	case strings.Contains(lower, "i/o timeout"), strings.Contains(lower, "client.timeout"),
+		strings.Contains(lower, "deadline exceeded"), strings.Contains(lower, "timed out"):
+		category = FetchErrorTimeout
+	case httpStatusPattern.MatchString(lower):
+		statusCode, _ := strconv.Atoi(httpStatusPattern.FindStringSubmatch(lower)[1])
+		category = classifyHTTPStatus(statusCode)
+	case strings.Contains(lower, "connection refused"), strings.Contains(lower, "connection reset"),
+		strings.Contains(lower, "network is unreachable"), strings.Contains(lower, "proxyconnect"):
+		category = FetchErrorConnection
+	case strings.Contains(lower, "failed to detect feed type"):
+		category = FetchErrorNotFeed
+	case strings.Contains(lower, "xml syntax error"), strings.Contains(lower, "invalid character"),
+		strings.Contains(lower, "unexpected eof"), strings.Contains(lower, "parse"):
+		category = FetchErrorParse
+	default:
+		category = FetchErrorUnknown
+	}
+
+	return category, fetchErrorSuggestions[category]
+}
+
+// classifyHTTPStatus maps an HTTP status code to a fetch error category
+func classifyHTTPStatus(statusCode int) string {
+	switch statusCode {
+	case 404, 410:
+		return FetchErrorNotFound
+	case 401, 403:
+		return FetchErrorForbidden
+	case 429:
+		return FetchErrorRateLimit
+	default:
+		return FetchErrorHTTP
+	}
+}
@@ -0,0 +1,72 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/mmcdole/gofeed"
+)
+
+func TestClassifyFetchError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"nil", nil, FetchErrorNone},
+		{"dns", &net.DNSError{Err: "no such host", Name: "example.invalid"}, FetchErrorDNS},
+		{"not found", gofeed.HTTPError{StatusCode: 404, Status: "404 Not Found"}, FetchErrorNotFound},
+		{"forbidden", gofeed.HTTPError{StatusCode: 403, Status: "403 Forbidden"}, FetchErrorForbidden},
+		{"rate limited", gofeed.HTTPError{StatusCode: 429, Status: "429 Too Many Requests"}, FetchErrorRateLimit},
+		{"server error", gofeed.HTTPError{StatusCode: 502, Status: "502 Bad Gateway"}, FetchErrorHTTP},
+		{"not a feed", fmt.Errorf("parse: %w", gofeed.ErrFeedTypeNotDetected), FetchErrorNotFeed},
+		{"timeout", fmt.Errorf("fetch: %w", context.DeadlineExceeded), FetchErrorTimeout},
+		{"tls message", errors.New("x509: certificate signed by unknown authority"), FetchErrorTLS},
+		{"parse message", errors.New("XML syntax error on line 3"), FetchErrorParse},
+		{"unknown", errors.New("something odd"), FetchErrorUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			category, suggestion := ClassifyFetchError(tt.err)
+			if category != tt.expected {
+				t.Errorf("expected category %q, got %q", tt.expected, category)
+			}
+			if tt.err != nil && suggestion == "" {
+				t.Error("expected a suggestion for a non-nil error")
+			}
+		})
+	}
+}
+
+func TestClassifyFetchErrorMessage(t *testing.T) {
+	category, _ := ClassifyFetchErrorMessage("http error: 404 Not Found")
+	if category != FetchErrorNotFound {
+		t.Errorf("expected %q, got %q", FetchErrorNotFound, category)
+	}
+
+	tests := []struct {
+		msg      string
+		expected string
+	}{
+		{"http error: 410 Gone", FetchErrorNotFound},
+		{"http error: 429 Too Many Requests", FetchErrorRateLimit},
+		{"unexpected status code: 403", FetchErrorForbidden},
+		{`Get "https://example.com/timeout/feed": dial tcp 10.0.0.1:443: i/o timeout`, FetchErrorTimeout},
+		{`Get "https://example.com/posts/404.xml": connection refused`, FetchErrorConnection},
+		{"http error: 500 Internal Server Error on https://example.com/410/feed", FetchErrorHTTP},
+	}
+	for _, tt := range tests {
+		if category, _ := ClassifyFetchErrorMessage(tt.msg); category != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.msg, tt.expected, category)
+		}
+	}
+
+	category, suggestion := ClassifyFetchErrorMessage("")
+	if category != FetchErrorNone || suggestion != "" {
+		t.Errorf("expected empty classification for empty message, got %q/%q", category, suggestion)
+	}
+}
@@ -0,0 +1,101 @@
+package feed
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+// fetchBudgetWindow is the rolling window max_fetches_per_hour applies to
+const fetchBudgetWindow = time.Hour
+
+// fetchBudget tracks feed fetch start times over a rolling window, shared by
+// scheduled, global and manual refreshes
+type fetchBudget struct {
+	mu      sync.Mutex
+	window  time.Duration
+	fetches []time.Time
+	now     func() time.Time
+}
+
+func newFetchBudget(window time.Duration) *fetchBudget {
+	return &fetchBudget{window: window, now: time.Now}
+}
+
+// prune drops fetches that have left the window. Callers must hold mu.
+func (b *fetchBudget) prune(now time.Time) {
+	cutoff := now.Add(-b.window)
+	i := 0
+	for i < len(b.fetches) && !b.fetches[i].After(cutoff) {
+		i++
+	}
+	b.fetches = b.fetches[i:]
+}
+
+// take records a fetch if fewer than limit happened within the window and
+// reports whether it did. A limit of 0 or less means unlimited.
+// A nil budget allows everything.
+func (b *fetchBudget) take(limit int) bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.prune(now)
+	if limit > 0 && len(b.fetches) >= limit {
+		return false
+	}
+	b.fetches = append(b.fetches, now)
+	return true
+}
+
+// remaining returns how many fetches the window still allows and how long until
+// the oldest recorded fetch leaves it (the whole window if nothing is recorded)
+func (b *fetchBudget) remaining(limit int) (int, time.Duration) {
+	if b == nil {
+		return limit, fetchBudgetWindow
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.prune(now)
+	resetIn := b.window
+	if len(b.fetches) > 0 {
+		resetIn = b.fetches[0].Add(b.window).Sub(now)
+	}
+	left := limit - len(b.fetches)
+	if left < 0 {
+		left = 0
+	}
+	return left, resetIn
+}
+
+// maxFetchesPerHour reads the max_fetches_per_hour setting; 0 means unlimited
+func (f *Fetcher) maxFetchesPerHour() int {
+	if f.db == nil {
+		return 0
+	}
+	v, err := f.db.GetSetting("max_fetches_per_hour")
+	if err != nil {
+		return 0
+	}
+	limit, err := strconv.Atoi(v)
+	if err != nil || limit < 0 {
+		return 0
+	}
+	return limit
+}
+
+// FetchBudget reports the hourly fetch limit, how many fetches are left in the
+// current window and when the window frees up again. A limit of 0 means unlimited.
+func (f *Fetcher) FetchBudget() (limit, remaining int, resetIn time.Duration) {
+	limit = f.maxFetchesPerHour()
+	if limit == 0 {
+		return 0, 0, 0
+	}
+	remaining, resetIn = f.fetchBudget.remaining(limit)
+	return limit, remaining, resetIn
+}
@@ -0,0 +1,42 @@
+package feed
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFetchBudget_RollingWindow(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := newFetchBudget(time.Hour)
+	b.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if !b.take(3) {
+			t.Fatalf("fetch %d should fit in the budget", i+1)
+		}
+		now = now.Add(10 * time.Minute)
+	}
+	if b.take(3) {
+		t.Fatal("expected the budget to be exhausted")
+	}
+	if left, resetIn := b.remaining(3); left != 0 || resetIn != 30*time.Minute {
+		t.Fatalf("expected 0 left resetting in 30m, got %d in %v", left, resetIn)
+	}
+
+	// The first fetch leaves the window an hour after it happened
+	now = now.Add(30 * time.Minute)
+	if !b.take(3) {
+		t.Fatal("expected a slot once the oldest fetch left the window")
+	}
+	if b.take(3) {
+		t.Fatal("expected only one slot to have freed up")
+	}
+
+	if !b.take(0) {
+		t.Fatal("a limit of 0 should be unlimited")
+	}
+	var nilBudget *fetchBudget
+	if !nilBudget.take(1) {
+		t.Fatal("a nil budget should allow everything")
+	}
+}
@@ -2,6 +2,7 @@ package feed
 
 import (
 	"MrRSS/internal/database"
+	"MrRSS/internal/events"
 	"MrRSS/internal/models"
 	"MrRSS/internal/rsshub"
 	"MrRSS/internal/rules"
@@ -34,6 +35,16 @@ type Fetcher struct {
 	refreshCalculator *IntelligentRefreshCalculator
 	taskManager       *TaskManager
 	cleanupManager    *CleanupManager
+	// Cancel functions for in-flight FetchAll/FetchFeedsByIDs runs, guarded by mu
+	refreshCancels []context.CancelFunc
+	// Non-blocking fan-out of progress updates to stream subscribers
+	progressBroadcaster *ProgressBroadcaster
+	// Live update hub for WebSocket clients; nil when not wired up
+	eventHub *events.Hub
+	// Limits concurrent full-content page fetches for eager content feeds
+	eagerContentSem chan struct{}
+	// Rolling-window count of feed fetches for max_fetches_per_hour
+	fetchBudget *fetchBudget
 }
 
 func NewFetcher(db *database.DB) *Fetcher {
@@ -46,10 +57,11 @@ func NewFetcher(db *database.DB) *Fetcher {
 
 	// Create HTTP client for feed parsing with proper User-Agent
 	// This is critical because many RSS servers block requests without a proper User-Agent
-	httpClient, err := utils.CreateHTTPClientWithUserAgent(
+	httpClient, err := utils.CreateSharedHTTPClientWithUserAgent(
 		"",
 		30*time.Second,
 		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+		utils.DefaultTransportOptions(),
 	)
 	if err != nil {
 		// Fallback to default client if proxy setup fails
@@ -66,12 +78,15 @@ func NewFetcher(db *database.DB) *Fetcher {
 	highPriorityParser.Client = httpClient
 
 	fetcher := &Fetcher{
-		db:                db,
-		fp:                parser,
-		highPriorityFp:    highPriorityParser,
-		scriptExecutor:    executor,
-		emailFetcher:      NewEmailFetcher(db),
-		refreshCalculator: NewIntelligentRefreshCalculator(db),
+		db:                  db,
+		fp:                  parser,
+		highPriorityFp:      highPriorityParser,
+		scriptExecutor:      executor,
+		emailFetcher:        NewEmailFetcher(db),
+		refreshCalculator:   NewIntelligentRefreshCalculator(db),
+		progressBroadcaster: NewProgressBroadcaster(MaxProgressSubscribers),
+		eagerContentSem:     make(chan struct{}, eagerContentConcurrency),
+		fetchBudget:         newFetchBudget(fetchBudgetWindow),
 	}
 
 	// Initialize task manager with default capacity (increased from 5 to 10)
@@ -183,14 +198,38 @@ func (f *Fetcher) getHTTPClient(feed models.Feed) (*http.Client, error) {
 
 	// Create HTTP client with browser-like headers to bypass Cloudflare and anti-bot protections
 	// This is critical for RSSHub feeds and other services with anti-bot protection
-	return utils.CreateHTTPClientWithUserAgent(
+	// Feeds with the same proxy share one pooled transport to avoid connection churn
+	return utils.CreateSharedHTTPClientWithUserAgent(
 		proxyURL,
 		30*time.Second,
 		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+		f.transportOptions(),
 	)
 }
 
+// transportOptions reads the connection pooling settings, leaving unset values at their defaults
+func (f *Fetcher) transportOptions() utils.TransportOptions {
+	var opts utils.TransportOptions
+	if f.db == nil {
+		return opts
+	}
+	if v, err := f.db.GetSetting("http_max_idle_conns"); err == nil {
+		opts.MaxIdleConns, _ = strconv.Atoi(v)
+	}
+	if v, err := f.db.GetSetting("http_max_idle_conns_per_host"); err == nil {
+		opts.MaxIdleConnsPerHost, _ = strconv.Atoi(v)
+	}
+	if v, err := f.db.GetSetting("http_idle_conn_timeout"); err == nil {
+		if seconds, _ := strconv.Atoi(v); seconds > 0 {
+			opts.IdleConnTimeout = time.Duration(seconds) * time.Second
+		}
+	}
+	return opts
+}
+
 func (f *Fetcher) FetchAll(ctx context.Context) {
+	ctx = f.beginRefresh(ctx)
+
 	// Get all feeds
 	feeds, err := f.db.GetFeeds()
 	if err != nil {
@@ -256,6 +295,8 @@ func (f *Fetcher) FetchAll(ctx context.Context) {
 	// Update task manager capacity based on network
 	concurrency := f.getConcurrencyLimit()
 	f.taskManager.SetPoolCapacity(concurrency)
+	adaptive, _ := f.db.GetSetting("adaptive_concurrency")
+	f.taskManager.SetAdaptiveConcurrency(adaptive != "false")
 
 	// Use task manager for global refresh (all feeds go to queue tail)
 	f.taskManager.AddGlobalRefresh(ctx, filteredFeeds)
@@ -265,7 +306,7 @@ func (f *Fetcher) FetchFeed(ctx context.Context, feed models.Feed) {
 	// Use ParseFeedWithFeed with normal priority for feed refresh
 	parsedFeed, err := f.ParseFeedWithFeed(ctx, &feed, false) // Normal priority for refresh
 	if err != nil {
-		log.Printf("Error parsing feed %s: %v", feed.URL, err)
+		utils.ContextLog(ctx, "Error parsing feed %s: %v", feed.URL, err)
 		f.db.UpdateFeedError(feed.ID, err.Error())
 		// Add error to progress for immediate feedback
 		f.mu.Lock()
@@ -290,8 +331,14 @@ func (f *Fetcher) FetchFeed(ctx context.Context, feed models.Feed) {
 		f.db.UpdateFeedLink(feed.ID, parsedFeed.Link)
 	}
 
+	// Fill in the title of feeds subscribed without one (URL list import)
+	if feed.Title == "" && parsedFeed.Title != "" {
+		f.db.UpdateFeedTitle(feed.ID, parsedFeed.Title)
+	}
+
 	// Process articles
 	articlesWithContent := f.processArticles(feed, parsedFeed.Items)
+	articlesWithContent = f.dropUnstableIDDuplicates(feed, articlesWithContent)
 
 	// Check context before heavy DB operation
 	select {
@@ -307,11 +354,22 @@ func (f *Fetcher) FetchFeed(ctx context.Context, feed models.Feed) {
 			articlesToSave[i] = awc.Article
 		}
 
-		if err := f.db.SaveArticles(ctx, articlesToSave); err != nil {
-			log.Printf("Error saving articles for feed %s: %v", feed.Title, err)
+		var newArticles []*ArticleWithContent
+		if feed.EagerContent {
+			newArticles = f.newArticlesOnly(articlesWithContent)
+		}
+
+		if inserted, err := f.db.SaveArticlesCount(ctx, articlesToSave); err != nil {
+			utils.ContextLog(ctx, "Error saving articles for feed %s: %v", feed.Title, err)
 		} else {
-			// Cache article content from RSS feed
-			f.cacheArticleContents(articlesWithContent)
+			f.publishNewArticles(feed.ID, inserted)
+
+			// Cache article content: full pages for new articles of eager feeds, RSS content otherwise
+			if feed.EagerContent {
+				f.cacheEagerContents(ctx, feed, newArticles)
+			} else {
+				f.cacheArticleContents(articlesWithContent)
+			}
 
 			// Apply rules to newly saved articles
 			// We fetch the recent articles for this feed since SaveArticles doesn't return IDs
@@ -321,7 +379,7 @@ func (f *Fetcher) FetchFeed(ctx context.Context, feed models.Feed) {
 				engine := rules.NewEngine(f.db)
 				affected, err := engine.ApplyRulesToArticles(savedArticles)
 				if err != nil {
-					log.Printf("Error applying rules for feed %s: %v", feed.Title, err)
+					utils.ContextLog(ctx, "Error applying rules for feed %s: %v", feed.Title, err)
 				} else if affected > 0 {
 					utils.DebugLog("Applied rules to %d articles in feed %s", affected, feed.Title)
 				}
@@ -360,6 +418,11 @@ func (f *Fetcher) fetchFeedWithContext(ctx context.Context, feed models.Feed) er
 		f.db.UpdateFeedLink(feed.ID, parsedFeed.Link)
 	}
 
+	// Fill in the title of feeds subscribed without one (URL list import)
+	if feed.Title == "" && parsedFeed.Title != "" {
+		f.db.UpdateFeedTitle(feed.ID, parsedFeed.Title)
+	}
+
 	// Check context before processing articles
 	select {
 	case <-ctx.Done():
@@ -369,6 +432,7 @@ func (f *Fetcher) fetchFeedWithContext(ctx context.Context, feed models.Feed) er
 
 	// Process articles
 	articlesWithContent := f.processArticles(feed, parsedFeed.Items)
+	articlesWithContent = f.dropUnstableIDDuplicates(feed, articlesWithContent)
 
 	// Check context before heavy DB operation
 	select {
@@ -384,21 +448,32 @@ func (f *Fetcher) fetchFeedWithContext(ctx context.Context, feed models.Feed) er
 			articlesToSave[i] = awc.Article
 		}
 
-		if err := f.db.SaveArticles(ctx, articlesToSave); err != nil {
+		var newArticles []*ArticleWithContent
+		if feed.EagerContent {
+			newArticles = f.newArticlesOnly(articlesWithContent)
+		}
+
+		inserted, err := f.db.SaveArticlesCount(ctx, articlesToSave)
+		if err != nil {
 			return err
 		}
+		f.publishNewArticles(feed.ID, inserted)
 
 		// Post-processing operations (content caching and rule application)
 		// These are non-critical and run asynchronously to avoid blocking the feed refresh
 		// Even if they fail or are slow, the feed has already been successfully saved
 		go func() {
-			// Cache article content from RSS feed
-			f.cacheArticleContents(articlesWithContent)
+			// Cache article content: full pages for new articles of eager feeds, RSS content otherwise
+			if feed.EagerContent {
+				f.cacheEagerContents(context.WithoutCancel(ctx), feed, newArticles)
+			} else {
+				f.cacheArticleContents(articlesWithContent)
+			}
 
 			// Apply rules to newly saved articles
 			savedArticles, err := f.db.GetArticles("", feed.ID, "", false, len(articlesToSave), 0)
 			if err != nil {
-				log.Printf("Error getting articles for rule application: %v", err)
+				utils.ContextLog(ctx, "Error getting articles for rule application: %v", err)
 				return
 			}
 			if len(savedArticles) == 0 {
@@ -408,7 +483,7 @@ func (f *Fetcher) fetchFeedWithContext(ctx context.Context, feed models.Feed) er
 			engine := rules.NewEngine(f.db)
 			affected, err := engine.ApplyRulesToArticles(savedArticles)
 			if err != nil {
-				log.Printf("Error applying rules for feed %s: %v", feed.Title, err)
+				utils.ContextLog(ctx, "Error applying rules for feed %s: %v", feed.Title, err)
 			} else if affected > 0 {
 				utils.DebugLog("Applied rules to %d articles in feed %s", affected, feed.Title)
 			}
@@ -445,12 +520,13 @@ func (f *Fetcher) FetchFeedsByIDs(ctx context.Context, feedIDs []int64) {
 	if len(feedIDs) == 0 {
 		return
 	}
+	ctx = f.beginRefresh(ctx)
 
 	// Fetch feeds by IDs
 	for _, feedID := range feedIDs {
 		feed, err := f.db.GetFeedByID(feedID)
 		if err != nil {
-			log.Printf("Error getting feed %d: %v", feedID, err)
+			utils.ContextLog(ctx, "Error getting feed %d: %v", feedID, err)
 			continue
 		}
 		// Add to queue head as high priority (manual add/edit)
@@ -458,12 +534,54 @@ func (f *Fetcher) FetchFeedsByIDs(ctx context.Context, feedIDs []int64) {
 	}
 }
 
-// cacheArticleContents caches article contents from RSS feeds
-// This is called after articles are saved to the database
+// beginRefresh derives a cancelable context for a manual refresh run and registers
+// its cancel function so that CancelRefresh can stop the run
+func (f *Fetcher) beginRefresh(ctx context.Context) context.Context {
+	ctx, cancel := context.WithCancel(ctx)
+	f.mu.Lock()
+	f.refreshCancels = append(f.refreshCancels, cancel)
+	f.mu.Unlock()
+	return ctx
+}
+
+// endRefresh forgets the cancel functions of finished refresh runs. They are not
+// invoked: a run that registered just before completion must keep its context.
+func (f *Fetcher) endRefresh() {
+	f.mu.Lock()
+	f.refreshCancels = nil
+	f.mu.Unlock()
+}
+
+// CancelRefresh stops the active refresh: pending feeds are dropped from the queue and
+// in-flight fetches are canceled. Progress is marked as not running once the workers
+// have observed the cancellation. Returns false if no refresh was running.
+func (f *Fetcher) CancelRefresh() bool {
+	f.mu.Lock()
+	cancels := f.refreshCancels
+	f.refreshCancels = nil
+	f.mu.Unlock()
+
+	if len(cancels) == 0 && !f.taskManager.IsRunning() {
+		return false
+	}
+
+	log.Printf("Canceling refresh (%d active runs)", len(cancels))
+	for _, cancel := range cancels {
+		cancel()
+	}
+	f.taskManager.ClearQueue()
+	f.taskManager.updateStats()
+	// Completes immediately if no worker is busy; otherwise the last worker to exit does
+	f.taskManager.checkCompletion()
+	return true
+}
+
+// cacheArticleContents caches article contents from RSS feeds, or only their snippets
+// in metadata-only mode. This is called after articles are saved to the database
 func (f *Fetcher) cacheArticleContents(articlesWithContent []*ArticleWithContent) {
 	for _, awc := range articlesWithContent {
-		// Only cache if content is not empty and URL is present
-		if awc.Content == "" || awc.Article.URL == "" {
+		// Only cache if there is content or a snippet and URL is present
+		if (awc.Content == "" && awc.Snippet == "") || awc.Article.URL == "" {
 			continue
 		}
 
@@ -475,6 +593,13 @@ func (f *Fetcher) cacheArticleContents(articlesWithContent []*ArticleWithContent
 			continue
 		}
 
+		if awc.Content == "" {
+			if err := f.db.UpdateArticleContent(articleID, awc.Snippet); err != nil {
+				log.Printf("Error storing snippet for article %d: %v", articleID, err)
+			}
+			continue
+		}
+
 		// Cache the content (this will overwrite any existing cache as required)
 		if err := f.db.SetArticleContent(articleID, awc.Content); err != nil {
 			log.Printf("Error caching content for article %d: %v", articleID, err)
@@ -2,6 +2,7 @@ package feed_test
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"sync/atomic"
@@ -128,3 +129,58 @@ func TestFetchAll_RespectsCancellation(t *testing.T) {
 		t.Fatalf("FetchAll did not return after cancellation")
 	}
 }
+
+// Test that CancelRefresh stops a running refresh and clears the running state
+func TestCancelRefresh_StopsRunningRefresh(t *testing.T) {
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	if err := db.Init(); err != nil {
+		t.Fatalf("db.Init: %v", err)
+	}
+	db.SetSetting("max_concurrent_refreshes", "1")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(5 * time.Second):
+		}
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(`<?xml version="1.0"?><rss><channel><title>t</title></channel></rss>`))
+	}))
+	defer srv.Close()
+
+	for i := 0; i < 3; i++ {
+		if _, err := db.AddFeed(&models.Feed{Title: "slow", URL: fmt.Sprintf("%s/feed%d", srv.URL, i)}); err != nil {
+			t.Fatalf("AddFeed: %v", err)
+		}
+	}
+
+	f := ff.NewFetcher(db)
+	if f.CancelRefresh() {
+		t.Fatalf("expected CancelRefresh to report nothing running")
+	}
+
+	f.GetTaskManager().MarkRunning()
+	f.FetchAll(context.Background())
+	time.Sleep(200 * time.Millisecond)
+
+	if !f.CancelRefresh() {
+		t.Fatalf("expected CancelRefresh to cancel the running refresh")
+	}
+	if !f.GetTaskManager().Wait(3 * time.Second) {
+		t.Fatalf("refresh still running after cancellation")
+	}
+
+	feeds, err := db.GetFeeds()
+	if err != nil {
+		t.Fatalf("GetFeeds: %v", err)
+	}
+	for _, feed := range feeds {
+		if feed.LastError != "" {
+			t.Errorf("feed %d: canceled fetch recorded error %q", feed.ID, feed.LastError)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package feed
+
+import (
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/mmcdole/gofeed"
+	ext "github.com/mmcdole/gofeed/extensions"
+)
+
+// minPreferredImageSize is the declared width or height from which a sized image is
+// preferred over images of unknown size. Smaller declared images (typically list
+// thumbnails) are only used when nothing else is available.
+const minPreferredImageSize = 300
+
+// imageCandidate is an article image announced by the feed itself
+type imageCandidate struct {
+	url    string
+	width  int
+	height int
+}
+
+func (c imageCandidate) sized() bool {
+	return c.width > 0 || c.height > 0
+}
+
+func (c imageCandidate) area() int {
+	w, h := c.width, c.height
+	if w == 0 {
+		w = h
+	}
+	if h == 0 {
+		h = w
+	}
+	return w * h
+}
+
+// rank orders candidates: large declared sizes first, then unknown sizes, then small thumbnails
+func (c imageCandidate) rank() int {
+	switch {
+	case !c.sized():
+		return 1
+	case c.width >= minPreferredImageSize || c.height >= minPreferredImageSize:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// bestFeedImage picks the highest-resolution image announced by the item through
+// item.Image, Media RSS (media:content and media:thumbnail, also inside media:group)
+// and image enclosures, including Atom <link rel="enclosure">. Images of unknown size
+// keep that source order. Returns "" when the item announces no image.
+func bestFeedImage(item *gofeed.Item) string {
+	var candidates []imageCandidate
+	if item.Image != nil && item.Image.URL != "" {
+		candidates = append(candidates, imageCandidate{url: item.Image.URL})
+	}
+	candidates = append(candidates, mediaImageCandidates(item.Extensions)...)
+	for _, enc := range item.Enclosures {
+		if enc == nil || enc.URL == "" {
+			continue
+		}
+		if strings.HasPrefix(enc.Type, "image/") || (enc.Type == "" && looksLikeImageURL(enc.URL)) {
+			candidates = append(candidates, imageCandidate{url: enc.URL})
+		}
+	}
+
+	// The same URL often appears in several places; keep its declared size if any
+	best := -1
+	seen := make(map[string]int)
+	var unique []imageCandidate
+	for _, c := range candidates {
+		if idx, ok := seen[c.url]; ok {
+			if !unique[idx].sized() && c.sized() {
+				unique[idx].width, unique[idx].height = c.width, c.height
+			}
+			continue
+		}
+		seen[c.url] = len(unique)
+		unique = append(unique, c)
+	}
+	for i, c := range unique {
+		if best < 0 || c.rank() > unique[best].rank() ||
+			(c.rank() == unique[best].rank() && c.sized() && c.area() > unique[best].area()) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return ""
+	}
+	return unique[best].url
+}
+
+// mediaImageCandidates collects image media:content and media:thumbnail entries, with
+// media:content listed before thumbnails
+func mediaImageCandidates(extensions ext.Extensions) []imageCandidate {
+	mediaExt, ok := extensions["media"]
+	if !ok {
+		return nil
+	}
+
+	var contents, thumbnails []imageCandidate
+	collect := func(m map[string][]ext.Extension) {
+		for _, content := range m["content"] {
+			if isImageMediaContent(content) {
+				contents = append(contents, newImageCandidate(content))
+			}
+			// Video content carries its poster frame as a nested thumbnail
+			for _, thumb := range content.Children["thumbnail"] {
+				thumbnails = append(thumbnails, newImageCandidate(thumb))
+			}
+		}
+		for _, thumb := range m["thumbnail"] {
+			thumbnails = append(thumbnails, newImageCandidate(thumb))
+		}
+	}
+
+	for _, group := range mediaExt["group"] {
+		collect(group.Children)
+	}
+	collect(mediaExt)
+
+	var candidates []imageCandidate
+	for _, c := range append(contents, thumbnails...) {
+		if c.url != "" {
+			candidates = append(candidates, c)
+		}
+	}
+	return candidates
+}
+
+// isImageMediaContent reports whether a media:content element describes an image
+func isImageMediaContent(content ext.Extension) bool {
+	medium := content.Attrs["medium"]
+	mimeType := content.Attrs["type"]
+	if medium != "" || mimeType != "" {
+		return medium == "image" || strings.HasPrefix(mimeType, "image/")
+	}
+	return looksLikeImageURL(content.Attrs["url"])
+}
+
+func newImageCandidate(e ext.Extension) imageCandidate {
+	width, _ := strconv.Atoi(strings.TrimSpace(e.Attrs["width"]))
+	height, _ := strconv.Atoi(strings.TrimSpace(e.Attrs["height"]))
+	return imageCandidate{url: strings.TrimSpace(e.Attrs["url"]), width: width, height: height}
+}
+
+// looksLikeImageURL reports whether a URL path ends in a common image extension
+func looksLikeImageURL(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(path.Ext(parsed.Path)) {
+	case ".jpg", ".jpeg", ".png", ".gif", ".webp", ".avif", ".svg":
+		return true
+	}
+	return false
+}
@@ -2,6 +2,8 @@ package feed
 
 import (
 	"time"
+
+	"MrRSS/internal/events"
 )
 
 // Progress tracks the state of feed fetching operations
@@ -37,6 +39,46 @@ func (f *Fetcher) GetProgressWithStats() ProgressWithStats {
 	}
 }
 
+// SubscribeProgress registers a progress stream subscriber.
+// Returns ErrTooManySubscribers when the subscriber cap has been reached.
+func (f *Fetcher) SubscribeProgress() (<-chan ProgressWithStats, func(), error) {
+	return f.progressBroadcaster.Subscribe()
+}
+
+// publishProgress broadcasts the current progress to stream and WebSocket subscribers
+func (f *Fetcher) publishProgress() {
+	sseListening := f.progressBroadcaster != nil && f.progressBroadcaster.SubscriberCount() > 0
+	wsListening := f.eventHub.SubscriberCount() > 0
+	if !sseListening && !wsListening {
+		return
+	}
+	progress := f.GetProgressWithStats()
+	if sseListening {
+		f.progressBroadcaster.Publish(progress)
+	}
+	if wsListening {
+		f.eventHub.Publish(events.TypeRefreshProgress, progress)
+	}
+}
+
+// SetEventHub sets the hub that receives live refresh and new-article events
+func (f *Fetcher) SetEventHub(hub *events.Hub) {
+	f.eventHub = hub
+}
+
+// publishNewArticles announces articles newly stored for a feed
+func (f *Fetcher) publishNewArticles(feedID int64, count int) {
+	if count == 0 || f.eventHub.SubscriberCount() == 0 {
+		return
+	}
+	f.eventHub.Publish(events.TypeNewArticles, map[string]interface{}{
+		"feed_counts": map[int64]int{feedID: count},
+		"total":       count,
+	})
+	// New unread articles change the badge counts as well
+	f.eventHub.Publish(events.TypeUnreadCountsChanged, nil)
+}
+
 // waitForProgressComplete waits for any running operation to complete with a timeout.
 // Returns true if the wait was successful, false if timeout occurred.
 func (f *Fetcher) waitForProgressComplete(timeout time.Duration) bool {
@@ -0,0 +1,87 @@
+package feed
+
+import (
+	"errors"
+	"sync"
+)
+
+// MaxProgressSubscribers caps the number of concurrent progress stream subscribers
+const MaxProgressSubscribers = 16
+
+// ErrTooManySubscribers is returned when the subscriber cap has been reached
+var ErrTooManySubscribers = errors.New("too many progress subscribers")
+
+// ProgressBroadcaster fans progress updates out to subscribers without ever
+// blocking the publisher. Each subscriber has a single-slot buffer: if a slow
+// client hasn't consumed the previous update, it is replaced by the newest one,
+// so updates are coalesced rather than queued.
+type ProgressBroadcaster struct {
+	mu             sync.Mutex
+	subscribers    map[chan ProgressWithStats]struct{}
+	maxSubscribers int
+}
+
+// NewProgressBroadcaster creates a broadcaster allowing at most maxSubscribers subscribers
+func NewProgressBroadcaster(maxSubscribers int) *ProgressBroadcaster {
+	if maxSubscribers < 1 {
+		maxSubscribers = MaxProgressSubscribers
+	}
+	return &ProgressBroadcaster{
+		subscribers:    make(map[chan ProgressWithStats]struct{}),
+		maxSubscribers: maxSubscribers,
+	}
+}
+
+// Subscribe registers a new subscriber and returns its update channel and an
+// unsubscribe function. The channel is closed on unsubscribe.
+func (b *ProgressBroadcaster) Subscribe() (<-chan ProgressWithStats, func(), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.subscribers) >= b.maxSubscribers {
+		return nil, nil, ErrTooManySubscribers
+	}
+
+	ch := make(chan ProgressWithStats, 1)
+	b.subscribers[ch] = struct{}{}
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			close(ch)
+			b.mu.Unlock()
+		})
+	}
+	return ch, unsubscribe, nil
+}
+
+// Publish delivers an update to all subscribers without blocking
+func (b *ProgressBroadcaster) Publish(progress ProgressWithStats) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- progress:
+		default:
+			// Subscriber is behind: drop its stale update and keep only the latest
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- progress:
+			default:
+			}
+		}
+	}
+}
+
+// SubscriberCount returns the number of active subscribers
+func (b *ProgressBroadcaster) SubscriberCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscribers)
+}
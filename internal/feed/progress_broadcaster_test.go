@@ -0,0 +1,71 @@
+package feed
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProgressBroadcaster_SlowSubscriberDoesNotBlockPublisher(t *testing.T) {
+	b := NewProgressBroadcaster(4)
+
+	slow, unsubscribeSlow, err := b.Subscribe()
+	if err != nil {
+		t.Fatalf("Subscribe error: %v", err)
+	}
+	defer unsubscribeSlow()
+
+	// Publish far more updates than the buffer holds without anyone reading
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 1000; i++ {
+			b.Publish(ProgressWithStats{QueueTaskCount: i})
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("publisher blocked on a slow subscriber")
+	}
+
+	// The slow subscriber only sees the latest coalesced update
+	select {
+	case p := <-slow:
+		if p.QueueTaskCount != 999 {
+			t.Fatalf("expected latest update 999, got %d", p.QueueTaskCount)
+		}
+	default:
+		t.Fatal("expected a pending update")
+	}
+}
+
+func TestProgressBroadcaster_SubscriberCap(t *testing.T) {
+	b := NewProgressBroadcaster(2)
+
+	_, unsub1, err := b.Subscribe()
+	if err != nil {
+		t.Fatalf("Subscribe error: %v", err)
+	}
+	_, unsub2, err := b.Subscribe()
+	if err != nil {
+		t.Fatalf("Subscribe error: %v", err)
+	}
+	defer unsub2()
+
+	if _, _, err := b.Subscribe(); err != ErrTooManySubscribers {
+		t.Fatalf("expected ErrTooManySubscribers, got %v", err)
+	}
+
+	unsub1()
+	unsub1() // unsubscribing twice must be safe
+	if b.SubscriberCount() != 1 {
+		t.Fatalf("expected 1 subscriber, got %d", b.SubscriberCount())
+	}
+
+	_, unsub3, err := b.Subscribe()
+	if err != nil {
+		t.Fatalf("expected subscribe to succeed after unsubscribe, got %v", err)
+	}
+	unsub3()
+}
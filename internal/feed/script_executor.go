@@ -41,6 +41,35 @@ func findPythonExecutable(ctx context.Context) (string, error) {
 // ExecuteScript runs the given script and parses the output as an RSS feed
 // The script should output valid RSS/Atom XML to stdout
 func (e *ScriptExecutor) ExecuteScript(ctx context.Context, scriptPath string) (*gofeed.Feed, error) {
+	output, err := e.runScript(ctx, scriptPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sanitize the XML to remove problematic links (like file:// URLs)
+	cleanedOutput := sanitizeFeedXML(output)
+
+	// Parse the sanitized output as RSS/Atom feed
+	fp := gofeed.NewParser()
+	feed, err := fp.ParseString(cleanedOutput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse script output as feed: %v", err)
+	}
+
+	// Fix Atom authors for feeds that use simple text format
+	fixFeedAuthors(feed, cleanedOutput)
+
+	return feed, nil
+}
+
+// ExecuteContentScript runs the given script with an article URL as its only argument
+// and returns the article HTML it writes to stdout
+func (e *ScriptExecutor) ExecuteContentScript(ctx context.Context, scriptPath, articleURL string) (string, error) {
+	return e.runScript(ctx, scriptPath, articleURL)
+}
+
+// runScript executes a script from the scripts directory and returns its stdout
+func (e *ScriptExecutor) runScript(ctx context.Context, scriptPath string, args ...string) (string, error) {
 	// Construct full path
 	fullPath := filepath.Join(e.scriptsDir, scriptPath)
 	fullPath = filepath.Clean(fullPath)
@@ -52,7 +81,7 @@ func (e *ScriptExecutor) ExecuteScript(ctx context.Context, scriptPath string) (
 	// Use filepath.Rel to prevent directory traversal attacks
 	relPath, err := filepath.Rel(cleanScriptsDir, fullPath)
 	if err != nil || strings.HasPrefix(relPath, "..") || strings.Contains(relPath, string(filepath.Separator)+"..") {
-		return nil, fmt.Errorf("invalid script path: script must be within scripts directory")
+		return "", fmt.Errorf("invalid script path: script must be within scripts directory")
 	}
 
 	// Create a context with timeout (30 seconds for script execution)
@@ -68,31 +97,31 @@ func (e *ScriptExecutor) ExecuteScript(ctx context.Context, scriptPath string) (
 		// Python script - try to find a working Python executable
 		pythonCmd, err := findPythonExecutable(execCtx)
 		if err != nil {
-			return nil, fmt.Errorf("python script execution failed: %w", err)
+			return "", fmt.Errorf("python script execution failed: %w", err)
 		}
-		cmd = exec.CommandContext(execCtx, pythonCmd, fullPath)
+		cmd = exec.CommandContext(execCtx, pythonCmd, append([]string{fullPath}, args...)...)
 	case ".sh":
 		// Shell script (Unix-like systems)
 		if runtime.GOOS == "windows" {
-			return nil, fmt.Errorf("shell scripts are not supported on Windows")
+			return "", fmt.Errorf("shell scripts are not supported on Windows")
 		}
-		cmd = exec.CommandContext(execCtx, "bash", fullPath)
+		cmd = exec.CommandContext(execCtx, "bash", append([]string{fullPath}, args...)...)
 	case ".ps1":
 		// PowerShell script (Windows)
 		if runtime.GOOS != "windows" {
-			cmd = exec.CommandContext(execCtx, "pwsh", "-File", fullPath)
+			cmd = exec.CommandContext(execCtx, "pwsh", append([]string{"-File", fullPath}, args...)...)
 		} else {
-			cmd = exec.CommandContext(execCtx, "powershell.exe", "-ExecutionPolicy", "Bypass", "-File", fullPath)
+			cmd = exec.CommandContext(execCtx, "powershell.exe", append([]string{"-ExecutionPolicy", "Bypass", "-File", fullPath}, args...)...)
 		}
 	case ".js":
 		// Node.js script
-		cmd = exec.CommandContext(execCtx, "node", fullPath)
+		cmd = exec.CommandContext(execCtx, "node", append([]string{fullPath}, args...)...)
 	case ".rb":
 		// Ruby script
-		cmd = exec.CommandContext(execCtx, "ruby", fullPath)
+		cmd = exec.CommandContext(execCtx, "ruby", append([]string{fullPath}, args...)...)
 	default:
 		// Try to execute directly (for compiled binaries)
-		cmd = exec.CommandContext(execCtx, fullPath)
+		cmd = exec.CommandContext(execCtx, fullPath, args...)
 	}
 
 	// Set working directory to the scripts directory
@@ -107,26 +136,10 @@ func (e *ScriptExecutor) ExecuteScript(ctx context.Context, scriptPath string) (
 	if err := cmd.Run(); err != nil {
 		stderrStr := stderr.String()
 		if stderrStr != "" {
-			return nil, fmt.Errorf("script execution failed: %v, stderr: %s", err, stderrStr)
+			return "", fmt.Errorf("script execution failed: %v, stderr: %s", err, stderrStr)
 		}
-		return nil, fmt.Errorf("script execution failed: %v", err)
-	}
-
-	// Get the script output
-	output := stdout.String()
-
-	// Sanitize the XML to remove problematic links (like file:// URLs)
-	cleanedOutput := sanitizeFeedXML(output)
-
-	// Parse the sanitized output as RSS/Atom feed
-	fp := gofeed.NewParser()
-	feed, err := fp.ParseString(cleanedOutput)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse script output as feed: %v", err)
+		return "", fmt.Errorf("script execution failed: %v", err)
 	}
 
-	// Fix Atom authors for feeds that use simple text format
-	fixFeedAuthors(feed, cleanedOutput)
-
-	return feed, nil
+	return stdout.String(), nil
 }
@@ -0,0 +1,168 @@
+package feed
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// feedTransport fetches a feed document over a protocol other than HTTP. It returns the
+// body and the URL the document was finally served from.
+type feedTransport func(ctx context.Context, u *url.URL) ([]byte, string, error)
+
+// feedTransports are the small-web protocols feeds can be fetched over, by URL scheme.
+// They connect directly; the HTTP proxy settings don't apply to them.
+var feedTransports = map[string]feedTransport{
+	"gemini": fetchGemini,
+	"gopher": fetchGopher,
+}
+
+const (
+	// smallWebTimeout bounds a request when the context has no deadline
+	smallWebTimeout = 30 * time.Second
+	// maxSmallWebBody caps the size of a fetched document
+	maxSmallWebBody = 10 * 1024 * 1024
+	// maxGeminiRedirects is how many redirects are followed, as the Gemini spec suggests
+	maxGeminiRedirects = 5
+)
+
+// lookupFeedTransport returns the transport for a feed URL that isn't fetched over HTTP
+func lookupFeedTransport(feedURL string) (feedTransport, *url.URL, bool) {
+	u, err := url.Parse(feedURL)
+	if err != nil {
+		return nil, nil, false
+	}
+	transport, ok := feedTransports[strings.ToLower(u.Scheme)]
+	return transport, u, ok
+}
+
+// dialSmallWeb opens a connection with the default port applied and the context's deadline,
+// or smallWebTimeout, set on it
+func dialSmallWeb(ctx context.Context, u *url.URL, defaultPort string, tlsConfig *tls.Config) (net.Conn, error) {
+	port := u.Port()
+	if port == "" {
+		port = defaultPort
+	}
+	addr := net.JoinHostPort(u.Hostname(), port)
+
+	var conn net.Conn
+	var err error
+	if tlsConfig != nil {
+		conn, err = (&tls.Dialer{Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(smallWebTimeout)
+	}
+	_ = conn.SetDeadline(deadline)
+	return conn, nil
+}
+
+// fetchGemini fetches a document over the Gemini protocol, following redirects.
+// Gemini servers mostly use self-signed certificates that clients trust on first use;
+// without a store of known hosts the certificate isn't verified.
+func fetchGemini(ctx context.Context, u *url.URL) ([]byte, string, error) {
+	for redirects := 0; ; redirects++ {
+		status, meta, body, err := geminiRequest(ctx, u)
+		if err != nil {
+			return nil, "", err
+		}
+
+		switch status[0] {
+		case '2':
+			return body, u.String(), nil
+		case '3':
+			if redirects == maxGeminiRedirects {
+				return nil, "", fmt.Errorf("gemini: too many redirects")
+			}
+			next, err := u.Parse(meta)
+			if err != nil || next.Scheme != "gemini" {
+				return nil, "", fmt.Errorf("gemini: invalid redirect to %q", meta)
+			}
+			u = next
+		default:
+			return nil, "", fmt.Errorf("gemini status %s: %s", status, meta)
+		}
+	}
+}
+
+// geminiRequest sends one Gemini request and returns the response status, meta and body.
+// The body is only read for successful responses.
+func geminiRequest(ctx context.Context, u *url.URL) (string, string, []byte, error) {
+	conn, err := dialSmallWeb(ctx, u, "1965", &tls.Config{
+		ServerName:         u.Hostname(),
+		InsecureSkipVerify: true,
+		MinVersion:         tls.VersionTLS12,
+	})
+	if err != nil {
+		return "", "", nil, fmt.Errorf("gemini: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := fmt.Fprintf(conn, "%s\r\n", u.String()); err != nil {
+		return "", "", nil, fmt.Errorf("gemini: %w", err)
+	}
+
+	reader := bufio.NewReader(io.LimitReader(conn, maxSmallWebBody))
+	header, err := reader.ReadString('\n')
+	if err != nil {
+		return "", "", nil, fmt.Errorf("gemini: failed to read response header: %w", err)
+	}
+	status, meta, _ := strings.Cut(strings.TrimRight(header, "\r\n"), " ")
+	if len(status) != 2 || status[0] < '1' || status[0] > '6' {
+		return "", "", nil, fmt.Errorf("gemini: invalid response header %q", header)
+	}
+	if status[0] != '2' {
+		return status, meta, nil, nil
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("gemini: failed to read response body: %w", err)
+	}
+	return status, meta, body, nil
+}
+
+// fetchGopher fetches a document over gopher. The URL path is the item type followed by
+// the selector (RFC 4266); text items have their terminating "." line removed.
+func fetchGopher(ctx context.Context, u *url.URL) ([]byte, string, error) {
+	itemType, selector := byte('1'), ""
+	if path := strings.TrimPrefix(u.Path, "/"); path != "" {
+		itemType, selector = path[0], path[1:]
+	}
+
+	conn, err := dialSmallWeb(ctx, u, "70", nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("gopher: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := fmt.Fprintf(conn, "%s\r\n", selector); err != nil {
+		return nil, "", fmt.Errorf("gopher: %w", err)
+	}
+	body, err := io.ReadAll(io.LimitReader(conn, maxSmallWebBody))
+	if err != nil {
+		return nil, "", fmt.Errorf("gopher: failed to read response: %w", err)
+	}
+
+	if itemType == '0' {
+		trimmed := bytes.TrimRight(body, "\r\n")
+		if bytes.HasSuffix(trimmed, []byte("\n.")) {
+			body = trimmed[:len(trimmed)-1]
+		}
+	}
+	return body, u.String(), nil
+}
@@ -0,0 +1,112 @@
+package feed
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"MrRSS/internal/models"
+)
+
+const smallWebAtom = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Capsule</title>
+  <entry><title>First post</title><link href="gemini://capsule.example/first.gmi"/><id>first</id><updated>2024-01-01T00:00:00Z</updated></entry>
+</feed>`
+
+// serveSmallWeb answers each connection with respond(requestLine) until the listener closes
+func serveSmallWeb(ln net.Listener, respond func(request string) string) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			request, err := bufio.NewReader(conn).ReadString('\n')
+			if err != nil {
+				return
+			}
+			conn.Write([]byte(respond(strings.TrimRight(request, "\r\n"))))
+		}()
+	}
+}
+
+func TestFetchGemini(t *testing.T) {
+	// Borrow a self-signed certificate, as Gemini capsules usually have
+	certSrv := httptest.NewUnstartedServer(nil)
+	certSrv.StartTLS()
+	defer certSrv.Close()
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: certSrv.TLS.Certificates})
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+	base := "gemini://" + ln.Addr().String()
+
+	go serveSmallWeb(ln, func(request string) string {
+		switch request {
+		case base + "/feed":
+			return "31 /atom.xml\r\n"
+		case base + "/atom.xml":
+			return "20 application/atom+xml\r\n" + smallWebAtom
+		default:
+			return "51 Not found\r\n"
+		}
+	})
+
+	u, _ := url.Parse(base + "/feed")
+	body, finalURL, err := fetchGemini(context.Background(), u)
+	if err != nil {
+		t.Fatalf("fetchGemini: %v", err)
+	}
+	if finalURL != base+"/atom.xml" || string(body) != smallWebAtom {
+		t.Errorf("expected the redirected feed, got %q from %s", body, finalURL)
+	}
+
+	u, _ = url.Parse(base + "/missing")
+	if _, _, err := fetchGemini(context.Background(), u); err == nil || !strings.Contains(err.Error(), "51") {
+		t.Errorf("expected the error status to be reported, got %v", err)
+	}
+
+	// Feeds are parsed like any other once fetched
+	fetcher := NewFetcher(setupDBForFeedTests(t))
+	parsed, err := fetcher.ParseFeedWithFeed(context.Background(), &models.Feed{URL: base + "/feed"}, false)
+	if err != nil {
+		t.Fatalf("ParseFeedWithFeed: %v", err)
+	}
+	if parsed.Title != "Capsule" || len(parsed.Items) != 1 || parsed.Items[0].Link != "gemini://capsule.example/first.gmi" {
+		t.Errorf("unexpected feed %+v", parsed)
+	}
+}
+
+func TestFetchGopher(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	selectors := make(chan string, 1)
+	go serveSmallWeb(ln, func(request string) string {
+		selectors <- request
+		return smallWebAtom + "\r\n.\r\n"
+	})
+
+	u, _ := url.Parse("gopher://" + ln.Addr().String() + "/0/phlog/atom.xml")
+	body, _, err := fetchGopher(context.Background(), u)
+	if err != nil {
+		t.Fatalf("fetchGopher: %v", err)
+	}
+	if selector := <-selectors; selector != "/phlog/atom.xml" {
+		t.Errorf("expected the selector without the item type, got %q", selector)
+	}
+	if strings.TrimSpace(string(body)) != smallWebAtom {
+		t.Errorf("expected the terminating line to be removed, got %q", body)
+	}
+}
@@ -109,18 +109,28 @@ func sanitizeFeedXML(xmlContent string) string {
 }
 
 // fetchAndSanitizeFeed fetches feed content and sanitizes it before parsing
-func (f *Fetcher) fetchAndSanitizeFeed(ctx context.Context, feedURL string) (string, error) {
+func (f *Fetcher) fetchAndSanitizeFeed(ctx context.Context, feedURL string) (string, string, error) {
 	debugTimer := NewDebugTimer(fmt.Sprintf("FetchSanitize-%s", feedURL), shouldEnableDebugLogging(feedURL))
 	defer debugTimer.End()
 
 	debugTimer.Stage("Starting fetchAndSanitizeFeed")
 
+	// Feeds on the small web are fetched over their own protocol
+	if transport, u, ok := lookupFeedTransport(feedURL); ok {
+		debugTimer.LogWithTime("Fetching over %s", u.Scheme)
+		body, finalURL, err := transport(ctx, u)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to fetch feed: %w", err)
+		}
+		return sanitizeFeedXML(string(body)), finalURL, nil
+	}
+
 	// Use the feed's HTTP client to fetch content
 	debugTimer.LogWithTime("Getting HTTP client")
 	httpClient, err := f.getHTTPClient(models.Feed{URL: feedURL})
 	if err != nil {
 		debugTimer.LogWithTime("Failed to create HTTP client: %v", err)
-		return "", fmt.Errorf("failed to create HTTP client: %w", err)
+		return "", "", fmt.Errorf("failed to create HTTP client: %w", err)
 	}
 	debugTimer.Stage("HTTP client created")
 
@@ -128,7 +138,7 @@ func (f *Fetcher) fetchAndSanitizeFeed(ctx context.Context, feedURL string) (str
 	req, err := http.NewRequestWithContext(ctx, "GET", feedURL, nil)
 	if err != nil {
 		debugTimer.LogWithTime("Failed to create request: %v", err)
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return "", "", fmt.Errorf("failed to create request: %w", err)
 	}
 	debugTimer.Stage("Request created")
 
@@ -146,14 +156,14 @@ func (f *Fetcher) fetchAndSanitizeFeed(ctx context.Context, feedURL string) (str
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		debugTimer.LogWithTime("HTTP request failed: %v", err)
-		return "", fmt.Errorf("failed to fetch feed: %w", err)
+		return "", "", fmt.Errorf("failed to fetch feed: %w", err)
 	}
 	defer resp.Body.Close()
 	debugTimer.Stage("HTTP request completed")
 
 	if resp.StatusCode != http.StatusOK {
 		debugTimer.LogWithTime("HTTP status not OK: %d", resp.StatusCode)
-		return "", fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+		return "", "", fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
 	}
 
 	debugTimer.LogWithTime("Reading response body")
@@ -162,7 +172,7 @@ func (f *Fetcher) fetchAndSanitizeFeed(ctx context.Context, feedURL string) (str
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		debugTimer.LogWithTime("Failed to read body: %v", err)
-		return "", fmt.Errorf("failed to read response body: %w", err)
+		return "", "", fmt.Errorf("failed to read response body: %w", err)
 	}
 	debugTimer.LogWithTime("Read %d bytes from response", len(body))
 	debugTimer.Stage("Body read complete")
@@ -175,7 +185,7 @@ func (f *Fetcher) fetchAndSanitizeFeed(ctx context.Context, feedURL string) (str
 	debugTimer.LogWithTime("Sanitization complete, length=%d", len(cleanedXML))
 	debugTimer.Stage("Sanitization complete")
 
-	return cleanedXML, nil
+	return cleanedXML, resp.Request.URL.String(), nil
 }
 
 // AddSubscription adds a new feed subscription and returns the feed ID.
@@ -184,7 +194,7 @@ func (f *Fetcher) AddSubscription(url string, category string, customTitle strin
 
 	// Try fetching and sanitizing the feed first
 	ctx := context.Background()
-	cleanedXML, err := f.fetchAndSanitizeFeed(ctx, url)
+	cleanedXML, _, err := f.fetchAndSanitizeFeed(ctx, url)
 	if err != nil {
 		utils.DebugLog("AddSubscription: Failed to fetch feed for %s: %v", url, err)
 		// Fall through to standard parsing which might handle it differently
@@ -222,6 +232,14 @@ func (f *Fetcher) AddSubscription(url string, category string, customTitle strin
 		utils.DebugLog("AddSubscription: Parsing sanitized feed failed: %v", parseErr)
 	}
 
+	// The fallbacks below fetch over HTTP
+	if _, _, ok := lookupFeedTransport(url); ok {
+		if err != nil {
+			return 0, err
+		}
+		return 0, fmt.Errorf("failed to parse feed from %s", url)
+	}
+
 	// Fallback: Try standard parsing (for backward compatibility)
 	utils.DebugLog("AddSubscription: Attempting standard RSS parsing for URL: %s", url)
 	parsedFeed, err := f.fp.ParseURL(url)
@@ -587,9 +605,17 @@ func (f *Fetcher) parseFeedWithFeedInternal(ctx context.Context, feed *models.Fe
 	// Try fetching and sanitizing the feed first to handle file:// URLs in atom:link
 	debugTimer.LogWithTime("About to call fetchAndSanitizeFeed")
 	utils.DebugLog("parseFeedWithFeedInternal: Attempting to fetch and sanitize feed for %s", actualURL)
-	cleanedXML, sanitizeErr := f.fetchAndSanitizeFeed(fetchCtx, actualURL)
+	cleanedXML, resolvedURL, sanitizeErr := f.fetchAndSanitizeFeed(fetchCtx, actualURL)
 	debugTimer.LogWithTime("fetchAndSanitizeFeed completed, err=%v", sanitizeErr)
 
+	// Remember where a subscribed feed ends up after redirects; RSSHub addresses are
+	// rewritten per instance, so only direct URLs are compared
+	if sanitizeErr == nil && feed.ID != 0 && actualURL == feed.URL {
+		if err := f.db.UpdateFeedResolvedURL(feed.ID, utils.CanonicalFeedURL(resolvedURL)); err != nil {
+			utils.DebugLog("parseFeedWithFeedInternal: Failed to store resolved URL for %s: %v", feed.URL, err)
+		}
+	}
+
 	if sanitizeErr == nil {
 		debugTimer.Stage("Parsing sanitized XML")
 		// Successfully fetched and sanitized, try parsing
@@ -616,6 +642,14 @@ func (f *Fetcher) parseFeedWithFeedInternal(ctx context.Context, feed *models.Fe
 		utils.DebugLog("parseFeedWithFeedInternal: Sanitization failed: %v", sanitizeErr)
 	}
 
+	// The fallbacks below fetch over HTTP
+	if _, _, ok := lookupFeedTransport(actualURL); ok {
+		if sanitizeErr != nil {
+			return nil, sanitizeErr
+		}
+		return nil, fmt.Errorf("failed to parse feed from %s", actualURL)
+	}
+
 	// Fallback: Try standard parsing first
 	debugTimer.Stage("Standard parsing via ParseURLWithContext")
 	debugTimer.LogWithTime("About to call ParseURLWithContext")
@@ -2,6 +2,7 @@ package feed
 
 import (
 	"MrRSS/internal/models"
+	"MrRSS/internal/utils"
 	"context"
 	"fmt"
 	"log"
@@ -45,6 +46,8 @@ type TaskManager struct {
 	// Pool configuration
 	poolCapacity int
 	poolSem      chan struct{} // Semaphore for pool capacity
+	// Lowers the effective capacity while network errors spike
+	limiter *adaptiveLimiter
 
 	// State tracking
 	isRunning  bool
@@ -84,6 +87,7 @@ func NewTaskManager(fetcher *Fetcher, poolCapacity int) *TaskManager {
 		pool:         make(map[int64]*RefreshTask),
 		poolCapacity: poolCapacity,
 		poolSem:      make(chan struct{}, poolCapacity),
+		limiter:      newAdaptiveLimiter(poolCapacity),
 		stopChan:     make(chan struct{}),
 	}
 
@@ -103,9 +107,27 @@ func (tm *TaskManager) SetPoolCapacity(capacity int) {
 	tm.poolCapacity = capacity
 	tm.poolMutex.Unlock()
 
+	// The configured capacity is the ceiling for adaptive throttling
+	tm.limiter.Reset(capacity)
+
 	log.Printf("Task manager pool capacity updated to %d", capacity)
 }
 
+// SetAdaptiveConcurrency enables or disables lowering the pool capacity when
+// fetches keep failing with network errors
+func (tm *TaskManager) SetAdaptiveConcurrency(enabled bool) {
+	tm.limiter.SetEnabled(enabled)
+}
+
+// effectiveCapacity returns the pool capacity after adaptive throttling.
+// Caller must hold poolMutex.
+func (tm *TaskManager) effectiveCapacity() int {
+	if limit := tm.limiter.Limit(); limit < tm.poolCapacity {
+		return limit
+	}
+	return tm.poolCapacity
+}
+
 // Start starts the task manager
 func (tm *TaskManager) Start() {
 	tm.stateMutex.Lock()
@@ -166,10 +188,11 @@ func (tm *TaskManager) MarkRunning() {
 // MarkCompleted marks the progress as completed
 func (tm *TaskManager) MarkCompleted() {
 	tm.progressMutex.Lock()
-	defer tm.progressMutex.Unlock()
-
 	tm.progress.IsRunning = false
+	tm.progressMutex.Unlock()
 	log.Println("Progress marked as completed")
+
+	tm.publishProgress()
 }
 
 // AddToQueueHead adds a task to the queue head (highest priority)
@@ -177,7 +200,7 @@ func (tm *TaskManager) MarkCompleted() {
 func (tm *TaskManager) AddToQueueHead(ctx context.Context, feed models.Feed, reason TaskReason) {
 	// Skip FreshRSS feeds - they are refreshed via sync, not standard refresh
 	if feed.IsFreshRSSSource {
-		log.Printf("Skipping FreshRSS feed %s (refreshed via sync only)", feed.Title)
+		utils.ContextLog(ctx, "Skipping FreshRSS feed %s (refreshed via sync only)", feed.Title)
 		return
 	}
 
@@ -186,7 +209,7 @@ func (tm *TaskManager) AddToQueueHead(ctx context.Context, feed models.Feed, rea
 	tm.stateMutex.RUnlock()
 
 	if isStopped {
-		log.Println("Task manager is stopped, ignoring task")
+		utils.ContextLog(ctx, "Task manager is stopped, ignoring task")
 		return
 	}
 
@@ -220,13 +243,13 @@ func (tm *TaskManager) AddToQueueHead(ctx context.Context, feed models.Feed, rea
 	// Log operation after releasing lock to avoid deadlock
 	if added {
 		if removed {
-			log.Printf("Moved feed %s to queue head (reason: %d)", feed.Title, reason)
+			utils.ContextLog(ctx, "Moved feed %s to queue head (reason: %d)", feed.Title, reason)
 		} else {
-			log.Printf("Added feed %s to queue head (reason: %d)", feed.Title, reason)
+			utils.ContextLog(ctx, "Added feed %s to queue head (reason: %d)", feed.Title, reason)
 		}
 		tm.logOperation("AF", feed.Title)
 	} else {
-		log.Printf("Feed %s already in pool, ignoring (reason: %d)", feed.Title, reason)
+		utils.ContextLog(ctx, "Feed %s already in pool, ignoring (reason: %d)", feed.Title, reason)
 		return
 	}
 
@@ -242,7 +265,7 @@ func (tm *TaskManager) AddToQueueHead(ctx context.Context, feed models.Feed, rea
 func (tm *TaskManager) AddToQueueTail(ctx context.Context, feed models.Feed, reason TaskReason) {
 	// Skip FreshRSS feeds - they are refreshed via sync, not standard refresh
 	if feed.IsFreshRSSSource {
-		log.Printf("Skipping FreshRSS feed %s (refreshed via sync only)", feed.Title)
+		utils.ContextLog(ctx, "Skipping FreshRSS feed %s (refreshed via sync only)", feed.Title)
 		return
 	}
 
@@ -251,7 +274,7 @@ func (tm *TaskManager) AddToQueueTail(ctx context.Context, feed models.Feed, rea
 	tm.stateMutex.RUnlock()
 
 	if isStopped {
-		log.Println("Task manager is stopped, ignoring task")
+		utils.ContextLog(ctx, "Task manager is stopped, ignoring task")
 		return
 	}
 
@@ -283,13 +306,13 @@ func (tm *TaskManager) AddToQueueTail(ctx context.Context, feed models.Feed, rea
 
 	// Log operation after releasing lock to avoid deadlock
 	if added {
-		log.Printf("Added feed %s to queue tail (reason: %d)", feed.Title, reason)
+		utils.ContextLog(ctx, "Added feed %s to queue tail (reason: %d)", feed.Title, reason)
 		tm.logOperation("AR", feed.Title)
 	} else {
 		if inQueue {
-			log.Printf("Feed %s already in queue, ignoring (reason: %d)", feed.Title, reason)
+			utils.ContextLog(ctx, "Feed %s already in queue, ignoring (reason: %d)", feed.Title, reason)
 		} else {
-			log.Printf("Feed %s already in pool, ignoring (reason: %d)", feed.Title, reason)
+			utils.ContextLog(ctx, "Feed %s already in pool, ignoring (reason: %d)", feed.Title, reason)
 		}
 		return
 	}
@@ -457,6 +480,9 @@ func (tm *TaskManager) ExecuteImmediately(ctx context.Context, feed models.Feed)
 
 	log.Printf("Executing feed %s immediately (article click)", feed.Title)
 
+	// Someone is waiting on this one, so it counts toward the fetch budget without being held back by it
+	tm.fetcher.fetchBudget.take(0)
+
 	// Start worker goroutine
 	tm.wg.Add(1)
 	go func() {
@@ -545,7 +571,7 @@ func (tm *TaskManager) processQueue(ctx context.Context) {
 
 		// Get next task from queue
 		var feedID int64
-		if len(tm.queue) > 0 && len(tm.pool) < tm.poolCapacity {
+		if len(tm.queue) > 0 && len(tm.pool) < tm.effectiveCapacity() {
 			feedID = tm.queue[0]
 			tm.queue = tm.queue[1:]
 		}
@@ -559,6 +585,20 @@ func (tm *TaskManager) processQueue(ctx context.Context) {
 			return
 		}
 
+		// Hourly fetch budget spent: leave the rest for the scheduler to pick up
+		// once the window frees up, since their last_updated is unchanged
+		if limit := tm.fetcher.maxFetchesPerHour(); !tm.fetcher.fetchBudget.take(limit) {
+			tm.queueMutex.Lock()
+			deferred := len(tm.queue) + 1
+			tm.queue = tm.queue[:0]
+			tm.queueMutex.Unlock()
+			_, resetIn := tm.fetcher.fetchBudget.remaining(limit)
+			log.Printf("Fetch budget of %d per hour exhausted, deferring %d feeds to the next window (in %v)",
+				limit, deferred, resetIn.Round(time.Second))
+			tm.checkCompletion()
+			return
+		}
+
 		// Get feed from database
 		feed, err := tm.fetcher.db.GetFeedByID(feedID)
 		if err != nil {
@@ -616,7 +656,7 @@ func (tm *TaskManager) processTask(ctx context.Context, task *RefreshTask) {
 		tm.processQueue(ctx)
 	}()
 
-	log.Printf("Processing feed: %s (reason: %d)", task.Feed.Title, task.Reason)
+	utils.ContextLog(ctx, "Processing feed: %s (reason: %d)", task.Feed.Title, task.Reason)
 
 	// Try fetching with timeout and retry
 	var err error
@@ -630,16 +670,16 @@ func (tm *TaskManager) processTask(ctx context.Context, task *RefreshTask) {
 	ctx1, cancel1 := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel1()
 
-	log.Printf("Starting first attempt to fetch feed: %s (timeout: 60s)", task.Feed.Title)
+	utils.ContextLog(ctx, "Starting first attempt to fetch feed: %s (timeout: 60s)", task.Feed.Title)
 	err = tm.fetcher.fetchFeedWithContext(ctx1, task.Feed)
 	if err == nil {
 		success = true
-		log.Printf("Successfully fetched feed: %s (first attempt)", task.Feed.Title)
+		utils.ContextLog(ctx, "Successfully fetched feed: %s (first attempt)", task.Feed.Title)
 	}
 
 	// Second attempt: use configured retry timeout if first attempt failed
 	if !success && err != nil {
-		log.Printf("First attempt failed for %s: %v, retrying with %v timeout", task.Feed.Title, err, retryTimeoutSeconds)
+		utils.ContextLog(ctx, "First attempt failed for %s: %v, retrying with %v timeout", task.Feed.Title, err, retryTimeoutSeconds)
 		tm.logOperation("RT", task.Feed.Title)
 
 		ctx2, cancel2 := context.WithTimeout(ctx, retryTimeoutSeconds)
@@ -648,13 +688,22 @@ func (tm *TaskManager) processTask(ctx context.Context, task *RefreshTask) {
 		err = tm.fetcher.fetchFeedWithContext(ctx2, task.Feed)
 		if err == nil {
 			success = true
-			log.Printf("Successfully fetched feed: %s (second attempt)", task.Feed.Title)
+			utils.ContextLog(ctx, "Successfully fetched feed: %s (second attempt)", task.Feed.Title)
 		}
 	}
 
+	// A canceled refresh is not a feed failure, leave the feed's error state alone
+	if err != nil && ctx.Err() != nil {
+		utils.ContextLog(ctx, "Fetch of feed %s canceled", task.Feed.Title)
+		tm.logOperation("CN", task.Feed.Title)
+		return
+	}
+
+	tm.limiter.Record(isNetworkError(err))
+
 	// Handle result
 	if err != nil {
-		log.Printf("Failed to fetch feed %s after retry: %v", task.Feed.Title, err)
+		utils.ContextLog(ctx, "Failed to fetch feed %s after retry: %v", task.Feed.Title, err)
 		tm.logOperation("FL", task.Feed.Title)
 
 		// Update feed error and last_updated in database
@@ -691,16 +740,23 @@ func (tm *TaskManager) checkCompletion() {
 	tm.statsMutex.RUnlock()
 
 	tm.progressMutex.Lock()
-	defer tm.progressMutex.Unlock()
-
-	if queueLen == 0 && poolLen == 0 && articleClickCount == 0 && tm.progress.IsRunning {
+	completed := queueLen == 0 && poolLen == 0 && articleClickCount == 0 && tm.progress.IsRunning
+	if completed {
 		// All tasks completed
 		tm.progress.IsRunning = false
+	}
+	tm.progressMutex.Unlock()
 
+	if completed {
 		log.Println("All tasks completed")
 
+		// Nothing is left to cancel
+		tm.fetcher.endRefresh()
+
 		// Trigger cleanup through cleanup manager
 		tm.fetcher.cleanupManager.RequestCleanup()
+
+		tm.publishProgress()
 	}
 }
 
@@ -709,9 +765,18 @@ func (tm *TaskManager) GetProgress() Progress {
 	tm.progressMutex.Lock()
 	defer tm.progressMutex.Unlock()
 
+	// Copy errors so callers can encode them while fetches keep running
+	var errs map[int64]string
+	if tm.progress.Errors != nil {
+		errs = make(map[int64]string, len(tm.progress.Errors))
+		for id, msg := range tm.progress.Errors {
+			errs[id] = msg
+		}
+	}
+
 	return Progress{
 		IsRunning: tm.progress.IsRunning,
-		Errors:    tm.progress.Errors,
+		Errors:    errs,
 	}
 }
 
@@ -898,6 +963,16 @@ func (tm *TaskManager) updateStats() {
 	tm.stats.PoolTaskCount = poolLen
 	tm.stats.QueueTaskCount = queueLen
 	tm.statsMutex.Unlock()
+
+	tm.publishProgress()
+}
+
+// publishProgress pushes the current progress to stream subscribers.
+// Must not be called while holding progressMutex or statsMutex.
+func (tm *TaskManager) publishProgress() {
+	if tm.fetcher != nil {
+		tm.fetcher.publishProgress()
+	}
 }
 
 // Helper functions
@@ -988,9 +1063,9 @@ func (tm *TaskManager) initTaskLog() {
 }
 
 // logOperation logs a task operation with the specified format
-// Format: AF/AR/MV/RT/SC/FL n/m name
+// Format: AF/AR/MV/RT/SC/FL/CN n/m name
 // AF = Add to Front (queue head), AR = Add to Rear (queue tail)
-// MV = Move to Pool, RT = Retry, SC = Success, FL = Failure
+// MV = Move to Pool, RT = Retry, SC = Success, FL = Failure, CN = Canceled
 // n = pool task count, m = queue task count
 func (tm *TaskManager) logOperation(operation string, feedName string) {
 	if !tm.logEnabled || tm.logFile == nil {
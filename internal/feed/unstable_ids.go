@@ -0,0 +1,71 @@
+package feed
+
+import (
+	"log"
+	"time"
+
+	"MrRSS/internal/models"
+)
+
+const (
+	// unstableIDWindow is how recent a stored article must be for a URL and title match
+	// under a new identifier to count as evidence that the feed's identifiers are unstable
+	unstableIDWindow = 7 * 24 * time.Hour
+	// unstableIDMinMatches is the number of such matches in one fetch needed to flag a feed
+	unstableIDMinMatches = 3
+)
+
+// dropUnstableIDDuplicates removes articles that are new by their unique ID but whose URL
+// and title match an article the feed already has. Feeds marked guid_unstable or set to
+// force URL dedup always get this treatment. Other feeds are flagged guid_unstable when
+// most of the new articles of a fetch turn out to be recent matches.
+func (f *Fetcher) dropUnstableIDDuplicates(feed models.Feed, articlesWithContent []*ArticleWithContent) []*ArticleWithContent {
+	if f.db == nil || len(articlesWithContent) == 0 {
+		return articlesWithContent
+	}
+
+	fresh := f.newArticlesOnly(articlesWithContent)
+	if len(fresh) == 0 {
+		return articlesWithContent
+	}
+
+	cutoff := time.Now().Add(-unstableIDWindow)
+	duplicates := make(map[*ArticleWithContent]bool)
+	recentMatches := 0
+	for _, awc := range fresh {
+		a := awc.Article
+		if a.URL == "" {
+			continue
+		}
+		publishedAt, found, err := f.db.GetFeedArticlePublishedByURLTitle(feed.ID, a.URL, a.Title)
+		if err != nil || !found {
+			continue
+		}
+		duplicates[awc] = true
+		if publishedAt.After(cutoff) {
+			recentMatches++
+		}
+	}
+	if len(duplicates) == 0 {
+		return articlesWithContent
+	}
+
+	if !feed.GUIDUnstable && !feed.ForceURLDedup {
+		if recentMatches < unstableIDMinMatches || recentMatches*2 < len(fresh) {
+			return articlesWithContent
+		}
+		log.Printf("Feed %s produced %d new identifiers for stored articles, deduplicating it by URL and title",
+			feed.Title, recentMatches)
+		if err := f.db.UpdateFeedGUIDUnstable(feed.ID, true); err != nil {
+			log.Printf("Error flagging feed %s as having unstable identifiers: %v", feed.Title, err)
+		}
+	}
+
+	kept := make([]*ArticleWithContent, 0, len(articlesWithContent)-len(duplicates))
+	for _, awc := range articlesWithContent {
+		if !duplicates[awc] {
+			kept = append(kept, awc)
+		}
+	}
+	return kept
+}
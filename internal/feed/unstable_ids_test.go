@@ -0,0 +1,63 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+func TestFetchFeed_UnstableIdentifiers(t *testing.T) {
+	db := setupDBForFeedTests(t)
+	fetcher := NewFetcher(db)
+
+	// The feed re-dates every item on each fetch, so the date-based unique ID keeps changing
+	itemsDated := func(published time.Time) []*gofeed.Item {
+		var items []*gofeed.Item
+		for i := 1; i <= 4; i++ {
+			p := published
+			items = append(items, &gofeed.Item{
+				Title:           fmt.Sprintf("Story %d", i),
+				Link:            fmt.Sprintf("http://test.com/story/%d", i),
+				PublishedParsed: &p,
+			})
+		}
+		return items
+	}
+	mock := &MockParser{Feed: &gofeed.Feed{Title: "Unstable Feed", Items: itemsDated(time.Now().Add(-48 * time.Hour))}}
+	fetcher.fp = mock
+
+	feedID, err := fetcher.AddSubscription("http://test.com/rss", "", "")
+	if err != nil {
+		t.Fatalf("AddSubscription failed: %v", err)
+	}
+	feed, _ := db.GetFeedByID(feedID)
+	fetcher.FetchFeed(context.Background(), *feed)
+
+	mock.Feed.Items = itemsDated(time.Now())
+	fetcher.FetchFeed(context.Background(), *feed)
+
+	articles, err := db.GetArticles("", feedID, "", false, 100, 0)
+	if err != nil {
+		t.Fatalf("GetArticles failed: %v", err)
+	}
+	if len(articles) != 4 {
+		t.Fatalf("expected re-dated items to be deduplicated, got %d articles", len(articles))
+	}
+	feed, _ = db.GetFeedByID(feedID)
+	if !feed.GUIDUnstable {
+		t.Fatal("expected the feed to be flagged guid_unstable")
+	}
+
+	// Genuinely new items still come through
+	mock.Feed.Items = append(itemsDated(time.Now().Add(24*time.Hour)), &gofeed.Item{
+		Title: "Story 5", Link: "http://test.com/story/5",
+	})
+	fetcher.FetchFeed(context.Background(), *feed)
+	articles, _ = db.GetArticles("", feedID, "", false, 100, 0)
+	if len(articles) != 5 {
+		t.Fatalf("expected only the new item to be added, got %d articles", len(articles))
+	}
+}
@@ -27,14 +27,25 @@ type SyncResult struct {
 type BidirectionalSyncService struct {
 	client *Client
 	db     *database.DB
+
+	conflictStrategy ConflictStrategy
+	lastSyncTime     time.Time // End of the previous full sync, zero if unknown
 }
 
 // NewBidirectionalSyncService creates a new bidirectional sync service
 func NewBidirectionalSyncService(serverURL, username, password string, db *database.DB) *BidirectionalSyncService {
-	return &BidirectionalSyncService{
-		client: NewClient(serverURL, username, password),
-		db:     db,
+	var opts ClientOptions
+	s := &BidirectionalSyncService{db: db, conflictStrategy: ConflictRemoteWins}
+	if db != nil {
+		opts = ClientOptionsFromSettings(db)
+		strategy, _ := db.GetSetting("sync_conflict_strategy")
+		s.conflictStrategy = ParseConflictStrategy(strategy)
+		if lastSync, err := db.GetSetting("freshrss_last_sync_time"); err == nil && lastSync != "" {
+			s.lastSyncTime, _ = time.Parse(time.RFC3339, lastSync)
+		}
 	}
+	s.client = NewClientWithOptions(serverURL, username, password, opts)
+	return s
 }
 
 // Sync performs a full bidirectional sync
@@ -114,6 +125,48 @@ func (s *BidirectionalSyncService) SyncFeed(ctx context.Context, streamID string
 	return count, nil
 }
 
+// UnsubscribeFeed removes a locally deleted FreshRSS feed from the server, so that it
+// is not recreated by the next sync
+func (s *BidirectionalSyncService) UnsubscribeFeed(ctx context.Context, feedURL string) error {
+	if err := s.client.Login(ctx); err != nil {
+		return fmt.Errorf("login failed: %w", err)
+	}
+	if err := s.client.Unsubscribe(ctx, feedURL); err != nil {
+		return err
+	}
+	log.Printf("[UnsubscribeFeed] Unsubscribed from %s", feedURL)
+	return nil
+}
+
+// UpdateSubscription pushes a local rename or category change of a FreshRSS feed to the
+// server. A nil title or category is left unchanged.
+func (s *BidirectionalSyncService) UpdateSubscription(ctx context.Context, feedURL string, title, category *string) error {
+	if err := s.client.Login(ctx); err != nil {
+		return fmt.Errorf("login failed: %w", err)
+	}
+	if title != nil {
+		if err := s.client.RenameSubscription(ctx, feedURL, *title); err != nil {
+			return err
+		}
+	}
+	if category != nil {
+		if err := s.client.SetSubscriptionCategory(ctx, feedURL, *category); err != nil {
+			return err
+		}
+	}
+	log.Printf("[UpdateSubscription] Updated %s", feedURL)
+	return nil
+}
+
+// MarkStreamAsRead marks a whole stream read on the server with one request instead of an
+// edit-tag call per article
+func (s *BidirectionalSyncService) MarkStreamAsRead(ctx context.Context, streamID string, olderThan time.Time) error {
+	if err := s.client.Login(ctx); err != nil {
+		return fmt.Errorf("login failed: %w", err)
+	}
+	return s.client.MarkStreamAsRead(ctx, streamID, olderThan)
+}
+
 // SyncArticleStatus syncs a single article's status immediately
 // This is called when user manually marks an article as read/unread or starred/unstarred
 // Logic: Immediately push local status to server, overwriting remote
@@ -170,6 +223,70 @@ func (s *BidirectionalSyncService) SyncArticleStatus(ctx context.Context, articl
 	return nil
 }
 
+// PushStatusChanges pushes a batch of article state changes with one edit-tag call per
+// action. When the push fails, the changes are added to the queue for the next sync.
+func (s *BidirectionalSyncService) PushStatusChanges(ctx context.Context, changes []database.SyncRequest) error {
+	err := s.pushStatusChanges(ctx, changes)
+	if err != nil {
+		for _, change := range changes {
+			if queueErr := s.db.EnqueueSyncChange(change.ArticleID, change.ArticleURL, change.Action); queueErr != nil {
+				log.Printf("[Push Batch] Failed to enqueue article %d for retry: %v", change.ArticleID, queueErr)
+			}
+		}
+	}
+	return err
+}
+
+func (s *BidirectionalSyncService) pushStatusChanges(ctx context.Context, changes []database.SyncRequest) error {
+	if err := s.client.Login(ctx); err != nil {
+		return fmt.Errorf("login failed: %w", err)
+	}
+
+	articleIDs := make([]int64, len(changes))
+	for i, change := range changes {
+		articleIDs[i] = change.ArticleID
+	}
+	articles, err := s.db.GetArticlesByIDs(articleIDs)
+	if err != nil {
+		return err
+	}
+	itemIDs := make(map[int64]string, len(articles))
+	for _, article := range articles {
+		itemIDs[article.ID] = article.FreshRSSItemID
+	}
+
+	// Use FreshRSS item ID if available, otherwise fall back to URL
+	byAction := make(map[database.SyncAction][]string)
+	for _, change := range changes {
+		identifier := itemIDs[change.ArticleID]
+		if identifier == "" {
+			identifier = change.ArticleURL
+		}
+		byAction[change.Action] = append(byAction[change.Action], identifier)
+	}
+
+	batches := []struct {
+		action database.SyncAction
+		push   func(context.Context, []string) error
+	}{
+		{database.SyncActionMarkRead, s.client.MarkAsReadBatch},
+		{database.SyncActionMarkUnread, s.client.MarkAsUnreadBatch},
+		{database.SyncActionStar, s.client.StarBatch},
+		{database.SyncActionUnstar, s.client.UnstarBatch},
+	}
+	for _, batch := range batches {
+		ids := byAction[batch.action]
+		if len(ids) == 0 {
+			continue
+		}
+		if err := batch.push(ctx, ids); err != nil {
+			return fmt.Errorf("%s batch: %w", batch.action, err)
+		}
+		log.Printf("[Push Batch] %s: %d articles", batch.action, len(ids))
+	}
+	return nil
+}
+
 // pullFromServer pulls changes from FreshRSS server
 func (s *BidirectionalSyncService) pullFromServer(ctx context.Context) (int, error) {
 	totalChanges := 0
@@ -253,6 +370,14 @@ func (s *BidirectionalSyncService) pullFromServer(ctx context.Context) (int, err
 			}
 		}
 		log.Printf("Applied starred status to %d articles from server", len(starredArticles))
+
+		// Articles unstarred on the server are unstarred locally too; the push below would
+		// otherwise star them again
+		unstarred, err := s.unstarRemovedFavorites(starredArticles)
+		if err != nil {
+			log.Printf("Warning: Failed to apply removed stars: %v", err)
+		}
+		totalChanges += unstarred
 	}
 
 	// Step 4: Apply read status from server using unread count API (fetch all with pagination)
@@ -330,7 +455,7 @@ func (s *BidirectionalSyncService) fetchAllArticles(ctx context.Context, streamI
 }
 
 // applyServerStatus applies server status to local article
-// If local has a pending sync for the same action type, clear it to avoid conflicts
+// A conflicting local change that isn't pushed yet is resolved by the conflict strategy
 func (s *BidirectionalSyncService) applyServerStatus(articleURL string, status bool, column string) (int, error) {
 	// Check if article exists locally
 	localArticle, err := s.db.GetArticleByURL(articleURL)
@@ -339,48 +464,53 @@ func (s *BidirectionalSyncService) applyServerStatus(articleURL string, status b
 		return 0, nil
 	}
 
-	// Check if there's a pending sync change for the SAME action type
-	// Only clear conflicting pending syncs
-	pendingItems, err := s.db.GetPendingSyncChanges(1000)
-	hasConflictingSync := false
-	for _, item := range pendingItems {
-		if item.ArticleURL == articleURL {
-			isConflictingAction := false
-			switch column {
-			case "is_favorite":
-				// Star/unstar actions conflict with favorite status changes
-				isConflictingAction = (item.Action == database.SyncActionStar || item.Action == database.SyncActionUnstar)
-			case "is_read":
-				// Mark read/unread actions conflict with read status changes
-				isConflictingAction = (item.Action == database.SyncActionMarkRead || item.Action == database.SyncActionMarkUnread)
-			}
-
-			if isConflictingAction {
-				hasConflictingSync = true
-				log.Printf("[Conflict detected] Article %s has pending %s, server says %s=%v - clearing pending sync",
-					articleURL, item.Action, column, status)
-				break
-			}
-		}
+	state := readState
+	if column == "is_favorite" {
+		state = starState
 	}
+	if _, err := s.applyRemoteState(localArticle, state, status); err != nil {
+		return 0, err
+	}
+	return 1, nil
+}
 
-	// Apply server status
-	switch column {
-	case "is_favorite":
-		err = s.db.SetArticleFavorite(localArticle.ID, status)
-	case "is_read":
-		err = s.db.MarkArticleRead(localArticle.ID, status)
+// unstarRemovedFavorites clears the favorite flag of local FreshRSS articles that are no
+// longer starred on the server. A star change waiting to be pushed is resolved by the
+// conflict strategy.
+func (s *BidirectionalSyncService) unstarRemovedFavorites(starredArticles []Article) (int, error) {
+	starred := make(map[string]bool, len(starredArticles))
+	for _, article := range starredArticles {
+		starred[article.URL] = true
 	}
+
+	favorites, err := s.db.GetArticles("favorites", 0, "", true, 100000, 0)
 	if err != nil {
-		return 0, err
+		return 0, fmt.Errorf("get favorites: %w", err)
 	}
 
-	// Clear conflicting pending sync if any
-	if hasConflictingSync {
-		_ = s.db.ClearPendingSyncForArticle(localArticle.ID)
+	unstarred := 0
+	for _, favorite := range favorites {
+		// Only articles that came from FreshRSS are starred there
+		if favorite.FreshRSSItemID == "" || starred[favorite.URL] {
+			continue
+		}
+		article, err := s.db.GetArticleByURL(favorite.URL)
+		if err != nil {
+			continue
+		}
+		changed, err := s.applyRemoteState(article, starState, false)
+		if err != nil {
+			log.Printf("Warning: Failed to unstar article %s: %v", favorite.URL, err)
+			continue
+		}
+		if changed {
+			unstarred++
+		}
 	}
-
-	return 1, nil
+	if unstarred > 0 {
+		log.Printf("Unstarred %d articles no longer starred on the server", unstarred)
+	}
+	return unstarred, nil
 }
 
 // createFeedsFromSubscriptions creates local feeds from FreshRSS subscriptions
@@ -703,27 +833,21 @@ func (s *BidirectionalSyncService) saveArticlesFromServer(ctx context.Context, a
 				}
 			}
 
-			// Update read status from FreshRSS (server is authoritative)
+			// Update read status from FreshRSS, unless an unpushed local change wins
 			// Only update if status differs to avoid unnecessary writes
-			if isRead != existingArticle.IsRead {
-				err := s.db.MarkArticleRead(existingArticle.ID, isRead)
-				if err != nil {
-					log.Printf("Warning: Failed to update read status for article %s: %v", article.URL, err)
-				} else {
-					log.Printf("Updated read status for article %s: %v (from FreshRSS)", article.URL, isRead)
-					updated = true
-				}
+			if changed, err := s.applyRemoteState(existingArticle, readState, isRead); err != nil {
+				log.Printf("Warning: Failed to update read status for article %s: %v", article.URL, err)
+			} else if changed {
+				log.Printf("Updated read status for article %s: %v (from FreshRSS)", article.URL, isRead)
+				updated = true
 			}
 
-			// Update favorite status from FreshRSS (server is authoritative)
-			if isStarred != existingArticle.IsFavorite {
-				err := s.db.SetArticleFavorite(existingArticle.ID, isStarred)
-				if err != nil {
-					log.Printf("Warning: Failed to update favorite status for article %s: %v", article.URL, err)
-				} else {
-					log.Printf("Updated favorite status for article %s: %v (from FreshRSS)", article.URL, isStarred)
-					updated = true
-				}
+			// Update favorite status from FreshRSS, unless an unpushed local change wins
+			if changed, err := s.applyRemoteState(existingArticle, starState, isStarred); err != nil {
+				log.Printf("Warning: Failed to update favorite status for article %s: %v", article.URL, err)
+			} else if changed {
+				log.Printf("Updated favorite status for article %s: %v (from FreshRSS)", article.URL, isStarred)
+				updated = true
 			}
 
 			// Extract and update thumbnail if article doesn't have one but has content
@@ -1005,16 +1129,11 @@ func (s *BidirectionalSyncService) pushToServer(ctx context.Context) (int, error
 }
 
 // pushPendingItems pushes items that failed previously (from the queue)
+// Each action is pushed as one batch. Items of a batch that fails are marked failed, so
+// they show up in GetFailedSyncItems, and stay queued for the next sync.
 func (s *BidirectionalSyncService) pushPendingItems(ctx context.Context, pendingChanges []database.SyncQueueItem) (int, error) {
 	totalChanges := 0
 
-	// Group changes by action type
-	readIDs := make([]string, 0)
-	unreadIDs := make([]string, 0)
-	starIDs := make([]string, 0)
-	unstarIDs := make([]string, 0)
-	itemIDs := make([]int64, 0)
-
 	// Get article IDs to fetch FreshRSS item IDs
 	articleIDs := make([]int64, len(pendingChanges))
 	for i, item := range pendingChanges {
@@ -1034,10 +1153,10 @@ func (s *BidirectionalSyncService) pushPendingItems(ctx context.Context, pending
 		articleByID[article.ID] = article
 	}
 
-	// Use FreshRSS item ID if available, otherwise fall back to URL
+	// Group changes by action type, using FreshRSS item ID if available, otherwise the URL
+	identifiers := make(map[database.SyncAction][]string)
+	queueIDs := make(map[database.SyncAction][]int64)
 	for _, item := range pendingChanges {
-		itemIDs = append(itemIDs, item.ID)
-
 		article, exists := articleByID[item.ArticleID]
 		identifier := item.ArticleURL // Default fallback
 
@@ -1048,53 +1167,46 @@ func (s *BidirectionalSyncService) pushPendingItems(ctx context.Context, pending
 			log.Printf("  Warning: No FreshRSS Item ID for article %d, using URL: %s", item.ArticleID, item.ArticleURL)
 		}
 
-		switch item.Action {
-		case database.SyncActionMarkRead:
-			readIDs = append(readIDs, identifier)
-		case database.SyncActionMarkUnread:
-			unreadIDs = append(unreadIDs, identifier)
-		case database.SyncActionStar:
-			starIDs = append(starIDs, identifier)
-		case database.SyncActionUnstar:
-			unstarIDs = append(unstarIDs, identifier)
-		}
+		identifiers[item.Action] = append(identifiers[item.Action], identifier)
+		queueIDs[item.Action] = append(queueIDs[item.Action], item.ID)
 	}
 
 	// Execute batch operations
-	if len(readIDs) > 0 {
-		log.Printf("[PushPending] Marking %d articles as read", len(readIDs))
-		if err := s.client.MarkAsReadBatch(ctx, readIDs); err != nil {
-			log.Printf("[PushPending] ERROR marking as read: %v", err)
-			return totalChanges, fmt.Errorf("mark read batch: %w", err)
-		}
-		log.Printf("[PushPending] Successfully marked %d articles as read", len(readIDs))
-		totalChanges += len(readIDs)
+	batches := []struct {
+		action database.SyncAction
+		push   func(context.Context, []string) error
+	}{
+		{database.SyncActionMarkRead, s.client.MarkAsReadBatch},
+		{database.SyncActionMarkUnread, s.client.MarkAsUnreadBatch},
+		{database.SyncActionStar, s.client.StarBatch},
+		{database.SyncActionUnstar, s.client.UnstarBatch},
 	}
-
-	if len(unreadIDs) > 0 {
-		if err := s.client.MarkAsUnreadBatch(ctx, unreadIDs); err != nil {
-			return totalChanges, fmt.Errorf("mark unread batch: %w", err)
+	var pushErr error
+	for _, batch := range batches {
+		ids := identifiers[batch.action]
+		if len(ids) == 0 {
+			continue
 		}
-		totalChanges += len(unreadIDs)
-	}
 
-	if len(starIDs) > 0 {
-		if err := s.client.StarBatch(ctx, starIDs); err != nil {
-			return totalChanges, fmt.Errorf("star batch: %w", err)
+		if err := batch.push(ctx, ids); err != nil {
+			log.Printf("[PushPending] ERROR pushing %d %s changes: %v", len(ids), batch.action, err)
+			for _, id := range queueIDs[batch.action] {
+				if markErr := s.db.MarkSyncFailed(id, err.Error()); markErr != nil {
+					log.Printf("Warning: Failed to mark sync item %d as failed: %v", id, markErr)
+				}
+			}
+			if pushErr == nil {
+				pushErr = fmt.Errorf("%s batch: %w", batch.action, err)
+			}
+			continue
 		}
-		totalChanges += len(starIDs)
-	}
 
-	if len(unstarIDs) > 0 {
-		if err := s.client.UnstarBatch(ctx, unstarIDs); err != nil {
-			return totalChanges, fmt.Errorf("unstar batch: %w", err)
+		// Mark the batch as synced
+		if err := s.db.MarkSynced(queueIDs[batch.action]); err != nil {
+			log.Printf("Warning: Failed to mark items as synced: %v", err)
 		}
-		totalChanges += len(unstarIDs)
-	}
-
-	// Mark all as synced
-	if err := s.db.MarkSynced(itemIDs); err != nil {
-		log.Printf("Warning: Failed to mark items as synced: %v", err)
+		log.Printf("[PushPending] %s: %d articles", batch.action, len(ids))
+		totalChanges += len(ids)
 	}
 
 	log.Printf("[PushPending] Successfully synced %d items from queue", totalChanges)
@@ -1102,7 +1214,7 @@ func (s *BidirectionalSyncService) pushPendingItems(ctx context.Context, pending
 	// Clean up old synced items
 	_ = s.db.DeleteOldSyncedItems(7 * 24 * time.Hour)
 
-	return totalChanges, nil
+	return totalChanges, pushErr
 }
 
 // GetPendingCount returns the number of pending sync changes
@@ -4,43 +4,194 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"MrRSS/internal/models"
+	"MrRSS/internal/utils"
 )
 
-// Client represents a FreshRSS API client
+// Client represents a FreshRSS API client. It speaks the Google Reader API, so it also
+// works with other services implementing it; see ServerType.
 type Client struct {
-	baseURL    string
+	// loginURL is the ClientLogin endpoint and apiURL the root of the reader API,
+	// ending in /reader/api/0
+	loginURL   string
+	apiURL     string
 	username   string
 	password   string
-	authToken  string
 	httpClient *http.Client
+
+	authMu    sync.RWMutex
+	authToken string
+
+	editTagChunkSize int
+}
+
+// ServerType selects which Google Reader API implementation a client talks to
+type ServerType string
+
+const (
+	// ServerTypeFreshRSS serves the API under /api/greader.php of the FreshRSS install
+	ServerTypeFreshRSS ServerType = "freshrss"
+	// ServerTypeInoreader serves the API at the root of https://www.inoreader.com
+	ServerTypeInoreader ServerType = "inoreader"
+	// ServerTypeTheOldReader serves the API at the root of https://theoldreader.com
+	ServerTypeTheOldReader ServerType = "theoldreader"
+)
+
+// defaultServerURLs are used for hosted services when no server URL is configured
+var defaultServerURLs = map[ServerType]string{
+	ServerTypeInoreader:    "https://www.inoreader.com",
+	ServerTypeTheOldReader: "https://theoldreader.com",
+}
+
+// ParseServerType returns the server type named by s, defaulting to FreshRSS for empty or
+// unknown values so existing configurations keep working
+func ParseServerType(s string) ServerType {
+	switch t := ServerType(strings.ToLower(strings.TrimSpace(s))); t {
+	case ServerTypeInoreader, ServerTypeTheOldReader:
+		return t
+	default:
+		return ServerTypeFreshRSS
+	}
+}
+
+// serverEndpoints returns the login and reader API URLs for serverURL. FreshRSS URLs get
+// the /api/greader.php suffix; hosted services may be given with or without their
+// /reader/api/0 path, or not at all.
+func serverEndpoints(serverType ServerType, serverURL string) (loginURL, apiURL string) {
+	root := strings.TrimSuffix(strings.TrimSpace(serverURL), "/")
+	root = strings.TrimSuffix(root, "/reader/api/0")
+	switch serverType {
+	case ServerTypeInoreader, ServerTypeTheOldReader:
+		if root == "" {
+			root = defaultServerURLs[serverType]
+		}
+	default:
+		if !strings.HasSuffix(root, "/api/greader.php") {
+			root += "/api/greader.php"
+		}
+	}
+	return root + "/accounts/ClientLogin", root + "/reader/api/0"
+}
+
+// DefaultTimeout is the request timeout used when no other is configured
+const DefaultTimeout = 30 * time.Second
+
+// ClientOptions holds connection settings for a FreshRSS client. The zero value connects
+// directly, verifies TLS certificates and uses DefaultTimeout.
+type ClientOptions struct {
+	// ServerType selects the API layout of the server; empty means FreshRSS
+	ServerType ServerType
+	// InsecureSkipVerify accepts any server certificate, for self-signed setups on a LAN
+	InsecureSkipVerify bool
+	// Timeout bounds each request; zero uses DefaultTimeout
+	Timeout time.Duration
+	// HTTPClient is the base client to send requests with, such as one routed through the
+	// global proxy. Its timeout and TLS verification are overridden by the fields above.
+	HTTPClient *http.Client
+}
+
+// DBInterface defines the minimal database interface needed for connection settings
+type DBInterface interface {
+	GetSetting(key string) (string, error)
+	GetEncryptedSetting(key string) (string, error)
 }
 
-// NewClient creates a new FreshRSS API client
+// CreateHTTPClientWithProxy creates an HTTP client with global proxy settings if enabled
+func CreateHTTPClientWithProxy(db DBInterface, timeout time.Duration) (*http.Client, error) {
+	var proxyURL string
+
+	// Check if global proxy is enabled
+	proxyEnabled, _ := db.GetSetting("proxy_enabled")
+	if proxyEnabled == "true" {
+		// Build proxy URL from global settings
+		proxyType, _ := db.GetSetting("proxy_type")
+		proxyHost, _ := db.GetSetting("proxy_host")
+		proxyPort, _ := db.GetSetting("proxy_port")
+		proxyUsername, _ := db.GetEncryptedSetting("proxy_username")
+		proxyPassword, _ := db.GetEncryptedSetting("proxy_password")
+		proxyURL = utils.BuildProxyURL(proxyType, proxyHost, proxyPort, proxyUsername, proxyPassword)
+	}
+
+	// Create HTTP client with or without proxy
+	return utils.CreateHTTPClient(proxyURL, timeout)
+}
+
+// ClientOptionsFromSettings reads the freshrss_server_type, freshrss_insecure_tls and
+// freshrss_timeout_seconds settings, keeping the secure defaults for anything unset or invalid, and routes requests
+// through the global proxy when one is enabled
+func ClientOptionsFromSettings(db DBInterface) ClientOptions {
+	var opts ClientOptions
+	if db == nil {
+		return opts
+	}
+	if serverType, err := db.GetSetting("freshrss_server_type"); err == nil {
+		opts.ServerType = ParseServerType(serverType)
+	}
+	if insecure, err := db.GetSetting("freshrss_insecure_tls"); err == nil {
+		opts.InsecureSkipVerify = insecure == "true"
+	}
+	if s, err := db.GetSetting("freshrss_timeout_seconds"); err == nil {
+		if seconds, err := strconv.Atoi(s); err == nil && seconds > 0 {
+			opts.Timeout = time.Duration(seconds) * time.Second
+		}
+	}
+	httpClient, err := CreateHTTPClientWithProxy(db, opts.Timeout)
+	if err != nil {
+		// Fall back to a direct connection if the proxy settings are invalid
+		log.Printf("[FreshRSS] Failed to create proxy client, connecting directly: %v", err)
+	} else {
+		opts.HTTPClient = httpClient
+	}
+	return opts
+}
+
+// NewClient creates a new FreshRSS API client with the default options
 func NewClient(serverURL, username, password string) *Client {
-	// Ensure URL ends with /api/greader.php
-	if !strings.HasSuffix(serverURL, "/api/greader.php") {
-		serverURL = strings.TrimSuffix(serverURL, "/") + "/api/greader.php"
+	return NewClientWithOptions(serverURL, username, password, ClientOptions{})
+}
+
+// NewClientWithOptions creates a new FreshRSS API client with the given connection options
+func NewClientWithOptions(serverURL, username, password string, opts ClientOptions) *Client {
+	loginURL, apiURL := serverEndpoints(ParseServerType(string(opts.ServerType)), serverURL)
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
 	}
 
-	return &Client{
-		baseURL:  serverURL,
-		username: username,
-		password: password,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
 			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{InsecureSkipVerify: false},
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: opts.InsecureSkipVerify},
 			},
-		},
+		}
+	} else if transport, ok := httpClient.Transport.(*http.Transport); ok && opts.InsecureSkipVerify {
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	}
+	httpClient.Timeout = timeout
+
+	return &Client{
+		loginURL:         loginURL,
+		apiURL:           apiURL,
+		username:         username,
+		password:         password,
+		editTagChunkSize: defaultEditTagChunkSize,
+		httpClient:       httpClient,
 	}
 }
 
@@ -51,7 +202,7 @@ func (c *Client) Login(ctx context.Context) error {
 	data.Set("Passwd", c.password)
 
 	req, err := http.NewRequestWithContext(ctx, "POST",
-		c.baseURL+"/accounts/ClientLogin",
+		c.loginURL,
 		strings.NewReader(data.Encode()))
 	if err != nil {
 		return fmt.Errorf("create login request: %w", err)
@@ -79,7 +230,9 @@ func (c *Client) Login(ctx context.Context) error {
 	lines := strings.Split(string(body), "\n")
 	for _, line := range lines {
 		if strings.HasPrefix(line, "Auth=") {
-			c.authToken = strings.TrimPrefix(line, "Auth=")
+			c.authMu.Lock()
+			c.authToken = strings.TrimSpace(strings.TrimPrefix(line, "Auth="))
+			c.authMu.Unlock()
 			return nil
 		}
 	}
@@ -87,20 +240,61 @@ func (c *Client) Login(ctx context.Context) error {
 	return fmt.Errorf("auth token not found in response")
 }
 
+// currentToken returns the auth token from the last successful login
+func (c *Client) currentToken() string {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	return c.authToken
+}
+
+// doAuthenticated sends req with the current auth token. Google Reader tokens expire, so
+// on a 401 or 403 it logs in again once and replays the request with the fresh token.
+// If the login fails the original response is returned for the caller to report.
+func (c *Client) doAuthenticated(req *http.Request) (*http.Response, error) {
+	token := c.currentToken()
+	req.Header.Set("Authorization", "GoogleLogin auth="+token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil || (resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden) {
+		return resp, err
+	}
+
+	// The replay needs a fresh copy of the body
+	retry := req.Clone(req.Context())
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return resp, nil
+		}
+		if retry.Body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+
+	// Another request may already have logged in again
+	if c.currentToken() == token {
+		if loginErr := c.Login(req.Context()); loginErr != nil {
+			log.Printf("[FreshRSS API] Re-login after status %d failed: %v", resp.StatusCode, loginErr)
+			return resp, nil
+		}
+	}
+	resp.Body.Close()
+
+	retry.Header.Set("Authorization", "GoogleLogin auth="+c.currentToken())
+	return c.httpClient.Do(retry)
+}
+
 // GetToken retrieves a write token for modifying operations
 func (c *Client) GetToken(ctx context.Context) (string, error) {
-	if c.authToken == "" {
+	if c.currentToken() == "" {
 		return "", fmt.Errorf("not authenticated")
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/reader/api/0/token", nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.apiURL+"/token", nil)
 	if err != nil {
 		return "", fmt.Errorf("create token request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "GoogleLogin auth="+c.authToken)
-
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doAuthenticated(req)
 	if err != nil {
 		return "", fmt.Errorf("token request: %w", err)
 	}
@@ -134,19 +328,17 @@ type Category struct {
 
 // GetCategories retrieves all categories/tags from FreshRSS
 func (c *Client) GetCategories(ctx context.Context) ([]Category, error) {
-	if c.authToken == "" {
+	if c.currentToken() == "" {
 		return nil, fmt.Errorf("not authenticated")
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "GET",
-		c.baseURL+"/reader/api/0/tag/list?output=json", nil)
+		c.apiURL+"/tag/list?output=json", nil)
 	if err != nil {
 		return nil, fmt.Errorf("create categories request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "GoogleLogin auth="+c.authToken)
-
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doAuthenticated(req)
 	if err != nil {
 		return nil, fmt.Errorf("categories request: %w", err)
 	}
@@ -185,19 +377,17 @@ func (c *Client) GetCategories(ctx context.Context) ([]Category, error) {
 
 // GetSubscriptions retrieves all feed subscriptions
 func (c *Client) GetSubscriptions(ctx context.Context) ([]Subscription, error) {
-	if c.authToken == "" {
+	if c.currentToken() == "" {
 		return nil, fmt.Errorf("not authenticated")
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "GET",
-		c.baseURL+"/reader/api/0/subscription/list?output=json", nil)
+		c.apiURL+"/subscription/list?output=json", nil)
 	if err != nil {
 		return nil, fmt.Errorf("create subscriptions request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "GoogleLogin auth="+c.authToken)
-
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doAuthenticated(req)
 	if err != nil {
 		return nil, fmt.Errorf("subscriptions request: %w", err)
 	}
@@ -233,20 +423,18 @@ type Article struct {
 
 // GetUnreadCount retrieves unread counts for all feeds
 func (c *Client) GetUnreadCount(ctx context.Context) (map[string]int, error) {
-	if c.authToken == "" {
+	if c.currentToken() == "" {
 		return nil, fmt.Errorf("not authenticated")
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "GET",
-		c.baseURL+"/reader/api/0/unread-count?output=json",
+		c.apiURL+"/unread-count?output=json",
 		nil)
 	if err != nil {
 		return nil, fmt.Errorf("create unread-count request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "GoogleLogin auth="+c.authToken)
-
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doAuthenticated(req)
 	if err != nil {
 		return nil, fmt.Errorf("unread-count request: %w", err)
 	}
@@ -309,7 +497,7 @@ func (c *Client) GetReadArticles(ctx context.Context, maxItems int) ([]Article,
 // maxItems: maximum number of items to retrieve
 // continuationToken: token for pagination (empty for first request)
 func (c *Client) GetStreamContents(ctx context.Context, streamID string, excludeTypes []string, maxItems int, continuationToken string) (*StreamContentsResult, error) {
-	if c.authToken == "" {
+	if c.currentToken() == "" {
 		return nil, fmt.Errorf("not authenticated")
 	}
 
@@ -328,17 +516,15 @@ func (c *Client) GetStreamContents(ctx context.Context, streamID string, exclude
 		params.Add("xt", exclude)
 	}
 
-	streamURL := fmt.Sprintf("%s/reader/api/0/stream/contents/%s?%s",
-		c.baseURL, streamID, params.Encode())
+	streamURL := fmt.Sprintf("%s/stream/contents/%s?%s",
+		c.apiURL, streamID, params.Encode())
 
 	req, err := http.NewRequestWithContext(ctx, "GET", streamURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("create stream contents request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "GoogleLogin auth="+c.authToken)
-
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doAuthenticated(req)
 	if err != nil {
 		return nil, fmt.Errorf("stream contents request: %w", err)
 	}
@@ -405,15 +591,92 @@ func (c *Client) GetStreamContents(ctx context.Context, streamID string, exclude
 	}, nil
 }
 
+// streamPageSize is the number of items requested per page by GetAllStreamContents
+const streamPageSize = 1000
+
+// GetAllStreamContents retrieves a stream by following its continuation tokens until
+// maxTotal articles are collected (0 for no limit) or the stream is exhausted.
+// On error or cancellation the articles fetched so far are returned with the error.
+func (c *Client) GetAllStreamContents(ctx context.Context, streamID string, excludeTypes []string, maxTotal int) ([]Article, error) {
+	var articles []Article
+	err := c.ForEachStreamPage(ctx, streamID, excludeTypes, maxTotal, func(page []Article) error {
+		articles = append(articles, page...)
+		return nil
+	})
+	return articles, err
+}
+
+// ForEachStreamPage follows a stream's continuation tokens like GetAllStreamContents, but
+// hands each page to fn as soon as it arrives instead of collecting the whole stream.
+// It stops at the first error returned by fn.
+func (c *Client) ForEachStreamPage(ctx context.Context, streamID string, excludeTypes []string, maxTotal int, fn func([]Article) error) error {
+	continuation := ""
+	seen := make(map[string]bool)
+	total := 0
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		pageSize := streamPageSize
+		if maxTotal > 0 && maxTotal-total < pageSize {
+			pageSize = maxTotal - total
+		}
+
+		result, err := c.GetStreamContents(ctx, streamID, excludeTypes, pageSize, continuation)
+		if err != nil {
+			return err
+		}
+		items := result.Items
+		if maxTotal > 0 && total+len(items) > maxTotal {
+			items = items[:maxTotal-total]
+		}
+		total += len(items)
+		if err := fn(items); err != nil {
+			return err
+		}
+
+		if maxTotal > 0 && total >= maxTotal {
+			return nil
+		}
+		if result.Continuation == "" || len(result.Items) == 0 {
+			return nil
+		}
+		// A server that hands out the same token again would otherwise loop forever
+		if seen[result.Continuation] {
+			log.Printf("[FreshRSS API] Stream %s repeated continuation token, stopping after %d articles", streamID, total)
+			return nil
+		}
+		seen[result.Continuation] = true
+		continuation = result.Continuation
+	}
+}
+
 // System tags for Google Reader API
 const (
 	TagRead    = "user/-/state/com.google/read"
 	TagStarred = "user/-/state/com.google/starred"
 )
 
-// editTag is a helper function to add or remove tags from items
+// defaultEditTagChunkSize is the number of item IDs sent per edit-tag request. Much larger
+// bodies are rejected with 413 or silently truncated by many FreshRSS/nginx setups.
+const defaultEditTagChunkSize = 250
+
+// SetEditTagChunkSize sets how many item IDs are sent per edit-tag request. Values below 1
+// restore the default.
+func (c *Client) SetEditTagChunkSize(size int) {
+	if size < 1 {
+		size = defaultEditTagChunkSize
+	}
+	c.editTagChunkSize = size
+}
+
+// editTag is a helper function to add or remove tags from items. Large ID lists are split
+// into sequential requests; a failed chunk doesn't stop the others and all failures are
+// returned together.
 func (c *Client) editTag(ctx context.Context, itemIDs []string, addTag string, removeTag string) error {
-	if c.authToken == "" {
+	if c.currentToken() == "" {
 		return fmt.Errorf("not authenticated")
 	}
 
@@ -426,6 +689,27 @@ func (c *Client) editTag(ctx context.Context, itemIDs []string, addTag string, r
 		return fmt.Errorf("get token: %w", err)
 	}
 
+	chunkSize := c.editTagChunkSize
+	if chunkSize < 1 {
+		chunkSize = defaultEditTagChunkSize
+	}
+
+	var errs []error
+	for start := 0; start < len(itemIDs); start += chunkSize {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		end := min(start+chunkSize, len(itemIDs))
+		if err := c.editTagChunk(ctx, token, itemIDs[start:end], addTag, removeTag); err != nil {
+			errs = append(errs, fmt.Errorf("items %d-%d: %w", start+1, end, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// editTagChunk sends a single edit-tag request for itemIDs
+func (c *Client) editTagChunk(ctx context.Context, token string, itemIDs []string, addTag string, removeTag string) error {
 	data := url.Values{}
 	data.Set("T", token)
 
@@ -445,20 +729,19 @@ func (c *Client) editTag(ctx context.Context, itemIDs []string, addTag string, r
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST",
-		c.baseURL+"/reader/api/0/edit-tag",
+		c.apiURL+"/edit-tag",
 		strings.NewReader(data.Encode()))
 	if err != nil {
 		return fmt.Errorf("create edit-tag request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "GoogleLogin auth="+c.authToken)
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
 	log.Printf("[FreshRSS API] edit-tag request: URL=%s addTag=%s removeTag=%s itemIDs=%d",
-		c.baseURL+"/reader/api/0/edit-tag", addTag, removeTag, len(itemIDs))
+		c.apiURL+"/edit-tag", addTag, removeTag, len(itemIDs))
 	log.Printf("[FreshRSS API] Request body: %s", data.Encode())
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doAuthenticated(req)
 	if err != nil {
 		return fmt.Errorf("edit-tag request: %w", err)
 	}
@@ -505,9 +788,52 @@ func (c *Client) UnstarBatch(ctx context.Context, itemIDs []string) error {
 	return c.editTag(ctx, itemIDs, "", TagStarred)
 }
 
+// MarkStreamAsRead marks every item of a stream as read in a single request. A non-zero
+// olderThan limits it to items received before that time, so that items arriving in the
+// meantime stay unread.
+func (c *Client) MarkStreamAsRead(ctx context.Context, streamID string, olderThan time.Time) error {
+	if c.currentToken() == "" {
+		return fmt.Errorf("not authenticated")
+	}
+
+	token, err := c.GetToken(ctx)
+	if err != nil {
+		return fmt.Errorf("get token: %w", err)
+	}
+
+	data := url.Values{}
+	data.Set("T", token)
+	data.Set("s", streamID)
+	if !olderThan.IsZero() {
+		data.Set("ts", fmt.Sprintf("%d", olderThan.UnixMicro()))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST",
+		c.apiURL+"/mark-all-as-read",
+		strings.NewReader(data.Encode()))
+	if err != nil {
+		return fmt.Errorf("create mark-all-as-read request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.doAuthenticated(req)
+	if err != nil {
+		return fmt.Errorf("mark-all-as-read request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("mark-all-as-read failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
 // SubscribeToFeed subscribes to a new feed
 func (c *Client) SubscribeToFeed(ctx context.Context, feedURL, title string) error {
-	if c.authToken == "" {
+	if c.currentToken() == "" {
 		return fmt.Errorf("not authenticated")
 	}
 
@@ -519,25 +845,21 @@ func (c *Client) SubscribeToFeed(ctx context.Context, feedURL, title string) err
 	data := url.Values{}
 	data.Set("T", token)
 	data.Set("s", "feed/"+feedURL)
+	data.Set("ac", "subscribe")
 	if title != "" {
 		data.Set("t", title)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST",
-		c.baseURL+"/reader/api/0/subscription/edit",
+		c.apiURL+"/subscription/edit",
 		strings.NewReader(data.Encode()))
 	if err != nil {
 		return fmt.Errorf("create subscribe request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "GoogleLogin auth="+c.authToken)
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	// Add subscription
-	data.Set("ac", "subscribe")
-	req.Body = io.NopCloser(strings.NewReader(data.Encode()))
-
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.doAuthenticated(req)
 	if err != nil {
 		return fmt.Errorf("subscribe request: %w", err)
 	}
@@ -551,10 +873,149 @@ func (c *Client) SubscribeToFeed(ctx context.Context, feedURL, title string) err
 	return nil
 }
 
+// Unsubscribe removes a feed subscription from the server
+func (c *Client) Unsubscribe(ctx context.Context, feedURL string) error {
+	if c.currentToken() == "" {
+		return fmt.Errorf("not authenticated")
+	}
+
+	token, err := c.GetToken(ctx)
+	if err != nil {
+		return fmt.Errorf("get token: %w", err)
+	}
+
+	data := url.Values{}
+	data.Set("T", token)
+	data.Set("s", "feed/"+feedURL)
+	data.Set("ac", "unsubscribe")
+
+	req, err := http.NewRequestWithContext(ctx, "POST",
+		c.apiURL+"/subscription/edit",
+		strings.NewReader(data.Encode()))
+	if err != nil {
+		return fmt.Errorf("create unsubscribe request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.doAuthenticated(req)
+	if err != nil {
+		return fmt.Errorf("unsubscribe request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unsubscribe from %s failed with status %d (is the feed subscribed?): %s", feedURL, resp.StatusCode, string(body))
+	}
+	if strings.TrimSpace(string(body)) != "OK" {
+		return fmt.Errorf("unsubscribe from %s failed (is the feed subscribed?): %s", feedURL, string(body))
+	}
+
+	return nil
+}
+
+// RenameSubscription changes the title of a feed subscription on the server
+func (c *Client) RenameSubscription(ctx context.Context, feedURL, newTitle string) error {
+	data := url.Values{}
+	data.Set("t", newTitle)
+	return c.editSubscription(ctx, feedURL, data)
+}
+
+// SetSubscriptionCategory moves a feed subscription into the label categoryLabel and out of
+// any other label. An empty categoryLabel only removes the current labels.
+func (c *Client) SetSubscriptionCategory(ctx context.Context, feedURL, categoryLabel string) error {
+	subscriptions, err := c.GetSubscriptions(ctx)
+	if err != nil {
+		return fmt.Errorf("get subscriptions: %w", err)
+	}
+
+	var current *Subscription
+	for i := range subscriptions {
+		if subscriptions[i].URL == feedURL || subscriptions[i].ID == "feed/"+feedURL {
+			current = &subscriptions[i]
+			break
+		}
+	}
+	if current == nil {
+		return fmt.Errorf("feed %s is not subscribed", feedURL)
+	}
+
+	data := url.Values{}
+	labelID := ""
+	if categoryLabel != "" {
+		labelID = "user/-/label/" + categoryLabel
+	}
+	hasLabel := false
+	for _, category := range current.Categories {
+		if category.ID == labelID {
+			hasLabel = true
+			continue
+		}
+		data.Add("r", category.ID)
+	}
+	if labelID != "" && !hasLabel {
+		data.Set("a", labelID)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return c.editSubscription(ctx, feedURL, data)
+}
+
+// editSubscription posts an ac=edit request for a feed subscription with the given
+// parameters (t to rename, a and r to add and remove labels)
+func (c *Client) editSubscription(ctx context.Context, feedURL string, data url.Values) error {
+	if c.currentToken() == "" {
+		return fmt.Errorf("not authenticated")
+	}
+
+	token, err := c.GetToken(ctx)
+	if err != nil {
+		return fmt.Errorf("get token: %w", err)
+	}
+
+	data.Set("T", token)
+	data.Set("s", "feed/"+feedURL)
+	data.Set("ac", "edit")
+
+	req, err := http.NewRequestWithContext(ctx, "POST",
+		c.apiURL+"/subscription/edit",
+		strings.NewReader(data.Encode()))
+	if err != nil {
+		return fmt.Errorf("create subscription edit request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.doAuthenticated(req)
+	if err != nil {
+		return fmt.Errorf("subscription edit request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || strings.TrimSpace(string(body)) != "OK" {
+		return fmt.Errorf("subscription edit for %s failed with status %d: %s", feedURL, resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// maxSyncUnreadArticles caps the unread articles pulled by a single Sync
+const maxSyncUnreadArticles = 10000
+
+// DefaultSyncSaveBatchSize is the number of synced articles saved per transaction when
+// no batch size is configured
+const DefaultSyncSaveBatchSize = 500
+
 // SyncService handles synchronization between MrRSS and FreshRSS
 type SyncService struct {
 	client *Client
 	db     Database
+	// saveBatchSize is the number of articles saved per transaction, so a large sync does
+	// not hold the database lock for its whole duration
+	saveBatchSize int
 }
 
 // Database interface for FreshRSS sync operations
@@ -567,9 +1028,20 @@ type Database interface {
 
 // NewSyncService creates a new sync service
 func NewSyncService(serverURL, username, password string, db Database) *SyncService {
+	var opts ClientOptions
+	saveBatchSize := DefaultSyncSaveBatchSize
+	if settings, ok := db.(DBInterface); ok {
+		opts = ClientOptionsFromSettings(settings)
+		if s, err := settings.GetSetting("freshrss_save_batch_size"); err == nil {
+			if size, err := strconv.Atoi(s); err == nil && size > 0 {
+				saveBatchSize = size
+			}
+		}
+	}
 	return &SyncService{
-		client: NewClient(serverURL, username, password),
-		db:     db,
+		client:        NewClientWithOptions(serverURL, username, password, opts),
+		db:            db,
+		saveBatchSize: saveBatchSize,
 	}
 }
 
@@ -633,14 +1105,6 @@ func (s *SyncService) Sync(ctx context.Context) error {
 		}
 	}
 
-	// Get unread articles from FreshRSS
-	result, err := s.client.GetStreamContents(ctx, "user/-/state/com.google/reading-list",
-		[]string{TagRead}, 100, "") // Get up to 100 unread articles
-	if err != nil {
-		return fmt.Errorf("get unread articles: %w", err)
-	}
-	freshArticles := result.Items
-
 	// Create or get FreshRSS feed for synced articles
 	freshRSSFeedID, err := s.getOrCreateFreshRSSFeed()
 	if err != nil {
@@ -659,33 +1123,59 @@ func (s *SyncService) Sync(ctx context.Context) error {
 		existingArticleMap[article.URL] = true
 	}
 
-	// Convert FreshRSS articles to MrRSS articles (only new ones)
-	mrssArticles := make([]*models.Article, 0, len(freshArticles))
-	for _, freshArt := range freshArticles {
-		// Skip if article already exists
-		if existingArticleMap[freshArt.URL] {
-			continue
-		}
-
-		article := &models.Article{
-			FeedID:      freshRSSFeedID,
-			Title:       freshArt.Title,
-			URL:         freshArt.URL,
-			Summary:     freshArt.Content, // Store FreshRSS content as summary
-			PublishedAt: freshArt.Published,
-			IsRead:      false, // FreshRSS unread articles
-			IsFavorite:  false,
-			IsHidden:    false,
+	// Pull unread articles page by page and save them in batches as they arrive, so
+	// neither memory nor a single transaction grows with the size of the stream
+	var pending []*models.Article
+	var saveErr error
+	saved := 0
+	flush := func(all bool) error {
+		for len(pending) >= s.saveBatchSize || (all && len(pending) > 0) {
+			n := min(len(pending), s.saveBatchSize)
+			if err := s.db.SaveArticles(ctx, pending[:n]); err != nil {
+				saveErr = fmt.Errorf("save articles: %w", err)
+				return saveErr
+			}
+			saved += n
+			pending = pending[n:]
 		}
-		mrssArticles = append(mrssArticles, article)
+		return nil
 	}
 
-	// Save new articles to database
-	if len(mrssArticles) > 0 {
-		if err := s.db.SaveArticles(ctx, mrssArticles); err != nil {
-			return fmt.Errorf("save articles: %w", err)
-		}
-		log.Printf("Synced %d new articles from FreshRSS", len(mrssArticles))
+	err = s.client.ForEachStreamPage(ctx, "user/-/state/com.google/reading-list",
+		[]string{TagRead}, maxSyncUnreadArticles, func(page []Article) error {
+			// Convert FreshRSS articles to MrRSS articles (only new ones)
+			for _, freshArt := range page {
+				// Skip if article already exists or came in an earlier page
+				if existingArticleMap[freshArt.URL] {
+					continue
+				}
+				existingArticleMap[freshArt.URL] = true
+
+				pending = append(pending, &models.Article{
+					FeedID:      freshRSSFeedID,
+					Title:       freshArt.Title,
+					URL:         freshArt.URL,
+					Summary:     freshArt.Content, // Store FreshRSS content as summary
+					PublishedAt: freshArt.Published,
+					IsRead:      false, // FreshRSS unread articles
+					IsFavorite:  false,
+					IsHidden:    false,
+				})
+			}
+			return flush(false)
+		})
+	if saveErr != nil {
+		return saveErr
+	}
+	// Earlier batches are already stored; keep the rest of what was pulled too
+	if err := flush(true); err != nil {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("get unread articles: %w", err)
+	}
+	if saved > 0 {
+		log.Printf("Synced %d new articles from FreshRSS", saved)
 	}
 
 	log.Printf("FreshRSS sync completed successfully")
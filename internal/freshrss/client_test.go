@@ -0,0 +1,580 @@
+package freshrss
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"MrRSS/internal/models"
+)
+
+// fakeReader is a minimal Google Reader API whose tokens can be expired on demand
+type fakeReader struct {
+	mu           sync.Mutex
+	logins       int
+	validToken   string
+	failLogin    bool
+	expireOnEdit bool
+	editBodies   []string
+}
+
+func (f *fakeReader) expire() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.validToken = ""
+}
+
+func (f *fakeReader) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if strings.HasSuffix(r.URL.Path, "/accounts/ClientLogin") {
+		if f.failLogin {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		f.logins++
+		f.validToken = fmt.Sprintf("token%d", f.logins)
+		fmt.Fprintf(w, "SID=sid\nAuth=%s\n", f.validToken)
+		return
+	}
+
+	if f.expireOnEdit && strings.HasSuffix(r.URL.Path, "/edit-tag") {
+		f.expireOnEdit = false
+		f.validToken = ""
+	}
+	if f.validToken == "" || r.Header.Get("Authorization") != "GoogleLogin auth="+f.validToken {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/subscription/list"):
+		fmt.Fprint(w, `{"subscriptions":[{"id":"feed/1","title":"One","url":"https://example.com/feed"}]}`)
+	case strings.HasSuffix(r.URL.Path, "/token"):
+		fmt.Fprint(w, "writetoken")
+	case strings.HasSuffix(r.URL.Path, "/edit-tag"):
+		r.ParseForm()
+		f.editBodies = append(f.editBodies, r.PostForm.Encode())
+		fmt.Fprint(w, "OK")
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestClient_ReloginOnExpiredToken(t *testing.T) {
+	reader := &fakeReader{}
+	server := httptest.NewServer(reader)
+	defer server.Close()
+
+	client := NewClient(server.URL, "user", "pass")
+	ctx := context.Background()
+	if err := client.Login(ctx); err != nil {
+		t.Fatalf("Login error: %v", err)
+	}
+
+	reader.expire()
+	subs, err := client.GetSubscriptions(ctx)
+	if err != nil {
+		t.Fatalf("expected the expired token to be renewed, got %v", err)
+	}
+	if len(subs) != 1 || reader.logins != 2 {
+		t.Fatalf("expected 1 subscription after 1 re-login, got %d subscriptions and %d logins", len(subs), reader.logins)
+	}
+
+	// POST bodies are replayed intact
+	reader.mu.Lock()
+	reader.expireOnEdit = true
+	reader.mu.Unlock()
+	if err := client.MarkAsRead(ctx, []string{"item1"}); err != nil {
+		t.Fatalf("MarkAsRead error: %v", err)
+	}
+	if len(reader.editBodies) != 1 || !strings.Contains(reader.editBodies[0], "i=item1") {
+		t.Fatalf("unexpected edit-tag bodies: %v", reader.editBodies)
+	}
+}
+
+func TestClient_ReloginFailureSurfacesOriginalError(t *testing.T) {
+	reader := &fakeReader{}
+	server := httptest.NewServer(reader)
+	defer server.Close()
+
+	client := NewClient(server.URL, "user", "pass")
+	ctx := context.Background()
+	if err := client.Login(ctx); err != nil {
+		t.Fatalf("Login error: %v", err)
+	}
+
+	reader.expire()
+	reader.mu.Lock()
+	reader.failLogin = true
+	reader.mu.Unlock()
+
+	_, err := client.GetSubscriptions(ctx)
+	if err == nil || !strings.Contains(err.Error(), "subscriptions request failed with status 401") {
+		t.Fatalf("expected the original 401 error, got %v", err)
+	}
+	if reader.logins != 1 {
+		t.Fatalf("expected no successful re-login, got %d logins", reader.logins)
+	}
+}
+
+func TestClient_GetAllStreamContents(t *testing.T) {
+	var requests int
+	repeatToken := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/accounts/ClientLogin") {
+			fmt.Fprint(w, "Auth=token\n")
+			return
+		}
+		requests++
+		// Three pages of two items each, chained by continuation tokens
+		page := 0
+		fmt.Sscanf(r.URL.Query().Get("c"), "page%d", &page)
+		next := ""
+		if page < 2 {
+			next = fmt.Sprintf("page%d", page+1)
+		}
+		if repeatToken {
+			next = "page1"
+		}
+		fmt.Fprintf(w, `{"continuation":%q,"items":[{"id":"item%d-a"},{"id":"item%d-b"}]}`, next, page, page)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user", "pass")
+	ctx := context.Background()
+	if err := client.Login(ctx); err != nil {
+		t.Fatalf("Login error: %v", err)
+	}
+
+	articles, err := client.GetAllStreamContents(ctx, "user/-/state/com.google/reading-list", nil, 0)
+	if err != nil {
+		t.Fatalf("GetAllStreamContents error: %v", err)
+	}
+	if len(articles) != 6 || requests != 3 {
+		t.Fatalf("expected 6 articles in 3 pages, got %d in %d", len(articles), requests)
+	}
+
+	articles, _ = client.GetAllStreamContents(ctx, "user/-/state/com.google/reading-list", nil, 3)
+	if len(articles) != 3 {
+		t.Errorf("expected maxTotal to cap the result at 3, got %d", len(articles))
+	}
+
+	requests = 0
+	repeatToken = true
+	articles, err = client.GetAllStreamContents(ctx, "user/-/state/com.google/reading-list", nil, 0)
+	if err != nil || requests != 2 || len(articles) != 4 {
+		t.Errorf("expected a repeated token to stop after 2 pages, got %d articles in %d requests (err %v)", len(articles), requests, err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := client.GetAllStreamContents(cancelled, "user/-/state/com.google/reading-list", nil, 0); err == nil {
+		t.Error("expected an error for a cancelled context")
+	}
+}
+
+func TestClient_Unsubscribe(t *testing.T) {
+	subscribed := map[string]bool{"feed/https://example.com/feed": true}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/accounts/ClientLogin"):
+			fmt.Fprint(w, "Auth=token\n")
+		case strings.HasSuffix(r.URL.Path, "/token"):
+			fmt.Fprint(w, "writetoken")
+		case strings.HasSuffix(r.URL.Path, "/subscription/edit"):
+			r.ParseForm()
+			if r.PostForm.Get("ac") != "unsubscribe" || r.PostForm.Get("T") != "writetoken" || !subscribed[r.PostForm.Get("s")] {
+				http.Error(w, "Bad Request!", http.StatusBadRequest)
+				return
+			}
+			delete(subscribed, r.PostForm.Get("s"))
+			fmt.Fprint(w, "OK")
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user", "pass")
+	ctx := context.Background()
+	if err := client.Login(ctx); err != nil {
+		t.Fatalf("Login error: %v", err)
+	}
+
+	if err := client.Unsubscribe(ctx, "https://example.com/feed"); err != nil {
+		t.Fatalf("Unsubscribe error: %v", err)
+	}
+	if len(subscribed) != 0 {
+		t.Error("expected the feed to be unsubscribed")
+	}
+	if err := client.Unsubscribe(ctx, "https://example.com/feed"); err == nil || !strings.Contains(err.Error(), "status 400") {
+		t.Errorf("expected an error for a feed that is not subscribed, got %v", err)
+	}
+}
+
+func TestClient_EditSubscription(t *testing.T) {
+	var edits []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/accounts/ClientLogin"):
+			fmt.Fprint(w, "Auth=token\n")
+		case strings.HasSuffix(r.URL.Path, "/token"):
+			fmt.Fprint(w, "writetoken")
+		case strings.HasSuffix(r.URL.Path, "/subscription/list"):
+			fmt.Fprint(w, `{"subscriptions":[{"id":"feed/12","url":"https://example.com/feed","categories":[{"id":"user/-/label/Old","label":"Old"}]}]}`)
+		case strings.HasSuffix(r.URL.Path, "/subscription/edit"):
+			if ct := r.Header.Get("Content-Type"); ct != "application/x-www-form-urlencoded" {
+				t.Errorf("unexpected content type %q", ct)
+			}
+			body, _ := io.ReadAll(r.Body)
+			edits = append(edits, string(body))
+			fmt.Fprint(w, "OK")
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user", "pass")
+	ctx := context.Background()
+	if err := client.Login(ctx); err != nil {
+		t.Fatalf("Login error: %v", err)
+	}
+
+	if err := client.RenameSubscription(ctx, "https://example.com/feed", "New title"); err != nil {
+		t.Fatalf("RenameSubscription error: %v", err)
+	}
+	if err := client.SetSubscriptionCategory(ctx, "https://example.com/feed", "Tech News"); err != nil {
+		t.Fatalf("SetSubscriptionCategory error: %v", err)
+	}
+	if err := client.SetSubscriptionCategory(ctx, "https://example.com/feed", "Old"); err != nil {
+		t.Fatalf("SetSubscriptionCategory error: %v", err)
+	}
+	if err := client.SetSubscriptionCategory(ctx, "https://example.com/other", "Tech"); err == nil {
+		t.Error("expected an error for a feed that is not subscribed")
+	}
+
+	want := []string{
+		"T=writetoken&ac=edit&s=feed%2Fhttps%3A%2F%2Fexample.com%2Ffeed&t=New+title",
+		"T=writetoken&a=user%2F-%2Flabel%2FTech+News&ac=edit&r=user%2F-%2Flabel%2FOld&s=feed%2Fhttps%3A%2F%2Fexample.com%2Ffeed",
+	}
+	// Moving into the label the feed already has is a no-op
+	if len(edits) != len(want) {
+		t.Fatalf("expected %d edit requests, got %d: %v", len(want), len(edits), edits)
+	}
+	for i := range want {
+		if edits[i] != want[i] {
+			t.Errorf("edit %d: got body %s, want %s", i, edits[i], want[i])
+		}
+	}
+}
+
+func TestClient_MarkStreamAsRead(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/accounts/ClientLogin"):
+			fmt.Fprint(w, "Auth=token\n")
+		case strings.HasSuffix(r.URL.Path, "/token"):
+			fmt.Fprint(w, "writetoken")
+		case strings.HasSuffix(r.URL.Path, "/mark-all-as-read"):
+			b, _ := io.ReadAll(r.Body)
+			body = string(b)
+			fmt.Fprint(w, "OK")
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user", "pass")
+	ctx := context.Background()
+	if err := client.Login(ctx); err != nil {
+		t.Fatalf("Login error: %v", err)
+	}
+
+	olderThan := time.Unix(1700000000, 123456000)
+	if err := client.MarkStreamAsRead(ctx, "feed/12", olderThan); err != nil {
+		t.Fatalf("MarkStreamAsRead error: %v", err)
+	}
+	if want := "T=writetoken&s=feed%2F12&ts=1700000000123456"; body != want {
+		t.Errorf("got body %s, want %s", body, want)
+	}
+
+	if err := client.MarkStreamAsRead(ctx, "feed/12", time.Time{}); err != nil {
+		t.Fatalf("MarkStreamAsRead error: %v", err)
+	}
+	if want := "T=writetoken&s=feed%2F12"; body != want {
+		t.Errorf("got body %s without a timestamp, want %s", body, want)
+	}
+}
+
+func TestClient_EditTagChunksLargeBatches(t *testing.T) {
+	reader := &fakeReader{}
+	server := httptest.NewServer(reader)
+	defer server.Close()
+
+	client := NewClient(server.URL, "user", "pass")
+	ctx := context.Background()
+	if err := client.Login(ctx); err != nil {
+		t.Fatalf("Login error: %v", err)
+	}
+
+	ids := make([]string, 600)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("item%d", i)
+	}
+	if err := client.MarkAsReadBatch(ctx, ids); err != nil {
+		t.Fatalf("MarkAsReadBatch error: %v", err)
+	}
+	if len(reader.editBodies) != 3 {
+		t.Fatalf("expected 3 edit-tag requests, got %d", len(reader.editBodies))
+	}
+	for i, want := range []int{250, 250, 100} {
+		if got := strings.Count(reader.editBodies[i], "i=item"); got != want {
+			t.Errorf("request %d: expected %d items, got %d", i, want, got)
+		}
+	}
+	if !strings.Contains(reader.editBodies[2], "i=item599") {
+		t.Error("expected the last chunk to carry the last item")
+	}
+
+	// A configured chunk size is honoured
+	reader.editBodies = nil
+	client.SetEditTagChunkSize(400)
+	if err := client.StarBatch(ctx, ids); err != nil {
+		t.Fatalf("StarBatch error: %v", err)
+	}
+	if len(reader.editBodies) != 2 {
+		t.Errorf("expected 2 edit-tag requests with a chunk size of 400, got %d", len(reader.editBodies))
+	}
+}
+
+func TestClient_EditTagAggregatesChunkErrors(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/accounts/ClientLogin"):
+			fmt.Fprint(w, "Auth=token\n")
+		case strings.HasSuffix(r.URL.Path, "/token"):
+			fmt.Fprint(w, "writetoken")
+		case strings.HasSuffix(r.URL.Path, "/edit-tag"):
+			requests++
+			if requests == 1 {
+				http.Error(w, "Request Entity Too Large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			fmt.Fprint(w, "OK")
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user", "pass")
+	ctx := context.Background()
+	if err := client.Login(ctx); err != nil {
+		t.Fatalf("Login error: %v", err)
+	}
+	client.SetEditTagChunkSize(2)
+
+	err := client.MarkAsReadBatch(ctx, []string{"a", "b", "c", "d", "e"})
+	if err == nil || !strings.Contains(err.Error(), "items 1-2") || !strings.Contains(err.Error(), "status 413") {
+		t.Errorf("expected the failed chunk to be reported, got %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("expected the remaining chunks to still be sent, got %d requests", requests)
+	}
+}
+
+type fakeSettings map[string]string
+
+func (f fakeSettings) GetSetting(key string) (string, error) {
+	return f[key], nil
+}
+
+func (f fakeSettings) GetEncryptedSetting(key string) (string, error) {
+	return f[key], nil
+}
+
+func TestClientOptionsFromSettings(t *testing.T) {
+	if opts := ClientOptionsFromSettings(fakeSettings{}); opts.InsecureSkipVerify || opts.Timeout != 0 {
+		t.Errorf("expected secure defaults without settings, got %+v", opts)
+	}
+	if opts := ClientOptionsFromSettings(fakeSettings{"freshrss_timeout_seconds": "abc"}); opts.Timeout != 0 {
+		t.Errorf("expected an invalid timeout to be ignored, got %v", opts.Timeout)
+	}
+
+	opts := ClientOptionsFromSettings(fakeSettings{"freshrss_insecure_tls": "true", "freshrss_timeout_seconds": "120"})
+	if !opts.InsecureSkipVerify || opts.Timeout != 2*time.Minute {
+		t.Errorf("unexpected options %+v", opts)
+	}
+	if c := NewClientWithOptions("https://example.com", "u", "p", opts); c.httpClient.Timeout != 2*time.Minute {
+		t.Errorf("expected the timeout to be applied, got %v", c.httpClient.Timeout)
+	}
+	if c := NewClient("https://example.com", "u", "p"); c.httpClient.Timeout != DefaultTimeout {
+		t.Errorf("expected the default timeout, got %v", c.httpClient.Timeout)
+	}
+}
+
+func TestClient_InsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "Auth=token\n")
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	if err := NewClient(server.URL, "user", "pass").Login(ctx); err == nil {
+		t.Error("expected a self-signed certificate to be rejected by default")
+	}
+	insecure := NewClientWithOptions(server.URL, "user", "pass", ClientOptions{InsecureSkipVerify: true})
+	if err := insecure.Login(ctx); err != nil {
+		t.Errorf("expected a self-signed certificate to be accepted, got %v", err)
+	}
+}
+
+func TestClientOptionsFromSettings_Proxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		fmt.Fprint(w, "Auth=token\n")
+	}))
+	defer proxy.Close()
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	settings := fakeSettings{
+		"proxy_enabled": "true",
+		"proxy_type":    "http",
+		"proxy_host":    proxyURL.Hostname(),
+		"proxy_port":    proxyURL.Port(),
+	}
+	client := NewClientWithOptions("http://freshrss.invalid", "user", "pass", ClientOptionsFromSettings(settings))
+	if err := client.Login(context.Background()); err != nil {
+		t.Fatalf("Login error: %v", err)
+	}
+	if len(proxied) != 1 || proxied[0] != "http://freshrss.invalid/api/greader.php/accounts/ClientLogin" {
+		t.Errorf("expected the login to go through the proxy, got %v", proxied)
+	}
+
+	// Without an enabled proxy requests go direct
+	settings["proxy_enabled"] = "false"
+	client = NewClientWithOptions("http://freshrss.invalid", "user", "pass", ClientOptionsFromSettings(settings))
+	if err := client.Login(context.Background()); err == nil || len(proxied) != 1 {
+		t.Errorf("expected a direct request that fails to resolve, got err %v and %d proxied requests", err, len(proxied))
+	}
+}
+
+func TestServerEndpoints(t *testing.T) {
+	tests := []struct {
+		serverType ServerType
+		serverURL  string
+		login, api string
+	}{
+		{ServerTypeFreshRSS, "https://rss.example.com/", "https://rss.example.com/api/greader.php/accounts/ClientLogin", "https://rss.example.com/api/greader.php/reader/api/0"},
+		{ServerTypeFreshRSS, "https://rss.example.com/api/greader.php", "https://rss.example.com/api/greader.php/accounts/ClientLogin", "https://rss.example.com/api/greader.php/reader/api/0"},
+		{ServerTypeInoreader, "https://www.inoreader.com/reader/api/0", "https://www.inoreader.com/accounts/ClientLogin", "https://www.inoreader.com/reader/api/0"},
+		{ServerTypeInoreader, "", "https://www.inoreader.com/accounts/ClientLogin", "https://www.inoreader.com/reader/api/0"},
+		{ServerTypeTheOldReader, "https://theoldreader.com", "https://theoldreader.com/accounts/ClientLogin", "https://theoldreader.com/reader/api/0"},
+	}
+	for _, tt := range tests {
+		login, api := serverEndpoints(tt.serverType, tt.serverURL)
+		if login != tt.login || api != tt.api {
+			t.Errorf("serverEndpoints(%q, %q) = %q, %q; want %q, %q", tt.serverType, tt.serverURL, login, api, tt.login, tt.api)
+		}
+	}
+
+	if got := ParseServerType("Inoreader"); got != ServerTypeInoreader {
+		t.Errorf("ParseServerType(Inoreader) = %q", got)
+	}
+	if got := ParseServerType("unknown"); got != ServerTypeFreshRSS {
+		t.Errorf("expected unknown server types to default to FreshRSS, got %q", got)
+	}
+	if opts := ClientOptionsFromSettings(fakeSettings{"freshrss_server_type": "theoldreader"}); opts.ServerType != ServerTypeTheOldReader {
+		t.Errorf("unexpected server type %q", opts.ServerType)
+	}
+}
+
+// fakeSyncDB records the batches SyncService saves
+type fakeSyncDB struct {
+	fakeSettings
+	feeds   []models.Feed
+	batches []int
+	saved   map[string]bool
+}
+
+func (f *fakeSyncDB) GetFeeds() ([]models.Feed, error) { return f.feeds, nil }
+
+func (f *fakeSyncDB) AddFeed(feed *models.Feed) (int64, error) {
+	feed.ID = int64(len(f.feeds) + 1)
+	f.feeds = append(f.feeds, *feed)
+	return feed.ID, nil
+}
+
+func (f *fakeSyncDB) SaveArticles(_ context.Context, articles []*models.Article) error {
+	f.batches = append(f.batches, len(articles))
+	for _, a := range articles {
+		f.saved[a.URL] = true
+	}
+	return nil
+}
+
+func (f *fakeSyncDB) GetArticles(string, int64, string, bool, int, int) ([]models.Article, error) {
+	return []models.Article{{URL: "https://example.com/existing"}}, nil
+}
+
+func TestSyncService_SavesInBatchesPerPage(t *testing.T) {
+	var pages []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/accounts/ClientLogin"):
+			fmt.Fprint(w, "Auth=token\n")
+		case strings.HasSuffix(r.URL.Path, "/subscription/list"):
+			fmt.Fprint(w, `{"subscriptions":[]}`)
+		case strings.Contains(r.URL.Path, "/stream/contents/"):
+			// Two pages of three items; the second repeats one item and one already stored
+			page := 0
+			fmt.Sscanf(r.URL.Query().Get("c"), "page%d", &page)
+			pages = append(pages, page)
+			if page == 0 {
+				fmt.Fprint(w, `{"continuation":"page1","items":[
+					{"id":"a","canonical":[{"href":"https://example.com/a"}]},
+					{"id":"b","canonical":[{"href":"https://example.com/b"}]},
+					{"id":"c","canonical":[{"href":"https://example.com/c"}]}]}`)
+				return
+			}
+			fmt.Fprint(w, `{"items":[
+				{"id":"c","canonical":[{"href":"https://example.com/c"}]},
+				{"id":"d","canonical":[{"href":"https://example.com/d"}]},
+				{"id":"e","canonical":[{"href":"https://example.com/existing"}]}]}`)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer server.Close()
+
+	db := &fakeSyncDB{fakeSettings: fakeSettings{"freshrss_save_batch_size": "2"}, saved: map[string]bool{}}
+	sync := NewSyncService(server.URL, "user", "pass", db)
+	if err := sync.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync error: %v", err)
+	}
+
+	if len(pages) != 2 {
+		t.Fatalf("expected 2 stream pages, got %v", pages)
+	}
+	// a, b saved after the first page; c and d after the second
+	if len(db.batches) != 2 || db.batches[0] != 2 || db.batches[1] != 2 {
+		t.Errorf("expected two batches of 2, got %v", db.batches)
+	}
+	for _, u := range []string{"https://example.com/a", "https://example.com/b", "https://example.com/c", "https://example.com/d"} {
+		if !db.saved[u] {
+			t.Errorf("expected %s to be saved", u)
+		}
+	}
+	if db.saved["https://example.com/existing"] {
+		t.Error("expected an already stored article to be skipped")
+	}
+
+	if s := NewSyncService(server.URL, "user", "pass", &fakeSyncDB{fakeSettings: fakeSettings{}}); s.saveBatchSize != DefaultSyncSaveBatchSize {
+		t.Errorf("expected the default batch size, got %d", s.saveBatchSize)
+	}
+}
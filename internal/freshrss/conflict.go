@@ -0,0 +1,120 @@
+package freshrss
+
+import (
+	"log"
+	"time"
+
+	"MrRSS/internal/database"
+)
+
+// ConflictStrategy decides which state an article keeps when its read or star state was
+// changed locally and on the server between syncs
+type ConflictStrategy string
+
+const (
+	// ConflictRemoteWins applies the server's state and drops the local change
+	ConflictRemoteWins ConflictStrategy = "remote_wins"
+	// ConflictLocalWins keeps the local state, which the next push sends to the server
+	ConflictLocalWins ConflictStrategy = "local_wins"
+	// ConflictNewestWins keeps whichever change was made last. The server's change is dated
+	// to the previous sync, so in practice it acts like ConflictLocalWins, see newestWins
+	ConflictNewestWins ConflictStrategy = "newest_wins"
+)
+
+// ParseConflictStrategy parses the sync_conflict_strategy setting. Unknown values use
+// ConflictRemoteWins, which matches how sync behaved before the setting existed.
+func ParseConflictStrategy(value string) ConflictStrategy {
+	switch ConflictStrategy(value) {
+	case ConflictLocalWins, ConflictNewestWins:
+		return ConflictStrategy(value)
+	default:
+		return ConflictRemoteWins
+	}
+}
+
+// syncedState describes one of the article states kept in sync with the server
+type syncedState struct {
+	name    string // Column name, used in logs
+	actions []database.SyncAction
+	local   func(a *database.Article) bool
+	changed func(a *database.Article) time.Time
+	set     func(db *database.DB, id int64, value bool) error
+}
+
+var (
+	readState = syncedState{
+		name:    "is_read",
+		actions: []database.SyncAction{database.SyncActionMarkRead, database.SyncActionMarkUnread},
+		local:   func(a *database.Article) bool { return a.IsRead },
+		changed: func(a *database.Article) time.Time { return a.ReadChangedAt },
+		set:     (*database.DB).MarkArticleRead,
+	}
+	starState = syncedState{
+		name:    "is_favorite",
+		actions: []database.SyncAction{database.SyncActionStar, database.SyncActionUnstar},
+		local:   func(a *database.Article) bool { return a.IsFavorite },
+		changed: func(a *database.Article) time.Time { return a.FavoriteChangedAt },
+		set:     (*database.DB).SetArticleFavorite,
+	}
+)
+
+// applyRemoteState brings the server's value of a state to the local article. When the
+// article has a local change of that state that isn't pushed yet, the two conflict and the
+// configured strategy picks the winner. It reports whether the local article changed.
+func (s *BidirectionalSyncService) applyRemoteState(article *database.Article, state syncedState, remote bool) (bool, error) {
+	if state.local(article) == remote {
+		return false, nil
+	}
+
+	pending, err := s.db.GetPendingSyncChangesForArticle(article.ID, state.actions...)
+	if err != nil {
+		return false, err
+	}
+	if len(pending) > 0 {
+		localChanged := state.changed(article)
+		if localChanged.IsZero() {
+			localChanged = pending[len(pending)-1].CreatedAt
+		}
+
+		keepLocal := s.keepLocalChange(localChanged)
+		winner := "remote"
+		if keepLocal {
+			winner = "local"
+		}
+		log.Printf("[Sync Conflict] Article %d %s: local=%v (changed %s), remote=%v - %s wins (%s)",
+			article.ID, state.name, state.local(article), localChanged.Format(time.RFC3339), remote, winner, s.conflictStrategy)
+		if keepLocal {
+			return false, nil
+		}
+		if err := s.db.ClearPendingSyncForArticle(article.ID, state.actions...); err != nil {
+			return false, err
+		}
+	}
+
+	if err := state.set(s.db, article.ID, remote); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// keepLocalChange decides a conflict with a local change made at localChanged
+func (s *BidirectionalSyncService) keepLocalChange(localChanged time.Time) bool {
+	switch s.conflictStrategy {
+	case ConflictLocalWins:
+		return true
+	case ConflictNewestWins:
+		return newestWins(localChanged, s.lastSyncTime)
+	default:
+		return false
+	}
+}
+
+// newestWins reports whether a local change is newer than the server's. The Google Reader
+// API only lists which articles are read or starred, not when that changed, so the
+// server's change is dated to the previous sync: a local change made since then is taken
+// as the newer one, while an older local change that is still unpushed, one whose push
+// failed, loses to the server. Since pushes normally go out with every sync, this is
+// nearly always ConflictLocalWins; the setting's description says so.
+func newestWins(localChanged, lastSync time.Time) bool {
+	return lastSync.IsZero() || localChanged.After(lastSync)
+}
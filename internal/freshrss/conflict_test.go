@@ -0,0 +1,119 @@
+package freshrss
+
+import (
+	"testing"
+	"time"
+
+	"MrRSS/internal/database"
+	"MrRSS/internal/models"
+)
+
+func TestParseConflictStrategy(t *testing.T) {
+	cases := map[string]ConflictStrategy{
+		"local_wins":  ConflictLocalWins,
+		"newest_wins": ConflictNewestWins,
+		"remote_wins": ConflictRemoteWins,
+		"":            ConflictRemoteWins,
+		"bogus":       ConflictRemoteWins,
+	}
+	for value, want := range cases {
+		if got := ParseConflictStrategy(value); got != want {
+			t.Errorf("ParseConflictStrategy(%q) = %s, want %s", value, got, want)
+		}
+	}
+}
+
+// newConflictTestArticle creates a FreshRSS article that was marked read locally while
+// the change couldn't be pushed, so it waits in the sync queue
+func newConflictTestArticle(t *testing.T) (*database.DB, *database.Article) {
+	t.Helper()
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	if err := db.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	_ = db.SetSetting("freshrss_enabled", "true")
+
+	feedID, err := db.AddFeed(&models.Feed{Title: "Remote", URL: "https://example.com/feed", IsFreshRSSSource: true})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+	const url = "https://example.com/post"
+	if err := db.SaveArticle(&models.Article{FeedID: feedID, Title: "Post", URL: url, PublishedAt: time.Now()}); err != nil {
+		t.Fatalf("SaveArticle: %v", err)
+	}
+	article, err := db.GetArticleByURL(url)
+	if err != nil {
+		t.Fatalf("GetArticleByURL: %v", err)
+	}
+
+	req, err := db.MarkArticleReadWithSync(article.ID, true)
+	if err != nil || req == nil {
+		t.Fatalf("MarkArticleReadWithSync: %v, %v", req, err)
+	}
+	if err := db.EnqueueSyncChange(req.ArticleID, req.ArticleURL, req.Action); err != nil {
+		t.Fatalf("EnqueueSyncChange: %v", err)
+	}
+	if article, err = db.GetArticleByURL(url); err != nil {
+		t.Fatalf("GetArticleByURL: %v", err)
+	}
+	if article.ReadChangedAt.IsZero() {
+		t.Fatal("expected the local change time to be recorded")
+	}
+	return db, article
+}
+
+func TestApplyRemoteStateConflict(t *testing.T) {
+	cases := []struct {
+		name      string
+		strategy  ConflictStrategy
+		lastSync  time.Time
+		wantRead  bool
+		wantQueue int
+	}{
+		{"remote wins", ConflictRemoteWins, time.Time{}, false, 0},
+		{"local wins", ConflictLocalWins, time.Time{}, true, 1},
+		{"newest wins, local change since last sync", ConflictNewestWins, time.Now().Add(-time.Hour), true, 1},
+		{"newest wins, local change before last sync", ConflictNewestWins, time.Now().Add(time.Hour), false, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, article := newConflictTestArticle(t)
+			s := &BidirectionalSyncService{db: db, conflictStrategy: tc.strategy, lastSyncTime: tc.lastSync}
+
+			// The server still has the article unread
+			if _, err := s.applyRemoteState(article, readState, false); err != nil {
+				t.Fatalf("applyRemoteState: %v", err)
+			}
+
+			got, _ := db.GetArticleByURL(article.URL)
+			if got.IsRead != tc.wantRead {
+				t.Errorf("expected is_read=%v, got %v", tc.wantRead, got.IsRead)
+			}
+			pending, _ := db.GetPendingSyncChangesForArticle(article.ID)
+			if len(pending) != tc.wantQueue {
+				t.Errorf("expected %d pending changes, got %d", tc.wantQueue, len(pending))
+			}
+		})
+	}
+}
+
+func TestApplyRemoteStateWithoutConflict(t *testing.T) {
+	db, article := newConflictTestArticle(t)
+	s := &BidirectionalSyncService{db: db, conflictStrategy: ConflictLocalWins}
+
+	// Star state has no pending local change, so the server's state is applied
+	changed, err := s.applyRemoteState(article, starState, true)
+	if err != nil || !changed {
+		t.Fatalf("expected the remote star to be applied, got %v, %v", changed, err)
+	}
+	if got, _ := db.GetArticleByURL(article.URL); !got.IsFavorite {
+		t.Error("expected the article to be starred")
+	}
+	// The unrelated pending read change is kept
+	if pending, _ := db.GetPendingSyncChangesForArticle(article.ID); len(pending) != 1 {
+		t.Errorf("expected the read change to stay queued, got %d", len(pending))
+	}
+}
@@ -0,0 +1,110 @@
+package freshrss
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"MrRSS/internal/database"
+)
+
+// DefaultPushDelay is how long read and star changes are collected before they are pushed
+const DefaultPushDelay = 2 * time.Second
+
+// PushFunc pushes a batch of article state changes to the server
+type PushFunc func(ctx context.Context, changes []database.SyncRequest) error
+
+// pushKey identifies one article state that changes can cancel out on
+type pushKey struct {
+	articleID int64
+	starred   bool // Star state rather than read state
+}
+
+// pendingPush is the first and the latest change of an article state in the window
+type pendingPush struct {
+	first database.SyncRequest
+	last  database.SyncRequest
+}
+
+// PushBatcher collects read and star changes for a short window and pushes them in one
+// batch, so marking articles read while scrolling doesn't send a request per article.
+// Changes that undo each other within the window, like read then unread, are dropped.
+type PushBatcher struct {
+	push PushFunc
+
+	mu      sync.Mutex
+	pending map[pushKey]*pendingPush
+	order   []pushKey
+	timer   *time.Timer
+}
+
+// NewPushBatcher creates a batcher that hands each batch to push
+func NewPushBatcher(push PushFunc) *PushBatcher {
+	return &PushBatcher{
+		push:    push,
+		pending: make(map[pushKey]*pendingPush),
+	}
+}
+
+// Add queues a change. The batch is pushed delay after the first change of the window.
+func (b *PushBatcher) Add(change database.SyncRequest, delay time.Duration) {
+	key := pushKey{
+		articleID: change.ArticleID,
+		starred:   change.Action == database.SyncActionStar || change.Action == database.SyncActionUnstar,
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if p, ok := b.pending[key]; ok {
+		p.last = change
+	} else {
+		b.pending[key] = &pendingPush{first: change, last: change}
+		b.order = append(b.order, key)
+	}
+	if b.timer == nil {
+		b.timer = time.AfterFunc(delay, func() { b.Flush(context.Background()) })
+	}
+}
+
+// Flush pushes the queued changes now. It is called when the window ends and on shutdown.
+func (b *PushBatcher) Flush(ctx context.Context) {
+	changes := b.take()
+	if len(changes) == 0 {
+		return
+	}
+	if err := b.push(ctx, changes); err != nil {
+		log.Printf("[Push Batch] Failed to push %d changes: %v", len(changes), err)
+	}
+}
+
+// Pending returns the number of queued article states
+func (b *PushBatcher) Pending() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.pending)
+}
+
+// take empties the queue and returns the net changes in the order they were first made
+func (b *PushBatcher) take() []database.SyncRequest {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+
+	var changes []database.SyncRequest
+	for _, key := range b.order {
+		p := b.pending[key]
+		// Toggles alternate, so a last change that differs from the first one restores
+		// the state the article had before the window
+		if p.last.Action != p.first.Action {
+			continue
+		}
+		changes = append(changes, p.last)
+	}
+	b.pending = make(map[pushKey]*pendingPush)
+	b.order = nil
+	return changes
+}
@@ -0,0 +1,73 @@
+package freshrss
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"MrRSS/internal/database"
+)
+
+func TestPushBatcherCoalescesChanges(t *testing.T) {
+	var pushed [][]database.SyncRequest
+	b := NewPushBatcher(func(_ context.Context, changes []database.SyncRequest) error {
+		pushed = append(pushed, changes)
+		return nil
+	})
+
+	read := func(id int64) database.SyncRequest {
+		return database.SyncRequest{ArticleID: id, Action: database.SyncActionMarkRead}
+	}
+	unread := func(id int64) database.SyncRequest {
+		return database.SyncRequest{ArticleID: id, Action: database.SyncActionMarkUnread}
+	}
+	star := database.SyncRequest{ArticleID: 1, Action: database.SyncActionStar}
+
+	b.Add(read(1), time.Hour)
+	b.Add(read(2), time.Hour)
+	b.Add(unread(2), time.Hour) // Cancels out
+	b.Add(star, time.Hour)      // Star state is separate from read state
+	b.Add(read(3), time.Hour)
+	b.Add(unread(3), time.Hour)
+	b.Add(read(3), time.Hour) // Net change is read
+	if b.Pending() != 4 {
+		t.Fatalf("expected 4 pending article states, got %d", b.Pending())
+	}
+
+	b.Flush(context.Background())
+	want := []database.SyncRequest{read(1), star, read(3)}
+	if len(pushed) != 1 || !reflect.DeepEqual(pushed[0], want) {
+		t.Fatalf("expected one batch %v, got %v", want, pushed)
+	}
+	if b.Pending() != 0 {
+		t.Errorf("expected an empty queue after the flush, got %d", b.Pending())
+	}
+
+	// Nothing left, nothing pushed
+	b.Flush(context.Background())
+	if len(pushed) != 1 {
+		t.Errorf("expected no push for an empty queue, got %v", pushed)
+	}
+}
+
+func TestPushBatcherFlushesAfterDelay(t *testing.T) {
+	done := make(chan []database.SyncRequest, 1)
+	b := NewPushBatcher(func(_ context.Context, changes []database.SyncRequest) error {
+		done <- changes
+		return errors.New("server down") // Logged; retries are up to the push function
+	})
+
+	b.Add(database.SyncRequest{ArticleID: 7, Action: database.SyncActionMarkRead}, 10*time.Millisecond)
+	b.Add(database.SyncRequest{ArticleID: 8, Action: database.SyncActionMarkRead}, 10*time.Millisecond)
+
+	select {
+	case changes := <-done:
+		if len(changes) != 2 {
+			t.Errorf("expected both changes in one batch, got %v", changes)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the batch to be pushed after the delay")
+	}
+}
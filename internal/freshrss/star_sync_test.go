@@ -0,0 +1,130 @@
+package freshrss
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"MrRSS/internal/database"
+	"MrRSS/internal/models"
+)
+
+// newStarSyncTestDB creates a database with two favorited FreshRSS articles
+func newStarSyncTestDB(t *testing.T) (*database.DB, []*database.Article) {
+	t.Helper()
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("NewDB: %v", err)
+	}
+	if err := db.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+
+	feedID, err := db.AddFeed(&models.Feed{Title: "Remote", URL: "https://example.com/feed", IsFreshRSSSource: true})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+	var articles []*database.Article
+	for i := 1; i <= 2; i++ {
+		url := fmt.Sprintf("https://example.com/post/%d", i)
+		if err := db.SaveArticle(&models.Article{FeedID: feedID, Title: fmt.Sprintf("Post %d", i), URL: url, PublishedAt: time.Now(), IsFavorite: true}); err != nil {
+			t.Fatalf("SaveArticle: %v", err)
+		}
+		if _, err := db.Exec(`UPDATE articles SET freshrss_item_id = ? WHERE url = ?`, fmt.Sprintf("item%d", i), url); err != nil {
+			t.Fatalf("set item id: %v", err)
+		}
+		article, err := db.GetArticleByURL(url)
+		if err != nil {
+			t.Fatalf("GetArticleByURL: %v", err)
+		}
+		articles = append(articles, article)
+	}
+	return db, articles
+}
+
+func TestUnstarRemovedFavorites(t *testing.T) {
+	db, articles := newStarSyncTestDB(t)
+	s := &BidirectionalSyncService{db: db, conflictStrategy: ConflictLocalWins}
+
+	// The second article was starred locally and the star isn't pushed yet
+	if err := db.EnqueueSyncChange(articles[1].ID, articles[1].URL, database.SyncActionStar); err != nil {
+		t.Fatalf("EnqueueSyncChange: %v", err)
+	}
+
+	unstarred, err := s.unstarRemovedFavorites(nil)
+	if err != nil || unstarred != 1 {
+		t.Fatalf("unstarRemovedFavorites = %d, %v; want 1", unstarred, err)
+	}
+	for i, want := range []bool{false, true} {
+		article, _ := db.GetArticleByURL(articles[i].URL)
+		if article.IsFavorite != want {
+			t.Errorf("article %d: IsFavorite = %v, want %v", i+1, article.IsFavorite, want)
+		}
+	}
+
+	// A star still on the server is kept
+	if unstarred, _ = s.unstarRemovedFavorites([]Article{{URL: articles[1].URL}}); unstarred != 0 {
+		t.Errorf("expected no changes for starred articles, got %d", unstarred)
+	}
+
+	// When the server wins, the queued star is dropped as well
+	s.conflictStrategy = ConflictRemoteWins
+	if unstarred, _ = s.unstarRemovedFavorites(nil); unstarred != 1 {
+		t.Errorf("expected the queued star to lose to the server, got %d changes", unstarred)
+	}
+	if count, _ := db.GetPendingSyncCount(); count != 0 {
+		t.Errorf("expected the queued star to be cleared, got %d pending", count)
+	}
+}
+
+func TestPushPendingItemsMarksFailedBatch(t *testing.T) {
+	db, articles := newStarSyncTestDB(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/accounts/ClientLogin"):
+			fmt.Fprint(w, "SID=sid\nAuth=token\n")
+		case strings.HasSuffix(r.URL.Path, "/token"):
+			fmt.Fprint(w, "writetoken")
+		case strings.HasSuffix(r.URL.Path, "/edit-tag"):
+			r.ParseForm()
+			if r.PostForm.Get("a") == TagStarred {
+				http.Error(w, "boom", http.StatusInternalServerError)
+				return
+			}
+			fmt.Fprint(w, "OK")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	s := NewBidirectionalSyncService(server.URL, "user", "pass", db)
+	if err := s.client.Login(context.Background()); err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+
+	_ = db.EnqueueSyncChange(articles[0].ID, articles[0].URL, database.SyncActionStar)
+	_ = db.EnqueueSyncChange(articles[1].ID, articles[1].URL, database.SyncActionMarkRead)
+	pending, err := db.GetPendingSyncChanges(10)
+	if err != nil || len(pending) != 2 {
+		t.Fatalf("GetPendingSyncChanges = %v, %v", pending, err)
+	}
+
+	pushed, err := s.pushPendingItems(context.Background(), pending)
+	if err == nil || pushed != 1 {
+		t.Fatalf("pushPendingItems = %d, %v; want 1 pushed and the star error", pushed, err)
+	}
+
+	failed, err := db.GetFailedSyncItems(10)
+	if err != nil || len(failed) != 1 || failed[0].Action != database.SyncActionStar {
+		t.Fatalf("expected the star change to be marked failed, got %+v, %v", failed, err)
+	}
+	if count, _ := db.GetPendingSyncCount(); count != 1 {
+		t.Errorf("expected the failed change to stay queued, got %d pending", count)
+	}
+}
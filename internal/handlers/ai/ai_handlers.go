@@ -11,6 +11,7 @@ import (
 	"MrRSS/internal/ai"
 	"MrRSS/internal/config"
 	"MrRSS/internal/handlers/core"
+	"MrRSS/internal/utils"
 )
 
 // TestResult represents the result of AI configuration test
@@ -119,7 +120,7 @@ func HandleTestAIConfig(h *core.Handler, w http.ResponseWriter, r *http.Request)
 		Model:    model,
 		Timeout:  30 * time.Second,
 	}
-	client := ai.NewClientWithHTTPClient(clientConfig, httpClient)
+	client := ai.NewClientWithHTTPClient(clientConfig, httpClient).WithContext(r.Context())
 
 	// Try a simple test request
 	_, err = client.Request("", "test")
@@ -167,6 +168,156 @@ func HandleGetAITestInfo(h *core.Handler, w http.ResponseWriter, r *http.Request
 	json.NewEncoder(w).Encode(result)
 }
 
+// FormatDetectionResult reports which API format an AI endpoint speaks
+type FormatDetectionResult struct {
+	Endpoint string `json:"endpoint"`
+	// Detected is the classification from the endpoint URL alone
+	Detected string `json:"detected"`
+	// Probes holds one result per API format when probing was requested
+	Probes []ai.ProbeResult `json:"probes,omitempty"`
+	// Recommended is the format to use: the detected one if it works, otherwise the first that does
+	Recommended  string `json:"recommended,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+// HandleDetectAIFormat handles POST /api/ai/detect-format to identify the API format of an endpoint
+// @Summary      Detect AI endpoint format
+// @Description  Classify an AI endpoint by its URL and, when probe is true, send a test request in every supported format (openai, anthropic, gemini, deepseek, ollama) to see which succeed. Endpoint, model and API key default to the saved AI settings. Keys are redacted from errors and logs.
+// @Tags         ai
+// @Accept       json
+// @Produce      json
+// @Param        request  body      object  false  "endpoint, model, api_key (all optional) and probe (bool)"
+// @Success      200  {object}  handlers.FormatDetectionResult  "Detection result"
+// @Failure      400  {object}  map[string]string  "Bad request"
+// @Router       /ai/detect-format [post]
+func HandleDetectAIFormat(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Endpoint string `json:"endpoint"`
+		Model    string `json:"model"`
+		APIKey   string `json:"api_key"`
+		Probe    bool   `json:"probe"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if req.Endpoint == "" {
+		req.Endpoint, _ = h.DB.GetSetting("ai_endpoint")
+	}
+	if req.Model == "" {
+		req.Model, _ = h.DB.GetSetting("ai_model")
+	}
+	if req.APIKey == "" {
+		req.APIKey, _ = h.DB.GetEncryptedSetting("ai_api_key")
+	}
+	if req.Endpoint == "" {
+		http.Error(w, "endpoint is required", http.StatusBadRequest)
+		return
+	}
+	parsedURL, err := url.Parse(req.Endpoint)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
+		http.Error(w, "API endpoint must be an HTTP or HTTPS URL", http.StatusBadRequest)
+		return
+	}
+
+	result := FormatDetectionResult{
+		Endpoint: ai.RedactAPIKey(req.Endpoint, req.APIKey),
+		Detected: ai.DetectAPIProvider(req.Endpoint),
+	}
+	result.Recommended = result.Detected
+
+	if req.Probe {
+		httpClient, err := createHTTPClientWithProxy(h)
+		if err != nil {
+			result.ErrorMessage = fmt.Sprintf("Failed to create HTTP client: %v", err)
+		} else {
+			httpClient.Timeout = 15 * time.Second
+			client := ai.NewClientWithHTTPClient(ai.ClientConfig{
+				APIKey:   req.APIKey,
+				Endpoint: req.Endpoint,
+				Model:    req.Model,
+			}, httpClient).WithContext(r.Context())
+			result.Probes = client.ProbeFormats()
+			result.Recommended = recommendedFormat(result.Detected, result.Probes)
+			for _, p := range result.Probes {
+				utils.ContextLog(r.Context(), "AI format probe %s on %s: success=%v %s", p.Format, result.Endpoint, p.Success, p.Error)
+			}
+			if result.Recommended == "" {
+				result.ErrorMessage = "No API format succeeded"
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// HeadersValidationResult reports whether a custom headers setting is usable
+type HeadersValidationResult struct {
+	Valid   bool              `json:"valid"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// HandleValidateCustomHeaders handles POST /api/ai/validate-headers to check the custom headers JSON
+// @Summary      Validate AI custom headers
+// @Description  Parse an ai_custom_headers value, a flat JSON object of header names to string values, and return the parsed headers or why it is invalid. Headers managed by the HTTP client, such as Host and Content-Length, are rejected.
+// @Tags         ai
+// @Accept       json
+// @Produce      json
+// @Param        request  body      object  true  "headers (string, the JSON to validate)"
+// @Success      200  {object}  handlers.HeadersValidationResult  "Parsed headers"
+// @Failure      400  {object}  handlers.HeadersValidationResult  "Invalid headers"
+// @Router       /ai/validate-headers [post]
+func HandleValidateCustomHeaders(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Headers string `json:"headers"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	headers, err := ai.ValidateCustomHeaders(req.Headers)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(HeadersValidationResult{Error: err.Error()})
+		return
+	}
+	json.NewEncoder(w).Encode(HeadersValidationResult{Valid: true, Headers: headers})
+}
+
+// recommendedFormat prefers the detected format when it works, otherwise the first successful one
+func recommendedFormat(detected string, probes []ai.ProbeResult) string {
+	first := ""
+	for _, p := range probes {
+		if !p.Success {
+			continue
+		}
+		if string(p.Format) == detected {
+			return detected
+		}
+		if first == "" {
+			first = string(p.Format)
+		}
+	}
+	return first
+}
+
 // createHTTPClientWithProxy creates an HTTP client with global proxy settings if enabled
 func createHTTPClientWithProxy(h *core.Handler) (*http.Client, error) {
 	// Check if global proxy is enabled
@@ -5,13 +5,17 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"time"
 
+	"MrRSS/internal/database"
 	"MrRSS/internal/handlers/core"
+	"MrRSS/internal/models"
 )
 
 // HandleArticles returns articles with filtering and pagination.
 // @Summary      Get articles with filtering
-// @Description  Retrieve articles with optional filtering by feed, category, status, and pagination
+// @Description  Retrieve articles with optional filtering by feed, category, status, and pagination. Pinned articles are always listed first.
+// @Description  When the show_feed_badge setting is enabled, each article carries a feed_badge with the feed's short name and icon URL.
 // @Tags         articles
 // @Accept       json
 // @Produce      json
@@ -20,7 +24,11 @@ import (
 // @Param        category  query     string  false  "Filter by category name"
 // @Param        page      query     int     false  "Page number (default: 1)"  minimum(1)
 // @Param        limit     query     int     false  "Items per page (default: 50, max: 500)"  minimum(1)  maximum(500)
+// @Param        compact   query     bool    false  "Omit heavy fields such as summary from the list"
+// @Param        sort      query     string  false  "Sort order (default: newest); favorites_first lists favorites after pinned articles"  Enums(newest, favorites_first)
+// @Param        collapse_duplicate_titles  query  bool  false  "Collapse near-identical titles of the same feed into their newest article, which carries duplicate_count and duplicate_ids; paging applies to the collapsed list"
 // @Success      200  {array}   models.Article  "List of articles"
+// @Failure      400  {object}  map[string]string  "Invalid sort order"
 // @Failure      500  {object}  map[string]string  "Internal server error"
 // @Router       /articles [get]
 func HandleArticles(h *core.Handler, w http.ResponseWriter, r *http.Request) {
@@ -58,15 +66,45 @@ func HandleArticles(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 
 	offset := (page - 1) * limit
 
+	sortOrder := r.URL.Query().Get("sort")
+	if sortOrder == "" {
+		sortOrder = database.SortNewest
+	}
+	if sortOrder != database.SortNewest && sortOrder != database.SortFavoritesFirst {
+		http.Error(w, "sort must be newest or favorites_first", http.StatusBadRequest)
+		return
+	}
+
 	// Get show_hidden_articles setting
 	showHiddenStr, _ := h.DB.GetSetting("show_hidden_articles")
 	showHidden := showHiddenStr == "true"
 
-	articles, err := h.DB.GetArticles(filter, feedID, category, showHidden, limit, offset)
+	compact := r.URL.Query().Get("compact") == "true"
+	if r.URL.Query().Get("collapse_duplicate_titles") == "true" {
+		// Group over the whole result so counts and pages stay consistent, then paginate
+		all, err := h.DB.GetArticlesForList(filter, feedID, category, showHidden, sortOrder, -1, 0, compact)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		collapsed := paginateArticles(collapseDuplicateTitles(all), limit, offset)
+		if err := attachFeedBadges(h, collapsed); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(collapsed)
+		return
+	}
+
+	articles, err := h.DB.GetArticlesForList(filter, feedID, category, showHidden, sortOrder, limit, offset, compact)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if err := attachFeedBadges(h, articles); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	json.NewEncoder(w).Encode(articles)
 }
 
@@ -99,6 +137,41 @@ func HandleToggleHideArticle(h *core.Handler, w http.ResponseWriter, r *http.Req
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	h.NotifyUnreadCountsChanged()
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]bool{"success": true})
+}
+
+// HandleTogglePin toggles whether an article is pinned to the top of article lists.
+// @Summary      Toggle article pinned status
+// @Description  Toggle the pinned status of an article (pinned articles are listed above all others regardless of date)
+// @Tags         articles
+// @Accept       json
+// @Produce      json
+// @Param        id   query     int64   true  "Article ID"
+// @Success      200  {object}  map[string]bool  "Success status"
+// @Failure      400  {object}  map[string]string  "Bad request"
+// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Router       /articles/toggle-pin [post]
+func HandleTogglePin(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	idStr := r.URL.Query().Get("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid article ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.DB.ToggleArticlePinned(id); err != nil {
+		log.Printf("Error toggling article pinned status: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]bool{"success": true})
@@ -196,3 +269,133 @@ func HandleImageGalleryArticles(h *core.Handler, w http.ResponseWriter, r *http.
 	}
 	json.NewEncoder(w).Encode(articles)
 }
+
+// HandleRecentlyRead returns articles marked as read within a recent time window.
+// @Summary      Get recently read articles
+// @Description  Retrieve articles marked as read in the last N hours across all feeds, most recently read first
+// @Tags         articles
+// @Accept       json
+// @Produce      json
+// @Param        hours  query     int     false  "Time window in hours (default: 24)"  minimum(1)
+// @Param        page   query     int     false  "Page number (default: 1)"  minimum(1)
+// @Param        limit  query     int     false  "Items per page (default: 50)"  minimum(1)
+// @Success      200  {array}   models.Article  "List of recently read articles"
+// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Router       /articles/recently-read [get]
+func HandleRecentlyRead(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	hoursStr := r.URL.Query().Get("hours")
+	pageStr := r.URL.Query().Get("page")
+	limitStr := r.URL.Query().Get("limit")
+
+	hours := 24
+	if hr, err := strconv.Atoi(hoursStr); err == nil && hr > 0 {
+		hours = hr
+	}
+
+	page := 1
+	if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+		page = p
+	}
+
+	limit := 50
+	if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+		limit = l
+	}
+
+	offset := (page - 1) * limit
+	since := time.Now().Add(-time.Duration(hours) * time.Hour)
+
+	articles, err := h.DB.GetRecentlyRead(since, limit, offset)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(articles)
+}
+
+// HandleReadLaterList returns the read-later queue in the order articles were added.
+// @Summary      Get read-later queue
+// @Description  Retrieve articles queued for reading later, oldest addition first. Each article carries read_later_at, the time it was queued.
+// @Tags         articles
+// @Accept       json
+// @Produce      json
+// @Param        page   query     int     false  "Page number (default: 1)"  minimum(1)
+// @Param        limit  query     int     false  "Items per page (default: 50)"  minimum(1)
+// @Success      200  {array}   models.Article  "Read-later queue"
+// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Router       /articles/read-later [get]
+func HandleReadLaterList(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	page := 1
+	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && p > 0 {
+		page = p
+	}
+
+	limit := 50
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+		limit = l
+	}
+
+	articles, err := h.DB.GetReadLaterArticles(limit, (page-1)*limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(articles)
+}
+
+// UnreadStreamPage is one page of the global unread stream
+type UnreadStreamPage struct {
+	Articles []models.Article `json:"articles"`
+	// NextCursor continues after this page; empty when there are no more articles
+	NextCursor string `json:"next_cursor"`
+}
+
+// HandleUnreadStream returns a page of the global unread stream using keyset pagination.
+// @Summary      Get the global unread stream
+// @Description  Retrieve unread articles across all timeline feeds ordered by publish time. Articles unread in several feeds under the same URL are listed once. Pass next_cursor from the previous page as cursor to continue; deep pages are as fast as the first.
+// @Tags         articles
+// @Accept       json
+// @Produce      json
+// @Param        sort    query     string  false  "Sort order (default: newest)"  Enums(newest, oldest)
+// @Param        cursor  query     string  false  "Cursor from the previous page's next_cursor"
+// @Param        limit   query     int     false  "Items per page (default: 50, max: 500)"  minimum(1)  maximum(500)
+// @Success      200  {object}  UnreadStreamPage  "Articles and the cursor for the next page"
+// @Failure      400  {object}  map[string]string  "Invalid sort order or cursor"
+// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Router       /articles/unread-stream [get]
+func HandleUnreadStream(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	sortOrder := r.URL.Query().Get("sort")
+	if sortOrder == "" {
+		sortOrder = database.SortNewest
+	}
+	if sortOrder != database.SortNewest && sortOrder != database.SortOldest {
+		http.Error(w, "sort must be newest or oldest", http.StatusBadRequest)
+		return
+	}
+
+	cursor, err := database.ParseCursor(r.URL.Query().Get("cursor"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	limit := 50
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+		limit = min(l, 500)
+	}
+
+	articles, err := h.DB.GetAllUnread(sortOrder, cursor, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	page := UnreadStreamPage{Articles: articles}
+	if page.Articles == nil {
+		page.Articles = []models.Article{}
+	}
+	if len(articles) == limit {
+		page.NextCursor = database.CursorAfter(articles[len(articles)-1]).String()
+	}
+	json.NewEncoder(w).Encode(page)
+}
@@ -5,39 +5,76 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
 	"net/http"
 	"strconv"
+	"time"
 
 	"MrRSS/internal/handlers/core"
 )
 
 // HandleGetUnreadCounts returns unread counts for all feeds.
 // @Summary      Get unread counts
-// @Description  Get total unread count and per-feed unread counts
+// @Description  Get total unread count and per-feed unread counts. With mode=with_total the response also carries total article counts (total_articles, feed_total_counts) and per-category unread and total rollups (category_counts), all computed in one query.
 // @Tags         articles
 // @Accept       json
 // @Produce      json
-// @Success      200  {object}  map[string]interface{}  "Unread counts (total + feed_counts map)"
+// @Param        mode  query     string  false  "Empty for unread counts only, with_total to include total article counts"
+// @Success      200  {object}  map[string]interface{}  "Unread counts (total + feed_counts map, plus total_articles, feed_total_counts and category_counts with mode=with_total)"
+// @Failure      400  {object}  map[string]string  "Unknown mode"
 // @Failure      500  {object}  map[string]string  "Internal server error"
 // @Router       /articles/unread-counts [get]
 func HandleGetUnreadCounts(h *core.Handler, w http.ResponseWriter, r *http.Request) {
-	// Get total unread count
-	totalCount, err := h.DB.GetTotalUnreadCount()
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	var response map[string]interface{}
+	switch mode := r.URL.Query().Get("mode"); mode {
+	case "":
+		// Get total unread count
+		totalCount, err := h.DB.GetTotalUnreadCount()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 
-	// Get unread counts per feed
-	feedCounts, err := h.DB.GetUnreadCountsForAllFeeds()
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+		// Get unread counts per feed
+		feedCounts, err := h.DB.GetUnreadCountsForAllFeeds()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 
-	response := map[string]interface{}{
-		"total":       totalCount,
-		"feed_counts": feedCounts,
+		response = map[string]interface{}{
+			"total":       totalCount,
+			"feed_counts": feedCounts,
+		}
+	case "with_total":
+		feedCounts, categoryCounts, err := h.DB.GetArticleCountsForAllFeeds()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		unreadCounts := make(map[int64]int)
+		totalCounts := make(map[int64]int)
+		totalUnread, totalArticles := 0, 0
+		for feedID, counts := range feedCounts {
+			if counts.Unread > 0 {
+				unreadCounts[feedID] = counts.Unread
+			}
+			totalCounts[feedID] = counts.Total
+			totalUnread += counts.Unread
+			totalArticles += counts.Total
+		}
+
+		response = map[string]interface{}{
+			"total":             totalUnread,
+			"feed_counts":       unreadCounts,
+			"total_articles":    totalArticles,
+			"feed_total_counts": totalCounts,
+			"category_counts":   categoryCounts,
+		}
+	default:
+		http.Error(w, fmt.Sprintf("unknown mode %q", mode), http.StatusBadRequest)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -137,18 +174,20 @@ func HandleGetFilterCounts(h *core.Handler, w http.ResponseWriter, r *http.Reque
 
 // HandleMarkAllAsRead marks all articles as read.
 // @Summary      Mark all articles as read
-// @Description  Mark all articles as read globally, by feed, or by category
+// @Description  Mark all articles as read globally, by feed, or by category. For a FreshRSS feed the whole stream is also marked read on the server with a single request. A category includes its subcategories, and its FreshRSS articles are queued for the next sync.
 // @Tags         articles
 // @Accept       json
 // @Produce      json
 // @Param        feed_id   query     int64   false  "Mark all as read for specific feed ID"
-// @Param        category  query     string  false  "Mark all as read for specific category"
+// @Param        category  query     string  false  "Mark all as read for a category and its subcategories; empty for uncategorized feeds"
 // @Success      200  {string}  string  "Articles marked as read successfully"
 // @Failure      400  {object}  map[string]string  "Bad request (invalid feed_id)"
 // @Failure      500  {object}  map[string]string  "Internal server error"
 // @Router       /articles/mark-all-read [post]
 func HandleMarkAllAsRead(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 	feedIDStr := r.URL.Query().Get("feed_id")
+	// An empty category parameter means the uncategorized feeds, not all of them
+	_, hasCategory := r.URL.Query()["category"]
 	category := r.URL.Query().Get("category")
 
 	var err error
@@ -159,10 +198,21 @@ func HandleMarkAllAsRead(h *core.Handler, w http.ResponseWriter, r *http.Request
 			http.Error(w, "Invalid feed_id parameter", http.StatusBadRequest)
 			return
 		}
+		markedAt := time.Now()
 		err = h.DB.MarkAllAsReadForFeed(feedID)
-	} else if category != "" {
-		// Mark all as read for a specific category
-		err = h.DB.MarkAllAsReadForCategory(category)
+		if err == nil {
+			if feed, feedErr := h.DB.GetFeedByID(feedID); feedErr == nil && feed.IsFreshRSSSource && feed.FreshRSSStreamID != "" {
+				go syncStreamMarkedRead(h, feed.FreshRSSStreamID, markedAt)
+			}
+		}
+	} else if hasCategory {
+		// Mark all as read for a category and its subcategories; FreshRSS articles are
+		// pushed with the next sync rather than one request per article now
+		syncRequests, markErr := h.DB.MarkAllAsReadForCategoryWithSync(category)
+		for _, req := range syncRequests {
+			_ = h.DB.EnqueueSyncChange(req.ArticleID, req.ArticleURL, req.Action)
+		}
+		err = markErr
 	} else {
 		// Mark all as read globally
 		err = h.DB.MarkAllAsRead()
@@ -172,6 +222,7 @@ func HandleMarkAllAsRead(h *core.Handler, w http.ResponseWriter, r *http.Request
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	h.NotifyUnreadCountsChanged()
 	w.WriteHeader(http.StatusOK)
 }
 
@@ -200,16 +251,56 @@ func HandleClearReadLater(h *core.Handler, w http.ResponseWriter, r *http.Reques
 
 // HandleRefresh triggers a refresh of all feeds.
 // @Summary      Refresh all feeds
-// @Description  Trigger a background refresh of all feeds
+// @Description  Trigger a background refresh of all feeds. Manual refreshes are throttled by min_manual_refresh_interval (seconds). If the refresh needs more fetches than max_fetches_per_hour has left, it still starts but the response carries a warning and the remaining feeds are deferred to the next window.
 // @Tags         articles
 // @Accept       json
 // @Produce      json
-// @Success      200  {string}  string  "Refresh started successfully"
-// @Router       /articles/refresh [post]
+// @Success      200  {object}  map[string]interface{}  "Refresh started (status, and warning when the fetch budget runs out)"
+// @Failure      409  {object}  map[string]interface{}  "A refresh is already running (error, progress)"
+// @Failure      429  {object}  map[string]interface{}  "Requested too soon after the last refresh (error, retry_after_seconds)"
+// @Router       /refresh [post]
 func HandleRefresh(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	taskManager := h.Fetcher.GetTaskManager()
+	if taskManager.IsRunning() {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error":    "A refresh is already running",
+			"progress": h.Fetcher.GetProgressWithStats(),
+		})
+		return
+	}
+
+	minInterval := 30
+	if v, err := h.DB.GetSetting("min_manual_refresh_interval"); err == nil {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			minInterval = n
+		}
+	}
+	if remaining, ok := h.ReserveManualRefresh(time.Duration(minInterval) * time.Second); !ok {
+		retryAfter := int(math.Ceil(remaining.Seconds()))
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+		w.WriteHeader(http.StatusTooManyRequests)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error":               "Refresh requested too soon after the last one",
+			"retry_after_seconds": retryAfter,
+		})
+		return
+	}
+
+	// Warn up front when the refresh will not fit in the hourly fetch budget
+	response := map[string]interface{}{"status": "refreshing"}
+	if limit, remaining, resetIn := h.Fetcher.FetchBudget(); limit > 0 {
+		if needed := countRefreshableFeeds(h); needed > remaining {
+			response["warning"] = fmt.Sprintf("Only %d of %d feeds fit in the hourly fetch budget; the rest will be fetched in %d minutes",
+				remaining, needed, int(math.Ceil(resetIn.Minutes())))
+			response["fetch_budget_remaining"] = remaining
+		}
+	}
+
 	// Mark progress as running before starting goroutine
 	// This ensures the frontend immediately sees is_running=true
-	taskManager := h.Fetcher.GetTaskManager()
 	taskManager.MarkRunning()
 
 	// Manual refresh - fetches all feeds in background
@@ -218,7 +309,46 @@ func HandleRefresh(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 	// Return success response
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{"status": "refreshing"})
+	json.NewEncoder(w).Encode(response)
+}
+
+// countRefreshableFeeds counts the feeds a full refresh fetches, skipping FreshRSS and never-refresh feeds
+func countRefreshableFeeds(h *core.Handler) int {
+	feeds, err := h.DB.GetFeeds()
+	if err != nil {
+		return 0
+	}
+	count := 0
+	for _, feed := range feeds {
+		if !feed.IsFreshRSSSource && feed.RefreshInterval != -2 {
+			count++
+		}
+	}
+	return count
+}
+
+// HandleCancelRefresh cancels the refresh that is currently running.
+// @Summary      Cancel refresh
+// @Description  Cancel the in-progress feed refresh. Queued feeds are dropped and in-flight fetches are aborted; progress reports is_running=false once they have stopped.
+// @Tags         articles
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  map[string]string  "Refresh canceled"
+// @Failure      409  {object}  map[string]string  "No refresh is running"
+// @Router       /refresh/cancel [post]
+func HandleCancelRefresh(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.Fetcher.CancelRefresh() {
+		http.Error(w, "No refresh is running", http.StatusConflict)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "canceling"})
 }
 
 // HandleCleanupArticles triggers manual cleanup of articles.
@@ -239,7 +369,7 @@ func HandleCleanupArticles(h *core.Handler, w http.ResponseWriter, r *http.Reque
 
 	// Manual cleanup: clear ALL articles and article contents, but keep feeds
 	// Step 1: Delete all article contents
-	contentCount, err := h.DB.CleanupAllArticleContents()
+	contentCount, err := h.DB.DeleteAllArticleContents()
 	if err != nil {
 		log.Printf("Error cleaning up article contents: %v", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -389,6 +519,7 @@ func HandleMarkRelativeToArticle(h *core.Handler, w http.ResponseWriter, r *http
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	h.NotifyUnreadCountsChanged()
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
@@ -2,12 +2,13 @@ package article
 
 import (
 	"encoding/json"
-	"log"
 	"net/http"
 	"strconv"
 
 	"MrRSS/internal/feed"
 	"MrRSS/internal/handlers/core"
+	"MrRSS/internal/translation"
+	"MrRSS/internal/utils"
 )
 
 // HandleGetArticleContent fetches the article content from RSS feed dynamically.
@@ -17,7 +18,7 @@ import (
 // @Accept       json
 // @Produce      json
 // @Param        id   query     int64   true  "Article ID"
-// @Success      200  {object}  map[string]string  "Article content (content, feed_url)"
+// @Success      200  {object}  map[string]interface{}  "Article content (content, feed_url, cached, content_language, content_direction, highlights, similar_read)"
 // @Failure      400  {object}  map[string]string  "Bad request (invalid article ID)"
 // @Failure      500  {object}  map[string]string  "Internal server error"
 // @Router       /articles/content [get]
@@ -37,7 +38,7 @@ func HandleGetArticleContent(h *core.Handler, w http.ResponseWriter, r *http.Req
 	// Get the article from database to access feed_id
 	article, err := h.DB.GetArticleByID(articleID)
 	if err != nil {
-		log.Printf("Error getting article: %v", err)
+		utils.ContextLog(r.Context(), "Error getting article: %v", err)
 		http.Error(w, "Failed to get article", http.StatusInternalServerError)
 		return
 	}
@@ -45,7 +46,7 @@ func HandleGetArticleContent(h *core.Handler, w http.ResponseWriter, r *http.Req
 	// Use the cached content fetching method
 	content, wasCached, err := h.GetArticleContent(articleID)
 	if err != nil {
-		log.Printf("Error getting article content: %v", err)
+		utils.ContextLog(r.Context(), "Error getting article content: %v", err)
 		http.Error(w, "Failed to fetch article content", http.StatusInternalServerError)
 		return
 	}
@@ -60,11 +61,41 @@ func HandleGetArticleContent(h *core.Handler, w http.ResponseWriter, r *http.Req
 		feedURL = feed.URL
 	}
 
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"content":  content,
-		"feed_url": feedURL,
-		"cached":   wasCached,
-	})
+	content = applyExternalLinkSetting(h, content, article.URL)
+
+	lang, dir := contentLanguage(h, articleID, content)
+	response := map[string]interface{}{
+		"content":           content,
+		"feed_url":          feedURL,
+		"cached":            wasCached,
+		"content_language":  lang,
+		"content_direction": dir,
+	}
+
+	// Return saved highlights so the reader can re-render them over the content
+	highlights, err := h.DB.GetHighlights(articleID)
+	if err != nil {
+		utils.ContextLog(r.Context(), "Error getting highlights: %v", err)
+	} else {
+		response["highlights"] = highlights
+	}
+
+	// Flag articles whose story was already read elsewhere recently. This doesn't check
+	// is_read: the list marks an article read as it opens, usually before this request lands.
+	similar, score, err := h.DB.FindSimilarRead(*article)
+	if err != nil {
+		utils.ContextLog(r.Context(), "Error checking for similar read articles: %v", err)
+	} else if similar != nil {
+		response["similar_read"] = map[string]interface{}{
+			"id":         similar.ID,
+			"title":      similar.Title,
+			"url":        similar.URL,
+			"feed_title": similar.FeedTitle,
+			"similarity": score,
+		}
+	}
+
+	json.NewEncoder(w).Encode(response)
 }
 
 // HandleFetchFullArticle fetches the full article content from the original URL using readability.
@@ -74,7 +105,7 @@ func HandleGetArticleContent(h *core.Handler, w http.ResponseWriter, r *http.Req
 // @Accept       json
 // @Produce      json
 // @Param        id   query     int64   true  "Article ID"
-// @Success      200  {object}  map[string]string  "Full article content (content, feed_url)"
+// @Success      200  {object}  map[string]string  "Full article content (content, feed_url, content_language, content_direction)"
 // @Failure      400  {object}  map[string]string  "Bad request (invalid ID or missing URL)"
 // @Failure      403  {object}  map[string]string  "Full-text fetching disabled"
 // @Failure      500  {object}  map[string]string  "Internal server error"
@@ -95,7 +126,7 @@ func HandleFetchFullArticle(h *core.Handler, w http.ResponseWriter, r *http.Requ
 	// Get the article from database
 	article, err := h.DB.GetArticleByID(articleID)
 	if err != nil {
-		log.Printf("Error getting article: %v", err)
+		utils.ContextLog(r.Context(), "Error getting article: %v", err)
 		http.Error(w, "Failed to get article", http.StatusInternalServerError)
 		return
 	}
@@ -116,7 +147,7 @@ func HandleFetchFullArticle(h *core.Handler, w http.ResponseWriter, r *http.Requ
 	// Fetch full content
 	fullContent, err := h.FetchFullArticleContent(article.URL)
 	if err != nil {
-		log.Printf("Error fetching full article content: %v", err)
+		utils.ContextLog(r.Context(), "Error fetching full article content: %v", err)
 		http.Error(w, "Failed to fetch full article content", http.StatusInternalServerError)
 		return
 	}
@@ -128,12 +159,37 @@ func HandleFetchFullArticle(h *core.Handler, w http.ResponseWriter, r *http.Requ
 		feedURL = feed.URL
 	}
 
+	fullContent = applyExternalLinkSetting(h, fullContent, article.URL)
+
+	lang, dir := contentLanguage(h, articleID, fullContent)
 	json.NewEncoder(w).Encode(map[string]string{
-		"content":  fullContent,
-		"feed_url": feedURL,
+		"content":           fullContent,
+		"feed_url":          feedURL,
+		"content_language":  lang,
+		"content_direction": dir,
 	})
 }
 
+// contentLanguage resolves the language and text direction of article content. The language
+// stored by the detection pass is reused; otherwise it is detected from the content. An
+// undetectable language is returned as "" with direction "auto".
+func contentLanguage(h *core.Handler, articleID int64, content string) (string, string) {
+	lang, _ := h.DB.GetArticleDetectedLanguage(articleID)
+	if lang == "" {
+		lang = translation.GetLanguageDetector().DetectLanguage(content)
+	}
+	return lang, translation.LanguageDirection(lang)
+}
+
+// applyExternalLinkSetting marks external links to open in a new tab when
+// open_external_links_new_tab is enabled
+func applyExternalLinkSetting(h *core.Handler, content, articleURL string) string {
+	if enabled, _ := h.DB.GetSetting("open_external_links_new_tab"); enabled != "true" {
+		return content
+	}
+	return utils.MarkExternalLinks(content, articleURL)
+}
+
 // HandleExtractAllImages extracts all image URLs from article content
 // @Summary      Extract all images from article
 // @Description  Extract all image URLs from article content (including relative URLs resolved to absolute)
@@ -161,7 +217,7 @@ func HandleExtractAllImages(h *core.Handler, w http.ResponseWriter, r *http.Requ
 	// Get the article from database
 	article, err := h.DB.GetArticleByID(articleID)
 	if err != nil {
-		log.Printf("Error getting article: %v", err)
+		utils.ContextLog(r.Context(), "Error getting article: %v", err)
 		http.Error(w, "Failed to get article", http.StatusInternalServerError)
 		return
 	}
@@ -176,7 +232,7 @@ func HandleExtractAllImages(h *core.Handler, w http.ResponseWriter, r *http.Requ
 	// Get article content
 	content, _, err := h.GetArticleContent(articleID)
 	if err != nil {
-		log.Printf("Error getting article content: %v", err)
+		utils.ContextLog(r.Context(), "Error getting article content: %v", err)
 		http.Error(w, "Failed to get article content", http.StatusInternalServerError)
 		return
 	}
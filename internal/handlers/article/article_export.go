@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"html"
+	"mime"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -115,8 +117,60 @@ func HandleExportToObsidian(h *core.Handler, w http.ResponseWriter, r *http.Requ
 	})
 }
 
+// HandleExportArticleMarkdown returns an article as a Markdown file download
+// @Summary      Download article as Markdown
+// @Description  Convert an article to Markdown (same format as the Obsidian export) and return it as a file attachment. Needs no Obsidian settings.
+// @Tags         articles
+// @Produce      text/markdown
+// @Param        article_id  query     int  true  "Article ID"
+// @Success      200  {file}    file  "Markdown file"
+// @Failure      400  {object}  map[string]string  "Invalid article ID"
+// @Failure      404  {object}  map[string]string  "Article not found"
+// @Router       /articles/export/markdown [get]
+func HandleExportArticleMarkdown(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	articleID, err := strconv.ParseInt(r.URL.Query().Get("article_id"), 10, 64)
+	if err != nil || articleID <= 0 {
+		http.Error(w, "Invalid article ID", http.StatusBadRequest)
+		return
+	}
+
+	article, err := h.DB.GetArticleByID(articleID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Article not found: %v", err), http.StatusNotFound)
+		return
+	}
+
+	content, _, err := h.GetArticleContent(articleID)
+	if err != nil {
+		// If content fetch fails, continue with empty content
+		content = ""
+	}
+
+	filename := sanitizeFilename(article.Title)
+	if filename == "" {
+		filename = fmt.Sprintf("Article_%d", article.ID)
+	}
+	filename += ".md"
+
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	// FormatMediaType falls back to RFC 2231 encoding for non-ASCII titles
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.Write([]byte(generateArticleMarkdown(*article, content, "Exported")))
+}
+
 // generateObsidianMarkdown converts an article to Markdown format for Obsidian
 func generateObsidianMarkdown(article models.Article, content string) string {
+	return generateArticleMarkdown(article, content, "Added to Obsidian")
+}
+
+// generateArticleMarkdown converts an article to Markdown with YAML front matter.
+// exportLabel names the export time line in the footer.
+func generateArticleMarkdown(article models.Article, content, exportLabel string) string {
 	var sb strings.Builder
 
 	// Front matter - exclude URL to avoid URI parsing issues
@@ -144,7 +198,7 @@ func generateObsidianMarkdown(article models.Article, content string) string {
 
 	// Add metadata at the end
 	sb.WriteString("---\n")
-	sb.WriteString(fmt.Sprintf("**Added to Obsidian:** %s\n", time.Now().Format("2006-01-02 15:04:05")))
+	sb.WriteString(fmt.Sprintf("**%s:** %s\n", exportLabel, time.Now().Format("2006-01-02 15:04:05")))
 	sb.WriteString(fmt.Sprintf("**Article ID:** %d\n", article.ID))
 
 	return sb.String()
@@ -67,6 +67,60 @@ func HandleProgress(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// HandleProgressStream streams fetch progress updates as server-sent events.
+// @Summary      Stream fetch progress
+// @Description  Stream feed fetching progress as server-sent events. Slow clients receive only the latest update.
+// @Tags         articles
+// @Produce      text/event-stream
+// @Success      200  {string}  string  "Stream of progress events"
+// @Failure      503  {object}  map[string]string  "Too many progress subscribers"
+// @Router       /progress/stream [get]
+func HandleProgressStream(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	updates, unsubscribe, err := h.Fetcher.SubscribeProgress()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	writeProgressEvent(w, h.Fetcher.GetProgressWithStats())
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case progress, ok := <-updates:
+			if !ok {
+				return
+			}
+			writeProgressEvent(w, progress)
+			flusher.Flush()
+		}
+	}
+}
+
+// writeProgressEvent writes a single progress server-sent event
+func writeProgressEvent(w http.ResponseWriter, progress interface{}) {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		log.Printf("[HandleProgressStream] ERROR encoding progress: %v", err)
+		return
+	}
+	fmt.Fprintf(w, "event: progress\ndata: %s\n\n", data)
+}
+
 // TaskDetailsResponse contains detailed task information
 type TaskDetailsResponse struct {
 	PoolTasks  []PoolTaskInfo  `json:"pool_tasks"`
@@ -11,10 +11,13 @@ import (
 	"time"
 
 	"MrRSS/internal/database"
+	"MrRSS/internal/events"
 	ff "MrRSS/internal/feed"
 	"MrRSS/internal/handlers/article"
 	"MrRSS/internal/handlers/core"
 	"MrRSS/internal/models"
+
+	"golang.org/x/net/websocket"
 )
 
 func setupHandler(t *testing.T) *core.Handler {
@@ -198,3 +201,674 @@ func TestHandleExportToObsidian(t *testing.T) {
 		t.Fatalf("Export not successful: %v", response)
 	}
 }
+
+func TestHandleCancelRefresh_NothingRunning(t *testing.T) {
+	h := setupHandler(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/refresh/cancel", nil)
+	rr := httptest.NewRecorder()
+	article.HandleCancelRefresh(h, rr, req)
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("expected 409 when no refresh is running, got %d", rr.Code)
+	}
+
+	h.Fetcher.GetTaskManager().MarkRunning()
+	rr = httptest.NewRecorder()
+	article.HandleCancelRefresh(h, rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 when canceling a running refresh, got %d", rr.Code)
+	}
+	if h.Fetcher.GetTaskManager().IsRunning() {
+		t.Errorf("expected refresh to be marked as not running after cancel")
+	}
+}
+
+func TestHandleRefresh_RunningAndThrottled(t *testing.T) {
+	h := setupHandler(t)
+	taskManager := h.Fetcher.GetTaskManager()
+	refresh := func() *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		article.HandleRefresh(h, rr, httptest.NewRequest(http.MethodPost, "/api/refresh", nil))
+		return rr
+	}
+	waitIdle := func() {
+		for i := 0; i < 100 && taskManager.IsRunning(); i++ {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	taskManager.MarkRunning()
+	rr := refresh()
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("expected 409 while a refresh is running, got %d", rr.Code)
+	}
+	var conflict map[string]interface{}
+	if err := json.NewDecoder(rr.Body).Decode(&conflict); err != nil || conflict["progress"] == nil {
+		t.Fatalf("expected progress in the 409 body, got %v (err %v)", conflict, err)
+	}
+	taskManager.MarkCompleted()
+
+	if rr := refresh(); rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 for the first manual refresh, got %d", rr.Code)
+	}
+	waitIdle()
+
+	rr = refresh()
+	if rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 for a refresh within the minimum interval, got %d", rr.Code)
+	}
+	if rr.Header().Get("Retry-After") == "" {
+		t.Errorf("expected a Retry-After header")
+	}
+	var throttled map[string]interface{}
+	if err := json.NewDecoder(rr.Body).Decode(&throttled); err != nil || throttled["retry_after_seconds"].(float64) <= 0 {
+		t.Fatalf("expected a positive retry_after_seconds, got %v (err %v)", throttled, err)
+	}
+
+	if err := h.DB.SetSetting("min_manual_refresh_interval", "0"); err != nil {
+		t.Fatalf("SetSetting: %v", err)
+	}
+	if rr := refresh(); rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 with the throttle disabled, got %d", rr.Code)
+	}
+	waitIdle()
+}
+
+func TestHandleExportArticleMarkdown(t *testing.T) {
+	h := setupHandler(t)
+
+	feedID, err := h.DB.AddFeed(&models.Feed{Title: "Test Feed", URL: "http://example.com"})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+	articleModel := &models.Article{
+		FeedID:      feedID,
+		Title:       "Test: Article",
+		URL:         "http://example.com/article",
+		PublishedAt: time.Now(),
+	}
+	if err := h.DB.SaveArticles(context.Background(), []*models.Article{articleModel}); err != nil {
+		t.Fatalf("SaveArticles: %v", err)
+	}
+	articles, err := h.DB.GetArticles("", feedID, "", false, 10, 0)
+	if err != nil || len(articles) == 0 {
+		t.Fatalf("GetArticles: %v", err)
+	}
+	articleID := articles[0].ID
+	if err := h.DB.SetArticleContent(articleID, "<p>Hello <strong>world</strong></p>"); err != nil {
+		t.Fatalf("SetArticleContent: %v", err)
+	}
+
+	// Obsidian is not configured; the download must not need it
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/articles/export/markdown?article_id=%d", articleID), nil)
+	w := httptest.NewRecorder()
+	article.HandleExportArticleMarkdown(h, w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Export failed: %d, body: %s", w.Code, w.Body.String())
+	}
+	if cd := w.Header().Get("Content-Disposition"); cd != `attachment; filename="Test_ Article.md"` {
+		t.Errorf("unexpected Content-Disposition %q", cd)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, "# Test: Article") || !strings.Contains(body, "Hello **world**") {
+		t.Errorf("unexpected markdown:\n%s", body)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/articles/export/markdown?article_id=abc", nil)
+	w = httptest.NewRecorder()
+	article.HandleExportArticleMarkdown(h, w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for invalid ID, got %d", w.Code)
+	}
+}
+
+func TestHandleMarkReadAfterView(t *testing.T) {
+	h := setupHandler(t)
+	if err := h.DB.SetSetting("auto_read_dwell_ms", "2000"); err != nil {
+		t.Fatalf("SetSetting: %v", err)
+	}
+
+	feedID, err := h.DB.AddFeed(&models.Feed{Title: "Test Feed", URL: "http://example.com"})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+	if err := h.DB.SaveArticles(context.Background(), []*models.Article{{FeedID: feedID, Title: "A", URL: "http://example.com/a", PublishedAt: time.Now()}}); err != nil {
+		t.Fatalf("SaveArticles: %v", err)
+	}
+	articles, err := h.DB.GetArticles("", feedID, "", false, 10, 0)
+	if err != nil || len(articles) == 0 {
+		t.Fatalf("GetArticles: %v", err)
+	}
+	id := articles[0].ID
+
+	readState := func() (read, auto bool) {
+		t.Helper()
+		if err := h.DB.QueryRow("SELECT is_read, auto_read FROM articles WHERE id = ?", id).Scan(&read, &auto); err != nil {
+			t.Fatalf("query read state: %v", err)
+		}
+		return
+	}
+
+	// Viewed for less than the configured dwell time
+	rr := httptest.NewRecorder()
+	article.HandleMarkReadAfterView(h, rr, httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/articles/read-after-view?id=%d&viewed_ms=500", id), nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 below the dwell time, got %d", rr.Code)
+	}
+	if read, _ := readState(); read {
+		t.Fatal("article should still be unread")
+	}
+
+	rr = httptest.NewRecorder()
+	article.HandleMarkReadAfterView(h, rr, httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/articles/read-after-view?id=%d&viewed_ms=2500", id), nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if read, auto := readState(); !read || !auto {
+		t.Fatalf("expected article to be read and flagged auto-read, got read=%v auto=%v", read, auto)
+	}
+
+	// A manual mark replaces the auto-read flag
+	if err := h.DB.MarkArticleRead(id, true); err != nil {
+		t.Fatalf("MarkArticleRead: %v", err)
+	}
+	if read, auto := readState(); !read || auto {
+		t.Fatalf("expected manual read to clear the auto-read flag, got read=%v auto=%v", read, auto)
+	}
+
+	// So do bulk marks and queueing the article for later
+	for name, mark := range map[string]func() error{
+		"MarkArticlesReadWithSync": func() error { _, err := h.DB.MarkArticlesReadWithSync([]int64{id}, true); return err },
+		"ToggleReadLater":          func() error { return h.DB.ToggleReadLater(id) },
+		"SetArticleReadLater":      func() error { return h.DB.SetArticleReadLater(id, true) },
+	} {
+		if _, err := h.DB.Exec("UPDATE articles SET is_read = 1, is_read_later = 0, auto_read = 1 WHERE id = ?", id); err != nil {
+			t.Fatalf("reset read state: %v", err)
+		}
+		if err := mark(); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if _, auto := readState(); auto {
+			t.Errorf("expected %s to clear the auto-read flag", name)
+		}
+	}
+}
+
+func TestHandleOpenOriginal(t *testing.T) {
+	h := setupHandler(t)
+	feedID, err := h.DB.AddFeed(&models.Feed{Title: "Test Feed", URL: "http://example.com"})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+	if err := h.DB.SaveArticles(context.Background(), []*models.Article{
+		{FeedID: feedID, Title: "A", URL: "https://example.com/a?id=7&utm_source=rss", PublishedAt: time.Now()},
+		{FeedID: feedID, Title: "B", URL: "https://example.com/b", PublishedAt: time.Now()},
+		{FeedID: feedID, Title: "C", URL: "javascript:alert(1)", PublishedAt: time.Now()},
+	}); err != nil {
+		t.Fatalf("SaveArticles: %v", err)
+	}
+	articles, _ := h.DB.GetArticles("", feedID, "", false, 10, 0)
+	ids := map[string]int64{}
+	for _, a := range articles {
+		ids[a.Title] = a.ID
+	}
+	open := func(id int64) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		article.HandleOpenOriginal(h, rr, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/articles/open-original?article_id=%d", id), nil))
+		return rr
+	}
+	isRead := func(id int64) bool {
+		a, err := h.DB.GetArticleByID(id)
+		if err != nil {
+			t.Fatalf("GetArticleByID: %v", err)
+		}
+		return a.IsRead
+	}
+
+	rr := open(ids["A"])
+	if rr.Code != http.StatusFound {
+		t.Fatalf("expected 302, got %d", rr.Code)
+	}
+	if loc := rr.Header().Get("Location"); loc != "https://example.com/a?id=7" {
+		t.Errorf("expected tracking to be stripped, got %q", loc)
+	}
+	if !isRead(ids["A"]) {
+		t.Error("expected the article to be marked read")
+	}
+
+	if err := h.DB.SetSetting("open_original_marks_read", "false"); err != nil {
+		t.Fatalf("SetSetting: %v", err)
+	}
+	if rr := open(ids["B"]); rr.Code != http.StatusFound {
+		t.Fatalf("expected 302, got %d", rr.Code)
+	}
+	if isRead(ids["B"]) {
+		t.Error("expected the article to stay unread when disabled")
+	}
+
+	if rr := open(ids["C"]); rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a non-web URL, got %d", rr.Code)
+	}
+	if rr := open(99999); rr.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for a missing article, got %d", rr.Code)
+	}
+}
+
+func TestHandleArticles_CollapseDuplicateTitles(t *testing.T) {
+	h := setupHandler(t)
+	feedID, err := h.DB.AddFeed(&models.Feed{Title: "Test Feed", URL: "http://example.com"})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+	otherID, err := h.DB.AddFeed(&models.Feed{Title: "Other Feed", URL: "http://other.example.com"})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+	now := time.Now()
+	if err := h.DB.SaveArticles(context.Background(), []*models.Article{
+		{FeedID: feedID, Title: "Weekly Roundup #3", URL: "https://example.com/3", PublishedAt: now},
+		{FeedID: feedID, Title: "Something else", URL: "https://example.com/x", PublishedAt: now.Add(-time.Hour)},
+		{FeedID: feedID, Title: "weekly roundup - 2", URL: "https://example.com/2", PublishedAt: now.Add(-2 * time.Hour)},
+		{FeedID: otherID, Title: "Weekly Roundup", URL: "https://other.example.com/1", PublishedAt: now.Add(-3 * time.Hour)},
+		{FeedID: feedID, Title: "Weekly roundup 1", URL: "https://example.com/1", PublishedAt: now.Add(-4 * time.Hour)},
+	}); err != nil {
+		t.Fatalf("SaveArticles: %v", err)
+	}
+
+	list := func(query string) []models.Article {
+		rr := httptest.NewRecorder()
+		article.HandleArticles(h, rr, httptest.NewRequest(http.MethodGet, "/api/articles?"+query, nil))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rr.Code)
+		}
+		var got []models.Article
+		if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return got
+	}
+
+	if got := list("limit=10"); len(got) != 5 {
+		t.Fatalf("expected 5 articles without collapsing, got %d", len(got))
+	}
+
+	got := list("limit=10&collapse_duplicate_titles=true")
+	if len(got) != 3 {
+		t.Fatalf("expected 3 entries after collapsing, got %d", len(got))
+	}
+	if got[0].Title != "Weekly Roundup #3" || got[0].DuplicateCount != 2 || len(got[0].DuplicateIDs) != 3 {
+		t.Errorf("expected the newest roundup to represent 3 articles, got %+v", got[0])
+	}
+	if got[0].DuplicateIDs[0] != got[0].ID {
+		t.Errorf("expected the representative first in duplicate_ids, got %v", got[0].DuplicateIDs)
+	}
+	// The same title from another feed is not folded in
+	if got[2].FeedID != otherID || got[2].DuplicateCount != 0 || got[2].DuplicateIDs != nil {
+		t.Errorf("expected the other feed's roundup on its own, got %+v", got[2])
+	}
+
+	// Pagination applies to the collapsed list
+	page2 := list("limit=2&page=2&collapse_duplicate_titles=true")
+	if len(page2) != 1 || page2[0].FeedID != otherID {
+		t.Errorf("expected only the other feed's roundup on page 2, got %+v", page2)
+	}
+}
+
+func TestHandleArticles_FeedBadge(t *testing.T) {
+	h := setupHandler(t)
+	plainID, err := h.DB.AddFeed(&models.Feed{Title: "Hacker News Daily", URL: "https://news.example.com/rss"})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+	prefixedID, err := h.DB.AddFeed(&models.Feed{Title: "Other Feed", URL: "https://other.example.com/rss", ImageURL: "https://other.example.com/icon.png"})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+	if err := h.DB.UpdateFeedListPrefix(prefixedID, " OTH "); err != nil {
+		t.Fatalf("UpdateFeedListPrefix: %v", err)
+	}
+	now := time.Now()
+	if err := h.DB.SaveArticles(context.Background(), []*models.Article{
+		{FeedID: plainID, Title: "a1", URL: "https://news.example.com/1", PublishedAt: now},
+		{FeedID: prefixedID, Title: "a2", URL: "https://other.example.com/2", PublishedAt: now.Add(-time.Hour)},
+	}); err != nil {
+		t.Fatalf("SaveArticles: %v", err)
+	}
+
+	list := func() []models.Article {
+		rr := httptest.NewRecorder()
+		article.HandleArticles(h, rr, httptest.NewRequest(http.MethodGet, "/api/articles", nil))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rr.Code)
+		}
+		var got []models.Article
+		if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return got
+	}
+
+	for _, a := range list() {
+		if a.FeedBadge != nil {
+			t.Fatalf("expected no badge while show_feed_badge is off, got %+v", a.FeedBadge)
+		}
+	}
+
+	if err := h.DB.SetSetting("show_feed_badge", "true"); err != nil {
+		t.Fatalf("SetSetting: %v", err)
+	}
+	got := list()
+	if len(got) != 2 || got[0].FeedBadge == nil || got[1].FeedBadge == nil {
+		t.Fatalf("expected a badge on both articles, got %+v", got)
+	}
+	if b := got[0].FeedBadge; b.ShortName != "HN" || b.IconURL != "https://www.google.com/s2/favicons?domain=news.example.com" {
+		t.Errorf("expected initials and the site favicon, got %+v", b)
+	}
+	if b := got[1].FeedBadge; b.ShortName != "OTH" || b.IconURL != "https://other.example.com/icon.png" {
+		t.Errorf("expected the list prefix and the feed image, got %+v", b)
+	}
+}
+
+func TestHandleMarkAllAsRead_Category(t *testing.T) {
+	h := setupHandler(t)
+	techID, err := h.DB.AddFeed(&models.Feed{Title: "Go", URL: "https://example.com/go", Category: "Tech/Go"})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+	looseID, err := h.DB.AddFeed(&models.Feed{Title: "Loose", URL: "https://example.com/loose"})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+	if err := h.DB.SaveArticles(context.Background(), []*models.Article{
+		{FeedID: techID, Title: "a1", URL: "https://example.com/go/1", PublishedAt: time.Now()},
+		{FeedID: looseID, Title: "a2", URL: "https://example.com/loose/1", PublishedAt: time.Now()},
+	}); err != nil {
+		t.Fatalf("SaveArticles: %v", err)
+	}
+
+	markAll := func(query string) map[int64]int {
+		rr := httptest.NewRecorder()
+		article.HandleMarkAllAsRead(h, rr, httptest.NewRequest(http.MethodPost, "/api/articles/mark-all-read?"+query, nil))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rr.Code)
+		}
+		counts, err := h.DB.GetUnreadCountsForAllFeeds()
+		if err != nil {
+			t.Fatalf("GetUnreadCountsForAllFeeds: %v", err)
+		}
+		return counts
+	}
+
+	// An empty category is the uncategorized feeds, not everything
+	if counts := markAll("category="); counts[looseID] != 0 || counts[techID] != 1 {
+		t.Errorf("expected only the uncategorized feed marked, got %v", counts)
+	}
+	// A parent category covers its subcategories
+	if counts := markAll("category=Tech"); counts[techID] != 0 {
+		t.Errorf("expected the subcategory feed marked, got %v", counts)
+	}
+}
+
+func TestHandleWS_PushesUnreadCountChanges(t *testing.T) {
+	h := setupHandler(t)
+	feedID, err := h.DB.AddFeed(&models.Feed{Title: "Test Feed", URL: "http://example.com"})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+	if err := h.DB.SaveArticles(context.Background(), []*models.Article{{FeedID: feedID, Title: "A", URL: "http://example.com/a", PublishedAt: time.Now()}}); err != nil {
+		t.Fatalf("SaveArticles: %v", err)
+	}
+	articles, err := h.DB.GetArticles("", feedID, "", false, 10, 0)
+	if err != nil || len(articles) == 0 {
+		t.Fatalf("GetArticles: %v", err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { article.HandleWS(h, w, r) }))
+	defer srv.Close()
+
+	conn, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), "", srv.URL)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+
+	receive := func() events.Event {
+		t.Helper()
+		conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		var e events.Event
+		if err := websocket.JSON.Receive(conn, &e); err != nil {
+			t.Fatalf("Receive: %v", err)
+		}
+		return e
+	}
+
+	// The current refresh progress is sent on connect
+	if e := receive(); e.Type != events.TypeRefreshProgress {
+		t.Fatalf("expected initial %s event, got %s", events.TypeRefreshProgress, e.Type)
+	}
+
+	rr := httptest.NewRecorder()
+	article.HandleMarkReadWithImmediateSync(h, rr, httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/articles/read?id=%d", articles[0].ID), nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	if e := receive(); e.Type != events.TypeUnreadCountsChanged {
+		t.Fatalf("expected %s event, got %s", events.TypeUnreadCountsChanged, e.Type)
+	}
+}
+
+func TestHandleWS_ConnectionCap(t *testing.T) {
+	h := setupHandler(t)
+	for i := 0; i < events.MaxSubscribers; i++ {
+		_, unsubscribe, err := h.Events.Subscribe()
+		if err != nil {
+			t.Fatalf("Subscribe: %v", err)
+		}
+		defer unsubscribe()
+	}
+
+	rr := httptest.NewRecorder()
+	article.HandleWS(h, rr, httptest.NewRequest(http.MethodGet, "/api/ws", nil))
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 when the hub is full, got %d", rr.Code)
+	}
+}
+
+func TestHandleGetArticleContent_ContentLanguage(t *testing.T) {
+	h := setupHandler(t)
+
+	feedID, err := h.DB.AddFeed(&models.Feed{Title: "F", URL: "http://x"})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+	if err := h.DB.SaveArticles(context.Background(), []*models.Article{{FeedID: feedID, Title: "a1", URL: "u1", PublishedAt: time.Now()}}); err != nil {
+		t.Fatalf("SaveArticles: %v", err)
+	}
+	all, _ := h.DB.GetArticles("", 0, "", false, 10, 0)
+	id := all[0].ID
+
+	get := func() map[string]interface{} {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		article.HandleGetArticleContent(h, rr, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/articles/content?id=%d", id), nil))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200 got %d: %s", rr.Code, rr.Body.String())
+		}
+		var resp map[string]interface{}
+		if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode failed: %v", err)
+		}
+		return resp
+	}
+
+	// Without a stored language the content is detected
+	if err := h.DB.SetArticleContent(id, "<p>هذه مقالة تجريبية عن التكنولوجيا والبرمجة في العالم العربي.</p>"); err != nil {
+		t.Fatalf("SetArticleContent: %v", err)
+	}
+	if resp := get(); resp["content_language"] != "ar" || resp["content_direction"] != "rtl" {
+		t.Errorf("expected detected ar/rtl, got %v/%v", resp["content_language"], resp["content_direction"])
+	}
+
+	// A stored language wins over detection
+	if _, err := h.DB.UpdateArticleDetectedLanguages(map[int64]string{id: "en"}); err != nil {
+		t.Fatalf("UpdateArticleDetectedLanguages: %v", err)
+	}
+	if resp := get(); resp["content_language"] != "en" || resp["content_direction"] != "ltr" {
+		t.Errorf("expected stored en/ltr, got %v/%v", resp["content_language"], resp["content_direction"])
+	}
+}
+
+func TestHandleHighlights(t *testing.T) {
+	h := setupHandler(t)
+
+	feedID, err := h.DB.AddFeed(&models.Feed{Title: "Research", URL: "http://x"})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+	if err := h.DB.SaveArticles(context.Background(), []*models.Article{
+		{FeedID: feedID, Title: "First", URL: "http://x/1", PublishedAt: time.Now()},
+		{FeedID: feedID, Title: "Second", URL: "http://x/2", PublishedAt: time.Now().Add(-time.Hour)},
+	}); err != nil {
+		t.Fatalf("SaveArticles: %v", err)
+	}
+	all, _ := h.DB.GetArticles("", 0, "", false, 10, 0)
+	first, second := all[0].ID, all[1].ID
+
+	add := func(articleID int64, quote, note string) (int, int64) {
+		t.Helper()
+		body := fmt.Sprintf(`{"article_id":%d,"quoted_text":%q,"note":%q}`, articleID, quote, note)
+		rr := httptest.NewRecorder()
+		article.HandleAddHighlight(h, rr, httptest.NewRequest(http.MethodPost, "/api/articles/highlights/add", strings.NewReader(body)))
+		var resp database.Highlight
+		json.NewDecoder(rr.Body).Decode(&resp)
+		return rr.Code, resp.ID
+	}
+
+	if code, _ := add(first, "  Quoted\nverbatim  ", "worth citing"); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	code, secondID := add(second, "Another passage", "")
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if code, _ := add(first, "   ", ""); code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an empty quote, got %d", code)
+	}
+	if code, _ := add(9999, "Orphan", ""); code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown article, got %d", code)
+	}
+
+	rr := httptest.NewRecorder()
+	article.HandleListHighlights(h, rr, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/articles/highlights?article_id=%d", first), nil))
+	var listed []database.Highlight
+	if err := json.NewDecoder(rr.Body).Decode(&listed); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if len(listed) != 1 || listed[0].QuotedText != "  Quoted\nverbatim  " || listed[0].Note != "worth citing" {
+		t.Fatalf("unexpected highlights: %+v", listed)
+	}
+
+	// Highlights come back with the article content
+	rr = httptest.NewRecorder()
+	h.DB.SetArticleContent(first, "<p>Quoted verbatim</p>")
+	article.HandleGetArticleContent(h, rr, httptest.NewRequest(http.MethodGet, fmt.Sprintf("/articles/content?id=%d", first), nil))
+	var content struct {
+		Highlights []database.Highlight `json:"highlights"`
+	}
+	json.NewDecoder(rr.Body).Decode(&content)
+	if len(content.Highlights) != 1 {
+		t.Errorf("expected 1 highlight with the content, got %d", len(content.Highlights))
+	}
+
+	rr = httptest.NewRecorder()
+	article.HandleExportHighlightsMarkdown(h, rr, httptest.NewRequest(http.MethodGet, "/api/articles/highlights/export", nil))
+	md := rr.Body.String()
+	for _, want := range []string{"## First", ">   Quoted\n> verbatim  ", "worth citing", "## Second", "> Another passage", "**Feed:** Research"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected export to contain %q:\n%s", want, md)
+		}
+	}
+
+	// Highlights keep their article's title and link after cleanup removes the article
+	if _, err := h.DB.Exec(`DELETE FROM articles WHERE id = ?`, first); err != nil {
+		t.Fatalf("failed to delete article: %v", err)
+	}
+	rr = httptest.NewRecorder()
+	article.HandleExportHighlightsMarkdown(h, rr, httptest.NewRequest(http.MethodGet, "/api/articles/highlights/export", nil))
+	md = rr.Body.String()
+	for _, want := range []string{"## First", "**Source:** http://x/1", "**Feed:** Research"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected export of a removed article to contain %q:\n%s", want, md)
+		}
+	}
+
+	rr = httptest.NewRecorder()
+	article.HandleDeleteHighlight(h, rr, httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/articles/highlights/delete?id=%d", secondID), nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	rr = httptest.NewRecorder()
+	article.HandleDeleteHighlight(h, rr, httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/articles/highlights/delete?id=%d", secondID), nil))
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for a deleted highlight, got %d", rr.Code)
+	}
+	if remaining, _ := h.DB.GetHighlights(0); len(remaining) != 1 {
+		t.Errorf("expected 1 highlight left, got %d", len(remaining))
+	}
+}
+
+func TestHandleStorageAdvisor(t *testing.T) {
+	h := setupHandler(t)
+	feedID, _ := h.DB.AddFeed(&models.Feed{Title: "F", URL: "http://x"})
+	for i := 0; i < 20; i++ {
+		a := &models.Article{FeedID: feedID, Title: fmt.Sprintf("a%d", i), URL: fmt.Sprintf("u%d", i), PublishedAt: time.Now()}
+		if err := h.DB.SaveArticle(a); err != nil {
+			t.Fatalf("SaveArticle: %v", err)
+		}
+	}
+	h.DB.SetSetting("max_cache_size_mb", "2000")
+	h.DB.SetSetting("auto_cleanup_enabled", "false")
+
+	rr := httptest.NewRecorder()
+	article.HandleStorageAdvisor(h, rr, httptest.NewRequest(http.MethodGet, "/api/articles/storage-advisor", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var advice struct {
+		FeedCount              int     `json:"feed_count"`
+		ArticleCount           int     `json:"article_count"`
+		DailyArticles          float64 `json:"daily_articles"`
+		MaxCacheSizeMB         int     `json:"max_cache_size_mb"`
+		RecommendedCacheSizeMB int     `json:"recommended_cache_size_mb"`
+		RecommendedCleanupDays int     `json:"recommended_cleanup_days"`
+		Report                 string  `json:"report"`
+	}
+	json.NewDecoder(rr.Body).Decode(&advice)
+	if advice.FeedCount != 1 || advice.ArticleCount != 20 || advice.MaxCacheSizeMB != 2000 {
+		t.Errorf("unexpected measurements %+v", advice)
+	}
+	// Everything was stored just now, so the window shrinks to a single day
+	if advice.DailyArticles != 20 {
+		t.Errorf("expected 20 articles a day, got %v", advice.DailyArticles)
+	}
+	// A tiny database gets the smallest recommendation
+	if advice.RecommendedCacheSizeMB != 100 {
+		t.Errorf("expected a 100 MB recommendation, got %d", advice.RecommendedCacheSizeMB)
+	}
+	if advice.RecommendedCleanupDays < 1 || advice.RecommendedCleanupDays > 30 {
+		t.Errorf("cleanup interval %d out of range", advice.RecommendedCleanupDays)
+	}
+	for _, want := range []string{"20 articles from 1 feeds", "Recommended cache size: 100 MB (currently 2000 MB)", "Auto cleanup is off"} {
+		if !strings.Contains(advice.Report, want) {
+			t.Errorf("expected the report to contain %q: %s", want, advice.Report)
+		}
+	}
+
+	rr = httptest.NewRecorder()
+	article.HandleStorageAdvisor(h, rr, httptest.NewRequest(http.MethodPost, "/api/articles/storage-advisor", nil))
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,235 @@
+package article
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"MrRSS/internal/database"
+	"MrRSS/internal/handlers/core"
+)
+
+// AddHighlightRequest represents the request for saving a highlighted passage
+type AddHighlightRequest struct {
+	ArticleID  int64  `json:"article_id"`
+	QuotedText string `json:"quoted_text"`
+	Note       string `json:"note"`
+}
+
+// HandleAddHighlight saves a passage quoted from an article
+// @Summary      Add highlight
+// @Description  Save a passage quoted from an article, with an optional note. The quoted text is stored verbatim.
+// @Tags         articles
+// @Accept       json
+// @Produce      json
+// @Param        request  body      AddHighlightRequest  true  "Highlight"
+// @Success      200  {object}  database.Highlight  "Saved highlight"
+// @Failure      400  {object}  map[string]string  "Bad request (invalid article ID or empty quote)"
+// @Failure      404  {object}  map[string]string  "Article not found"
+// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Router       /articles/highlights/add [post]
+func HandleAddHighlight(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req AddHighlightRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.ArticleID <= 0 {
+		http.Error(w, "Invalid article ID", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.QuotedText) == "" {
+		http.Error(w, "quoted_text is required", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.DB.GetArticleByID(req.ArticleID); err != nil {
+		http.Error(w, "Article not found", http.StatusNotFound)
+		return
+	}
+
+	note := strings.TrimSpace(req.Note)
+	id, err := h.DB.AddHighlight(req.ArticleID, req.QuotedText, note)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(database.Highlight{
+		ID:         id,
+		ArticleID:  req.ArticleID,
+		QuotedText: req.QuotedText,
+		Note:       note,
+		CreatedAt:  time.Now(),
+	})
+}
+
+// HandleListHighlights lists saved highlights
+// @Summary      List highlights
+// @Description  List the highlights of an article in the order they were made, or of every article when article_id is omitted
+// @Tags         articles
+// @Produce      json
+// @Param        article_id  query     int64  false  "Article ID"
+// @Success      200  {array}   database.Highlight  "Highlights"
+// @Failure      400  {object}  map[string]string  "Invalid article ID"
+// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Router       /articles/highlights [get]
+func HandleListHighlights(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	articleID, ok := optionalArticleID(w, r)
+	if !ok {
+		return
+	}
+
+	highlights, err := h.DB.GetHighlights(articleID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(highlights)
+}
+
+// HandleDeleteHighlight removes a highlight
+// @Summary      Delete highlight
+// @Description  Delete a saved highlight
+// @Tags         articles
+// @Produce      json
+// @Param        id   query     int64  true  "Highlight ID"
+// @Success      200  {object}  map[string]bool  "Success status"
+// @Failure      400  {object}  map[string]string  "Invalid highlight ID"
+// @Failure      404  {object}  map[string]string  "Highlight not found"
+// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Router       /articles/highlights/delete [post]
+func HandleDeleteHighlight(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodDelete {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "Invalid highlight ID", http.StatusBadRequest)
+		return
+	}
+
+	found, err := h.DB.DeleteHighlight(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !found {
+		http.Error(w, "Highlight not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]bool{"success": true})
+}
+
+// HandleExportHighlightsMarkdown returns highlights as a Markdown file download
+// @Summary      Download highlights as Markdown
+// @Description  Export the highlights of an article, or of every article when article_id is omitted, as a Markdown file with one section per article
+// @Tags         articles
+// @Produce      text/markdown
+// @Param        article_id  query     int64  false  "Article ID"
+// @Success      200  {file}    file  "Markdown file"
+// @Failure      400  {object}  map[string]string  "Invalid article ID"
+// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Router       /articles/highlights/export [get]
+func HandleExportHighlightsMarkdown(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	articleID, ok := optionalArticleID(w, r)
+	if !ok {
+		return
+	}
+
+	highlights, err := h.DB.GetHighlights(articleID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	filename := "Highlights.md"
+	if articleID > 0 {
+		filename = fmt.Sprintf("Highlights_%d.md", articleID)
+		if len(highlights) > 0 {
+			if title := sanitizeFilename(highlights[0].ArticleTitle); title != "" {
+				filename = "Highlights - " + title + ".md"
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.Write([]byte(generateHighlightsMarkdown(highlights)))
+}
+
+// optionalArticleID parses the article_id query parameter, returning 0 when it is absent.
+// It writes a 400 response and returns false when the parameter is invalid.
+func optionalArticleID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	articleIDStr := r.URL.Query().Get("article_id")
+	if articleIDStr == "" {
+		return 0, true
+	}
+	articleID, err := strconv.ParseInt(articleIDStr, 10, 64)
+	if err != nil || articleID <= 0 {
+		http.Error(w, "Invalid article ID", http.StatusBadRequest)
+		return 0, false
+	}
+	return articleID, true
+}
+
+// generateHighlightsMarkdown renders highlights, already grouped by article, as Markdown
+// with a heading per article and each quote as a blockquote followed by its note
+func generateHighlightsMarkdown(highlights []database.Highlight) string {
+	var sb strings.Builder
+	sb.WriteString("# Highlights\n\n")
+
+	currentArticle := int64(-1)
+	for _, hl := range highlights {
+		if hl.ArticleID != currentArticle {
+			currentArticle = hl.ArticleID
+			title := hl.ArticleTitle
+			if title == "" {
+				title = fmt.Sprintf("Article %d", hl.ArticleID)
+			}
+			sb.WriteString(fmt.Sprintf("## %s\n\n", title))
+			if hl.FeedTitle != "" {
+				sb.WriteString(fmt.Sprintf("**Feed:** %s\n\n", hl.FeedTitle))
+			}
+			if hl.ArticleURL != "" {
+				sb.WriteString(fmt.Sprintf("**Source:** %s\n\n", htmlEncodeURL(hl.ArticleURL)))
+			}
+		}
+
+		for _, line := range strings.Split(hl.QuotedText, "\n") {
+			sb.WriteString("> " + line + "\n")
+		}
+		if hl.Note != "" {
+			sb.WriteString("\n" + hl.Note + "\n")
+		}
+		sb.WriteString(fmt.Sprintf("\n*%s*\n\n", hl.CreatedAt.Format("2006-01-02 15:04")))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,214 @@
+package article
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"MrRSS/internal/handlers/core"
+	"MrRSS/internal/models"
+	"MrRSS/internal/utils"
+)
+
+const (
+	// MaxPrefetchArticles caps how many articles a single prefetch job may warm
+	MaxPrefetchArticles = 200
+	// prefetchConcurrency is the number of feeds fetched in parallel by a prefetch job
+	prefetchConcurrency = 4
+	// prefetchFeedTimeout bounds the time spent fetching a single feed
+	prefetchFeedTimeout = 30 * time.Second
+	// prefetchJobRetention is how long finished jobs stay queryable
+	prefetchJobRetention = 10 * time.Minute
+)
+
+// PrefetchJob reports the progress of a background category prefetch
+type PrefetchJob struct {
+	ID         string     `json:"id"`
+	Category   string     `json:"category"`
+	Total      int        `json:"total"`
+	Cached     int        `json:"cached"`
+	Skipped    int        `json:"skipped"`
+	Failed     int        `json:"failed"`
+	Done       bool       `json:"done"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+var (
+	prefetchMu   sync.Mutex
+	prefetchJobs = make(map[string]*PrefetchJob)
+)
+
+// HandlePrefetchCategory warms the content cache for the unread articles of a category.
+// @Summary      Prefetch category content
+// @Description  Start a background job that fetches and caches the content of unread articles in a category. Returns immediately with a job handle.
+// @Tags         articles
+// @Accept       json
+// @Produce      json
+// @Param        category  query     string  true   "Category path (empty for uncategorized)"
+// @Param        limit     query     int     false  "Maximum number of articles to prefetch (capped at 200)"
+// @Success      202  {object}  PrefetchJob  "Prefetch job"
+// @Failure      400  {object}  map[string]string  "Bad request (missing category)"
+// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Router       /articles/prefetch-category [post]
+func HandlePrefetchCategory(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if _, exists := r.URL.Query()["category"]; !exists {
+		http.Error(w, "category is required", http.StatusBadRequest)
+		return
+	}
+	category := r.URL.Query().Get("category")
+	// Same convention as HandleArticles: empty string means uncategorized
+	dbCategory := category
+	if dbCategory == "" {
+		dbCategory = "\x00"
+	}
+
+	limit := MaxPrefetchArticles
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 && l < limit {
+		limit = l
+	}
+
+	articles, err := h.DB.GetArticles("unread", 0, dbCategory, false, limit, 0)
+	if err != nil {
+		utils.ContextLog(r.Context(), "Error listing articles for prefetch: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	job := &PrefetchJob{
+		ID:        utils.NewRequestID(),
+		Category:  category,
+		Total:     len(articles),
+		StartedAt: time.Now(),
+	}
+	registerPrefetchJob(job)
+
+	go runPrefetchJob(h, job, articles)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(snapshotPrefetchJob(job.ID))
+}
+
+// HandleGetPrefetchStatus returns the progress of a prefetch job.
+// @Summary      Get prefetch status
+// @Description  Get the progress of a category prefetch job
+// @Tags         articles
+// @Accept       json
+// @Produce      json
+// @Param        job_id  query     string  true  "Prefetch job ID"
+// @Success      200  {object}  PrefetchJob  "Prefetch job"
+// @Failure      404  {object}  map[string]string  "Job not found"
+// @Router       /articles/prefetch-status [get]
+func HandleGetPrefetchStatus(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	job := snapshotPrefetchJob(r.URL.Query().Get("job_id"))
+	if job == nil {
+		http.Error(w, "Prefetch job not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(job)
+}
+
+// runPrefetchJob groups the articles by feed and caches their content,
+// fetching each feed once with at most prefetchConcurrency feeds in flight
+func runPrefetchJob(h *core.Handler, job *PrefetchJob, articles []models.Article) {
+	byFeed := make(map[int64][]models.Article)
+	for _, article := range articles {
+		if _, found, err := h.DB.GetArticleContent(article.ID); err == nil && found {
+			updatePrefetchJob(job.ID, func(j *PrefetchJob) { j.Skipped++ })
+			continue
+		}
+		byFeed[article.FeedID] = append(byFeed[article.FeedID], article)
+	}
+
+	sem := make(chan struct{}, prefetchConcurrency)
+	var wg sync.WaitGroup
+	for feedID, feedArticles := range byFeed {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(feedID int64, feedArticles []models.Article) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			cached, err := prefetchFeed(h, feedID, feedArticles)
+			if err != nil {
+				log.Printf("[Prefetch] Failed to prefetch feed %d: %v", feedID, err)
+			}
+			updatePrefetchJob(job.ID, func(j *PrefetchJob) {
+				j.Cached += cached
+				j.Failed += len(feedArticles) - cached
+			})
+		}(feedID, feedArticles)
+	}
+	wg.Wait()
+
+	updatePrefetchJob(job.ID, func(j *PrefetchJob) {
+		now := time.Now()
+		j.Done = true
+		j.FinishedAt = &now
+	})
+}
+
+// prefetchFeed caches the content of the given articles from a single feed
+func prefetchFeed(h *core.Handler, feedID int64, articles []models.Article) (int, error) {
+	targetFeed, err := h.DB.GetFeedByID(feedID)
+	if err != nil {
+		return 0, err
+	}
+	if targetFeed == nil {
+		return 0, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), prefetchFeedTimeout)
+	defer cancel()
+	return h.CacheFeedArticleContent(ctx, targetFeed, articles)
+}
+
+// registerPrefetchJob stores a job and drops finished jobs past their retention
+func registerPrefetchJob(job *PrefetchJob) {
+	prefetchMu.Lock()
+	defer prefetchMu.Unlock()
+	for id, j := range prefetchJobs {
+		if j.Done && j.FinishedAt != nil && time.Since(*j.FinishedAt) > prefetchJobRetention {
+			delete(prefetchJobs, id)
+		}
+	}
+	prefetchJobs[job.ID] = job
+}
+
+// updatePrefetchJob applies fn to the job under the registry lock
+func updatePrefetchJob(id string, fn func(*PrefetchJob)) {
+	prefetchMu.Lock()
+	defer prefetchMu.Unlock()
+	if job, ok := prefetchJobs[id]; ok {
+		fn(job)
+	}
+}
+
+// snapshotPrefetchJob returns a copy of the job, or nil if it is unknown
+func snapshotPrefetchJob(id string) *PrefetchJob {
+	prefetchMu.Lock()
+	defer prefetchMu.Unlock()
+	job, ok := prefetchJobs[id]
+	if !ok {
+		return nil
+	}
+	snapshot := *job
+	return &snapshot
+}
@@ -0,0 +1,92 @@
+package article_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"MrRSS/internal/handlers/article"
+	"MrRSS/internal/models"
+)
+
+func TestHandlePrefetchCategory(t *testing.T) {
+	rss := `<?xml version="1.0"?><rss version="2.0"><channel><title>T</title>
+<item><title>a1</title><link>http://example.com/a1</link><description>&lt;p&gt;hello prefetch&lt;/p&gt;</description></item>
+</channel></rss>`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		fmt.Fprint(w, rss)
+	}))
+	defer srv.Close()
+
+	h := setupHandler(t)
+	feedID, err := h.DB.AddFeed(&models.Feed{Title: "F", URL: srv.URL, Category: "Tech"})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+	articles := []*models.Article{
+		{FeedID: feedID, Title: "a1", URL: "http://example.com/a1", PublishedAt: time.Now()},
+	}
+	if err := h.DB.SaveArticles(context.Background(), articles); err != nil {
+		t.Fatalf("SaveArticles: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/articles/prefetch-category?category=Tech", nil)
+	w := httptest.NewRecorder()
+	article.HandlePrefetchCategory(h, w, req)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d: %s", w.Code, w.Body.String())
+	}
+	var job article.PrefetchJob
+	if err := json.NewDecoder(w.Body).Decode(&job); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if job.ID == "" || job.Total != 1 {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		req = httptest.NewRequest(http.MethodGet, "/api/articles/prefetch-status?job_id="+job.ID, nil)
+		w = httptest.NewRecorder()
+		article.HandleGetPrefetchStatus(h, w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("status: expected 200, got %d", w.Code)
+		}
+		if err := json.NewDecoder(w.Body).Decode(&job); err != nil {
+			t.Fatalf("decode status: %v", err)
+		}
+		if job.Done {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("prefetch did not finish: %+v", job)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if job.Cached != 1 {
+		t.Fatalf("expected 1 cached article, got %+v", job)
+	}
+
+	all, err := h.DB.GetArticles("", feedID, "", false, 10, 0)
+	if err != nil || len(all) != 1 {
+		t.Fatalf("GetArticles: %v (%d)", err, len(all))
+	}
+	if _, found, _ := h.DB.GetArticleContent(all[0].ID); !found {
+		t.Fatal("expected article content to be cached")
+	}
+}
+
+func TestHandleGetPrefetchStatus_NotFound(t *testing.T) {
+	h := setupHandler(t)
+	req := httptest.NewRequest(http.MethodGet, "/api/articles/prefetch-status?job_id=missing", nil)
+	w := httptest.NewRecorder()
+	article.HandleGetPrefetchStatus(h, w, req)
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", w.Code)
+	}
+}
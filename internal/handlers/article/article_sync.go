@@ -4,11 +4,14 @@ import (
 	"context"
 	"log"
 	"net/http"
+	"net/url"
 	"strconv"
+	"time"
 
 	"MrRSS/internal/database"
 	"MrRSS/internal/freshrss"
 	"MrRSS/internal/handlers/core"
+	"MrRSS/internal/utils"
 )
 
 // HandleMarkReadWithImmediateSync marks an article as read/unread and immediately syncs to FreshRSS
@@ -38,6 +41,7 @@ func HandleMarkReadWithImmediateSync(h *core.Handler, w http.ResponseWriter, r *
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	h.NotifyUnreadCountsChanged()
 
 	w.WriteHeader(http.StatusOK)
 
@@ -47,6 +51,101 @@ func HandleMarkReadWithImmediateSync(h *core.Handler, w http.ResponseWriter, r *
 	}
 }
 
+// HandleMarkReadAfterView marks an article as read once it has been in view for the
+// configured dwell time, flagging it as auto-read
+// @Summary      Auto-mark article as read after viewing
+// @Description  Mark an unread article as read because it stayed in view for at least auto_read_dwell_ms. The article is flagged as auto-read so it can be told apart from manual reads; the read is synced to FreshRSS if configured.
+// @Tags         articles
+// @Accept       json
+// @Produce      json
+// @Param        id         query     int64  true  "Article ID"
+// @Param        viewed_ms  query     int    true  "How long the article was in view, in milliseconds"
+// @Success      200  {string}  string  "Article marked as read"
+// @Failure      400  {object}  map[string]string  "Bad request (invalid ID or dwell time not reached)"
+// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Router       /articles/read-after-view [post]
+func HandleMarkReadAfterView(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	id, err := strconv.ParseInt(r.URL.Query().Get("id"), 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "Invalid article ID", http.StatusBadRequest)
+		return
+	}
+
+	viewedMs, _ := strconv.Atoi(r.URL.Query().Get("viewed_ms"))
+	dwellStr, _ := h.DB.GetSetting("auto_read_dwell_ms")
+	dwellMs, _ := strconv.Atoi(dwellStr)
+	if viewedMs < dwellMs {
+		http.Error(w, "Article was not viewed long enough", http.StatusBadRequest)
+		return
+	}
+
+	syncReq, err := h.DB.MarkArticleAutoReadWithSync(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	h.NotifyUnreadCountsChanged()
+
+	w.WriteHeader(http.StatusOK)
+
+	// Immediately sync to FreshRSS if needed
+	if syncReq != nil {
+		go performImmediateSync(h, syncReq)
+	}
+}
+
+// HandleOpenOriginal redirects to an article's original page with tracking parameters removed
+// @Summary      Open original article
+// @Description  Redirect to the article's original URL with tracking parameters (utm_*, fbclid, ...) stripped. Unless open_original_marks_read is disabled, the article is also marked read, counted in reading statistics and synced to FreshRSS if configured.
+// @Tags         articles
+// @Param        article_id  query     int64  true  "Article ID"
+// @Success      302  {string}  string  "Redirect to the original article"
+// @Failure      400  {object}  map[string]string  "Invalid article ID or article URL"
+// @Failure      404  {object}  map[string]string  "Article not found"
+// @Router       /articles/open-original [get]
+func HandleOpenOriginal(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("article_id"), 10, 64)
+	if err != nil || id <= 0 {
+		http.Error(w, "Invalid article ID", http.StatusBadRequest)
+		return
+	}
+
+	article, err := h.DB.GetArticleByID(id)
+	if err != nil {
+		http.Error(w, "Article not found", http.StatusNotFound)
+		return
+	}
+
+	// Only redirect to web pages, never to script or file URLs taken from a feed
+	target := utils.CleanURL(article.URL)
+	if parsed, err := url.Parse(target); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		http.Error(w, "Article has no web URL", http.StatusBadRequest)
+		return
+	}
+
+	if markRead, _ := h.DB.GetSetting("open_original_marks_read"); markRead != "false" && !article.IsRead {
+		syncReq, err := h.DB.MarkArticleReadWithSync(id, true)
+		if err != nil {
+			utils.ContextLog(r.Context(), "Error marking article %d read on open: %v", id, err)
+		} else {
+			if h.Stats != nil {
+				h.Stats.TrackArticleRead()
+			}
+			h.NotifyUnreadCountsChanged()
+			if syncReq != nil {
+				go performImmediateSync(h, syncReq)
+			}
+		}
+	}
+
+	http.Redirect(w, r, target, http.StatusFound)
+}
+
 // HandleToggleFavoriteWithImmediateSync toggles favorite and immediately syncs to FreshRSS
 // @Summary      Toggle article favorite status with immediate FreshRSS sync
 // @Description  Toggle the favorite/starred status of an article and immediately sync to FreshRSS if configured
@@ -90,6 +189,12 @@ func performImmediateSync(h *core.Handler, syncReq *database.SyncRequest) {
 		return
 	}
 
+	// Collect changes made in quick succession into one push
+	if delay := h.FreshRSSPushDelay(); delay > 0 {
+		h.FreshRSSPush.Add(*syncReq, delay)
+		return
+	}
+
 	// Create sync service
 	syncService := freshrss.NewBidirectionalSyncService(serverURL, username, password, h.DB)
 
@@ -105,3 +210,24 @@ func performImmediateSync(h *core.Handler, syncReq *database.SyncRequest) {
 		log.Printf("[Immediate Sync] Success for article %d: %s", syncReq.ArticleID, syncReq.Action)
 	}
 }
+
+// syncStreamMarkedRead marks a FreshRSS stream read on the server, up to the time it was
+// marked read locally
+func syncStreamMarkedRead(h *core.Handler, streamID string, olderThan time.Time) {
+	enabled, _ := h.DB.GetSetting("freshrss_enabled")
+	if enabled != "true" {
+		return
+	}
+
+	serverURL, username, password, err := h.DB.GetFreshRSSConfig()
+	if err != nil || serverURL == "" || username == "" || password == "" {
+		return
+	}
+
+	syncService := freshrss.NewBidirectionalSyncService(serverURL, username, password, h.DB)
+	if err := syncService.MarkStreamAsRead(context.Background(), streamID, olderThan); err != nil {
+		log.Printf("[Immediate Sync] Failed to mark stream %s as read: %v", streamID, err)
+	} else {
+		log.Printf("[Immediate Sync] Marked stream %s as read", streamID)
+	}
+}
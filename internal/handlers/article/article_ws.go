@@ -0,0 +1,85 @@
+package article
+
+import (
+	"net/http"
+	"time"
+
+	"MrRSS/internal/events"
+	"MrRSS/internal/handlers/core"
+	"MrRSS/internal/utils"
+
+	"golang.org/x/net/websocket"
+)
+
+// wsWriteTimeout bounds a single event write so a stalled client cannot pin the connection
+const wsWriteTimeout = 10 * time.Second
+
+// HandleWS pushes live updates to the client over a WebSocket.
+// @Summary      Live updates WebSocket
+// @Description  Upgrade to a WebSocket that pushes JSON events of the form {"type": ..., "data": ...}. Types are refresh_progress (fetch progress), new_articles (feed_counts map of feed ID to new article count, plus total) and unread_counts_changed (refetch /articles/unread-counts). Slow clients lose their oldest events.
+// @Tags         articles
+// @Success      101  {string}  string  "Switching protocols"
+// @Failure      503  {object}  map[string]string  "Too many live update connections"
+// @Router       /ws [get]
+func HandleWS(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	// Subscribe before upgrading so a full hub can still be answered with a plain HTTP error
+	updates, unsubscribe, err := h.Events.Subscribe()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	defer unsubscribe()
+
+	server := websocket.Server{
+		Handler: func(conn *websocket.Conn) {
+			defer conn.Close()
+			serveEvents(h, conn, updates)
+		},
+	}
+	server.ServeHTTP(w, r)
+}
+
+// serveEvents writes events to the connection until the client goes away
+func serveEvents(h *core.Handler, conn *websocket.Conn, updates <-chan events.Event) {
+	// The client never sends anything meaningful; reading only detects disconnects
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		var discard string
+		for {
+			if err := websocket.Message.Receive(conn, &discard); err != nil {
+				return
+			}
+		}
+	}()
+
+	if h.Fetcher != nil {
+		if !sendEvent(conn, events.Event{Type: events.TypeRefreshProgress, Data: h.Fetcher.GetProgressWithStats()}) {
+			return
+		}
+	}
+
+	for {
+		select {
+		case <-closed:
+			return
+		case event, ok := <-updates:
+			if !ok {
+				return
+			}
+			if !sendEvent(conn, event) {
+				return
+			}
+		}
+	}
+}
+
+// sendEvent writes a single JSON event, reporting whether the connection is still usable
+func sendEvent(conn *websocket.Conn, event events.Event) bool {
+	conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	if err := websocket.JSON.Send(conn, event); err != nil {
+		utils.DebugLog("[HandleWS] Closing connection after write error: %v", err)
+		return false
+	}
+	return true
+}
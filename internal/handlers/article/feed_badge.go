@@ -0,0 +1,89 @@
+package article
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+
+	"MrRSS/internal/handlers/core"
+	"MrRSS/internal/models"
+)
+
+// maxBadgeInitials caps the length of a short name derived from the feed title.
+const maxBadgeInitials = 2
+
+// attachFeedBadges sets the feed badge of each article from a single feed lookup
+// when show_feed_badge is enabled, so the list needs no per-article feed request.
+func attachFeedBadges(h *core.Handler, articles []models.Article) error {
+	if len(articles) == 0 {
+		return nil
+	}
+	if enabled, _ := h.DB.GetSetting("show_feed_badge"); enabled != "true" {
+		return nil
+	}
+
+	feeds, err := h.DB.GetFeeds()
+	if err != nil {
+		return err
+	}
+	badges := make(map[int64]*models.FeedBadge, len(feeds))
+	for i := range feeds {
+		badges[feeds[i].ID] = feedBadge(&feeds[i])
+	}
+
+	for i := range articles {
+		articles[i].FeedBadge = badges[articles[i].FeedID]
+	}
+	return nil
+}
+
+// feedBadge builds the badge for a feed: its list prefix or the title's initials,
+// and its image or the site's favicon, as the sidebar shows it.
+func feedBadge(feed *models.Feed) *models.FeedBadge {
+	shortName := feed.ListPrefix
+	if shortName == "" {
+		shortName = titleInitials(feed.Title)
+	}
+	iconURL := feed.ImageURL
+	if iconURL == "" {
+		iconURL = faviconURL(feed.URL)
+	}
+	return &models.FeedBadge{ShortName: shortName, IconURL: iconURL}
+}
+
+// titleInitials returns the uppercased first letters of the title's first words,
+// or the first letters of the title when it is a single word.
+func titleInitials(title string) string {
+	words := strings.FieldsFunc(title, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return ""
+	}
+
+	var initials []rune
+	if len(words) == 1 {
+		initials = []rune(words[0])
+		if len(initials) > maxBadgeInitials {
+			initials = initials[:maxBadgeInitials]
+		}
+	} else {
+		for _, word := range words {
+			if len(initials) == maxBadgeInitials {
+				break
+			}
+			initials = append(initials, []rune(word)[0])
+		}
+	}
+	return strings.ToUpper(string(initials))
+}
+
+// faviconURL returns the favicon service URL for the host of a feed URL.
+func faviconURL(feedURL string) string {
+	u, err := url.Parse(feedURL)
+	if err != nil || u.Hostname() == "" {
+		return ""
+	}
+	return fmt.Sprintf("https://www.google.com/s2/favicons?domain=%s", u.Hostname())
+}
@@ -0,0 +1,184 @@
+package article
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"MrRSS/internal/database"
+	"MrRSS/internal/handlers/core"
+)
+
+const (
+	// ingestWindow is how far back the daily ingest rate is measured
+	ingestWindow = 14 * 24 * time.Hour
+	// storageOverhead scales the measured article text to its share of the database,
+	// covering indexes, translation and summary caches and free pages
+	storageOverhead = 1.5
+	// cacheHeadroom leaves room above the projected size for bursts of new articles
+	cacheHeadroom = 1.25
+	// minRecommendedCacheMB and cacheRoundingMB keep the recommendation a plain number
+	minRecommendedCacheMB = 100
+	cacheRoundingMB       = 50
+)
+
+// storageAdvice is the projected database growth and the settings recommended for it
+type storageAdvice struct {
+	SizeMB                 float64 `json:"size_mb"`
+	FeedCount              int     `json:"feed_count"`
+	ArticleCount           int     `json:"article_count"`
+	AvgArticleKB           float64 `json:"avg_article_kb"`
+	AvgContentKB           float64 `json:"avg_content_kb"`
+	DailyArticles          float64 `json:"daily_articles"`
+	DailyGrowthMB          float64 `json:"daily_growth_mb"`
+	Projected30DayMB       float64 `json:"projected_30_day_mb"`
+	SteadyStateMB          float64 `json:"steady_state_mb"`
+	MaxCacheSizeMB         int     `json:"max_cache_size_mb"`
+	MaxArticleAgeDays      int     `json:"max_article_age_days"`
+	DaysUntilLimit         int     `json:"days_until_limit"` // -1 when the database isn't growing
+	RecommendedCacheSizeMB int     `json:"recommended_cache_size_mb"`
+	RecommendedCleanupDays int     `json:"recommended_cleanup_days"`
+	Report                 string  `json:"report"`
+}
+
+// HandleStorageAdvisor projects how fast the database grows and recommends a cache size
+// and cleanup cadence for it.
+// @Summary      Get storage advice
+// @Description  Measure article sizes and the daily ingest rate, project database growth and recommend max_cache_size_mb and a cleanup interval
+// @Tags         articles
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}  "Projection, recommendations and a short report"
+// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Router       /articles/storage-advisor [get]
+func HandleStorageAdvisor(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	usage, err := h.DB.GetStorageUsage(ingestWindow)
+	if err != nil {
+		log.Printf("Error measuring storage usage: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	maxCacheMB := positiveSetting(h, "max_cache_size_mb", 500)
+	maxAgeDays := positiveSetting(h, "max_article_age_days", 30)
+	autoCleanup, _ := h.DB.GetSetting("auto_cleanup_enabled")
+
+	advice := adviseStorage(usage, maxCacheMB, maxAgeDays, autoCleanup == "true")
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(advice)
+}
+
+// positiveSetting reads an integer setting, using the default when it is unset or invalid,
+// the same way cleanup does
+func positiveSetting(h *core.Handler, key string, def int) int {
+	value, err := h.DB.GetSetting(key)
+	if err != nil {
+		return def
+	}
+	if n, err := strconv.Atoi(value); err == nil && n > 0 {
+		return n
+	}
+	return def
+}
+
+// adviseStorage projects growth from the measured usage. Age-based cleanup keeps about
+// maxAgeDays of articles, so the database levels off at that many days of ingest on top
+// of what isn't articles.
+func adviseStorage(usage database.StorageUsage, maxCacheMB, maxAgeDays int, autoCleanup bool) storageAdvice {
+	const mb = 1024 * 1024
+
+	bytesPerArticle := usage.AvgArticleBytes
+	if usage.ArticleCount > 0 {
+		// Only some articles have their full content cached
+		bytesPerArticle += usage.AvgContentBytes * float64(usage.ContentCount) / float64(usage.ArticleCount)
+	}
+	bytesPerArticle *= storageOverhead
+
+	dailyArticles := float64(usage.RecentArticles) / usage.WindowDays
+	dailyGrowthMB := dailyArticles * bytesPerArticle / mb
+	baseMB := math.Max(usage.SizeMB-float64(usage.ArticleCount)*bytesPerArticle/mb, 0)
+	steadyStateMB := baseMB + dailyGrowthMB*float64(maxAgeDays)
+
+	recommendedMB := int(math.Ceil(steadyStateMB*cacheHeadroom/cacheRoundingMB)) * cacheRoundingMB
+	if recommendedMB < minRecommendedCacheMB {
+		recommendedMB = minRecommendedCacheMB
+	}
+
+	daysUntilLimit := -1
+	if usage.SizeMB >= float64(maxCacheMB) {
+		daysUntilLimit = 0
+	} else if dailyGrowthMB > 0 {
+		daysUntilLimit = int((float64(maxCacheMB) - usage.SizeMB) / dailyGrowthMB)
+	}
+
+	// Clean up about as often as a tenth of the recommended size arrives
+	cleanupDays := 30
+	if dailyGrowthMB > 0 {
+		cleanupDays = int(float64(recommendedMB) * 0.1 / dailyGrowthMB)
+	}
+	cleanupDays = max(1, min(cleanupDays, 30))
+
+	advice := storageAdvice{
+		SizeMB:                 round2(usage.SizeMB),
+		FeedCount:              usage.FeedCount,
+		ArticleCount:           usage.ArticleCount,
+		AvgArticleKB:           round2(usage.AvgArticleBytes / 1024),
+		AvgContentKB:           round2(usage.AvgContentBytes / 1024),
+		DailyArticles:          round2(dailyArticles),
+		DailyGrowthMB:          round2(dailyGrowthMB),
+		Projected30DayMB:       round2(usage.SizeMB + dailyGrowthMB*30),
+		SteadyStateMB:          round2(steadyStateMB),
+		MaxCacheSizeMB:         maxCacheMB,
+		MaxArticleAgeDays:      maxAgeDays,
+		DaysUntilLimit:         daysUntilLimit,
+		RecommendedCacheSizeMB: recommendedMB,
+		RecommendedCleanupDays: cleanupDays,
+	}
+	advice.Report = storageReport(advice, autoCleanup)
+	return advice
+}
+
+// storageReport summarizes the advice in a few sentences
+func storageReport(a storageAdvice, autoCleanup bool) string {
+	lines := []string{
+		fmt.Sprintf("The database is %.1f MB with %d articles from %d feeds.", a.SizeMB, a.ArticleCount, a.FeedCount),
+		fmt.Sprintf("About %.0f articles arrive per day, adding roughly %.2f MB per day.", a.DailyArticles, a.DailyGrowthMB),
+		fmt.Sprintf("Keeping articles for %d days, it should level off around %.0f MB.", a.MaxArticleAgeDays, a.SteadyStateMB),
+	}
+
+	switch {
+	case a.DaysUntilLimit == 0:
+		lines = append(lines, fmt.Sprintf("It is already over the %d MB cache limit.", a.MaxCacheSizeMB))
+	case a.DaysUntilLimit > 0 && a.DaysUntilLimit <= 30:
+		lines = append(lines, fmt.Sprintf("Without cleanup it reaches the %d MB cache limit in about %d days.", a.MaxCacheSizeMB, a.DaysUntilLimit))
+	}
+
+	if a.RecommendedCacheSizeMB == a.MaxCacheSizeMB {
+		lines = append(lines, fmt.Sprintf("The current cache size of %d MB fits.", a.MaxCacheSizeMB))
+	} else {
+		lines = append(lines, fmt.Sprintf("Recommended cache size: %d MB (currently %d MB).", a.RecommendedCacheSizeMB, a.MaxCacheSizeMB))
+	}
+
+	cleanup := fmt.Sprintf("Clean up every %d days.", a.RecommendedCleanupDays)
+	if a.RecommendedCleanupDays == 1 {
+		cleanup = "Clean up daily."
+	}
+	if !autoCleanup {
+		cleanup += " Auto cleanup is off, so enable it or clean up manually."
+	}
+	return strings.Join(append(lines, cleanup), " ")
+}
+
+// round2 rounds to two decimals for display
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,68 @@
+package article
+
+import (
+	"strings"
+	"unicode"
+
+	"MrRSS/internal/models"
+)
+
+// collapseDuplicateTitles folds articles of the same feed whose titles are near-identical
+// into the first (highest-ranked) of them. The representative keeps its position and gets
+// the IDs of the whole group plus the number of articles folded into it.
+func collapseDuplicateTitles(articles []models.Article) []models.Article {
+	collapsed := make([]models.Article, 0, len(articles))
+	groups := make(map[titleGroupKey]int)
+
+	for _, article := range articles {
+		key := titleGroupKey{feedID: article.FeedID, title: normalizeTitleForGrouping(article.Title)}
+		if key.title == "" {
+			collapsed = append(collapsed, article)
+			continue
+		}
+		if idx, ok := groups[key]; ok {
+			rep := &collapsed[idx]
+			rep.DuplicateCount++
+			rep.DuplicateIDs = append(rep.DuplicateIDs, article.ID)
+			continue
+		}
+		groups[key] = len(collapsed)
+		article.DuplicateIDs = []int64{article.ID}
+		collapsed = append(collapsed, article)
+	}
+
+	// A lone article is not a group
+	for i := range collapsed {
+		if collapsed[i].DuplicateCount == 0 {
+			collapsed[i].DuplicateIDs = nil
+		}
+	}
+	return collapsed
+}
+
+type titleGroupKey struct {
+	feedID int64
+	title  string
+}
+
+// normalizeTitleForGrouping reduces a title to its lowercase words so that titles differing
+// only in case, punctuation or numbering ("Weekly Roundup #12", "Weekly roundup – 13")
+// group together
+func normalizeTitleForGrouping(title string) string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// paginateArticles returns the page of articles starting at offset
+func paginateArticles(articles []models.Article, limit, offset int) []models.Article {
+	if offset >= len(articles) {
+		return []models.Article{}
+	}
+	end := offset + limit
+	if end > len(articles) {
+		end = len(articles)
+	}
+	return articles[offset:end]
+}
@@ -3,13 +3,14 @@ package chat
 import (
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"MrRSS/internal/ai"
+	"MrRSS/internal/aiusage"
 	"MrRSS/internal/handlers/core"
 	"MrRSS/internal/utils"
 )
@@ -48,36 +49,111 @@ type ChatResponse struct {
 // @Failure      500  {object}  map[string]string  "Internal server error"
 // @Router       /chat [post]
 func HandleAIChat(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	call, ok := prepareChat(h, w, r)
+	if !ok {
+		return
+	}
+
+	// Send chat request using universal client
+	result, err := call.client.RequestWithMessages(call.messagesMap)
+	if err != nil {
+		utils.ContextLog(r.Context(), "AI chat request failed: %v", err)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": "No response from AI"})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(finishChat(h, r, call, result))
+}
+
+// HandleAIChatStream handles chat requests like HandleAIChat, streaming the answer as it arrives
+// @Summary      AI chat with article (streaming)
+// @Description  Send messages to AI for discussing article content and stream the answer as server-sent events: "delta" events with each piece of raw content, then a "done" event with the final response and html (thinking tags removed), or an "error" event
+// @Tags         chat
+// @Accept       json
+// @Produce      text/event-stream
+// @Param        request  body      chat.ChatRequest  true  "Chat request (messages, article info)"
+// @Success      200  {string}  string  "Stream of delta events ({content}) followed by a done event (chat.ChatResponse)"
+// @Failure      400  {object}  map[string]string  "Bad request (missing messages)"
+// @Failure      403  {object}  map[string]string  "AI chat is disabled"
+// @Failure      500  {object}  map[string]string  "Streaming not supported"
+// @Router       /ai-chat/stream [post]
+func HandleAIChatStream(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	call, ok := prepareChat(h, w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	result, err := call.client.RequestStreamWithMessages(call.messagesMap, func(delta string) error {
+		writeSSEEvent(w, "delta", map[string]string{"content": delta})
+		flusher.Flush()
+		// Stop generating once the client has gone away
+		return r.Context().Err()
+	})
+	if err != nil {
+		utils.ContextLog(r.Context(), "AI chat stream failed: %v", err)
+		writeSSEEvent(w, "error", map[string]string{"error": "No response from AI"})
+		flusher.Flush()
+		return
+	}
+
+	writeSSEEvent(w, "done", finishChat(h, r, call, result))
+	flusher.Flush()
+}
+
+// chatCall is a validated chat request with the client to send it with
+type chatCall struct {
+	messages    []ChatMessage
+	messagesMap []map[string]string
+	client      *ai.Client
+}
+
+// prepareChat decodes and checks a chat request and sets up the AI client. When it returns
+// false the response has been written.
+func prepareChat(h *core.Handler, w http.ResponseWriter, r *http.Request) (*chatCall, bool) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
+		return nil, false
 	}
 
 	var req ChatRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+		return nil, false
 	}
 
 	if len(req.Messages) == 0 {
 		http.Error(w, "Missing messages", http.StatusBadRequest)
-		return
+		return nil, false
 	}
 
 	// Check if AI chat is enabled
 	chatEnabled, _ := h.DB.GetSetting("ai_chat_enabled")
 	if chatEnabled != "true" {
 		http.Error(w, "AI chat is disabled", http.StatusForbidden)
-		return
+		return nil, false
 	}
 
 	// Check if AI usage limit is reached
 	if h.AITracker.IsLimitReached() {
-		log.Printf("AI usage limit reached for chat")
+		utils.ContextLog(r.Context(), "AI usage limit reached for chat")
 		json.NewEncoder(w).Encode(map[string]string{
 			"error": "AI usage limit reached",
 		})
-		return
+		return nil, false
 	}
 
 	// Apply rate limiting for AI requests
@@ -110,55 +186,63 @@ func HandleAIChat(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 	// Create HTTP client with proxy support if configured
 	httpClient, err := createHTTPClientWithProxy(h)
 	if err != nil {
-		log.Printf("Failed to create HTTP client with proxy: %v", err)
+		utils.ContextLog(r.Context(), "Failed to create HTTP client with proxy: %v", err)
 		httpClient = &http.Client{Timeout: 60 * time.Second}
 	} else {
 		httpClient.Timeout = 60 * time.Second
 	}
 
 	// Create AI client
+	maxTokens := 0
+	if s, err := h.DB.GetSetting("ai_chat_max_tokens"); err == nil {
+		maxTokens, _ = strconv.Atoi(s)
+	}
 	clientConfig := ai.ClientConfig{
-		APIKey:   apiKey,
-		Endpoint: endpoint,
-		Model:    model,
-		Timeout:  60 * time.Second,
+		APIKey:    apiKey,
+		Endpoint:  endpoint,
+		Model:     model,
+		Timeout:   60 * time.Second,
+		MaxTokens: maxTokens,
+		Fallbacks: ai.LoadEndpoints(h.DB),
 	}
-	client := ai.NewClientWithHTTPClient(clientConfig, httpClient)
 
-	// Send chat request using universal client
-	result, err := client.RequestWithMessages(messagesMap)
-	if err != nil {
-		log.Printf("AI chat request failed: %v", err)
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(map[string]string{"error": "No response from AI"})
-		return
-	}
+	return &chatCall{
+		messages:    optimizedMessages,
+		messagesMap: messagesMap,
+		client:      ai.NewClientWithHTTPClient(clientConfig, httpClient).WithContext(r.Context()),
+	}, true
+}
 
+// finishChat strips thinking tags from the assembled answer, renders it and tracks usage
+func finishChat(h *core.Handler, r *http.Request, call *chatCall, result ai.ResponseResult) ChatResponse {
 	// Extract thinking content and remove tags
-	response := result.Content
-	thinking := ai.ExtractThinking(response)
-	response = ai.RemoveThinkingTags(response)
+	thinking := ai.ExtractThinking(result.Content)
+	response := ai.RemoveThinkingTags(result.Content)
 
 	// Convert markdown response to HTML
 	htmlResponse := utils.ConvertMarkdownToHTML(response)
 
 	// Log thinking if present (for debugging)
 	if thinking != "" {
-		log.Printf("AI chat thinking: %s", thinking)
+		utils.ContextLog(r.Context(), "AI chat thinking: %s", thinking)
 	}
 
-	// Track AI usage (estimate tokens from input and output)
-	estimatedTokens := estimateChatTokens(optimizedMessages, response)
-	if err := h.AITracker.AddUsage(int64(estimatedTokens)); err != nil {
-		log.Printf("Warning: failed to track AI usage: %v", err)
-	}
+	// Track AI usage, estimated from input and output when the API didn't report it
+	h.AITracker.TrackUsage(result.Usage, estimateChatTokens(call.messages, response))
 
 	// Track statistics
 	_ = h.DB.IncrementStat("ai_chat")
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(ChatResponse{Response: response, HTML: htmlResponse})
+	return ChatResponse{Response: response, HTML: htmlResponse}
+}
+
+// writeSSEEvent writes a named server-sent event with a JSON payload
+func writeSSEEvent(w http.ResponseWriter, event string, data interface{}) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
 }
 
 // optimizeChatContext reduces the chat context to save tokens while preserving important information
@@ -185,19 +269,12 @@ func optimizeChatContext(messages []ChatMessage, articleTitle, articleURL, artic
 }
 
 // estimateChatTokens estimates the number of tokens used for a chat request/response
-func estimateChatTokens(messages []ChatMessage, response string) int {
-	// Rough estimation: ~4 characters per token
-	totalChars := 0
+func estimateChatTokens(messages []ChatMessage, response string) int64 {
+	tokens := aiusage.EstimateTokens(response)
 	for _, msg := range messages {
-		totalChars += len(msg.Content)
+		tokens += aiusage.EstimateTokens(msg.Content)
 	}
-	totalChars += len(response)
-
-	// Add some overhead for JSON formatting and API overhead
-	totalChars = int(float64(totalChars) * 1.2)
-
-	// Estimate tokens (roughly 4 characters per token for English)
-	return totalChars / 4
+	return tokens
 }
 
 // createHTTPClientWithProxy creates an HTTP client with global proxy settings if enabled
@@ -1,10 +1,13 @@
 package core
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"MrRSS/internal/database"
 	"MrRSS/internal/feed"
+	"MrRSS/internal/utils"
 )
 
 func TestNewHandler_ConstructsHandler(t *testing.T) {
@@ -29,3 +32,29 @@ func TestNewHandler_ConstructsHandler(t *testing.T) {
 		t.Fatal("DiscoveryService should be initialized")
 	}
 }
+
+func TestRequestLoggingMiddleware_PropagatesRequestID(t *testing.T) {
+	var seen string
+	handler := RequestLoggingMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = utils.RequestIDFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/feeds", nil)
+	req.Header.Set(utils.RequestIDHeader, "abc123")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if seen != "abc123" {
+		t.Fatalf("expected request ID in context, got %q", seen)
+	}
+	if got := rr.Header().Get(utils.RequestIDHeader); got != "abc123" {
+		t.Fatalf("expected request ID header, got %q", got)
+	}
+
+	// Without an incoming header a new ID is generated
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/feeds", nil))
+	if rr.Header().Get(utils.RequestIDHeader) == "" || seen == "" {
+		t.Fatal("expected a generated request ID")
+	}
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"maps"
+	"time"
+)
+
+// FeedSummaryTTL is how long a feed overview is reused while the feed's articles are unchanged
+const FeedSummaryTTL = 15 * time.Minute
+
+// feedSummaryEntry is a cached feed overview, valid while the feed's article set matches
+type feedSummaryEntry struct {
+	articleKey string
+	expiresAt  time.Time
+	response   map[string]interface{}
+}
+
+// CachedFeedSummary returns a copy of the cached overview for a feed, marked as cached.
+// articleKey identifies the articles the overview was built from; when it differs
+// (new, read or removed articles) the entry is dropped and no result is returned.
+func (h *Handler) CachedFeedSummary(feedID int64, articleKey string) (map[string]interface{}, bool) {
+	h.feedSummaryMu.Lock()
+	defer h.feedSummaryMu.Unlock()
+
+	entry, ok := h.feedSummaryCache[feedID]
+	if !ok {
+		return nil, false
+	}
+	if entry.articleKey != articleKey || time.Now().After(entry.expiresAt) {
+		delete(h.feedSummaryCache, feedID)
+		return nil, false
+	}
+
+	response := maps.Clone(entry.response)
+	response["cached"] = true
+	return response, true
+}
+
+// StoreFeedSummary caches a feed overview built from the articles identified by articleKey.
+func (h *Handler) StoreFeedSummary(feedID int64, articleKey string, response map[string]interface{}) {
+	h.feedSummaryMu.Lock()
+	defer h.feedSummaryMu.Unlock()
+
+	if h.feedSummaryCache == nil {
+		h.feedSummaryCache = make(map[int64]feedSummaryEntry)
+	}
+	h.feedSummaryCache[feedID] = feedSummaryEntry{
+		articleKey: articleKey,
+		expiresAt:  time.Now().Add(FeedSummaryTTL),
+		response:   maps.Clone(response),
+	}
+}
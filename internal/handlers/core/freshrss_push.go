@@ -0,0 +1,52 @@
+package core
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"MrRSS/internal/database"
+	"MrRSS/internal/freshrss"
+)
+
+// FreshRSSPushDelay returns how long read and star changes are collected before they are
+// pushed to FreshRSS, from freshrss_push_delay_seconds. Zero pushes each change at once.
+func (h *Handler) FreshRSSPushDelay() time.Duration {
+	value, err := h.DB.GetSetting("freshrss_push_delay_seconds")
+	if err != nil || value == "" {
+		return freshrss.DefaultPushDelay
+	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return freshrss.DefaultPushDelay
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// FlushFreshRSSPushes pushes the changes still collected for FreshRSS, so none are lost
+// on shutdown
+func (h *Handler) FlushFreshRSSPushes(ctx context.Context) {
+	if h.FreshRSSPush.Pending() == 0 {
+		return
+	}
+	log.Printf("[Push Batch] Flushing %d pending FreshRSS changes", h.FreshRSSPush.Pending())
+	h.FreshRSSPush.Flush(ctx)
+}
+
+// pushFreshRSSChanges pushes a batch collected by FreshRSSPush
+func (h *Handler) pushFreshRSSChanges(ctx context.Context, changes []database.SyncRequest) error {
+	enabled, _ := h.DB.GetSetting("freshrss_enabled")
+	if enabled != "true" {
+		return nil
+	}
+
+	serverURL, username, password, err := h.DB.GetFreshRSSConfig()
+	if err != nil || serverURL == "" || username == "" || password == "" {
+		log.Printf("[Push Batch] FreshRSS not configured, skipping sync")
+		return nil
+	}
+
+	syncService := freshrss.NewBidirectionalSyncService(serverURL, username, password, h.DB)
+	return syncService.PushStatusChanges(ctx, changes)
+}
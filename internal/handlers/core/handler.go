@@ -15,7 +15,9 @@ import (
 	"MrRSS/internal/cache"
 	"MrRSS/internal/database"
 	"MrRSS/internal/discovery"
+	"MrRSS/internal/events"
 	"MrRSS/internal/feed"
+	"MrRSS/internal/freshrss"
 	"MrRSS/internal/models"
 	"MrRSS/internal/statistics"
 	"MrRSS/internal/translation"
@@ -41,6 +43,17 @@ type DiscoveryState struct {
 	Feeds      []discovery.DiscoveredBlog `json:"feeds,omitempty"`
 	Error      string                     `json:"error,omitempty"`
 	IsComplete bool                       `json:"is_complete"`
+	// CompletedAt is set when the operation finishes; completed state expires after discovery_result_ttl
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// LanguageRedetectState reports the progress of a background article language re-detection
+type LanguageRedetectState struct {
+	IsRunning bool   `json:"is_running"`
+	Processed int    `json:"processed"`
+	Total     int    `json:"total"`
+	Updated   int    `json:"updated"`
+	Error     string `json:"error,omitempty"`
 }
 
 // Handler holds all dependencies for HTTP handlers.
@@ -49,27 +62,51 @@ type Handler struct {
 	Fetcher          *feed.Fetcher
 	Translator       translation.Translator
 	AITracker        *aiusage.Tracker
+	AIPool           *aiusage.WorkerPool // Shared limit for bulk AI operations
 	DiscoveryService *discovery.Service
 	App              interface{}         // Wails app instance for browser integration (interface{} to avoid import in server mode)
 	ContentCache     *cache.ContentCache // Cache for article content
 	Stats            *statistics.Service // Statistics tracking service
+	Events           *events.Hub         // Live updates pushed to WebSocket clients
+
+	// Read and star changes waiting to be pushed to FreshRSS in one batch
+	FreshRSSPush *freshrss.PushBatcher
 
 	// Discovery state tracking for polling-based progress
 	DiscoveryMu          sync.RWMutex
 	SingleDiscoveryState *DiscoveryState
 	BatchDiscoveryState  *DiscoveryState
+
+	// Start of the last manual refresh, for min_manual_refresh_interval throttling
+	refreshMu         sync.Mutex
+	lastManualRefresh time.Time
+
+	// Cached AI feed overviews by feed ID, see CachedFeedSummary
+	feedSummaryMu    sync.Mutex
+	feedSummaryCache map[int64]feedSummaryEntry
+
+	// Language re-detection state tracking for polling-based progress
+	LanguageRedetectMu    sync.RWMutex
+	LanguageRedetectState *LanguageRedetectState
 }
 
 // NewHandler creates a new Handler with the given dependencies.
 func NewHandler(db *database.DB, fetcher *feed.Fetcher, translator translation.Translator) *Handler {
+	tracker := aiusage.NewTracker(db)
 	h := &Handler{
 		DB:               db,
 		Fetcher:          fetcher,
 		Translator:       translator,
-		AITracker:        aiusage.NewTracker(db),
+		AITracker:        tracker,
+		AIPool:           aiusage.NewWorkerPool(tracker),
 		DiscoveryService: discovery.NewService(),
 		ContentCache:     cache.NewContentCache(100, 30*time.Minute), // Cache up to 100 articles for 30 minutes
 		Stats:            statistics.NewService(db),
+		Events:           events.NewHub(events.MaxSubscribers),
+	}
+	h.FreshRSSPush = freshrss.NewPushBatcher(h.pushFreshRSSChanges)
+	if fetcher != nil {
+		fetcher.SetEventHub(h.Events)
 	}
 
 	return h
@@ -94,6 +131,27 @@ func (h *Handler) SetApp(app interface{}) {
 	h.App = app
 }
 
+// NotifyUnreadCountsChanged tells live-update clients to refresh their unread counts.
+// Safe to call when no hub is configured.
+func (h *Handler) NotifyUnreadCountsChanged() {
+	h.Events.Publish(events.TypeUnreadCountsChanged, nil)
+}
+
+// ReserveManualRefresh records the start of a manual refresh. If the previous one started
+// less than minInterval ago nothing is recorded and the remaining cooldown is returned
+// with false.
+func (h *Handler) ReserveManualRefresh(minInterval time.Duration) (time.Duration, bool) {
+	h.refreshMu.Lock()
+	defer h.refreshMu.Unlock()
+
+	now := time.Now()
+	if remaining := h.lastManualRefresh.Add(minInterval).Sub(now); remaining > 0 {
+		return remaining, false
+	}
+	h.lastManualRefresh = now
+	return 0, true
+}
+
 // Statistics returns the statistics service
 func (h *Handler) Statistics() *statistics.Service {
 	return h.Stats
@@ -136,34 +194,75 @@ func (h *Handler) GetArticleContent(articleID int64) (string, bool, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// Fetch the feed immediately (article click triggered)
-	h.Fetcher.FetchFeedForArticle(ctx, *targetFeed)
+	feedContent := func() (string, error) {
+		// Fetch the feed immediately (article click triggered)
+		h.Fetcher.FetchFeedForArticle(ctx, *targetFeed)
 
-	// Parse the feed to get fresh content
-	parsedFeed, err := h.Fetcher.ParseFeedWithFeed(ctx, targetFeed, true) // High priority for content fetching
-	if err != nil {
+		// Parse the feed to get fresh content
+		parsedFeed, err := h.Fetcher.ParseFeedWithFeed(ctx, targetFeed, true) // High priority for content fetching
+		if err != nil {
+			return "", err
+		}
+
+		// Cache the feed for future use
+		h.ContentCache.SetFeed(targetFeed.ID, parsedFeed)
+
+		// Find the article in the feed by multiple criteria for better matching
+		matchingItem := h.findMatchingFeedItem(article, parsedFeed.Items)
+		if matchingItem == nil {
+			return "", nil
+		}
+		return utils.CleanHTML(feed.ExtractContent(matchingItem)), nil
+	}
+
+	var cleanContent string
+	if len(targetFeed.ContentStrategy) == 0 {
+		cleanContent, err = feedContent()
+	} else {
+		cleanContent, _ = h.Fetcher.ExtractContentWithStrategies(ctx, *targetFeed, article.URL, feedContent)
+	}
+	if cleanContent == "" {
+		// Articles fetched in metadata-only mode still have their snippet, e.g. while offline
+		if snippet, _ := h.DB.GetArticleSnippet(articleID); snippet != "" {
+			return snippet, false, nil
+		}
 		return "", false, err
 	}
 
-	// Cache the feed for future use
-	h.ContentCache.SetFeed(targetFeed.ID, parsedFeed)
+	// Cache the content in both memory and database
+	h.ContentCache.Set(articleID, cleanContent)
+	if err := h.DB.SetArticleContent(articleID, cleanContent); err != nil {
+		log.Printf("Error caching content to database: %v", err)
+	}
 
-	// Find the article in the feed by multiple criteria for better matching
-	matchingItem := h.findMatchingFeedItem(article, parsedFeed.Items)
-	if matchingItem != nil {
-		content := feed.ExtractContent(matchingItem)
-		cleanContent := utils.CleanHTML(content)
+	return cleanContent, false, nil
+}
+
+// CacheFeedArticleContent parses a feed once and caches the content of every given
+// article found in it. Used for bulk prefetching, where fetching the feed per article
+// would hammer the source. Returns the number of articles that were cached.
+func (h *Handler) CacheFeedArticleContent(ctx context.Context, targetFeed *models.Feed, articles []models.Article) (int, error) {
+	parsedFeed, err := h.Fetcher.ParseFeedWithFeed(ctx, targetFeed, false)
+	if err != nil {
+		return 0, err
+	}
+	h.ContentCache.SetFeed(targetFeed.ID, parsedFeed)
 
-		// Cache the content in both memory and database
-		h.ContentCache.Set(articleID, cleanContent)
-		if err := h.DB.SetArticleContent(articleID, cleanContent); err != nil {
+	cached := 0
+	for i := range articles {
+		matchingItem := h.findMatchingFeedItem(&articles[i], parsedFeed.Items)
+		if matchingItem == nil {
+			continue
+		}
+		cleanContent := utils.CleanHTML(feed.ExtractContent(matchingItem))
+		h.ContentCache.Set(articles[i].ID, cleanContent)
+		if err := h.DB.SetArticleContent(articles[i].ID, cleanContent); err != nil {
 			log.Printf("Error caching content to database: %v", err)
+			continue
 		}
-
-		return cleanContent, false, nil
+		cached++
 	}
-
-	return "", false, nil
+	return cached, nil
 }
 
 // FetchFullArticleContent fetches the full article content from the original URL using readability.
@@ -0,0 +1,65 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"MrRSS/internal/utils"
+)
+
+// statusRecorder captures the response status code for request logging.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// Flush keeps streaming responses working through the middleware.
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack allows connection upgrades through the middleware.
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	return hj.Hijack()
+}
+
+// RequestLoggingMiddleware assigns a correlation ID to every request.
+// The ID is taken from the X-Request-ID header when present, otherwise generated,
+// stored in the request context for utils.ContextLog, and echoed in the response.
+// Failed and slow requests are always logged; everything else only in debug mode.
+func RequestLoggingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(utils.RequestIDHeader)
+		if requestID == "" || len(requestID) > 64 {
+			requestID = utils.NewRequestID()
+		}
+
+		w.Header().Set(utils.RequestIDHeader, requestID)
+		ctx := utils.WithRequestID(r.Context(), requestID)
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		start := time.Now()
+		next.ServeHTTP(rec, r.WithContext(ctx))
+		elapsed := time.Since(start)
+
+		if rec.status >= http.StatusBadRequest || elapsed > 5*time.Second {
+			utils.ContextLog(ctx, "%s %s -> %d (%s)", r.Method, r.URL.Path, rec.status, elapsed.Round(time.Millisecond))
+		} else {
+			utils.DebugLog("[req=%s] %s %s -> %d (%s)", requestID, r.Method, r.URL.Path, rec.status, elapsed.Round(time.Millisecond))
+		}
+	})
+}
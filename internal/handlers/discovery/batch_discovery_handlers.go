@@ -6,10 +6,10 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"time"
 
 	"MrRSS/internal/discovery"
 	"MrRSS/internal/handlers/core"
-	"MrRSS/internal/models"
 )
 
 // HandleDiscoverAllFeeds discovers feeds from all subscriptions that haven't been discovered yet.
@@ -27,8 +27,8 @@ func HandleDiscoverAllFeeds(h *core.Handler, w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	// Get all feeds
-	feeds, err := h.DB.GetFeeds()
+	// Get feeds that haven't been discovered yet
+	feedsToDiscover, err := h.DB.GetUndiscoveredFeeds()
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -41,14 +41,6 @@ func HandleDiscoverAllFeeds(h *core.Handler, w http.ResponseWriter, r *http.Requ
 		subscribedURLs = make(map[string]bool) // Continue with empty set
 	}
 
-	// Filter feeds that haven't been discovered yet
-	var feedsToDiscover []models.Feed
-	for _, feed := range feeds {
-		if !feed.DiscoveryCompleted {
-			feedsToDiscover = append(feedsToDiscover, feed)
-		}
-	}
-
 	if len(feedsToDiscover) == 0 {
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"message":         "All feeds have already been discovered",
@@ -61,9 +53,13 @@ func HandleDiscoverAllFeeds(h *core.Handler, w http.ResponseWriter, r *http.Requ
 	// Discover feeds with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), core.BatchDiscoveryTimeout)
 	defer cancel()
+	// Sources often link to the same blogs; check each candidate feed once per run
+	ctx = discovery.WithValidationRun(ctx, discoveryValidationOptions(h))
 
 	allDiscovered := make(map[string][]discovery.DiscoveredBlog)
 	discoveredCount := 0
+	targetCount := discoveryTargetCount(h)
+	feedTimeout := discoveryFeedTimeout(h)
 
 	log.Printf("Starting batch discovery for %d feeds", len(feedsToDiscover))
 
@@ -77,7 +73,9 @@ discoveryLoop:
 		}
 
 		log.Printf("Discovering from feed: %s (%s)", feed.Title, feed.URL)
-		discovered, err := h.DiscoveryService.DiscoverFromFeed(ctx, feed.URL)
+		feedCtx, feedCancel := context.WithTimeout(ctx, feedTimeout)
+		discovered, err := h.DiscoveryService.DiscoverFromFeed(feedCtx, feed.URL)
+		feedCancel()
 		if err != nil {
 			log.Printf("Error discovering from feed %s: %v", feed.Title, err)
 			continue
@@ -100,6 +98,11 @@ discoveryLoop:
 		if err := h.DB.MarkFeedDiscovered(feed.ID); err != nil {
 			log.Printf("Error marking feed as discovered: %v", err)
 		}
+
+		if targetCount > 0 && discoveredCount >= targetCount {
+			log.Printf("Batch discovery target of %d feeds reached", targetCount)
+			break
+		}
 	}
 
 	log.Printf("Batch discovery complete: discovered %d feeds from %d sources", discoveredCount, len(feedsToDiscover))
@@ -113,7 +116,7 @@ discoveryLoop:
 
 // HandleStartBatchDiscovery starts batch discovery in the background.
 // @Summary      Start batch discovery
-// @Description  Start an asynchronous blog discovery process for all undiscovered feeds
+// @Description  Start an asynchronous blog discovery process for all undiscovered feeds. Each source feed is crawled for at most discovery_feed_timeout seconds, and the run stops early with stage target_reached once discovery_target_count new feeds were found (0 for no limit).
 // @Tags         discovery
 // @Accept       json
 // @Produce      json
@@ -146,8 +149,8 @@ func HandleStartBatchDiscovery(h *core.Handler, w http.ResponseWriter, r *http.R
 	}
 	h.DiscoveryMu.Unlock()
 
-	// Get all feeds
-	feeds, err := h.DB.GetFeeds()
+	// Get feeds that haven't been discovered yet
+	feedsToDiscover, err := h.DB.GetUndiscoveredFeeds()
 	if err != nil {
 		h.DiscoveryMu.Lock()
 		h.BatchDiscoveryState.IsRunning = false
@@ -165,14 +168,6 @@ func HandleStartBatchDiscovery(h *core.Handler, w http.ResponseWriter, r *http.R
 		subscribedURLs = make(map[string]bool)
 	}
 
-	// Filter feeds that haven't been discovered yet
-	var feedsToDiscover []models.Feed
-	for _, feed := range feeds {
-		if !feed.DiscoveryCompleted {
-			feedsToDiscover = append(feedsToDiscover, feed)
-		}
-	}
-
 	if len(feedsToDiscover) == 0 {
 		h.DiscoveryMu.Lock()
 		h.BatchDiscoveryState.IsRunning = false
@@ -196,23 +191,25 @@ func HandleStartBatchDiscovery(h *core.Handler, w http.ResponseWriter, r *http.R
 	go func() {
 		ctx, cancel := context.WithTimeout(context.Background(), core.BatchDiscoveryTimeout)
 		defer cancel()
+		// Sources often link to the same blogs; check each candidate feed once per run
+		ctx = discovery.WithValidationRun(ctx, discoveryValidationOptions(h))
 
 		allDiscovered := make(map[string][]discovery.DiscoveredBlog)
 		discoveredCount := 0
+		sourcesProcessed := 0
+		targetReached := false
+		timedOut := false
+		targetCount := discoveryTargetCount(h)
+		feedTimeout := discoveryFeedTimeout(h)
 
 		log.Printf("Starting background batch discovery for %d feeds", len(feedsToDiscover))
 
 		for i, feed := range feedsToDiscover {
-			select {
-			case <-ctx.Done():
+			// The remaining feeds stay undiscovered for the next run, and what was found so far is kept
+			if ctx.Err() != nil {
 				log.Println("Batch discovery cancelled: timeout")
-				h.DiscoveryMu.Lock()
-				h.BatchDiscoveryState.IsRunning = false
-				h.BatchDiscoveryState.IsComplete = true
-				h.BatchDiscoveryState.Error = "Discovery timeout"
-				h.DiscoveryMu.Unlock()
-				return
-			default:
+				timedOut = true
+				break
 			}
 
 			// Update progress
@@ -245,7 +242,10 @@ func HandleStartBatchDiscovery(h *core.Handler, w http.ResponseWriter, r *http.R
 				h.DiscoveryMu.Unlock()
 			}
 
-			discovered, err := h.DiscoveryService.DiscoverFromFeedWithProgress(ctx, feed.URL, feedProgressCb)
+			feedCtx, feedCancel := context.WithTimeout(ctx, feedTimeout)
+			discovered, err := h.DiscoveryService.DiscoverFromFeedWithProgress(feedCtx, feed.URL, feedProgressCb)
+			feedCancel()
+			sourcesProcessed++
 			if err != nil {
 				log.Printf("Error discovering from feed %s: %v", feed.Title, err)
 				if err := h.DB.MarkFeedDiscovered(feed.ID); err != nil {
@@ -274,9 +274,15 @@ func HandleStartBatchDiscovery(h *core.Handler, w http.ResponseWriter, r *http.R
 			if err := h.DB.MarkFeedDiscovered(feed.ID); err != nil {
 				log.Printf("Error marking feed as discovered: %v", err)
 			}
+
+			// The remaining feeds stay undiscovered for the next run
+			if targetCount > 0 && discoveredCount >= targetCount {
+				targetReached = true
+				break
+			}
 		}
 
-		log.Printf("Batch discovery complete: discovered %d feeds from %d sources", discoveredCount, len(feedsToDiscover))
+		log.Printf("Batch discovery complete: discovered %d feeds from %d sources", discoveredCount, sourcesProcessed)
 
 		// Update final state
 		h.DiscoveryMu.Lock()
@@ -284,16 +290,31 @@ func HandleStartBatchDiscovery(h *core.Handler, w http.ResponseWriter, r *http.R
 			h.BatchDiscoveryState.IsRunning = false
 			h.BatchDiscoveryState.IsComplete = true
 			h.BatchDiscoveryState.Progress.Stage = "complete"
-			h.BatchDiscoveryState.Progress.Message = fmt.Sprintf("Found %d feeds from %d sources", discoveredCount, len(feedsToDiscover))
+			h.BatchDiscoveryState.Progress.Message = fmt.Sprintf("Found %d feeds from %d sources", discoveredCount, sourcesProcessed)
+			if targetReached {
+				h.BatchDiscoveryState.Progress.Stage = "target_reached"
+				h.BatchDiscoveryState.Progress.Message = fmt.Sprintf("Found %d feeds from %d sources, target of %d reached", discoveredCount, sourcesProcessed, targetCount)
+			}
+			if timedOut {
+				h.BatchDiscoveryState.Error = "Discovery timeout"
+			}
 			h.BatchDiscoveryState.Progress.FoundCount = discoveredCount
-			// Store feeds as a slice for the response
+			// Store feeds as a slice for the response, in a stable order
 			var allFeedsSlice []discovery.DiscoveredBlog
-			for _, blogs := range allDiscovered {
-				allFeedsSlice = append(allFeedsSlice, blogs...)
+			for _, source := range sortedSources(allDiscovered) {
+				allFeedsSlice = append(allFeedsSlice, allDiscovered[source]...)
 			}
 			h.BatchDiscoveryState.Feeds = allFeedsSlice
+			now := time.Now()
+			h.BatchDiscoveryState.CompletedAt = &now
+			scheduleBatchStateExpiry(h, h.BatchDiscoveryState)
 		}
 		h.DiscoveryMu.Unlock()
+
+		// Persist results so they survive the in-memory state expiring or a restart
+		if err := persistDiscoveredBlogs(h, allDiscovered); err != nil {
+			log.Printf("Error persisting discovered blogs: %v", err)
+		}
 	}()
 
 	w.WriteHeader(http.StatusAccepted)
@@ -322,6 +343,11 @@ func HandleGetBatchDiscoveryProgress(h *core.Handler, w http.ResponseWriter, r *
 	h.DiscoveryMu.RUnlock()
 
 	if state == nil {
+		// In-memory state expired or was lost on restart; fall back to persisted results
+		if persisted := loadPersistedDiscoveryState(h); persisted != nil {
+			json.NewEncoder(w).Encode(persisted)
+			return
+		}
 		json.NewEncoder(w).Encode(&core.DiscoveryState{
 			IsRunning:  false,
 			IsComplete: false,
@@ -350,6 +376,48 @@ func HandleClearBatchDiscovery(h *core.Handler, w http.ResponseWriter, r *http.R
 	h.BatchDiscoveryState = nil
 	h.DiscoveryMu.Unlock()
 
+	if err := h.DB.ClearDiscoveredBlogs(); err != nil {
+		log.Printf("Error clearing persisted discovered blogs: %v", err)
+	}
+
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{"status": "cleared"})
 }
+
+// HandleGetUndiscoveredFeeds lists the feeds that batch discovery would process.
+// @Summary      List undiscovered feeds
+// @Description  Get the feeds that haven't been processed by discovery yet, with a total count
+// @Tags         discovery
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}  "Undiscovered feeds (total, feeds[id, title, url, category])"
+// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Router       /feeds/discover-all/pending [get]
+func HandleGetUndiscoveredFeeds(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	feeds, err := h.DB.GetUndiscoveredFeeds()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	type undiscoveredFeed struct {
+		ID       int64  `json:"id"`
+		Title    string `json:"title"`
+		URL      string `json:"url"`
+		Category string `json:"category"`
+	}
+	result := make([]undiscoveredFeed, 0, len(feeds))
+	for _, feed := range feeds {
+		result = append(result, undiscoveredFeed{ID: feed.ID, Title: feed.Title, URL: feed.URL, Category: feed.Category})
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"total": len(result),
+		"feeds": result,
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"MrRSS/internal/database"
+	"MrRSS/internal/discovery"
 	"MrRSS/internal/handlers/core"
 	"MrRSS/internal/models"
 )
@@ -133,3 +134,243 @@ func TestHandleDiscoverBlogs_Success(t *testing.T) {
 		t.Fatalf("expected 200 from clear, got %d", cw.Result().StatusCode)
 	}
 }
+
+func TestHandleNormalizeURL(t *testing.T) {
+	h := setupHandler(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write([]byte(`<?xml version="1.0"?><rss><channel><title>T</title></channel></rss>`))
+	}))
+	defer srv.Close()
+
+	body, _ := json.Marshal(map[string]string{"url": "  " + srv.URL + "/feed?utm_source=x  "})
+	req := httptest.NewRequest(http.MethodPost, "/api/feeds/normalize-url", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	HandleNormalizeURL(h, w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	var resp map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp["normalized_url"] != srv.URL+"/feed" || resp["is_feed"] != true {
+		t.Fatalf("unexpected response: %v", resp)
+	}
+
+	body, _ = json.Marshal(map[string]string{"url": "not a url"})
+	req = httptest.NewRequest(http.MethodPost, "/api/feeds/normalize-url", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	HandleNormalizeURL(h, w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid URL, got %d", w.Code)
+	}
+}
+
+func TestHandleGetUndiscoveredFeeds(t *testing.T) {
+	h := setupHandler(t)
+
+	pendingID, err := h.DB.AddFeed(&models.Feed{Title: "Pending", URL: "https://a.example.com/feed"})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+	doneID, err := h.DB.AddFeed(&models.Feed{Title: "Done", URL: "https://b.example.com/feed"})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+	if err := h.DB.MarkFeedDiscovered(doneID); err != nil {
+		t.Fatalf("MarkFeedDiscovered: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/feeds/discover-all/pending", nil)
+	w := httptest.NewRecorder()
+	HandleGetUndiscoveredFeeds(h, w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	var resp struct {
+		Total int `json:"total"`
+		Feeds []struct {
+			ID    int64  `json:"id"`
+			Title string `json:"title"`
+		} `json:"feeds"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if resp.Total != 1 || len(resp.Feeds) != 1 || resp.Feeds[0].ID != pendingID {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
+
+func TestHandleGetBatchDiscoveryProgress_PersistedResults(t *testing.T) {
+	h := setupHandler(t)
+
+	err := persistDiscoveredBlogs(h, map[string][]discovery.DiscoveredBlog{
+		"Source": {
+			{Name: "Blog A", RSSFeed: "https://a.example.com/feed", RecentArticles: []discovery.RecentArticle{{Title: "Hello"}}},
+			{Name: "Blog B", RSSFeed: "https://b.example.com/feed"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("persistDiscoveredBlogs: %v", err)
+	}
+	// Subscribed since discovery ran, so it should be dropped from the results
+	if _, err := h.DB.AddFeed(&models.Feed{Title: "B", URL: "https://b.example.com/feed"}); err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/feeds/discover-all/progress", nil)
+	w := httptest.NewRecorder()
+	HandleGetBatchDiscoveryProgress(h, w, req)
+
+	var state core.DiscoveryState
+	if err := json.NewDecoder(w.Body).Decode(&state); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !state.IsComplete || len(state.Feeds) != 1 || state.Feeds[0].Name != "Blog A" {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+	if len(state.Feeds[0].RecentArticles) != 1 {
+		t.Fatalf("recent articles not restored: %+v", state.Feeds[0])
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/api/feeds/discover-all/clear", nil)
+	HandleClearBatchDiscovery(h, httptest.NewRecorder(), req)
+	if blogs, _ := h.DB.GetDiscoveredBlogs(); len(blogs) != 0 {
+		t.Fatalf("expected persisted results cleared, got %d", len(blogs))
+	}
+}
+
+func TestHandleBulkSubscribe(t *testing.T) {
+	h := setupHandler(t)
+
+	existingID, err := h.DB.AddFeed(&models.Feed{Title: "Existing", URL: "https://existing.example.com/feed"})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+
+	body := `[
+		{"rss_feed": "https://new.example.com/feed", "title": "New", "category": "Blogs"},
+		{"rss_feed": "http://www.existing.example.com/feed/", "title": "Existing again"},
+		{"rss_feed": "https://new.example.com/feed/", "title": "New again"},
+		{"rss_feed": ""}
+	]`
+	req := httptest.NewRequest(http.MethodPost, "/api/feeds/discover/subscribe", bytes.NewReader([]byte(body)))
+	w := httptest.NewRecorder()
+	HandleBulkSubscribe(h, w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var resp struct {
+		Results []struct {
+			Status string `json:"status"`
+			FeedID int64  `json:"feed_id"`
+		} `json:"results"`
+		AddedIDs []int64 `json:"added_ids"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(resp.Results) != 4 || len(resp.AddedIDs) != 1 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+	want := []string{"added", "skipped", "skipped", "error"}
+	for i, s := range want {
+		if resp.Results[i].Status != s {
+			t.Errorf("result %d: status %q, want %q", i, resp.Results[i].Status, s)
+		}
+	}
+	if resp.Results[1].FeedID != existingID {
+		t.Errorf("expected skipped result to reference feed %d, got %d", existingID, resp.Results[1].FeedID)
+	}
+
+	feed, err := h.DB.GetFeedByID(resp.AddedIDs[0])
+	if err != nil || feed.Category != "Blogs" {
+		t.Fatalf("added feed not stored as expected: %+v, %v", feed, err)
+	}
+}
+
+func TestHandleStartBatchDiscovery_TargetReached(t *testing.T) {
+	h := setupHandler(t)
+	if err := h.DB.SetSetting("discovery_target_count", "1"); err != nil {
+		t.Fatalf("SetSetting error: %v", err)
+	}
+
+	friendRSS := `<?xml version="1.0"?><rss><channel><title>Friend</title><link>/</link><item><title>F1</title><link>/1</link><guid>1</guid></item></channel></rss>`
+	var friendSrv *httptest.Server
+	friendSrv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/friend1/rss" {
+			w.Header().Set("Content-Type", "application/rss+xml")
+			w.Write([]byte(friendRSS))
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<html><head><link rel="alternate" type="application/rss+xml" href="` + friendSrv.URL + `/friend1/rss"></head><body>friend</body></html>`))
+	}))
+	defer friendSrv.Close()
+
+	var mainSrv *httptest.Server
+	mainSrv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/feed1", "/feed2":
+			w.Header().Set("Content-Type", "application/rss+xml")
+			w.Write([]byte(`<?xml version="1.0"?><rss><channel><title>Main</title><link>` + mainSrv.URL + `/home</link></channel></rss>`))
+		case "/home":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`<html><body><a href="` + mainSrv.URL + `/links.html">Links</a></body></html>`))
+		case "/links.html":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`<html><body><a href="` + friendSrv.URL + `/friend1">Friend Site</a></body></html>`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer mainSrv.Close()
+
+	for _, path := range []string{"/feed1", "/feed2"} {
+		if _, err := h.DB.AddFeed(&models.Feed{Title: path, URL: mainSrv.URL + path}); err != nil {
+			t.Fatalf("AddFeed error: %v", err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	HandleStartBatchDiscovery(h, w, httptest.NewRequest(http.MethodPost, "/api/discovery/batch/start", nil))
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202 Accepted, got %d", w.Code)
+	}
+
+	var state core.DiscoveryState
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		pw := httptest.NewRecorder()
+		HandleGetBatchDiscoveryProgress(h, pw, httptest.NewRequest(http.MethodGet, "/api/discovery/batch/progress", nil))
+		if err := json.NewDecoder(pw.Body).Decode(&state); err != nil {
+			t.Fatalf("decode progress: %v", err)
+		}
+		if state.IsComplete {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if !state.IsComplete || state.Progress.Stage != "target_reached" {
+		t.Fatalf("expected discovery to stop with target_reached, got %+v", state)
+	}
+	if len(state.Feeds) != 1 {
+		t.Errorf("expected 1 discovered feed, got %d", len(state.Feeds))
+	}
+	// The second source was never crawled and stays pending for the next run
+	pending, err := h.DB.GetUndiscoveredFeeds()
+	if err != nil {
+		t.Fatalf("GetUndiscoveredFeeds error: %v", err)
+	}
+	if len(pending) != 1 {
+		t.Errorf("expected 1 feed left undiscovered, got %d", len(pending))
+	}
+}
@@ -0,0 +1,161 @@
+package discovery
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"MrRSS/internal/database"
+	"MrRSS/internal/discovery"
+	"MrRSS/internal/handlers/core"
+)
+
+// defaultDiscoveryResultTTL is how long completed batch results stay in memory
+const defaultDiscoveryResultTTL = time.Hour
+
+// discoveryResultTTL returns the configured lifetime of completed batch discovery state
+func discoveryResultTTL(h *core.Handler) time.Duration {
+	if s, err := h.DB.GetSetting("discovery_result_ttl"); err == nil {
+		if minutes, err := strconv.Atoi(s); err == nil && minutes > 0 {
+			return time.Duration(minutes) * time.Minute
+		}
+	}
+	return defaultDiscoveryResultTTL
+}
+
+// discoveryTargetCount returns the number of new feeds after which batch discovery stops
+// early, or 0 to crawl every undiscovered feed
+func discoveryTargetCount(h *core.Handler) int {
+	if s, err := h.DB.GetSetting("discovery_target_count"); err == nil {
+		if count, err := strconv.Atoi(s); err == nil && count > 0 {
+			return count
+		}
+	}
+	return 0
+}
+
+// discoveryFeedTimeout returns the crawl time budget of a single source feed, so that one
+// slow site cannot use up the whole batch discovery run
+func discoveryFeedTimeout(h *core.Handler) time.Duration {
+	if s, err := h.DB.GetSetting("discovery_feed_timeout"); err == nil {
+		if seconds, err := strconv.Atoi(s); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return core.SingleFeedDiscoveryTimeout
+}
+
+// discoveryValidationOptions returns the configured per-check timeout and network retry
+// count for validating candidate feed URLs
+func discoveryValidationOptions(h *core.Handler) discovery.ValidationOptions {
+	opts := discovery.DefaultValidationOptions()
+	if s, err := h.DB.GetSetting("discovery_validation_timeout"); err == nil {
+		if seconds, err := strconv.Atoi(s); err == nil && seconds > 0 {
+			opts.Timeout = time.Duration(seconds) * time.Second
+		}
+	}
+	if s, err := h.DB.GetSetting("discovery_validation_retries"); err == nil {
+		if retries, err := strconv.Atoi(s); err == nil && retries >= 0 {
+			opts.Retries = retries
+		}
+	}
+	return opts
+}
+
+// scheduleBatchStateExpiry drops the completed batch state after the TTL, unless a
+// newer discovery has replaced it in the meantime
+func scheduleBatchStateExpiry(h *core.Handler, state *core.DiscoveryState) {
+	time.AfterFunc(discoveryResultTTL(h), func() {
+		h.DiscoveryMu.Lock()
+		defer h.DiscoveryMu.Unlock()
+		if h.BatchDiscoveryState == state {
+			h.BatchDiscoveryState = nil
+		}
+	})
+}
+
+// sortedSources returns the source feed titles of batch results in order
+func sortedSources(bySource map[string][]discovery.DiscoveredBlog) []string {
+	sources := make([]string, 0, len(bySource))
+	for source := range bySource {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+// persistDiscoveredBlogs stores the final batch results, keyed by source feed title and
+// in source order
+func persistDiscoveredBlogs(h *core.Handler, bySource map[string][]discovery.DiscoveredBlog) error {
+	var records []database.DiscoveredBlogRecord
+	for _, source := range sortedSources(bySource) {
+		for _, blog := range bySource[source] {
+			recent, err := json.Marshal(blog.RecentArticles)
+			if err != nil {
+				return err
+			}
+			records = append(records, database.DiscoveredBlogRecord{
+				SourceFeed:     source,
+				Name:           blog.Name,
+				Homepage:       blog.Homepage,
+				RSSFeed:        blog.RSSFeed,
+				IconURL:        blog.IconURL,
+				RecentArticles: string(recent),
+			})
+		}
+	}
+	return h.DB.ReplaceDiscoveredBlogs(records)
+}
+
+// loadPersistedDiscoveryState rebuilds a completed discovery state from stored results,
+// skipping blogs that have been subscribed since. Returns nil if nothing is stored.
+func loadPersistedDiscoveryState(h *core.Handler) *core.DiscoveryState {
+	records, err := h.DB.GetDiscoveredBlogs()
+	if err != nil {
+		log.Printf("Error loading persisted discovered blogs: %v", err)
+		return nil
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	subscribedURLs, err := h.DB.GetAllFeedURLs()
+	if err != nil {
+		subscribedURLs = make(map[string]bool)
+	}
+
+	feeds := make([]discovery.DiscoveredBlog, 0, len(records))
+	var completedAt time.Time
+	for _, rec := range records {
+		if rec.DiscoveredAt.After(completedAt) {
+			completedAt = rec.DiscoveredAt
+		}
+		if subscribedURLs[rec.RSSFeed] {
+			continue
+		}
+		blog := discovery.DiscoveredBlog{
+			Name:     rec.Name,
+			Homepage: rec.Homepage,
+			RSSFeed:  rec.RSSFeed,
+			IconURL:  rec.IconURL,
+		}
+		if rec.RecentArticles != "" {
+			_ = json.Unmarshal([]byte(rec.RecentArticles), &blog.RecentArticles)
+		}
+		feeds = append(feeds, blog)
+	}
+
+	return &core.DiscoveryState{
+		IsComplete: true,
+		Progress: discovery.Progress{
+			Stage:      "complete",
+			Message:    fmt.Sprintf("Found %d feeds", len(feeds)),
+			FoundCount: len(feeds),
+		},
+		Feeds:       feeds,
+		CompletedAt: &completedAt,
+	}
+}
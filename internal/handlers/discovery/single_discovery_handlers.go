@@ -61,6 +61,7 @@ func HandleDiscoverBlogs(h *core.Handler, w http.ResponseWriter, r *http.Request
 	// Discover blogs with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
+	ctx = discovery.WithValidationRun(ctx, discoveryValidationOptions(h))
 
 	log.Printf("Starting blog discovery for feed: %s (%s)", targetFeed.Title, targetFeed.URL)
 	discovered, err := h.DiscoveryService.DiscoverFromFeed(ctx, targetFeed.URL)
@@ -168,6 +169,7 @@ func HandleStartSingleDiscovery(h *core.Handler, w http.ResponseWriter, r *http.
 
 		ctx, cancel := context.WithTimeout(context.Background(), core.SingleFeedDiscoveryTimeout)
 		defer cancel()
+		ctx = discovery.WithValidationRun(ctx, discoveryValidationOptions(h))
 
 		log.Printf("Starting background discovery for feed: %s (%s)", targetFeed.Title, targetFeed.URL)
 		discovered, err := h.DiscoveryService.DiscoverFromFeedWithProgress(ctx, targetFeed.URL, progressCb)
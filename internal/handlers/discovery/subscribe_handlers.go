@@ -0,0 +1,112 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"MrRSS/internal/handlers/core"
+	"MrRSS/internal/models"
+	"MrRSS/internal/utils"
+)
+
+// bulkSubscribeResult reports what happened to one requested feed
+type bulkSubscribeResult struct {
+	RSSFeed string `json:"rss_feed"`
+	Status  string `json:"status"` // "added", "skipped" or "error"
+	FeedID  int64  `json:"feed_id,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// HandleBulkSubscribe subscribes to several discovered blogs at once.
+// @Summary      Subscribe to discovered blogs
+// @Description  Add several discovered feeds in one call, skipping ones already subscribed, then fetch the new feeds immediately
+// @Tags         discovery
+// @Accept       json
+// @Produce      json
+// @Param        request  body      array   true  "Feeds to add ([{rss_feed, title, category}])"
+// @Success      200  {object}  map[string]interface{}  "Per-feed results (results, added_ids)"
+// @Failure      400  {object}  map[string]string  "Bad request"
+// @Router       /feeds/discover/subscribe [post]
+func HandleBulkSubscribe(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req []struct {
+		RSSFeed  string `json:"rss_feed"`
+		Title    string `json:"title"`
+		Category string `json:"category"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	subscribedURLs, err := h.DB.GetAllFeedURLs()
+	if err != nil {
+		utils.ContextLog(r.Context(), "Error getting subscribed URLs: %v", err)
+		subscribedURLs = make(map[string]bool)
+	}
+
+	results := make([]bulkSubscribeResult, 0, len(req))
+	addedIDs := make([]int64, 0, len(req))
+	seen := make(map[string]bool)
+
+	for _, item := range req {
+		feedURL := utils.NormalizeFeedURL(strings.TrimSpace(item.RSSFeed))
+		result := bulkSubscribeResult{RSSFeed: feedURL}
+
+		canonical := utils.CanonicalFeedURL(feedURL)
+		switch {
+		case feedURL == "":
+			result.Status = "error"
+			result.Reason = "missing rss_feed"
+		case subscribedURLs[feedURL]:
+			result.Status = "skipped"
+			result.Reason = "already subscribed"
+		case seen[canonical]:
+			result.Status = "skipped"
+			result.Reason = "duplicate in request"
+		default:
+			if similar, err := h.DB.FindFeedByNormalizedURL(feedURL); err == nil && similar != nil {
+				result.Status = "skipped"
+				result.Reason = "already subscribed"
+				result.FeedID = similar.ID
+				break
+			}
+
+			title := item.Title
+			if title == "" {
+				title = feedURL
+			}
+			id, err := h.DB.AddFeed(&models.Feed{Title: title, URL: feedURL, Category: item.Category})
+			if err != nil {
+				result.Status = "error"
+				result.Reason = err.Error()
+				break
+			}
+			result.Status = "added"
+			result.FeedID = id
+			addedIDs = append(addedIDs, id)
+		}
+
+		if feedURL != "" {
+			seen[canonical] = true
+		}
+		results = append(results, result)
+	}
+
+	// Populate the new feeds right away rather than waiting for the next refresh. The fetch
+	// outlives the request, so it keeps the request ID but not the cancellation.
+	if len(addedIDs) > 0 && h.Fetcher != nil {
+		go h.Fetcher.FetchFeedsByIDs(context.WithoutCancel(r.Context()), addedIDs)
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"results":   results,
+		"added_ids": addedIDs,
+	})
+}
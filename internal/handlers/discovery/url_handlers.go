@@ -0,0 +1,55 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"MrRSS/internal/discovery"
+	"MrRSS/internal/handlers/core"
+)
+
+// HandleNormalizeURL validates and normalizes a pasted feed or site URL.
+// @Summary      Normalize URL
+// @Description  Clean up a pasted URL (add missing scheme, strip tracking parameters) and report whether it is a feed or a site needing discovery
+// @Tags         discovery
+// @Accept       json
+// @Produce      json
+// @Param        request  body      object  true  "Normalize request (url)"
+// @Success      200  {object}  map[string]interface{}  "Normalized URL (normalized_url, is_feed, needs_discovery)"
+// @Failure      400  {object}  map[string]string  "Bad request (invalid URL)"
+// @Router       /feeds/normalize-url [post]
+func HandleNormalizeURL(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		URL string `json:"url"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	normalized, err := discovery.NormalizeURL(req.URL)
+	if err != nil {
+		http.Error(w, "Invalid URL", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+	ctx = discovery.WithValidationRun(ctx, discoveryValidationOptions(h))
+
+	isFeed := h.DiscoveryService.IsFeedURL(ctx, normalized)
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"normalized_url":  normalized,
+		"is_feed":         isFeed,
+		"needs_discovery": !isFeed,
+	})
+}
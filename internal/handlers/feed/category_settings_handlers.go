@@ -0,0 +1,56 @@
+package feed
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"MrRSS/internal/handlers/core"
+)
+
+// HandleCategorySettings lists or updates per-category overrides.
+// @Summary      Get or set category settings
+// @Description  GET returns every category override. POST sets the translation target language of a category path ("News/Tech"); it also applies to subcategories unless they set their own, and an empty translation_target_lang removes the override so the global target_language applies again.
+// @Tags         feeds
+// @Accept       json
+// @Produce      json
+// @Param        request  body      object  false  "Category override (category, translation_target_lang)"
+// @Success      200  {array}   database.CategorySettings  "Category overrides"
+// @Failure      400  {object}  map[string]string  "Bad request"
+// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Router       /categories/settings [get]
+// @Router       /categories/settings [post]
+func HandleCategorySettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		var req struct {
+			Category              string `json:"category"`
+			TranslationTargetLang string `json:"translation_target_lang"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		category := strings.Trim(strings.TrimSpace(req.Category), "/")
+		if category == "" {
+			http.Error(w, "category is required", http.StatusBadRequest)
+			return
+		}
+		if err := h.DB.SetCategoryTranslationTargetLang(category, strings.TrimSpace(req.TranslationTargetLang)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	settings, err := h.DB.GetCategorySettings()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(settings)
+}
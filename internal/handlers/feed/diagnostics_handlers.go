@@ -0,0 +1,67 @@
+package feed
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	feedpkg "MrRSS/internal/feed"
+	"MrRSS/internal/handlers/core"
+)
+
+// FeedDiagnostics describes the last fetch error of a feed with actionable guidance.
+type FeedDiagnostics struct {
+	FeedID        int64     `json:"feed_id"`
+	Title         string    `json:"title"`
+	URL           string    `json:"url"`
+	LastUpdated   time.Time `json:"last_updated"`
+	HasError      bool      `json:"has_error"`
+	LastError     string    `json:"last_error,omitempty"`
+	ErrorCategory string    `json:"error_category,omitempty"`
+	Suggestion    string    `json:"suggestion,omitempty"`
+}
+
+// HandleGetFeedDiagnostics returns the last fetch error of a feed, its category and a suggestion.
+// @Summary      Get feed diagnostics
+// @Description  Classify a feed's last fetch error (DNS, TLS, timeout, 404, parse error, non-feed content) and suggest a fix
+// @Tags         feeds
+// @Accept       json
+// @Produce      json
+// @Param        feed_id  query     int64  true  "Feed ID"
+// @Success      200  {object}  FeedDiagnostics  "Feed diagnostics"
+// @Failure      400  {object}  map[string]string  "Bad request (invalid feed ID)"
+// @Failure      404  {object}  map[string]string  "Feed not found"
+// @Router       /feeds/diagnostics [get]
+func HandleGetFeedDiagnostics(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	feedID, err := strconv.ParseInt(r.URL.Query().Get("feed_id"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid feed ID", http.StatusBadRequest)
+		return
+	}
+
+	feed, err := h.DB.GetFeedByID(feedID)
+	if err != nil {
+		http.Error(w, "Feed not found", http.StatusNotFound)
+		return
+	}
+
+	category, suggestion := feedpkg.ClassifyFetchErrorMessage(feed.LastError)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(FeedDiagnostics{
+		FeedID:        feed.ID,
+		Title:         feed.Title,
+		URL:           feed.URL,
+		LastUpdated:   feed.LastUpdated,
+		HasError:      feed.LastError != "",
+		LastError:     feed.LastError,
+		ErrorCategory: category,
+		Suggestion:    suggestion,
+	})
+}
@@ -0,0 +1,41 @@
+package feed_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ff "MrRSS/internal/feed"
+	fh "MrRSS/internal/handlers/feed"
+	"MrRSS/internal/models"
+)
+
+func TestHandleGetFeedDiagnostics(t *testing.T) {
+	h := setupHandler(t)
+
+	id, err := h.DB.AddFeed(&models.Feed{Title: "a", URL: "http://x/1"})
+	if err != nil {
+		t.Fatalf("add feed: %v", err)
+	}
+	if err := h.DB.UpdateFeedError(id, "http error: 404 Not Found"); err != nil {
+		t.Fatalf("update error: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/feeds/diagnostics?feed_id=%d", id), nil)
+	w := httptest.NewRecorder()
+	fh.HandleGetFeedDiagnostics(h, w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	var diag fh.FeedDiagnostics
+	if err := json.NewDecoder(w.Body).Decode(&diag); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !diag.HasError || diag.ErrorCategory != ff.FetchErrorNotFound || diag.Suggestion == "" {
+		t.Fatalf("unexpected diagnostics: %+v", diag)
+	}
+}
@@ -3,9 +3,14 @@ package feed
 import (
 	"context"
 	"encoding/json"
+	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	feedpkg "MrRSS/internal/feed"
+	"MrRSS/internal/freshrss"
 	"MrRSS/internal/handlers/core"
 	"MrRSS/internal/rsshub"
 	"MrRSS/internal/utils"
@@ -44,7 +49,7 @@ func HandleFeeds(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 // @Param        request  body      object  true  "Feed details"
 // @Success      200  {string}  string  "Feed added successfully"
 // @Failure      400  {object}  map[string]string  "Bad request"
-// @Failure      409  {object}  map[string]string  "Feed URL already exists"
+// @Failure      409  {object}  map[string]interface{}  "Feed URL already exists, or a likely duplicate was found (error=possible_duplicate; resend with force=true to add anyway)"
 // @Failure      500  {object}  map[string]string  "Internal server error"
 // @Router       /feeds/add [post]
 func HandleAddFeed(h *core.Handler, w http.ResponseWriter, r *http.Request) {
@@ -59,19 +64,28 @@ func HandleAddFeed(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 		RefreshInterval  int    `json:"refresh_interval"`
 		IsImageMode      bool   `json:"is_image_mode"`
 		// XPath fields
-		Type                string `json:"type"`
-		XPathItem           string `json:"xpath_item"`
-		XPathItemTitle      string `json:"xpath_item_title"`
-		XPathItemContent    string `json:"xpath_item_content"`
-		XPathItemUri        string `json:"xpath_item_uri"`
-		XPathItemAuthor     string `json:"xpath_item_author"`
-		XPathItemTimestamp  string `json:"xpath_item_timestamp"`
-		XPathItemTimeFormat string `json:"xpath_item_time_format"`
-		XPathItemThumbnail  string `json:"xpath_item_thumbnail"`
-		XPathItemCategories string `json:"xpath_item_categories"`
-		XPathItemUid        string `json:"xpath_item_uid"`
-		ArticleViewMode     string `json:"article_view_mode"`
-		AutoExpandContent   string `json:"auto_expand_content"`
+		Type                string   `json:"type"`
+		XPathItem           string   `json:"xpath_item"`
+		XPathItemTitle      string   `json:"xpath_item_title"`
+		XPathItemContent    string   `json:"xpath_item_content"`
+		XPathItemUri        string   `json:"xpath_item_uri"`
+		XPathItemAuthor     string   `json:"xpath_item_author"`
+		XPathItemTimestamp  string   `json:"xpath_item_timestamp"`
+		XPathItemTimeFormat string   `json:"xpath_item_time_format"`
+		XPathItemThumbnail  string   `json:"xpath_item_thumbnail"`
+		XPathItemCategories string   `json:"xpath_item_categories"`
+		XPathItemUid        string   `json:"xpath_item_uid"`
+		ArticleViewMode     string   `json:"article_view_mode"`
+		AutoExpandContent   string   `json:"auto_expand_content"`
+		TranslationMode     string   `json:"translation_mode"`
+		SourceLanguage      string   `json:"source_language_override"`
+		MaxArticleAgeDays   int      `json:"max_article_age_days"`
+		ReadLaterByDefault  bool     `json:"read_later_by_default"`
+		EagerContent        bool     `json:"eager_content"`
+		ForceURLDedup       bool     `json:"force_url_dedup"`
+		ContentStrategy     []string `json:"content_strategy"`
+		ContentSelector     string   `json:"content_selector"`
+		ContentScriptPath   string   `json:"content_script_path"`
 		// Email/Newsletter fields
 		EmailAddress    string `json:"email_address"`
 		EmailIMAPServer string `json:"email_imap_server"`
@@ -79,11 +93,18 @@ func HandleAddFeed(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 		EmailUsername   string `json:"email_username"`
 		EmailPassword   string `json:"email_password"`
 		EmailFolder     string `json:"email_folder"`
+		// Force skips the near-duplicate check after the user has confirmed
+		Force bool `json:"force"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	contentStrategy, err := feedpkg.NormalizeContentStrategy(req.ContentStrategy)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	// Normalize the URL to ensure it has a protocol
 	req.URL = utils.NormalizeFeedURL(req.URL)
@@ -99,13 +120,28 @@ func HandleAddFeed(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 	// Check if feed with this URL already exists (excluding FreshRSS feeds)
 	var existingID int64
 	var existingIsFreshRSS bool
-	err := h.DB.QueryRow("SELECT id, is_freshrss_source FROM feeds WHERE url = ?", feedURL).Scan(&existingID, &existingIsFreshRSS)
+	err = h.DB.QueryRow("SELECT id, is_freshrss_source FROM feeds WHERE url = ?", feedURL).Scan(&existingID, &existingIsFreshRSS)
 	if err == nil && !existingIsFreshRSS {
 		// Feed exists and is not a FreshRSS feed - return conflict error
 		http.Error(w, "feed with this URL already exists", http.StatusConflict)
 		return
 	}
 
+	// Catch the same feed under a slightly different URL (http vs https, www, trailing slash)
+	if !req.Force && req.ScriptPath == "" && req.Type != "email" {
+		if similar, err := h.DB.FindFeedByNormalizedURL(feedURL); err == nil && similar != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"error":            "possible_duplicate",
+				"existing_feed_id": similar.ID,
+				"existing_title":   similar.Title,
+				"existing_url":     similar.URL,
+			})
+			return
+		}
+	}
+
 	var feedID int64
 	if req.ScriptPath != "" {
 		// Add feed using custom script
@@ -138,15 +174,58 @@ func HandleAddFeed(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "feed created but failed to update settings: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if req.TranslationMode != "" {
+		if err := h.DB.UpdateFeedTranslationMode(feed.ID, req.TranslationMode); err != nil {
+			http.Error(w, "feed created but failed to update settings: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	if req.SourceLanguage != "" {
+		if err := h.DB.UpdateFeedSourceLanguage(feed.ID, req.SourceLanguage); err != nil {
+			http.Error(w, "feed created but failed to update settings: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	if req.MaxArticleAgeDays > 0 {
+		if err := h.DB.UpdateFeedMaxArticleAge(feed.ID, req.MaxArticleAgeDays); err != nil {
+			http.Error(w, "feed created but failed to update settings: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	if req.ReadLaterByDefault {
+		if err := h.DB.UpdateFeedReadLaterDefault(feed.ID, true); err != nil {
+			http.Error(w, "feed created but failed to update settings: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	if req.EagerContent {
+		if err := h.DB.UpdateFeedEagerContent(feed.ID, true); err != nil {
+			http.Error(w, "feed created but failed to update settings: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	if req.ForceURLDedup {
+		if err := h.DB.UpdateFeedForceURLDedup(feed.ID, true); err != nil {
+			http.Error(w, "feed created but failed to update settings: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	if len(contentStrategy) > 0 || req.ContentSelector != "" || req.ContentScriptPath != "" {
+		if err := h.DB.UpdateFeedContentStrategy(feed.ID, contentStrategy, strings.TrimSpace(req.ContentSelector), strings.TrimSpace(req.ContentScriptPath)); err != nil {
+			http.Error(w, "feed created but failed to update settings: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
 
 	// Immediately fetch articles for the newly added feed in background
+	ctx := context.WithoutCancel(r.Context())
 	go func() {
 		feed, err := h.DB.GetFeedByID(feedID)
 		if err != nil {
 			return
 		}
 		// Use manual refresh (queue head) for newly added feed
-		h.Fetcher.FetchSingleFeed(context.Background(), *feed, true)
+		h.Fetcher.FetchSingleFeed(ctx, *feed, true)
 	}()
 
 	w.WriteHeader(http.StatusOK)
@@ -165,13 +244,56 @@ func HandleAddFeed(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 func HandleDeleteFeed(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 	idStr := r.URL.Query().Get("id")
 	id, _ := strconv.ParseInt(idStr, 10, 64)
+	feed, _ := h.DB.GetFeedByID(id)
 	if err := h.DB.DeleteFeed(id); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	// Otherwise the next sync brings the feed back
+	if feed != nil && feed.IsFreshRSSSource {
+		go unsubscribeFromFreshRSS(h, feed.URL)
+	}
 	w.WriteHeader(http.StatusOK)
 }
 
+// unsubscribeFromFreshRSS removes a deleted FreshRSS feed from the server
+func unsubscribeFromFreshRSS(h *core.Handler, feedURL string) {
+	syncService := freshRSSSyncService(h)
+	if syncService == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err := syncService.UnsubscribeFeed(ctx, feedURL); err != nil {
+		log.Printf("Failed to unsubscribe deleted feed %s from FreshRSS: %v", feedURL, err)
+	}
+}
+
+// updateFreshRSSSubscription pushes the new title and/or category of a FreshRSS feed
+func updateFreshRSSSubscription(h *core.Handler, feedURL string, title, category *string) {
+	syncService := freshRSSSyncService(h)
+	if syncService == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err := syncService.UpdateSubscription(ctx, feedURL, title, category); err != nil {
+		log.Printf("Failed to update FreshRSS subscription %s: %v", feedURL, err)
+	}
+}
+
+// freshRSSSyncService returns a sync service when FreshRSS is enabled and configured
+func freshRSSSyncService(h *core.Handler) *freshrss.BidirectionalSyncService {
+	if enabled, _ := h.DB.GetSetting("freshrss_enabled"); enabled != "true" {
+		return nil
+	}
+	serverURL, username, password, err := h.DB.GetFreshRSSConfig()
+	if err != nil || serverURL == "" || username == "" || password == "" {
+		return nil
+	}
+	return freshrss.NewBidirectionalSyncService(serverURL, username, password, h.DB)
+}
+
 // HandleUpdateFeed updates a feed's properties.
 // @Summary      Update a feed
 // @Description  Update properties of an existing feed subscription
@@ -197,19 +319,30 @@ func HandleUpdateFeed(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 		RefreshInterval  int    `json:"refresh_interval"`
 		IsImageMode      bool   `json:"is_image_mode"`
 		// XPath fields
-		Type                string `json:"type"`
-		XPathItem           string `json:"xpath_item"`
-		XPathItemTitle      string `json:"xpath_item_title"`
-		XPathItemContent    string `json:"xpath_item_content"`
-		XPathItemUri        string `json:"xpath_item_uri"`
-		XPathItemAuthor     string `json:"xpath_item_author"`
-		XPathItemTimestamp  string `json:"xpath_item_timestamp"`
-		XPathItemTimeFormat string `json:"xpath_item_time_format"`
-		XPathItemThumbnail  string `json:"xpath_item_thumbnail"`
-		XPathItemCategories string `json:"xpath_item_categories"`
-		XPathItemUid        string `json:"xpath_item_uid"`
-		ArticleViewMode     string `json:"article_view_mode"`
-		AutoExpandContent   string `json:"auto_expand_content"`
+		Type                string    `json:"type"`
+		XPathItem           string    `json:"xpath_item"`
+		XPathItemTitle      string    `json:"xpath_item_title"`
+		XPathItemContent    string    `json:"xpath_item_content"`
+		XPathItemUri        string    `json:"xpath_item_uri"`
+		XPathItemAuthor     string    `json:"xpath_item_author"`
+		XPathItemTimestamp  string    `json:"xpath_item_timestamp"`
+		XPathItemTimeFormat string    `json:"xpath_item_time_format"`
+		XPathItemThumbnail  string    `json:"xpath_item_thumbnail"`
+		XPathItemCategories string    `json:"xpath_item_categories"`
+		XPathItemUid        string    `json:"xpath_item_uid"`
+		ArticleViewMode     string    `json:"article_view_mode"`
+		AutoExpandContent   string    `json:"auto_expand_content"`
+		TranslationMode     string    `json:"translation_mode"`
+		SourceLanguage      string    `json:"source_language_override"`
+		MaxArticleAgeDays   *int      `json:"max_article_age_days"`
+		ReadLaterByDefault  *bool     `json:"read_later_by_default"`
+		EagerContent        *bool     `json:"eager_content"`
+		ForceURLDedup       *bool     `json:"force_url_dedup"`
+		GUIDUnstable        *bool     `json:"guid_unstable"`
+		ContentStrategy     *[]string `json:"content_strategy"`
+		ContentSelector     *string   `json:"content_selector"`
+		ContentScriptPath   *string   `json:"content_script_path"`
+		ListPrefix          *string   `json:"list_prefix"`
 		// Email/Newsletter fields
 		EmailAddress    string `json:"email_address"`
 		EmailIMAPServer string `json:"email_imap_server"`
@@ -222,6 +355,14 @@ func HandleUpdateFeed(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	var contentStrategy []string
+	if req.ContentStrategy != nil {
+		var err error
+		if contentStrategy, err = feedpkg.NormalizeContentStrategy(*req.ContentStrategy); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
 
 	// Normalize the URL to ensure it has a protocol
 	req.URL = utils.NormalizeFeedURL(req.URL)
@@ -270,10 +411,101 @@ func HandleUpdateFeed(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	previous, _ := h.DB.GetFeedByID(req.ID)
 	if err := h.DB.UpdateFeed(req.ID, req.Title, req.URL, req.Category, req.ScriptPath, req.HideFromTimeline, req.ProxyURL, req.ProxyEnabled, req.RefreshInterval, req.IsImageMode, req.Type, req.XPathItem, req.XPathItemTitle, req.XPathItemContent, req.XPathItemUri, req.XPathItemAuthor, req.XPathItemTimestamp, req.XPathItemTimeFormat, req.XPathItemThumbnail, req.XPathItemCategories, req.XPathItemUid, req.ArticleViewMode, req.AutoExpandContent, req.EmailAddress, req.EmailIMAPServer, req.EmailUsername, req.EmailPassword, req.EmailFolder, req.EmailIMAPPort); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	// Older clients don't send translation_mode; keep the stored value then
+	if req.TranslationMode != "" {
+		if err := h.DB.UpdateFeedTranslationMode(req.ID, req.TranslationMode); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	// Same for the source language; "auto" clears it
+	if req.SourceLanguage != "" {
+		if err := h.DB.UpdateFeedSourceLanguage(req.ID, req.SourceLanguage); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	// Absent keeps the stored age limit, 0 removes it
+	if req.MaxArticleAgeDays != nil {
+		if err := h.DB.UpdateFeedMaxArticleAge(req.ID, *req.MaxArticleAgeDays); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	// Absent keeps the stored read-later default
+	if req.ReadLaterByDefault != nil {
+		if err := h.DB.UpdateFeedReadLaterDefault(req.ID, *req.ReadLaterByDefault); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	// Absent keeps the stored list prefix, empty falls back to the title's initials
+	if req.ListPrefix != nil {
+		if err := h.DB.UpdateFeedListPrefix(req.ID, *req.ListPrefix); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	// Absent keeps the stored eager content flag
+	if req.EagerContent != nil {
+		if err := h.DB.UpdateFeedEagerContent(req.ID, *req.EagerContent); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	if req.ForceURLDedup != nil {
+		if err := h.DB.UpdateFeedForceURLDedup(req.ID, *req.ForceURLDedup); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	// Clearing the detected flag gives the feed's identifiers another chance
+	if req.GUIDUnstable != nil {
+		if err := h.DB.UpdateFeedGUIDUnstable(req.ID, *req.GUIDUnstable); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	// Absent content pipeline fields keep their stored values
+	if req.ContentStrategy != nil || req.ContentSelector != nil || req.ContentScriptPath != nil {
+		current, err := h.DB.GetFeedByID(req.ID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		selector, scriptPath := current.ContentSelector, current.ContentScriptPath
+		if req.ContentStrategy == nil {
+			contentStrategy = current.ContentStrategy
+		}
+		if req.ContentSelector != nil {
+			selector = strings.TrimSpace(*req.ContentSelector)
+		}
+		if req.ContentScriptPath != nil {
+			scriptPath = strings.TrimSpace(*req.ContentScriptPath)
+		}
+		if err := h.DB.UpdateFeedContentStrategy(req.ID, contentStrategy, selector, scriptPath); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	// Push renames and moves of FreshRSS feeds, or the next sync reverts them
+	if previous != nil && previous.IsFreshRSSSource {
+		var title, category *string
+		if req.Title != previous.Title {
+			title = &req.Title
+		}
+		if req.Category != previous.Category {
+			category = &req.Category
+		}
+		if title != nil || category != nil {
+			go updateFreshRSSSubscription(h, previous.URL, title, category)
+		}
+	}
 	w.WriteHeader(http.StatusOK)
 }
 
@@ -308,7 +540,7 @@ func HandleRefreshFeed(h *core.Handler, w http.ResponseWriter, r *http.Request)
 	}
 
 	// Refresh the feed in background with progress tracking (manual = queue head)
-	go h.Fetcher.FetchSingleFeed(context.Background(), *feed, true)
+	go h.Fetcher.FetchSingleFeed(context.WithoutCancel(r.Context()), *feed, true)
 
 	// Return success response
 	w.Header().Set("Content-Type", "application/json")
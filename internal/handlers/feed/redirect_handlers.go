@@ -0,0 +1,115 @@
+package feed
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"MrRSS/internal/handlers/core"
+	"MrRSS/internal/models"
+)
+
+// RedirectDuplicateGroup is a set of feeds whose addresses redirect to the same feed.
+type RedirectDuplicateGroup struct {
+	ResolvedURL string        `json:"resolved_url"`
+	Feeds       []models.Feed `json:"feeds"`
+}
+
+// MergeRedirectDuplicatesRequest names the feed to keep and the duplicates to fold into it.
+type MergeRedirectDuplicatesRequest struct {
+	TargetID  int64   `json:"target_id"`
+	SourceIDs []int64 `json:"source_ids"`
+}
+
+// HandleDetectRedirectDuplicates lists feeds that redirect to the same canonical feed and merges them.
+// @Summary      Detect and merge feeds that redirect to the same feed
+// @Description  GET groups feeds whose URLs resolved to the same final URL on their last fetch. POST merges the source feeds of one group into the target feed, moving their articles and deleting the sources.
+// @Tags         feeds
+// @Accept       json
+// @Produce      json
+// @Param        request  body      MergeRedirectDuplicatesRequest  false  "Feeds to merge (POST only)"
+// @Success      200  {array}   RedirectDuplicateGroup  "Groups of duplicate feeds (GET)"
+// @Success      200  {object}  map[string]int64  "Merged feeds and moved articles (POST)"
+// @Failure      400  {object}  map[string]string  "Bad request (feeds do not share a resolved URL)"
+// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Router       /feeds/redirect-duplicates [get]
+func HandleDetectRedirectDuplicates(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		listRedirectDuplicates(h, w)
+	case http.MethodPost:
+		mergeRedirectDuplicates(h, w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func listRedirectDuplicates(h *core.Handler, w http.ResponseWriter) {
+	groupIDs, err := h.DB.GetRedirectDuplicateFeeds()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	groups := []RedirectDuplicateGroup{}
+	for resolvedURL, ids := range groupIDs {
+		group := RedirectDuplicateGroup{ResolvedURL: resolvedURL}
+		for _, id := range ids {
+			feed, err := h.DB.GetFeedByID(id)
+			if err != nil {
+				continue
+			}
+			feed.EmailPassword = ""
+			group.Feeds = append(group.Feeds, *feed)
+		}
+		if len(group.Feeds) > 1 {
+			groups = append(groups, group)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].ResolvedURL < groups[j].ResolvedURL })
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(groups)
+}
+
+func mergeRedirectDuplicates(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	var req MergeRedirectDuplicatesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.TargetID == 0 || len(req.SourceIDs) == 0 {
+		http.Error(w, "target_id and source_ids are required", http.StatusBadRequest)
+		return
+	}
+
+	// Only merge feeds that were seen to redirect to the same place
+	targetURL, err := h.DB.GetFeedResolvedURL(req.TargetID)
+	if err != nil || targetURL == "" {
+		http.Error(w, "Target feed has no resolved URL", http.StatusBadRequest)
+		return
+	}
+	for _, id := range req.SourceIDs {
+		if id == req.TargetID {
+			http.Error(w, "A feed cannot be merged into itself", http.StatusBadRequest)
+			return
+		}
+		sourceURL, err := h.DB.GetFeedResolvedURL(id)
+		if err != nil || sourceURL != targetURL {
+			http.Error(w, "Feeds do not resolve to the same URL", http.StatusBadRequest)
+			return
+		}
+	}
+
+	moved, err := h.DB.MergeFeeds(req.TargetID, req.SourceIDs)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]int64{
+		"merged_feeds":   int64(len(req.SourceIDs)),
+		"moved_articles": moved,
+	})
+}
@@ -0,0 +1,84 @@
+package feed_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	fh "MrRSS/internal/handlers/feed"
+	"MrRSS/internal/models"
+)
+
+func TestHandleDetectRedirectDuplicates(t *testing.T) {
+	h := setupHandler(t)
+
+	published := time.Now().Add(-time.Hour)
+	var ids []int64
+	for i, u := range []string{"http://example.com/rss", "https://example.com/feed.xml", "https://other.com/feed"} {
+		id, err := h.DB.AddFeed(&models.Feed{Title: fmt.Sprintf("f%d", i), URL: u})
+		if err != nil {
+			t.Fatalf("add feed: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	h.DB.UpdateFeedResolvedURL(ids[0], "example.com/feed.xml")
+	h.DB.UpdateFeedResolvedURL(ids[1], "example.com/feed.xml")
+	h.DB.UpdateFeedResolvedURL(ids[2], "other.com/feed")
+	for _, a := range []*models.Article{
+		{FeedID: ids[0], Title: "shared", URL: "https://example.com/shared", PublishedAt: published, HasValidPublishedTime: true},
+		{FeedID: ids[0], Title: "only old", URL: "https://example.com/old", PublishedAt: published, HasValidPublishedTime: true},
+		{FeedID: ids[1], Title: "shared", URL: "https://example.com/shared", PublishedAt: published, HasValidPublishedTime: true},
+	} {
+		if err := h.DB.SaveArticle(a); err != nil {
+			t.Fatalf("save article: %v", err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	fh.HandleDetectRedirectDuplicates(h, w, httptest.NewRequest(http.MethodGet, "/api/feeds/redirect-duplicates", nil))
+	var groups []fh.RedirectDuplicateGroup
+	if err := json.NewDecoder(w.Body).Decode(&groups); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(groups) != 1 || groups[0].ResolvedURL != "example.com/feed.xml" || len(groups[0].Feeds) != 2 {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+
+	merge := func(target int64, sources ...int64) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(fh.MergeRedirectDuplicatesRequest{TargetID: target, SourceIDs: sources})
+		w := httptest.NewRecorder()
+		fh.HandleDetectRedirectDuplicates(h, w, httptest.NewRequest(http.MethodPost, "/api/feeds/redirect-duplicates", bytes.NewReader(body)))
+		return w
+	}
+
+	if w := merge(ids[1], ids[2]); w.Code != http.StatusBadRequest {
+		t.Errorf("expected feeds with different resolved URLs to be rejected, got %d", w.Code)
+	}
+
+	w = merge(ids[1], ids[0])
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp map[string]int64
+	json.NewDecoder(w.Body).Decode(&resp)
+	if resp["moved_articles"] != 1 {
+		t.Errorf("expected only the article missing from the target to move, got %v", resp)
+	}
+
+	if feed, _ := h.DB.GetFeedByID(ids[0]); feed != nil {
+		t.Error("expected the merged feed to be deleted")
+	}
+	articles, err := h.DB.GetArticles("", ids[1], "", false, 10, 0)
+	if err != nil || len(articles) != 2 {
+		t.Fatalf("expected 2 articles in the target feed, got %d (err %v)", len(articles), err)
+	}
+
+	// The moved article is recognised as the target feed's own on the next refresh
+	if _, err := h.DB.GetArticleIDByUniqueID("only old", ids[1], published, true); err != nil {
+		t.Errorf("expected the moved article to carry the target feed's unique ID: %v", err)
+	}
+}
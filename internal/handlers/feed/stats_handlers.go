@@ -0,0 +1,64 @@
+package feed
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"MrRSS/internal/database"
+	feedpkg "MrRSS/internal/feed"
+	"MrRSS/internal/handlers/core"
+)
+
+// FeedStatsResponse is a feed's activity together with the interval intelligent refresh
+// would use for it
+type FeedStatsResponse struct {
+	*database.FeedStats
+	IntelligentRefreshMinutes int `json:"intelligent_refresh_minutes"`
+}
+
+// HandleGetFeedStats returns a feed's posting statistics.
+// @Summary      Get feed statistics
+// @Description  Articles per week, average article length, date of the last article, unread ratio and the intelligent refresh interval of a feed
+// @Tags         feeds
+// @Accept       json
+// @Produce      json
+// @Param        feed_id  query     int64  true  "Feed ID"
+// @Success      200  {object}  FeedStatsResponse  "Feed statistics"
+// @Failure      400  {object}  map[string]string  "Bad request (invalid feed ID)"
+// @Failure      404  {object}  map[string]string  "Feed not found"
+// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Router       /feeds/stats [get]
+func HandleGetFeedStats(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	feedID, err := strconv.ParseInt(r.URL.Query().Get("feed_id"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid feed ID", http.StatusBadRequest)
+		return
+	}
+
+	feed, err := h.DB.GetFeedByID(feedID)
+	if err != nil {
+		http.Error(w, "Feed not found", http.StatusNotFound)
+		return
+	}
+
+	stats, err := h.DB.GetFeedStats(feedID)
+	if err != nil {
+		log.Printf("Error getting feed stats: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	interval := feedpkg.NewIntelligentRefreshCalculator(h.DB).CalculateInterval(*feed)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(FeedStatsResponse{
+		FeedStats:                 stats,
+		IntelligentRefreshMinutes: int(interval.Minutes()),
+	})
+}
@@ -0,0 +1,76 @@
+package feed_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	fh "MrRSS/internal/handlers/feed"
+	"MrRSS/internal/models"
+)
+
+func TestHandleGetFeedStats(t *testing.T) {
+	h := setupHandler(t)
+
+	id, err := h.DB.AddFeed(&models.Feed{Title: "a", URL: "http://x/1"})
+	if err != nil {
+		t.Fatalf("add feed: %v", err)
+	}
+	// Eight articles a day apart, the last one an hour ago; two of them read
+	last := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for i := 0; i < 8; i++ {
+		a := &models.Article{FeedID: id, Title: fmt.Sprintf("a%d", i), URL: fmt.Sprintf("http://x/1/%d", i), PublishedAt: last.Add(-time.Duration(i) * 24 * time.Hour), IsRead: i < 2}
+		if err := h.DB.SaveArticle(a); err != nil {
+			t.Fatalf("save article: %v", err)
+		}
+	}
+	articles, _ := h.DB.GetArticles("", id, "", false, 10, 0)
+	h.DB.SetArticleContent(articles[0].ID, "<p>Exactly twenty chars</p>")
+	h.DB.SetArticleContent(articles[1].ID, "<p>Ten &amp; char</p>")
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/feeds/stats?feed_id=%d", id), nil)
+	w := httptest.NewRecorder()
+	fh.HandleGetFeedStats(h, w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var stats struct {
+		ArticleCount              int        `json:"article_count"`
+		UnreadCount               int        `json:"unread_count"`
+		UnreadRatio               float64    `json:"unread_ratio"`
+		ArticlesPerWeek           float64    `json:"articles_per_week"`
+		AvgLength                 int        `json:"avg_length"`
+		LastArticleAt             *time.Time `json:"last_article_at"`
+		IntelligentRefreshMinutes int        `json:"intelligent_refresh_minutes"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&stats); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if stats.ArticleCount != 8 || stats.UnreadCount != 6 || stats.UnreadRatio != 0.75 {
+		t.Errorf("unexpected counts %+v", stats)
+	}
+	// Seven days between the oldest and the newest article, so the whole feed is one week
+	if stats.ArticlesPerWeek < 7 || stats.ArticlesPerWeek > 8 {
+		t.Errorf("expected about 8 articles a week, got %v", stats.ArticlesPerWeek)
+	}
+	if stats.AvgLength != 15 {
+		t.Errorf("expected an average length of 15 characters, got %d", stats.AvgLength)
+	}
+	if stats.LastArticleAt == nil || !stats.LastArticleAt.Equal(last) {
+		t.Errorf("expected the last article at %v, got %v", last, stats.LastArticleAt)
+	}
+	// Articles a day apart are refreshed twice a day
+	if stats.IntelligentRefreshMinutes != 12*60 {
+		t.Errorf("expected a 720 minute refresh interval, got %d", stats.IntelligentRefreshMinutes)
+	}
+
+	w = httptest.NewRecorder()
+	fh.HandleGetFeedStats(h, w, httptest.NewRequest(http.MethodGet, "/api/feeds/stats?feed_id=999", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown feed, got %d", w.Code)
+	}
+}
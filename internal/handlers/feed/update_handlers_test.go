@@ -41,3 +41,45 @@ func TestHandleUpdateFeed_ValidAndInvalid(t *testing.T) {
 		t.Fatalf("expected 400 for invalid payload, got %d", w2.Result().StatusCode)
 	}
 }
+
+func TestHandleUpdateFeed_ContentStrategy(t *testing.T) {
+	h := setupHandler(t)
+
+	id, err := h.DB.AddFeed(&models.Feed{Title: "old", URL: "http://example.com/feed"})
+	if err != nil {
+		t.Fatalf("AddFeed error: %v", err)
+	}
+
+	update := func(payload map[string]interface{}) int {
+		payload["id"] = id
+		payload["url"] = "http://example.com/feed"
+		body, _ := json.Marshal(payload)
+		w := httptest.NewRecorder()
+		fh.HandleUpdateFeed(h, w, httptest.NewRequest("POST", "/api/feeds/update", bytes.NewReader(body)))
+		return w.Result().StatusCode
+	}
+
+	if code := update(map[string]interface{}{"content_strategy": []string{"selector", "readability", "feed"}, "content_selector": " article .body "}); code != 200 {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	feed, err := h.DB.GetFeedByID(id)
+	if err != nil {
+		t.Fatalf("GetFeedByID error: %v", err)
+	}
+	if len(feed.ContentStrategy) != 3 || feed.ContentStrategy[0] != "selector" || feed.ContentSelector != "article .body" {
+		t.Fatalf("unexpected content pipeline: %v %q", feed.ContentStrategy, feed.ContentSelector)
+	}
+
+	// Updating only the selector keeps the stored strategy order
+	if code := update(map[string]interface{}{"content_selector": "#main"}); code != 200 {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	feed, _ = h.DB.GetFeedByID(id)
+	if len(feed.ContentStrategy) != 3 || feed.ContentSelector != "#main" {
+		t.Fatalf("expected strategy kept and selector changed, got %v %q", feed.ContentStrategy, feed.ContentSelector)
+	}
+
+	if code := update(map[string]interface{}{"content_strategy": []string{"guess"}}); code != 400 {
+		t.Fatalf("expected 400 for an unknown strategy, got %d", code)
+	}
+}
@@ -0,0 +1,77 @@
+package feed
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"MrRSS/internal/handlers/core"
+	"MrRSS/internal/models"
+)
+
+// maxNewSinceVisitArticles caps how many articles the changelog of a feed returns.
+const maxNewSinceVisitArticles = 200
+
+// FeedNewSinceVisit lists the articles that arrived in a feed since its view was last opened.
+type FeedNewSinceVisit struct {
+	FeedID int64 `json:"feed_id"`
+	// Since is when the feed was last viewed; nil if it never has been.
+	Since    *time.Time       `json:"since"`
+	Articles []models.Article `json:"articles"`
+	Count    int              `json:"count"`
+}
+
+// HandleFeedNewSinceVisit returns the articles published or added since the feed was last viewed.
+// @Summary      Get articles new since the last visit
+// @Description  GET lists articles published or added since the feed's view was last opened. POST does the same and then records the current time as the last visit, so it should be called when the feed view is opened.
+// @Tags         feeds
+// @Accept       json
+// @Produce      json
+// @Param        feed_id  query     int64  true  "Feed ID"
+// @Success      200  {object}  FeedNewSinceVisit  "Articles new since the last visit"
+// @Failure      400  {object}  map[string]string  "Bad request (invalid feed ID)"
+// @Failure      404  {object}  map[string]string  "Feed not found"
+// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Router       /feeds/new-since-visit [get]
+func HandleFeedNewSinceVisit(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	feedID, err := strconv.ParseInt(r.URL.Query().Get("feed_id"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid feed ID", http.StatusBadRequest)
+		return
+	}
+
+	lastViewed, err := h.DB.GetFeedLastViewed(feedID)
+	if err != nil {
+		http.Error(w, "Feed not found", http.StatusNotFound)
+		return
+	}
+
+	resp := FeedNewSinceVisit{FeedID: feedID, Articles: []models.Article{}}
+	// A feed that has never been viewed has no changelog yet; everything in it is new.
+	if !lastViewed.IsZero() {
+		resp.Since = &lastViewed
+		articles, err := h.DB.GetArticlesNewSince(feedID, lastViewed, maxNewSinceVisitArticles)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		resp.Articles = articles
+	}
+	resp.Count = len(resp.Articles)
+
+	if r.Method == http.MethodPost {
+		if err := h.DB.SetFeedLastViewed(feedID); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
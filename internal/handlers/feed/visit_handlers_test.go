@@ -0,0 +1,66 @@
+package feed_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	fh "MrRSS/internal/handlers/feed"
+	"MrRSS/internal/models"
+)
+
+func TestHandleFeedNewSinceVisit(t *testing.T) {
+	h := setupHandler(t)
+
+	id, err := h.DB.AddFeed(&models.Feed{Title: "a", URL: "http://x/1"})
+	if err != nil {
+		t.Fatalf("add feed: %v", err)
+	}
+	if err := h.DB.SaveArticle(&models.Article{FeedID: id, Title: "old", URL: "http://x/old", PublishedAt: time.Now().Add(-48 * time.Hour)}); err != nil {
+		t.Fatalf("save article: %v", err)
+	}
+
+	call := func(method string) fh.FeedNewSinceVisit {
+		t.Helper()
+		req := httptest.NewRequest(method, fmt.Sprintf("/api/feeds/new-since-visit?feed_id=%d", id), nil)
+		w := httptest.NewRecorder()
+		fh.HandleFeedNewSinceVisit(h, w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp fh.FeedNewSinceVisit
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return resp
+	}
+
+	// First visit: nothing to compare against yet.
+	if resp := call(http.MethodPost); resp.Since != nil || resp.Count != 0 {
+		t.Fatalf("unexpected first visit: %+v", resp)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	// Published before the visit but only fetched after it.
+	if err := h.DB.SaveArticle(&models.Article{FeedID: id, Title: "late", URL: "http://x/late", PublishedAt: time.Now().Add(-24 * time.Hour)}); err != nil {
+		t.Fatalf("save article: %v", err)
+	}
+	if err := h.DB.SaveArticle(&models.Article{FeedID: id, Title: "new", URL: "http://x/new", PublishedAt: time.Now()}); err != nil {
+		t.Fatalf("save article: %v", err)
+	}
+
+	// GET peeks without moving the last visit.
+	if resp := call(http.MethodGet); resp.Since == nil || resp.Count != 2 {
+		t.Fatalf("unexpected peek: %+v", resp)
+	}
+	resp := call(http.MethodPost)
+	if resp.Count != 2 || resp.Articles[0].Title != "new" || resp.Articles[1].Title != "late" {
+		t.Fatalf("unexpected changelog: %+v", resp)
+	}
+	if resp := call(http.MethodGet); resp.Count != 0 {
+		t.Fatalf("expected empty changelog after visit, got %+v", resp)
+	}
+}
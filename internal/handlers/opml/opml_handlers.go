@@ -109,8 +109,9 @@ func HandleOPMLImport(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 
 	// Fetch articles for the newly imported feeds asynchronously with progress tracking
 	if len(feedIDs) > 0 {
+		ctx := context.WithoutCancel(r.Context())
 		go func() {
-			h.Fetcher.FetchFeedsByIDs(context.Background(), feedIDs)
+			h.Fetcher.FetchFeedsByIDs(ctx, feedIDs)
 		}()
 	}
 
@@ -295,8 +296,9 @@ func HandleOPMLImportDialog(h *core.Handler, w http.ResponseWriter, r *http.Requ
 
 	// Fetch articles for the newly imported feeds asynchronously with progress tracking
 	if len(feedIDs) > 0 {
+		ctx := context.WithoutCancel(r.Context())
 		go func() {
-			h.Fetcher.FetchFeedsByIDs(context.Background(), feedIDs)
+			h.Fetcher.FetchFeedsByIDs(ctx, feedIDs)
 		}()
 	}
 
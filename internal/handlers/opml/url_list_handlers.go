@@ -0,0 +1,152 @@
+package opml
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"MrRSS/internal/handlers/core"
+	"MrRSS/internal/models"
+	"MrRSS/internal/utils"
+)
+
+// maxURLListSize caps the size of a plain-text URL list upload
+const maxURLListSize = 1 << 20
+
+// URL list import outcomes
+const (
+	URLListAdded     = "added"
+	URLListDuplicate = "duplicate"
+	URLListInvalid   = "invalid"
+	URLListFailed    = "failed"
+	URLListTruncated = "truncated" // The rest of the list couldn't be read
+)
+
+// URLListResult reports what happened to one URL line of an import
+type URLListResult struct {
+	Line     int    `json:"line"`
+	URL      string `json:"url"`
+	Category string `json:"category,omitempty"`
+	Status   string `json:"status"`
+	FeedID   int64  `json:"feed_id,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// HandleImportURLList subscribes to feeds listed one URL per line in a plain-text body.
+// @Summary      Import feeds from a URL list
+// @Description  Subscribe to every feed URL in a plain-text body, one per line. A line starting with # sets the category for the URLs below it ("#" alone resets to uncategorized). Blank lines are skipped, as are URLs that are already subscribed or repeated. New feeds are fetched in the background. If the body can't be read to the end (e.g. it is over 1 MB), the URLs read so far are still imported and a final "truncated" result carries the error.
+// @Tags         opml
+// @Accept       plain
+// @Produce      json
+// @Param        body  body      string  true  "Newline-separated feed URLs"
+// @Success      200  {array}   URLListResult  "Result per URL line"
+// @Failure      400  {object}  map[string]string  "Bad request (nothing could be read)"
+// @Router       /opml/import-urls [post]
+func HandleImportURLList(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	results, err := importURLList(h, http.MaxBytesReader(w, r.Body, maxURLListSize))
+	if err != nil && len(results) == 1 {
+		// Only the truncated result, so no URL was read
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var feedIDs []int64
+	for _, result := range results {
+		if result.Status == URLListAdded {
+			feedIDs = append(feedIDs, result.FeedID)
+		}
+	}
+	if len(feedIDs) > 0 {
+		utils.ContextLog(r.Context(), "Imported %d feeds from URL list", len(feedIDs))
+		go h.Fetcher.FetchFeedsByIDs(context.WithoutCancel(r.Context()), feedIDs)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
+
+// importURLList subscribes to each URL line and returns one result per URL line. When the
+// body fails partway, the lines read so far are kept and a final truncated result is
+// returned along with the error.
+func importURLList(h *core.Handler, body io.Reader) ([]URLListResult, error) {
+	results := []URLListResult{}
+	seen := make(map[string]bool)
+	category := ""
+
+	scanner := bufio.NewScanner(body)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			category = strings.TrimSpace(strings.TrimPrefix(line, "#"))
+			continue
+		}
+
+		result := URLListResult{Line: lineNo, URL: line, Category: category}
+		feedURL := utils.NormalizeFeedURL(line)
+		if !isValidFeedURL(feedURL) {
+			result.Status = URLListInvalid
+			result.Error = "not a valid feed URL"
+			results = append(results, result)
+			continue
+		}
+		result.URL = feedURL
+
+		canonical := utils.CanonicalFeedURL(feedURL)
+		if seen[canonical] {
+			result.Status = URLListDuplicate
+			results = append(results, result)
+			continue
+		}
+		seen[canonical] = true
+
+		if existing, err := h.DB.FindFeedByNormalizedURL(feedURL); err == nil && existing != nil {
+			result.Status = URLListDuplicate
+			result.FeedID = existing.ID
+			results = append(results, result)
+			continue
+		}
+
+		// The title is filled in from the feed on its first fetch
+		feedID, err := h.DB.AddFeed(&models.Feed{URL: feedURL, Category: category})
+		if err != nil {
+			result.Status = URLListFailed
+			result.Error = err.Error()
+		} else {
+			result.Status = URLListAdded
+			result.FeedID = feedID
+		}
+		results = append(results, result)
+	}
+	if err := scanner.Err(); err != nil {
+		results = append(results, URLListResult{Line: lineNo + 1, Status: URLListTruncated, Error: err.Error()})
+		return results, err
+	}
+	return results, nil
+}
+
+// isValidFeedURL reports whether a normalized line looks like a subscribable feed URL
+func isValidFeedURL(feedURL string) bool {
+	parsed, err := url.Parse(feedURL)
+	if err != nil || parsed.Host == "" || strings.ContainsAny(feedURL, " \t") {
+		return false
+	}
+	switch parsed.Scheme {
+	case "http", "https", "feed", "rsshub":
+		return true
+	}
+	return false
+}
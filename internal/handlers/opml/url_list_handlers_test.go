@@ -0,0 +1,118 @@
+package opml
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"MrRSS/internal/database"
+	corepkg "MrRSS/internal/handlers/core"
+	"MrRSS/internal/models"
+)
+
+func TestImportURLList(t *testing.T) {
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	if err := db.Init(); err != nil {
+		t.Fatalf("failed to init db: %v", err)
+	}
+	if _, err := db.AddFeed(&models.Feed{Title: "Existing", URL: "https://existing.example.com/feed"}); err != nil {
+		t.Fatalf("AddFeed failed: %v", err)
+	}
+	h := &corepkg.Handler{DB: db}
+
+	list := `https://a.example.com/rss
+
+# Tech
+a.example.com/other
+http://www.existing.example.com/feed/
+https://b.example.com/atom.xml
+https://b.example.com/atom.xml/
+not a url
+#
+https://c.example.com/feed
+`
+	results, err := importURLList(h, strings.NewReader(list))
+	if err != nil {
+		t.Fatalf("importURLList failed: %v", err)
+	}
+
+	want := []struct {
+		line     int
+		status   string
+		category string
+	}{
+		{1, URLListAdded, ""},
+		{4, URLListAdded, "Tech"},
+		{5, URLListDuplicate, "Tech"},
+		{6, URLListAdded, "Tech"},
+		{7, URLListDuplicate, "Tech"},
+		{8, URLListInvalid, "Tech"},
+		{10, URLListAdded, ""},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("expected %d results, got %d: %+v", len(want), len(results), results)
+	}
+	for i, w := range want {
+		got := results[i]
+		if got.Line != w.line || got.Status != w.status || got.Category != w.category {
+			t.Errorf("result %d = %+v, want line %d status %s category %q", i, got, w.line, w.status, w.category)
+		}
+	}
+
+	feeds, err := db.GetFeeds()
+	if err != nil {
+		t.Fatalf("GetFeeds failed: %v", err)
+	}
+	if len(feeds) != 5 {
+		t.Fatalf("expected 5 feeds after import, got %d", len(feeds))
+	}
+}
+
+func TestImportURLList_ReadErrorKeepsImportedLines(t *testing.T) {
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	if err := db.Init(); err != nil {
+		t.Fatalf("failed to init db: %v", err)
+	}
+	h := &corepkg.Handler{DB: db}
+
+	body := io.MultiReader(
+		strings.NewReader("https://a.example.com/rss\nhttps://b.example.com/rss\n"),
+		iotest.ErrReader(errors.New("request body too large")),
+	)
+	results, err := importURLList(h, body)
+	if err == nil {
+		t.Fatal("expected the read error to be returned")
+	}
+	if len(results) != 3 || results[0].Status != URLListAdded || results[1].Status != URLListAdded {
+		t.Fatalf("expected the lines read before the error to be imported, got %+v", results)
+	}
+	if last := results[2]; last.Status != URLListTruncated || last.Line != 3 || !strings.Contains(last.Error, "too large") {
+		t.Errorf("expected a final truncated result, got %+v", last)
+	}
+
+	feeds, err := db.GetFeeds()
+	if err != nil {
+		t.Fatalf("GetFeeds failed: %v", err)
+	}
+	if len(feeds) != 2 {
+		t.Errorf("expected 2 feeds after a partial import, got %d", len(feeds))
+	}
+}
+
+func TestHandleImportURLList_MethodNotAllowed(t *testing.T) {
+	rr := httptest.NewRecorder()
+	HandleImportURLList(&corepkg.Handler{}, rr, httptest.NewRequest(http.MethodGet, "/api/opml/import-urls", nil))
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", rr.Code)
+	}
+}
@@ -47,21 +47,36 @@ func sanitizeValue(value string) string {
 func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
+		adaptiveConcurrency := safeGetSetting(h, "adaptive_concurrency")
 		aiApiKey := safeGetEncryptedSetting(h, "ai_api_key")
 		aiChatEnabled := safeGetSetting(h, "ai_chat_enabled")
+		aiChatMaxTokens := safeGetSetting(h, "ai_chat_max_tokens")
 		aiCustomHeaders := safeGetSetting(h, "ai_custom_headers")
 		aiEndpoint := safeGetSetting(h, "ai_endpoint")
+		aiEndpoints := safeGetEncryptedSetting(h, "ai_endpoints")
+		aiFeedSummaryPrompt := safeGetSetting(h, "ai_feed_summary_prompt")
 		aiModel := safeGetSetting(h, "ai_model")
+		aiPreamblePatterns := safeGetSetting(h, "ai_preamble_patterns")
+		aiSummaryMaxTokens := safeGetSetting(h, "ai_summary_max_tokens")
 		aiSummaryPrompt := safeGetSetting(h, "ai_summary_prompt")
+		aiTranslationChunkChars := safeGetSetting(h, "ai_translation_chunk_chars")
+		aiTranslationMaxTokens := safeGetSetting(h, "ai_translation_max_tokens")
 		aiTranslationPrompt := safeGetSetting(h, "ai_translation_prompt")
 		aiUsageLimit := safeGetSetting(h, "ai_usage_limit")
 		aiUsageTokens := safeGetSetting(h, "ai_usage_tokens")
+		aiWorkerConcurrency := safeGetSetting(h, "ai_worker_concurrency")
+		aiWorkerTimeout := safeGetSetting(h, "ai_worker_timeout")
 		autoCleanupEnabled := safeGetSetting(h, "auto_cleanup_enabled")
+		autoReadDwellMs := safeGetSetting(h, "auto_read_dwell_ms")
 		autoShowAllContent := safeGetSetting(h, "auto_show_all_content")
+		azureTranslatorKey := safeGetEncryptedSetting(h, "azure_translator_key")
+		azureTranslatorRegion := safeGetSetting(h, "azure_translator_region")
 		baiduAppId := safeGetSetting(h, "baidu_app_id")
 		baiduSecretKey := safeGetEncryptedSetting(h, "baidu_secret_key")
 		closeToTray := safeGetSetting(h, "close_to_tray")
+		collapseDuplicateTitles := safeGetSetting(h, "collapse_duplicate_titles")
 		compactMode := safeGetSetting(h, "compact_mode")
+		compressArticleContent := safeGetSetting(h, "compress_article_content")
 		contentFontFamily := safeGetSetting(h, "content_font_family")
 		contentFontSize := safeGetSetting(h, "content_font_size")
 		contentLineHeight := safeGetSetting(h, "content_line_height")
@@ -78,35 +93,58 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 		deeplApiKey := safeGetEncryptedSetting(h, "deepl_api_key")
 		deeplEndpoint := safeGetSetting(h, "deepl_endpoint")
 		defaultViewMode := safeGetSetting(h, "default_view_mode")
+		discoveryFeedTimeout := safeGetSetting(h, "discovery_feed_timeout")
+		discoveryResultTtl := safeGetSetting(h, "discovery_result_ttl")
+		discoveryTargetCount := safeGetSetting(h, "discovery_target_count")
+		discoveryValidationRetries := safeGetSetting(h, "discovery_validation_retries")
+		discoveryValidationTimeout := safeGetSetting(h, "discovery_validation_timeout")
 		feedDrawerExpanded := safeGetSetting(h, "feed_drawer_expanded")
 		feedDrawerPinned := safeGetSetting(h, "feed_drawer_pinned")
 		freshrssApiPassword := safeGetEncryptedSetting(h, "freshrss_api_password")
 		freshrssAutoSyncInterval := safeGetSetting(h, "freshrss_auto_sync_interval")
 		freshrssEnabled := safeGetSetting(h, "freshrss_enabled")
+		freshrssInsecureTls := safeGetSetting(h, "freshrss_insecure_tls")
 		freshrssLastSyncTime := safeGetSetting(h, "freshrss_last_sync_time")
+		freshrssPushDelaySeconds := safeGetSetting(h, "freshrss_push_delay_seconds")
+		freshrssSaveBatchSize := safeGetSetting(h, "freshrss_save_batch_size")
+		freshrssServerType := safeGetSetting(h, "freshrss_server_type")
 		freshrssServerUrl := safeGetSetting(h, "freshrss_server_url")
 		freshrssSyncOnStartup := safeGetSetting(h, "freshrss_sync_on_startup")
+		freshrssTimeoutSeconds := safeGetSetting(h, "freshrss_timeout_seconds")
 		freshrssUsername := safeGetSetting(h, "freshrss_username")
 		fullTextFetchEnabled := safeGetSetting(h, "full_text_fetch_enabled")
 		googleTranslateEndpoint := safeGetSetting(h, "google_translate_endpoint")
+		googleTranslateFallbackProvider := safeGetSetting(h, "google_translate_fallback_provider")
 		hoverMarkAsRead := safeGetSetting(h, "hover_mark_as_read")
+		httpIdleConnTimeout := safeGetSetting(h, "http_idle_conn_timeout")
+		httpMaxIdleConns := safeGetSetting(h, "http_max_idle_conns")
+		httpMaxIdleConnsPerHost := safeGetSetting(h, "http_max_idle_conns_per_host")
 		imageGalleryEnabled := safeGetSetting(h, "image_gallery_enabled")
+		keepFavoriteContent := safeGetSetting(h, "keep_favorite_content")
 		language := safeGetSetting(h, "language")
 		lastGlobalRefresh := safeGetSetting(h, "last_global_refresh")
 		lastNetworkTest := safeGetSetting(h, "last_network_test")
+		libreApiKey := safeGetEncryptedSetting(h, "libre_api_key")
+		libreEndpoint := safeGetSetting(h, "libre_endpoint")
 		maxArticleAgeDays := safeGetSetting(h, "max_article_age_days")
+		maxArticleImages := safeGetSetting(h, "max_article_images")
 		maxCacheSizeMb := safeGetSetting(h, "max_cache_size_mb")
 		maxConcurrentRefreshes := safeGetSetting(h, "max_concurrent_refreshes")
+		maxFetchesPerHour := safeGetSetting(h, "max_fetches_per_hour")
 		mediaCacheEnabled := safeGetSetting(h, "media_cache_enabled")
 		mediaCacheMaxAgeDays := safeGetSetting(h, "media_cache_max_age_days")
 		mediaCacheMaxSizeMb := safeGetSetting(h, "media_cache_max_size_mb")
 		mediaProxyFallback := safeGetSetting(h, "media_proxy_fallback")
+		metadataOnlyFetch := safeGetSetting(h, "metadata_only_fetch")
+		minManualRefreshInterval := safeGetSetting(h, "min_manual_refresh_interval")
 		networkBandwidthMbps := safeGetSetting(h, "network_bandwidth_mbps")
 		networkLatencyMs := safeGetSetting(h, "network_latency_ms")
 		networkSpeed := safeGetSetting(h, "network_speed")
 		obsidianEnabled := safeGetSetting(h, "obsidian_enabled")
 		obsidianVault := safeGetSetting(h, "obsidian_vault")
 		obsidianVaultPath := safeGetSetting(h, "obsidian_vault_path")
+		openExternalLinksNewTab := safeGetSetting(h, "open_external_links_new_tab")
+		openOriginalMarksRead := safeGetSetting(h, "open_original_marks_read")
 		proxyEnabled := safeGetSetting(h, "proxy_enabled")
 		proxyHost := safeGetSetting(h, "proxy_host")
 		proxyPassword := safeGetEncryptedSetting(h, "proxy_password")
@@ -122,14 +160,18 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 		shortcuts := safeGetSetting(h, "shortcuts")
 		shortcutsEnabled := safeGetSetting(h, "shortcuts_enabled")
 		showArticlePreviewImages := safeGetSetting(h, "show_article_preview_images")
+		showFeedBadge := safeGetSetting(h, "show_feed_badge")
 		showHiddenArticles := safeGetSetting(h, "show_hidden_articles")
 		startupOnBoot := safeGetSetting(h, "startup_on_boot")
 		summaryEnabled := safeGetSetting(h, "summary_enabled")
 		summaryLength := safeGetSetting(h, "summary_length")
+		summaryMinLength := safeGetSetting(h, "summary_min_length")
 		summaryProvider := safeGetSetting(h, "summary_provider")
 		summaryTriggerMode := safeGetSetting(h, "summary_trigger_mode")
+		syncConflictStrategy := safeGetSetting(h, "sync_conflict_strategy")
 		targetLanguage := safeGetSetting(h, "target_language")
 		theme := safeGetSetting(h, "theme")
+		translationConcurrency := safeGetSetting(h, "translation_concurrency")
 		translationEnabled := safeGetSetting(h, "translation_enabled")
 		translationOnlyMode := safeGetSetting(h, "translation_only_mode")
 		translationProvider := safeGetSetting(h, "translation_provider")
@@ -140,198 +182,291 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 		windowX := safeGetSetting(h, "window_x")
 		windowY := safeGetSetting(h, "window_y")
 		json.NewEncoder(w).Encode(map[string]string{
-			"ai_api_key":                       aiApiKey,
-			"ai_chat_enabled":                  aiChatEnabled,
-			"ai_custom_headers":                aiCustomHeaders,
-			"ai_endpoint":                      aiEndpoint,
-			"ai_model":                         aiModel,
-			"ai_summary_prompt":                aiSummaryPrompt,
-			"ai_translation_prompt":            aiTranslationPrompt,
-			"ai_usage_limit":                   aiUsageLimit,
-			"ai_usage_tokens":                  aiUsageTokens,
-			"auto_cleanup_enabled":             autoCleanupEnabled,
-			"auto_show_all_content":            autoShowAllContent,
-			"baidu_app_id":                     baiduAppId,
-			"baidu_secret_key":                 baiduSecretKey,
-			"close_to_tray":                    closeToTray,
-			"compact_mode":                     compactMode,
-			"content_font_family":              contentFontFamily,
-			"content_font_size":                contentFontSize,
-			"content_line_height":              contentLineHeight,
-			"custom_css_file":                  customCssFile,
-			"custom_translation_body_template": customTranslationBodyTemplate,
-			"custom_translation_enabled":       customTranslationEnabled,
-			"custom_translation_endpoint":      customTranslationEndpoint,
-			"custom_translation_headers":       customTranslationHeaders,
-			"custom_translation_lang_mapping":  customTranslationLangMapping,
-			"custom_translation_method":        customTranslationMethod,
-			"custom_translation_name":          customTranslationName,
-			"custom_translation_response_path": customTranslationResponsePath,
-			"custom_translation_timeout":       customTranslationTimeout,
-			"deepl_api_key":                    deeplApiKey,
-			"deepl_endpoint":                   deeplEndpoint,
-			"default_view_mode":                defaultViewMode,
-			"feed_drawer_expanded":             feedDrawerExpanded,
-			"feed_drawer_pinned":               feedDrawerPinned,
-			"freshrss_api_password":            freshrssApiPassword,
-			"freshrss_auto_sync_interval":      freshrssAutoSyncInterval,
-			"freshrss_enabled":                 freshrssEnabled,
-			"freshrss_last_sync_time":          freshrssLastSyncTime,
-			"freshrss_server_url":              freshrssServerUrl,
-			"freshrss_sync_on_startup":         freshrssSyncOnStartup,
-			"freshrss_username":                freshrssUsername,
-			"full_text_fetch_enabled":          fullTextFetchEnabled,
-			"google_translate_endpoint":        googleTranslateEndpoint,
-			"hover_mark_as_read":               hoverMarkAsRead,
-			"image_gallery_enabled":            imageGalleryEnabled,
-			"language":                         language,
-			"last_global_refresh":              lastGlobalRefresh,
-			"last_network_test":                lastNetworkTest,
-			"max_article_age_days":             maxArticleAgeDays,
-			"max_cache_size_mb":                maxCacheSizeMb,
-			"max_concurrent_refreshes":         maxConcurrentRefreshes,
-			"media_cache_enabled":              mediaCacheEnabled,
-			"media_cache_max_age_days":         mediaCacheMaxAgeDays,
-			"media_cache_max_size_mb":          mediaCacheMaxSizeMb,
-			"media_proxy_fallback":             mediaProxyFallback,
-			"network_bandwidth_mbps":           networkBandwidthMbps,
-			"network_latency_ms":               networkLatencyMs,
-			"network_speed":                    networkSpeed,
-			"obsidian_enabled":                 obsidianEnabled,
-			"obsidian_vault":                   obsidianVault,
-			"obsidian_vault_path":              obsidianVaultPath,
-			"proxy_enabled":                    proxyEnabled,
-			"proxy_host":                       proxyHost,
-			"proxy_password":                   proxyPassword,
-			"proxy_port":                       proxyPort,
-			"proxy_type":                       proxyType,
-			"proxy_username":                   proxyUsername,
-			"refresh_mode":                     refreshMode,
-			"retry_timeout_seconds":            retryTimeoutSeconds,
-			"rsshub_api_key":                   rsshubApiKey,
-			"rsshub_enabled":                   rsshubEnabled,
-			"rsshub_endpoint":                  rsshubEndpoint,
-			"rules":                            rules,
-			"shortcuts":                        shortcuts,
-			"shortcuts_enabled":                shortcutsEnabled,
-			"show_article_preview_images":      showArticlePreviewImages,
-			"show_hidden_articles":             showHiddenArticles,
-			"startup_on_boot":                  startupOnBoot,
-			"summary_enabled":                  summaryEnabled,
-			"summary_length":                   summaryLength,
-			"summary_provider":                 summaryProvider,
-			"summary_trigger_mode":             summaryTriggerMode,
-			"target_language":                  targetLanguage,
-			"theme":                            theme,
-			"translation_enabled":              translationEnabled,
-			"translation_only_mode":            translationOnlyMode,
-			"translation_provider":             translationProvider,
-			"update_interval":                  updateInterval,
-			"window_height":                    windowHeight,
-			"window_maximized":                 windowMaximized,
-			"window_width":                     windowWidth,
-			"window_x":                         windowX,
-			"window_y":                         windowY,
+			"adaptive_concurrency":               adaptiveConcurrency,
+			"ai_api_key":                         aiApiKey,
+			"ai_chat_enabled":                    aiChatEnabled,
+			"ai_chat_max_tokens":                 aiChatMaxTokens,
+			"ai_custom_headers":                  aiCustomHeaders,
+			"ai_endpoint":                        aiEndpoint,
+			"ai_endpoints":                       aiEndpoints,
+			"ai_feed_summary_prompt":             aiFeedSummaryPrompt,
+			"ai_model":                           aiModel,
+			"ai_preamble_patterns":               aiPreamblePatterns,
+			"ai_summary_max_tokens":              aiSummaryMaxTokens,
+			"ai_summary_prompt":                  aiSummaryPrompt,
+			"ai_translation_chunk_chars":         aiTranslationChunkChars,
+			"ai_translation_max_tokens":          aiTranslationMaxTokens,
+			"ai_translation_prompt":              aiTranslationPrompt,
+			"ai_usage_limit":                     aiUsageLimit,
+			"ai_usage_tokens":                    aiUsageTokens,
+			"ai_worker_concurrency":              aiWorkerConcurrency,
+			"ai_worker_timeout":                  aiWorkerTimeout,
+			"auto_cleanup_enabled":               autoCleanupEnabled,
+			"auto_read_dwell_ms":                 autoReadDwellMs,
+			"auto_show_all_content":              autoShowAllContent,
+			"azure_translator_key":               azureTranslatorKey,
+			"azure_translator_region":            azureTranslatorRegion,
+			"baidu_app_id":                       baiduAppId,
+			"baidu_secret_key":                   baiduSecretKey,
+			"close_to_tray":                      closeToTray,
+			"collapse_duplicate_titles":          collapseDuplicateTitles,
+			"compact_mode":                       compactMode,
+			"compress_article_content":           compressArticleContent,
+			"content_font_family":                contentFontFamily,
+			"content_font_size":                  contentFontSize,
+			"content_line_height":                contentLineHeight,
+			"custom_css_file":                    customCssFile,
+			"custom_translation_body_template":   customTranslationBodyTemplate,
+			"custom_translation_enabled":         customTranslationEnabled,
+			"custom_translation_endpoint":        customTranslationEndpoint,
+			"custom_translation_headers":         customTranslationHeaders,
+			"custom_translation_lang_mapping":    customTranslationLangMapping,
+			"custom_translation_method":          customTranslationMethod,
+			"custom_translation_name":            customTranslationName,
+			"custom_translation_response_path":   customTranslationResponsePath,
+			"custom_translation_timeout":         customTranslationTimeout,
+			"deepl_api_key":                      deeplApiKey,
+			"deepl_endpoint":                     deeplEndpoint,
+			"default_view_mode":                  defaultViewMode,
+			"discovery_feed_timeout":             discoveryFeedTimeout,
+			"discovery_result_ttl":               discoveryResultTtl,
+			"discovery_target_count":             discoveryTargetCount,
+			"discovery_validation_retries":       discoveryValidationRetries,
+			"discovery_validation_timeout":       discoveryValidationTimeout,
+			"feed_drawer_expanded":               feedDrawerExpanded,
+			"feed_drawer_pinned":                 feedDrawerPinned,
+			"freshrss_api_password":              freshrssApiPassword,
+			"freshrss_auto_sync_interval":        freshrssAutoSyncInterval,
+			"freshrss_enabled":                   freshrssEnabled,
+			"freshrss_insecure_tls":              freshrssInsecureTls,
+			"freshrss_last_sync_time":            freshrssLastSyncTime,
+			"freshrss_push_delay_seconds":        freshrssPushDelaySeconds,
+			"freshrss_save_batch_size":           freshrssSaveBatchSize,
+			"freshrss_server_type":               freshrssServerType,
+			"freshrss_server_url":                freshrssServerUrl,
+			"freshrss_sync_on_startup":           freshrssSyncOnStartup,
+			"freshrss_timeout_seconds":           freshrssTimeoutSeconds,
+			"freshrss_username":                  freshrssUsername,
+			"full_text_fetch_enabled":            fullTextFetchEnabled,
+			"google_translate_endpoint":          googleTranslateEndpoint,
+			"google_translate_fallback_provider": googleTranslateFallbackProvider,
+			"hover_mark_as_read":                 hoverMarkAsRead,
+			"http_idle_conn_timeout":             httpIdleConnTimeout,
+			"http_max_idle_conns":                httpMaxIdleConns,
+			"http_max_idle_conns_per_host":       httpMaxIdleConnsPerHost,
+			"image_gallery_enabled":              imageGalleryEnabled,
+			"keep_favorite_content":              keepFavoriteContent,
+			"language":                           language,
+			"last_global_refresh":                lastGlobalRefresh,
+			"last_network_test":                  lastNetworkTest,
+			"libre_api_key":                      libreApiKey,
+			"libre_endpoint":                     libreEndpoint,
+			"max_article_age_days":               maxArticleAgeDays,
+			"max_article_images":                 maxArticleImages,
+			"max_cache_size_mb":                  maxCacheSizeMb,
+			"max_concurrent_refreshes":           maxConcurrentRefreshes,
+			"max_fetches_per_hour":               maxFetchesPerHour,
+			"media_cache_enabled":                mediaCacheEnabled,
+			"media_cache_max_age_days":           mediaCacheMaxAgeDays,
+			"media_cache_max_size_mb":            mediaCacheMaxSizeMb,
+			"media_proxy_fallback":               mediaProxyFallback,
+			"metadata_only_fetch":                metadataOnlyFetch,
+			"min_manual_refresh_interval":        minManualRefreshInterval,
+			"network_bandwidth_mbps":             networkBandwidthMbps,
+			"network_latency_ms":                 networkLatencyMs,
+			"network_speed":                      networkSpeed,
+			"obsidian_enabled":                   obsidianEnabled,
+			"obsidian_vault":                     obsidianVault,
+			"obsidian_vault_path":                obsidianVaultPath,
+			"open_external_links_new_tab":        openExternalLinksNewTab,
+			"open_original_marks_read":           openOriginalMarksRead,
+			"proxy_enabled":                      proxyEnabled,
+			"proxy_host":                         proxyHost,
+			"proxy_password":                     proxyPassword,
+			"proxy_port":                         proxyPort,
+			"proxy_type":                         proxyType,
+			"proxy_username":                     proxyUsername,
+			"refresh_mode":                       refreshMode,
+			"retry_timeout_seconds":              retryTimeoutSeconds,
+			"rsshub_api_key":                     rsshubApiKey,
+			"rsshub_enabled":                     rsshubEnabled,
+			"rsshub_endpoint":                    rsshubEndpoint,
+			"rules":                              rules,
+			"shortcuts":                          shortcuts,
+			"shortcuts_enabled":                  shortcutsEnabled,
+			"show_article_preview_images":        showArticlePreviewImages,
+			"show_feed_badge":                    showFeedBadge,
+			"show_hidden_articles":               showHiddenArticles,
+			"startup_on_boot":                    startupOnBoot,
+			"summary_enabled":                    summaryEnabled,
+			"summary_length":                     summaryLength,
+			"summary_min_length":                 summaryMinLength,
+			"summary_provider":                   summaryProvider,
+			"summary_trigger_mode":               summaryTriggerMode,
+			"sync_conflict_strategy":             syncConflictStrategy,
+			"target_language":                    targetLanguage,
+			"theme":                              theme,
+			"translation_concurrency":            translationConcurrency,
+			"translation_enabled":                translationEnabled,
+			"translation_only_mode":              translationOnlyMode,
+			"translation_provider":               translationProvider,
+			"update_interval":                    updateInterval,
+			"window_height":                      windowHeight,
+			"window_maximized":                   windowMaximized,
+			"window_width":                       windowWidth,
+			"window_x":                           windowX,
+			"window_y":                           windowY,
 		})
 	case http.MethodPost:
 		var req struct {
-			AIAPIKey                      string `json:"ai_api_key"`
-			AIChatEnabled                 string `json:"ai_chat_enabled"`
-			AICustomHeaders               string `json:"ai_custom_headers"`
-			AIEndpoint                    string `json:"ai_endpoint"`
-			AIModel                       string `json:"ai_model"`
-			AISummaryPrompt               string `json:"ai_summary_prompt"`
-			AITranslationPrompt           string `json:"ai_translation_prompt"`
-			AIUsageLimit                  string `json:"ai_usage_limit"`
-			AIUsageTokens                 string `json:"ai_usage_tokens"`
-			AutoCleanupEnabled            string `json:"auto_cleanup_enabled"`
-			AutoShowAllContent            string `json:"auto_show_all_content"`
-			BaiduAppId                    string `json:"baidu_app_id"`
-			BaiduSecretKey                string `json:"baidu_secret_key"`
-			CloseToTray                   string `json:"close_to_tray"`
-			CompactMode                   string `json:"compact_mode"`
-			ContentFontFamily             string `json:"content_font_family"`
-			ContentFontSize               string `json:"content_font_size"`
-			ContentLineHeight             string `json:"content_line_height"`
-			CustomCssFile                 string `json:"custom_css_file"`
-			CustomTranslationBodyTemplate string `json:"custom_translation_body_template"`
-			CustomTranslationEnabled      string `json:"custom_translation_enabled"`
-			CustomTranslationEndpoint     string `json:"custom_translation_endpoint"`
-			CustomTranslationHeaders      string `json:"custom_translation_headers"`
-			CustomTranslationLangMapping  string `json:"custom_translation_lang_mapping"`
-			CustomTranslationMethod       string `json:"custom_translation_method"`
-			CustomTranslationName         string `json:"custom_translation_name"`
-			CustomTranslationResponsePath string `json:"custom_translation_response_path"`
-			CustomTranslationTimeout      string `json:"custom_translation_timeout"`
-			DeeplAPIKey                   string `json:"deepl_api_key"`
-			DeeplEndpoint                 string `json:"deepl_endpoint"`
-			DefaultViewMode               string `json:"default_view_mode"`
-			FeedDrawerExpanded            string `json:"feed_drawer_expanded"`
-			FeedDrawerPinned              string `json:"feed_drawer_pinned"`
-			FreshRSSAPIPassword           string `json:"freshrss_api_password"`
-			FreshRSSAutoSyncInterval      string `json:"freshrss_auto_sync_interval"`
-			FreshRSSEnabled               string `json:"freshrss_enabled"`
-			FreshRSSLastSyncTime          string `json:"freshrss_last_sync_time"`
-			FreshRSSServerUrl             string `json:"freshrss_server_url"`
-			FreshRSSSyncOnStartup         string `json:"freshrss_sync_on_startup"`
-			FreshRSSUsername              string `json:"freshrss_username"`
-			FullTextFetchEnabled          string `json:"full_text_fetch_enabled"`
-			GoogleTranslateEndpoint       string `json:"google_translate_endpoint"`
-			HoverMarkAsRead               string `json:"hover_mark_as_read"`
-			ImageGalleryEnabled           string `json:"image_gallery_enabled"`
-			Language                      string `json:"language"`
-			LastGlobalRefresh             string `json:"last_global_refresh"`
-			LastNetworkTest               string `json:"last_network_test"`
-			MaxArticleAgeDays             string `json:"max_article_age_days"`
-			MaxCacheSizeMb                string `json:"max_cache_size_mb"`
-			MaxConcurrentRefreshes        string `json:"max_concurrent_refreshes"`
-			MediaCacheEnabled             string `json:"media_cache_enabled"`
-			MediaCacheMaxAgeDays          string `json:"media_cache_max_age_days"`
-			MediaCacheMaxSizeMb           string `json:"media_cache_max_size_mb"`
-			MediaProxyFallback            string `json:"media_proxy_fallback"`
-			NetworkBandwidthMbps          string `json:"network_bandwidth_mbps"`
-			NetworkLatencyMs              string `json:"network_latency_ms"`
-			NetworkSpeed                  string `json:"network_speed"`
-			ObsidianEnabled               string `json:"obsidian_enabled"`
-			ObsidianVault                 string `json:"obsidian_vault"`
-			ObsidianVaultPath             string `json:"obsidian_vault_path"`
-			ProxyEnabled                  string `json:"proxy_enabled"`
-			ProxyHost                     string `json:"proxy_host"`
-			ProxyPassword                 string `json:"proxy_password"`
-			ProxyPort                     string `json:"proxy_port"`
-			ProxyType                     string `json:"proxy_type"`
-			ProxyUsername                 string `json:"proxy_username"`
-			RefreshMode                   string `json:"refresh_mode"`
-			RetryTimeoutSeconds           string `json:"retry_timeout_seconds"`
-			RsshubAPIKey                  string `json:"rsshub_api_key"`
-			RsshubEnabled                 string `json:"rsshub_enabled"`
-			RsshubEndpoint                string `json:"rsshub_endpoint"`
-			Rules                         string `json:"rules"`
-			Shortcuts                     string `json:"shortcuts"`
-			ShortcutsEnabled              string `json:"shortcuts_enabled"`
-			ShowArticlePreviewImages      string `json:"show_article_preview_images"`
-			ShowHiddenArticles            string `json:"show_hidden_articles"`
-			StartupOnBoot                 string `json:"startup_on_boot"`
-			SummaryEnabled                string `json:"summary_enabled"`
-			SummaryLength                 string `json:"summary_length"`
-			SummaryProvider               string `json:"summary_provider"`
-			SummaryTriggerMode            string `json:"summary_trigger_mode"`
-			TargetLanguage                string `json:"target_language"`
-			Theme                         string `json:"theme"`
-			TranslationEnabled            string `json:"translation_enabled"`
-			TranslationOnlyMode           string `json:"translation_only_mode"`
-			TranslationProvider           string `json:"translation_provider"`
-			UpdateInterval                string `json:"update_interval"`
-			WindowHeight                  string `json:"window_height"`
-			WindowMaximized               string `json:"window_maximized"`
-			WindowWidth                   string `json:"window_width"`
-			WindowX                       string `json:"window_x"`
-			WindowY                       string `json:"window_y"`
+			AdaptiveConcurrency             string `json:"adaptive_concurrency"`
+			AIAPIKey                        string `json:"ai_api_key"`
+			AIChatEnabled                   string `json:"ai_chat_enabled"`
+			AIChatMaxTokens                 string `json:"ai_chat_max_tokens"`
+			AICustomHeaders                 string `json:"ai_custom_headers"`
+			AIEndpoint                      string `json:"ai_endpoint"`
+			AIEndpoints                     string `json:"ai_endpoints"`
+			AIFeedSummaryPrompt             string `json:"ai_feed_summary_prompt"`
+			AIModel                         string `json:"ai_model"`
+			AIPreamblePatterns              string `json:"ai_preamble_patterns"`
+			AISummaryMaxTokens              string `json:"ai_summary_max_tokens"`
+			AISummaryPrompt                 string `json:"ai_summary_prompt"`
+			AITranslationChunkChars         string `json:"ai_translation_chunk_chars"`
+			AITranslationMaxTokens          string `json:"ai_translation_max_tokens"`
+			AITranslationPrompt             string `json:"ai_translation_prompt"`
+			AIUsageLimit                    string `json:"ai_usage_limit"`
+			AIUsageTokens                   string `json:"ai_usage_tokens"`
+			AIWorkerConcurrency             string `json:"ai_worker_concurrency"`
+			AIWorkerTimeout                 string `json:"ai_worker_timeout"`
+			AutoCleanupEnabled              string `json:"auto_cleanup_enabled"`
+			AutoReadDwellMs                 string `json:"auto_read_dwell_ms"`
+			AutoShowAllContent              string `json:"auto_show_all_content"`
+			AzureTranslatorKey              string `json:"azure_translator_key"`
+			AzureTranslatorRegion           string `json:"azure_translator_region"`
+			BaiduAppId                      string `json:"baidu_app_id"`
+			BaiduSecretKey                  string `json:"baidu_secret_key"`
+			CloseToTray                     string `json:"close_to_tray"`
+			CollapseDuplicateTitles         string `json:"collapse_duplicate_titles"`
+			CompactMode                     string `json:"compact_mode"`
+			CompressArticleContent          string `json:"compress_article_content"`
+			ContentFontFamily               string `json:"content_font_family"`
+			ContentFontSize                 string `json:"content_font_size"`
+			ContentLineHeight               string `json:"content_line_height"`
+			CustomCssFile                   string `json:"custom_css_file"`
+			CustomTranslationBodyTemplate   string `json:"custom_translation_body_template"`
+			CustomTranslationEnabled        string `json:"custom_translation_enabled"`
+			CustomTranslationEndpoint       string `json:"custom_translation_endpoint"`
+			CustomTranslationHeaders        string `json:"custom_translation_headers"`
+			CustomTranslationLangMapping    string `json:"custom_translation_lang_mapping"`
+			CustomTranslationMethod         string `json:"custom_translation_method"`
+			CustomTranslationName           string `json:"custom_translation_name"`
+			CustomTranslationResponsePath   string `json:"custom_translation_response_path"`
+			CustomTranslationTimeout        string `json:"custom_translation_timeout"`
+			DeeplAPIKey                     string `json:"deepl_api_key"`
+			DeeplEndpoint                   string `json:"deepl_endpoint"`
+			DefaultViewMode                 string `json:"default_view_mode"`
+			DiscoveryFeedTimeout            string `json:"discovery_feed_timeout"`
+			DiscoveryResultTtl              string `json:"discovery_result_ttl"`
+			DiscoveryTargetCount            string `json:"discovery_target_count"`
+			DiscoveryValidationRetries      string `json:"discovery_validation_retries"`
+			DiscoveryValidationTimeout      string `json:"discovery_validation_timeout"`
+			FeedDrawerExpanded              string `json:"feed_drawer_expanded"`
+			FeedDrawerPinned                string `json:"feed_drawer_pinned"`
+			FreshRSSAPIPassword             string `json:"freshrss_api_password"`
+			FreshRSSAutoSyncInterval        string `json:"freshrss_auto_sync_interval"`
+			FreshRSSEnabled                 string `json:"freshrss_enabled"`
+			FreshRSSInsecureTls             string `json:"freshrss_insecure_tls"`
+			FreshRSSLastSyncTime            string `json:"freshrss_last_sync_time"`
+			FreshRSSPushDelaySeconds        string `json:"freshrss_push_delay_seconds"`
+			FreshRSSSaveBatchSize           string `json:"freshrss_save_batch_size"`
+			FreshRSSServerType              string `json:"freshrss_server_type"`
+			FreshRSSServerUrl               string `json:"freshrss_server_url"`
+			FreshRSSSyncOnStartup           string `json:"freshrss_sync_on_startup"`
+			FreshRSSTimeoutSeconds          string `json:"freshrss_timeout_seconds"`
+			FreshRSSUsername                string `json:"freshrss_username"`
+			FullTextFetchEnabled            string `json:"full_text_fetch_enabled"`
+			GoogleTranslateEndpoint         string `json:"google_translate_endpoint"`
+			GoogleTranslateFallbackProvider string `json:"google_translate_fallback_provider"`
+			HoverMarkAsRead                 string `json:"hover_mark_as_read"`
+			HttpIdleConnTimeout             string `json:"http_idle_conn_timeout"`
+			HttpMaxIdleConns                string `json:"http_max_idle_conns"`
+			HttpMaxIdleConnsPerHost         string `json:"http_max_idle_conns_per_host"`
+			ImageGalleryEnabled             string `json:"image_gallery_enabled"`
+			KeepFavoriteContent             string `json:"keep_favorite_content"`
+			Language                        string `json:"language"`
+			LastGlobalRefresh               string `json:"last_global_refresh"`
+			LastNetworkTest                 string `json:"last_network_test"`
+			LibreAPIKey                     string `json:"libre_api_key"`
+			LibreEndpoint                   string `json:"libre_endpoint"`
+			MaxArticleAgeDays               string `json:"max_article_age_days"`
+			MaxArticleImages                string `json:"max_article_images"`
+			MaxCacheSizeMb                  string `json:"max_cache_size_mb"`
+			MaxConcurrentRefreshes          string `json:"max_concurrent_refreshes"`
+			MaxFetchesPerHour               string `json:"max_fetches_per_hour"`
+			MediaCacheEnabled               string `json:"media_cache_enabled"`
+			MediaCacheMaxAgeDays            string `json:"media_cache_max_age_days"`
+			MediaCacheMaxSizeMb             string `json:"media_cache_max_size_mb"`
+			MediaProxyFallback              string `json:"media_proxy_fallback"`
+			MetadataOnlyFetch               string `json:"metadata_only_fetch"`
+			MinManualRefreshInterval        string `json:"min_manual_refresh_interval"`
+			NetworkBandwidthMbps            string `json:"network_bandwidth_mbps"`
+			NetworkLatencyMs                string `json:"network_latency_ms"`
+			NetworkSpeed                    string `json:"network_speed"`
+			ObsidianEnabled                 string `json:"obsidian_enabled"`
+			ObsidianVault                   string `json:"obsidian_vault"`
+			ObsidianVaultPath               string `json:"obsidian_vault_path"`
+			OpenExternalLinksNewTab         string `json:"open_external_links_new_tab"`
+			OpenOriginalMarksRead           string `json:"open_original_marks_read"`
+			ProxyEnabled                    string `json:"proxy_enabled"`
+			ProxyHost                       string `json:"proxy_host"`
+			ProxyPassword                   string `json:"proxy_password"`
+			ProxyPort                       string `json:"proxy_port"`
+			ProxyType                       string `json:"proxy_type"`
+			ProxyUsername                   string `json:"proxy_username"`
+			RefreshMode                     string `json:"refresh_mode"`
+			RetryTimeoutSeconds             string `json:"retry_timeout_seconds"`
+			RsshubAPIKey                    string `json:"rsshub_api_key"`
+			RsshubEnabled                   string `json:"rsshub_enabled"`
+			RsshubEndpoint                  string `json:"rsshub_endpoint"`
+			Rules                           string `json:"rules"`
+			Shortcuts                       string `json:"shortcuts"`
+			ShortcutsEnabled                string `json:"shortcuts_enabled"`
+			ShowArticlePreviewImages        string `json:"show_article_preview_images"`
+			ShowFeedBadge                   string `json:"show_feed_badge"`
+			ShowHiddenArticles              string `json:"show_hidden_articles"`
+			StartupOnBoot                   string `json:"startup_on_boot"`
+			SummaryEnabled                  string `json:"summary_enabled"`
+			SummaryLength                   string `json:"summary_length"`
+			SummaryMinLength                string `json:"summary_min_length"`
+			SummaryProvider                 string `json:"summary_provider"`
+			SummaryTriggerMode              string `json:"summary_trigger_mode"`
+			SyncConflictStrategy            string `json:"sync_conflict_strategy"`
+			TargetLanguage                  string `json:"target_language"`
+			Theme                           string `json:"theme"`
+			TranslationConcurrency          string `json:"translation_concurrency"`
+			TranslationEnabled              string `json:"translation_enabled"`
+			TranslationOnlyMode             string `json:"translation_only_mode"`
+			TranslationProvider             string `json:"translation_provider"`
+			UpdateInterval                  string `json:"update_interval"`
+			WindowHeight                    string `json:"window_height"`
+			WindowMaximized                 string `json:"window_maximized"`
+			WindowWidth                     string `json:"window_width"`
+			WindowX                         string `json:"window_x"`
+			WindowY                         string `json:"window_y"`
 		}
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
+		if err := validateSetting("ai_custom_headers", req.AICustomHeaders); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if req.AdaptiveConcurrency != "" {
+			h.DB.SetSetting("adaptive_concurrency", req.AdaptiveConcurrency)
+		}
+
 		if err := h.DB.SetEncryptedSetting("ai_api_key", req.AIAPIKey); err != nil {
 			log.Printf("Failed to save ai_api_key: %v", err)
 			http.Error(w, "Failed to save ai_api_key", http.StatusInternalServerError)
@@ -342,6 +477,10 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 			h.DB.SetSetting("ai_chat_enabled", req.AIChatEnabled)
 		}
 
+		if req.AIChatMaxTokens != "" {
+			h.DB.SetSetting("ai_chat_max_tokens", req.AIChatMaxTokens)
+		}
+
 		if req.AICustomHeaders != "" {
 			h.DB.SetSetting("ai_custom_headers", req.AICustomHeaders)
 		}
@@ -350,14 +489,40 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 			h.DB.SetSetting("ai_endpoint", req.AIEndpoint)
 		}
 
+		if err := h.DB.SetEncryptedSetting("ai_endpoints", req.AIEndpoints); err != nil {
+			log.Printf("Failed to save ai_endpoints: %v", err)
+			http.Error(w, "Failed to save ai_endpoints", http.StatusInternalServerError)
+			return
+		}
+
+		if req.AIFeedSummaryPrompt != "" {
+			h.DB.SetSetting("ai_feed_summary_prompt", req.AIFeedSummaryPrompt)
+		}
+
 		if req.AIModel != "" {
 			h.DB.SetSetting("ai_model", req.AIModel)
 		}
 
+		if req.AIPreamblePatterns != "" {
+			h.DB.SetSetting("ai_preamble_patterns", req.AIPreamblePatterns)
+		}
+
+		if req.AISummaryMaxTokens != "" {
+			h.DB.SetSetting("ai_summary_max_tokens", req.AISummaryMaxTokens)
+		}
+
 		if req.AISummaryPrompt != "" {
 			h.DB.SetSetting("ai_summary_prompt", req.AISummaryPrompt)
 		}
 
+		if req.AITranslationChunkChars != "" {
+			h.DB.SetSetting("ai_translation_chunk_chars", req.AITranslationChunkChars)
+		}
+
+		if req.AITranslationMaxTokens != "" {
+			h.DB.SetSetting("ai_translation_max_tokens", req.AITranslationMaxTokens)
+		}
+
 		if req.AITranslationPrompt != "" {
 			h.DB.SetSetting("ai_translation_prompt", req.AITranslationPrompt)
 		}
@@ -370,14 +535,36 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 			h.DB.SetSetting("ai_usage_tokens", req.AIUsageTokens)
 		}
 
+		if req.AIWorkerConcurrency != "" {
+			h.DB.SetSetting("ai_worker_concurrency", req.AIWorkerConcurrency)
+		}
+
+		if req.AIWorkerTimeout != "" {
+			h.DB.SetSetting("ai_worker_timeout", req.AIWorkerTimeout)
+		}
+
 		if req.AutoCleanupEnabled != "" {
 			h.DB.SetSetting("auto_cleanup_enabled", req.AutoCleanupEnabled)
 		}
 
+		if req.AutoReadDwellMs != "" {
+			h.DB.SetSetting("auto_read_dwell_ms", req.AutoReadDwellMs)
+		}
+
 		if req.AutoShowAllContent != "" {
 			h.DB.SetSetting("auto_show_all_content", req.AutoShowAllContent)
 		}
 
+		if err := h.DB.SetEncryptedSetting("azure_translator_key", req.AzureTranslatorKey); err != nil {
+			log.Printf("Failed to save azure_translator_key: %v", err)
+			http.Error(w, "Failed to save azure_translator_key", http.StatusInternalServerError)
+			return
+		}
+
+		if req.AzureTranslatorRegion != "" {
+			h.DB.SetSetting("azure_translator_region", req.AzureTranslatorRegion)
+		}
+
 		if req.BaiduAppId != "" {
 			h.DB.SetSetting("baidu_app_id", req.BaiduAppId)
 		}
@@ -392,10 +579,18 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 			h.DB.SetSetting("close_to_tray", req.CloseToTray)
 		}
 
+		if req.CollapseDuplicateTitles != "" {
+			h.DB.SetSetting("collapse_duplicate_titles", req.CollapseDuplicateTitles)
+		}
+
 		if req.CompactMode != "" {
 			h.DB.SetSetting("compact_mode", req.CompactMode)
 		}
 
+		if req.CompressArticleContent != "" {
+			h.DB.SetSetting("compress_article_content", req.CompressArticleContent)
+		}
+
 		if req.ContentFontFamily != "" {
 			h.DB.SetSetting("content_font_family", req.ContentFontFamily)
 		}
@@ -462,6 +657,26 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 			h.DB.SetSetting("default_view_mode", req.DefaultViewMode)
 		}
 
+		if req.DiscoveryFeedTimeout != "" {
+			h.DB.SetSetting("discovery_feed_timeout", req.DiscoveryFeedTimeout)
+		}
+
+		if req.DiscoveryResultTtl != "" {
+			h.DB.SetSetting("discovery_result_ttl", req.DiscoveryResultTtl)
+		}
+
+		if req.DiscoveryTargetCount != "" {
+			h.DB.SetSetting("discovery_target_count", req.DiscoveryTargetCount)
+		}
+
+		if req.DiscoveryValidationRetries != "" {
+			h.DB.SetSetting("discovery_validation_retries", req.DiscoveryValidationRetries)
+		}
+
+		if req.DiscoveryValidationTimeout != "" {
+			h.DB.SetSetting("discovery_validation_timeout", req.DiscoveryValidationTimeout)
+		}
+
 		if req.FeedDrawerExpanded != "" {
 			h.DB.SetSetting("feed_drawer_expanded", req.FeedDrawerExpanded)
 		}
@@ -484,10 +699,26 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 			h.DB.SetSetting("freshrss_enabled", req.FreshRSSEnabled)
 		}
 
+		if req.FreshRSSInsecureTls != "" {
+			h.DB.SetSetting("freshrss_insecure_tls", req.FreshRSSInsecureTls)
+		}
+
 		if req.FreshRSSLastSyncTime != "" {
 			h.DB.SetSetting("freshrss_last_sync_time", req.FreshRSSLastSyncTime)
 		}
 
+		if req.FreshRSSPushDelaySeconds != "" {
+			h.DB.SetSetting("freshrss_push_delay_seconds", req.FreshRSSPushDelaySeconds)
+		}
+
+		if req.FreshRSSSaveBatchSize != "" {
+			h.DB.SetSetting("freshrss_save_batch_size", req.FreshRSSSaveBatchSize)
+		}
+
+		if req.FreshRSSServerType != "" {
+			h.DB.SetSetting("freshrss_server_type", req.FreshRSSServerType)
+		}
+
 		if req.FreshRSSServerUrl != "" {
 			h.DB.SetSetting("freshrss_server_url", req.FreshRSSServerUrl)
 		}
@@ -496,6 +727,10 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 			h.DB.SetSetting("freshrss_sync_on_startup", req.FreshRSSSyncOnStartup)
 		}
 
+		if req.FreshRSSTimeoutSeconds != "" {
+			h.DB.SetSetting("freshrss_timeout_seconds", req.FreshRSSTimeoutSeconds)
+		}
+
 		if req.FreshRSSUsername != "" {
 			h.DB.SetSetting("freshrss_username", req.FreshRSSUsername)
 		}
@@ -508,14 +743,34 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 			h.DB.SetSetting("google_translate_endpoint", req.GoogleTranslateEndpoint)
 		}
 
+		if req.GoogleTranslateFallbackProvider != "" {
+			h.DB.SetSetting("google_translate_fallback_provider", req.GoogleTranslateFallbackProvider)
+		}
+
 		if req.HoverMarkAsRead != "" {
 			h.DB.SetSetting("hover_mark_as_read", req.HoverMarkAsRead)
 		}
 
+		if req.HttpIdleConnTimeout != "" {
+			h.DB.SetSetting("http_idle_conn_timeout", req.HttpIdleConnTimeout)
+		}
+
+		if req.HttpMaxIdleConns != "" {
+			h.DB.SetSetting("http_max_idle_conns", req.HttpMaxIdleConns)
+		}
+
+		if req.HttpMaxIdleConnsPerHost != "" {
+			h.DB.SetSetting("http_max_idle_conns_per_host", req.HttpMaxIdleConnsPerHost)
+		}
+
 		if req.ImageGalleryEnabled != "" {
 			h.DB.SetSetting("image_gallery_enabled", req.ImageGalleryEnabled)
 		}
 
+		if req.KeepFavoriteContent != "" {
+			h.DB.SetSetting("keep_favorite_content", req.KeepFavoriteContent)
+		}
+
 		if req.Language != "" {
 			h.DB.SetSetting("language", req.Language)
 		}
@@ -528,10 +783,24 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 			h.DB.SetSetting("last_network_test", req.LastNetworkTest)
 		}
 
+		if err := h.DB.SetEncryptedSetting("libre_api_key", req.LibreAPIKey); err != nil {
+			log.Printf("Failed to save libre_api_key: %v", err)
+			http.Error(w, "Failed to save libre_api_key", http.StatusInternalServerError)
+			return
+		}
+
+		if req.LibreEndpoint != "" {
+			h.DB.SetSetting("libre_endpoint", req.LibreEndpoint)
+		}
+
 		if req.MaxArticleAgeDays != "" {
 			h.DB.SetSetting("max_article_age_days", req.MaxArticleAgeDays)
 		}
 
+		if req.MaxArticleImages != "" {
+			h.DB.SetSetting("max_article_images", req.MaxArticleImages)
+		}
+
 		if req.MaxCacheSizeMb != "" {
 			h.DB.SetSetting("max_cache_size_mb", req.MaxCacheSizeMb)
 		}
@@ -540,6 +809,10 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 			h.DB.SetSetting("max_concurrent_refreshes", req.MaxConcurrentRefreshes)
 		}
 
+		if req.MaxFetchesPerHour != "" {
+			h.DB.SetSetting("max_fetches_per_hour", req.MaxFetchesPerHour)
+		}
+
 		if req.MediaCacheEnabled != "" {
 			h.DB.SetSetting("media_cache_enabled", req.MediaCacheEnabled)
 		}
@@ -556,6 +829,14 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 			h.DB.SetSetting("media_proxy_fallback", req.MediaProxyFallback)
 		}
 
+		if req.MetadataOnlyFetch != "" {
+			h.DB.SetSetting("metadata_only_fetch", req.MetadataOnlyFetch)
+		}
+
+		if req.MinManualRefreshInterval != "" {
+			h.DB.SetSetting("min_manual_refresh_interval", req.MinManualRefreshInterval)
+		}
+
 		if req.NetworkBandwidthMbps != "" {
 			h.DB.SetSetting("network_bandwidth_mbps", req.NetworkBandwidthMbps)
 		}
@@ -580,6 +861,14 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 			h.DB.SetSetting("obsidian_vault_path", req.ObsidianVaultPath)
 		}
 
+		if req.OpenExternalLinksNewTab != "" {
+			h.DB.SetSetting("open_external_links_new_tab", req.OpenExternalLinksNewTab)
+		}
+
+		if req.OpenOriginalMarksRead != "" {
+			h.DB.SetSetting("open_original_marks_read", req.OpenOriginalMarksRead)
+		}
+
 		if req.ProxyEnabled != "" {
 			h.DB.SetSetting("proxy_enabled", req.ProxyEnabled)
 		}
@@ -646,6 +935,10 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 			h.DB.SetSetting("show_article_preview_images", req.ShowArticlePreviewImages)
 		}
 
+		if req.ShowFeedBadge != "" {
+			h.DB.SetSetting("show_feed_badge", req.ShowFeedBadge)
+		}
+
 		if req.ShowHiddenArticles != "" {
 			h.DB.SetSetting("show_hidden_articles", req.ShowHiddenArticles)
 		}
@@ -662,6 +955,10 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 			h.DB.SetSetting("summary_length", req.SummaryLength)
 		}
 
+		if req.SummaryMinLength != "" {
+			h.DB.SetSetting("summary_min_length", req.SummaryMinLength)
+		}
+
 		if req.SummaryProvider != "" {
 			h.DB.SetSetting("summary_provider", req.SummaryProvider)
 		}
@@ -670,6 +967,10 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 			h.DB.SetSetting("summary_trigger_mode", req.SummaryTriggerMode)
 		}
 
+		if req.SyncConflictStrategy != "" {
+			h.DB.SetSetting("sync_conflict_strategy", req.SyncConflictStrategy)
+		}
+
 		if req.TargetLanguage != "" {
 			h.DB.SetSetting("target_language", req.TargetLanguage)
 		}
@@ -678,6 +979,10 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 			h.DB.SetSetting("theme", req.Theme)
 		}
 
+		if req.TranslationConcurrency != "" {
+			h.DB.SetSetting("translation_concurrency", req.TranslationConcurrency)
+		}
+
 		if req.TranslationEnabled != "" {
 			h.DB.SetSetting("translation_enabled", req.TranslationEnabled)
 		}
@@ -714,21 +1019,36 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 			h.DB.SetSetting("window_y", req.WindowY)
 		}
 		// Re-fetch all settings after save to return updated values
+		adaptiveConcurrency := safeGetSetting(h, "adaptive_concurrency")
 		aiApiKey := safeGetEncryptedSetting(h, "ai_api_key")
 		aiChatEnabled := safeGetSetting(h, "ai_chat_enabled")
+		aiChatMaxTokens := safeGetSetting(h, "ai_chat_max_tokens")
 		aiCustomHeaders := safeGetSetting(h, "ai_custom_headers")
 		aiEndpoint := safeGetSetting(h, "ai_endpoint")
+		aiEndpoints := safeGetEncryptedSetting(h, "ai_endpoints")
+		aiFeedSummaryPrompt := safeGetSetting(h, "ai_feed_summary_prompt")
 		aiModel := safeGetSetting(h, "ai_model")
+		aiPreamblePatterns := safeGetSetting(h, "ai_preamble_patterns")
+		aiSummaryMaxTokens := safeGetSetting(h, "ai_summary_max_tokens")
 		aiSummaryPrompt := safeGetSetting(h, "ai_summary_prompt")
+		aiTranslationChunkChars := safeGetSetting(h, "ai_translation_chunk_chars")
+		aiTranslationMaxTokens := safeGetSetting(h, "ai_translation_max_tokens")
 		aiTranslationPrompt := safeGetSetting(h, "ai_translation_prompt")
 		aiUsageLimit := safeGetSetting(h, "ai_usage_limit")
 		aiUsageTokens := safeGetSetting(h, "ai_usage_tokens")
+		aiWorkerConcurrency := safeGetSetting(h, "ai_worker_concurrency")
+		aiWorkerTimeout := safeGetSetting(h, "ai_worker_timeout")
 		autoCleanupEnabled := safeGetSetting(h, "auto_cleanup_enabled")
+		autoReadDwellMs := safeGetSetting(h, "auto_read_dwell_ms")
 		autoShowAllContent := safeGetSetting(h, "auto_show_all_content")
+		azureTranslatorKey := safeGetEncryptedSetting(h, "azure_translator_key")
+		azureTranslatorRegion := safeGetSetting(h, "azure_translator_region")
 		baiduAppId := safeGetSetting(h, "baidu_app_id")
 		baiduSecretKey := safeGetEncryptedSetting(h, "baidu_secret_key")
 		closeToTray := safeGetSetting(h, "close_to_tray")
+		collapseDuplicateTitles := safeGetSetting(h, "collapse_duplicate_titles")
 		compactMode := safeGetSetting(h, "compact_mode")
+		compressArticleContent := safeGetSetting(h, "compress_article_content")
 		contentFontFamily := safeGetSetting(h, "content_font_family")
 		contentFontSize := safeGetSetting(h, "content_font_size")
 		contentLineHeight := safeGetSetting(h, "content_line_height")
@@ -745,35 +1065,58 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 		deeplApiKey := safeGetEncryptedSetting(h, "deepl_api_key")
 		deeplEndpoint := safeGetSetting(h, "deepl_endpoint")
 		defaultViewMode := safeGetSetting(h, "default_view_mode")
+		discoveryFeedTimeout := safeGetSetting(h, "discovery_feed_timeout")
+		discoveryResultTtl := safeGetSetting(h, "discovery_result_ttl")
+		discoveryTargetCount := safeGetSetting(h, "discovery_target_count")
+		discoveryValidationRetries := safeGetSetting(h, "discovery_validation_retries")
+		discoveryValidationTimeout := safeGetSetting(h, "discovery_validation_timeout")
 		feedDrawerExpanded := safeGetSetting(h, "feed_drawer_expanded")
 		feedDrawerPinned := safeGetSetting(h, "feed_drawer_pinned")
 		freshrssApiPassword := safeGetEncryptedSetting(h, "freshrss_api_password")
 		freshrssAutoSyncInterval := safeGetSetting(h, "freshrss_auto_sync_interval")
 		freshrssEnabled := safeGetSetting(h, "freshrss_enabled")
+		freshrssInsecureTls := safeGetSetting(h, "freshrss_insecure_tls")
 		freshrssLastSyncTime := safeGetSetting(h, "freshrss_last_sync_time")
+		freshrssPushDelaySeconds := safeGetSetting(h, "freshrss_push_delay_seconds")
+		freshrssSaveBatchSize := safeGetSetting(h, "freshrss_save_batch_size")
+		freshrssServerType := safeGetSetting(h, "freshrss_server_type")
 		freshrssServerUrl := safeGetSetting(h, "freshrss_server_url")
 		freshrssSyncOnStartup := safeGetSetting(h, "freshrss_sync_on_startup")
+		freshrssTimeoutSeconds := safeGetSetting(h, "freshrss_timeout_seconds")
 		freshrssUsername := safeGetSetting(h, "freshrss_username")
 		fullTextFetchEnabled := safeGetSetting(h, "full_text_fetch_enabled")
 		googleTranslateEndpoint := safeGetSetting(h, "google_translate_endpoint")
+		googleTranslateFallbackProvider := safeGetSetting(h, "google_translate_fallback_provider")
 		hoverMarkAsRead := safeGetSetting(h, "hover_mark_as_read")
+		httpIdleConnTimeout := safeGetSetting(h, "http_idle_conn_timeout")
+		httpMaxIdleConns := safeGetSetting(h, "http_max_idle_conns")
+		httpMaxIdleConnsPerHost := safeGetSetting(h, "http_max_idle_conns_per_host")
 		imageGalleryEnabled := safeGetSetting(h, "image_gallery_enabled")
+		keepFavoriteContent := safeGetSetting(h, "keep_favorite_content")
 		language := safeGetSetting(h, "language")
 		lastGlobalRefresh := safeGetSetting(h, "last_global_refresh")
 		lastNetworkTest := safeGetSetting(h, "last_network_test")
+		libreApiKey := safeGetEncryptedSetting(h, "libre_api_key")
+		libreEndpoint := safeGetSetting(h, "libre_endpoint")
 		maxArticleAgeDays := safeGetSetting(h, "max_article_age_days")
+		maxArticleImages := safeGetSetting(h, "max_article_images")
 		maxCacheSizeMb := safeGetSetting(h, "max_cache_size_mb")
 		maxConcurrentRefreshes := safeGetSetting(h, "max_concurrent_refreshes")
+		maxFetchesPerHour := safeGetSetting(h, "max_fetches_per_hour")
 		mediaCacheEnabled := safeGetSetting(h, "media_cache_enabled")
 		mediaCacheMaxAgeDays := safeGetSetting(h, "media_cache_max_age_days")
 		mediaCacheMaxSizeMb := safeGetSetting(h, "media_cache_max_size_mb")
 		mediaProxyFallback := safeGetSetting(h, "media_proxy_fallback")
+		metadataOnlyFetch := safeGetSetting(h, "metadata_only_fetch")
+		minManualRefreshInterval := safeGetSetting(h, "min_manual_refresh_interval")
 		networkBandwidthMbps := safeGetSetting(h, "network_bandwidth_mbps")
 		networkLatencyMs := safeGetSetting(h, "network_latency_ms")
 		networkSpeed := safeGetSetting(h, "network_speed")
 		obsidianEnabled := safeGetSetting(h, "obsidian_enabled")
 		obsidianVault := safeGetSetting(h, "obsidian_vault")
 		obsidianVaultPath := safeGetSetting(h, "obsidian_vault_path")
+		openExternalLinksNewTab := safeGetSetting(h, "open_external_links_new_tab")
+		openOriginalMarksRead := safeGetSetting(h, "open_original_marks_read")
 		proxyEnabled := safeGetSetting(h, "proxy_enabled")
 		proxyHost := safeGetSetting(h, "proxy_host")
 		proxyPassword := safeGetEncryptedSetting(h, "proxy_password")
@@ -789,14 +1132,18 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 		shortcuts := safeGetSetting(h, "shortcuts")
 		shortcutsEnabled := safeGetSetting(h, "shortcuts_enabled")
 		showArticlePreviewImages := safeGetSetting(h, "show_article_preview_images")
+		showFeedBadge := safeGetSetting(h, "show_feed_badge")
 		showHiddenArticles := safeGetSetting(h, "show_hidden_articles")
 		startupOnBoot := safeGetSetting(h, "startup_on_boot")
 		summaryEnabled := safeGetSetting(h, "summary_enabled")
 		summaryLength := safeGetSetting(h, "summary_length")
+		summaryMinLength := safeGetSetting(h, "summary_min_length")
 		summaryProvider := safeGetSetting(h, "summary_provider")
 		summaryTriggerMode := safeGetSetting(h, "summary_trigger_mode")
+		syncConflictStrategy := safeGetSetting(h, "sync_conflict_strategy")
 		targetLanguage := safeGetSetting(h, "target_language")
 		theme := safeGetSetting(h, "theme")
+		translationConcurrency := safeGetSetting(h, "translation_concurrency")
 		translationEnabled := safeGetSetting(h, "translation_enabled")
 		translationOnlyMode := safeGetSetting(h, "translation_only_mode")
 		translationProvider := safeGetSetting(h, "translation_provider")
@@ -807,98 +1154,140 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 		windowX := safeGetSetting(h, "window_x")
 		windowY := safeGetSetting(h, "window_y")
 		json.NewEncoder(w).Encode(map[string]string{
-			"ai_api_key":                       aiApiKey,
-			"ai_chat_enabled":                  aiChatEnabled,
-			"ai_custom_headers":                aiCustomHeaders,
-			"ai_endpoint":                      aiEndpoint,
-			"ai_model":                         aiModel,
-			"ai_summary_prompt":                aiSummaryPrompt,
-			"ai_translation_prompt":            aiTranslationPrompt,
-			"ai_usage_limit":                   aiUsageLimit,
-			"ai_usage_tokens":                  aiUsageTokens,
-			"auto_cleanup_enabled":             autoCleanupEnabled,
-			"auto_show_all_content":            autoShowAllContent,
-			"baidu_app_id":                     baiduAppId,
-			"baidu_secret_key":                 baiduSecretKey,
-			"close_to_tray":                    closeToTray,
-			"compact_mode":                     compactMode,
-			"content_font_family":              contentFontFamily,
-			"content_font_size":                contentFontSize,
-			"content_line_height":              contentLineHeight,
-			"custom_css_file":                  customCssFile,
-			"custom_translation_body_template": customTranslationBodyTemplate,
-			"custom_translation_enabled":       customTranslationEnabled,
-			"custom_translation_endpoint":      customTranslationEndpoint,
-			"custom_translation_headers":       customTranslationHeaders,
-			"custom_translation_lang_mapping":  customTranslationLangMapping,
-			"custom_translation_method":        customTranslationMethod,
-			"custom_translation_name":          customTranslationName,
-			"custom_translation_response_path": customTranslationResponsePath,
-			"custom_translation_timeout":       customTranslationTimeout,
-			"deepl_api_key":                    deeplApiKey,
-			"deepl_endpoint":                   deeplEndpoint,
-			"default_view_mode":                defaultViewMode,
-			"feed_drawer_expanded":             feedDrawerExpanded,
-			"feed_drawer_pinned":               feedDrawerPinned,
-			"freshrss_api_password":            freshrssApiPassword,
-			"freshrss_auto_sync_interval":      freshrssAutoSyncInterval,
-			"freshrss_enabled":                 freshrssEnabled,
-			"freshrss_last_sync_time":          freshrssLastSyncTime,
-			"freshrss_server_url":              freshrssServerUrl,
-			"freshrss_sync_on_startup":         freshrssSyncOnStartup,
-			"freshrss_username":                freshrssUsername,
-			"full_text_fetch_enabled":          fullTextFetchEnabled,
-			"google_translate_endpoint":        googleTranslateEndpoint,
-			"hover_mark_as_read":               hoverMarkAsRead,
-			"image_gallery_enabled":            imageGalleryEnabled,
-			"language":                         language,
-			"last_global_refresh":              lastGlobalRefresh,
-			"last_network_test":                lastNetworkTest,
-			"max_article_age_days":             maxArticleAgeDays,
-			"max_cache_size_mb":                maxCacheSizeMb,
-			"max_concurrent_refreshes":         maxConcurrentRefreshes,
-			"media_cache_enabled":              mediaCacheEnabled,
-			"media_cache_max_age_days":         mediaCacheMaxAgeDays,
-			"media_cache_max_size_mb":          mediaCacheMaxSizeMb,
-			"media_proxy_fallback":             mediaProxyFallback,
-			"network_bandwidth_mbps":           networkBandwidthMbps,
-			"network_latency_ms":               networkLatencyMs,
-			"network_speed":                    networkSpeed,
-			"obsidian_enabled":                 obsidianEnabled,
-			"obsidian_vault":                   obsidianVault,
-			"obsidian_vault_path":              obsidianVaultPath,
-			"proxy_enabled":                    proxyEnabled,
-			"proxy_host":                       proxyHost,
-			"proxy_password":                   proxyPassword,
-			"proxy_port":                       proxyPort,
-			"proxy_type":                       proxyType,
-			"proxy_username":                   proxyUsername,
-			"refresh_mode":                     refreshMode,
-			"retry_timeout_seconds":            retryTimeoutSeconds,
-			"rsshub_api_key":                   rsshubApiKey,
-			"rsshub_enabled":                   rsshubEnabled,
-			"rsshub_endpoint":                  rsshubEndpoint,
-			"rules":                            rules,
-			"shortcuts":                        shortcuts,
-			"shortcuts_enabled":                shortcutsEnabled,
-			"show_article_preview_images":      showArticlePreviewImages,
-			"show_hidden_articles":             showHiddenArticles,
-			"startup_on_boot":                  startupOnBoot,
-			"summary_enabled":                  summaryEnabled,
-			"summary_length":                   summaryLength,
-			"summary_provider":                 summaryProvider,
-			"summary_trigger_mode":             summaryTriggerMode,
-			"target_language":                  targetLanguage,
-			"theme":                            theme,
-			"translation_enabled":              translationEnabled,
-			"translation_only_mode":            translationOnlyMode,
-			"translation_provider":             translationProvider,
-			"update_interval":                  updateInterval,
-			"window_height":                    windowHeight,
-			"window_maximized":                 windowMaximized,
-			"window_width":                     windowWidth,
-			"window_x":                         windowX,
-			"window_y":                         windowY,
+			"adaptive_concurrency":               adaptiveConcurrency,
+			"ai_api_key":                         aiApiKey,
+			"ai_chat_enabled":                    aiChatEnabled,
+			"ai_chat_max_tokens":                 aiChatMaxTokens,
+			"ai_custom_headers":                  aiCustomHeaders,
+			"ai_endpoint":                        aiEndpoint,
+			"ai_endpoints":                       aiEndpoints,
+			"ai_feed_summary_prompt":             aiFeedSummaryPrompt,
+			"ai_model":                           aiModel,
+			"ai_preamble_patterns":               aiPreamblePatterns,
+			"ai_summary_max_tokens":              aiSummaryMaxTokens,
+			"ai_summary_prompt":                  aiSummaryPrompt,
+			"ai_translation_chunk_chars":         aiTranslationChunkChars,
+			"ai_translation_max_tokens":          aiTranslationMaxTokens,
+			"ai_translation_prompt":              aiTranslationPrompt,
+			"ai_usage_limit":                     aiUsageLimit,
+			"ai_usage_tokens":                    aiUsageTokens,
+			"ai_worker_concurrency":              aiWorkerConcurrency,
+			"ai_worker_timeout":                  aiWorkerTimeout,
+			"auto_cleanup_enabled":               autoCleanupEnabled,
+			"auto_read_dwell_ms":                 autoReadDwellMs,
+			"auto_show_all_content":              autoShowAllContent,
+			"azure_translator_key":               azureTranslatorKey,
+			"azure_translator_region":            azureTranslatorRegion,
+			"baidu_app_id":                       baiduAppId,
+			"baidu_secret_key":                   baiduSecretKey,
+			"close_to_tray":                      closeToTray,
+			"collapse_duplicate_titles":          collapseDuplicateTitles,
+			"compact_mode":                       compactMode,
+			"compress_article_content":           compressArticleContent,
+			"content_font_family":                contentFontFamily,
+			"content_font_size":                  contentFontSize,
+			"content_line_height":                contentLineHeight,
+			"custom_css_file":                    customCssFile,
+			"custom_translation_body_template":   customTranslationBodyTemplate,
+			"custom_translation_enabled":         customTranslationEnabled,
+			"custom_translation_endpoint":        customTranslationEndpoint,
+			"custom_translation_headers":         customTranslationHeaders,
+			"custom_translation_lang_mapping":    customTranslationLangMapping,
+			"custom_translation_method":          customTranslationMethod,
+			"custom_translation_name":            customTranslationName,
+			"custom_translation_response_path":   customTranslationResponsePath,
+			"custom_translation_timeout":         customTranslationTimeout,
+			"deepl_api_key":                      deeplApiKey,
+			"deepl_endpoint":                     deeplEndpoint,
+			"default_view_mode":                  defaultViewMode,
+			"discovery_feed_timeout":             discoveryFeedTimeout,
+			"discovery_result_ttl":               discoveryResultTtl,
+			"discovery_target_count":             discoveryTargetCount,
+			"discovery_validation_retries":       discoveryValidationRetries,
+			"discovery_validation_timeout":       discoveryValidationTimeout,
+			"feed_drawer_expanded":               feedDrawerExpanded,
+			"feed_drawer_pinned":                 feedDrawerPinned,
+			"freshrss_api_password":              freshrssApiPassword,
+			"freshrss_auto_sync_interval":        freshrssAutoSyncInterval,
+			"freshrss_enabled":                   freshrssEnabled,
+			"freshrss_insecure_tls":              freshrssInsecureTls,
+			"freshrss_last_sync_time":            freshrssLastSyncTime,
+			"freshrss_push_delay_seconds":        freshrssPushDelaySeconds,
+			"freshrss_save_batch_size":           freshrssSaveBatchSize,
+			"freshrss_server_type":               freshrssServerType,
+			"freshrss_server_url":                freshrssServerUrl,
+			"freshrss_sync_on_startup":           freshrssSyncOnStartup,
+			"freshrss_timeout_seconds":           freshrssTimeoutSeconds,
+			"freshrss_username":                  freshrssUsername,
+			"full_text_fetch_enabled":            fullTextFetchEnabled,
+			"google_translate_endpoint":          googleTranslateEndpoint,
+			"google_translate_fallback_provider": googleTranslateFallbackProvider,
+			"hover_mark_as_read":                 hoverMarkAsRead,
+			"http_idle_conn_timeout":             httpIdleConnTimeout,
+			"http_max_idle_conns":                httpMaxIdleConns,
+			"http_max_idle_conns_per_host":       httpMaxIdleConnsPerHost,
+			"image_gallery_enabled":              imageGalleryEnabled,
+			"keep_favorite_content":              keepFavoriteContent,
+			"language":                           language,
+			"last_global_refresh":                lastGlobalRefresh,
+			"last_network_test":                  lastNetworkTest,
+			"libre_api_key":                      libreApiKey,
+			"libre_endpoint":                     libreEndpoint,
+			"max_article_age_days":               maxArticleAgeDays,
+			"max_article_images":                 maxArticleImages,
+			"max_cache_size_mb":                  maxCacheSizeMb,
+			"max_concurrent_refreshes":           maxConcurrentRefreshes,
+			"max_fetches_per_hour":               maxFetchesPerHour,
+			"media_cache_enabled":                mediaCacheEnabled,
+			"media_cache_max_age_days":           mediaCacheMaxAgeDays,
+			"media_cache_max_size_mb":            mediaCacheMaxSizeMb,
+			"media_proxy_fallback":               mediaProxyFallback,
+			"metadata_only_fetch":                metadataOnlyFetch,
+			"min_manual_refresh_interval":        minManualRefreshInterval,
+			"network_bandwidth_mbps":             networkBandwidthMbps,
+			"network_latency_ms":                 networkLatencyMs,
+			"network_speed":                      networkSpeed,
+			"obsidian_enabled":                   obsidianEnabled,
+			"obsidian_vault":                     obsidianVault,
+			"obsidian_vault_path":                obsidianVaultPath,
+			"open_external_links_new_tab":        openExternalLinksNewTab,
+			"open_original_marks_read":           openOriginalMarksRead,
+			"proxy_enabled":                      proxyEnabled,
+			"proxy_host":                         proxyHost,
+			"proxy_password":                     proxyPassword,
+			"proxy_port":                         proxyPort,
+			"proxy_type":                         proxyType,
+			"proxy_username":                     proxyUsername,
+			"refresh_mode":                       refreshMode,
+			"retry_timeout_seconds":              retryTimeoutSeconds,
+			"rsshub_api_key":                     rsshubApiKey,
+			"rsshub_enabled":                     rsshubEnabled,
+			"rsshub_endpoint":                    rsshubEndpoint,
+			"rules":                              rules,
+			"shortcuts":                          shortcuts,
+			"shortcuts_enabled":                  shortcutsEnabled,
+			"show_article_preview_images":        showArticlePreviewImages,
+			"show_feed_badge":                    showFeedBadge,
+			"show_hidden_articles":               showHiddenArticles,
+			"startup_on_boot":                    startupOnBoot,
+			"summary_enabled":                    summaryEnabled,
+			"summary_length":                     summaryLength,
+			"summary_min_length":                 summaryMinLength,
+			"summary_provider":                   summaryProvider,
+			"summary_trigger_mode":               summaryTriggerMode,
+			"sync_conflict_strategy":             syncConflictStrategy,
+			"target_language":                    targetLanguage,
+			"theme":                              theme,
+			"translation_concurrency":            translationConcurrency,
+			"translation_enabled":                translationEnabled,
+			"translation_only_mode":              translationOnlyMode,
+			"translation_provider":               translationProvider,
+			"update_interval":                    updateInterval,
+			"window_height":                      windowHeight,
+			"window_maximized":                   windowMaximized,
+			"window_width":                       windowWidth,
+			"window_x":                           windowX,
+			"window_y":                           windowY,
 		})
 	default:
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
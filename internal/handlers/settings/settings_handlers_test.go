@@ -89,3 +89,24 @@ func TestHandleSettings_POST(t *testing.T) {
 		t.Fatalf("expected deepl_api_key decrypted to be deadbeef, got %s", dec)
 	}
 }
+
+func TestHandleSettings_POSTRejectsInvalidCustomHeaders(t *testing.T) {
+	h := setupHandlerWithDB(t)
+
+	body, _ := json.Marshal(map[string]string{
+		"language":          "xx-YY",
+		"ai_custom_headers": `{"Host": "example.com"}`,
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/settings", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	HandleSettings(h, w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 Bad Request, got %d", w.Code)
+	}
+	// Nothing from the rejected request is saved
+	if got, _ := h.DB.GetSetting("language"); got == "xx-YY" {
+		t.Error("expected the other settings not to be saved")
+	}
+}
@@ -0,0 +1,27 @@
+package settings
+
+import (
+	"fmt"
+
+	"MrRSS/internal/ai"
+)
+
+// settingValidators check the settings marked validated in the schema before they are saved
+var settingValidators = map[string]func(value string) error{
+	"ai_custom_headers": func(value string) error {
+		_, err := ai.ValidateCustomHeaders(value)
+		return err
+	},
+}
+
+// validateSetting returns why a value can't be saved for the setting, or nil if it can
+func validateSetting(key, value string) error {
+	validate, ok := settingValidators[key]
+	if !ok || value == "" {
+		return nil
+	}
+	if err := validate(value); err != nil {
+		return fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"MrRSS/internal/ai"
+	"MrRSS/internal/config"
+	"MrRSS/internal/handlers/core"
+	"MrRSS/internal/summary"
+	"MrRSS/internal/utils"
+)
+
+// maxAISummaryInputChars caps how much of an article is sent for an AI summary, in runes
+const maxAISummaryInputChars = 12000
+
+// aiSummaryTimeout bounds a single AI summary request
+const aiSummaryTimeout = 60 * time.Second
+
+// defaultAISummaryPrompt is the system prompt used when ai_summary_prompt isn't set
+const defaultAISummaryPrompt = "You summarize articles for a feed reader. Write a concise summary of the article " +
+	"in a short paragraph or a few bullet points, covering only its main points. Do not add opinions, " +
+	"introductions or information that is not in the article."
+
+// HandleAISummarizeArticle summarizes an article with the configured AI.
+// @Summary      Summarize article with AI
+// @Description  Generate a concise AI summary of an article. Unlike /articles/summarize there is no local fallback: when the AI usage limit is reached or the request fails, an error is returned. Summaries are cached per article.
+// @Tags         summary
+// @Accept       json
+// @Produce      json
+// @Param        request  body      object  true  "Summarize request (article_id)"
+// @Success      200  {object}  map[string]interface{}  "Summary result (summary, html, cached, is_too_short, limit_reached, error)"
+// @Failure      400  {object}  map[string]string  "Bad request (missing article_id)"
+// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Router       /articles/ai-summary [post]
+func HandleAISummarizeArticle(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		ArticleID int64 `json:"article_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.ArticleID <= 0 {
+		http.Error(w, "Missing article_id", http.StatusBadRequest)
+		return
+	}
+
+	if cached, err := h.DB.GetArticleAISummary(req.ArticleID); err == nil && cached != "" {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"summary": cached,
+			"html":    utils.ConvertMarkdownToHTML(cached),
+			"cached":  true,
+		})
+		return
+	}
+
+	content, _, err := h.GetArticleContent(req.ArticleID)
+	if err != nil {
+		utils.ContextLog(r.Context(), "Error getting article content for AI summary: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	text := summary.PlainText(content)
+	if summary.IsContentTooShort(text, getSummaryMinLength(h)) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"summary":      "",
+			"is_too_short": true,
+			"error":        "Not enough content to summarize",
+		})
+		return
+	}
+
+	// Summarizing needs the AI, so a used-up limit is reported instead of degrading
+	if h.AITracker.IsLimitReached() {
+		utils.ContextLog(r.Context(), "AI usage limit reached for AI summary")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"summary":       "",
+			"limit_reached": true,
+			"error":         "AI usage limit reached",
+		})
+		return
+	}
+
+	// Run in the shared AI pool, which applies the rate limit
+	userPrompt := aiSummaryUserPrompt(h, text)
+	var answer ai.ResponseResult
+	err = h.AIPool.Do(r.Context(), func(ctx context.Context) error {
+		var err error
+		answer, err = newAISummaryClient(h).WithContext(ctx).RequestWithThinking(aiSummarySystemPrompt(h), userPrompt)
+		return err
+	})
+	if err != nil {
+		utils.ContextLog(r.Context(), "Error generating AI summary for article %d: %v", req.ArticleID, err)
+		http.Error(w, "Failed to generate AI summary: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	result := strings.TrimSpace(ai.RemoveThinkingTags(answer.Content))
+	if result == "" {
+		http.Error(w, "Failed to generate AI summary: empty response", http.StatusInternalServerError)
+		return
+	}
+	h.AITracker.TrackSummary(userPrompt, result, answer.Usage)
+	_ = h.DB.IncrementStat("ai_summary")
+
+	if err := h.DB.UpdateArticleAISummary(req.ArticleID, result); err != nil {
+		utils.ContextLog(r.Context(), "Failed to cache AI summary for article %d: %v", req.ArticleID, err)
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"summary":       result,
+		"html":          utils.ConvertMarkdownToHTML(result),
+		"cached":        false,
+		"limit_reached": false,
+	})
+}
+
+// newAISummaryClient creates an AI client from the global AI settings, with proxy support
+func newAISummaryClient(h *core.Handler) *ai.Client {
+	defaults := config.Get()
+	apiKey, _ := h.DB.GetEncryptedSetting("ai_api_key")
+	endpoint, _ := h.DB.GetSetting("ai_endpoint")
+	model, _ := h.DB.GetSetting("ai_model")
+	customHeaders, _ := h.DB.GetSetting("ai_custom_headers")
+	if endpoint == "" {
+		endpoint = defaults.AIEndpoint
+	}
+	if model == "" {
+		model = defaults.AIModel
+	}
+
+	httpClient, err := summary.CreateHTTPClientWithProxy(h.DB, aiSummaryTimeout)
+	if err != nil {
+		httpClient = &http.Client{Timeout: aiSummaryTimeout}
+	}
+
+	return ai.NewClientWithHTTPClient(ai.ClientConfig{
+		APIKey:        apiKey,
+		Endpoint:      strings.TrimSuffix(endpoint, "/"),
+		Model:         model,
+		CustomHeaders: customHeaders,
+		Timeout:       aiSummaryTimeout,
+		MaxTokens:     getIntSetting(h, "ai_summary_max_tokens"),
+		Fallbacks:     ai.LoadEndpoints(h.DB),
+	}, httpClient)
+}
+
+// aiSummarySystemPrompt returns the configured summary prompt, or the concise default
+func aiSummarySystemPrompt(h *core.Handler) string {
+	if prompt, _ := h.DB.GetSetting("ai_summary_prompt"); strings.TrimSpace(prompt) != "" {
+		return prompt
+	}
+	return defaultAISummaryPrompt
+}
+
+// aiSummaryUserPrompt asks for the summary in the interface language, with the article text
+// truncated to maxAISummaryInputChars
+func aiSummaryUserPrompt(h *core.Handler, text string) string {
+	if runes := []rune(text); len(runes) > maxAISummaryInputChars {
+		text = string(runes[:maxAISummaryInputChars])
+	}
+
+	language, _ := h.DB.GetSetting("language")
+	if strings.HasPrefix(language, "zh") {
+		return fmt.Sprintf("请用中文总结以下文章：\n\n%s", text)
+	}
+	return fmt.Sprintf("Summarize the following article in English:\n\n%s", text)
+}
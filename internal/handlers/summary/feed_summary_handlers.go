@@ -0,0 +1,145 @@
+package summary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"MrRSS/internal/ai"
+	"MrRSS/internal/handlers/core"
+	"MrRSS/internal/summary"
+	"MrRSS/internal/utils"
+)
+
+// feedSummaryMaxArticles is how many recent unread articles are considered for a feed overview
+const feedSummaryMaxArticles = 50
+
+// HandleSummarizeFeed generates an AI overview of a feed's recent unread articles.
+// @Summary      Summarize feed
+// @Description  Ask the AI for a brief bulleted overview of the themes in a feed's recent unread articles, from their titles and summaries. Uses the ai_feed_summary_prompt setting as the system prompt when set. The input is truncated to fit the model's context, and results are cached briefly per feed until its unread articles change.
+// @Tags         summary
+// @Accept       json
+// @Produce      json
+// @Param        request  body      object  true  "Summarize request (feed_id)"
+// @Success      200  {object}  map[string]interface{}  "Overview (summary, html, article_count, cached, limit_reached, thinking, error)"
+// @Failure      400  {object}  map[string]string  "Bad request (invalid feed ID)"
+// @Failure      404  {object}  map[string]string  "Feed not found"
+// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Router       /feeds/summarize [post]
+func HandleSummarizeFeed(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		FeedID int64 `json:"feed_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.FeedID <= 0 {
+		http.Error(w, "Invalid feed ID", http.StatusBadRequest)
+		return
+	}
+
+	feed, err := h.DB.GetFeedByID(req.FeedID)
+	if err != nil || feed == nil {
+		http.Error(w, "Feed not found", http.StatusNotFound)
+		return
+	}
+
+	articles, err := h.DB.GetArticles("unread", req.FeedID, "", false, feedSummaryMaxArticles, 0)
+	if err != nil {
+		utils.ContextLog(r.Context(), "Error getting articles for feed summary: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(articles) == 0 {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"summary":       "",
+			"article_count": 0,
+			"error":         "No unread articles in this feed",
+		})
+		return
+	}
+
+	var articleKey strings.Builder
+	items := make([]summary.FeedDigestItem, 0, len(articles))
+	for _, article := range articles {
+		fmt.Fprintf(&articleKey, "%d,", article.ID)
+		articleSummary := article.Summary
+		if articleSummary == "<no content>" {
+			articleSummary = ""
+		}
+		items = append(items, summary.FeedDigestItem{Title: article.Title, Summary: articleSummary})
+	}
+
+	if cached, ok := h.CachedFeedSummary(req.FeedID, articleKey.String()); ok {
+		json.NewEncoder(w).Encode(cached)
+		return
+	}
+
+	// The overview has no local fallback, so stop before the AI when the limit is used up
+	if h.AITracker.IsLimitReached() {
+		utils.ContextLog(r.Context(), "AI usage limit reached for feed summary")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"summary":       "",
+			"limit_reached": true,
+			"error":         "AI usage limit reached",
+		})
+		return
+	}
+
+	digest, included := summary.BuildFeedDigest(items, summary.MaxFeedDigestChars)
+
+	apiKey, _ := h.DB.GetEncryptedSetting("ai_api_key")
+	endpoint, _ := h.DB.GetSetting("ai_endpoint")
+	model, _ := h.DB.GetSetting("ai_model")
+	systemPrompt, _ := h.DB.GetSetting("ai_feed_summary_prompt")
+	customHeaders, _ := h.DB.GetSetting("ai_custom_headers")
+	language, _ := h.DB.GetSetting("language")
+
+	aiSummarizer := summary.NewAISummarizerWithDB(apiKey, endpoint, model, h.DB)
+	if systemPrompt != "" {
+		aiSummarizer.SetSystemPrompt(systemPrompt)
+	}
+	if customHeaders != "" {
+		aiSummarizer.SetCustomHeaders(customHeaders)
+	}
+	if language != "" {
+		aiSummarizer.SetLanguage(language)
+	}
+	aiSummarizer.SetFallbackEndpoints(ai.LoadEndpoints(h.DB))
+	aiSummarizer.SetMaxTokens(getIntSetting(h, "ai_summary_max_tokens"))
+
+	// Run in the shared AI pool, which applies the rate limit
+	var result summary.SummaryResult
+	err = h.AIPool.Do(r.Context(), func(ctx context.Context) error {
+		var err error
+		result, err = aiSummarizer.SummarizeFeed(ctx, feed.Title, digest)
+		return err
+	})
+	if err != nil {
+		utils.ContextLog(r.Context(), "Error generating feed summary: %v", err)
+		http.Error(w, "Failed to generate feed summary: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	h.AITracker.TrackSummary(digest, result.Summary, result.Usage)
+	_ = h.DB.IncrementStat("ai_summary")
+
+	response := map[string]interface{}{
+		"summary":       result.Summary,
+		"html":          utils.ConvertMarkdownToHTML(result.Summary),
+		"article_count": included,
+		"limit_reached": false,
+		"thinking":      result.Thinking,
+	}
+	h.StoreFeedSummary(req.FeedID, articleKey.String(), response)
+
+	response["cached"] = false
+	json.NewEncoder(w).Encode(response)
+}
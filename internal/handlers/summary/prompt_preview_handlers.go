@@ -0,0 +1,88 @@
+package summary
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"unicode/utf8"
+
+	"MrRSS/internal/aiusage"
+	"MrRSS/internal/handlers/core"
+	"MrRSS/internal/summary"
+)
+
+// SummaryPromptPreview is the AI summary request that would be made for an article.
+type SummaryPromptPreview struct {
+	ArticleID    int64  `json:"article_id"`
+	SystemPrompt string `json:"system_prompt"`
+	UserPrompt   string `json:"user_prompt"`
+	// Content is the cleaned article text the user prompt embeds
+	Content      string `json:"content"`
+	ContentChars int    `json:"content_chars"`
+	// EstimatedTokens estimates the input tokens of both prompts
+	EstimatedTokens int64 `json:"estimated_tokens"`
+	// IsTooShort reports that the content is below the minimum length, so no AI request would be made
+	IsTooShort bool `json:"is_too_short"`
+}
+
+// HandlePreviewSummaryPrompt assembles the AI summary prompt for an article without calling the AI.
+// @Summary      Preview AI summary prompt
+// @Description  Return the system prompt, user prompt and cleaned article content an AI summary would send, with an estimated token count. No AI request is made.
+// @Tags         summary
+// @Accept       json
+// @Produce      json
+// @Param        article_id  query     int64   true   "Article ID"
+// @Param        length      query     string  false  "Summary length (short, medium, long)"
+// @Success      200  {object}  SummaryPromptPreview  "Prompt preview"
+// @Failure      400  {object}  map[string]string  "Bad request (invalid article ID or length)"
+// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Router       /articles/summarize/preview [get]
+func HandlePreviewSummaryPrompt(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	articleID, err := strconv.ParseInt(r.URL.Query().Get("article_id"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid article_id", http.StatusBadRequest)
+		return
+	}
+
+	summaryLength := summary.Medium
+	switch r.URL.Query().Get("length") {
+	case "short":
+		summaryLength = summary.Short
+	case "long":
+		summaryLength = summary.Long
+	case "medium", "":
+		summaryLength = summary.Medium
+	default:
+		http.Error(w, "Invalid length parameter. Use 'short', 'medium', or 'long'", http.StatusBadRequest)
+		return
+	}
+
+	content, err := getArticleContent(h, articleID, "")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	apiKey, _ := h.DB.GetEncryptedSetting("ai_api_key")
+	prompt := newAISummarizer(h, apiKey, getSummaryMinLength(h)).BuildPrompt(content, summaryLength)
+
+	preview := SummaryPromptPreview{
+		ArticleID:    articleID,
+		SystemPrompt: prompt.SystemPrompt,
+		UserPrompt:   prompt.UserPrompt,
+		Content:      prompt.Content,
+		ContentChars: utf8.RuneCountInString(prompt.Content),
+		IsTooShort:   prompt.IsTooShort,
+	}
+	if !prompt.IsTooShort {
+		preview.EstimatedTokens = aiusage.EstimateTokens(prompt.SystemPrompt + "\n" + prompt.UserPrompt)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(preview)
+}
@@ -1,10 +1,12 @@
 package summary
 
 import (
+	"context"
 	"encoding/json"
-	"log"
 	"net/http"
+	"strconv"
 
+	"MrRSS/internal/ai"
 	"MrRSS/internal/handlers/core"
 	"MrRSS/internal/summary"
 	"MrRSS/internal/utils"
@@ -73,7 +75,7 @@ func HandleSummarizeArticle(h *core.Handler, w http.ResponseWriter, r *http.Requ
 	// Get the article content
 	content, err := getArticleContent(h, req.ArticleID, req.Content)
 	if err != nil {
-		log.Printf("Error getting article content for summary: %v", err)
+		utils.ContextLog(r.Context(), "Error getting article content for summary: %v", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -93,60 +95,56 @@ func HandleSummarizeArticle(h *core.Handler, w http.ResponseWriter, r *http.Requ
 		provider = "local" // Default to local algorithm
 	}
 
+	minLength := getSummaryMinLength(h)
+
 	var result summary.SummaryResult
 	usedFallback := false
 	limitReached := false
 
-	if provider == "ai" {
+	if summary.IsContentTooShort(content, minLength) {
+		// Skip the provider entirely so short link-posts don't cost an AI request
+		summarizer := summary.NewSummarizer()
+		summarizer.SetMinContentLength(minLength)
+		result = summarizer.Summarize(content, summaryLength)
+	} else if provider == "ai" {
 		// Check if AI usage limit is reached - fallback to local if so
 		if h.AITracker.IsLimitReached() {
-			log.Printf("AI usage limit reached, falling back to local summarization")
+			utils.ContextLog(r.Context(), "AI usage limit reached, falling back to local summarization")
 			limitReached = true
 			summarizer := summary.NewSummarizer()
+			summarizer.SetMinContentLength(minLength)
 			result = summarizer.Summarize(content, summaryLength)
 			usedFallback = true
 		} else {
 			// Use AI summarization (API key is optional for some providers)
 			apiKey, err := h.DB.GetEncryptedSetting("ai_api_key")
 			// Some AI providers don't require API keys, so we proceed regardless
-			log.Printf("Using AI summarization (API key: %s)", func() string {
+			utils.ContextLog(r.Context(), "Using AI summarization (API key: %s)", func() string {
 				if apiKey != "" {
 					return "configured"
 				}
 				return "not configured (using keyless provider)"
 			}())
 
-			// Apply rate limiting for AI requests
-			h.AITracker.WaitForRateLimit()
-
-			// Get global AI settings
-			endpoint, _ := h.DB.GetSetting("ai_endpoint")
-			model, _ := h.DB.GetSetting("ai_model")
-			systemPrompt, _ := h.DB.GetSetting("ai_summary_prompt")
-			customHeaders, _ := h.DB.GetSetting("ai_custom_headers")
-			language, _ := h.DB.GetSetting("language")
-
-			aiSummarizer := summary.NewAISummarizerWithDB(apiKey, endpoint, model, h.DB)
-			if systemPrompt != "" {
-				aiSummarizer.SetSystemPrompt(systemPrompt)
-			}
-			if customHeaders != "" {
-				aiSummarizer.SetCustomHeaders(customHeaders)
-			}
-			if language != "" {
-				aiSummarizer.SetLanguage(language)
-			}
-			aiResult, err := aiSummarizer.Summarize(content, summaryLength)
+			// Run in the shared AI pool, which applies the rate limit
+			aiSummarizer := newAISummarizer(h, apiKey, minLength)
+			var aiResult summary.SummaryResult
+			err = h.AIPool.Do(r.Context(), func(ctx context.Context) error {
+				var err error
+				aiResult, err = aiSummarizer.Summarize(ctx, content, summaryLength)
+				return err
+			})
 			if err != nil {
-				log.Printf("Error generating AI summary, falling back to local: %v", err)
+				utils.ContextLog(r.Context(), "Error generating AI summary, falling back to local: %v", err)
 				// Fallback to local algorithm on any AI error
 				summarizer := summary.NewSummarizer()
+				summarizer.SetMinContentLength(minLength)
 				result = summarizer.Summarize(content, summaryLength)
 				usedFallback = true
 			} else {
 				result = aiResult
 				// Track AI usage only on success
-				h.AITracker.TrackSummary(content, result.Summary)
+				h.AITracker.TrackSummary(content, result.Summary, result.Usage)
 				// Track statistics
 				_ = h.DB.IncrementStat("ai_summary")
 			}
@@ -154,12 +152,13 @@ func HandleSummarizeArticle(h *core.Handler, w http.ResponseWriter, r *http.Requ
 	} else {
 		// Use local algorithm
 		summarizer := summary.NewSummarizer()
+		summarizer.SetMinContentLength(minLength)
 		result = summarizer.Summarize(content, summaryLength)
 	}
 
 	// Cache the summary in the database
 	if err := h.DB.UpdateArticleSummary(req.ArticleID, result.Summary); err != nil {
-		log.Printf("Failed to cache summary for article %d: %v", req.ArticleID, err)
+		utils.ContextLog(r.Context(), "Failed to cache summary for article %d: %v", req.ArticleID, err)
 		// Don't fail the request if caching fails
 	}
 
@@ -181,6 +180,51 @@ func HandleSummarizeArticle(h *core.Handler, w http.ResponseWriter, r *http.Requ
 	json.NewEncoder(w).Encode(response)
 }
 
+// newAISummarizer creates an AI summarizer configured from the global AI settings
+func newAISummarizer(h *core.Handler, apiKey string, minLength int) *summary.AISummarizer {
+	endpoint, _ := h.DB.GetSetting("ai_endpoint")
+	model, _ := h.DB.GetSetting("ai_model")
+	systemPrompt, _ := h.DB.GetSetting("ai_summary_prompt")
+	customHeaders, _ := h.DB.GetSetting("ai_custom_headers")
+	language, _ := h.DB.GetSetting("language")
+
+	aiSummarizer := summary.NewAISummarizerWithDB(apiKey, endpoint, model, h.DB)
+	if systemPrompt != "" {
+		aiSummarizer.SetSystemPrompt(systemPrompt)
+	}
+	if customHeaders != "" {
+		aiSummarizer.SetCustomHeaders(customHeaders)
+	}
+	if language != "" {
+		aiSummarizer.SetLanguage(language)
+	}
+	aiSummarizer.SetFallbackEndpoints(ai.LoadEndpoints(h.DB))
+	aiSummarizer.SetMinContentLength(minLength)
+	aiSummarizer.SetMaxTokens(getIntSetting(h, "ai_summary_max_tokens"))
+	return aiSummarizer
+}
+
+// getSummaryMinLength returns the configured minimum content length for summarization
+func getSummaryMinLength(h *core.Handler) int {
+	minLengthStr, err := h.DB.GetSetting("summary_min_length")
+	if err == nil {
+		if minLength, err := strconv.Atoi(minLengthStr); err == nil && minLength > 0 {
+			return minLength
+		}
+	}
+	return summary.MinContentLength
+}
+
+// getIntSetting returns a positive integer setting, or 0 if it is unset or invalid
+func getIntSetting(h *core.Handler, key string) int {
+	if s, err := h.DB.GetSetting(key); err == nil {
+		if n, err := strconv.Atoi(s); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 0
+}
+
 // getArticleContent fetches the content of an article by ID, or uses provided content
 func getArticleContent(h *core.Handler, articleID int64, providedContent string) (string, error) {
 	// If content is provided, use it directly
@@ -209,7 +253,7 @@ func HandleClearSummaries(h *core.Handler, w http.ResponseWriter, r *http.Reques
 	}
 
 	if err := h.DB.ClearAllSummaries(); err != nil {
-		log.Printf("Error clearing summaries: %v", err)
+		utils.ContextLog(r.Context(), "Error clearing summaries: %v", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
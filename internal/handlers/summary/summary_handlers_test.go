@@ -3,10 +3,14 @@ package summary
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 	"unsafe"
@@ -114,3 +118,301 @@ func (m *mockParser) ParseURL(url string) (*gofeed.Feed, error) {
 func (m *mockParser) ParseURLWithContext(url string, ctx context.Context) (*gofeed.Feed, error) {
 	return &gofeed.Feed{Items: m.items}, nil
 }
+
+func TestHandleSummarizeFeed(t *testing.T) {
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	if err := db.Init(); err != nil {
+		t.Fatalf("db init failed: %v", err)
+	}
+	h := core.NewHandler(db, feed.NewFetcher(db), nil)
+
+	var prompts []string
+	aiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		for _, m := range body.Messages {
+			prompts = append(prompts, m.Content)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"- Go releases"}}]}`))
+	}))
+	defer aiServer.Close()
+	db.SetSetting("ai_endpoint", aiServer.URL+"/v1/chat/completions")
+	db.SetSetting("ai_model", "gpt-4o-mini")
+	db.SetSetting("ai_feed_summary_prompt", "List the themes.")
+
+	feedID, err := db.AddFeed(&models.Feed{Title: "Go Blog", URL: "http://example.com/go"})
+	if err != nil {
+		t.Fatalf("AddFeed failed: %v", err)
+	}
+	if err := db.SaveArticles(context.Background(), []*models.Article{
+		{FeedID: feedID, Title: "Go 1.25 is released", URL: "http://example.com/go/1", PublishedAt: time.Now()},
+		{FeedID: feedID, Title: "Range over functions", URL: "http://example.com/go/2", PublishedAt: time.Now().Add(-time.Hour)},
+	}); err != nil {
+		t.Fatalf("SaveArticles failed: %v", err)
+	}
+
+	summarize := func(feedID int64) (int, map[string]interface{}) {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		payload := []byte(fmt.Sprintf(`{"feed_id": %d}`, feedID))
+		HandleSummarizeFeed(h, rr, httptest.NewRequest(http.MethodPost, "/api/feeds/summarize", bytes.NewReader(payload)))
+		var resp map[string]interface{}
+		json.NewDecoder(rr.Body).Decode(&resp)
+		return rr.Code, resp
+	}
+
+	code, resp := summarize(feedID)
+	if code != http.StatusOK || resp["summary"] != "- Go releases" || resp["cached"] != false || resp["article_count"] != float64(2) {
+		t.Fatalf("unexpected response %d: %v", code, resp)
+	}
+	joined := strings.Join(prompts, "\n")
+	if !strings.Contains(joined, "List the themes.") || !strings.Contains(joined, "Go 1.25 is released") || !strings.Contains(joined, "Range over functions") {
+		t.Errorf("expected the configured prompt and article titles to be sent, got %q", joined)
+	}
+
+	// A repeat request is served from the cache
+	calls := len(prompts)
+	if _, resp := summarize(feedID); resp["cached"] != true || len(prompts) != calls {
+		t.Errorf("expected a cached overview without another AI request, got %v", resp)
+	}
+
+	// Reading an article changes the unread set, so the overview is regenerated
+	var readID int64
+	if err := db.QueryRow("SELECT id FROM articles WHERE url = ?", "http://example.com/go/2").Scan(&readID); err != nil {
+		t.Fatalf("failed to query article id: %v", err)
+	}
+	if err := db.MarkArticleRead(readID, true); err != nil {
+		t.Fatalf("MarkArticleRead failed: %v", err)
+	}
+	if _, resp := summarize(feedID); resp["cached"] != false || resp["article_count"] != float64(1) {
+		t.Errorf("expected a fresh overview after the unread articles changed, got %v", resp)
+	}
+
+	// Another handler doesn't see this handler's cache
+	other := core.NewHandler(db, feed.NewFetcher(db), nil)
+	rr := httptest.NewRecorder()
+	HandleSummarizeFeed(other, rr, httptest.NewRequest(http.MethodPost, "/api/feeds/summarize", bytes.NewReader([]byte(fmt.Sprintf(`{"feed_id": %d}`, feedID)))))
+	if strings.Contains(rr.Body.String(), `"cached":true`) {
+		t.Errorf("expected the cache to be per handler, got %s", rr.Body.String())
+	}
+
+	if code, _ := summarize(9999); code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown feed, got %d", code)
+	}
+}
+
+func TestHandlePreviewSummaryPrompt(t *testing.T) {
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	if err := db.Init(); err != nil {
+		t.Fatalf("db init failed: %v", err)
+	}
+	feedID, err := db.AddFeed(&models.Feed{Title: "T", URL: "http://example.com/feed"})
+	if err != nil {
+		t.Fatalf("AddFeed failed: %v", err)
+	}
+	art := &models.Article{FeedID: feedID, Title: "A", URL: "http://example.com/article/1", PublishedAt: time.Now()}
+	if err := db.SaveArticle(art); err != nil {
+		t.Fatalf("SaveArticle failed: %v", err)
+	}
+	var articleID int64
+	if err := db.QueryRow("SELECT id FROM articles WHERE url = ?", art.URL).Scan(&articleID); err != nil {
+		t.Fatalf("failed to query article id: %v", err)
+	}
+	db.SetSetting("ai_summary_prompt", "Summarize tersely.")
+
+	// Cached content keeps the test from fetching the feed over the network
+	content := "<p>" + strings.Repeat("Prompt previews show what would be sent. ", 20) + "</p>"
+	if err := db.SetArticleContent(articleID, content); err != nil {
+		t.Fatalf("SetArticleContent failed: %v", err)
+	}
+	h := core.NewHandler(db, feed.NewFetcher(db), nil)
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/articles/summarize/preview?article_id=%d&length=short", articleID), nil)
+	rr := httptest.NewRecorder()
+	HandlePreviewSummaryPrompt(h, rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected %d got %d; body: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+
+	var preview SummaryPromptPreview
+	if err := json.NewDecoder(rr.Body).Decode(&preview); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if preview.SystemPrompt != "Summarize tersely." {
+		t.Errorf("expected the configured system prompt, got %q", preview.SystemPrompt)
+	}
+	if strings.Contains(preview.Content, "<p>") || !strings.Contains(preview.UserPrompt, preview.Content) {
+		t.Errorf("expected the cleaned content embedded in the user prompt, got %+v", preview)
+	}
+	if preview.IsTooShort || preview.EstimatedTokens <= 0 || preview.ContentChars != len(preview.Content) {
+		t.Errorf("unexpected preview %+v", preview)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/articles/summarize/preview?article_id=x", nil)
+	rr = httptest.NewRecorder()
+	HandlePreviewSummaryPrompt(h, rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected %d for an invalid article ID, got %d", http.StatusBadRequest, rr.Code)
+	}
+}
+
+func TestHandleAISummarizeArticle(t *testing.T) {
+	db, err := database.NewDB(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	if err := db.Init(); err != nil {
+		t.Fatalf("db init failed: %v", err)
+	}
+	h := core.NewHandler(db, feed.NewFetcher(db), nil)
+
+	var calls int
+	aiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"<thinking>plan</thinking>Short **summary**."}}]}`))
+	}))
+	defer aiServer.Close()
+	db.SetSetting("ai_endpoint", aiServer.URL+"/v1/chat/completions")
+	db.SetSetting("ai_model", "gpt-4o-mini")
+
+	feedID, err := db.AddFeed(&models.Feed{Title: "T", URL: "http://example.com/feed"})
+	if err != nil {
+		t.Fatalf("AddFeed failed: %v", err)
+	}
+	art := &models.Article{FeedID: feedID, Title: "A", URL: "http://example.com/article/1", PublishedAt: time.Now()}
+	if err := db.SaveArticle(art); err != nil {
+		t.Fatalf("SaveArticle failed: %v", err)
+	}
+	var articleID int64
+	if err := db.QueryRow("SELECT id FROM articles WHERE url = ?", art.URL).Scan(&articleID); err != nil {
+		t.Fatalf("failed to query article id: %v", err)
+	}
+	content := "<p>" + strings.Repeat("AI summaries are cached per article. ", 20) + "</p>"
+	if err := db.SetArticleContent(articleID, content); err != nil {
+		t.Fatalf("SetArticleContent failed: %v", err)
+	}
+
+	summarize := func() (int, map[string]interface{}) {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		payload := []byte(fmt.Sprintf(`{"article_id": %d}`, articleID))
+		HandleAISummarizeArticle(h, rr, httptest.NewRequest(http.MethodPost, "/api/articles/ai-summary", bytes.NewReader(payload)))
+		var resp map[string]interface{}
+		json.NewDecoder(rr.Body).Decode(&resp)
+		return rr.Code, resp
+	}
+
+	// A used-up limit is an error, not a local summary
+	db.SetSetting("ai_usage_limit", "10")
+	db.SetSetting("ai_usage_tokens", "10")
+	if code, resp := summarize(); code != http.StatusOK || resp["limit_reached"] != true || resp["summary"] != "" || calls != 0 {
+		t.Fatalf("expected a limit error without an AI request, got %d: %v", code, resp)
+	}
+	db.SetSetting("ai_usage_limit", "0")
+
+	code, resp := summarize()
+	if code != http.StatusOK || resp["summary"] != "Short **summary**." || resp["cached"] != false {
+		t.Fatalf("unexpected response %d: %v", code, resp)
+	}
+	if html, _ := resp["html"].(string); !strings.Contains(html, "<strong>summary</strong>") {
+		t.Errorf("expected rendered HTML, got %q", html)
+	}
+	if stored, _ := db.GetArticleAISummary(articleID); stored != "Short **summary**." {
+		t.Errorf("expected the summary to be stored, got %q", stored)
+	}
+
+	// A repeat request is served from the stored summary
+	made := calls
+	if _, resp := summarize(); resp["cached"] != true || resp["summary"] != "Short **summary**." || calls != made {
+		t.Errorf("expected the cached summary without another AI request, got %v", resp)
+	}
+}
+
+func TestHandleAISummarizeArticle_WorkerConcurrency(t *testing.T) {
+	// A file database, since each connection to :memory: would get its own empty database
+	db, err := database.NewDB(t.TempDir() + "/test.db")
+	if err != nil {
+		t.Fatalf("failed to create db: %v", err)
+	}
+	if err := db.Init(); err != nil {
+		t.Fatalf("db init failed: %v", err)
+	}
+	defer db.Close()
+	h := core.NewHandler(db, feed.NewFetcher(db), nil)
+	h.AITracker.SetMinInterval(0)
+
+	var inFlight, maxInFlight int32
+	aiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"Summary."}}]}`))
+	}))
+	defer aiServer.Close()
+	db.SetSetting("ai_endpoint", aiServer.URL+"/v1/chat/completions")
+	db.SetSetting("ai_model", "gpt-4o-mini")
+	db.SetSetting("ai_worker_concurrency", "1")
+
+	feedID, err := db.AddFeed(&models.Feed{Title: "T", URL: "http://example.com/feed"})
+	if err != nil {
+		t.Fatalf("AddFeed failed: %v", err)
+	}
+	var articleIDs []int64
+	for i := 0; i < 4; i++ {
+		art := &models.Article{FeedID: feedID, Title: fmt.Sprintf("A%d", i), URL: fmt.Sprintf("http://example.com/article/%d", i), PublishedAt: time.Now()}
+		if err := db.SaveArticle(art); err != nil {
+			t.Fatalf("SaveArticle failed: %v", err)
+		}
+		var id int64
+		if err := db.QueryRow("SELECT id FROM articles WHERE url = ?", art.URL).Scan(&id); err != nil {
+			t.Fatalf("failed to query article id: %v", err)
+		}
+		if err := db.SetArticleContent(id, "<p>"+strings.Repeat("Bulk summaries share the AI pool. ", 20)+"</p>"); err != nil {
+			t.Fatalf("SetArticleContent failed: %v", err)
+		}
+		articleIDs = append(articleIDs, id)
+	}
+
+	// Summaries requested at once go to the AI one at a time
+	var wg sync.WaitGroup
+	codes := make([]int, len(articleIDs))
+	for i, id := range articleIDs {
+		wg.Add(1)
+		go func(i int, id int64) {
+			defer wg.Done()
+			rr := httptest.NewRecorder()
+			payload := []byte(fmt.Sprintf(`{"article_id": %d}`, id))
+			HandleAISummarizeArticle(h, rr, httptest.NewRequest(http.MethodPost, "/api/articles/ai-summary", bytes.NewReader(payload)))
+			codes[i] = rr.Code
+		}(i, id)
+	}
+	wg.Wait()
+
+	for i, code := range codes {
+		if code != http.StatusOK {
+			t.Errorf("request %d: expected 200, got %d", i, code)
+		}
+	}
+	if maxInFlight != 1 {
+		t.Errorf("expected ai_worker_concurrency to allow one AI call at a time, got %d", maxInFlight)
+	}
+}
@@ -0,0 +1,176 @@
+package translation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"MrRSS/internal/ai"
+	"MrRSS/internal/handlers/core"
+	"MrRSS/internal/models"
+	"MrRSS/internal/translation"
+	"MrRSS/internal/utils"
+)
+
+// maxBatchTranslateItems caps the number of titles in one batch request
+const maxBatchTranslateItems = 200
+
+// maxTitlesPerPrompt caps how many titles are combined into one AI request, keeping both
+// the prompt and the numbered response small enough to come back intact
+const maxTitlesPerPrompt = 25
+
+// BatchTranslateResponse maps article IDs to their translated titles
+type BatchTranslateResponse struct {
+	Translations map[int64]string `json:"translations"`
+	// Failed lists the articles whose title could not be translated
+	Failed       []int64 `json:"failed"`
+	LimitReached bool    `json:"limit_reached"`
+}
+
+// pendingTitle is a title that still has to be translated
+type pendingTitle struct {
+	articleID int64
+	title     string
+}
+
+// HandleTranslateBatch translates the titles of several articles in one request.
+// @Summary      Translate article titles in batch
+// @Description  Translate many article titles at once. Titles already translated are returned as stored and feed translation settings apply as for a single title. With the AI provider titles are combined into numbered-line prompts, falling back to one request per title if a response cannot be matched up.
+// @Tags         translation
+// @Accept       json
+// @Produce      json
+// @Param        request  body      object  true  "Batch request (items of article_id and title, target_language)"
+// @Success      200  {object}  BatchTranslateResponse  "Translated titles by article ID"
+// @Failure      400  {object}  map[string]string  "Bad request (missing fields or too many items)"
+// @Router       /articles/translate-batch [post]
+func HandleTranslateBatch(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Items []struct {
+			ArticleID int64  `json:"article_id"`
+			Title     string `json:"title"`
+		} `json:"items"`
+		TargetLang string `json:"target_language"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(req.Items) == 0 || req.TargetLang == "" {
+		http.Error(w, "Missing required fields", http.StatusBadRequest)
+		return
+	}
+	if len(req.Items) > maxBatchTranslateItems {
+		http.Error(w, "Too many items", http.StatusBadRequest)
+		return
+	}
+
+	resp := BatchTranslateResponse{Translations: make(map[int64]string, len(req.Items)), Failed: []int64{}}
+
+	// Group the titles that need translating by source and target language, which can
+	// differ per feed, keeping the request order
+	type scope struct{ sourceLang, targetLang string }
+	groups := make(map[scope][]pendingTitle)
+	var order []scope
+	for _, item := range req.Items {
+		if item.Title == "" {
+			continue
+		}
+		article, _ := h.DB.GetArticleByID(item.ArticleID)
+		if article != nil && article.TranslatedTitle != "" && article.TranslatedTitle != article.Title {
+			resp.Translations[item.ArticleID] = article.TranslatedTitle
+			continue
+		}
+
+		translationMode, sourceLang, targetLang := titleTranslationScope(h, article, "", req.TargetLang)
+		if translationMode == models.TranslationModeNever {
+			resp.Translations[item.ArticleID] = item.Title
+			continue
+		}
+		if translationMode != models.TranslationModeAlways && !needsTranslation(item.Title, sourceLang, targetLang, false) {
+			// Already in the target language
+			if err := h.DB.UpdateArticleTranslation(item.ArticleID, item.Title); err != nil {
+				utils.ContextLog(r.Context(), "Error storing title translation: %v", err)
+			}
+			resp.Translations[item.ArticleID] = item.Title
+			continue
+		}
+
+		key := scope{sourceLang, targetLang}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], pendingTitle{item.ArticleID, item.Title})
+	}
+
+	isAIProvider := configuredTranslationProvider(h) == "ai"
+	for _, key := range order {
+		if isAIProvider && h.AITracker.IsLimitReached() {
+			resp.LimitReached = true
+		}
+		translated := translateTitles(h, r, groups[key], key.sourceLang, key.targetLang, isAIProvider)
+		for i, title := range groups[key] {
+			if translated[i] == "" {
+				resp.Failed = append(resp.Failed, title.articleID)
+				continue
+			}
+			if err := h.DB.UpdateArticleTranslation(title.articleID, translated[i]); err != nil {
+				utils.ContextLog(r.Context(), "Error storing title translation: %v", err)
+			}
+			resp.Translations[title.articleID] = translated[i]
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// translateTitles translates titles from one source to one target language, returning ""
+// for those that failed. With the AI provider under its usage limit, titles go out in
+// numbered batches through the shared AI pool; a batch whose response cannot be matched
+// up, and every title with other providers, is translated one title at a time.
+func translateTitles(h *core.Handler, r *http.Request, titles []pendingTitle, sourceLang, targetLang string, isAIProvider bool) []string {
+	results := make([]string, len(titles))
+	for start := 0; start < len(titles); start += maxTitlesPerPrompt {
+		end := min(start+maxTitlesPerPrompt, len(titles))
+
+		if isAIProvider && end-start > 1 && !h.AITracker.IsLimitReached() {
+			texts := make([]string, 0, end-start)
+			for _, title := range titles[start:end] {
+				texts = append(texts, title.title)
+			}
+			var translated []string
+			var usage ai.Usage
+			err := h.AIPool.Do(r.Context(), func(ctx context.Context) error {
+				var err error
+				translated, usage, err = translation.TranslateNumberedLines(ctx, texts, translation.WithSourceLanguage(h.Translator, sourceLang), targetLang)
+				return err
+			})
+			if err == nil {
+				h.AITracker.TrackTranslation(strings.Join(texts, "\n"), strings.Join(translated, "\n"), usage)
+				copy(results[start:end], translated)
+				continue
+			}
+			// A response that couldn't be matched up still used tokens
+			if usage.Total() > 0 {
+				h.AITracker.TrackUsage(usage, 0)
+			}
+			utils.ContextLog(r.Context(), "Batch title translation failed, translating one by one: %v", err)
+		}
+
+		for i := start; i < end; i++ {
+			translated, err := translateMarkdownText(h, r, titles[i].title, sourceLang, targetLang)
+			if err != nil {
+				utils.ContextLog(r.Context(), "Error translating title of article %d: %v", titles[i].articleID, err)
+				continue
+			}
+			results[i] = translated
+		}
+	}
+	return results
+}
@@ -0,0 +1,97 @@
+package translation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"MrRSS/internal/aiusage"
+	corepkg "MrRSS/internal/handlers/core"
+)
+
+// numberedTranslator answers numbered batches line by line, or garbles them when broken
+type numberedTranslator struct {
+	calls  int
+	broken bool
+}
+
+func (n *numberedTranslator) Translate(text, targetLang string) (string, error) {
+	n.calls++
+	if n.broken && strings.Contains(text, "\n") {
+		return "Here are your translations", nil
+	}
+	return strings.ToUpper(text), nil
+}
+
+func translateBatch(t *testing.T, h *corepkg.Handler, ids []int64, titles []string) BatchTranslateResponse {
+	t.Helper()
+	items := make([]map[string]interface{}, len(ids))
+	for i := range ids {
+		items[i] = map[string]interface{}{"article_id": ids[i], "title": titles[i]}
+	}
+	b, _ := json.Marshal(map[string]interface{}{"items": items, "target_language": "fr"})
+	rr := httptest.NewRecorder()
+	HandleTranslateBatch(h, rr, httptest.NewRequest(http.MethodPost, "/api/articles/translate-batch", bytes.NewReader(b)))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp BatchTranslateResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	return resp
+}
+
+func TestHandleTranslateBatch(t *testing.T) {
+	db := setupDB(t)
+	if err := db.SetSetting("translation_provider", "ai"); err != nil {
+		t.Fatalf("set provider failed: %v", err)
+	}
+	translator := &numberedTranslator{}
+	tracker := aiusage.NewTracker(db)
+	h := &corepkg.Handler{DB: db, Translator: translator, AITracker: tracker, AIPool: aiusage.NewWorkerPool(tracker)}
+
+	if _, err := db.Exec("INSERT INTO feeds (id, title, url, description) VALUES (1, 'f', 'http://example.com/feed', '')"); err != nil {
+		t.Fatalf("insert feed failed: %v", err)
+	}
+	var ids []int64
+	var titles []string
+	for i := 0; i < 3; i++ {
+		title := fmt.Sprintf("This is article title number %d in English", i)
+		res, err := db.Exec("INSERT INTO articles (feed_id, title, url, published_at) VALUES (1, ?, ?, datetime('now'))", title, fmt.Sprintf("u%d", i))
+		if err != nil {
+			t.Fatalf("insert article failed: %v", err)
+		}
+		id, _ := res.LastInsertId()
+		ids, titles = append(ids, id), append(titles, title)
+	}
+	if err := db.UpdateArticleTranslation(ids[0], "déjà traduit"); err != nil {
+		t.Fatalf("update translation failed: %v", err)
+	}
+
+	// The stored translation is reused and the other two share one request
+	resp := translateBatch(t, h, ids, titles)
+	if translator.calls != 1 || len(resp.Failed) != 0 {
+		t.Fatalf("expected one combined request, got %d calls and failures %v", translator.calls, resp.Failed)
+	}
+	if resp.Translations[ids[0]] != "déjà traduit" || resp.Translations[ids[1]] != strings.ToUpper(titles[1]) || resp.Translations[ids[2]] != strings.ToUpper(titles[2]) {
+		t.Fatalf("unexpected translations %v", resp.Translations)
+	}
+	if article, _ := db.GetArticleByID(ids[2]); article.TranslatedTitle != strings.ToUpper(titles[2]) {
+		t.Errorf("expected the translation to be stored, got %q", article.TranslatedTitle)
+	}
+
+	// An unparsable batch response falls back to one request per title
+	if _, err := db.Exec("UPDATE articles SET translated_title = ''"); err != nil {
+		t.Fatalf("reset translations failed: %v", err)
+	}
+	translator.calls, translator.broken = 0, true
+	resp = translateBatch(t, h, ids[1:], titles[1:])
+	if translator.calls != 3 || resp.Translations[ids[1]] != strings.ToUpper(titles[1]) || resp.Translations[ids[2]] != strings.ToUpper(titles[2]) {
+		t.Fatalf("expected per-title fallback, got %d calls and %v", translator.calls, resp.Translations)
+	}
+}
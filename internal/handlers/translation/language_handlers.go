@@ -0,0 +1,128 @@
+package translation
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"MrRSS/internal/handlers/core"
+	"MrRSS/internal/translation"
+	"MrRSS/internal/utils"
+)
+
+// languageRedetectBatchSize is the number of articles detected and written per transaction
+const languageRedetectBatchSize = 200
+
+// HandleRedetectLanguages re-runs language detection over stored articles in the background.
+// @Summary      Re-detect article languages
+// @Description  Start a background pass that detects the language of articles from their title and summary and stores it in detected_language. By default only articles without a language are visited; all=true re-detects every article, e.g. after the detector learned new languages. Poll /articles/redetect-languages/status for progress.
+// @Tags         translation
+// @Produce      json
+// @Param        all  query     bool  false  "Re-detect articles that already have a language"
+// @Success      202  {object}  core.LanguageRedetectState  "Re-detection started"
+// @Failure      409  {object}  map[string]string  "Re-detection already in progress"
+// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Router       /articles/redetect-languages [post]
+func HandleRedetectLanguages(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	all := r.URL.Query().Get("all") == "true"
+
+	h.LanguageRedetectMu.Lock()
+	if h.LanguageRedetectState != nil && h.LanguageRedetectState.IsRunning {
+		h.LanguageRedetectMu.Unlock()
+		http.Error(w, "Language re-detection already in progress", http.StatusConflict)
+		return
+	}
+
+	total, err := h.DB.CountArticlesNeedingLanguage(all)
+	if err != nil {
+		h.LanguageRedetectMu.Unlock()
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	state := &core.LanguageRedetectState{IsRunning: total > 0, Total: total}
+	h.LanguageRedetectState = state
+	snapshot := *state
+	h.LanguageRedetectMu.Unlock()
+
+	if total > 0 {
+		utils.ContextLog(r.Context(), "Re-detecting languages for %d articles (all=%v)", total, all)
+		go redetectLanguages(h, all)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(snapshot)
+}
+
+// HandleRedetectLanguagesStatus reports the progress of the language re-detection.
+// @Summary      Get language re-detection status
+// @Description  Get the progress of the last language re-detection (is_running, processed, total, updated, error)
+// @Tags         translation
+// @Produce      json
+// @Success      200  {object}  core.LanguageRedetectState  "Re-detection state"
+// @Router       /articles/redetect-languages/status [get]
+func HandleRedetectLanguagesStatus(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	h.LanguageRedetectMu.RLock()
+	state := core.LanguageRedetectState{}
+	if h.LanguageRedetectState != nil {
+		state = *h.LanguageRedetectState
+	}
+	h.LanguageRedetectMu.RUnlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(state)
+}
+
+// redetectLanguages walks the articles in ID order, one batch per transaction,
+// updating the shared state as it goes
+func redetectLanguages(h *core.Handler, all bool) {
+	detector := translation.GetLanguageDetector()
+	var afterID int64
+
+	finish := func(errMsg string) {
+		h.LanguageRedetectMu.Lock()
+		h.LanguageRedetectState.IsRunning = false
+		h.LanguageRedetectState.Error = errMsg
+		h.LanguageRedetectMu.Unlock()
+	}
+
+	for {
+		samples, err := h.DB.GetArticlesNeedingLanguage(afterID, languageRedetectBatchSize, all)
+		if err != nil {
+			log.Printf("Language re-detection failed: %v", err)
+			finish(err.Error())
+			return
+		}
+		if len(samples) == 0 {
+			break
+		}
+
+		languages := make(map[int64]string, len(samples))
+		for _, sample := range samples {
+			languages[sample.ID] = detector.DetectLanguage(sample.Title + "\n" + sample.Summary)
+		}
+		afterID = samples[len(samples)-1].ID
+
+		updated, err := h.DB.UpdateArticleDetectedLanguages(languages)
+		if err != nil {
+			log.Printf("Language re-detection failed: %v", err)
+			finish(err.Error())
+			return
+		}
+
+		h.LanguageRedetectMu.Lock()
+		h.LanguageRedetectState.Processed += len(samples)
+		h.LanguageRedetectState.Updated += updated
+		h.LanguageRedetectMu.Unlock()
+	}
+
+	h.LanguageRedetectMu.RLock()
+	log.Printf("Language re-detection complete: %d of %d articles updated",
+		h.LanguageRedetectState.Updated, h.LanguageRedetectState.Processed)
+	h.LanguageRedetectMu.RUnlock()
+	finish("")
+}
@@ -0,0 +1,93 @@
+package translation
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strings"
+
+	"MrRSS/internal/handlers/core"
+	"MrRSS/internal/translation"
+	"MrRSS/internal/utils"
+)
+
+// RoundTripResponse is the result of translating text to a target language and back
+type RoundTripResponse struct {
+	SourceLanguage     string  `json:"source_language"`
+	TargetLanguage     string  `json:"target_language"`
+	TranslatedText     string  `json:"translated_text"`
+	BackTranslatedText string  `json:"back_translated_text"`
+	Similarity         float64 `json:"similarity"`
+}
+
+// HandleTranslateRoundTrip translates text to a target language and back to evaluate a provider.
+// @Summary      Test translation round trip
+// @Description  Translate text to the target language and back to its source language with the configured provider, and score how close the result is to the original (0..1, word overlap). A low score points at a provider that garbles content. The source language is detected unless given.
+// @Tags         translation
+// @Accept       json
+// @Produce      json
+// @Param        request  body      object  true  "Round-trip request (text, target_language, optional source_language to skip detection)"
+// @Success      200  {object}  RoundTripResponse  "Both translations and the similarity score"
+// @Failure      400  {object}  map[string]string  "Bad request (missing fields, undetectable or same language)"
+// @Failure      500  {object}  map[string]string  "Translation failed"
+// @Router       /translation/round-trip [post]
+func HandleTranslateRoundTrip(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Text       string `json:"text"`
+		TargetLang string `json:"target_language"`
+		SourceLang string `json:"source_language"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	text := strings.TrimSpace(req.Text)
+	if text == "" || req.TargetLang == "" {
+		http.Error(w, "Missing required fields", http.StatusBadRequest)
+		return
+	}
+
+	// The return leg needs an explicit language, so the source cannot be left to the provider
+	sourceLang := req.SourceLang
+	if sourceLang == "" {
+		sourceLang = translation.GetLanguageDetector().DetectLanguage(text)
+	}
+	if sourceLang == "" {
+		http.Error(w, "Could not detect the source language, please provide source_language", http.StatusBadRequest)
+		return
+	}
+	if translation.SameLanguage(sourceLang, req.TargetLang) {
+		http.Error(w, "Text is already in the target language", http.StatusBadRequest)
+		return
+	}
+
+	translated, err := translateMarkdownText(h, r, text, sourceLang, req.TargetLang)
+	if err != nil {
+		utils.ContextLog(r.Context(), "Round trip to %s failed: %v", req.TargetLang, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	backTranslated, err := translateMarkdownText(h, r, translated, req.TargetLang, sourceLang)
+	if err != nil {
+		utils.ContextLog(r.Context(), "Round trip back to %s failed: %v", sourceLang, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	similarity := utils.TokenSimilarity(utils.TitleTokens(text), utils.TitleTokens(backTranslated))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(RoundTripResponse{
+		SourceLanguage:     sourceLang,
+		TargetLanguage:     req.TargetLang,
+		TranslatedText:     translated,
+		BackTranslatedText: backTranslated,
+		Similarity:         math.Round(similarity*1000) / 1000,
+	})
+}
@@ -0,0 +1,104 @@
+package translation
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"MrRSS/internal/handlers/core"
+	"MrRSS/internal/models"
+	"MrRSS/internal/translation"
+	"MrRSS/internal/utils"
+)
+
+// maxSnippetRunes caps the length of a list preview snippet; longer text is cut off
+const maxSnippetRunes = 500
+
+// snippetCacheProvider is the cache namespace for snippet translations. It matches the
+// provider name of the Google translator so snippets share cached Google translations.
+const snippetCacheProvider = "google"
+
+// SnippetTranslationResponse is the result of translating a list preview snippet
+type SnippetTranslationResponse struct {
+	TranslatedText string `json:"translated_text"`
+	Skipped        bool   `json:"skipped"`
+	Reason         string `json:"reason,omitempty"`
+}
+
+// HandleTranslateSnippet translates a short list preview snippet on the cheapest path.
+// @Summary      Translate list preview snippet
+// @Description  Translate a short snippet for the article list. Always uses the translation cache and then Google Translate (free), never AI, so previews do not consume the AI budget whatever the provider setting. Snippets already in the target language are returned untranslated. With article_id, the feed's translation mode applies as it does to titles: "never" skips the snippet and "always" skips language detection. Text beyond 500 characters is cut off.
+// @Tags         translation
+// @Accept       json
+// @Produce      json
+// @Param        request  body      object  true  "Snippet translation request (text, target_language, optional source_language to skip detection, optional article_id)"
+// @Success      200  {object}  SnippetTranslationResponse  "Translated snippet"
+// @Failure      400  {object}  map[string]string  "Bad request (missing required fields)"
+// @Failure      500  {object}  map[string]string  "Translation failed"
+// @Router       /articles/translate-snippet [post]
+func HandleTranslateSnippet(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Text       string `json:"text"`
+		TargetLang string `json:"target_language"`
+		SourceLang string `json:"source_language"`
+		ArticleID  int64  `json:"article_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	text := strings.TrimSpace(req.Text)
+	if text == "" || req.TargetLang == "" {
+		http.Error(w, "Missing required fields", http.StatusBadRequest)
+		return
+	}
+	if runes := []rune(text); len(runes) > maxSnippetRunes {
+		text = string(runes[:maxSnippetRunes])
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var article *models.Article
+	if req.ArticleID > 0 {
+		if a, err := h.DB.GetArticleByID(req.ArticleID); err == nil {
+			article = a
+		}
+	}
+	translationMode, sourceLang, targetLang := titleTranslationScope(h, article, req.SourceLang, req.TargetLang)
+	if translationMode == models.TranslationModeNever {
+		json.NewEncoder(w).Encode(SnippetTranslationResponse{
+			TranslatedText: text,
+			Skipped:        true,
+			Reason:         "feed_translation_disabled",
+		})
+		return
+	}
+
+	if translationMode != models.TranslationModeAlways && !needsTranslation(text, sourceLang, targetLang, false) {
+		json.NewEncoder(w).Encode(SnippetTranslationResponse{
+			TranslatedText: text,
+			Skipped:        true,
+			Reason:         "already_target_language",
+		})
+		return
+	}
+
+	translator := translation.NewCachedTranslator(translation.NewGoogleFreeTranslatorWithDB(h.DB), h.DB, snippetCacheProvider)
+	translated, err := translator.TranslateFrom(text, sourceLang, targetLang)
+	if err != nil {
+		utils.ContextLog(r.Context(), "Error translating snippet: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(SnippetTranslationResponse{
+		TranslatedText: translated,
+		Skipped:        translated == text,
+	})
+}
@@ -0,0 +1,118 @@
+package translation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"MrRSS/internal/handlers/core"
+	"MrRSS/internal/translation"
+	"MrRSS/internal/utils"
+)
+
+// translationChunkEvent is a single server-sent event emitted while streaming a translation
+type translationChunkEvent struct {
+	Index          int    `json:"index"`
+	Total          int    `json:"total"`
+	Original       string `json:"original,omitempty"`
+	TranslatedText string `json:"translated_text,omitempty"`
+	HTML           string `json:"html,omitempty"`
+	Skipped        bool   `json:"skipped,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+// HandleTranslateTextStream translates text paragraph by paragraph and streams each chunk.
+// @Summary      Translate text (streaming)
+// @Description  Translate text paragraph by paragraph and stream each translated chunk as a server-sent event ("chunk" events followed by a final "done" event with the total and the content_language and content_direction of the translation)
+// @Tags         translation
+// @Accept       json
+// @Produce      text/event-stream
+// @Param        request  body      object  true  "Translation request (text, target_language, optional source_language, force, optional article_id to apply its category's target language)"
+// @Success      200  {string}  string  "Stream of chunk events (index, total, original, translated_text, html, skipped, error)"
+// @Failure      400  {object}  map[string]string  "Bad request (missing required fields)"
+// @Failure      500  {object}  map[string]string  "Streaming not supported"
+// @Router       /translate/text/stream [post]
+func HandleTranslateTextStream(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		Text       string `json:"text"`
+		TargetLang string `json:"target_language"`
+		SourceLang string `json:"source_language"`
+		Force      bool   `json:"force"`
+		ArticleID  int64  `json:"article_id"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.ContextLog(r.Context(), "Error decoding translation stream request: %v", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.Text == "" || req.TargetLang == "" {
+		http.Error(w, "Missing required fields", http.StatusBadRequest)
+		return
+	}
+	req.TargetLang = categoryTargetLang(h, req.ArticleID, req.TargetLang)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	paragraphs := translation.SplitIntoParagraphs(req.Text)
+
+	for i, paragraph := range paragraphs {
+		// Stop translating once the client has gone away
+		if r.Context().Err() != nil {
+			return
+		}
+
+		event := translationChunkEvent{Index: i, Total: len(paragraphs), Original: paragraph}
+
+		if !req.Force && !needsTranslation(paragraph, req.SourceLang, req.TargetLang, false) {
+			event.TranslatedText = paragraph
+			event.Skipped = true
+		} else {
+			// translateMarkdownText applies the AI rate limit before each chunk
+			translated, err := translateMarkdownText(h, r, paragraph, req.SourceLang, req.TargetLang)
+			if err != nil {
+				utils.ContextLog(r.Context(), "Error translating chunk %d/%d: %v", i+1, len(paragraphs), err)
+				event.TranslatedText = paragraph
+				event.Error = err.Error()
+			} else {
+				event.TranslatedText = translated
+				event.Skipped = translated == paragraph
+			}
+		}
+		event.HTML = utils.ConvertMarkdownToHTML(event.TranslatedText)
+
+		writeSSEEvent(w, "chunk", event)
+		flusher.Flush()
+	}
+
+	writeSSEEvent(w, "done", map[string]interface{}{
+		"total":             len(paragraphs),
+		"content_language":  req.TargetLang,
+		"content_direction": translation.LanguageDirection(req.TargetLang),
+	})
+	flusher.Flush()
+}
+
+// writeSSEEvent writes a named server-sent event with a JSON payload
+func writeSSEEvent(w http.ResponseWriter, event string, data interface{}) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+}
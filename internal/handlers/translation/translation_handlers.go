@@ -1,12 +1,15 @@
 package translation
 
 import (
+	"context"
 	"encoding/json"
-	"log"
 	"net/http"
+	"strconv"
 
+	"MrRSS/internal/ai"
 	"MrRSS/internal/aiusage"
 	"MrRSS/internal/handlers/core"
+	"MrRSS/internal/models"
 	"MrRSS/internal/translation"
 	"MrRSS/internal/utils"
 )
@@ -31,8 +34,8 @@ type TestCustomTranslationResponse struct {
 // @Tags         translation
 // @Accept       json
 // @Produce      json
-// @Param        request  body      object  true  "Translation request (article_id, title, target_language)"
-// @Success      200  {object}  map[string]interface{}  "Translation result (translated_title, limit_reached)"
+// @Param        request  body      object  true  "Translation request (article_id, title, target_language, optional source_language overriding the feed's and detection). The target language of the feed's category, if set, replaces target_language."
+// @Success      200  {object}  map[string]interface{}  "Translation result (translated_title, limit_reached, detected_language of the title, empty when detection fails)"
 // @Failure      400  {object}  map[string]string  "Bad request (missing required fields)"
 // @Failure      500  {object}  map[string]string  "Internal server error"
 // @Router       /translate/article [post]
@@ -46,6 +49,7 @@ func HandleTranslateArticle(h *core.Handler, w http.ResponseWriter, r *http.Requ
 		ArticleID  int64  `json:"article_id"`
 		Title      string `json:"title"`
 		TargetLang string `json:"target_language"`
+		SourceLang string `json:"source_language"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -58,6 +62,9 @@ func HandleTranslateArticle(h *core.Handler, w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// Reported so clients can show the source language, empty when detection fails
+	detectedLang := translation.GetLanguageDetector().DetectLanguage(req.Title)
+
 	// Step 0: Check if article already has a translation in database
 	// This prevents re-translating already translated content
 	article, err := h.DB.GetArticleByID(req.ArticleID)
@@ -65,18 +72,31 @@ func HandleTranslateArticle(h *core.Handler, w http.ResponseWriter, r *http.Requ
 		if article.TranslatedTitle != "" && article.TranslatedTitle != article.Title {
 			// Translation already exists and is different from original
 			json.NewEncoder(w).Encode(map[string]interface{}{
-				"translated_title": article.TranslatedTitle,
-				"limit_reached":    false,
-				"skipped":          true, // Indicate translation was skipped (from cache)
-				"cached":           true,
+				"detected_language": detectedLang,
+				"translated_title":  article.TranslatedTitle,
+				"limit_reached":     false,
+				"skipped":           true, // Indicate translation was skipped (from cache)
+				"cached":            true,
 			})
 			return
 		}
 	}
 
+	translationMode, sourceLang, targetLang := titleTranslationScope(h, article, req.SourceLang, req.TargetLang)
+	req.TargetLang = targetLang
+	if translationMode == models.TranslationModeNever {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"detected_language": detectedLang,
+			"translated_title":  req.Title,
+			"limit_reached":     false,
+			"skipped":           true,
+			"reason":            "feed_translation_disabled",
+		})
+		return
+	}
+
 	// Step 1: Pre-translation language detection to avoid unnecessary API calls
-	detector := translation.GetLanguageDetector()
-	shouldTranslate := detector.ShouldTranslate(req.Title, req.TargetLang)
+	shouldTranslate := translationMode == models.TranslationModeAlways || needsTranslation(req.Title, sourceLang, req.TargetLang, false)
 
 	if !shouldTranslate {
 		// Text is already in target language, return original title
@@ -85,10 +105,11 @@ func HandleTranslateArticle(h *core.Handler, w http.ResponseWriter, r *http.Requ
 			return
 		}
 		json.NewEncoder(w).Encode(map[string]interface{}{
-			"translated_title": req.Title,
-			"limit_reached":    false,
-			"skipped":          true, // Indicate translation was skipped
-			"reason":           "already_target_language",
+			"detected_language": detectedLang,
+			"translated_title":  req.Title,
+			"limit_reached":     false,
+			"skipped":           true, // Indicate translation was skipped
+			"reason":            "already_target_language",
 		})
 		return
 	}
@@ -100,6 +121,7 @@ func HandleTranslateArticle(h *core.Handler, w http.ResponseWriter, r *http.Requ
 
 	var translatedTitle string
 	var translateErr error
+	var usage ai.Usage
 	var limitReached = false
 
 	if isAIProvider {
@@ -107,29 +129,31 @@ func HandleTranslateArticle(h *core.Handler, w http.ResponseWriter, r *http.Requ
 		if h.AITracker.IsLimitReached() {
 			limitReached = true
 			// Fallback to Google Translate
-			googleTranslator := translation.NewGoogleFreeTranslatorWithDB(h.DB)
+			googleTranslator := translation.WithSourceLanguage(translation.NewGoogleFreeTranslatorWithDB(h.DB), sourceLang)
 			translatedTitle, translateErr = translation.TranslateMarkdownPreservingStructure(req.Title, googleTranslator, req.TargetLang)
 		} else {
-			// Apply rate limiting for AI requests
-			h.AITracker.WaitForRateLimit()
-
-			// Use markdown-preserving translation for better list structure
-			translatedTitle, translateErr = translation.TranslateMarkdownAIPrompt(req.Title, h.Translator, req.TargetLang)
+			// Use markdown-preserving translation for better list structure, in the shared
+			// AI pool which applies the rate limit
+			translateErr = h.AIPool.Do(r.Context(), func(ctx context.Context) error {
+				var err error
+				translatedTitle, usage, err = translation.TranslateMarkdownAIPrompt(ctx, req.Title, translation.WithSourceLanguage(h.Translator, sourceLang), req.TargetLang)
+				return err
+			})
 
 			// If AI fails, fallback to Google Translate
 			if translateErr != nil {
-				googleTranslator := translation.NewGoogleFreeTranslatorWithDB(h.DB)
+				googleTranslator := translation.WithSourceLanguage(translation.NewGoogleFreeTranslatorWithDB(h.DB), sourceLang)
 				translatedTitle, translateErr = translation.TranslateMarkdownPreservingStructure(req.Title, googleTranslator, req.TargetLang)
 			}
 
 			// Track AI usage only on success (whether AI or fallback)
 			if translateErr == nil {
-				h.AITracker.TrackTranslation(req.Title, translatedTitle)
+				h.AITracker.TrackTranslation(req.Title, translatedTitle, usage)
 			}
 		}
 	} else {
 		// Non-AI provider, use markdown-preserving translation
-		translatedTitle, translateErr = translation.TranslateMarkdownPreservingStructure(req.Title, h.Translator, req.TargetLang)
+		translatedTitle, translateErr = translation.TranslateMarkdownPreservingStructure(req.Title, translation.WithSourceLanguage(h.Translator, sourceLang), req.TargetLang)
 	}
 
 	if translateErr != nil {
@@ -146,10 +170,11 @@ func HandleTranslateArticle(h *core.Handler, w http.ResponseWriter, r *http.Requ
 			return
 		}
 		json.NewEncoder(w).Encode(map[string]interface{}{
-			"translated_title": translatedTitle,
-			"limit_reached":    limitReached,
-			"skipped":          true, // Indicate no actual translation was performed
-			"reason":           "translation_equals_original",
+			"detected_language": detectedLang,
+			"translated_title":  translatedTitle,
+			"limit_reached":     limitReached,
+			"skipped":           true, // Indicate no actual translation was performed
+			"reason":            "translation_equals_original",
 		})
 		return
 	}
@@ -161,12 +186,38 @@ func HandleTranslateArticle(h *core.Handler, w http.ResponseWriter, r *http.Requ
 	}
 
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"translated_title": translatedTitle,
-		"limit_reached":    limitReached,
-		"skipped":          false, // Translation was performed
+		"detected_language": detectedLang,
+		"translated_title":  translatedTitle,
+		"limit_reached":     limitReached,
+		"skipped":           false, // Translation was performed
 	})
 }
 
+// titleTranslationScope applies the article's feed settings to a title translation. It
+// returns the feed's translation mode ("never" skips translation, "always" bypasses language
+// detection), the source language (the given one, else the feed's override, else the
+// article's detected language) and the target language of the feed's category, if set.
+func titleTranslationScope(h *core.Handler, article *models.Article, sourceLang, targetLang string) (string, string, string) {
+	translationMode := models.TranslationModeInherit
+	if article == nil {
+		return translationMode, sourceLang, targetLang
+	}
+	if feed, feedErr := h.DB.GetFeedByID(article.FeedID); feedErr == nil {
+		translationMode = feed.TranslationMode
+		if sourceLang == "" {
+			sourceLang = feed.SourceLanguageOverride
+		}
+		if lang, _ := h.DB.ResolveCategoryTranslationTargetLang(feed.Category); lang != "" {
+			targetLang = lang
+		}
+	}
+	// Fall back to the language stored by the last detection pass
+	if sourceLang == "" {
+		sourceLang, _ = h.DB.GetArticleDetectedLanguage(article.ID)
+	}
+	return translationMode, sourceLang, targetLang
+}
+
 // HandleClearTranslations clears all translated titles from the database.
 // @Summary      Clear all translations
 // @Description  Clear all translated article titles from the database
@@ -183,7 +234,7 @@ func HandleClearTranslations(h *core.Handler, w http.ResponseWriter, r *http.Req
 	}
 
 	if err := h.DB.ClearAllTranslations(); err != nil {
-		log.Printf("Error clearing translations: %v", err)
+		utils.ContextLog(r.Context(), "Error clearing translations: %v", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -192,6 +243,45 @@ func HandleClearTranslations(h *core.Handler, w http.ResponseWriter, r *http.Req
 	json.NewEncoder(w).Encode(map[string]bool{"success": true})
 }
 
+// HandleClearTranslationsScoped clears the translations of one feed and/or one target language.
+// @Summary      Clear translations of a feed or language
+// @Description  Clear translated article titles and cached translations of a feed, of a target language, or both, so only that subset is re-translated. With neither given everything is cleared.
+// @Tags         translation
+// @Accept       json
+// @Produce      json
+// @Param        request  body      object  true  "Scope (optional feed_id, optional target_language)"
+// @Success      200  {object}  map[string]interface{}  "Success status and number of cleared article titles"
+// @Failure      400  {object}  map[string]string  "Bad request"
+// @Failure      500  {object}  map[string]string  "Internal server error"
+// @Router       /articles/clear-translations/scoped [post]
+func HandleClearTranslationsScoped(h *core.Handler, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		FeedID     int64  `json:"feed_id"`
+		TargetLang string `json:"target_language"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	cleared, err := h.DB.ClearTranslationsFor(req.FeedID, req.TargetLang)
+	if err != nil {
+		utils.ContextLog(r.Context(), "Error clearing translations: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"cleared": cleared,
+	})
+}
+
 // HandleTranslateText translates any text to the target language.
 // This is used for translating content, summaries, etc.
 // @Summary      Translate text
@@ -199,8 +289,8 @@ func HandleClearTranslations(h *core.Handler, w http.ResponseWriter, r *http.Req
 // @Tags         translation
 // @Accept       json
 // @Produce      json
-// @Param        request  body      object  true  "Translation request (text, target_language)"
-// @Success      200  {object}  map[string]string  "Translation result (translated_text, html)"
+// @Param        request  body      object  true  "Translation request (text, target_language, optional source_language to skip detection, optional article_id to apply its category's target language)"
+// @Success      200  {object}  map[string]interface{}  "Translation result (translated_text, html, content_language and content_direction of the result, cached when reused from the translation cache, detected_language of the text, empty when detection fails)"
 // @Failure      400  {object}  map[string]string  "Bad request (missing required fields)"
 // @Failure      500  {object}  map[string]string  "Internal server error"
 // @Router       /translate/text [post]
@@ -213,104 +303,243 @@ func HandleTranslateText(h *core.Handler, w http.ResponseWriter, r *http.Request
 	var req struct {
 		Text       string `json:"text"`
 		TargetLang string `json:"target_language"`
+		SourceLang string `json:"source_language"`
 		Force      bool   `json:"force"`
+		ArticleID  int64  `json:"article_id"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		log.Printf("Error decoding translation request: %v", err)
+		utils.ContextLog(r.Context(), "Error decoding translation request: %v", err)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	if req.Text == "" || req.TargetLang == "" {
-		log.Printf("Missing required fields in translation request")
+		utils.ContextLog(r.Context(), "Missing required fields in translation request")
 		http.Error(w, "Missing required fields", http.StatusBadRequest)
 		return
 	}
+	req.TargetLang = categoryTargetLang(h, req.ArticleID, req.TargetLang)
+	// Reported so clients can show the source language, empty when detection fails
+	detectedLang := translation.GetLanguageDetector().DetectLanguage(req.Text)
 
 	// Step 1: Pre-translation language detection to avoid unnecessary API calls
-	detector := translation.GetLanguageDetector()
 	// Use full-text analysis for better accuracy on longer content
 	// Skip language detection if force flag is set
-	shouldTranslate := req.Force || detector.ShouldTranslateFullText(req.Text, req.TargetLang)
+	shouldTranslate := req.Force || needsTranslation(req.Text, req.SourceLang, req.TargetLang, true)
 
 	if !shouldTranslate {
 		// Text is already in target language, return original text
 		htmlText := utils.ConvertMarkdownToHTML(req.Text)
 		json.NewEncoder(w).Encode(map[string]interface{}{
-			"translated_text": req.Text,
-			"html":            htmlText,
-			"skipped":         "true", // Indicate translation was skipped
-			"reason":          "already_target_language",
+			"detected_language": detectedLang,
+			"translated_text":   req.Text,
+			"html":              htmlText,
+			"skipped":           "true", // Indicate translation was skipped
+			"reason":            "already_target_language",
+			"content_language":  req.TargetLang,
+			"content_direction": translation.LanguageDirection(req.TargetLang),
 		})
 		return
 	}
 
-	// Step 2: Proceed with translation
+	// Step 2: Proceed with translation, reusing an earlier translation of the same text
+	translatedText, cached, err := translateMarkdownTextCached(h, r, articleFeedID(h, req.ArticleID), req.Text, req.SourceLang, req.TargetLang)
+	if err != nil {
+		utils.ContextLog(r.Context(), "Error translating text: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Step 3: Post-translation check - if translation equals original, it was already in target language
+	// This provides a safety net in case pre-translation detection was inaccurate
+	if translatedText == req.Text {
+		htmlText := utils.ConvertMarkdownToHTML(translatedText)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"detected_language": detectedLang,
+			"translated_text":   translatedText,
+			"html":              htmlText,
+			"skipped":           "true", // Indicate no actual translation was performed
+			"cached":            cached,
+			"content_language":  req.TargetLang,
+			"content_direction": translation.LanguageDirection(req.TargetLang),
+		})
+		return
+	}
+
+	// Convert translated markdown to HTML
+	htmlText := utils.ConvertMarkdownToHTML(translatedText)
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"detected_language": detectedLang,
+		"translated_text":   translatedText,
+		"html":              htmlText,
+		"skipped":           "false", // Translation was performed
+		"cached":            cached,  // Translation was reused from the cache
+		"content_language":  req.TargetLang,
+		"content_direction": translation.LanguageDirection(req.TargetLang),
+	})
+}
+
+// needsTranslation reports whether text has to be translated. A known source language
+// replaces language detection, which is unreliable on short or mixed-script text.
+func needsTranslation(text, sourceLang, targetLang string, fullText bool) bool {
+	if sourceLang != "" {
+		return !translation.SameLanguage(sourceLang, targetLang)
+	}
+	detector := translation.GetLanguageDetector()
+	if fullText {
+		return detector.ShouldTranslateFullText(text, targetLang)
+	}
+	return detector.ShouldTranslate(text, targetLang)
+}
+
+// categoryTargetLang returns the target language configured for the category of the
+// article's feed, or fallback when the article is unknown or its category has no override
+func categoryTargetLang(h *core.Handler, articleID int64, fallback string) string {
+	if articleID <= 0 {
+		return fallback
+	}
+	article, err := h.DB.GetArticleByID(articleID)
+	if err != nil || article == nil {
+		return fallback
+	}
+	feed, err := h.DB.GetFeedByID(article.FeedID)
+	if err != nil {
+		return fallback
+	}
+	if lang, _ := h.DB.ResolveCategoryTranslationTargetLang(feed.Category); lang != "" {
+		return lang
+	}
+	return fallback
+}
+
+// articleFeedID returns the feed of the article, or 0 when the article is unknown
+func articleFeedID(h *core.Handler, articleID int64) int64 {
+	if articleID <= 0 {
+		return 0
+	}
+	article, err := h.DB.GetArticleByID(articleID)
+	if err != nil || article == nil {
+		return 0
+	}
+	return article.FeedID
+}
+
+// markdownCachePrefix namespaces cached full-text translations in the translation cache,
+// apart from the single strings cached by translation.CachedTranslator
+const markdownCachePrefix = "markdown:"
+
+// translateMarkdownText translates markdown text with the configured provider,
+// falling back to Google Translate when AI is unavailable or fails.
+// An empty sourceLang lets the provider detect the source language.
+func translateMarkdownText(h *core.Handler, r *http.Request, text, sourceLang, targetLang string) (string, error) {
+	translatedText, _, err := translateMarkdownTextWithProvider(h, r, text, sourceLang, targetLang)
+	return translatedText, err
+}
+
+// translateMarkdownTextCached is translateMarkdownText backed by the translation cache,
+// keyed by the source text, source and target language and the configured provider. It
+// reports whether the result came from the cache. Entries are recorded under feedID (0 when
+// the text is not from a feed) so clearing that feed's translations removes them. Fallback
+// translations are not cached so the configured provider is tried again next time.
+func translateMarkdownTextCached(h *core.Handler, r *http.Request, feedID int64, text, sourceLang, targetLang string) (string, bool, error) {
+	provider := configuredTranslationProvider(h)
+	key := translation.CacheKey(text, sourceLang)
+	if cached, found, err := h.DB.GetCachedTranslation(key, targetLang, markdownCachePrefix+provider); err == nil && found {
+		return cached, true, nil
+	}
+
+	translatedText, usedProvider, err := translateMarkdownTextWithProvider(h, r, text, sourceLang, targetLang)
+	if err != nil {
+		return "", false, err
+	}
+	if usedProvider == provider {
+		if err := h.DB.SetCachedFeedTranslation(feedID, key, text, targetLang, translatedText, markdownCachePrefix+provider); err != nil {
+			utils.ContextLog(r.Context(), "Failed to cache translation: %v", err)
+		}
+	}
+	return translatedText, false, nil
+}
+
+// configuredTranslationProvider returns the translation_provider setting, which defaults
+// to Google Translate
+func configuredTranslationProvider(h *core.Handler) string {
+	if provider, _ := h.DB.GetSetting("translation_provider"); provider != "" {
+		return provider
+	}
+	return "google"
+}
+
+// translateMarkdownTextWithProvider is translateMarkdownText that also returns the
+// provider which produced the translation, "google" when AI fell back to it.
+func translateMarkdownTextWithProvider(h *core.Handler, r *http.Request, text, sourceLang, targetLang string) (string, string, error) {
 	// Check if we should use AI translation or fallback to Google
-	provider, _ := h.DB.GetSetting("translation_provider")
+	provider := configuredTranslationProvider(h)
 	isAIProvider := provider == "ai"
 
 	var translatedText string
+	var usage ai.Usage
 	var err error
 
 	if isAIProvider {
 		// Check if AI usage limit is reached
 		if h.AITracker.IsLimitReached() {
-			log.Printf("AI usage limit reached, falling back to Google Translate")
+			utils.ContextLog(r.Context(), "AI usage limit reached, falling back to Google Translate")
 			// Fallback to Google Translate
-			googleTranslator := translation.NewGoogleFreeTranslatorWithDB(h.DB)
-			translatedText, err = translation.TranslateMarkdownPreservingStructure(req.Text, googleTranslator, req.TargetLang)
+			provider = "google"
+			googleTranslator := translation.WithSourceLanguage(translation.NewGoogleFreeTranslatorWithDB(h.DB), sourceLang)
+			translatedText, err = translation.TranslateMarkdownConcurrently(text, googleTranslator, targetLang, translationConcurrency(h))
 		} else {
-			// Apply rate limiting for AI requests
-			h.AITracker.WaitForRateLimit()
-
-			// Use markdown-preserving translation for better list structure
-			translatedText, err = translation.TranslateMarkdownAIPrompt(req.Text, h.Translator, req.TargetLang)
+			// Use markdown-preserving translation for better list structure, in the shared
+			// AI pool which applies the rate limit
+			err = h.AIPool.Do(r.Context(), func(ctx context.Context) error {
+				var err error
+				translatedText, usage, err = translation.TranslateMarkdownAIPromptChunked(ctx, text, translation.WithSourceLanguage(h.Translator, sourceLang), targetLang, aiTranslationChunkChars(h))
+				return err
+			})
 
 			// If AI fails, fallback to Google Translate
 			if err != nil {
-				log.Printf("AI translation failed, falling back to Google Translate: %v", err)
-				googleTranslator := translation.NewGoogleFreeTranslatorWithDB(h.DB)
-				translatedText, err = translation.TranslateMarkdownPreservingStructure(req.Text, googleTranslator, req.TargetLang)
+				utils.ContextLog(r.Context(), "AI translation failed, falling back to Google Translate: %v", err)
+				provider = "google"
+				googleTranslator := translation.WithSourceLanguage(translation.NewGoogleFreeTranslatorWithDB(h.DB), sourceLang)
+				translatedText, err = translation.TranslateMarkdownConcurrently(text, googleTranslator, targetLang, translationConcurrency(h))
 			}
 
 			// Track AI usage only on success (whether AI or fallback)
 			if err == nil {
-				h.AITracker.TrackTranslation(req.Text, translatedText)
+				h.AITracker.TrackTranslation(text, translatedText, usage)
 			}
 		}
 	} else {
-		// Non-AI provider, use markdown-preserving translation
-		translatedText, err = translation.TranslateMarkdownPreservingStructure(req.Text, h.Translator, req.TargetLang)
+		// Non-AI provider, use markdown-preserving translation with paragraphs in parallel
+		translatedText, err = translation.TranslateMarkdownConcurrently(text, translation.WithSourceLanguage(h.Translator, sourceLang), targetLang, translationConcurrency(h))
 	}
 
-	if err != nil {
-		log.Printf("Error translating text: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	return translatedText, provider, err
+}
 
-	// Step 3: Post-translation check - if translation equals original, it was already in target language
-	// This provides a safety net in case pre-translation detection was inaccurate
-	if translatedText == req.Text {
-		htmlText := utils.ConvertMarkdownToHTML(translatedText)
-		json.NewEncoder(w).Encode(map[string]string{
-			"translated_text": translatedText,
-			"html":            htmlText,
-			"skipped":         "true", // Indicate no actual translation was performed
-		})
-		return
+// aiTranslationChunkChars returns the configured chunk size for long AI translations;
+// zero disables chunking
+func aiTranslationChunkChars(h *core.Handler) int {
+	if s, err := h.DB.GetSetting("ai_translation_chunk_chars"); err == nil {
+		if n, err := strconv.Atoi(s); err == nil && n >= 0 {
+			return n
+		}
 	}
+	return translation.DefaultAITranslationChunkChars
+}
 
-	// Convert translated markdown to HTML
-	htmlText := utils.ConvertMarkdownToHTML(translatedText)
-
-	json.NewEncoder(w).Encode(map[string]string{
-		"translated_text": translatedText,
-		"html":            htmlText,
-		"skipped":         "false", // Translation was performed
-	})
+// translationConcurrency returns how many paragraphs of long content non-AI providers
+// translate in parallel
+func translationConcurrency(h *core.Handler) int {
+	if s, err := h.DB.GetSetting("translation_concurrency"); err == nil {
+		if n, err := strconv.Atoi(s); err == nil && n > 0 {
+			return n
+		}
+	}
+	return translation.DefaultTranslationConcurrency
 }
 
 // HandleResetAIUsage resets the AI usage counter.
@@ -329,7 +558,7 @@ func HandleResetAIUsage(h *core.Handler, w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := h.AITracker.ResetUsage(); err != nil {
-		log.Printf("Error resetting AI usage: %v", err)
+		utils.ContextLog(r.Context(), "Error resetting AI usage: %v", err)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -2,13 +2,19 @@ package translation
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"MrRSS/internal/database"
 	corepkg "MrRSS/internal/handlers/core"
+	"MrRSS/internal/models"
 	transpkg "MrRSS/internal/translation"
 )
 
@@ -41,7 +47,7 @@ func TestHandleTranslateText_Success(t *testing.T) {
 		t.Fatalf("expected 200 got %d", rr.Code)
 	}
 
-	var resp map[string]string
+	var resp map[string]interface{}
 	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
 		t.Fatalf("decode failed: %v", err)
 	}
@@ -50,6 +56,72 @@ func TestHandleTranslateText_Success(t *testing.T) {
 	if resp["translated_text"] != expected {
 		t.Fatalf("unexpected translation: got %v, want %v", resp["translated_text"], expected)
 	}
+	if resp["detected_language"] != "en" {
+		t.Errorf("expected detected_language en, got %v", resp["detected_language"])
+	}
+}
+
+func TestHandleTranslateText_DetectedLanguageUnknown(t *testing.T) {
+	db := setupDB(t)
+	h := &corepkg.Handler{DB: db, Translator: transpkg.NewMockTranslator()}
+
+	b, _ := json.Marshal(map[string]string{"text": "OK", "target_language": "fr"})
+	rr := httptest.NewRecorder()
+	HandleTranslateText(h, rr, httptest.NewRequest(http.MethodPost, "/translate/text", bytes.NewReader(b)))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 got %d", rr.Code)
+	}
+
+	var resp map[string]interface{}
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if lang, ok := resp["detected_language"]; !ok || lang != "" {
+		t.Errorf("expected an empty detected_language when detection fails, got %v (present: %v)", lang, ok)
+	}
+}
+
+// countingTranslator counts the requests that reach the provider
+type countingTranslator struct {
+	calls int
+}
+
+func (c *countingTranslator) Translate(text, targetLang string) (string, error) {
+	c.calls++
+	return "[" + strings.ToUpper(targetLang) + "] " + text, nil
+}
+
+func TestHandleTranslateText_Cached(t *testing.T) {
+	db := setupDB(t)
+	translator := &countingTranslator{}
+	h := &corepkg.Handler{DB: db, Translator: translator}
+
+	translate := func(target string) map[string]interface{} {
+		t.Helper()
+		b, _ := json.Marshal(map[string]string{"text": "This is a test article in English", "target_language": target})
+		rr := httptest.NewRecorder()
+		HandleTranslateText(h, rr, httptest.NewRequest(http.MethodPost, "/translate/text", bytes.NewReader(b)))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200 got %d", rr.Code)
+		}
+		var resp map[string]interface{}
+		if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode failed: %v", err)
+		}
+		return resp
+	}
+
+	if resp := translate("fr"); resp["cached"] != false || translator.calls != 1 {
+		t.Fatalf("expected a fresh translation, got %v after %d calls", resp, translator.calls)
+	}
+	resp := translate("fr")
+	if resp["cached"] != true || resp["translated_text"] != "[FR] This is a test article in English" || translator.calls != 1 {
+		t.Fatalf("expected the cached translation, got %v after %d calls", resp, translator.calls)
+	}
+	// Another target language is a separate entry
+	if resp := translate("de"); resp["cached"] != false || translator.calls != 2 {
+		t.Fatalf("expected a fresh translation, got %v after %d calls", resp, translator.calls)
+	}
 }
 
 func TestHandleTranslateArticle_SuccessAndDBUpdate(t *testing.T) {
@@ -87,6 +159,9 @@ func TestHandleTranslateArticle_SuccessAndDBUpdate(t *testing.T) {
 	if resp["translated_title"] != expected {
 		t.Fatalf("unexpected translation: got %v, want %v", resp["translated_title"], expected)
 	}
+	if resp["detected_language"] != "en" {
+		t.Errorf("expected detected_language en, got %v", resp["detected_language"])
+	}
 
 	// verify in DB
 	var stored string
@@ -128,3 +203,361 @@ func TestHandleClearTranslations(t *testing.T) {
 		t.Fatalf("expected 0 translations remaining, got %d", count)
 	}
 }
+
+func TestHandleTranslateTextStream(t *testing.T) {
+	db := setupDB(t)
+	h := &corepkg.Handler{DB: db, Translator: transpkg.NewMockTranslator()}
+
+	body := map[string]interface{}{
+		"text":            "This is the first paragraph in English\n\nThis is the second paragraph in English",
+		"target_language": "fr",
+		"force":           true,
+	}
+	b, _ := json.Marshal(body)
+
+	req := httptest.NewRequest(http.MethodPost, "/translate/text/stream", bytes.NewReader(b))
+	rr := httptest.NewRecorder()
+
+	HandleTranslateTextStream(h, rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 got %d", rr.Code)
+	}
+	if ct := rr.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("unexpected content type: %s", ct)
+	}
+
+	out := rr.Body.String()
+	if got := strings.Count(out, "event: chunk\n"); got != 2 {
+		t.Fatalf("expected 2 chunk events, got %d: %s", got, out)
+	}
+	if !strings.Contains(out, "[FR] This is the second paragraph in English") {
+		t.Fatalf("missing translated chunk: %s", out)
+	}
+	if !strings.HasSuffix(out, "event: done\ndata: {\"content_direction\":\"ltr\",\"content_language\":\"fr\",\"total\":2}\n\n") {
+		t.Fatalf("missing done event: %s", out)
+	}
+}
+
+func TestHandleTranslateArticle_FeedTranslationModeNever(t *testing.T) {
+	db := setupDB(t)
+
+	res, err := db.Exec("INSERT INTO feeds (title, url, description) VALUES ('f', 'http://example.com/feed', '')")
+	if err != nil {
+		t.Fatalf("insert feed failed: %v", err)
+	}
+	feedID, _ := res.LastInsertId()
+	if err := db.UpdateFeedTranslationMode(feedID, "never"); err != nil {
+		t.Fatalf("set translation mode failed: %v", err)
+	}
+
+	res, err = db.Exec("INSERT INTO articles (feed_id, title, url, published_at) VALUES (?, 't', 'u', datetime('now'))", feedID)
+	if err != nil {
+		t.Fatalf("insert article failed: %v", err)
+	}
+	id, _ := res.LastInsertId()
+
+	h := &corepkg.Handler{DB: db, Translator: transpkg.NewMockTranslator()}
+
+	title := "This is an article title in English"
+	body := map[string]interface{}{"article_id": id, "title": title, "target_language": "es"}
+	b, _ := json.Marshal(body)
+
+	req := httptest.NewRequest(http.MethodPost, "/translate/article", bytes.NewReader(b))
+	rr := httptest.NewRecorder()
+
+	HandleTranslateArticle(h, rr, req)
+
+	var resp map[string]interface{}
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if resp["translated_title"] != title || resp["reason"] != "feed_translation_disabled" {
+		t.Fatalf("expected translation to be skipped, got %v", resp)
+	}
+}
+
+func TestHandleTranslateArticle_FeedSourceLanguageOverride(t *testing.T) {
+	db := setupDB(t)
+
+	res, err := db.Exec("INSERT INTO feeds (title, url, description) VALUES ('f', 'http://example.com/feed', '')")
+	if err != nil {
+		t.Fatalf("insert feed failed: %v", err)
+	}
+	feedID, _ := res.LastInsertId()
+	// Detection would call this English title already in the target language
+	if err := db.UpdateFeedSourceLanguage(feedID, "fr"); err != nil {
+		t.Fatalf("set source language failed: %v", err)
+	}
+
+	res, err = db.Exec("INSERT INTO articles (feed_id, title, url, published_at) VALUES (?, 't', 'u', datetime('now'))", feedID)
+	if err != nil {
+		t.Fatalf("insert article failed: %v", err)
+	}
+	id, _ := res.LastInsertId()
+
+	h := &corepkg.Handler{DB: db, Translator: transpkg.NewMockTranslator()}
+
+	title := "This is an article title in English"
+	body := map[string]interface{}{"article_id": id, "title": title, "target_language": "en"}
+	b, _ := json.Marshal(body)
+
+	req := httptest.NewRequest(http.MethodPost, "/translate/article", bytes.NewReader(b))
+	rr := httptest.NewRecorder()
+
+	HandleTranslateArticle(h, rr, req)
+
+	var resp map[string]interface{}
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if resp["skipped"] != false || resp["translated_title"] != "[EN] "+title {
+		t.Fatalf("expected the feed's source language to bypass detection, got %v", resp)
+	}
+}
+
+func TestHandleTranslateSnippet(t *testing.T) {
+	db := setupDB(t)
+	// The AI provider must never be used for snippets; a nil AITracker would panic if it were
+	if err := db.SetSetting("translation_provider", "ai"); err != nil {
+		t.Fatalf("SetSetting: %v", err)
+	}
+	h := &corepkg.Handler{DB: db, Translator: transpkg.NewMockTranslator()}
+
+	post := func(body map[string]interface{}) map[string]interface{} {
+		t.Helper()
+		b, _ := json.Marshal(body)
+		rr := httptest.NewRecorder()
+		HandleTranslateSnippet(h, rr, httptest.NewRequest(http.MethodPost, "/articles/translate-snippet", bytes.NewReader(b)))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200 got %d: %s", rr.Code, rr.Body.String())
+		}
+		var resp map[string]interface{}
+		if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode failed: %v", err)
+		}
+		return resp
+	}
+
+	// Same language is skipped without a translation call
+	resp := post(map[string]interface{}{"text": "Hello world", "target_language": "en", "source_language": "en"})
+	if resp["skipped"] != true || resp["translated_text"] != "Hello world" {
+		t.Errorf("expected same-language snippet to be skipped, got %v", resp)
+	}
+
+	// A cached Google translation is served without going to the network
+	text := "A short preview of the article"
+	sum := sha256.Sum256([]byte(text))
+	if err := db.SetCachedTranslation(hex.EncodeToString(sum[:]), text, "fr", "Un court aperçu de l'article", "google"); err != nil {
+		t.Fatalf("SetCachedTranslation: %v", err)
+	}
+	resp = post(map[string]interface{}{"text": text, "target_language": "fr"})
+	if resp["translated_text"] != "Un court aperçu de l'article" || resp["skipped"] != false {
+		t.Errorf("expected cached translation, got %v", resp)
+	}
+
+	// Snippets of a feed that is never translated are skipped like its titles
+	feedID, err := db.AddFeed(&models.Feed{Title: "Local", URL: "http://example.com/local"})
+	if err != nil {
+		t.Fatalf("AddFeed: %v", err)
+	}
+	if err := db.UpdateFeedTranslationMode(feedID, models.TranslationModeNever); err != nil {
+		t.Fatalf("UpdateFeedTranslationMode: %v", err)
+	}
+	if err := db.SaveArticle(&models.Article{FeedID: feedID, Title: "Local news", URL: "http://example.com/local/1", PublishedAt: time.Now()}); err != nil {
+		t.Fatalf("SaveArticle: %v", err)
+	}
+	var articleID int64
+	if err := db.QueryRow("SELECT id FROM articles WHERE feed_id = ?", feedID).Scan(&articleID); err != nil {
+		t.Fatalf("query article id: %v", err)
+	}
+	resp = post(map[string]interface{}{"text": text, "target_language": "fr", "article_id": articleID})
+	if resp["skipped"] != true || resp["reason"] != "feed_translation_disabled" || resp["translated_text"] != text {
+		t.Errorf("expected the feed's never mode to skip the snippet, got %v", resp)
+	}
+}
+
+func TestHandleRedetectLanguages(t *testing.T) {
+	db := setupDB(t)
+	for _, title := range []string{
+		"This is an article title written in plain English",
+		"Ceci est un titre d'article écrit en français courant",
+	} {
+		if _, err := db.Exec("INSERT INTO articles (feed_id, title, url, published_at) VALUES (1, ?, ?, datetime('now'))", title, title); err != nil {
+			t.Fatalf("insert article failed: %v", err)
+		}
+	}
+	// Already detected articles are skipped unless all=true
+	if _, err := db.Exec("UPDATE articles SET detected_language = 'de' WHERE id = 2"); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+
+	h := &corepkg.Handler{DB: db}
+	waitDone := func() corepkg.LanguageRedetectState {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			rr := httptest.NewRecorder()
+			HandleRedetectLanguagesStatus(h, rr, httptest.NewRequest(http.MethodGet, "/articles/redetect-languages/status", nil))
+			var state corepkg.LanguageRedetectState
+			if err := json.NewDecoder(rr.Body).Decode(&state); err != nil {
+				t.Fatalf("decode failed: %v", err)
+			}
+			if !state.IsRunning {
+				return state
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatal("re-detection did not finish")
+		return corepkg.LanguageRedetectState{}
+	}
+
+	rr := httptest.NewRecorder()
+	HandleRedetectLanguages(h, rr, httptest.NewRequest(http.MethodPost, "/articles/redetect-languages", nil))
+	if rr.Code != http.StatusAccepted {
+		t.Fatalf("expected 202 got %d", rr.Code)
+	}
+	if state := waitDone(); state.Total != 1 || state.Processed != 1 || state.Updated != 1 {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+	if lang, _ := db.GetArticleDetectedLanguage(1); lang != "en" {
+		t.Fatalf("expected en, got %q", lang)
+	}
+	if lang, _ := db.GetArticleDetectedLanguage(2); lang != "de" {
+		t.Fatalf("expected the detected article to be left alone, got %q", lang)
+	}
+
+	rr = httptest.NewRecorder()
+	HandleRedetectLanguages(h, rr, httptest.NewRequest(http.MethodPost, "/articles/redetect-languages?all=true", nil))
+	if state := waitDone(); state.Total != 2 || state.Updated != 1 {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+	if lang, _ := db.GetArticleDetectedLanguage(2); lang != "fr" {
+		t.Fatalf("expected fr after a full pass, got %q", lang)
+	}
+}
+
+func TestHandleTranslateRoundTrip(t *testing.T) {
+	db := setupDB(t)
+	h := &corepkg.Handler{DB: db, Translator: transpkg.NewMockTranslator()}
+
+	post := func(body map[string]string) *httptest.ResponseRecorder {
+		t.Helper()
+		b, _ := json.Marshal(body)
+		rr := httptest.NewRecorder()
+		HandleTranslateRoundTrip(h, rr, httptest.NewRequest(http.MethodPost, "/translation/round-trip", bytes.NewReader(b)))
+		return rr
+	}
+
+	rr := post(map[string]string{"text": "The quick brown fox jumps over the lazy dog while the children are playing in the garden", "target_language": "fr"})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp RoundTripResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if resp.SourceLanguage != "en" {
+		t.Errorf("expected detected source language en, got %q", resp.SourceLanguage)
+	}
+	if resp.TranslatedText != "[FR] The quick brown fox jumps over the lazy dog while the children are playing in the garden" ||
+		resp.BackTranslatedText != "[EN] [FR] The quick brown fox jumps over the lazy dog while the children are playing in the garden" {
+		t.Errorf("unexpected round trip: %+v", resp)
+	}
+	// The mock only adds language markers, so most words survive
+	if resp.Similarity < 0.7 || resp.Similarity >= 1 {
+		t.Errorf("unexpected similarity %v", resp.Similarity)
+	}
+
+	if rr := post(map[string]string{"text": "Hello world", "target_language": "en", "source_language": "en"}); rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for same source and target, got %d", rr.Code)
+	}
+}
+
+func TestHandleClearTranslationsScoped(t *testing.T) {
+	db := setupDB(t)
+	h := &corepkg.Handler{DB: db, Translator: transpkg.NewMockTranslator()}
+
+	for _, id := range []int64{1, 2} {
+		if _, err := db.Exec("INSERT INTO feeds (id, title, url) VALUES (?, ?, ?)", id, fmt.Sprintf("Feed %d", id), fmt.Sprintf("https://example.com/%d", id)); err != nil {
+			t.Fatalf("insert feed failed: %v", err)
+		}
+	}
+	for _, a := range []struct {
+		feedID            int64
+		title, translated string
+		lang              string
+	}{
+		{1, "one", "un", "fr"},
+		{1, "two", "zwei", "de"},
+		{2, "three", "trois", "fr"},
+	} {
+		if _, err := db.Exec("INSERT INTO articles (feed_id, title, url, translated_title, published_at) VALUES (?, ?, ?, ?, datetime('now'))",
+			a.feedID, a.title, "u/"+a.title, a.translated); err != nil {
+			t.Fatalf("insert article failed: %v", err)
+		}
+		if err := db.SetCachedTranslation(transpkg.CacheKey(a.title, ""), a.title, a.lang, a.translated, "google"); err != nil {
+			t.Fatalf("cache translation failed: %v", err)
+		}
+	}
+
+	clear := func(body string) float64 {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		HandleClearTranslationsScoped(h, rr, httptest.NewRequest(http.MethodPost, "/api/articles/clear-translations/scoped", strings.NewReader(body)))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200 got %d", rr.Code)
+		}
+		var resp map[string]interface{}
+		if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+			t.Fatalf("decode failed: %v", err)
+		}
+		return resp["cleared"].(float64)
+	}
+	translated := func(title string) string {
+		t.Helper()
+		var s string
+		if err := db.QueryRow("SELECT translated_title FROM articles WHERE title = ?", title).Scan(&s); err != nil {
+			t.Fatalf("query failed: %v", err)
+		}
+		return s
+	}
+
+	// One feed and one language: only "one" matches both
+	if n := clear(`{"feed_id": 1, "target_language": "fr"}`); n != 1 {
+		t.Fatalf("expected 1 cleared, got %v", n)
+	}
+	if translated("one") != "" || translated("two") != "zwei" || translated("three") != "trois" {
+		t.Fatal("expected only the French translation of feed 1 to be cleared")
+	}
+	if _, found, _ := db.GetCachedTranslation(transpkg.CacheKey("one", ""), "fr", "google"); found {
+		t.Error("expected the cached translation to be removed too")
+	}
+	if _, found, _ := db.GetCachedTranslation(transpkg.CacheKey("three", ""), "fr", "google"); !found {
+		t.Error("expected other feeds' cached translations to be kept")
+	}
+
+	// Full-text translations of a feed's articles go with it
+	var articleID int64
+	if err := db.QueryRow("SELECT id FROM articles WHERE title = 'three'").Scan(&articleID); err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	body := "The full text of the third article"
+	b, _ := json.Marshal(map[string]interface{}{"text": body, "target_language": "fr", "force": true, "article_id": articleID})
+	rr := httptest.NewRecorder()
+	HandleTranslateText(h, rr, httptest.NewRequest(http.MethodPost, "/translate/text", bytes.NewReader(b)))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 got %d", rr.Code)
+	}
+	if _, found, _ := db.GetCachedTranslation(transpkg.CacheKey(body, ""), "fr", markdownCachePrefix+"google"); !found {
+		t.Fatal("expected the full-text translation to be cached")
+	}
+
+	// A whole feed
+	if n := clear(`{"feed_id": 2}`); n != 1 || translated("three") != "" || translated("two") != "zwei" {
+		t.Fatalf("expected only feed 2 to be cleared, got %v", n)
+	}
+	if _, found, _ := db.GetCachedTranslation(transpkg.CacheKey(body, ""), "fr", markdownCachePrefix+"google"); found {
+		t.Error("expected the feed's full-text translation to be removed")
+	}
+}
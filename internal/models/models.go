@@ -2,6 +2,21 @@ package models
 
 import "time"
 
+// Feed translation modes
+const (
+	TranslationModeInherit = "inherit" // Use the global setting plus language detection
+	TranslationModeAlways  = "always"  // Always translate, even when global translation is off
+	TranslationModeNever   = "never"   // Never translate this feed
+)
+
+// Feed content extraction strategies, tried in the order a feed lists them
+const (
+	ContentStrategyFeed        = "feed"        // Content carried by the feed item
+	ContentStrategySelector    = "selector"    // CSS selector applied to the article page
+	ContentStrategyReadability = "readability" // Readability extraction of the article page
+	ContentStrategyScript      = "script"      // Per-feed script that prints the article's HTML
+)
+
 type Feed struct {
 	ID                 int64     `json:"id"`
 	Title              string    `json:"title"`
@@ -34,6 +49,25 @@ type Feed struct {
 	XPathItemUid        string `json:"xpath_item_uid"`         // XPath to extract item unique ID
 	ArticleViewMode     string `json:"article_view_mode"`      // Article view mode override ('global', 'webpage', 'rendered')
 	AutoExpandContent   string `json:"auto_expand_content"`    // Auto expand content mode ('global', 'enabled', 'disabled')
+	TranslationMode     string `json:"translation_mode"`       // Translation override ('inherit', 'always', 'never')
+	// Source language used for translations instead of detection; empty means auto-detect
+	SourceLanguageOverride string `json:"source_language_override,omitempty"`
+	// Articles older than this many days are hidden from the feed's list view; 0 means no limit
+	MaxArticleAgeDays int `json:"max_article_age_days"`
+	// New articles from this feed are added to the read-later queue
+	ReadLaterByDefault bool `json:"read_later_by_default"`
+	// Full content of new articles is fetched and stored at refresh time for offline reading
+	EagerContent bool `json:"eager_content"`
+	// Set automatically when the feed keeps producing new identifiers for articles already stored
+	GUIDUnstable bool `json:"guid_unstable"`
+	// Articles are deduplicated by URL and title even if the feed looks stable
+	ForceURLDedup bool `json:"force_url_dedup"`
+	// Ordered content extraction strategies tried until one returns content; empty means feed content only
+	ContentStrategy []string `json:"content_strategy,omitempty"`
+	// CSS selector for the selector strategy
+	ContentSelector string `json:"content_selector,omitempty"`
+	// Script (relative to the scripts directory) for the script strategy; gets the article URL as its argument
+	ContentScriptPath string `json:"content_script_path,omitempty"`
 	// Email/Newsletter support
 	EmailAddress    string `json:"email_address,omitempty"`     // Email address for newsletter subscriptions
 	EmailIMAPServer string `json:"email_imap_server,omitempty"` // IMAP server address
@@ -45,6 +79,8 @@ type Feed struct {
 	// FreshRSS integration
 	IsFreshRSSSource bool   `json:"is_freshrss_source"` // Whether this feed is from FreshRSS sync
 	FreshRSSStreamID string `json:"freshrss_stream_id"` // FreshRSS stream ID (e.g., "feed/http://...")
+	// Short name shown in front of the feed's articles in the list view; empty uses the title's initials
+	ListPrefix string `json:"list_prefix,omitempty"`
 	// Statistics
 	LatestArticleTime *time.Time `json:"latest_article_time,omitempty"` // Latest article publish time
 	ArticlesPerMonth  float64    `json:"articles_per_month,omitempty"`  // Average articles per month (last 90 days / 3)
@@ -52,23 +88,36 @@ type Feed struct {
 }
 
 type Article struct {
-	ID                    int64     `json:"id"`
-	FeedID                int64     `json:"feed_id"`
-	Title                 string    `json:"title"`
-	URL                   string    `json:"url"`
-	ImageURL              string    `json:"image_url"`
-	AudioURL              string    `json:"audio_url"`
-	VideoURL              string    `json:"video_url"` // YouTube video URL for embedded player
-	PublishedAt           time.Time `json:"published_at"`
-	HasValidPublishedTime bool      `json:"-"` // Internal field, not serialized
-	IsRead                bool      `json:"is_read"`
-	IsFavorite            bool      `json:"is_favorite"`
-	IsHidden              bool      `json:"is_hidden"`
-	IsReadLater           bool      `json:"is_read_later"`
-	FeedTitle             string    `json:"feed_title,omitempty"` // Joined field
-	Author                string    `json:"author,omitempty"`     // Article author
-	TranslatedTitle       string    `json:"translated_title"`
-	Summary               string    `json:"summary"`          // Cached AI-generated summary
-	UniqueID              string    `json:"unique_id"`        // Unique identifier for deduplication (title+feed_id+published_date)
-	FreshRSSItemID        string    `json:"freshrss_item_id"` // FreshRSS/Google Reader item ID for API operations
+	ID                    int64      `json:"id"`
+	FeedID                int64      `json:"feed_id"`
+	Title                 string     `json:"title"`
+	URL                   string     `json:"url"`
+	ImageURL              string     `json:"image_url"`
+	Images                []string   `json:"images,omitempty"`         // Gallery images from the article body
+	DiscussionURL         string     `json:"discussion_url,omitempty"` // Comment thread on an aggregator (HN, Reddit, ...)
+	AudioURL              string     `json:"audio_url"`
+	VideoURL              string     `json:"video_url"` // YouTube video URL for embedded player
+	PublishedAt           time.Time  `json:"published_at"`
+	HasValidPublishedTime bool       `json:"-"` // Internal field, not serialized
+	IsRead                bool       `json:"is_read"`
+	IsFavorite            bool       `json:"is_favorite"`
+	IsHidden              bool       `json:"is_hidden"`
+	IsReadLater           bool       `json:"is_read_later"`
+	IsPinned              bool       `json:"is_pinned"`
+	ReadLaterAt           *time.Time `json:"read_later_at,omitempty"` // When the article was queued for reading later
+	FeedTitle             string     `json:"feed_title,omitempty"`    // Joined field
+	Author                string     `json:"author,omitempty"`        // Article author
+	TranslatedTitle       string     `json:"translated_title"`
+	Summary               string     `json:"summary"`                   // Cached AI-generated summary
+	UniqueID              string     `json:"unique_id"`                 // Unique identifier for deduplication (title+feed_id+published_date)
+	FreshRSSItemID        string     `json:"freshrss_item_id"`          // FreshRSS/Google Reader item ID for API operations
+	DuplicateCount        int        `json:"duplicate_count,omitempty"` // Articles collapsed into this one by title (list view only)
+	DuplicateIDs          []int64    `json:"duplicate_ids,omitempty"`   // IDs of the collapsed group, this article first
+	FeedBadge             *FeedBadge `json:"feed_badge,omitempty"`      // Feed short name and icon (list view only, when enabled)
+}
+
+// FeedBadge identifies an article's feed in the list view without a separate feed lookup.
+type FeedBadge struct {
+	ShortName string `json:"short_name"`
+	IconURL   string `json:"icon_url,omitempty"`
 }
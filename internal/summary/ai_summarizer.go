@@ -1,6 +1,7 @@
 package summary
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
@@ -18,8 +19,12 @@ type AISummarizer struct {
 	Model         string
 	SystemPrompt  string
 	CustomHeaders string
-	Language      string // User's language setting (e.g., "en", "zh")
+	Language      string        // User's language setting (e.g., "en", "zh")
+	MinLength     int           // Minimum cleaned text length required before calling the API
+	MaxTokens     int           // Maximum output tokens; zero uses the client default
+	Fallbacks     []ai.Endpoint // Tried in order when the endpoint fails
 	client        *ai.Client
+	httpClient    *http.Client // Proxy-aware client from NewAISummarizerWithDB, nil for the default
 }
 
 // DBInterface defines the minimal database interface needed for proxy settings
@@ -76,6 +81,7 @@ func NewAISummarizer(apiKey, endpoint, model string) *AISummarizer {
 		SystemPrompt:  "",   // Will be set from settings when used
 		CustomHeaders: "",   // Will be set from settings when used
 		Language:      "en", // Default to English
+		MinLength:     MinContentLength,
 		client:        ai.NewClient(clientConfig),
 	}
 }
@@ -110,7 +116,9 @@ func NewAISummarizerWithDB(apiKey, endpoint, model string, db DBInterface) *AISu
 		SystemPrompt:  "",
 		CustomHeaders: "",   // Will be set from settings when used
 		Language:      "en", // Default to English
+		MinLength:     MinContentLength,
 		client:        ai.NewClientWithHTTPClient(clientConfig, httpClient),
+		httpClient:    httpClient,
 	}
 }
 
@@ -136,7 +144,31 @@ func (s *AISummarizer) SetLanguage(language string) {
 	}
 }
 
-// recreateClient re-creates the AI client with current configuration
+// SetMinContentLength overrides the minimum content length.
+// Non-positive values keep the current threshold.
+func (s *AISummarizer) SetMinContentLength(minLength int) {
+	if minLength > 0 {
+		s.MinLength = minLength
+	}
+}
+
+// SetMaxTokens sets the maximum number of output tokens for summaries.
+// Non-positive values keep the default.
+func (s *AISummarizer) SetMaxTokens(maxTokens int) {
+	if maxTokens > 0 {
+		s.MaxTokens = maxTokens
+		s.recreateClient()
+	}
+}
+
+// SetFallbackEndpoints sets the endpoints tried in order when the configured one fails.
+func (s *AISummarizer) SetFallbackEndpoints(endpoints []ai.Endpoint) {
+	s.Fallbacks = endpoints
+	s.recreateClient()
+}
+
+// recreateClient re-creates the AI client with current configuration, keeping the
+// proxy-aware HTTP client when there is one
 func (s *AISummarizer) recreateClient() {
 	clientConfig := ai.ClientConfig{
 		APIKey:        s.APIKey,
@@ -145,6 +177,12 @@ func (s *AISummarizer) recreateClient() {
 		SystemPrompt:  s.SystemPrompt,
 		CustomHeaders: s.CustomHeaders,
 		Timeout:       30 * time.Second,
+		MaxTokens:     s.MaxTokens,
+		Fallbacks:     s.Fallbacks,
+	}
+	if s.httpClient != nil {
+		s.client = ai.NewClientWithHTTPClient(clientConfig, s.httpClient)
+		return
 	}
 	s.client = ai.NewClient(clientConfig)
 }
@@ -167,18 +205,22 @@ func (s *AISummarizer) getUserPrompt(targetWords int, text string) string {
 	return fmt.Sprintf("Summarize the following text in English in approximately %d words:\n\n%s", targetWords, text)
 }
 
-// Summarize generates a summary of the given text using an OpenAI-compatible API.
-// Automatically detects and adapts to different API formats (Gemini, OpenAI, Ollama).
-func (s *AISummarizer) Summarize(text string, length SummaryLength) (SummaryResult, error) {
+// PromptPreview is the request Summarize would send to the AI for a text.
+type PromptPreview struct {
+	SystemPrompt string
+	UserPrompt   string
+	Content      string // Cleaned article text embedded in UserPrompt
+	IsTooShort   bool   // No request would be made; the prompts are left empty
+}
+
+// BuildPrompt assembles the system and user prompts for summarizing text without calling the API.
+func (s *AISummarizer) BuildPrompt(text string, length SummaryLength) PromptPreview {
 	// Clean the text first
 	cleanedText := cleanText(text)
 
-	// Check if text is too short
-	if len(cleanedText) < MinContentLength {
-		return SummaryResult{
-			Summary:    cleanedText,
-			IsTooShort: true,
-		}, nil
+	// Check if text is too short (no API request is made)
+	if len(cleanedText) < s.MinLength {
+		return PromptPreview{Content: cleanedText, IsTooShort: true}
 	}
 
 	targetWords := getTargetWordCount(length)
@@ -189,11 +231,28 @@ func (s *AISummarizer) Summarize(text string, length SummaryLength) (SummaryResu
 		systemPrompt = s.getDefaultSystemPrompt()
 	}
 
-	// Generate localized user prompt with target language specification
-	userPrompt := s.getUserPrompt(targetWords, cleanedText)
+	return PromptPreview{
+		SystemPrompt: systemPrompt,
+		// Generate localized user prompt with target language specification
+		UserPrompt: s.getUserPrompt(targetWords, cleanedText),
+		Content:    cleanedText,
+	}
+}
+
+// Summarize generates a summary of the given text using an OpenAI-compatible API.
+// Automatically detects and adapts to different API formats (Gemini, OpenAI, Ollama).
+// The request is made in ctx.
+func (s *AISummarizer) Summarize(ctx context.Context, text string, length SummaryLength) (SummaryResult, error) {
+	prompt := s.BuildPrompt(text, length)
+	if prompt.IsTooShort {
+		return SummaryResult{
+			Summary:    prompt.Content,
+			IsTooShort: true,
+		}, nil
+	}
 
 	// Use the universal client which handles format detection automatically
-	result, err := s.client.RequestWithThinking(systemPrompt, userPrompt)
+	result, err := s.client.WithContext(ctx).RequestWithThinking(prompt.SystemPrompt, prompt.UserPrompt)
 	if err != nil {
 		return SummaryResult{}, err
 	}
@@ -210,5 +269,6 @@ func (s *AISummarizer) Summarize(text string, length SummaryLength) (SummaryResu
 		Thinking:      thinking,
 		SentenceCount: len(sentences),
 		IsTooShort:    false,
+		Usage:         result.Usage,
 	}, nil
 }
@@ -0,0 +1,52 @@
+package summary
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// proxySettings enables the global HTTP proxy at proxyURL
+type proxySettings struct{ proxyURL *url.URL }
+
+func (p proxySettings) GetSetting(key string) (string, error) {
+	switch key {
+	case "proxy_enabled":
+		return "true", nil
+	case "proxy_type":
+		return "http", nil
+	case "proxy_host":
+		return p.proxyURL.Hostname(), nil
+	case "proxy_port":
+		return p.proxyURL.Port(), nil
+	}
+	return "", nil
+}
+
+func (p proxySettings) GetEncryptedSetting(key string) (string, error) { return "", nil }
+
+func TestAISummarizer_KeepsProxyAfterReconfiguring(t *testing.T) {
+	var proxied int
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"- Overview"}}]}`))
+	}))
+	defer proxy.Close()
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	// The endpoint only resolves through the proxy
+	s := NewAISummarizerWithDB("key", "http://ai.invalid/v1/chat/completions", "gpt-4o-mini", proxySettings{proxyURL})
+	s.SetMaxTokens(512)
+	s.SetSystemPrompt("Summarize the feed.")
+
+	result, err := s.SummarizeFeed(context.Background(), "Feed", "digest")
+	if err != nil {
+		t.Fatalf("SummarizeFeed failed: %v", err)
+	}
+	if proxied == 0 || result.Summary != "- Overview" {
+		t.Errorf("expected the request to go through the proxy, got %d proxied requests and %q", proxied, result.Summary)
+	}
+}
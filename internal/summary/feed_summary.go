@@ -0,0 +1,98 @@
+package summary
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"MrRSS/internal/ai"
+)
+
+// MaxFeedDigestChars bounds the article text sent to the AI for a feed overview, keeping
+// the request well inside small context windows
+const MaxFeedDigestChars = 12000
+
+// feedDigestSummaryChars caps how much of each article's summary goes into the digest
+const feedDigestSummaryChars = 300
+
+// FeedDigestItem is one article considered for a feed overview
+type FeedDigestItem struct {
+	Title   string
+	Summary string
+}
+
+// BuildFeedDigest renders items as a numbered list of titles with shortened summaries.
+// Items are added in order until maxChars would be exceeded; it returns the digest and
+// how many items it includes.
+func BuildFeedDigest(items []FeedDigestItem, maxChars int) (string, int) {
+	if maxChars <= 0 {
+		maxChars = MaxFeedDigestChars
+	}
+
+	var sb strings.Builder
+	included := 0
+	for _, item := range items {
+		title := strings.TrimSpace(cleanText(item.Title))
+		if title == "" {
+			continue
+		}
+		entry := fmt.Sprintf("%d. %s\n", included+1, title)
+		if summary := truncateRunes(strings.TrimSpace(cleanText(item.Summary)), feedDigestSummaryChars); summary != "" {
+			entry += "   " + summary + "\n"
+		}
+		if sb.Len()+len(entry) > maxChars {
+			break
+		}
+		sb.WriteString(entry)
+		included++
+	}
+	return sb.String(), included
+}
+
+// truncateRunes shortens s to at most n runes, marking the cut with an ellipsis
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return strings.TrimSpace(string(runes[:n])) + "…"
+}
+
+// getDefaultFeedSystemPrompt returns the default feed overview prompt for the configured language
+func (s *AISummarizer) getDefaultFeedSystemPrompt() string {
+	if strings.HasPrefix(s.Language, "zh") {
+		return "你是一个订阅源概览助手。根据订阅源最近文章的标题和摘要，用简短的项目符号列表概括其近期的主要主题。只输出列表。"
+	}
+	return "You are an assistant that gives a quick overview of a news feed. From the titles and summaries of its recent articles, write a brief bulleted list of the feed's recent themes. Output ONLY the list."
+}
+
+// SummarizeFeed asks the AI for a bulleted overview of the recent themes in a feed, given
+// a digest from BuildFeedDigest, making the request in ctx. A custom system prompt replaces
+// the default one.
+func (s *AISummarizer) SummarizeFeed(ctx context.Context, feedTitle, digest string) (SummaryResult, error) {
+	systemPrompt := s.SystemPrompt
+	if systemPrompt == "" {
+		systemPrompt = s.getDefaultFeedSystemPrompt()
+	}
+
+	var userPrompt string
+	if strings.HasPrefix(s.Language, "zh") {
+		userPrompt = fmt.Sprintf("请用中文概括订阅源「%s」最近文章的主要主题：\n\n%s", feedTitle, digest)
+	} else {
+		userPrompt = fmt.Sprintf("Give an overview in English of the recent themes in the feed \"%s\":\n\n%s", feedTitle, digest)
+	}
+
+	result, err := s.client.WithContext(ctx).RequestWithThinking(systemPrompt, userPrompt)
+	if err != nil {
+		return SummaryResult{}, err
+	}
+
+	thinking := ai.ExtractThinking(result.Content)
+	summary := ai.RemoveThinkingTags(result.Content)
+	return SummaryResult{
+		Summary:       summary,
+		Thinking:      thinking,
+		SentenceCount: len(splitSentences(summary)),
+		Usage:         result.Usage,
+	}, nil
+}
@@ -8,11 +8,21 @@ import (
 )
 
 // Summarizer provides text summarization capabilities
-type Summarizer struct{}
+type Summarizer struct {
+	MinLength int // Minimum cleaned text length required to summarize
+}
 
 // NewSummarizer creates a new Summarizer instance
 func NewSummarizer() *Summarizer {
-	return &Summarizer{}
+	return &Summarizer{MinLength: MinContentLength}
+}
+
+// SetMinContentLength overrides the minimum content length.
+// Non-positive values keep the current threshold.
+func (s *Summarizer) SetMinContentLength(minLength int) {
+	if minLength > 0 {
+		s.MinLength = minLength
+	}
 }
 
 // Summarize generates a summary of the given text using combined TF-IDF and TextRank scoring
@@ -21,7 +31,7 @@ func (s *Summarizer) Summarize(text string, length SummaryLength) SummaryResult
 	cleanedText := cleanText(text)
 
 	// Check if text is too short
-	if len(cleanedText) < MinContentLength {
+	if len(cleanedText) < s.MinLength {
 		return SummaryResult{
 			Summary:    cleanedText,
 			IsTooShort: true,
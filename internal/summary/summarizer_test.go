@@ -21,6 +21,29 @@ func TestSummarize_ShortText(t *testing.T) {
 	}
 }
 
+func TestSummarize_CustomMinContentLength(t *testing.T) {
+	s := NewSummarizer()
+	s.SetMinContentLength(5000)
+
+	text := strings.Repeat("This sentence is long enough to pass the default threshold. ", 10)
+	result := s.Summarize(text, Medium)
+
+	if !result.IsTooShort {
+		t.Error("Expected IsTooShort to be true when below the configured minimum")
+	}
+}
+
+func TestIsContentTooShort(t *testing.T) {
+	text := strings.Repeat("a", 150)
+
+	if !IsContentTooShort(text, 0) {
+		t.Error("Expected text below the default minimum to be too short")
+	}
+	if IsContentTooShort(text, 100) {
+		t.Error("Expected text above a lowered minimum not to be too short")
+	}
+}
+
 func TestSummarize_MediumLength(t *testing.T) {
 	s := NewSummarizer()
 
@@ -256,3 +279,29 @@ func TestSummarize_SingleSentence(t *testing.T) {
 		t.Error("Expected IsTooShort to be true for single sentence")
 	}
 }
+
+func TestBuildFeedDigest(t *testing.T) {
+	items := []FeedDigestItem{
+		{Title: "<b>First</b> story", Summary: strings.Repeat("word ", 100)},
+		{Title: "   "},
+		{Title: "Second story"},
+		{Title: "Third story", Summary: "Short summary"},
+	}
+
+	digest, included := BuildFeedDigest(items, 0)
+	if included != 3 {
+		t.Fatalf("expected 3 items with a title, got %d", included)
+	}
+	if !strings.HasPrefix(digest, "1. First story\n") || !strings.Contains(digest, "2. Second story\n3. Third story\n   Short summary\n") {
+		t.Errorf("unexpected digest:\n%s", digest)
+	}
+	if !strings.Contains(digest, "…") {
+		t.Error("expected a long summary to be truncated")
+	}
+
+	// Items that would overflow the budget are dropped
+	digest, included = BuildFeedDigest(items, 330)
+	if included != 1 || len(digest) > 330 {
+		t.Errorf("expected only the first item within 330 chars, got %d items in %d chars", included, len(digest))
+	}
+}
@@ -2,6 +2,8 @@
 // It implements TF-IDF and TextRank-based sentence scoring for extractive summarization.
 package summary
 
+import "MrRSS/internal/ai"
+
 // SummaryLength represents the desired length of the summary
 type SummaryLength string
 
@@ -14,9 +16,25 @@ const (
 	Long SummaryLength = "long"
 )
 
-// MinContentLength is the minimum text length required for meaningful summarization
+// MinContentLength is the default minimum text length required for meaningful summarization.
+// It can be overridden with the summary_min_length setting.
 const MinContentLength = 200
 
+// IsContentTooShort reports whether text is below minLength after cleaning.
+// A non-positive minLength falls back to MinContentLength.
+func IsContentTooShort(text string, minLength int) bool {
+	if minLength <= 0 {
+		minLength = MinContentLength
+	}
+	return len(cleanText(text)) < minLength
+}
+
+// PlainText returns text with HTML tags removed and whitespace normalized, as it is
+// measured and sent for summarization.
+func PlainText(text string) string {
+	return cleanText(text)
+}
+
 // MinSentenceCount is the minimum number of sentences required for summarization
 const MinSentenceCount = 3
 
@@ -34,6 +52,8 @@ type SummaryResult struct {
 	Thinking      string `json:"thinking,omitempty"` // AI thinking process (optional)
 	SentenceCount int    `json:"sentence_count"`
 	IsTooShort    bool   `json:"is_too_short"`
+	// Usage is the token usage the AI API reported, zero for local summaries
+	Usage ai.Usage `json:"-"`
 }
 
 // scoredSentence holds a sentence with its calculated score and position
@@ -1,6 +1,7 @@
 package translation
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
@@ -17,7 +18,13 @@ type AITranslator struct {
 	Model         string
 	SystemPrompt  string
 	CustomHeaders string
-	client        *ai.Client
+	// PreamblePatterns are extra leading patterns stripped from model output
+	PreamblePatterns []string
+	// MaxTokens limits the output length; zero uses the client default
+	MaxTokens int
+	// Fallbacks are tried in order when the endpoint fails
+	Fallbacks []ai.Endpoint
+	client    *ai.Client
 }
 
 // NewAITranslator creates a new AI translator with the given credentials.
@@ -95,6 +102,8 @@ func (t *AITranslator) SetSystemPrompt(prompt string) {
 		SystemPrompt:  prompt,
 		CustomHeaders: t.CustomHeaders,
 		Timeout:       30 * time.Second,
+		MaxTokens:     t.MaxTokens,
+		Fallbacks:     t.Fallbacks,
 	}
 	t.client = ai.NewClient(clientConfig)
 }
@@ -110,15 +119,78 @@ func (t *AITranslator) SetCustomHeaders(headers string) {
 		SystemPrompt:  t.SystemPrompt,
 		CustomHeaders: headers,
 		Timeout:       30 * time.Second,
+		MaxTokens:     t.MaxTokens,
+		Fallbacks:     t.Fallbacks,
 	}
 	t.client = ai.NewClient(clientConfig)
 }
 
+// SetPreamblePatterns sets extra patterns for boilerplate to strip from the model output.
+func (t *AITranslator) SetPreamblePatterns(patterns []string) {
+	t.PreamblePatterns = patterns
+}
+
+// SetMaxTokens sets the maximum number of output tokens. Non-positive values keep the default.
+func (t *AITranslator) SetMaxTokens(maxTokens int) {
+	if maxTokens <= 0 {
+		return
+	}
+	t.MaxTokens = maxTokens
+	t.client = ai.NewClient(ai.ClientConfig{
+		APIKey:        t.APIKey,
+		Endpoint:      t.Endpoint,
+		Model:         t.Model,
+		SystemPrompt:  t.SystemPrompt,
+		CustomHeaders: t.CustomHeaders,
+		Timeout:       30 * time.Second,
+		MaxTokens:     maxTokens,
+		Fallbacks:     t.Fallbacks,
+	})
+}
+
+// SetFallbackEndpoints sets the endpoints tried in order when the configured one fails.
+func (t *AITranslator) SetFallbackEndpoints(endpoints []ai.Endpoint) {
+	t.Fallbacks = endpoints
+	t.client = ai.NewClient(ai.ClientConfig{
+		APIKey:        t.APIKey,
+		Endpoint:      t.Endpoint,
+		Model:         t.Model,
+		SystemPrompt:  t.SystemPrompt,
+		CustomHeaders: t.CustomHeaders,
+		Timeout:       30 * time.Second,
+		MaxTokens:     t.MaxTokens,
+		Fallbacks:     endpoints,
+	})
+}
+
 // Translate translates text to the target language using an OpenAI-compatible API.
 // Automatically detects and adapts to different API formats (Gemini, OpenAI, Ollama).
 func (t *AITranslator) Translate(text, targetLang string) (string, error) {
+	return t.TranslateFrom(text, "", targetLang)
+}
+
+// TranslateFrom translates text, naming the source language in the prompt when it is known.
+// The output is cleaned up as a short text such as a title or snippet.
+func (t *AITranslator) TranslateFrom(text, sourceLang, targetLang string) (string, error) {
+	translated, _, err := t.translate(context.Background(), text, sourceLang, targetLang, false)
+	return translated, err
+}
+
+// TranslateWithUsage is TranslateFrom that also returns the token usage the API reported.
+func (t *AITranslator) TranslateWithUsage(ctx context.Context, text, sourceLang, targetLang string) (string, ai.Usage, error) {
+	return t.translate(ctx, text, sourceLang, targetLang, false)
+}
+
+// translateBody is TranslateWithUsage for article bodies: only a leading preamble is removed
+// from the output, so paragraphs like "Note: ..." in the article are kept.
+func (t *AITranslator) translateBody(ctx context.Context, text, sourceLang, targetLang string) (string, ai.Usage, error) {
+	return t.translate(ctx, text, sourceLang, targetLang, true)
+}
+
+// translate requests a translation and strips model boilerplate from the output
+func (t *AITranslator) translate(ctx context.Context, text, sourceLang, targetLang string, body bool) (string, ai.Usage, error) {
 	if text == "" {
-		return "", nil
+		return "", ai.Usage{}, nil
 	}
 
 	langName := getLanguageName(targetLang)
@@ -129,36 +201,54 @@ func (t *AITranslator) Translate(text, targetLang string) (string, error) {
 		systemPrompt = "You are a translator. Translate the given text accurately. Output ONLY the translated text, nothing else."
 	}
 	userPrompt := fmt.Sprintf("Translate to %s:\n%s", langName, text)
+	if sourceLang != "" {
+		userPrompt = fmt.Sprintf("Translate from %s to %s:\n%s", getLanguageName(sourceLang), langName, text)
+	}
 
 	// Use the universal client which handles format detection automatically
-	result, err := t.client.RequestWithThinking(systemPrompt, userPrompt)
+	result, err := t.client.WithContext(ctx).RequestWithThinking(systemPrompt, userPrompt)
 	if err != nil {
-		return "", err
+		return "", ai.Usage{}, err
 	}
 
-	// Clean up the response - remove any quotes or extra whitespace
-	translated := strings.TrimSpace(result.Content)
+	// Clean up the response - remove any model preamble, quotes or extra whitespace
+	if body {
+		return ai.StripBodyPreamble(result.Content, t.PreamblePatterns), result.Usage, nil
+	}
+	translated := ai.StripPreambleWithPatterns(result.Content, t.PreamblePatterns)
 	translated = strings.Trim(translated, "\"'")
-	return translated, nil
+	return translated, result.Usage, nil
+}
+
+// languageNames maps lowercase language codes to the English names used in AI prompts. It
+// covers every code the language detector can report.
+var languageNames = map[string]string{
+	"en":    "English",
+	"zh":    "Simplified Chinese",
+	"zh-tw": "Traditional Chinese",
+	"es":    "Spanish",
+	"fr":    "French",
+	"de":    "German",
+	"ja":    "Japanese",
+	"ko":    "Korean",
+	"pt":    "Portuguese",
+	"ru":    "Russian",
+	"it":    "Italian",
+	"nl":    "Dutch",
+	"pl":    "Polish",
+	"tr":    "Turkish",
+	"vi":    "Vietnamese",
+	"th":    "Thai",
+	"id":    "Indonesian",
+	"hi":    "Hindi",
+	"ar":    "Arabic",
+	"he":    "Hebrew",
+	"fa":    "Persian",
 }
 
 // getLanguageName converts a language code to a human-readable name.
 func getLanguageName(code string) string {
-	langNames := map[string]string{
-		"en":    "English",
-		"zh":    "Simplified Chinese",
-		"zh-TW": "Traditional Chinese",
-		"es":    "Spanish",
-		"fr":    "French",
-		"de":    "German",
-		"ja":    "Japanese",
-		"ko":    "Korean",
-		"pt":    "Portuguese",
-		"ru":    "Russian",
-		"it":    "Italian",
-		"ar":    "Arabic",
-	}
-	if name, ok := langNames[code]; ok {
+	if name, ok := languageNames[strings.ToLower(code)]; ok {
 		return name
 	}
 	return code
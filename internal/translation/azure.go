@@ -0,0 +1,127 @@
+package translation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// AzureTranslatorEndpoint is the global endpoint of Azure AI Translator (Microsoft Translator)
+const AzureTranslatorEndpoint = "https://api.cognitive.microsofttranslator.com"
+
+// AzureTranslator implements translation using Azure AI Translator.
+type AzureTranslator struct {
+	APIKey   string
+	Region   string // Required for regional and multi-service resources, empty for global ones
+	Endpoint string
+	client   *http.Client
+	db       DBInterface
+}
+
+// NewAzureTranslator creates a new Azure Translator
+func NewAzureTranslator(apiKey, region string) *AzureTranslator {
+	return &AzureTranslator{
+		APIKey:   apiKey,
+		Region:   region,
+		Endpoint: AzureTranslatorEndpoint,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// NewAzureTranslatorWithDB creates an Azure Translator with database for proxy support
+func NewAzureTranslatorWithDB(apiKey, region string, db DBInterface) *AzureTranslator {
+	client, err := CreateHTTPClientWithProxy(db, 10*time.Second)
+	if err != nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &AzureTranslator{
+		APIKey:   apiKey,
+		Region:   region,
+		Endpoint: AzureTranslatorEndpoint,
+		client:   client,
+		db:       db,
+	}
+}
+
+func (t *AzureTranslator) Translate(text, targetLang string) (string, error) {
+	return t.TranslateFrom(text, "", targetLang)
+}
+
+// TranslateFrom translates text from sourceLang, or lets Azure detect it when empty.
+// Azure Translator API: POST /translate?api-version=3.0&to=<lang> with JSON body [{"Text": "..."}]
+func (t *AzureTranslator) TranslateFrom(text, sourceLang, targetLang string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	params := url.Values{}
+	params.Set("api-version", "3.0")
+	params.Set("to", mapToAzureLang(targetLang))
+	if sourceLang != "" {
+		params.Set("from", mapToAzureLang(sourceLang))
+	}
+
+	jsonBody, err := json.Marshal([]map[string]string{{"Text": text}})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal azure request: %w", err)
+	}
+
+	endpoint := strings.TrimSuffix(t.Endpoint, "/") + "/translate?" + params.Encode()
+	req, err := http.NewRequest("POST", endpoint, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return "", fmt.Errorf("failed to create azure request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Ocp-Apim-Subscription-Key", t.APIKey)
+	if t.Region != "" {
+		req.Header.Set("Ocp-Apim-Subscription-Region", t.Region)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("azure request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// Errors come back as {"error": {"code": ..., "message": "..."}}
+		var errResult struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&errResult) == nil && errResult.Error.Message != "" {
+			return "", newStatusError(resp, fmt.Sprintf("azure translator returned status %d: %s", resp.StatusCode, errResult.Error.Message))
+		}
+		return "", newStatusError(resp, fmt.Sprintf("azure translator returned status: %d", resp.StatusCode))
+	}
+
+	var result []struct {
+		Translations []struct {
+			Text string `json:"text"`
+		} `json:"translations"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode azure response: %w", err)
+	}
+	if len(result) == 0 || len(result[0].Translations) == 0 {
+		return "", fmt.Errorf("no translation found from azure")
+	}
+	return result[0].Translations[0].Text, nil
+}
+
+// mapToAzureLang maps standard language codes to Azure's language codes.
+// Azure only accepts the script-tagged forms for Chinese.
+func mapToAzureLang(lang string) string {
+	switch lang {
+	case "zh", "zh-CN":
+		return "zh-Hans"
+	case "zh-TW":
+		return "zh-Hant"
+	}
+	return lang
+}
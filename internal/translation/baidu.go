@@ -48,6 +48,11 @@ func NewBaiduTranslatorWithDB(appID, secretKey string, db DBInterface) *BaiduTra
 
 // Translate translates text to the target language using Baidu Translate API.
 func (t *BaiduTranslator) Translate(text, targetLang string) (string, error) {
+	return t.TranslateFrom(text, "", targetLang)
+}
+
+// TranslateFrom translates text from sourceLang, or lets Baidu detect it when empty.
+func (t *BaiduTranslator) TranslateFrom(text, sourceLang, targetLang string) (string, error) {
 	if text == "" {
 		return "", nil
 	}
@@ -73,7 +78,11 @@ func (t *BaiduTranslator) Translate(text, targetLang string) (string, error) {
 	apiURL := "https://fanyi-api.baidu.com/api/trans/vip/translate"
 	data := url.Values{}
 	data.Set("q", text)
-	data.Set("from", "auto")
+	baiduSource := "auto"
+	if sourceLang != "" {
+		baiduSource = mapToBaiduLang(sourceLang)
+	}
+	data.Set("from", baiduSource)
 	data.Set("to", baiduLang)
 	data.Set("appid", t.AppID)
 	data.Set("salt", salt)
@@ -86,7 +95,7 @@ func (t *BaiduTranslator) Translate(text, targetLang string) (string, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("baidu api returned status: %d", resp.StatusCode)
+		return "", newStatusError(resp, fmt.Sprintf("baidu api returned status: %d", resp.StatusCode))
 	}
 
 	var result struct {
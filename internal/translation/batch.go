@@ -0,0 +1,65 @@
+package translation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"MrRSS/internal/ai"
+)
+
+// numberedLinePattern matches one numbered line of a batch response, allowing the
+// punctuation models commonly substitute after the number
+var numberedLinePattern = regexp.MustCompile(`^(\d+)\s*[.)．、:：]\s*(.*)$`)
+
+// TranslateNumberedLines translates several single-line texts in one request by sending
+// them as numbered lines. It fails if any text spans several lines or the response cannot
+// be mapped back to the texts one-to-one, so callers can translate them one by one instead.
+// The token usage the AI API reported for the request is returned with the translations.
+func TranslateNumberedLines(ctx context.Context, lines []string, translator Translator, targetLang string) ([]string, ai.Usage, error) {
+	var b strings.Builder
+	for i, line := range lines {
+		if strings.ContainsAny(line, "\r\n") {
+			return nil, ai.Usage{}, fmt.Errorf("text %d spans several lines", i+1)
+		}
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%d. %s", i+1, line)
+	}
+
+	translated, usage, err := TranslateWithUsage(ctx, translator, b.String(), "", targetLang)
+	if err != nil {
+		return nil, ai.Usage{}, err
+	}
+	results, err := parseNumberedLines(translated, len(lines))
+	return results, usage, err
+}
+
+// parseNumberedLines splits a response to TranslateNumberedLines into its n translations
+func parseNumberedLines(text string, n int) ([]string, error) {
+	results := make([]string, n)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		m := numberedLinePattern.FindStringSubmatch(line)
+		if m == nil {
+			return nil, fmt.Errorf("unnumbered line in response: %q", line)
+		}
+		i, _ := strconv.Atoi(m[1])
+		if i < 1 || i > n || results[i-1] != "" {
+			return nil, fmt.Errorf("unexpected line number %d in response", i)
+		}
+		results[i-1] = strings.TrimSpace(m[2])
+	}
+	for i, result := range results {
+		if result == "" {
+			return nil, fmt.Errorf("response is missing line %d", i+1)
+		}
+	}
+	return results, nil
+}
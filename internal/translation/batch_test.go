@@ -0,0 +1,33 @@
+package translation
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestTranslateNumberedLines(t *testing.T) {
+	translator := &TestTranslator{TranslateFunc: func(text, targetLang string) (string, error) {
+		// Answer out of order with full-width punctuation
+		return "2．SECOND\n1．FIRST", nil
+	}}
+	got, _, err := TranslateNumberedLines(context.Background(), []string{"first", "second"}, translator, "zh")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(got, "|") != "FIRST|SECOND" {
+		t.Errorf("unexpected translations %q", got)
+	}
+
+	// Responses that cannot be mapped back are rejected
+	for _, response := range []string{"1. FIRST", "1. FIRST\n2. SECOND\n3. THIRD", "FIRST\nSECOND", "1. FIRST\n1. SECOND"} {
+		translator.TranslateFunc = func(text, targetLang string) (string, error) { return response, nil }
+		if _, _, err := TranslateNumberedLines(context.Background(), []string{"first", "second"}, translator, "zh"); err == nil {
+			t.Errorf("expected response %q to be rejected", response)
+		}
+	}
+
+	if _, _, err := TranslateNumberedLines(context.Background(), []string{"two\nlines"}, translator, "zh"); err == nil {
+		t.Error("expected multi-line texts to be rejected")
+	}
+}
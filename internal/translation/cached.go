@@ -1,9 +1,12 @@
 package translation
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
-	"log"
+
+	"MrRSS/internal/ai"
+	"MrRSS/internal/utils"
 )
 
 // TranslationCache is an interface for caching translations
@@ -30,35 +33,57 @@ func NewCachedTranslator(translator Translator, cache TranslationCache, provider
 
 // Translate translates text, using cache when available
 func (ct *CachedTranslator) Translate(text, targetLang string) (string, error) {
+	return ct.TranslateFrom(text, "", targetLang)
+}
+
+// TranslateFrom translates text from an explicit source language, using cache when available.
+// Translations with an explicit source are cached separately from auto-detected ones.
+func (ct *CachedTranslator) TranslateFrom(text, sourceLang, targetLang string) (string, error) {
+	translated, _, err := ct.TranslateWithUsage(context.Background(), text, sourceLang, targetLang)
+	return translated, err
+}
+
+// TranslateWithUsage is TranslateFrom that also returns the token usage of the wrapped
+// translator, zero when the translation came from the cache.
+func (ct *CachedTranslator) TranslateWithUsage(ctx context.Context, text, sourceLang, targetLang string) (string, ai.Usage, error) {
 	if text == "" {
-		return "", nil
+		return "", ai.Usage{}, nil
 	}
 
 	// Generate hash for cache lookup
-	textHash := hashText(text)
+	textHash := CacheKey(text, sourceLang)
 
 	// Try to get from cache first
 	if ct.cache != nil {
 		if cached, found, err := ct.cache.GetCachedTranslation(textHash, targetLang, ct.provider); err == nil && found {
-			return cached, nil
+			return cached, ai.Usage{}, nil
 		}
 	}
 
 	// Not in cache, perform translation
-	translated, err := ct.translator.Translate(text, targetLang)
+	translated, usage, err := TranslateWithUsage(ctx, ct.translator, text, sourceLang, targetLang)
 	if err != nil {
-		return "", err
+		return "", ai.Usage{}, err
 	}
 
 	// Cache the result (including when source == translation, meaning no translation needed)
 	if ct.cache != nil {
 		if cacheErr := ct.cache.SetCachedTranslation(textHash, text, targetLang, translated, ct.provider); cacheErr != nil {
 			// Log but don't fail - caching is optional
-			log.Printf("Warning: failed to cache translation: %v", cacheErr)
+			utils.ContextLog(ctx, "Warning: failed to cache translation: %v", cacheErr)
 		}
 	}
 
-	return translated, nil
+	return translated, usage, nil
+}
+
+// CacheKey returns the source text hash a translation of text is cached under. Translations
+// with an explicit source language are keyed separately from auto-detected ones.
+func CacheKey(text, sourceLang string) string {
+	if sourceLang != "" {
+		return hashText(sourceLang + "\x00" + text)
+	}
+	return hashText(text)
 }
 
 // hashText creates a SHA256 hash of the text for cache lookup
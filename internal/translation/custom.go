@@ -89,7 +89,7 @@ func (t *CustomTranslator) Translate(text, targetLang string) (string, error) {
 	// Check status code
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		return "", newStatusError(resp, fmt.Sprintf("API returned status %d: %s", resp.StatusCode, string(body)))
 	}
 
 	// Parse response and extract translation
@@ -68,13 +68,18 @@ func NewDeepLTranslatorWithEndpointAndDB(apiKey, endpoint string, db DBInterface
 }
 
 func (t *DeepLTranslator) Translate(text, targetLang string) (string, error) {
+	return t.TranslateFrom(text, "", targetLang)
+}
+
+// TranslateFrom translates text from sourceLang, or lets DeepL detect it when empty.
+func (t *DeepLTranslator) TranslateFrom(text, sourceLang, targetLang string) (string, error) {
 	if text == "" {
 		return "", nil
 	}
 
 	// Use custom endpoint if provided (for deeplx self-hosted service)
 	if t.Endpoint != "" {
-		return t.translateWithDeeplx(text, targetLang)
+		return t.translateWithDeeplx(text, sourceLang, targetLang)
 	}
 
 	// Standard DeepL API
@@ -87,6 +92,10 @@ func (t *DeepLTranslator) Translate(text, targetLang string) (string, error) {
 	data.Set("auth_key", t.APIKey)
 	data.Set("text", text)
 	data.Set("target_lang", strings.ToUpper(targetLang))
+	if sourceLang != "" {
+		// DeepL source languages have no regional variants (ZH, not ZH-TW)
+		data.Set("source_lang", deeplSourceLang(sourceLang))
+	}
 
 	resp, err := t.client.PostForm(apiURL, data)
 	if err != nil {
@@ -95,7 +104,7 @@ func (t *DeepLTranslator) Translate(text, targetLang string) (string, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("deepl api returned status: %d", resp.StatusCode)
+		return "", newStatusError(resp, fmt.Sprintf("deepl api returned status: %d", resp.StatusCode))
 	}
 
 	var result struct {
@@ -117,12 +126,16 @@ func (t *DeepLTranslator) Translate(text, targetLang string) (string, error) {
 
 // translateWithDeeplx handles translation using deeplx self-hosted service
 // deeplx API: POST /translate with JSON body {text, source_lang, target_lang}
-func (t *DeepLTranslator) translateWithDeeplx(text, targetLang string) (string, error) {
+func (t *DeepLTranslator) translateWithDeeplx(text, sourceLang, targetLang string) (string, error) {
 	apiURL := t.Endpoint + "/translate"
 
+	deeplxSource := "auto"
+	if sourceLang != "" {
+		deeplxSource = deeplSourceLang(sourceLang)
+	}
 	requestBody := map[string]string{
 		"text":        text,
-		"source_lang": "auto",
+		"source_lang": deeplxSource,
 		"target_lang": strings.ToUpper(targetLang),
 	}
 
@@ -149,7 +162,7 @@ func (t *DeepLTranslator) translateWithDeeplx(text, targetLang string) (string,
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("deeplx returned status: %d", resp.StatusCode)
+		return "", newStatusError(resp, fmt.Sprintf("deeplx returned status: %d", resp.StatusCode))
 	}
 
 	// deeplx response format: {code, message, data, source_lang, target_lang, alternatives}
@@ -174,3 +187,11 @@ func (t *DeepLTranslator) translateWithDeeplx(text, targetLang string) (string,
 
 	return "", fmt.Errorf("no translation found from deeplx")
 }
+
+// deeplSourceLang converts a language code to DeepL's source language form
+func deeplSourceLang(lang string) string {
+	if i := strings.Index(lang, "-"); i > 0 {
+		lang = lang[:i]
+	}
+	return strings.ToUpper(lang)
+}
@@ -1,10 +1,15 @@
 package translation
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strings"
 	"sync"
+
+	"MrRSS/internal/ai"
+	"MrRSS/internal/utils"
 )
 
 // SettingsProvider is an interface for retrieving translation settings.
@@ -31,6 +36,7 @@ type DynamicTranslator struct {
 	cachedAPIKey        string
 	cachedAppID         string
 	cachedSecretKey     string
+	cachedRegion        string
 	cachedEndpoint      string
 	cachedModel         string
 	cachedPrompt        string
@@ -39,6 +45,14 @@ type DynamicTranslator struct {
 	cachedResponsePath  string
 	cachedLangMapping   string
 	cachedTimeout       int
+	// Extra boilerplate patterns stripped from AI translations
+	cachedPreamblePatterns string
+	cachedMaxTokens        int
+	// Raw ai_endpoints setting, the AI fallback endpoints
+	cachedAIEndpoints string
+	// Used when Google Free is blocked, see google_translate_fallback_provider
+	fallback         *DynamicTranslator
+	fallbackProvider string
 }
 
 // NewDynamicTranslator creates a new dynamic translator that uses the given settings provider.
@@ -58,22 +72,82 @@ func NewDynamicTranslatorWithCache(settings SettingsProvider, cache CacheProvide
 
 // Translate translates text using the currently configured translation provider.
 func (t *DynamicTranslator) Translate(text, targetLang string) (string, error) {
+	return t.TranslateFrom(text, "", targetLang)
+}
+
+// TranslateFrom translates text from an explicit source language using the currently
+// configured provider. Providers that cannot take a source language detect it themselves.
+func (t *DynamicTranslator) TranslateFrom(text, sourceLang, targetLang string) (string, error) {
+	translated, _, err := t.TranslateWithUsage(context.Background(), text, sourceLang, targetLang)
+	return translated, err
+}
+
+// TranslateWithUsage is TranslateFrom that also returns the token usage reported when the
+// configured provider is AI.
+func (t *DynamicTranslator) TranslateWithUsage(ctx context.Context, text, sourceLang, targetLang string) (string, ai.Usage, error) {
 	if text == "" {
-		return "", nil
+		return "", ai.Usage{}, nil
 	}
 
 	translator, provider, err := t.getTranslatorWithProvider()
 	if err != nil {
-		return "", err
+		return "", ai.Usage{}, err
+	}
+
+	// Retry transient provider failures; the AI client handles its own requests
+	if provider != "ai" {
+		translator = NewRetryingTranslator(translator)
 	}
 
 	// Wrap with caching if cache is available
 	if t.cache != nil {
-		cachedTranslator := NewCachedTranslator(translator, t.cache, provider)
-		return cachedTranslator.Translate(text, targetLang)
+		translator = NewCachedTranslator(translator, t.cache, provider)
 	}
 
-	return translator.Translate(text, targetLang)
+	result, usage, err := TranslateWithUsage(ctx, translator, text, sourceLang, targetLang)
+	if err != nil && errors.Is(err, ErrGoogleBlocked) {
+		if fallback, name := t.fallbackTranslator(provider); fallback != nil {
+			utils.ContextLog(ctx, "Google Translate is blocked, falling back to %s", name)
+			return fallback.TranslateWithUsage(ctx, text, sourceLang, targetLang)
+		}
+	}
+	return result, usage, err
+}
+
+// fallbackTranslator returns the translator configured to stand in for a blocked
+// Google Free provider, or nil when none is configured
+func (t *DynamicTranslator) fallbackTranslator(provider string) (*DynamicTranslator, string) {
+	if provider != "google" {
+		return nil, ""
+	}
+	name, _ := t.settings.GetSetting("google_translate_fallback_provider")
+	if name == "" || name == "google" {
+		return nil, ""
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.fallback == nil || t.fallbackProvider != name {
+		t.fallback = &DynamicTranslator{
+			settings: providerOverride{SettingsProvider: t.settings, provider: name},
+			cache:    t.cache,
+		}
+		t.fallbackProvider = name
+	}
+	return t.fallback, name
+}
+
+// providerOverride reports a fixed translation provider and passes every other setting through
+type providerOverride struct {
+	SettingsProvider
+	provider string
+}
+
+func (p providerOverride) GetSetting(key string) (string, error) {
+	if key == "translation_provider" {
+		return p.provider, nil
+	}
+	return p.SettingsProvider.GetSetting(key)
 }
 
 // getTranslatorWithProvider returns the appropriate translator and provider name based on current settings.
@@ -85,13 +159,19 @@ func (t *DynamicTranslator) getTranslatorWithProvider() (Translator, string, err
 	}
 
 	// Get provider-specific settings (use encrypted methods for sensitive credentials)
-	var apiKey, appID, secretKey, endpoint, model, systemPrompt, customHeaders string
+	var apiKey, appID, secretKey, region, endpoint, model, systemPrompt, customHeaders, preamblePatterns, aiEndpoints string
 	var customName, customMethod, customBodyTemplate, customResponsePath, customLangMapping string
-	var customTimeout int
+	var customTimeout, maxTokens int
 	switch provider {
 	case "deepl":
 		apiKey, _ = t.settings.GetEncryptedSetting("deepl_api_key")
 		endpoint, _ = t.settings.GetSetting("deepl_endpoint")
+	case "libre":
+		apiKey, _ = t.settings.GetEncryptedSetting("libre_api_key")
+		endpoint, _ = t.settings.GetSetting("libre_endpoint")
+	case "azure":
+		apiKey, _ = t.settings.GetEncryptedSetting("azure_translator_key")
+		region, _ = t.settings.GetSetting("azure_translator_region")
 	case "baidu":
 		appID, _ = t.settings.GetSetting("baidu_app_id")
 		secretKey, _ = t.settings.GetEncryptedSetting("baidu_secret_key")
@@ -101,6 +181,11 @@ func (t *DynamicTranslator) getTranslatorWithProvider() (Translator, string, err
 		model, _ = t.settings.GetSetting("ai_model")
 		systemPrompt, _ = t.settings.GetSetting("ai_translation_prompt")
 		customHeaders, _ = t.settings.GetSetting("ai_custom_headers")
+		preamblePatterns, _ = t.settings.GetSetting("ai_preamble_patterns")
+		aiEndpoints, _ = t.settings.GetEncryptedSetting("ai_endpoints")
+		if s, err := t.settings.GetSetting("ai_translation_max_tokens"); err == nil {
+			fmt.Sscanf(s, "%d", &maxTokens)
+		}
 	case "custom":
 		customName, _ = t.settings.GetSetting("custom_translation_name")
 		endpoint, _ = t.settings.GetSetting("custom_translation_endpoint")
@@ -122,10 +207,14 @@ func (t *DynamicTranslator) getTranslatorWithProvider() (Translator, string, err
 		t.cachedAPIKey == apiKey &&
 		t.cachedAppID == appID &&
 		t.cachedSecretKey == secretKey &&
+		t.cachedRegion == region &&
 		t.cachedEndpoint == endpoint &&
 		t.cachedModel == model &&
 		t.cachedPrompt == systemPrompt &&
 		t.cachedCustomHeaders == customHeaders &&
+		t.cachedPreamblePatterns == preamblePatterns &&
+		t.cachedMaxTokens == maxTokens &&
+		t.cachedAIEndpoints == aiEndpoints &&
 		t.cachedBodyTemplate == customBodyTemplate &&
 		t.cachedResponsePath == customResponsePath &&
 		t.cachedLangMapping == customLangMapping &&
@@ -154,6 +243,16 @@ func (t *DynamicTranslator) getTranslatorWithProvider() (Translator, string, err
 		} else {
 			translator = NewDeepLTranslator(apiKey)
 		}
+	case "libre":
+		if endpoint == "" {
+			return nil, "", fmt.Errorf("LibreTranslate endpoint is required")
+		}
+		translator = NewLibreTranslatorWithDB(endpoint, apiKey, t.settings)
+	case "azure":
+		if apiKey == "" {
+			return nil, "", fmt.Errorf("Azure Translator key is required")
+		}
+		translator = NewAzureTranslatorWithDB(apiKey, region, t.settings)
 	case "baidu":
 		if appID == "" || secretKey == "" {
 			return nil, "", fmt.Errorf("Baidu App ID and Secret Key are required")
@@ -171,6 +270,9 @@ func (t *DynamicTranslator) getTranslatorWithProvider() (Translator, string, err
 		if customHeaders != "" {
 			aiTranslator.SetCustomHeaders(customHeaders)
 		}
+		aiTranslator.SetPreamblePatterns(ai.ParsePreamblePatterns(preamblePatterns))
+		aiTranslator.SetMaxTokens(maxTokens)
+		aiTranslator.SetFallbackEndpoints(ai.LoadEndpoints(t.settings))
 		translator = aiTranslator
 	case "custom":
 		// Custom translator with user-defined configuration
@@ -199,6 +301,7 @@ func (t *DynamicTranslator) getTranslatorWithProvider() (Translator, string, err
 	t.cachedAPIKey = apiKey
 	t.cachedAppID = appID
 	t.cachedSecretKey = secretKey
+	t.cachedRegion = region
 	t.cachedEndpoint = endpoint
 	t.cachedModel = model
 	t.cachedPrompt = systemPrompt
@@ -207,6 +310,9 @@ func (t *DynamicTranslator) getTranslatorWithProvider() (Translator, string, err
 	t.cachedResponsePath = customResponsePath
 	t.cachedLangMapping = customLangMapping
 	t.cachedTimeout = customTimeout
+	t.cachedPreamblePatterns = preamblePatterns
+	t.cachedMaxTokens = maxTokens
+	t.cachedAIEndpoints = aiEndpoints
 
 	return translator, provider, nil
 }
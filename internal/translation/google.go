@@ -1,16 +1,34 @@
 package translation
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 	"time"
 )
 
+// googleBlockCooldown is how long requests are held back after Google starts blocking
+const googleBlockCooldown = 5 * time.Minute
+
+// ErrGoogleBlocked is returned while Google answers with rate limits or CAPTCHA pages
+var ErrGoogleBlocked = errors.New("Google Translate temporarily blocked")
+
+// Markers found in the HTML pages Google serves instead of translations when it blocks a client
+var googleBlockMarkers = []string{"captcha", "unusual traffic", "/sorry/"}
+
 type GoogleFreeTranslator struct {
 	client *http.Client
 	db     DBInterface
+
+	mu           sync.Mutex
+	blockedUntil time.Time // Requests fail fast until then after a block
 }
 
 // NewGoogleFreeTranslator creates a new Google Free Translator
@@ -36,10 +54,20 @@ func NewGoogleFreeTranslatorWithDB(db DBInterface) *GoogleFreeTranslator {
 }
 
 func (t *GoogleFreeTranslator) Translate(text, targetLang string) (string, error) {
+	return t.TranslateFrom(text, "", targetLang)
+}
+
+// TranslateFrom translates text from sourceLang, or auto-detects it when empty.
+func (t *GoogleFreeTranslator) TranslateFrom(text, sourceLang, targetLang string) (string, error) {
 	if text == "" {
 		return "", nil
 	}
 
+	// Back off while Google is blocking us instead of hammering it
+	if remaining := t.blockRemaining(); remaining > 0 {
+		return "", fmt.Errorf("%w, retry in %s", ErrGoogleBlocked, remaining.Round(time.Second))
+	}
+
 	// Get the configured endpoint, default to translate.googleapis.com
 	endpoint := "translate.googleapis.com"
 	if t.db != nil {
@@ -68,7 +96,12 @@ func (t *GoogleFreeTranslator) Translate(text, targetLang string) (string, error
 
 	q := u.Query()
 	q.Set("client", clientParam)
-	q.Set("sl", "auto")
+	if sourceLang == "" {
+		sourceLang = "auto"
+	} else if sourceLang == "zh" {
+		sourceLang = "zh-CN"
+	}
+	q.Set("sl", sourceLang)
 	// Map zh-TW to zh-TW for Google Translate
 	// Google Translate uses "zh-TW" for Traditional Chinese and "zh-CN" for Simplified
 	googleLang := targetLang
@@ -86,14 +119,24 @@ func (t *GoogleFreeTranslator) Translate(text, targetLang string) (string, error
 	}
 	defer resp.Body.Close()
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if isGoogleBlockResponse(resp.StatusCode, resp.Header.Get("Content-Type"), body) {
+		t.markBlocked(resp.StatusCode)
+		return "", ErrGoogleBlocked
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("translation api returned status: %d", resp.StatusCode)
+		return "", newStatusError(resp, fmt.Sprintf("translation api returned status: %d", resp.StatusCode))
 	}
 
 	// The response is a complex nested array structure
 	// [[[ "translated", "original", ... ]], ...]
 	var result []interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+	if err := json.Unmarshal(body, &result); err != nil {
 		return "", err
 	}
 
@@ -115,3 +158,40 @@ func (t *GoogleFreeTranslator) Translate(text, targetLang string) (string, error
 
 	return "", fmt.Errorf("invalid response format")
 }
+
+// blockRemaining returns how long requests are still held back after a block
+func (t *GoogleFreeTranslator) blockRemaining() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return time.Until(t.blockedUntil)
+}
+
+// markBlocked starts the block cooldown
+func (t *GoogleFreeTranslator) markBlocked(status int) {
+	t.mu.Lock()
+	t.blockedUntil = time.Now().Add(googleBlockCooldown)
+	t.mu.Unlock()
+	log.Printf("Google Translate blocked the request (status %d), backing off for %s", status, googleBlockCooldown)
+}
+
+// isGoogleBlockResponse reports whether a response is Google refusing service (a 429,
+// an HTML page instead of JSON, or a CAPTCHA) rather than a translation or an ordinary error
+func isGoogleBlockResponse(status int, contentType string, body []byte) bool {
+	if status == http.StatusTooManyRequests {
+		return true
+	}
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && (trimmed[0] == '[' || trimmed[0] == '{') {
+		return false
+	}
+	if strings.Contains(contentType, "text/html") || bytes.HasPrefix(trimmed, []byte("<")) {
+		return true
+	}
+	lower := strings.ToLower(string(trimmed))
+	for _, marker := range googleBlockMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
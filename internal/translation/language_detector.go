@@ -1,6 +1,7 @@
 package translation
 
 import (
+	"regexp"
 	"strings"
 	"sync"
 	"unicode"
@@ -84,6 +85,12 @@ func (ld *LanguageDetector) DetectLanguage(text string) string {
 // - Detected language differs from target language
 // Returns false if text is already in target language
 func (ld *LanguageDetector) ShouldTranslate(text, targetLang string) bool {
+	// Nothing to translate in a bare link, address or version number, and detection
+	// would fail on it and send it to the provider anyway
+	if isMostlyUntranslatable(text) {
+		return false
+	}
+
 	detectedLang := ld.DetectLanguage(text)
 
 	// If detection failed, assume translation is needed (fallback behavior)
@@ -100,6 +107,54 @@ func (ld *LanguageDetector) ShouldTranslate(text, targetLang string) bool {
 	return detectedLang != targetLang
 }
 
+// untranslatableRatio is the share of non-whitespace characters in URLs, email addresses,
+// digits and punctuation above which text is left untranslated
+const untranslatableRatio = 0.8
+
+var (
+	urlPattern   = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+)
+
+// isMostlyUntranslatable reports whether text is overwhelmingly made of URLs, email
+// addresses, digits and punctuation, leaving no words worth translating. Empty text is not.
+func isMostlyUntranslatable(text string) bool {
+	text = removeHTMLTags(text)
+
+	untranslatable := 0
+	countMatch := func(match string) string {
+		untranslatable += nonSpaceRuneCount(match)
+		return " "
+	}
+	rest := urlPattern.ReplaceAllStringFunc(text, countMatch)
+	rest = emailPattern.ReplaceAllStringFunc(rest, countMatch)
+
+	total := untranslatable
+	for _, r := range rest {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		total++
+		if unicode.IsDigit(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			untranslatable++
+		}
+	}
+	if total == 0 {
+		return false
+	}
+	return float64(untranslatable)/float64(total) > untranslatableRatio
+}
+
+func nonSpaceRuneCount(s string) int {
+	n := 0
+	for _, r := range s {
+		if !unicode.IsSpace(r) {
+			n++
+		}
+	}
+	return n
+}
+
 // ShouldTranslateFullText analyzes the full text to determine if translation is needed
 // It samples multiple paragraphs and calculates the language ratio
 // Returns false if the target language accounts for more than 60% of the content
@@ -112,6 +167,9 @@ func (ld *LanguageDetector) ShouldTranslateFullText(text, targetLang string) boo
 	// Clean text and split into paragraphs
 	cleanText := removeHTMLTags(text)
 	cleanText = strings.TrimSpace(cleanText)
+	if isMostlyUntranslatable(cleanText) {
+		return false
+	}
 
 	// Split by common paragraph delimiters
 	paragraphs := splitIntoParagraphs(cleanText)
@@ -167,6 +225,18 @@ func (ld *LanguageDetector) ShouldTranslateFullText(text, targetLang string) boo
 	return true
 }
 
+// SplitIntoParagraphs splits text into non-empty paragraphs so long content can be
+// translated chunk by chunk
+func SplitIntoParagraphs(text string) []string {
+	var paragraphs []string
+	for _, p := range splitIntoParagraphs(text) {
+		if p != "" {
+			paragraphs = append(paragraphs, p)
+		}
+	}
+	return paragraphs
+}
+
 // splitIntoParagraphs splits text into paragraphs using common delimiters
 func splitIntoParagraphs(text string) []string {
 	// Replace multiple newlines with single delimiter
@@ -216,6 +286,9 @@ func supportedLanguages() []whatlanggo.Lang {
 		whatlanggo.Tha,
 		whatlanggo.Ind,
 		whatlanggo.Hin,
+		whatlanggo.Arb,
+		whatlanggo.Heb,
+		whatlanggo.Pes,
 	}
 }
 
@@ -239,6 +312,9 @@ func whatlangToISOCode(lang whatlanggo.Lang) string {
 		whatlanggo.Tha: "th",
 		whatlanggo.Ind: "id",
 		whatlanggo.Hin: "hi",
+		whatlanggo.Arb: "ar",
+		whatlanggo.Heb: "he",
+		whatlanggo.Pes: "fa",
 	}
 
 	if code, ok := langMap[lang]; ok {
@@ -247,6 +323,29 @@ func whatlangToISOCode(lang whatlanggo.Lang) string {
 	return ""
 }
 
+// rtlLanguages are the base language codes written right to left
+var rtlLanguages = map[string]bool{
+	"ar": true, "he": true, "fa": true, "ur": true, "yi": true, "ps": true, "sd": true, "ug": true, "dv": true,
+}
+
+// LanguageDirection returns the text direction of a language code: "rtl", "ltr", or
+// "auto" when the language is unknown so that the renderer decides from the text
+func LanguageDirection(lang string) string {
+	if lang == "" {
+		return "auto"
+	}
+	if rtlLanguages[normalizeLangCode(lang)] {
+		return "rtl"
+	}
+	return "ltr"
+}
+
+// SameLanguage reports whether two language codes refer to the same language,
+// ignoring region except for Traditional Chinese
+func SameLanguage(a, b string) bool {
+	return normalizeLangCode(a) == normalizeLangCode(b)
+}
+
 // normalizeLangCode normalizes language codes (e.g., "zh-CN" -> "zh", "zh-TW" -> "zh-TW", "en-US" -> "en")
 // Special handling for zh-TW to preserve the distinction
 func normalizeLangCode(code string) string {
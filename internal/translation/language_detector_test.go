@@ -37,6 +37,12 @@ func TestLanguageDetector_DetectLanguage(t *testing.T) {
 			wantLang: "ko",
 			wantOk:   true,
 		},
+		{
+			name:     "Arabic text",
+			text:     "هذه مقالة تجريبية عن التكنولوجيا والبرمجة في العالم العربي.",
+			wantLang: "ar",
+			wantOk:   true,
+		},
 		{
 			name:     "Spanish text",
 			text:     "Este es un artículo de prueba sobre tecnología y programación.",
@@ -162,6 +168,24 @@ func TestLanguageDetector_ShouldTranslate(t *testing.T) {
 			targetLang: "en-US",
 			wantShould: false,
 		},
+		{
+			name:       "Bare URL - should skip",
+			text:       "https://example.com/blog/2024/01/post?id=42",
+			targetLang: "zh",
+			wantShould: false,
+		},
+		{
+			name:       "Email address and date - should skip",
+			text:       "contact@example.com 2024-01-15",
+			targetLang: "zh",
+			wantShould: false,
+		},
+		{
+			name:       "Version number with words - should translate",
+			text:       "v2.3.1 release notes",
+			targetLang: "zh",
+			wantShould: true,
+		},
 		{
 			name:       "Normalized language codes (zh-CN to zh)",
 			text:       "这是一篇文章。",
@@ -251,6 +275,15 @@ func TestRemoveHTMLTags(t *testing.T) {
 	}
 }
 
+func TestLanguageDirection(t *testing.T) {
+	tests := map[string]string{"ar": "rtl", "he-IL": "rtl", "fa": "rtl", "en": "ltr", "zh-TW": "ltr", "": "auto"}
+	for lang, want := range tests {
+		if got := LanguageDirection(lang); got != want {
+			t.Errorf("LanguageDirection(%q) = %q, want %q", lang, got, want)
+		}
+	}
+}
+
 func TestDetectChineseVariant(t *testing.T) {
 	tests := []struct {
 		name string
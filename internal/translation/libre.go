@@ -0,0 +1,105 @@
+package translation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// LibreTranslator implements translation using a LibreTranslate server.
+type LibreTranslator struct {
+	Endpoint string
+	APIKey   string // Optional, only needed by servers that require one
+	client   *http.Client
+	db       DBInterface
+}
+
+// NewLibreTranslator creates a new LibreTranslate Translator
+func NewLibreTranslator(endpoint, apiKey string) *LibreTranslator {
+	return &LibreTranslator{
+		Endpoint: strings.TrimSuffix(endpoint, "/"),
+		APIKey:   apiKey,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// NewLibreTranslatorWithDB creates a LibreTranslate Translator with database for proxy support
+func NewLibreTranslatorWithDB(endpoint, apiKey string, db DBInterface) *LibreTranslator {
+	client, err := CreateHTTPClientWithProxy(db, 10*time.Second)
+	if err != nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &LibreTranslator{
+		Endpoint: strings.TrimSuffix(endpoint, "/"),
+		APIKey:   apiKey,
+		client:   client,
+		db:       db,
+	}
+}
+
+func (t *LibreTranslator) Translate(text, targetLang string) (string, error) {
+	return t.TranslateFrom(text, "", targetLang)
+}
+
+// TranslateFrom translates text from sourceLang, or lets LibreTranslate detect it when empty.
+// LibreTranslate API: POST /translate with JSON body {q, source, target, format, api_key}
+func (t *LibreTranslator) TranslateFrom(text, sourceLang, targetLang string) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	source := "auto"
+	if sourceLang != "" {
+		source = sourceLang
+	}
+	requestBody := map[string]string{
+		"q":      text,
+		"source": source,
+		"target": targetLang,
+		"format": "text",
+	}
+	if t.APIKey != "" {
+		requestBody["api_key"] = t.APIKey
+	}
+
+	jsonBody, err := json.Marshal(requestBody)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal libretranslate request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", t.Endpoint+"/translate", bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return "", fmt.Errorf("failed to create libretranslate request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("libretranslate request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Errors come back as {"error": "..."} with a non-200 status
+	var result struct {
+		TranslatedText string `json:"translatedText"`
+		Error          string `json:"error"`
+	}
+	decodeErr := json.NewDecoder(resp.Body).Decode(&result)
+
+	if resp.StatusCode != http.StatusOK {
+		if result.Error != "" {
+			return "", newStatusError(resp, fmt.Sprintf("libretranslate returned status %d: %s", resp.StatusCode, result.Error))
+		}
+		return "", newStatusError(resp, fmt.Sprintf("libretranslate returned status: %d", resp.StatusCode))
+	}
+	if decodeErr != nil {
+		return "", fmt.Errorf("failed to decode libretranslate response: %w", decodeErr)
+	}
+	if result.TranslatedText == "" {
+		return "", fmt.Errorf("no translation found from libretranslate")
+	}
+	return result.TranslatedText, nil
+}
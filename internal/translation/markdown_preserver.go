@@ -1,8 +1,27 @@
 package translation
 
 import (
+	"context"
+	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"MrRSS/internal/ai"
+)
+
+var (
+	// inlineCodePattern matches backtick-delimited code spans on a single line
+	inlineCodePattern = regexp.MustCompile("``[^`\n]+``|`[^`\n]+`")
+	// linkTargetPattern matches the "(url)" or "(url \"title\")" target that follows the
+	// text of a markdown link or image
+	linkTargetPattern = regexp.MustCompile(`\]\(([^()\s]+(?:\s+"[^"]*")?)\)`)
+	// placeholderPattern matches a placeholder, tolerating spaces a translator may insert
+	placeholderPattern = regexp.MustCompile(`\{\{\s*MD(\d+)\s*\}\}`)
+	// blockSeparatorPattern matches the blank lines between markdown blocks
+	blockSeparatorPattern = regexp.MustCompile(`\n[ \t]*\n\s*`)
 )
 
 // TranslateMarkdownPreservingStructure translates markdown while preserving list structure
@@ -10,11 +29,132 @@ import (
 // 1. Extracts list markers and indentation
 // 2. Translates only the content text
 // 3. Reassembles with proper structure
+// Inline code spans and link targets are swapped for placeholders while translating so
+// they come back byte-identical; link text is still translated.
 func TranslateMarkdownPreservingStructure(markdown string, translator Translator, targetLang string) (string, error) {
 	if markdown == "" {
 		return "", nil
 	}
 
+	protected, spans := protectMarkdownSpans(markdown)
+	translated, err := translateMarkdownStructure(protected, translator, targetLang)
+	if err != nil {
+		return "", err
+	}
+	return restoreMarkdownSpans(translated, spans), nil
+}
+
+// DefaultTranslationConcurrency is the number of blocks translated in parallel when none is configured
+const DefaultTranslationConcurrency = 4
+
+// TranslateMarkdownConcurrently translates markdown like TranslateMarkdownPreservingStructure,
+// but translates the blocks between blank lines in parallel with at most workers requests in
+// flight, reassembling them in their original order. Blocks that fail, e.g. because a
+// rate-limited provider rejected the burst, are retried one at a time before the translation
+// fails. AI translators, which rely on seeing the text in order, and a workers value below 2
+// translate sequentially instead.
+func TranslateMarkdownConcurrently(markdown string, translator Translator, targetLang string, workers int) (string, error) {
+	if workers < 2 || isAITranslator(translator) {
+		return TranslateMarkdownPreservingStructure(markdown, translator, targetLang)
+	}
+	if markdown == "" {
+		return "", nil
+	}
+
+	protected, spans := protectMarkdownSpans(markdown)
+	blocks := blockSeparatorPattern.Split(protected, -1)
+	if len(blocks) == 1 {
+		translated, err := translateMarkdownStructure(protected, translator, targetLang)
+		if err != nil {
+			return "", err
+		}
+		return restoreMarkdownSpans(translated, spans), nil
+	}
+	separators := blockSeparatorPattern.FindAllString(protected, -1)
+
+	translated := make([]string, len(blocks))
+	errs := make([]error, len(blocks))
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, block := range blocks {
+		if strings.TrimSpace(block) == "" {
+			translated[i] = block
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, block string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			translated[i], errs[i] = translateMarkdownStructure(block, translator, targetLang)
+		}(i, block)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		if translated[i], err = translateMarkdownStructure(blocks[i], translator, targetLang); err != nil {
+			return "", err
+		}
+	}
+
+	var result strings.Builder
+	for i, block := range translated {
+		if i > 0 {
+			result.WriteString(separators[i-1])
+		}
+		result.WriteString(block)
+	}
+	return restoreMarkdownSpans(result.String(), spans), nil
+}
+
+// isAITranslator reports whether translator is an AI translator, looking through a pinned
+// source language
+func isAITranslator(translator Translator) bool {
+	if pinned, ok := translator.(*sourceLanguageTranslator); ok {
+		translator = pinned.translator
+	}
+	_, ok := translator.(*AITranslator)
+	return ok
+}
+
+// protectMarkdownSpans replaces inline code spans and link targets with numbered
+// placeholders, returning the text and the replaced spans in placeholder order
+func protectMarkdownSpans(markdown string) (string, []string) {
+	var spans []string
+	placeholder := func(span string) string {
+		spans = append(spans, span)
+		return fmt.Sprintf("{{MD%d}}", len(spans)-1)
+	}
+
+	// Code first, so link syntax inside a code span is left alone
+	protected := inlineCodePattern.ReplaceAllStringFunc(markdown, placeholder)
+	protected = linkTargetPattern.ReplaceAllStringFunc(protected, func(target string) string {
+		// Keep the closing bracket of the link text in the text to translate
+		return "]" + placeholder(target[1:])
+	})
+	return protected, spans
+}
+
+// restoreMarkdownSpans puts the spans replaced by protectMarkdownSpans back in place
+func restoreMarkdownSpans(text string, spans []string) string {
+	if len(spans) == 0 {
+		return text
+	}
+	return placeholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+		index, err := strconv.Atoi(placeholderPattern.FindStringSubmatch(match)[1])
+		if err != nil || index >= len(spans) {
+			return match
+		}
+		return spans[index]
+	})
+}
+
+// translateMarkdownStructure translates markdown line by line when it contains lists, or
+// as a whole otherwise
+func translateMarkdownStructure(markdown string, translator Translator, targetLang string) (string, error) {
 	// Check if it contains list structures
 	if !containsListStructure(markdown) {
 		// No lists, translate directly
@@ -111,17 +251,144 @@ func translateLinePreservingStructure(line string, translator Translator, target
 	return line, nil
 }
 
-// TranslateMarkdownAIPrompt creates a specialized prompt for AI translation that preserves structure
-func TranslateMarkdownAIPrompt(markdown string, translator Translator, targetLang string) (string, error) {
+// DefaultAITranslationChunkChars is the chunk size used for long text when none is configured
+const DefaultAITranslationChunkChars = 4000
+
+// chunkContextChars bounds the excerpt of the previous chunk and its translation that is
+// passed along with the next chunk to keep names and terms consistent
+const chunkContextChars = 500
+
+// TranslateMarkdownAIPrompt creates a specialized prompt for AI translation that preserves
+// structure. It also returns the token usage the AI API reported.
+func TranslateMarkdownAIPrompt(ctx context.Context, markdown string, translator Translator, targetLang string) (string, ai.Usage, error) {
+	return translateMarkdownAI(ctx, markdown, translator, targetLang, DefaultAITranslationChunkChars, false)
+}
+
+// TranslateMarkdownAIPromptChunked translates markdown like TranslateMarkdownAIPrompt, but
+// splits text longer than chunkChars at paragraph boundaries and translates the chunks in
+// order so long articles fit in the model's context. Each chunk after the first is sent
+// with an excerpt of the previous one and its translation. A non-positive chunkChars sends
+// the whole text at once.
+func TranslateMarkdownAIPromptChunked(ctx context.Context, markdown string, translator Translator, targetLang string, chunkChars int) (string, ai.Usage, error) {
+	return translateMarkdownAI(ctx, markdown, translator, targetLang, chunkChars, true)
+}
+
+// translateMarkdownAI implements TranslateMarkdownAIPrompt and TranslateMarkdownAIPromptChunked.
+// With body set the text is an article body, and AI output is cleaned up as one. The
+// usage of all chunks is added up.
+func translateMarkdownAI(ctx context.Context, markdown string, translator Translator, targetLang string, chunkChars int, body bool) (string, ai.Usage, error) {
 	if markdown == "" {
-		return "", nil
+		return "", ai.Usage{}, nil
+	}
+
+	chunks := []markdownChunk{{text: markdown}}
+	if chunkChars > 0 {
+		chunks = chunkMarkdown(markdown, chunkChars)
+	}
+
+	var result strings.Builder
+	var usage ai.Usage
+	var prevSource, prevTranslation string
+	for _, chunk := range chunks {
+		translated, chunkUsage, err := translateMarkdownAIChunk(ctx, chunk.text, translator, targetLang, prevSource, prevTranslation, body)
+		if err != nil {
+			return "", ai.Usage{}, err
+		}
+		usage = usage.Add(chunkUsage)
+		result.WriteString(chunk.sep)
+		result.WriteString(translated)
+		prevSource, prevTranslation = chunk.text, translated
+	}
+	return result.String(), usage, nil
+}
+
+// markdownChunk is a piece of text to translate on its own and the separator that
+// preceded it in the original text
+type markdownChunk struct {
+	text string
+	sep  string
+}
+
+// chunkMarkdown groups the paragraphs of text into chunks of at most maxChars characters.
+// Paragraphs longer than that are split between lines; a single line longer than maxChars
+// becomes a chunk of its own. Lines keep their indentation so lists survive reassembly.
+func chunkMarkdown(text string, maxChars int) []markdownChunk {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	var units []markdownChunk
+	for _, block := range strings.Split(text, "\n\n") {
+		block = strings.Trim(block, "\n")
+		if strings.TrimSpace(block) == "" {
+			continue
+		}
+		sep := "\n\n"
+		if len(units) == 0 {
+			sep = ""
+		}
+		if utf8.RuneCountInString(block) <= maxChars {
+			units = append(units, markdownChunk{text: block, sep: sep})
+			continue
+		}
+		for i, line := range strings.Split(block, "\n") {
+			if i > 0 {
+				sep = "\n"
+			}
+			units = append(units, markdownChunk{text: line, sep: sep})
+		}
+	}
+
+	var chunks []markdownChunk
+	var current markdownChunk
+	size := 0
+	for _, unit := range units {
+		unitSize := utf8.RuneCountInString(unit.sep) + utf8.RuneCountInString(unit.text)
+		if current.text != "" && size+unitSize > maxChars {
+			chunks = append(chunks, current)
+			current, size = markdownChunk{}, 0
+		}
+		if current.text == "" {
+			current = unit
+			size = utf8.RuneCountInString(unit.text)
+			continue
+		}
+		current.text += unit.sep + unit.text
+		size += unitSize
+	}
+	if current.text != "" {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+// tailRunes returns the last n runes of s
+func tailRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[len(runes)-n:])
+}
+
+// translateMarkdownAIChunk translates one chunk with a structure-preserving prompt. When the
+// translator is an AI translator and prevTranslation is set, the end of the previous chunk
+// and its translation are added to the prompt for consistency.
+func translateMarkdownAIChunk(ctx context.Context, markdown string, translator Translator, targetLang, prevSource, prevTranslation string, body bool) (string, ai.Usage, error) {
+	if markdown == "" {
+		return "", ai.Usage{}, nil
+	}
+
+	// Look through a pinned source language so the AI path still applies
+	inner, sourceLang := translator, ""
+	if pinned, ok := translator.(*sourceLanguageTranslator); ok {
+		inner, sourceLang = pinned.translator, pinned.sourceLang
 	}
 
 	// For AI translation, use a specialized prompt that emphasizes structure preservation
-	aiTranslator, ok := translator.(*AITranslator)
+	aiTranslator, ok := inner.(*AITranslator)
 	if !ok {
 		// Not an AI translator, use standard preservation
-		return TranslateMarkdownPreservingStructure(markdown, translator, targetLang)
+		translated, err := TranslateMarkdownPreservingStructure(markdown, translator, targetLang)
+		return translated, ai.Usage{}, err
 	}
 
 	// Create a system prompt that emphasizes preserving markdown structure
@@ -144,14 +411,22 @@ Output (to Chinese):
 - 第一项
   - 嵌套项目
 - 第二项`
+	if prevTranslation != "" {
+		structurePrompt += "\n\nThe text continues an earlier part that was translated as below. Translate names and terms the same way, and do NOT repeat this excerpt in your output.\nEarlier source:\n" +
+			tailRunes(prevSource, chunkContextChars) + "\nEarlier translation:\n" + tailRunes(prevTranslation, chunkContextChars)
+	}
 
 	aiTranslator.SetSystemPrompt(structurePrompt)
 
 	// Translate
-	result, err := aiTranslator.Translate(markdown, targetLang)
+	translate := aiTranslator.TranslateWithUsage
+	if body {
+		translate = aiTranslator.translateBody
+	}
+	result, usage, err := translate(ctx, markdown, sourceLang, targetLang)
 
 	// Restore original prompt
 	aiTranslator.SetSystemPrompt(originalPrompt)
 
-	return result, err
+	return result, usage, err
 }
@@ -1,8 +1,17 @@
 package translation
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // MockTranslator for testing
@@ -258,6 +267,32 @@ func TestTranslateMarkdownPreservingStructure(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:     "Links and inline code in a list item",
+			markdown: "- See [the docs](https://example.com/docs?a=1&b=2) and [source](https://example.com/src \"Source code\") for `go test ./...`",
+			validate: func(t *testing.T, result string) {
+				for _, want := range []string{"(https://example.com/docs?a=1&b=2)", "(https://example.com/src \"Source code\")", "`go test ./...`"} {
+					if !strings.Contains(result, want) {
+						t.Errorf("Expected %q to survive translation byte-identical, got: %s", want, result)
+					}
+				}
+				if !strings.HasPrefix(result, "- See [译]") || !strings.Contains(result, "docs]") {
+					t.Errorf("Expected the list marker kept and link text translated, got: %s", result)
+				}
+				if strings.Contains(result, "{{MD") {
+					t.Errorf("Expected no placeholder left, got: %s", result)
+				}
+			},
+		},
+		{
+			name:     "Link syntax inside inline code",
+			markdown: "Write `[x](y)` for a link to [MrRSS](https://github.com/WCY-dt/MrRSS)",
+			validate: func(t *testing.T, result string) {
+				if !strings.Contains(result, "`[x](y)`") || !strings.Contains(result, "[MrRSS](https://github.com/WCY-dt/MrRSS)") {
+					t.Errorf("Expected code and link to survive translation, got: %s", result)
+				}
+			},
+		},
 		{
 			name:     "Plain text without lists",
 			markdown: "This is plain text\nWith multiple lines",
@@ -279,3 +314,185 @@ func TestTranslateMarkdownPreservingStructure(t *testing.T) {
 		})
 	}
 }
+
+func TestChunkMarkdown(t *testing.T) {
+	text := "First paragraph.\n\n- item one\n  - nested item\n- item two\n\n\nLast paragraph."
+
+	// Everything fits in one chunk, with blank-line runs collapsed
+	chunks := chunkMarkdown(text, 1000)
+	if len(chunks) != 1 || chunks[0].text != "First paragraph.\n\n- item one\n  - nested item\n- item two\n\nLast paragraph." {
+		t.Fatalf("unexpected single chunk: %#v", chunks)
+	}
+
+	// Paragraphs are kept whole when they fit, and an oversized list is split between lines
+	chunks = chunkMarkdown(text, 25)
+	want := []markdownChunk{
+		{text: "First paragraph.", sep: ""},
+		{text: "- item one", sep: "\n\n"},
+		{text: "  - nested item", sep: "\n"},
+		{text: "- item two", sep: "\n"},
+		{text: "Last paragraph.", sep: "\n\n"},
+	}
+	if len(chunks) != len(want) {
+		t.Fatalf("expected %d chunks, got %#v", len(want), chunks)
+	}
+	for i := range want {
+		if chunks[i] != want[i] {
+			t.Errorf("chunk %d = %#v, want %#v", i, chunks[i], want[i])
+		}
+	}
+}
+
+func TestTranslateMarkdownAIPromptChunked(t *testing.T) {
+	var calls []string
+	translator := &TestTranslator{TranslateFunc: func(text, targetLang string) (string, error) {
+		calls = append(calls, text)
+		return strings.ToUpper(text), nil
+	}}
+
+	text := strings.Repeat("a", 30) + "\n\n" + strings.Repeat("b", 30) + "\n\n" + strings.Repeat("c", 10)
+	result, _, err := TranslateMarkdownAIPromptChunked(context.Background(), text, translator, "en", 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != strings.ToUpper(text) {
+		t.Errorf("expected the structure to be reassembled, got %q", result)
+	}
+	if len(calls) != 2 || calls[0] != strings.Repeat("a", 30) {
+		t.Errorf("expected two sequential chunks, got %q", calls)
+	}
+
+	// Chunking disabled sends the whole text at once
+	calls = nil
+	if _, _, err := TranslateMarkdownAIPromptChunked(context.Background(), text, translator, "en", 0); err != nil || len(calls) != 1 {
+		t.Errorf("expected a single request, got %d (err %v)", len(calls), err)
+	}
+}
+
+func TestTranslateMarkdownAIPromptChunked_PassesPriorTranslation(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		// Only answer the OpenAI format so format detection settles on it
+		if !strings.Contains(string(body), `"messages"`) {
+			http.Error(w, "unsupported", http.StatusBadRequest)
+			return
+		}
+		bodies = append(bodies, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"choices":[{"message":{"content":"chunk %d"}}],"usage":{"prompt_tokens":10,"completion_tokens":2,"total_tokens":12}}`, len(bodies))
+	}))
+	defer server.Close()
+
+	translator := NewAITranslator("key", server.URL+"/v1/chat/completions", "m1")
+	text := strings.Repeat("a", 30) + "\n\n" + strings.Repeat("b", 30)
+	result, usage, err := TranslateMarkdownAIPromptChunked(context.Background(), text, translator, "fr", 40)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != "chunk 1\n\nchunk 2" {
+		t.Errorf("unexpected result %q", result)
+	}
+	if usage.Total() != 24 {
+		t.Errorf("expected the usage of both chunks to be added up, got %+v", usage)
+	}
+	if len(bodies) != 2 || strings.Contains(bodies[0], "Earlier translation") || !strings.Contains(bodies[1], "Earlier translation") {
+		t.Errorf("expected only the second request to carry the prior translation, got %q", bodies)
+	}
+	if translator.SystemPrompt != "" {
+		t.Errorf("expected the system prompt to be restored, got %q", translator.SystemPrompt)
+	}
+}
+
+func TestTranslateMarkdownConcurrently(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	trans := &TestTranslator{
+		TranslateFunc: func(text, targetLang string) (string, error) {
+			mu.Lock()
+			inFlight++
+			maxInFlight = max(maxInFlight, inFlight)
+			mu.Unlock()
+			time.Sleep(5 * time.Millisecond)
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+			return strings.ToUpper(text), nil
+		},
+	}
+
+	markdown := "First paragraph with `code`.\n\n- item one\n  - nested [link](https://example.com/a)\n\n\nThird paragraph\n\n1. ordered\n2. list\n\nLast one"
+	want := "FIRST PARAGRAPH WITH `code`.\n\n- ITEM ONE\n  - NESTED [LINK](https://example.com/a)\n\n\nTHIRD PARAGRAPH\n\n1. ORDERED\n2. LIST\n\nLAST ONE"
+
+	got, err := TranslateMarkdownConcurrently(markdown, trans, "en", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != want {
+		t.Errorf("got:\n%q\nwant:\n%q", got, want)
+	}
+	if maxInFlight > 2 {
+		t.Errorf("expected at most 2 concurrent requests, got %d", maxInFlight)
+	}
+	if maxInFlight < 2 {
+		t.Errorf("expected paragraphs to be translated in parallel, got %d at once", maxInFlight)
+	}
+
+	// A single worker matches the sequential translation
+	sequential, _ := TranslateMarkdownPreservingStructure(markdown, trans, "en")
+	if got, _ := TranslateMarkdownConcurrently(markdown, trans, "en", 1); got != sequential {
+		t.Errorf("expected one worker to translate sequentially, got %q", got)
+	}
+
+	failing := &TestTranslator{TranslateFunc: func(text, targetLang string) (string, error) {
+		if strings.Contains(text, "Third") {
+			return "", fmt.Errorf("boom")
+		}
+		return text, nil
+	}}
+	if _, err := TranslateMarkdownConcurrently(markdown, failing, "en", 4); err == nil {
+		t.Error("expected a failed paragraph to fail the translation")
+	}
+
+	// A paragraph rejected during the burst is retried on its own
+	var thirdCalls int32
+	flaky := &TestTranslator{TranslateFunc: func(text, targetLang string) (string, error) {
+		if strings.Contains(text, "Third") && atomic.AddInt32(&thirdCalls, 1) == 1 {
+			return "", fmt.Errorf("rate limited")
+		}
+		return strings.ToUpper(text), nil
+	}}
+	if got, err := TranslateMarkdownConcurrently(markdown, flaky, "en", 4); err != nil || got != want {
+		t.Errorf("expected the failed paragraph to be retried, got %q (%v)", got, err)
+	}
+
+	if !isAITranslator(WithSourceLanguage(NewAITranslator("key", "http://localhost", "m"), "en")) {
+		t.Error("expected a pinned AI translator to be recognized")
+	}
+}
+
+func TestTranslateMarkdownAIPromptChunked_KeepsNoteParagraphs(t *testing.T) {
+	translated := "Premier paragraphe.\n\nNote : la suite compte aussi.\n\nDernier paragraphe."
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"messages"`) {
+			http.Error(w, "unsupported", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"choices": []map[string]interface{}{{"message": map[string]string{"content": translated}}},
+		})
+	}))
+	defer server.Close()
+
+	translator := NewAITranslator("key", server.URL+"/v1/chat/completions", "m1")
+	text := "First paragraph.\n\nNote: the rest matters too.\n\nLast paragraph."
+	result, _, err := TranslateMarkdownAIPromptChunked(context.Background(), text, translator, "fr", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != translated {
+		t.Errorf("expected the whole article to be kept, got %q", result)
+	}
+}
@@ -0,0 +1,142 @@
+package translation
+
+import (
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultRetryAttempts is the number of times a transient translation failure is tried in total
+const DefaultRetryAttempts = 3
+
+// retryBaseDelay is the pause before the first retry; it doubles for each further attempt
+const retryBaseDelay = 500 * time.Millisecond
+
+// maxRetryAfter caps how long a provider's Retry-After is honored. A provider asking for a
+// longer wait fails the translation instead of stalling the caller.
+const maxRetryAfter = 30 * time.Second
+
+// StatusError reports a provider response with an unexpected HTTP status.
+type StatusError struct {
+	StatusCode int
+	// RetryAfter is the wait the provider asked for, zero when it didn't say
+	RetryAfter time.Duration
+	message    string
+}
+
+func (e *StatusError) Error() string {
+	return e.message
+}
+
+// newStatusError builds the error for a failed provider response, picking up its Retry-After
+func newStatusError(resp *http.Response, message string) *StatusError {
+	return &StatusError{
+		StatusCode: resp.StatusCode,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		message:    message,
+	}
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+// isRetryableError reports whether a translation failure is likely to go away on its own:
+// network errors, rate limiting and server errors. Other client errors such as rejected
+// credentials fail the same way again. A blocked Google Free provider has its own back-off.
+func isRetryableError(err error) bool {
+	if errors.Is(err, ErrGoogleBlocked) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// RetryingTranslator retries transient failures of a wrapped translator with exponential
+// backoff and jitter, waiting as long as the provider asks when it sends Retry-After.
+type RetryingTranslator struct {
+	translator Translator
+	attempts   int
+	baseDelay  time.Duration
+	sleep      func(time.Duration)
+}
+
+// NewRetryingTranslator wraps a translator so transient failures are retried
+func NewRetryingTranslator(translator Translator) *RetryingTranslator {
+	return &RetryingTranslator{
+		translator: translator,
+		attempts:   DefaultRetryAttempts,
+		baseDelay:  retryBaseDelay,
+		sleep:      time.Sleep,
+	}
+}
+
+// Translate translates text, retrying transient failures
+func (rt *RetryingTranslator) Translate(text, targetLang string) (string, error) {
+	return rt.TranslateFrom(text, "", targetLang)
+}
+
+// TranslateFrom translates text from an explicit source language, retrying transient failures
+func (rt *RetryingTranslator) TranslateFrom(text, sourceLang, targetLang string) (string, error) {
+	var lastErr error
+	for attempt := 0; attempt < rt.attempts; attempt++ {
+		if attempt > 0 {
+			delay, ok := rt.retryDelay(attempt, lastErr)
+			if !ok {
+				break
+			}
+			rt.sleep(delay)
+		}
+
+		result, err := TranslateFrom(rt.translator, text, sourceLang, targetLang)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+		if !isRetryableError(err) {
+			break
+		}
+	}
+	return "", lastErr
+}
+
+// retryDelay returns the wait before the given retry attempt, and false when the
+// provider asked for a longer wait than is worth blocking on
+func (rt *RetryingTranslator) retryDelay(attempt int, lastErr error) (time.Duration, bool) {
+	var statusErr *StatusError
+	if errors.As(lastErr, &statusErr) && statusErr.RetryAfter > 0 {
+		return statusErr.RetryAfter, statusErr.RetryAfter <= maxRetryAfter
+	}
+
+	delay := rt.baseDelay << (attempt - 1)
+	if delay > 0 {
+		delay += time.Duration(rand.Int63n(int64(delay)/2 + 1))
+	}
+	return delay, true
+}
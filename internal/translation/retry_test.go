@@ -0,0 +1,163 @@
+package translation
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// flakyTranslator fails with the queued errors before succeeding
+type flakyTranslator struct {
+	errs  []error
+	calls int
+}
+
+func (f *flakyTranslator) Translate(text, targetLang string) (string, error) {
+	f.calls++
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		return "", err
+	}
+	return "translated " + text, nil
+}
+
+func newTestRetryingTranslator(inner Translator) (*RetryingTranslator, *[]time.Duration) {
+	var delays []time.Duration
+	rt := NewRetryingTranslator(inner)
+	rt.sleep = func(d time.Duration) { delays = append(delays, d) }
+	return rt, &delays
+}
+
+func TestRetryingTranslator_RetriesTransientFailures(t *testing.T) {
+	inner := &flakyTranslator{errs: []error{
+		&StatusError{StatusCode: http.StatusServiceUnavailable, message: "unavailable"},
+		fmt.Errorf("request failed: %w", &netTimeoutError{}),
+	}}
+	rt, delays := newTestRetryingTranslator(inner)
+
+	got, err := rt.Translate("hello", "zh")
+	if err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if got != "translated hello" || inner.calls != 3 {
+		t.Errorf("expected a result on the third call, got %q after %d calls", got, inner.calls)
+	}
+	if len(*delays) != 2 {
+		t.Fatalf("expected 2 waits, got %v", *delays)
+	}
+	// Exponential backoff with up to 50% jitter
+	if d := (*delays)[0]; d < retryBaseDelay || d > retryBaseDelay*3/2 {
+		t.Errorf("first wait %v outside [%v, %v]", d, retryBaseDelay, retryBaseDelay*3/2)
+	}
+	if d := (*delays)[1]; d < 2*retryBaseDelay || d > 3*retryBaseDelay {
+		t.Errorf("second wait %v outside [%v, %v]", d, 2*retryBaseDelay, 3*retryBaseDelay)
+	}
+}
+
+func TestRetryingTranslator_GivesUpAfterAttempts(t *testing.T) {
+	inner := &flakyTranslator{errs: []error{
+		&StatusError{StatusCode: 500, message: "a"},
+		&StatusError{StatusCode: 502, message: "b"},
+		&StatusError{StatusCode: 503, message: "c"},
+	}}
+	rt, _ := newTestRetryingTranslator(inner)
+
+	if _, err := rt.Translate("hello", "zh"); err == nil || err.Error() != "c" {
+		t.Fatalf("expected the last error, got %v", err)
+	}
+	if inner.calls != DefaultRetryAttempts {
+		t.Errorf("expected %d calls, got %d", DefaultRetryAttempts, inner.calls)
+	}
+}
+
+func TestRetryingTranslator_DoesNotRetryPermanentFailures(t *testing.T) {
+	for _, err := range []error{
+		&StatusError{StatusCode: http.StatusForbidden, message: "forbidden"},
+		ErrGoogleBlocked,
+		errors.New("no translation found"),
+	} {
+		inner := &flakyTranslator{errs: []error{err}}
+		rt, delays := newTestRetryingTranslator(inner)
+		if _, got := rt.Translate("hello", "zh"); got != err {
+			t.Errorf("expected %v to be returned as is, got %v", err, got)
+		}
+		if inner.calls != 1 || len(*delays) != 0 {
+			t.Errorf("expected %v not to be retried, got %d calls", err, inner.calls)
+		}
+	}
+}
+
+func TestRetryingTranslator_HonorsRetryAfter(t *testing.T) {
+	inner := &flakyTranslator{errs: []error{
+		&StatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: 3 * time.Second, message: "slow down"},
+	}}
+	rt, delays := newTestRetryingTranslator(inner)
+	if _, err := rt.Translate("hello", "zh"); err != nil {
+		t.Fatalf("expected success after waiting, got %v", err)
+	}
+	if len(*delays) != 1 || (*delays)[0] != 3*time.Second {
+		t.Errorf("expected to wait the requested 3s, got %v", *delays)
+	}
+
+	// A wait longer than the cap fails instead of blocking
+	inner = &flakyTranslator{errs: []error{
+		&StatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Hour, message: "come back later"},
+	}}
+	rt, delays = newTestRetryingTranslator(inner)
+	if _, err := rt.Translate("hello", "zh"); err == nil {
+		t.Fatal("expected an error when the provider asks for a long wait")
+	}
+	if inner.calls != 1 || len(*delays) != 0 {
+		t.Errorf("expected no retry, got %d calls and waits %v", inner.calls, *delays)
+	}
+}
+
+func TestDeepL_StatusErrorCarriesRetryAfter(t *testing.T) {
+	tr := NewDeepLTranslator("key")
+	tr.client = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		h := make(http.Header)
+		h.Set("Retry-After", "7")
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Body: io.NopCloser(strings.NewReader("")), Header: h}, nil
+	})}
+
+	_, err := tr.Translate("hello", "en")
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("expected a StatusError, got %v", err)
+	}
+	if statusErr.StatusCode != http.StatusTooManyRequests || statusErr.RetryAfter != 7*time.Second {
+		t.Errorf("unexpected status error %+v", statusErr)
+	}
+	if err.Error() != "deepl api returned status: 429" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cases := map[string]time.Duration{
+		"":                              0,
+		"5":                             5 * time.Second,
+		"-1":                            0,
+		"soon":                          0,
+		"Mon, 01 Jan 2024 12:00:10 GMT": 10 * time.Second,
+		"Mon, 01 Jan 2024 11:00:00 GMT": 0,
+	}
+	for value, want := range cases {
+		if got := parseRetryAfter(value, now); got != want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+// netTimeoutError is a network timeout as returned by an HTTP client
+type netTimeoutError struct{}
+
+func (netTimeoutError) Error() string   { return "i/o timeout" }
+func (netTimeoutError) Timeout() bool   { return true }
+func (netTimeoutError) Temporary() bool { return true }
@@ -2,8 +2,10 @@ package translation
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
@@ -93,3 +95,73 @@ func TestAI_Non200AndTimeout(t *testing.T) {
 		t.Fatalf("expected timeout error")
 	}
 }
+
+func TestGoogle_BlockDetectionAndBackoff(t *testing.T) {
+	g := NewGoogleFreeTranslator()
+	calls := 0
+	g.client = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		body := `<html><body>Our systems have detected unusual traffic. Please solve the CAPTCHA.</body></html>`
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body)), Header: http.Header{"Content-Type": {"text/html"}}}, nil
+	}), Timeout: 2 * time.Second}
+
+	if _, err := g.Translate("hello", "es"); !errors.Is(err, ErrGoogleBlocked) {
+		t.Fatalf("expected ErrGoogleBlocked for a CAPTCHA page, got %v", err)
+	}
+
+	// While backing off no request reaches Google
+	if _, err := g.Translate("hello", "es"); !errors.Is(err, ErrGoogleBlocked) {
+		t.Fatalf("expected ErrGoogleBlocked during back-off, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 request during back-off, got %d", calls)
+	}
+
+	tests := []struct {
+		status      int
+		contentType string
+		body        string
+		blocked     bool
+	}{
+		{http.StatusTooManyRequests, "application/json", `{}`, true},
+		{http.StatusOK, "", `<!DOCTYPE html><html></html>`, true},
+		{http.StatusOK, "application/json", `[[["hola","hello"]]]`, false},
+		{http.StatusInternalServerError, "text/plain", "internal error", false},
+	}
+	for _, tt := range tests {
+		if got := isGoogleBlockResponse(tt.status, tt.contentType, []byte(tt.body)); got != tt.blocked {
+			t.Errorf("isGoogleBlockResponse(%d, %q, %q) = %v, want %v", tt.status, tt.contentType, tt.body, got, tt.blocked)
+		}
+	}
+}
+
+func TestDynamicTranslator_FallsBackWhenGoogleBlocked(t *testing.T) {
+	deeplx := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"code":200,"data":"Hola"}`)
+	}))
+	defer deeplx.Close()
+
+	settings := &mockSettingsProvider{settings: map[string]string{"translation_provider": "google"}}
+	d := NewDynamicTranslator(settings)
+
+	blocked := NewGoogleFreeTranslator()
+	blocked.blockedUntil = time.Now().Add(time.Minute)
+	d.cachedTranslator = blocked
+	d.cachedProvider = "google"
+
+	// Without a fallback the block is reported to the caller
+	if _, err := d.Translate("Hello", "es"); !errors.Is(err, ErrGoogleBlocked) {
+		t.Fatalf("expected ErrGoogleBlocked without a fallback, got %v", err)
+	}
+
+	settings.settings["google_translate_fallback_provider"] = "deepl"
+	settings.settings["deepl_endpoint"] = deeplx.URL
+	out, err := d.Translate("Hello", "es")
+	if err != nil {
+		t.Fatalf("expected fallback translation, got error %v", err)
+	}
+	if out != "Hola" {
+		t.Fatalf("expected Hola from the fallback, got %q", out)
+	}
+}
@@ -134,3 +134,146 @@ func TestAITranslate_AutoDetectOllama(t *testing.T) {
 		t.Fatalf("expected 1 API call (Ollama format should succeed on first try), got %d", callCount)
 	}
 }
+
+func TestLibreTranslate_SuccessAndEmpty(t *testing.T) {
+	t1 := NewLibreTranslator("http://libre.local/", "key")
+
+	out, err := t1.Translate("", "es")
+	if err != nil || out != "" {
+		t.Fatalf("expected empty translate for empty input, got %q err=%v", out, err)
+	}
+
+	var gotURL string
+	var gotBody map[string]string
+	t1.client = &http.Client{Transport: rtFunc(func(req *http.Request) (*http.Response, error) {
+		gotURL = req.URL.String()
+		json.NewDecoder(req.Body).Decode(&gotBody)
+		body := `{"translatedText":"Hola"}`
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body)), Header: http.Header{"Content-Type": {"application/json"}}}, nil
+	}), Timeout: 5 * time.Second}
+
+	out2, err := t1.Translate("Hello", "es")
+	if err != nil {
+		t.Fatalf("LibreTranslate translate failed: %v", err)
+	}
+	if out2 != "Hola" {
+		t.Fatalf("expected Hola, got %s", out2)
+	}
+	if gotURL != "http://libre.local/translate" {
+		t.Errorf("unexpected request URL %s", gotURL)
+	}
+	want := map[string]string{"q": "Hello", "source": "auto", "target": "es", "format": "text", "api_key": "key"}
+	for k, v := range want {
+		if gotBody[k] != v {
+			t.Errorf("request %s = %q, want %q", k, gotBody[k], v)
+		}
+	}
+
+	// Server errors are surfaced with their message
+	t1.client = &http.Client{Transport: rtFunc(func(req *http.Request) (*http.Response, error) {
+		body := `{"error":"Invalid API key"}`
+		return &http.Response{StatusCode: 403, Body: io.NopCloser(strings.NewReader(body)), Header: http.Header{"Content-Type": {"application/json"}}}, nil
+	}), Timeout: 5 * time.Second}
+	if _, err := t1.Translate("Hello", "es"); err == nil || !strings.Contains(err.Error(), "Invalid API key") {
+		t.Errorf("expected the server error to be reported, got %v", err)
+	}
+}
+
+func TestDynamicTranslator_Libre(t *testing.T) {
+	settings := &mockSettingsProvider{settings: map[string]string{"translation_provider": "libre"}}
+	d := NewDynamicTranslator(settings)
+	if _, _, err := d.getTranslatorWithProvider(); err == nil {
+		t.Fatal("expected an error without a LibreTranslate endpoint")
+	}
+
+	settings.settings["libre_endpoint"] = "http://libre.local"
+	translator, provider, err := d.getTranslatorWithProvider()
+	if err != nil {
+		t.Fatalf("getTranslatorWithProvider error: %v", err)
+	}
+	if _, ok := translator.(*LibreTranslator); !ok || provider != "libre" {
+		t.Errorf("expected a LibreTranslator, got %T (%s)", translator, provider)
+	}
+}
+
+func TestAzureTranslate_SuccessAndError(t *testing.T) {
+	t1 := NewAzureTranslator("key", "eastus")
+
+	out, err := t1.Translate("", "es")
+	if err != nil || out != "" {
+		t.Fatalf("expected empty translate for empty input, got %q err=%v", out, err)
+	}
+
+	var gotReq *http.Request
+	var gotBody []map[string]string
+	t1.client = &http.Client{Transport: rtFunc(func(req *http.Request) (*http.Response, error) {
+		gotReq = req
+		json.NewDecoder(req.Body).Decode(&gotBody)
+		body := `[{"detectedLanguage":{"language":"en","score":1.0},"translations":[{"text":"Hola","to":"es"}]}]`
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body)), Header: http.Header{"Content-Type": {"application/json"}}}, nil
+	}), Timeout: 5 * time.Second}
+
+	out2, err := t1.Translate("Hello", "es")
+	if err != nil {
+		t.Fatalf("Azure translate failed: %v", err)
+	}
+	if out2 != "Hola" {
+		t.Fatalf("expected Hola, got %s", out2)
+	}
+	if gotReq.URL.String() != "https://api.cognitive.microsofttranslator.com/translate?api-version=3.0&to=es" {
+		t.Errorf("unexpected request URL %s", gotReq.URL)
+	}
+	if gotReq.Header.Get("Ocp-Apim-Subscription-Key") != "key" || gotReq.Header.Get("Ocp-Apim-Subscription-Region") != "eastus" {
+		t.Errorf("unexpected auth headers %v", gotReq.Header)
+	}
+	if len(gotBody) != 1 || gotBody[0]["Text"] != "Hello" {
+		t.Errorf("unexpected request body %v", gotBody)
+	}
+
+	// An explicit source language is passed as from
+	if _, err := t1.TranslateFrom("Hello", "en", "es"); err != nil {
+		t.Fatalf("Azure translate with source failed: %v", err)
+	}
+	if gotReq.URL.Query().Get("from") != "en" {
+		t.Errorf("expected from=en, got %s", gotReq.URL.RawQuery)
+	}
+
+	// Chinese codes are sent in Azure's script-tagged form
+	if _, err := t1.TranslateFrom("你好", "zh-TW", "zh"); err != nil {
+		t.Fatalf("Azure translate from Chinese failed: %v", err)
+	}
+	if q := gotReq.URL.Query(); q.Get("from") != "zh-Hant" || q.Get("to") != "zh-Hans" {
+		t.Errorf("expected from=zh-Hant&to=zh-Hans, got %s", gotReq.URL.RawQuery)
+	}
+
+	// Server errors are surfaced with their message
+	t1.client = &http.Client{Transport: rtFunc(func(req *http.Request) (*http.Response, error) {
+		body := `{"error":{"code":401000,"message":"The request is not authorized because credentials are missing or invalid."}}`
+		return &http.Response{StatusCode: 401, Body: io.NopCloser(strings.NewReader(body)), Header: http.Header{"Content-Type": {"application/json"}}}, nil
+	}), Timeout: 5 * time.Second}
+	if _, err := t1.Translate("Hello", "es"); err == nil || !strings.Contains(err.Error(), "credentials are missing") {
+		t.Errorf("expected the server error to be reported, got %v", err)
+	}
+}
+
+func TestDynamicTranslator_Azure(t *testing.T) {
+	settings := &mockSettingsProvider{settings: map[string]string{"translation_provider": "azure"}}
+	d := NewDynamicTranslator(settings)
+	if _, _, err := d.getTranslatorWithProvider(); err == nil {
+		t.Fatal("expected an error without an Azure Translator key")
+	}
+
+	settings.settings["azure_translator_key"] = "key"
+	settings.settings["azure_translator_region"] = "westeurope"
+	translator, provider, err := d.getTranslatorWithProvider()
+	if err != nil {
+		t.Fatalf("getTranslatorWithProvider error: %v", err)
+	}
+	azure, ok := translator.(*AzureTranslator)
+	if !ok || provider != "azure" {
+		t.Fatalf("expected an AzureTranslator, got %T (%s)", translator, provider)
+	}
+	if azure.APIKey != "key" || azure.Region != "westeurope" {
+		t.Errorf("unexpected translator settings %+v", azure)
+	}
+}
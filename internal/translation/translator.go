@@ -1,7 +1,9 @@
 package translation
 
 import (
+	"MrRSS/internal/ai"
 	"MrRSS/internal/utils"
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
@@ -13,6 +15,65 @@ type Translator interface {
 	Translate(text, targetLang string) (string, error)
 }
 
+// SourceLanguageTranslator is implemented by translators that can be told the source
+// language instead of detecting it. An empty sourceLang means auto-detect.
+type SourceLanguageTranslator interface {
+	TranslateFrom(text, sourceLang, targetLang string) (string, error)
+}
+
+// TranslateFrom translates text from an explicit source language when the translator
+// supports it, and falls back to the translator's own detection otherwise.
+func TranslateFrom(translator Translator, text, sourceLang, targetLang string) (string, error) {
+	if sourceLang != "" {
+		if st, ok := translator.(SourceLanguageTranslator); ok {
+			return st.TranslateFrom(text, sourceLang, targetLang)
+		}
+	}
+	return translator.Translate(text, targetLang)
+}
+
+// UsageTranslator is implemented by translators that can return the token usage the AI
+// API reported for a translation. The translation runs in ctx, usually the context of the
+// request it is made for. An empty sourceLang means auto-detect.
+type UsageTranslator interface {
+	TranslateWithUsage(ctx context.Context, text, sourceLang, targetLang string) (string, ai.Usage, error)
+}
+
+// TranslateWithUsage translates like TranslateFrom and also returns the reported token
+// usage, which is zero when the translator isn't AI-backed or the API reported none.
+// Translators that don't implement UsageTranslator ignore ctx.
+func TranslateWithUsage(ctx context.Context, translator Translator, text, sourceLang, targetLang string) (string, ai.Usage, error) {
+	if ut, ok := translator.(UsageTranslator); ok {
+		return ut.TranslateWithUsage(ctx, text, sourceLang, targetLang)
+	}
+	translated, err := TranslateFrom(translator, text, sourceLang, targetLang)
+	return translated, ai.Usage{}, err
+}
+
+// sourceLanguageTranslator pins the source language of a wrapped translator
+type sourceLanguageTranslator struct {
+	translator Translator
+	sourceLang string
+}
+
+// WithSourceLanguage returns a translator that always translates from sourceLang.
+// It returns the translator unchanged when sourceLang is empty.
+func WithSourceLanguage(translator Translator, sourceLang string) Translator {
+	if sourceLang == "" {
+		return translator
+	}
+	return &sourceLanguageTranslator{translator: translator, sourceLang: sourceLang}
+}
+
+func (t *sourceLanguageTranslator) Translate(text, targetLang string) (string, error) {
+	return TranslateFrom(t.translator, text, t.sourceLang, targetLang)
+}
+
+// TranslateWithUsage translates from the pinned source language, ignoring sourceLang
+func (t *sourceLanguageTranslator) TranslateWithUsage(ctx context.Context, text, _, targetLang string) (string, ai.Usage, error) {
+	return TranslateWithUsage(ctx, t.translator, text, t.sourceLang, targetLang)
+}
+
 // DBInterface defines the minimal database interface needed for proxy settings
 type DBInterface interface {
 	GetSetting(key string) (string, error)
@@ -95,7 +95,9 @@ func TestGetLanguageName(t *testing.T) {
 		{"en", "English"},
 		{"zh", "Simplified Chinese"},
 		{"zh-TW", "Traditional Chinese"},
+		{"zh-tw", "Traditional Chinese"},
 		{"ja", "Japanese"},
+		{"vi", "Vietnamese"},
 		{"unknown", "unknown"},
 	}
 
@@ -107,6 +109,22 @@ func TestGetLanguageName(t *testing.T) {
 	}
 }
 
+func TestGetLanguageName_CoversDetectedLanguages(t *testing.T) {
+	codes := []string{normalizeLangCode("zh-TW")}
+	for _, lang := range supportedLanguages() {
+		codes = append(codes, whatlangToISOCode(lang))
+	}
+	for _, code := range codes {
+		if code == "" {
+			t.Error("detector language without an ISO code")
+			continue
+		}
+		if name := getLanguageName(code); name == code {
+			t.Errorf("getLanguageName(%q) has no English name", code)
+		}
+	}
+}
+
 // mockSettingsProvider implements SettingsProvider for testing
 type mockSettingsProvider struct {
 	settings map[string]string
@@ -218,3 +236,37 @@ func TestCreateHTTPClientWithProxy_EnabledAndDisabled(t *testing.T) {
 		t.Fatalf("expected proxy to be configured when enabled")
 	}
 }
+
+// sourceRecordingTranslator records the source language it was asked to translate from
+type sourceRecordingTranslator struct {
+	sourceLang string
+}
+
+func (s *sourceRecordingTranslator) Translate(text, targetLang string) (string, error) {
+	return s.TranslateFrom(text, "", targetLang)
+}
+
+func (s *sourceRecordingTranslator) TranslateFrom(text, sourceLang, targetLang string) (string, error) {
+	s.sourceLang = sourceLang
+	return text, nil
+}
+
+func TestWithSourceLanguage(t *testing.T) {
+	inner := &sourceRecordingTranslator{}
+	if _, err := WithSourceLanguage(inner, "ja").Translate("text", "en"); err != nil {
+		t.Fatalf("Translate failed: %v", err)
+	}
+	if inner.sourceLang != "ja" {
+		t.Errorf("expected source language ja to be forwarded, got %q", inner.sourceLang)
+	}
+
+	if WithSourceLanguage(inner, "") != Translator(inner) {
+		t.Error("expected an empty source language to leave the translator unchanged")
+	}
+
+	// Translators without source support fall back to their own detection
+	result, err := WithSourceLanguage(NewMockTranslator(), "ja").Translate("text", "en")
+	if err != nil || result != "[EN] text" {
+		t.Errorf("expected fallback to plain Translate, got %q, %v", result, err)
+	}
+}
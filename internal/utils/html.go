@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"net/url"
 	"regexp"
 	"strings"
 )
@@ -36,6 +37,15 @@ var (
 
 	// Matches <script> tags and their content
 	scriptTagRegex = regexp.MustCompile(`(?i)<script[^>]*>.*?</script>`)
+
+	// Matches opening <a> tags
+	anchorTagRegex = regexp.MustCompile(`(?i)<a\s[^>]*>`)
+
+	// Matches the href attribute of a tag (double or single quotes)
+	hrefAttrRegex = regexp.MustCompile(`(?i)\shref\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+
+	// Matches existing target and rel attributes of a tag
+	targetRelAttrRegex = regexp.MustCompile(`(?i)\s(?:target|rel)\s*=\s*(?:"[^"]*"|'[^']*'|[^\s>]+)`)
 )
 
 // CleanHTML sanitizes HTML content by fixing common malformed patterns
@@ -77,3 +87,49 @@ func CleanHTML(html string) string {
 
 	return html
 }
+
+// MarkExternalLinks adds target="_blank" rel="noopener noreferrer" to links whose
+// host differs from the article's host, so external links open outside the reader.
+// Relative links and links to the article's own host are left untouched.
+func MarkExternalLinks(html, articleURL string) string {
+	if html == "" {
+		return html
+	}
+
+	articleHost := ""
+	if u, err := url.Parse(articleURL); err == nil {
+		articleHost = normalizeLinkHost(u.Hostname())
+	}
+
+	return anchorTagRegex.ReplaceAllStringFunc(html, func(tag string) string {
+		m := hrefAttrRegex.FindStringSubmatch(tag)
+		if m == nil {
+			return tag
+		}
+		href := m[1]
+		if href == "" {
+			href = m[2]
+		}
+
+		u, err := url.Parse(strings.TrimSpace(href))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return tag
+		}
+		if normalizeLinkHost(u.Hostname()) == articleHost {
+			return tag
+		}
+
+		tag = targetRelAttrRegex.ReplaceAllString(tag, "")
+		closing := ">"
+		if strings.HasSuffix(tag, "/>") {
+			closing = "/>"
+		}
+		tag = strings.TrimRight(strings.TrimSuffix(tag, closing), " ")
+		return tag + ` target="_blank" rel="noopener noreferrer"` + closing
+	})
+}
+
+// normalizeLinkHost lowercases a host and strips a leading "www."
+func normalizeLinkHost(host string) string {
+	return strings.TrimPrefix(strings.ToLower(host), "www.")
+}
@@ -215,3 +215,46 @@ func TestCleanHTML_RemovesInlineStyles(t *testing.T) {
 		})
 	}
 }
+
+func TestMarkExternalLinks(t *testing.T) {
+	articleURL := "https://www.example.com/posts/1"
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "external link",
+			input:    `<a href="https://other.org/page">x</a>`,
+			expected: `<a href="https://other.org/page" target="_blank" rel="noopener noreferrer">x</a>`,
+		},
+		{
+			name:     "same host link",
+			input:    `<a href="https://example.com/posts/2">x</a>`,
+			expected: `<a href="https://example.com/posts/2">x</a>`,
+		},
+		{
+			name:     "relative link",
+			input:    `<a href="/posts/2">x</a>`,
+			expected: `<a href="/posts/2">x</a>`,
+		},
+		{
+			name:     "existing target replaced",
+			input:    `<a target="_self" href='https://other.org/' rel="nofollow">x</a>`,
+			expected: `<a href='https://other.org/' target="_blank" rel="noopener noreferrer">x</a>`,
+		},
+		{
+			name:     "mailto link",
+			input:    `<a href="mailto:me@other.org">x</a>`,
+			expected: `<a href="mailto:me@other.org">x</a>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MarkExternalLinks(tt.input, articleURL); got != tt.expected {
+				t.Errorf("MarkExternalLinks() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
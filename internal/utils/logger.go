@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"log"
 	"os"
 )
@@ -12,3 +15,40 @@ func DebugLog(format string, args ...interface{}) {
 		log.Printf(format, args...)
 	}
 }
+
+// RequestIDHeader is the header used to receive and return request correlation IDs
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// NewRequestID generates a short random correlation ID
+func NewRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// WithRequestID returns a copy of ctx carrying the given correlation ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the correlation ID stored in ctx, or an empty string
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// ContextLog logs like log.Printf, prefixing the message with the request ID from ctx if present
+func ContextLog(ctx context.Context, format string, args ...interface{}) {
+	if id := RequestIDFromContext(ctx); id != "" {
+		log.Printf("[req=%s] "+format, append([]interface{}{id}, args...)...)
+		return
+	}
+	log.Printf(format, args...)
+}
@@ -1,10 +1,8 @@
 package utils
 
 import (
-	"crypto/tls"
 	"fmt"
 	"net/http"
-	"net/url"
 	"time"
 )
 
@@ -30,29 +28,9 @@ func BuildProxyURL(proxyType, proxyHost, proxyPort, username, password string) s
 // CreateHTTPClient creates an HTTP client with optional proxy support
 // This is the canonical implementation with proper TLS config and connection pooling
 func CreateHTTPClient(proxyURL string, timeout time.Duration) (*http.Client, error) {
-	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{
-			MinVersion: tls.VersionTLS12,
-		},
-		MaxIdleConns:        50, // Reduced from 100 to prevent connection exhaustion
-		MaxIdleConnsPerHost: 5,  // Reduced from 10 to limit connections per host
-		IdleConnTimeout:     90 * time.Second,
-		// Disable HTTP/2 for RSS feeds - it can cause performance issues
-		// HTTP/1.1 is more reliable and faster for simple RSS feed fetching
-		ForceAttemptHTTP2: false,
-		// Write buffer size
-		WriteBufferSize: 32 * 1024, // 32KB
-		// Read buffer size
-		ReadBufferSize: 32 * 1024, // 32KB
-	}
-
-	// Configure proxy if provided
-	if proxyURL != "" {
-		parsedProxy, err := url.Parse(proxyURL)
-		if err != nil {
-			return nil, fmt.Errorf("invalid proxy URL: %w", err)
-		}
-		transport.Proxy = http.ProxyURL(parsedProxy)
+	transport, err := newTransport(proxyURL, DefaultTransportOptions())
+	if err != nil {
+		return nil, err
 	}
 
 	client := &http.Client{
@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// titleStopwords are common words that carry no meaning when comparing headlines
+var titleStopwords = map[string]bool{
+	"a": true, "an": true, "the": true, "and": true, "or": true, "of": true, "to": true,
+	"in": true, "on": true, "for": true, "with": true, "at": true, "by": true, "from": true,
+	"is": true, "are": true, "was": true, "as": true, "its": true, "it": true, "after": true,
+}
+
+// TitleTokens splits a title into normalized tokens for similarity comparison.
+// Latin words are lowercased with stopwords removed; runs of CJK characters, which
+// have no word boundaries, are split into overlapping character bigrams.
+func TitleTokens(title string) map[string]bool {
+	tokens := make(map[string]bool)
+	var run []rune
+	runIsCJK := false
+
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+		word := string(run)
+		switch {
+		case runIsCJK && len(run) == 1:
+			tokens[word] = true
+		case runIsCJK:
+			for i := 0; i+1 < len(run); i++ {
+				tokens[string(run[i:i+2])] = true
+			}
+		case len(run) >= 2 && !titleStopwords[word]:
+			tokens[word] = true
+		}
+		run = run[:0]
+	}
+
+	for _, r := range strings.ToLower(title) {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		cjk := isCJK(r)
+		if len(run) > 0 && cjk != runIsCJK {
+			flush()
+		}
+		runIsCJK = cjk
+		run = append(run, r)
+	}
+	flush()
+	return tokens
+}
+
+// TitleSimilarity returns the Jaccard similarity (0..1) of the normalized tokens of two titles
+func TitleSimilarity(a, b string) float64 {
+	return TokenSimilarity(TitleTokens(a), TitleTokens(b))
+}
+
+// TokenSimilarity returns the Jaccard similarity (0..1) of two token sets
+func TokenSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	common := 0
+	for t := range a {
+		if b[t] {
+			common++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+// isCJK reports whether r is a Han, Hiragana, Katakana or Hangul character
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
@@ -0,0 +1,21 @@
+package utils
+
+import "testing"
+
+func TestTitleSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		similar bool
+	}{
+		{"Apple announces new iPhone 17 at September event", "Apple Announces New iPhone 17 at Its September Event", true},
+		{"Apple announces new iPhone 17", "Rust 1.90 released with faster builds", false},
+		{"苹果发布新款 iPhone 17 手机", "苹果发布新款iPhone 17手机", true},
+		{"", "Anything", false},
+	}
+	for _, tt := range tests {
+		score := TitleSimilarity(tt.a, tt.b)
+		if (score >= 0.6) != tt.similar {
+			t.Errorf("TitleSimilarity(%q, %q) = %.2f, want similar=%v", tt.a, tt.b, score, tt.similar)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// TransportOptions tunes connection reuse for an HTTP transport
+type TransportOptions struct {
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+}
+
+// DefaultTransportOptions returns the pooling limits used when nothing is configured
+func DefaultTransportOptions() TransportOptions {
+	return TransportOptions{
+		MaxIdleConns:        50, // Reduced from 100 to prevent connection exhaustion
+		MaxIdleConnsPerHost: 5,  // Reduced from 10 to limit connections per host
+		IdleConnTimeout:     90 * time.Second,
+	}
+}
+
+// withDefaults fills unset or invalid fields from DefaultTransportOptions
+func (o TransportOptions) withDefaults() TransportOptions {
+	defaults := DefaultTransportOptions()
+	if o.MaxIdleConns <= 0 {
+		o.MaxIdleConns = defaults.MaxIdleConns
+	}
+	if o.MaxIdleConnsPerHost <= 0 {
+		o.MaxIdleConnsPerHost = defaults.MaxIdleConnsPerHost
+	}
+	if o.IdleConnTimeout <= 0 {
+		o.IdleConnTimeout = defaults.IdleConnTimeout
+	}
+	return o
+}
+
+// sharedTransports caches one transport per proxy URL so feeds behind the same
+// proxy share a connection pool instead of each opening their own
+var sharedTransports = struct {
+	sync.Mutex
+	byProxy map[string]*sharedTransport
+}{byProxy: make(map[string]*sharedTransport)}
+
+type sharedTransport struct {
+	transport *http.Transport
+	opts      TransportOptions
+}
+
+// newTransport builds a transport with the repo's TLS and buffer settings
+func newTransport(proxyURL string, opts TransportOptions) (*http.Transport, error) {
+	opts = opts.withDefaults()
+	transport := &http.Transport{
+		TLSClientConfig: &tls.Config{
+			MinVersion: tls.VersionTLS12,
+		},
+		MaxIdleConns:        opts.MaxIdleConns,
+		MaxIdleConnsPerHost: opts.MaxIdleConnsPerHost,
+		IdleConnTimeout:     opts.IdleConnTimeout,
+		// Disable HTTP/2 for RSS feeds - it can cause performance issues
+		// HTTP/1.1 is more reliable and faster for simple RSS feed fetching
+		ForceAttemptHTTP2: false,
+		// Write buffer size
+		WriteBufferSize: 32 * 1024, // 32KB
+		// Read buffer size
+		ReadBufferSize: 32 * 1024, // 32KB
+	}
+
+	// Configure proxy if provided
+	if proxyURL != "" {
+		parsedProxy, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(parsedProxy)
+	}
+
+	return transport, nil
+}
+
+// SharedTransport returns the cached transport for a proxy URL ("" for direct),
+// creating it on first use. If the pooling options changed since it was created,
+// the old transport's idle connections are closed and a new one replaces it.
+func SharedTransport(proxyURL string, opts TransportOptions) (*http.Transport, error) {
+	opts = opts.withDefaults()
+
+	sharedTransports.Lock()
+	defer sharedTransports.Unlock()
+
+	if cached, ok := sharedTransports.byProxy[proxyURL]; ok {
+		if cached.opts == opts {
+			return cached.transport, nil
+		}
+		// In-flight requests keep using the old transport; only idle connections are dropped
+		cached.transport.CloseIdleConnections()
+	}
+
+	transport, err := newTransport(proxyURL, opts)
+	if err != nil {
+		return nil, err
+	}
+	sharedTransports.byProxy[proxyURL] = &sharedTransport{transport: transport, opts: opts}
+	return transport, nil
+}
+
+// CreateSharedHTTPClientWithUserAgent creates an HTTP client with a custom User-Agent
+// on top of the shared transport for the given proxy URL
+func CreateSharedHTTPClientWithUserAgent(proxyURL string, timeout time.Duration, userAgent string, opts TransportOptions) (*http.Client, error) {
+	transport, err := SharedTransport(proxyURL, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{
+		Transport: &UserAgentTransport{
+			Original:  transport,
+			userAgent: userAgent,
+		},
+		Timeout: timeout,
+	}, nil
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSharedTransport(t *testing.T) {
+	opts := TransportOptions{MaxIdleConns: 20, MaxIdleConnsPerHost: 4, IdleConnTimeout: 30 * time.Second}
+
+	direct, err := SharedTransport("", opts)
+	if err != nil {
+		t.Fatalf("SharedTransport error: %v", err)
+	}
+	again, _ := SharedTransport("", opts)
+	if direct != again {
+		t.Error("expected the same transport for the same proxy and options")
+	}
+	if direct.MaxIdleConns != 20 || direct.MaxIdleConnsPerHost != 4 || direct.IdleConnTimeout != 30*time.Second {
+		t.Errorf("options not applied: %+v", direct)
+	}
+
+	proxied, err := SharedTransport("http://127.0.0.1:8080", opts)
+	if err != nil {
+		t.Fatalf("SharedTransport error: %v", err)
+	}
+	if proxied == direct {
+		t.Error("expected a separate transport per proxy")
+	}
+
+	retuned, _ := SharedTransport("", TransportOptions{MaxIdleConns: 10})
+	if retuned == direct {
+		t.Error("expected a new transport after the options changed")
+	}
+	if retuned.MaxIdleConnsPerHost != DefaultTransportOptions().MaxIdleConnsPerHost {
+		t.Errorf("expected unset options to use defaults, got %d", retuned.MaxIdleConnsPerHost)
+	}
+
+	if _, err := SharedTransport("://bad", opts); err == nil {
+		t.Error("expected an error for an invalid proxy URL")
+	}
+}
@@ -30,6 +30,8 @@ func NormalizeFeedURL(feedURL string) string {
 		"feed://",
 		"ftp://",
 		"file://",
+		"gemini://",
+		"gopher://",
 	}
 
 	for _, protocol := range protocols {
@@ -42,6 +44,31 @@ func NormalizeFeedURL(feedURL string) string {
 	return "https://" + feedURL
 }
 
+// CanonicalFeedURL returns a form of an http(s) feed URL suitable for spotting duplicate
+// subscriptions. It ignores the scheme, a leading "www.", default ports, host case and a
+// trailing slash, so http://www.example.com:80/feed/ and https://example.com/feed compare equal.
+// Non-http URLs (script://, email://, rsshub://) are returned trimmed but otherwise unchanged.
+func CanonicalFeedURL(rawURL string) string {
+	rawURL = strings.TrimSpace(rawURL)
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https" && parsed.Scheme != "feed") {
+		return rawURL
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	host = strings.TrimPrefix(host, "www.")
+	if port := parsed.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+
+	path := strings.TrimRight(parsed.EscapedPath(), "/")
+	result := host + path
+	if parsed.RawQuery != "" {
+		result += "?" + parsed.RawQuery
+	}
+	return result
+}
+
 // NormalizeURLForComparison returns a normalized URL for comparison purposes.
 // It strips query parameters that often change between feed fetches (like tracking params).
 // This helps match articles even when feeds use dynamic URL parameters.
@@ -61,6 +88,50 @@ func NormalizeURLForComparison(rawURL string) string {
 	return parsed.Scheme + "://" + parsed.Host + parsed.Path
 }
 
+// CleanURL removes click-tracking parameters (utm_*, fbclid, gclid, msclkid, ...) from a URL,
+// keeping all other parameters in their original order and encoding.
+// URLs that cannot be parsed are returned unchanged.
+// The list is deliberately shorter than IsTrackingParameter: cleaned URLs are opened,
+// and generic names like ref or sn are required by some sites (e.g. WeChat articles).
+func CleanURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.RawQuery == "" {
+		return rawURL
+	}
+
+	var kept []string
+	for _, pair := range strings.Split(parsed.RawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		key := pair
+		if i := strings.IndexByte(pair, '='); i >= 0 {
+			key = pair[:i]
+		}
+		if unescaped, err := url.QueryUnescape(key); err == nil {
+			key = unescaped
+		}
+		if !isClickTrackingParameter(key) {
+			kept = append(kept, pair)
+		}
+	}
+	parsed.RawQuery = strings.Join(kept, "&")
+	return parsed.String()
+}
+
+// isClickTrackingParameter checks if a parameter is safe to strip from a URL the user opens
+func isClickTrackingParameter(key string) bool {
+	keyLower := strings.ToLower(key)
+	if strings.HasPrefix(keyLower, "utm_") {
+		return true
+	}
+	switch keyLower {
+	case "fbclid", "gclid", "dclid", "msclkid", "yclid", "igshid", "mc_cid", "mc_eid":
+		return true
+	}
+	return false
+}
+
 // URLsMatch checks if two URLs refer to the same article by comparing their normalized forms.
 // It first tries exact match, then falls back to intelligent normalization that preserves
 // important query parameters while ignoring tracking parameters.
@@ -126,7 +197,7 @@ func isImportantParameter(key string, values []string) bool {
 	}
 
 	// Ignore known tracking parameters
-	if isTrackingParameter(key) {
+	if IsTrackingParameter(key) {
 		return false
 	}
 
@@ -191,11 +262,12 @@ func isNumeric(s string) bool {
 	return true
 }
 
-// isTrackingParameter checks if parameter is a known tracking parameter
-func isTrackingParameter(key string) bool {
+// IsTrackingParameter checks if parameter is a known tracking parameter
+func IsTrackingParameter(key string) bool {
 	keyLower := strings.ToLower(key)
-	trackingPrefixes := []string{"utm_", "fbclid", "gclid", "msclkid", "ttclid", "_ga", "_gid", "_gat"}
-	exactMatches := []string{"ref", "referrer", "source", "campaign", "medium", "term", "content", "fc", "sn"}
+	trackingPrefixes := []string{"utm_", "fbclid", "gclid", "dclid", "msclkid", "ttclid", "yclid", "igshid", "mc_cid", "mc_eid", "_ga", "_gid", "_gat"}
+	// Generic names like ref, source or content are left out, since sites also use them for content
+	exactMatches := []string{"referrer", "ref_src", "spm", "campaign", "medium", "fc", "sn"}
 
 	for _, prefix := range trackingPrefixes {
 		if strings.HasPrefix(keyLower, prefix) {
@@ -68,6 +68,16 @@ func TestNormalizeFeedURL(t *testing.T) {
 			input:    "ftp://example.com/file.xml",
 			expected: "ftp://example.com/file.xml",
 		},
+		{
+			name:     "gemini:// protocol",
+			input:    "gemini://example.org/atom.xml",
+			expected: "gemini://example.org/atom.xml",
+		},
+		{
+			name:     "gopher:// protocol",
+			input:    "gopher://example.org/0/feed.xml",
+			expected: "gopher://example.org/0/feed.xml",
+		},
 	}
 
 	for _, tt := range tests {
@@ -191,10 +201,28 @@ func TestURLsMatch(t *testing.T) {
 		},
 		{
 			name:     "Numeric parameter preservation",
-			url1:     "https://example.com/post/456?ref=homepage",
-			url2:     "https://example.com/post/456?ref=search",
+			url1:     "https://example.com/post/456?ref_src=twsrc",
+			url2:     "https://example.com/post/456?ref_src=tfw",
 			expected: true, // Numeric path segment treated as ID
 		},
+		{
+			name:     "Click and campaign IDs ignored",
+			url1:     "https://example.com/post?dclid=a1&yclid=b2&igshid=c3&mc_cid=d4&mc_eid=e5&spm=a2c",
+			url2:     "https://example.com/post?dclid=z9&yclid=y8&igshid=x7&mc_cid=w6&mc_eid=v5&spm=b3d",
+			expected: true,
+		},
+		{
+			name:     "Generic parameter names are content",
+			url1:     "https://example.com/browse?source=podcasts&content=episodes",
+			url2:     "https://example.com/browse?source=blogs&content=episodes",
+			expected: false,
+		},
+		{
+			name:     "Ref parameter is content",
+			url1:     "https://example.com/compare?ref=main",
+			url2:     "https://example.com/compare?ref=release",
+			expected: false,
+		},
 		{
 			name:     "Tracking parameter filtering",
 			url1:     "https://example.com/page?utm_campaign=summer&utm_medium=email",
@@ -218,3 +246,60 @@ func TestURLsMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestCanonicalFeedURL(t *testing.T) {
+	same := []string{
+		"https://example.com/feed",
+		"http://example.com/feed/",
+		"https://www.example.com/feed",
+		"http://WWW.Example.com:80/feed",
+		"https://example.com:443/feed/",
+	}
+	want := CanonicalFeedURL(same[0])
+	for _, u := range same[1:] {
+		if got := CanonicalFeedURL(u); got != want {
+			t.Errorf("CanonicalFeedURL(%q) = %q, want %q", u, got, want)
+		}
+	}
+
+	different := []string{
+		"https://example.com/feed.xml",
+		"https://example.com:8080/feed",
+		"https://example.com/feed?lang=en",
+		"https://blog.example.com/feed",
+	}
+	for _, u := range different {
+		if got := CanonicalFeedURL(u); got == want {
+			t.Errorf("CanonicalFeedURL(%q) unexpectedly equals %q", u, want)
+		}
+	}
+
+	if got := CanonicalFeedURL("script://fetch.py"); got != "script://fetch.py" {
+		t.Errorf("non-http URL changed: %q", got)
+	}
+}
+
+func TestCleanURL(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"https://example.com/post?id=42&utm_source=rss&utm_medium=feed", "https://example.com/post?id=42"},
+		{"https://example.com/post?fbclid=abc&b=2&a=1#section", "https://example.com/post?b=2&a=1#section"},
+		{"https://example.com/post?utm_campaign=x", "https://example.com/post"},
+		{"https://example.com/post?q=a%20b&gclid=x&ref=home", "https://example.com/post?q=a%20b&ref=home"},
+		// WeChat articles need every parameter, including sn, to open
+		{
+			"https://mp.weixin.qq.com/s?__biz=MzA3&mid=2650&idx=1&sn=8f1c2e&chksm=84a1&utm_source=rss",
+			"https://mp.weixin.qq.com/s?__biz=MzA3&mid=2650&idx=1&sn=8f1c2e&chksm=84a1",
+		},
+		{"https://example.com/post?igshid=abc&mc_cid=1&mc_eid=2&p=3", "https://example.com/post?p=3"},
+		{"https://example.com/post", "https://example.com/post"},
+		{"://bad", "://bad"},
+	}
+	for _, tt := range tests {
+		if got := CleanURL(tt.in); got != tt.want {
+			t.Errorf("CleanURL(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -79,7 +79,7 @@ func debugLog(format string, args ...interface{}) {
 var frontendFiles embed.FS
 
 type CombinedHandler struct {
-	apiMux     *http.ServeMux
+	apiMux     http.Handler
 	fileServer http.Handler
 }
 
@@ -177,27 +177,55 @@ func main() {
 	apiMux.HandleFunc("/api/feeds/discover/start", func(w http.ResponseWriter, r *http.Request) { discovery.HandleStartSingleDiscovery(h, w, r) })
 	apiMux.HandleFunc("/api/feeds/discover/progress", func(w http.ResponseWriter, r *http.Request) { discovery.HandleGetSingleDiscoveryProgress(h, w, r) })
 	apiMux.HandleFunc("/api/feeds/discover/clear", func(w http.ResponseWriter, r *http.Request) { discovery.HandleClearSingleDiscovery(h, w, r) })
+	apiMux.HandleFunc("/api/feeds/discover/subscribe", func(w http.ResponseWriter, r *http.Request) { discovery.HandleBulkSubscribe(h, w, r) })
 	apiMux.HandleFunc("/api/feeds/discover-all/start", func(w http.ResponseWriter, r *http.Request) { discovery.HandleStartBatchDiscovery(h, w, r) })
 	apiMux.HandleFunc("/api/feeds/discover-all/progress", func(w http.ResponseWriter, r *http.Request) { discovery.HandleGetBatchDiscoveryProgress(h, w, r) })
 	apiMux.HandleFunc("/api/feeds/discover-all/clear", func(w http.ResponseWriter, r *http.Request) { discovery.HandleClearBatchDiscovery(h, w, r) })
+	apiMux.HandleFunc("/api/feeds/discover-all/pending", func(w http.ResponseWriter, r *http.Request) { discovery.HandleGetUndiscoveredFeeds(h, w, r) })
+	apiMux.HandleFunc("/api/feeds/normalize-url", func(w http.ResponseWriter, r *http.Request) { discovery.HandleNormalizeURL(h, w, r) })
 	apiMux.HandleFunc("/api/feeds/reorder", func(w http.ResponseWriter, r *http.Request) { feedhandlers.HandleReorderFeed(h, w, r) })
 	apiMux.HandleFunc("/api/feeds/test-imap", func(w http.ResponseWriter, r *http.Request) { feedhandlers.HandleTestIMAPConnection(h, w, r) })
+	apiMux.HandleFunc("/api/feeds/diagnostics", func(w http.ResponseWriter, r *http.Request) { feedhandlers.HandleGetFeedDiagnostics(h, w, r) })
+	apiMux.HandleFunc("/api/feeds/stats", func(w http.ResponseWriter, r *http.Request) { feedhandlers.HandleGetFeedStats(h, w, r) })
+	apiMux.HandleFunc("/api/feeds/new-since-visit", func(w http.ResponseWriter, r *http.Request) { feedhandlers.HandleFeedNewSinceVisit(h, w, r) })
+	apiMux.HandleFunc("/api/feeds/redirect-duplicates", func(w http.ResponseWriter, r *http.Request) { feedhandlers.HandleDetectRedirectDuplicates(h, w, r) })
+	apiMux.HandleFunc("/api/categories/settings", func(w http.ResponseWriter, r *http.Request) { feedhandlers.HandleCategorySettings(h, w, r) })
 	apiMux.HandleFunc("/api/articles", func(w http.ResponseWriter, r *http.Request) { article.HandleArticles(h, w, r) })
 	apiMux.HandleFunc("/api/articles/images", func(w http.ResponseWriter, r *http.Request) { article.HandleImageGalleryArticles(h, w, r) })
+	apiMux.HandleFunc("/api/articles/recently-read", func(w http.ResponseWriter, r *http.Request) { article.HandleRecentlyRead(h, w, r) })
+	apiMux.HandleFunc("/api/articles/read-later", func(w http.ResponseWriter, r *http.Request) { article.HandleReadLaterList(h, w, r) })
+	apiMux.HandleFunc("/api/articles/unread-stream", func(w http.ResponseWriter, r *http.Request) { article.HandleUnreadStream(h, w, r) })
+	apiMux.HandleFunc("/api/articles/prefetch-category", func(w http.ResponseWriter, r *http.Request) { article.HandlePrefetchCategory(h, w, r) })
+	apiMux.HandleFunc("/api/articles/prefetch-status", func(w http.ResponseWriter, r *http.Request) { article.HandleGetPrefetchStatus(h, w, r) })
 	apiMux.HandleFunc("/api/articles/filter", func(w http.ResponseWriter, r *http.Request) { article.HandleFilteredArticles(h, w, r) })
 	apiMux.HandleFunc("/api/articles/read", func(w http.ResponseWriter, r *http.Request) { article.HandleMarkReadWithImmediateSync(h, w, r) })
+	apiMux.HandleFunc("/api/articles/open-original", func(w http.ResponseWriter, r *http.Request) { article.HandleOpenOriginal(h, w, r) })
+	apiMux.HandleFunc("/api/articles/read-after-view", func(w http.ResponseWriter, r *http.Request) { article.HandleMarkReadAfterView(h, w, r) })
 	apiMux.HandleFunc("/api/articles/favorite", func(w http.ResponseWriter, r *http.Request) { article.HandleToggleFavoriteWithImmediateSync(h, w, r) })
 	apiMux.HandleFunc("/api/articles/mark-relative", func(w http.ResponseWriter, r *http.Request) { article.HandleMarkRelativeToArticle(h, w, r) })
 	apiMux.HandleFunc("/api/articles/cleanup", func(w http.ResponseWriter, r *http.Request) { article.HandleCleanupArticles(h, w, r) })
 	apiMux.HandleFunc("/api/articles/cleanup-content", func(w http.ResponseWriter, r *http.Request) { article.HandleCleanupArticleContent(h, w, r) })
 	apiMux.HandleFunc("/api/articles/content-cache-info", func(w http.ResponseWriter, r *http.Request) { article.HandleGetArticleContentCacheInfo(h, w, r) })
+	apiMux.HandleFunc("/api/articles/storage-advisor", func(w http.ResponseWriter, r *http.Request) { article.HandleStorageAdvisor(h, w, r) })
 	apiMux.HandleFunc("/api/articles/translate", func(w http.ResponseWriter, r *http.Request) { translationhandlers.HandleTranslateArticle(h, w, r) })
+	apiMux.HandleFunc("/api/articles/translate-batch", func(w http.ResponseWriter, r *http.Request) { translationhandlers.HandleTranslateBatch(h, w, r) })
 	apiMux.HandleFunc("/api/articles/translate-text", func(w http.ResponseWriter, r *http.Request) { translationhandlers.HandleTranslateText(h, w, r) })
+	apiMux.HandleFunc("/api/articles/translate-text/stream", func(w http.ResponseWriter, r *http.Request) { translationhandlers.HandleTranslateTextStream(h, w, r) })
+	apiMux.HandleFunc("/api/articles/translate-snippet", func(w http.ResponseWriter, r *http.Request) { translationhandlers.HandleTranslateSnippet(h, w, r) })
+	apiMux.HandleFunc("/api/articles/redetect-languages", func(w http.ResponseWriter, r *http.Request) { translationhandlers.HandleRedetectLanguages(h, w, r) })
+	apiMux.HandleFunc("/api/articles/redetect-languages/status", func(w http.ResponseWriter, r *http.Request) {
+		translationhandlers.HandleRedetectLanguagesStatus(h, w, r)
+	})
 	apiMux.HandleFunc("/api/articles/clear-translations", func(w http.ResponseWriter, r *http.Request) { translationhandlers.HandleClearTranslations(h, w, r) })
+	apiMux.HandleFunc("/api/articles/clear-translations/scoped", func(w http.ResponseWriter, r *http.Request) {
+		translationhandlers.HandleClearTranslationsScoped(h, w, r)
+	})
 	apiMux.HandleFunc("/api/ai-usage", func(w http.ResponseWriter, r *http.Request) { translationhandlers.HandleGetAIUsage(h, w, r) })
 	apiMux.HandleFunc("/api/ai-usage/reset", func(w http.ResponseWriter, r *http.Request) { translationhandlers.HandleResetAIUsage(h, w, r) })
 	apiMux.HandleFunc("/api/translation/test-custom", func(w http.ResponseWriter, r *http.Request) { translationhandlers.HandleTestCustomTranslation(h, w, r) })
+	apiMux.HandleFunc("/api/translation/round-trip", func(w http.ResponseWriter, r *http.Request) { translationhandlers.HandleTranslateRoundTrip(h, w, r) })
 	apiMux.HandleFunc("/api/ai-chat", func(w http.ResponseWriter, r *http.Request) { chat.HandleAIChat(h, w, r) })
+	apiMux.HandleFunc("/api/ai-chat/stream", func(w http.ResponseWriter, r *http.Request) { chat.HandleAIChatStream(h, w, r) })
 	apiMux.HandleFunc("/api/ai/chat/sessions/delete-all", func(w http.ResponseWriter, r *http.Request) { chat.HandleDeleteAllSessions(h, w, r) })
 	apiMux.HandleFunc("/api/ai/chat/sessions", func(w http.ResponseWriter, r *http.Request) { chat.HandleListSessions(h, w, r) })
 	apiMux.HandleFunc("/api/ai/chat/session/create", func(w http.ResponseWriter, r *http.Request) { chat.HandleCreateSession(h, w, r) })
@@ -217,7 +245,10 @@ func main() {
 	apiMux.HandleFunc("/api/ai/chat/message/delete", func(w http.ResponseWriter, r *http.Request) { chat.HandleDeleteMessage(h, w, r) })
 	apiMux.HandleFunc("/api/ai/test", func(w http.ResponseWriter, r *http.Request) { aihandlers.HandleTestAIConfig(h, w, r) })
 	apiMux.HandleFunc("/api/ai/test/info", func(w http.ResponseWriter, r *http.Request) { aihandlers.HandleGetAITestInfo(h, w, r) })
+	apiMux.HandleFunc("/api/ai/detect-format", func(w http.ResponseWriter, r *http.Request) { aihandlers.HandleDetectAIFormat(h, w, r) })
+	apiMux.HandleFunc("/api/ai/validate-headers", func(w http.ResponseWriter, r *http.Request) { aihandlers.HandleValidateCustomHeaders(h, w, r) })
 	apiMux.HandleFunc("/api/articles/toggle-hide", func(w http.ResponseWriter, r *http.Request) { article.HandleToggleHideArticle(h, w, r) })
+	apiMux.HandleFunc("/api/articles/toggle-pin", func(w http.ResponseWriter, r *http.Request) { article.HandleTogglePin(h, w, r) })
 	apiMux.HandleFunc("/api/articles/toggle-read-later", func(w http.ResponseWriter, r *http.Request) { article.HandleToggleReadLater(h, w, r) })
 	apiMux.HandleFunc("/api/articles/content", func(w http.ResponseWriter, r *http.Request) { article.HandleGetArticleContent(h, w, r) })
 	apiMux.HandleFunc("/api/articles/fetch-full", func(w http.ResponseWriter, r *http.Request) { article.HandleFetchFullArticle(h, w, r) })
@@ -227,13 +258,25 @@ func main() {
 	apiMux.HandleFunc("/api/articles/mark-all-read", func(w http.ResponseWriter, r *http.Request) { article.HandleMarkAllAsRead(h, w, r) })
 	apiMux.HandleFunc("/api/articles/clear-read-later", func(w http.ResponseWriter, r *http.Request) { article.HandleClearReadLater(h, w, r) })
 	apiMux.HandleFunc("/api/articles/summarize", func(w http.ResponseWriter, r *http.Request) { summary.HandleSummarizeArticle(h, w, r) })
+	apiMux.HandleFunc("/api/articles/ai-summary", func(w http.ResponseWriter, r *http.Request) { summary.HandleAISummarizeArticle(h, w, r) })
+	apiMux.HandleFunc("/api/articles/summarize/preview", func(w http.ResponseWriter, r *http.Request) { summary.HandlePreviewSummaryPrompt(h, w, r) })
 	apiMux.HandleFunc("/api/articles/clear-summaries", func(w http.ResponseWriter, r *http.Request) { summary.HandleClearSummaries(h, w, r) })
+	apiMux.HandleFunc("/api/feeds/summarize", func(w http.ResponseWriter, r *http.Request) { summary.HandleSummarizeFeed(h, w, r) })
 	apiMux.HandleFunc("/api/articles/export/obsidian", func(w http.ResponseWriter, r *http.Request) { article.HandleExportToObsidian(h, w, r) })
+	apiMux.HandleFunc("/api/articles/export/markdown", func(w http.ResponseWriter, r *http.Request) { article.HandleExportArticleMarkdown(h, w, r) })
+	apiMux.HandleFunc("/api/articles/highlights", func(w http.ResponseWriter, r *http.Request) { article.HandleListHighlights(h, w, r) })
+	apiMux.HandleFunc("/api/articles/highlights/add", func(w http.ResponseWriter, r *http.Request) { article.HandleAddHighlight(h, w, r) })
+	apiMux.HandleFunc("/api/articles/highlights/delete", func(w http.ResponseWriter, r *http.Request) { article.HandleDeleteHighlight(h, w, r) })
+	apiMux.HandleFunc("/api/articles/highlights/export", func(w http.ResponseWriter, r *http.Request) { article.HandleExportHighlightsMarkdown(h, w, r) })
 	apiMux.HandleFunc("/api/settings", func(w http.ResponseWriter, r *http.Request) { settings.HandleSettings(h, w, r) })
 	apiMux.HandleFunc("/api/refresh", func(w http.ResponseWriter, r *http.Request) { article.HandleRefresh(h, w, r) })
+	apiMux.HandleFunc("/api/refresh/cancel", func(w http.ResponseWriter, r *http.Request) { article.HandleCancelRefresh(h, w, r) })
 	apiMux.HandleFunc("/api/progress", func(w http.ResponseWriter, r *http.Request) { article.HandleProgress(h, w, r) })
+	apiMux.HandleFunc("/api/progress/stream", func(w http.ResponseWriter, r *http.Request) { article.HandleProgressStream(h, w, r) })
+	apiMux.HandleFunc("/api/ws", func(w http.ResponseWriter, r *http.Request) { article.HandleWS(h, w, r) })
 	apiMux.HandleFunc("/api/progress/task-details", func(w http.ResponseWriter, r *http.Request) { article.HandleTaskDetails(h, w, r) })
 	apiMux.HandleFunc("/api/opml/import", func(w http.ResponseWriter, r *http.Request) { opml.HandleOPMLImport(h, w, r) })
+	apiMux.HandleFunc("/api/opml/import-urls", func(w http.ResponseWriter, r *http.Request) { opml.HandleImportURLList(h, w, r) })
 	apiMux.HandleFunc("/api/opml/export", func(w http.ResponseWriter, r *http.Request) { opml.HandleOPMLExport(h, w, r) })
 	apiMux.HandleFunc("/api/opml/import-dialog", func(w http.ResponseWriter, r *http.Request) { opml.HandleOPMLImportDialog(h, w, r) })
 	apiMux.HandleFunc("/api/opml/export-dialog", func(w http.ResponseWriter, r *http.Request) { opml.HandleOPMLExportDialog(h, w, r) })
@@ -297,7 +340,7 @@ func main() {
 	fileServer := http.FileServer(http.FS(frontendFS))
 
 	combinedHandler := &CombinedHandler{
-		apiMux:     apiMux,
+		apiMux:     handlers.RequestLoggingMiddleware(apiMux),
 		fileServer: fileServer,
 	}
 
@@ -352,6 +395,9 @@ func main() {
 		log.Printf("Server forced to shutdown: %v", err)
 	}
 
+	// Push read and star changes still waiting for their FreshRSS batch
+	h.FlushFreshRSSPushes(ctx)
+
 	// Close Database
 	if err := db.Close(); err != nil {
 		log.Printf("Error closing database: %v", err)
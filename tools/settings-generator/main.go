@@ -26,6 +26,7 @@ type SettingDef struct {
 	Default     interface{} `json:"default"`
 	Category    string      `json:"category"`
 	Encrypted   bool        `json:"encrypted"`
+	Validated   bool        `json:"validated,omitempty"` // Checked by validateSetting before saving
 	FrontendKey string      `json:"frontend_key"`
 }
 
@@ -303,6 +304,7 @@ func generateSettingsHandlersGo(schema *SettingsSchema) error {
 
 	// Generate POST struct fields and save logic
 	var structFields []string
+	var validateStatements []string
 	var saveStatements []string
 
 	// Find maximum field name length for alignment
@@ -321,6 +323,10 @@ func generateSettingsHandlersGo(schema *SettingsSchema) error {
 		padding := maxFieldNameLen - len(goKey)
 		structFields = append(structFields, fmt.Sprintf("\t\t%s%s string `json:\"%s\"`", goKey, strings.Repeat(" ", padding), key))
 
+		if def.Validated {
+			validateStatements = append(validateStatements, fmt.Sprintf("\t\tif err := validateSetting(\"%s\", req.%s); err != nil {\n\t\t\thttp.Error(w, err.Error(), http.StatusBadRequest)\n\t\t\treturn\n\t\t}", key, goKey))
+		}
+
 		if def.Encrypted {
 			saveStatements = append(saveStatements, fmt.Sprintf("\t\tif err := h.DB.SetEncryptedSetting(\"%s\", req.%s); err != nil {\n\t\t\tlog.Printf(\"Failed to save %s: %%v\", err)\n\t\t\thttp.Error(w, \"Failed to save %s\", http.StatusInternalServerError)\n\t\t\treturn\n\t\t}", key, goKey, key, key))
 		} else {
@@ -389,6 +395,8 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
+%s
+
 %s
 		// Re-fetch all settings after save to return updated values
 %s
@@ -405,6 +413,7 @@ func HandleSettings(h *core.Handler, w http.ResponseWriter, r *http.Request) {
 		strings.Join(getVars, "\n"),
 		strings.Join(jsonFields, "\n"),
 		strings.Join(structFields, "\n"),
+		strings.Join(validateStatements, "\n"),
 		strings.Join(saveStatements, "\n\n"),
 		strings.Join(getVars, "\n"),
 		strings.Join(jsonFields, "\n"))